@@ -0,0 +1,246 @@
+// Package audit records who changed what across the platform, and when, for compliance review.
+//
+// Services describe each change as an Event, and hand it to a Recorder, which completes it with the actor
+// and request found in the request context, and passes it to every configured Sink: the platform's own store,
+// from which events are queried through the API, a bucket, or an external HTTP collector.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// Action is the kind of change an Event records.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+	// Run is recorded when a task is run manually, or a run is retried.
+	Run Action = "run"
+)
+
+// Resource types of the events the platform records.
+const (
+	TaskResourceType          = "task"
+	AuthorizationResourceType = "authorization"
+)
+
+// ReviewPermission is the permission required to query events.
+// Events cover every organization, and name the authorizations and users that made changes,
+// so reviewing them requires the operator's permission to write organizations, which the token created at onboarding has.
+var ReviewPermission = platform.Permission{
+	Action:   platform.WriteAction,
+	Resource: platform.OrganizationResource,
+}
+
+// Actor is who made a change: the authorizer of the request that made it, and the user the authorizer belongs to.
+type Actor struct {
+	AuthorizerID   platform.ID `json:"authorizerID,omitempty"`
+	AuthorizerKind string      `json:"authorizerKind,omitempty"`
+	UserID         platform.ID `json:"userID,omitempty"`
+}
+
+// Resource is what a change was made to.
+type Resource struct {
+	Type string      `json:"type"`
+	ID   platform.ID `json:"id"`
+	// OrgID is the organization that owns the resource. It is zero for resources that belong to no organization.
+	OrgID platform.ID `json:"orgID,omitempty"`
+}
+
+// Event records a single change.
+type Event struct {
+	// ID identifies the event. It is set by the store that keeps the event.
+	ID platform.ID `json:"id,omitempty"`
+
+	Time   time.Time `json:"time"`
+	Actor  Actor     `json:"actor"`
+	Action Action    `json:"action"`
+
+	Resource Resource `json:"resource"`
+
+	// RequestID is the ID of the API request that made the change, if any.
+	RequestID string `json:"requestID,omitempty"`
+
+	// Before and After are snapshots of the resource before and after the change, as made by Snapshot.
+	// Before is empty for Create, and After is empty for Delete.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// Reason explains the change, such as why a task was disabled automatically. May be empty.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Snapshot returns the JSON encoding of v, for an event's Before or After, or nil if v does not encode.
+// Callers must leave credentials such as tokens out of v; see RedactAuthorization.
+func Snapshot(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// Filter selects the events returned by Store.FindEvents.
+type Filter struct {
+	// If set, only events about resources of this type, with this ID, or owned by this organization are returned.
+	ResourceType string
+	ResourceID   platform.ID
+	OrgID        platform.ID
+
+	// If set, only events made by this user are returned.
+	UserID platform.ID
+
+	// If not zero, only events after After and before Before are returned.
+	After, Before time.Time
+
+	// The maximum number of events to return.
+	// Zero means DefaultLimit, and values greater than MaxLimit are capped.
+	Limit int
+}
+
+// DefaultLimit is the number of events FindEvents returns when Filter.Limit is zero.
+const DefaultLimit = 100
+
+// MaxLimit is the largest number of events FindEvents returns.
+const MaxLimit = 1000
+
+// EffectiveLimit returns the maximum number of events to return for f.
+func (f Filter) EffectiveLimit() int {
+	switch {
+	case f.Limit <= 0:
+		return DefaultLimit
+	case f.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return f.Limit
+	}
+}
+
+// Match reports whether e is one of the events f selects, not accounting for the limit.
+func (f Filter) Match(e Event) bool {
+	switch {
+	case f.ResourceType != "" && e.Resource.Type != f.ResourceType:
+		return false
+	case f.ResourceID.Valid() && e.Resource.ID != f.ResourceID:
+		return false
+	case f.OrgID.Valid() && e.Resource.OrgID != f.OrgID:
+		return false
+	case f.UserID.Valid() && e.Actor.UserID != f.UserID:
+		return false
+	case !f.After.IsZero() && !e.Time.After(f.After):
+		return false
+	case !f.Before.IsZero() && !e.Time.Before(f.Before):
+		return false
+	}
+	return true
+}
+
+// Sink is a destination for events.
+type Sink interface {
+	// RecordEvent records e. It must not modify e.
+	RecordEvent(ctx context.Context, e Event) error
+}
+
+// Store is a Sink that keeps events, and finds them again for review.
+type Store interface {
+	Sink
+
+	// FindEvents returns the events that f selects, oldest first.
+	FindEvents(ctx context.Context, f Filter) ([]Event, error)
+}
+
+// Recorder completes events and passes them to its sinks.
+// A nil *Recorder records nothing, so that services can hold one whether or not auditing is configured.
+type Recorder struct {
+	sinks []namedSink
+	now   func() time.Time
+
+	logger  *zap.Logger
+	records *prometheus.CounterVec
+}
+
+type namedSink struct {
+	name string
+	Sink
+}
+
+// NewRecorder returns a Recorder with no sinks. Add them with AddSink.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		now:    time.Now,
+		logger: zap.NewNop(),
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "audit",
+			Name:      "events_total",
+			Help:      "Number of audit events recorded, split out by sink and result.",
+		}, []string{"sink", "result"}),
+	}
+}
+
+// AddSink adds s to the sinks the Recorder passes events to. The name identifies s in logs and metrics, such as "store".
+// Sinks must be added before the Recorder records events.
+func (r *Recorder) AddSink(name string, s Sink) {
+	r.sinks = append(r.sinks, namedSink{name: name, Sink: s})
+}
+
+// WithLogger sets the logger for the Recorder.
+// The logger reports each event that a sink fails to record.
+func (r *Recorder) WithLogger(l *zap.Logger) {
+	r.logger = l.With(zap.String("service", "audit"))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (r *Recorder) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{r.records}
+}
+
+// Record completes e with the authorizer and request ID found in ctx, and the current time if e has none,
+// and passes it to every sink. The change e describes has already been made, so a sink that fails
+// is logged and counted rather than failing the change.
+func (r *Recorder) Record(ctx context.Context, e Event) {
+	if r == nil || len(r.sinks) == 0 {
+		return
+	}
+
+	if a, err := pctx.GetAuthorizer(ctx); err == nil {
+		e.Actor = Actor{
+			AuthorizerID:   a.Identifier(),
+			AuthorizerKind: a.Kind(),
+			UserID:         a.GetUserID(),
+		}
+	}
+	if e.RequestID == "" {
+		e.RequestID = pctx.GetRequestID(ctx)
+	}
+	if e.Time.IsZero() {
+		e.Time = r.now()
+	}
+	e.Time = e.Time.UTC()
+
+	for _, s := range r.sinks {
+		if err := s.RecordEvent(ctx, e); err != nil {
+			r.records.WithLabelValues(s.name, "error").Inc()
+			r.logger.Info("Failed to record audit event",
+				zap.String("sink", s.name),
+				zap.String("resource_type", e.Resource.Type),
+				zap.String("resource_id", e.Resource.ID.String()),
+				zap.String("action", string(e.Action)),
+				zap.Error(err),
+			)
+			continue
+		}
+		r.records.WithLabelValues(s.name, "success").Inc()
+	}
+}
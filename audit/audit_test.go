@@ -0,0 +1,119 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"github.com/influxdata/platform/mock"
+)
+
+type sinkFunc func(ctx context.Context, e audit.Event) error
+
+func (f sinkFunc) RecordEvent(ctx context.Context, e audit.Event) error { return f(ctx, e) }
+
+func TestRecorder_Record(t *testing.T) {
+	var got []audit.Event
+	r := audit.NewRecorder()
+	r.AddSink("good", sinkFunc(func(ctx context.Context, e audit.Event) error {
+		got = append(got, e)
+		return nil
+	}))
+	r.AddSink("bad", sinkFunc(func(ctx context.Context, e audit.Event) error {
+		return errors.New("unavailable")
+	}))
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(r)
+
+	auth := &platform.Authorization{ID: 5, UserID: 6, Status: platform.Active}
+	ctx := pctx.SetRequestID(pctx.SetAuthorizer(context.Background(), auth), "request-1")
+	r.Record(ctx, audit.Event{Action: audit.Create, Resource: audit.Resource{Type: audit.TaskResourceType, ID: 1}})
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(got))
+	}
+	e := got[0]
+	if e.Actor != (audit.Actor{AuthorizerID: 5, AuthorizerKind: "authorization", UserID: 6}) {
+		t.Fatalf("expected the actor from the context, got %+v", e.Actor)
+	}
+	if e.RequestID != "request-1" || e.Time.IsZero() {
+		t.Fatalf("expected the request ID and time to be set, got %+v", e)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	if got := promtest.MustFindMetric(t, mfs, "audit_events_total", map[string]string{"sink": "bad", "result": "error"}).GetCounter().GetValue(); got != 1 {
+		t.Fatalf("expected 1 failed event, got %v", got)
+	}
+
+	// A nil Recorder records nothing, rather than panicking.
+	var nilRecorder *audit.Recorder
+	nilRecorder.Record(ctx, e)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var got audit.Event
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	e := audit.Event{Action: audit.Delete, Resource: audit.Resource{Type: audit.AuthorizationResourceType, ID: 2}}
+	if err := audit.NewHTTPSink(srv.URL, "secret").RecordEvent(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+	if got.Action != audit.Delete || got.Resource.ID != 2 || token != "Token secret" {
+		t.Fatalf("unexpected event %+v with authorization %q", got, token)
+	}
+}
+
+func TestAuthorizationService(t *testing.T) {
+	var got []audit.Event
+	r := audit.NewRecorder()
+	r.AddSink("test", sinkFunc(func(ctx context.Context, e audit.Event) error {
+		got = append(got, e)
+		return nil
+	}))
+
+	existing := &platform.Authorization{ID: 3, Token: "tok", Status: platform.Active}
+	s := audit.NewAuthorizationService(&mock.AuthorizationService{
+		FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+			return existing, nil
+		},
+		SetAuthorizationStatusFn: func(ctx context.Context, id platform.ID, status platform.Status) error {
+			return nil
+		},
+	}, r)
+
+	if err := s.SetAuthorizationStatus(context.Background(), 3, platform.Inactive); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Action != audit.Update {
+		t.Fatalf("expected an update event, got %+v", got)
+	}
+
+	var before, after platform.Authorization
+	if err := json.Unmarshal(got[0].Before, &before); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(got[0].After, &after); err != nil {
+		t.Fatal(err)
+	}
+	if before.Status != platform.Active || after.Status != platform.Inactive {
+		t.Fatalf("expected the status change in the snapshots, got %s to %s", before.Status, after.Status)
+	}
+	if before.Token != "" || after.Token != "" {
+		t.Fatal("expected the token to be redacted from the snapshots")
+	}
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// AuthorizationService records the changes made through an authorization service:
+// authorizations created, activated, deactivated, rotated and deleted.
+type AuthorizationService struct {
+	platform.AuthorizationService
+	Recorder *Recorder
+}
+
+var _ platform.AuthorizationService = (*AuthorizationService)(nil)
+
+// NewAuthorizationService returns an AuthorizationService that records the changes made through s with r.
+func NewAuthorizationService(s platform.AuthorizationService, r *Recorder) *AuthorizationService {
+	return &AuthorizationService{AuthorizationService: s, Recorder: r}
+}
+
+// CreateAuthorization creates a and records its creation.
+func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *platform.Authorization) error {
+	if err := s.AuthorizationService.CreateAuthorization(ctx, a); err != nil {
+		return err
+	}
+	s.Recorder.Record(ctx, Event{
+		Action:   Create,
+		Resource: Resource{Type: AuthorizationResourceType, ID: a.ID},
+		After:    Snapshot(RedactAuthorization(a)),
+	})
+	return nil
+}
+
+// SetAuthorizationStatus sets the status of the authorization with id, and records the change.
+func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
+	before, _ := s.AuthorizationService.FindAuthorizationByID(ctx, id)
+	if err := s.AuthorizationService.SetAuthorizationStatus(ctx, id, status); err != nil {
+		return err
+	}
+
+	e := Event{
+		Action:   Update,
+		Resource: Resource{Type: AuthorizationResourceType, ID: id},
+	}
+	if before != nil {
+		e.Before = Snapshot(RedactAuthorization(before))
+		after := *before
+		after.Status = status
+		e.After = Snapshot(RedactAuthorization(&after))
+	}
+	s.Recorder.Record(ctx, e)
+	return nil
+}
+
+// RotateAuthorization gives the authorization with id a new token, and records the change.
+// The tokens are redacted from the recorded snapshots, which show the new expiry of the previous token.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	before, _ := s.AuthorizationService.FindAuthorizationByID(ctx, id)
+	a, err := s.AuthorizationService.RotateAuthorization(ctx, id, grace)
+	if err != nil {
+		return nil, err
+	}
+
+	e := Event{
+		Action:   Update,
+		Resource: Resource{Type: AuthorizationResourceType, ID: id},
+		After:    Snapshot(RedactAuthorization(a)),
+	}
+	if before != nil {
+		e.Before = Snapshot(RedactAuthorization(before))
+	}
+	s.Recorder.Record(ctx, e)
+	return a, nil
+}
+
+// DeleteAuthorization deletes the authorization with id, and records its deletion.
+func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platform.ID) error {
+	before, _ := s.AuthorizationService.FindAuthorizationByID(ctx, id)
+	if err := s.AuthorizationService.DeleteAuthorization(ctx, id); err != nil {
+		return err
+	}
+
+	e := Event{
+		Action:   Delete,
+		Resource: Resource{Type: AuthorizationResourceType, ID: id},
+	}
+	if before != nil {
+		e.Before = Snapshot(RedactAuthorization(before))
+	}
+	s.Recorder.Record(ctx, e)
+	return nil
+}
+
+// RedactAuthorization returns a copy of a without its tokens, to snapshot in events.
+func RedactAuthorization(a *platform.Authorization) *platform.Authorization {
+	r := *a
+	r.Token = ""
+	r.PreviousToken = ""
+	return &r
+}
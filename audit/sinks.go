@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// HTTPSink posts each event as JSON to an external collector, such as a SIEM.
+type HTTPSink struct {
+	URL string
+	// Token, if set, is sent in the Authorization header, as "Token <token>".
+	Token  string
+	Client *http.Client
+}
+
+// NewHTTPSink returns an HTTPSink that posts to url, with a client with a 10 second timeout.
+func NewHTTPSink(url, token string) *HTTPSink {
+	return &HTTPSink{
+		URL:    url,
+		Token:  token,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// RecordEvent posts e to the collector. Any status but 2xx is an error.
+func (s *HTTPSink) RecordEvent(ctx context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", s.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.Token)
+	}
+
+	resp, err := s.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("audit collector responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// PointsWriter writes points to the storage engine. It is satisfied by storage.PointsWriter.
+type PointsWriter interface {
+	WritePoints([]models.Point) error
+}
+
+// BucketMeasurement is the measurement BucketSink writes events to.
+const BucketMeasurement = "audit"
+
+// BucketSink writes each event as a point to a bucket, so that events can be queried and alerted on with Flux.
+// The point is tagged with the event's action, resource type and organization, and holds the rest of the event
+// in its fields, with the before and after snapshots as JSON strings.
+type BucketSink struct {
+	OrgID, BucketID platform.ID
+	Writer          PointsWriter
+}
+
+// NewBucketSink returns a BucketSink that writes to the bucket with bucketID, of the organization with orgID.
+func NewBucketSink(orgID, bucketID platform.ID, w PointsWriter) *BucketSink {
+	return &BucketSink{OrgID: orgID, BucketID: bucketID, Writer: w}
+}
+
+// RecordEvent writes e to the bucket.
+func (s *BucketSink) RecordEvent(ctx context.Context, e Event) error {
+	tags := models.NewTags(map[string]string{
+		"action":        string(e.Action),
+		"resource_type": e.Resource.Type,
+	})
+	if e.Resource.OrgID.Valid() {
+		tags.Set([]byte("org_id"), []byte(e.Resource.OrgID.String()))
+	}
+
+	fields := models.Fields{
+		"resource_id":     e.Resource.ID.String(),
+		"authorizer_kind": e.Actor.AuthorizerKind,
+	}
+	if e.Actor.AuthorizerID.Valid() {
+		fields["authorizer_id"] = e.Actor.AuthorizerID.String()
+	}
+	if e.Actor.UserID.Valid() {
+		fields["user_id"] = e.Actor.UserID.String()
+	}
+	if e.RequestID != "" {
+		fields["request_id"] = e.RequestID
+	}
+	if len(e.Before) > 0 {
+		fields["before"] = string(e.Before)
+	}
+	if len(e.After) > 0 {
+		fields["after"] = string(e.After)
+	}
+	if e.Reason != "" {
+		fields["reason"] = e.Reason
+	}
+
+	pt, err := models.NewPoint(BucketMeasurement, tags, fields, e.Time)
+	if err != nil {
+		return err
+	}
+	pts, err := tsdb.ExplodePoints(s.OrgID, s.BucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+	return s.Writer.WritePoints(pts)
+}
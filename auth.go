@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"time"
 )
 
 // Authorization is a authorization. 🎉
@@ -13,6 +14,14 @@ type Authorization struct {
 	User        string       `json:"user,omitempty"`
 	UserID      ID           `json:"userID,omitempty"`
 	Permissions []Permission `json:"permissions,omitempty"`
+
+	// ExpiresAt is when the token stops being accepted. Nil if it does not expire.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// PreviousToken is the token the authorization had before it was last rotated,
+	// which is still accepted until PreviousTokenExpiresAt so that its clients can switch to the new token.
+	PreviousToken          string     `json:"previousToken,omitempty"`
+	PreviousTokenExpiresAt *time.Time `json:"previousTokenExpiresAt,omitempty"`
 }
 
 // Allowed returns true if the authorization is active and request permission
@@ -30,9 +39,23 @@ func IsActive(a *Authorization) bool {
 	return a.IsActive()
 }
 
-// IsActive returns true if the authorization active.
+// IsActive returns true if the authorization is active and has not expired.
 func (a *Authorization) IsActive() bool {
-	return a.Status == Active
+	return a.Status == Active && !a.Expired(time.Now())
+}
+
+// Expired returns true if the authorization has an expiry at or before now.
+func (a *Authorization) Expired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// AcceptsToken returns true if t is the authorization's token,
+// or its previous token and the grace window after it was rotated has not passed by now.
+func (a *Authorization) AcceptsToken(t string, now time.Time) bool {
+	if t == a.Token {
+		return true
+	}
+	return t != "" && t == a.PreviousToken && a.PreviousTokenExpiresAt != nil && now.Before(*a.PreviousTokenExpiresAt)
 }
 
 // GetUserID returns the user id.
@@ -54,6 +77,7 @@ const (
 	OpCreateAuthorization      = "CreateAuthorization"
 	OpSetAuthorizationStatus   = "SetAuthorizationStatus"
 	OpDeleteAuthorization      = "DeleteAuthorization"
+	OpRotateAuthorization      = "RotateAuthorization"
 )
 
 // AuthorizationService represents a service for managing authorization data.
@@ -77,6 +101,10 @@ type AuthorizationService interface {
 
 	// Removes a authorization by token.
 	DeleteAuthorization(ctx context.Context, id ID) error
+
+	// RotateAuthorization gives the authorization a new token, and returns the updated authorization.
+	// Its replaced token is still accepted for grace, as its PreviousToken, in place of any earlier previous token.
+	RotateAuthorization(ctx context.Context, id ID, grace time.Duration) (*Authorization, error)
 }
 
 // AuthorizationFilter represents a set of filter that restrict the returned results.
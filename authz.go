@@ -34,19 +34,25 @@ func allowed(p Permission, ps []Permission) bool {
 	return false
 }
 
-type action string
+// Action is an action that a permission allows on a resource.
+type Action string
 
 const (
 	// ReadAction is the action for reading.
-	ReadAction action = "read"
+	ReadAction Action = "read"
 	// WriteAction is the action for writing.
-	WriteAction action = "write"
+	WriteAction Action = "write"
 	// CreateAction is the action for creating new resources.
-	CreateAction action = "create"
+	CreateAction Action = "create"
 	// DeleteAction is the action for deleting an existing resource.
-	DeleteAction action = "delete"
+	DeleteAction Action = "delete"
+	// RunAction is the action for running a task, by forcing, retrying or canceling its runs.
+	RunAction Action = "run"
 )
 
+// TaskActions are the actions that apply to tasks. WriteAction applies to updating a task.
+var TaskActions = []Action{ReadAction, WriteAction, CreateAction, DeleteAction, RunAction}
+
 type resource string
 
 const (
@@ -61,6 +67,17 @@ func TaskResource(orgID ID) resource {
 	return resource(fmt.Sprintf("org/%s/task", orgID))
 }
 
+// TaskIDResource represents a single task, whichever organization it belongs to.
+// It is the resource of the permissions a task's owners and members have through their user resource mappings.
+func TaskIDResource(taskID ID) resource {
+	return resource(fmt.Sprintf("%s/%s", TaskResourceType, taskID))
+}
+
+// SecretResource represents the secrets of an organization.
+func SecretResource(orgID ID) resource {
+	return resource(fmt.Sprintf("org/%s/secret", orgID))
+}
+
 // BucketResource constructs a bucket resource.
 func BucketResource(id ID) resource {
 	return resource(fmt.Sprintf("bucket/%s", id))
@@ -68,7 +85,7 @@ func BucketResource(id ID) resource {
 
 // Permission defines an action and a resource.
 type Permission struct {
-	Action   action   `json:"action"`
+	Action   Action   `json:"action"`
 	Resource resource `json:"resource"`
 }
 
@@ -104,3 +121,51 @@ func WriteBucketPermission(id ID) Permission {
 		Resource: BucketResource(id),
 	}
 }
+
+// ReadSecretPermission constructs a permission for listing the keys of an organization's secrets.
+// Secret values are never read through the API; only task runs load them.
+func ReadSecretPermission(orgID ID) Permission {
+	return Permission{
+		Action:   ReadAction,
+		Resource: SecretResource(orgID),
+	}
+}
+
+// WriteSecretPermission constructs a permission for setting and deleting an organization's secrets.
+func WriteSecretPermission(orgID ID) Permission {
+	return Permission{
+		Action:   WriteAction,
+		Resource: SecretResource(orgID),
+	}
+}
+
+// TaskPermission constructs a permission for taking action on the tasks of an organization.
+func TaskPermission(a Action, orgID ID) Permission {
+	return Permission{
+		Action:   a,
+		Resource: TaskResource(orgID),
+	}
+}
+
+// TaskIDPermission constructs a permission for taking action on a single task.
+func TaskIDPermission(a Action, taskID ID) Permission {
+	return Permission{
+		Action:   a,
+		Resource: TaskIDResource(taskID),
+	}
+}
+
+// TaskPermissions returns the permissions for taking every task action on the tasks of an organization.
+func TaskPermissions(orgID ID) []Permission {
+	ps := make([]Permission, 0, len(TaskActions))
+	for _, a := range TaskActions {
+		ps = append(ps, TaskPermission(a, orgID))
+	}
+	return ps
+}
+
+// AllowedTask reports whether a allows action on the task with taskID in the organization with orgID,
+// by a permission for the organization's tasks or for the task alone.
+func AllowedTask(a Authorizer, act Action, orgID, taskID ID) bool {
+	return a.Allowed(TaskPermission(act, orgID)) || a.Allowed(TaskIDPermission(act, taskID))
+}
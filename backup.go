@@ -0,0 +1,21 @@
+package platform
+
+import (
+	"context"
+	"io"
+)
+
+// BackupService streams consistent snapshots of a store while the store is in use.
+type BackupService interface {
+	// Backup writes a consistent snapshot of the store to w, and returns the number of bytes written.
+	// Writes to the store may continue while the snapshot is written, and are not part of it.
+	Backup(ctx context.Context, w io.Writer) (int64, error)
+}
+
+// BackupPermission is the permission required to back up the platform's stores.
+// A backup holds every resource, including tokens, so it requires the operator's permission to write organizations,
+// which the token created at onboarding has.
+var BackupPermission = Permission{
+	Action:   WriteAction,
+	Resource: OrganizationResource,
+}
@@ -0,0 +1,82 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform/audit"
+)
+
+var (
+	auditBucket = []byte("auditv1")
+)
+
+var _ audit.Store = (*Client)(nil)
+
+func (c *Client) initializeAudit(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(auditBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RecordEvent appends e to the audit log, with a new ID.
+func (c *Client) RecordEvent(ctx context.Context, e audit.Event) error {
+	e.ID = c.IDGenerator.ID()
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(auditBucket).Put(encodeAuditKey(e), v)
+	})
+}
+
+// FindEvents returns the events in the audit log that f selects, oldest first.
+func (c *Client) FindEvents(ctx context.Context, f audit.Filter) ([]audit.Event, error) {
+	limit := f.EffectiveLimit()
+	var es []audit.Event
+	err := c.db.View(func(tx *bolt.Tx) error {
+		cur := tx.Bucket(auditBucket).Cursor()
+
+		// Keys begin with the event's time, so the scan can start at f.After.
+		var k, v []byte
+		if f.After.IsZero() {
+			k, v = cur.First()
+		} else {
+			seek := make([]byte, 8)
+			binary.BigEndian.PutUint64(seek, uint64(f.After.UnixNano()))
+			k, v = cur.Seek(seek)
+		}
+
+		for ; k != nil && len(es) < limit; k, v = cur.Next() {
+			var e audit.Event
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			if !f.Before.IsZero() && !e.Time.Before(f.Before) {
+				break
+			}
+			if f.Match(e) {
+				es = append(es, e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// encodeAuditKey returns the key of e in the audit bucket: its time, so that events are kept in time order,
+// followed by its ID, so that events at the same time are kept apart.
+func encodeAuditKey(e audit.Event) []byte {
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k[:8], uint64(e.Time.UnixNano()))
+	binary.BigEndian.PutUint64(k[8:], uint64(e.ID))
+	return k
+}
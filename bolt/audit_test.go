@@ -0,0 +1,68 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+)
+
+func TestClient_AuditEvents(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	start := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+	org1, org2 := platform.ID(1), platform.ID(2)
+	events := []audit.Event{
+		{Time: start, Action: audit.Create, Resource: audit.Resource{Type: audit.TaskResourceType, ID: 10, OrgID: org1}},
+		{Time: start.Add(time.Minute), Action: audit.Update, Resource: audit.Resource{Type: audit.TaskResourceType, ID: 10, OrgID: org1}},
+		{Time: start.Add(2 * time.Minute), Action: audit.Create, Resource: audit.Resource{Type: audit.TaskResourceType, ID: 20, OrgID: org2}},
+		{Time: start.Add(3 * time.Minute), Action: audit.Delete, Resource: audit.Resource{Type: audit.AuthorizationResourceType, ID: 30}},
+	}
+	// Record out of order, to check that events are found in time order.
+	for _, i := range []int{3, 1, 0, 2} {
+		if err := c.RecordEvent(ctx, events[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	find := func(f audit.Filter) []audit.Action {
+		t.Helper()
+		es, err := c.FindEvents(ctx, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actions []audit.Action
+		for _, e := range es {
+			if !e.ID.Valid() {
+				t.Fatalf("expected event to have an ID: %+v", e)
+			}
+			actions = append(actions, e.Action)
+		}
+		return actions
+	}
+	expect := func(name string, got []audit.Action, want ...audit.Action) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", name, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: expected %v, got %v", name, want, got)
+			}
+		}
+	}
+
+	expect("all", find(audit.Filter{}), audit.Create, audit.Update, audit.Create, audit.Delete)
+	expect("org", find(audit.Filter{OrgID: org1}), audit.Create, audit.Update)
+	expect("resource", find(audit.Filter{ResourceType: audit.AuthorizationResourceType}), audit.Delete)
+	expect("after", find(audit.Filter{After: start}), audit.Update, audit.Create, audit.Delete)
+	expect("before", find(audit.Filter{Before: start.Add(2 * time.Minute)}), audit.Create, audit.Update)
+	expect("limit", find(audit.Filter{Limit: 1}), audit.Create)
+}
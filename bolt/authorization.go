@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
@@ -12,6 +14,9 @@ import (
 var (
 	authorizationBucket = []byte("authorizationsv1")
 	authorizationIndex  = []byte("authorizationindexv1")
+
+	authorizationMigrations  = []byte("authorizationmigrationsv1")
+	taskPermissionsMigration = []byte("taskpermissions")
 )
 
 var _ platform.AuthorizationService = (*Client)(nil)
@@ -23,7 +28,74 @@ func (c *Client) initializeAuthorizations(ctx context.Context, tx *bolt.Tx) erro
 	if _, err := tx.CreateBucketIfNotExists([]byte(authorizationIndex)); err != nil {
 		return err
 	}
-	return nil
+	return c.migrateTaskPermissions(ctx, tx)
+}
+
+// migrateTaskPermissions gives the authorizations that may create an organization's tasks
+// every other action on them too, as tasks were only checked on creation before the other task actions existed.
+// It runs once, so that authorizations created later keep only the task actions they are given.
+func (c *Client) migrateTaskPermissions(ctx context.Context, tx *bolt.Tx) error {
+	b, err := tx.CreateBucketIfNotExists(authorizationMigrations)
+	if err != nil {
+		return err
+	}
+	if b.Get(taskPermissionsMigration) != nil {
+		return nil
+	}
+
+	updated := make(map[string][]byte)
+	cur := tx.Bucket(authorizationBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		a := &platform.Authorization{}
+		if err := decodeAuthorization(v, a); err != nil {
+			return err
+		}
+
+		var migrated bool
+		for _, p := range a.Permissions {
+			if p.Action != platform.CreateAction {
+				continue
+			}
+			var orgID platform.ID
+			if err := orgID.DecodeFromString(strings.TrimSuffix(strings.TrimPrefix(string(p.Resource), "org/"), "/task")); err != nil {
+				continue
+			}
+			if p.Resource != platform.TaskResource(orgID) {
+				continue
+			}
+			for _, tp := range platform.TaskPermissions(orgID) {
+				if !hasPermission(a.Permissions, tp) {
+					a.Permissions = append(a.Permissions, tp)
+					migrated = true
+				}
+			}
+		}
+		if !migrated {
+			continue
+		}
+
+		v, err := encodeAuthorization(a)
+		if err != nil {
+			return err
+		}
+		updated[string(k)] = v
+	}
+
+	for k, v := range updated {
+		if err := tx.Bucket(authorizationBucket).Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return b.Put(taskPermissionsMigration, []byte("done"))
+}
+
+func hasPermission(ps []platform.Permission, p platform.Permission) bool {
+	for _, perm := range ps {
+		if perm == p {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Client) setUserOnAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.Authorization) *platform.Error {
@@ -120,7 +192,18 @@ func (c *Client) findAuthorizationByToken(ctx context.Context, tx *bolt.Tx, n st
 			Err:  err,
 		}
 	}
-	return c.findAuthorizationByID(ctx, tx, id)
+	auth, pe := c.findAuthorizationByID(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+	// The index keeps the previous token of a rotated authorization, even once it is no longer accepted.
+	if !auth.AcceptsToken(n, c.time()) {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "authorization not found",
+		}
+	}
+	return auth, nil
 }
 
 func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
@@ -242,6 +325,7 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 			}
 		}
 		a.Token = token
+		a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
 
 		a.ID = c.IDGenerator.ID()
 
@@ -300,6 +384,14 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 			Err:  err,
 		}
 	}
+	if a.PreviousToken != "" {
+		if err := tx.Bucket(authorizationIndex).Put(authorizationIndexKey(a.PreviousToken), encodedID); err != nil {
+			return &platform.Error{
+				Code: platform.EInternal,
+				Err:  err,
+			}
+		}
+	}
 	if err := tx.Bucket(authorizationBucket).Put(encodedID, v); err != nil {
 		return &platform.Error{
 			Err: err,
@@ -370,6 +462,13 @@ func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 			Err: err,
 		}
 	}
+	if a.PreviousToken != "" {
+		if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.PreviousToken)); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
+	}
 	encodedID, err := id.Encode()
 	if err != nil {
 		return &platform.Error{
@@ -422,3 +521,65 @@ func (c *Client) updateAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 	}
 	return nil
 }
+
+// RotateAuthorization gives the authorization a new token, keeping its replaced token for grace.
+func (c *Client) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	var a *platform.Authorization
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var pe *platform.Error
+		a, pe = c.rotateAuthorization(ctx, tx, id, grace)
+		if pe != nil {
+			pe.Op = getOp(platform.OpRotateAuthorization)
+			return pe
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (c *Client) rotateAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID, grace time.Duration) (*platform.Authorization, *platform.Error) {
+	a, pe := c.findAuthorizationByID(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+
+	token, err := c.TokenGenerator.Token()
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	if v := tx.Bucket(authorizationIndex).Get(authorizationIndexKey(token)); len(v) != 0 {
+		return nil, &platform.Error{
+			Code: platform.EConflict,
+			Msg:  "token already exists",
+		}
+	}
+
+	// Only the token being replaced remains accepted, and only if there is a grace window.
+	if a.PreviousToken != "" {
+		if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.PreviousToken)); err != nil {
+			return nil, &platform.Error{
+				Err: err,
+			}
+		}
+	}
+	a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	if grace > 0 {
+		expiresAt := c.time().Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	} else if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.Token)); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	a.Token = token
+
+	if pe := c.putAuthorization(ctx, tx, a); pe != nil {
+		return nil, pe
+	}
+	return a, nil
+}
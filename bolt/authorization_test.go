@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	bbolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -45,3 +46,77 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 func TestAuthorizationService(t *testing.T) {
 	platformtesting.AuthorizationService(initAuthorizationService, t)
 }
+
+func TestClient_MigrateTaskPermissions(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	u := &platform.User{Name: "cooluser", ID: platformtesting.MustIDBase16("020f755c3c082001")}
+	if err := c.PutUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+
+	// An authorization from before the other task actions existed, which could only create tasks.
+	old := &platform.Authorization{
+		ID:          platformtesting.MustIDBase16("020f755c3c082002"),
+		UserID:      u.ID,
+		Token:       "old",
+		Permissions: []platform.Permission{platform.TaskPermission(platform.CreateAction, orgID)},
+	}
+	if err := c.PutAuthorization(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopen the store as one the migration has not run on yet.
+	if err := c.DB().Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("authorizationmigrationsv1")).Delete([]byte("taskpermissions"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := c.FindAuthorizationByID(ctx, old.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range platform.TaskPermissions(orgID) {
+		if !a.Allowed(p) {
+			t.Errorf("expected migrated authorization to allow %s", p)
+		}
+	}
+
+	// Authorizations created after the migration keep only the task actions they are given.
+	scoped := &platform.Authorization{
+		ID:          platformtesting.MustIDBase16("020f755c3c082003"),
+		UserID:      u.ID,
+		Token:       "scoped",
+		Permissions: []platform.Permission{platform.TaskPermission(platform.CreateAction, orgID)},
+	}
+	if err := c.PutAuthorization(ctx, scoped); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err = c.FindAuthorizationByID(ctx, scoped.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Allowed(platform.TaskPermission(platform.DeleteAction, orgID)) {
+		t.Error("expected authorization created after the migration not to gain task actions")
+	}
+}
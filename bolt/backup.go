@@ -0,0 +1,98 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BackupService = (*Client)(nil)
+
+// Backup writes a consistent snapshot of the database to w, and returns the number of bytes written.
+// The snapshot is taken in a read transaction, so the services using the database keep running while it is written.
+func (c *Client) Backup(ctx context.Context, w io.Writer) (int64, error) {
+	var n int64
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
+// ValidateBackup checks that the file at path is a sound bolt database holding the platform's resources,
+// such as a file written by Backup, so that it can be restored.
+func ValidateBackup(path string) error {
+	db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return fmt.Errorf("unable to open backup %s: %v", path, err)
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		var errs []string
+		for err := range tx.Check() {
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("backup %s is corrupt: %s", path, strings.Join(errs, "; "))
+		}
+		if tx.Bucket(organizationBucket) == nil || tx.Bucket(authorizationBucket) == nil {
+			return fmt.Errorf("backup %s does not hold platform resources", path)
+		}
+		return nil
+	})
+}
+
+// Restore replaces the database at path with the backup at backupPath, after validating the backup.
+// It must be called before the database is opened. The database it replaces, if any,
+// is kept beside it with the suffix ".pre-restore", so that a restore can be undone.
+func Restore(backupPath, path string) error {
+	if err := ValidateBackup(backupPath); err != nil {
+		return err
+	}
+
+	src, err := os.Open(backupPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Copy to a temporary file first, so that a failed copy does not leave a partial database at path.
+	tmp := path + ".restoring"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("unable to copy backup %s: %v", backupPath, err)
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+".pre-restore"); err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("unable to keep database %s before restoring: %v", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,85 @@
+package bolt_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+)
+
+func TestClient_BackupRestore(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	org := &platform.Organization{Name: "o1"}
+	if err := c.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := c.Backup(ctx, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bolt.ValidateBackup(f.Name()); err != nil {
+		t.Fatalf("expected backup to be valid: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "influxdata-platform-bolt-restore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := dir + "/influxd.bolt"
+	if err := ioutil.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bolt.Restore(f.Name(), path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".pre-restore"); err != nil {
+		t.Fatalf("expected the replaced database to be kept: %v", err)
+	}
+
+	restored := bolt.NewClient()
+	restored.Path = path
+	if err := restored.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+	if _, err := restored.FindOrganizationByID(ctx, org.ID); err != nil {
+		t.Fatalf("expected organization in restored database: %v", err)
+	}
+}
+
+func TestValidateBackup_Invalid(t *testing.T) {
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("not a database")
+	f.Close()
+
+	if err := bolt.ValidateBackup(f.Name()); err == nil {
+		t.Fatal("expected an invalid backup to fail validation")
+	}
+	if err := bolt.Restore(f.Name(), f.Name()+".restored"); err == nil {
+		t.Fatal("expected restoring an invalid backup to fail")
+	}
+}
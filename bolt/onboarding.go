@@ -132,6 +132,9 @@ func (c *Client) Generate(ctx context.Context, req *platform.OnboardingRequest)
 			platform.WriteBucketPermission(bucket.ID),
 		},
 	}
+	// The onboarding token manages the organization's tasks and secrets, as it does the organization.
+	auth.Permissions = append(auth.Permissions, platform.TaskPermissions(o.ID)...)
+	auth.Permissions = append(auth.Permissions, platform.ReadSecretPermission(o.ID), platform.WriteSecretPermission(o.ID))
 	if err = c.CreateAuthorization(ctx, auth); err != nil {
 		return nil, err
 	}
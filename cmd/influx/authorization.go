@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
@@ -31,6 +33,9 @@ type AuthorizationCreateFlags struct {
 
 	readBucketPermissions  []string
 	writeBucketPermissions []string
+
+	orgTaskPermissions []string
+	taskPermissions    []string
 }
 
 var authorizationCreateFlags AuthorizationCreateFlags
@@ -51,6 +56,9 @@ func init() {
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "bucket id")
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "bucket id")
 
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.orgTaskPermissions, "org-task", "", []string{}, "action:org id, granting the action (read, write, create, delete or run) on the organization's tasks")
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.taskPermissions, "task", "", []string{}, "action:task id, granting the action (read, write, delete or run) on the task")
+
 	authorizationCmd.AddCommand(authorizationCreateCmd)
 }
 
@@ -79,6 +87,22 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 		}
 		permissions = append(permissions, platform.ReadBucketPermission(id))
 	}
+	for _, p := range authorizationCreateFlags.orgTaskPermissions {
+		perm, err := parseTaskPermission(p, true)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		permissions = append(permissions, perm)
+	}
+	for _, p := range authorizationCreateFlags.taskPermissions {
+		perm, err := parseTaskPermission(p, false)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		permissions = append(permissions, perm)
+	}
 
 	authorization := &platform.Authorization{
 		User:        authorizationCreateFlags.user,
@@ -122,6 +146,30 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 	w.Flush()
 }
 
+// parseTaskPermission parses a task permission flag, of the form action:id,
+// where id is an organization's ID if orgTasks is set, or else a task's ID.
+func parseTaskPermission(s string, orgTasks bool) (platform.Permission, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return platform.Permission{}, fmt.Errorf("task permission %q must be of the form action:id", s)
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(parts[1]); err != nil {
+		return platform.Permission{}, err
+	}
+	for _, a := range platform.TaskActions {
+		if string(a) != parts[0] {
+			continue
+		}
+		if orgTasks {
+			return platform.TaskPermission(a, id), nil
+		}
+		return platform.TaskIDPermission(a, id), nil
+	}
+	return platform.Permission{}, fmt.Errorf("unknown task action %q", parts[0])
+}
+
 // AuthorizationFindFlags are command line args used when finding a authorization
 type AuthorizationFindFlags struct {
 	user   string
@@ -439,3 +487,70 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) {
 	})
 	w.Flush()
 }
+
+// AuthorizationRotateFlags are command line args used when rotating an authorization's token
+type AuthorizationRotateFlags struct {
+	id    string
+	grace time.Duration
+}
+
+var authorizationRotateFlags AuthorizationRotateFlags
+
+func init() {
+	authorizationRotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the token of an authorization",
+		Run:   authorizationRotateF,
+	}
+
+	authorizationRotateCmd.Flags().StringVarP(&authorizationRotateFlags.id, "id", "i", "", "authorization id (required)")
+	authorizationRotateCmd.MarkFlagRequired("id")
+	authorizationRotateCmd.Flags().DurationVarP(&authorizationRotateFlags.grace, "grace", "", http.DefaultRotationGrace, "how long the replaced token is still accepted")
+
+	authorizationCmd.AddCommand(authorizationRotateCmd)
+}
+
+func authorizationRotateF(cmd *cobra.Command, args []string) {
+	s, err := newAuthorizationService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(authorizationRotateFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	a, err := s.RotateAuthorization(context.Background(), id, authorizationRotateFlags.grace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Token",
+		"PreviousToken",
+		"PreviousTokenExpiresAt",
+		"User",
+		"UserID",
+	)
+
+	expiresAt := ""
+	if a.PreviousTokenExpiresAt != nil {
+		expiresAt = a.PreviousTokenExpiresAt.Format(time.RFC3339)
+	}
+
+	w.Write(map[string]interface{}{
+		"ID":                     a.ID.String(),
+		"Token":                  a.Token,
+		"PreviousToken":          a.PreviousToken,
+		"PreviousTokenExpiresAt": expiresAt,
+		"User":                   a.User,
+		"UserID":                 a.UserID.String(),
+	})
+	w.Flush()
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/cmd/influx/internal"
 	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/task/backend"
 	"github.com/spf13/cobra"
 )
 
@@ -40,19 +44,141 @@ func logF(cmd *cobra.Command, args []string) {
 
 var runCmd = &cobra.Command{
 	Use:   "run",
-	Short: "run related commands",
+	Short: "Run a task now, or run related commands",
 	Run:   runF,
 }
 
+// TaskRunFlags define the Run command, which runs a task now
+type TaskRunFlags struct {
+	id           string
+	scheduledFor string
+}
+
+var taskRunFlags TaskRunFlags
+
 func runF(cmd *cobra.Command, args []string) {
-	cmd.Usage()
+	if taskRunFlags.id == "" {
+		cmd.Usage()
+		return
+	}
+
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(taskRunFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	scheduledFor := time.Now()
+	if taskRunFlags.scheduledFor != "" {
+		t, err := time.Parse(time.RFC3339, taskRunFlags.scheduledFor)
+		if err != nil {
+			fmt.Printf("scheduled-for must be an RFC3339 time: %v\n", err)
+			os.Exit(1)
+		}
+		scheduledFor = t
+	}
+
+	run, err := s.ForceRun(context.Background(), id, scheduledFor.Unix())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeRuns([]*platform.Run{run})
+}
+
+// TaskFlags define the flags shared by all task commands
+type TaskFlags struct {
+	json bool
 }
 
+var taskFlags TaskFlags
+
 func init() {
+	taskCmd.PersistentFlags().BoolVarP(&taskFlags.json, "json", "", false, "print results as JSON rather than as a table")
+
+	runCmd.Flags().StringVarP(&taskRunFlags.id, "id", "i", "", "ID of the task to run now")
+	runCmd.Flags().StringVarP(&taskRunFlags.scheduledFor, "scheduled-for", "", "", "RFC3339 time to run the task for; the run is for the task's first schedule at or after it, and defaults to now")
+
 	taskCmd.AddCommand(runCmd)
 	taskCmd.AddCommand(logCmd)
 }
 
+// writeJSON prints v as indented JSON, for the --json flag.
+func writeJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// writeTasks prints tasks as a table, or as JSON with the --json flag.
+func writeTasks(tasks []*platform.Task) {
+	if taskFlags.json {
+		writeJSON(tasks)
+		return
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"Organization",
+		"Status",
+		"Every",
+		"Cron",
+	)
+	for _, t := range tasks {
+		w.Write(map[string]interface{}{
+			"ID":           t.ID.String(),
+			"Name":         t.Name,
+			"Organization": t.Organization.String(),
+			"Status":       t.Status,
+			"Every":        t.Every,
+			"Cron":         t.Cron,
+		})
+	}
+	w.Flush()
+}
+
+// writeRuns prints runs as a table, or as JSON with the --json flag.
+func writeRuns(runs []*platform.Run) {
+	if taskFlags.json {
+		writeJSON(runs)
+		return
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Status",
+		"ScheduledFor",
+		"StartedAt",
+		"FinishedAt",
+		"RequestedAt",
+	)
+	for _, r := range runs {
+		w.Write(map[string]interface{}{
+			"ID":           r.ID,
+			"TaskID":       r.TaskID,
+			"Status":       r.Status,
+			"ScheduledFor": r.ScheduledFor,
+			"StartedAt":    r.StartedAt,
+			"FinishedAt":   r.FinishedAt,
+			"RequestedAt":  r.RequestedAt,
+		})
+	}
+	w.Flush()
+}
+
 // TaskCreateFlags define the Create Command
 type TaskCreateFlags struct {
 	org   string
@@ -147,24 +273,7 @@ func taskCreateF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	w := internal.NewTabWriter(os.Stdout)
-	w.WriteHeaders(
-		"ID",
-		"Name",
-		"Organization",
-		"Status",
-		"Every",
-		"Cron",
-	)
-	w.Write(map[string]interface{}{
-		"ID":           t.ID.String(),
-		"Name":         t.Name,
-		"Organization": t.Organization.String(),
-		"Status":       t.Status,
-		"Every":        t.Every,
-		"Cron":         t.Cron,
-	})
-	w.Flush()
+	writeTasks([]*platform.Task{t})
 }
 
 // taskFindFlags define the Find Command
@@ -248,26 +357,7 @@ func taskFindF(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	w := internal.NewTabWriter(os.Stdout)
-	w.WriteHeaders(
-		"ID",
-		"Name",
-		"Organization",
-		"Status",
-		"Every",
-		"Cron",
-	)
-	for _, t := range tasks {
-		w.Write(map[string]interface{}{
-			"ID":           t.ID.String(),
-			"Name":         t.Name,
-			"Organization": t.Organization.String(),
-			"Status":       t.Status,
-			"Every":        t.Every,
-			"Cron":         t.Cron,
-		})
-	}
-	w.Flush()
+	writeTasks(tasks)
 }
 
 // taskUpdateFlags define the Update Command
@@ -324,24 +414,7 @@ func taskUpdateF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	w := internal.NewTabWriter(os.Stdout)
-	w.WriteHeaders(
-		"ID",
-		"Name",
-		"Organization",
-		"Status",
-		"Every",
-		"Cron",
-	)
-	w.Write(map[string]interface{}{
-		"ID":           t.ID.String(),
-		"Name":         t.Name,
-		"Organization": t.Organization.String(),
-		"Status":       t.Status,
-		"Every":        t.Every,
-		"Cron":         t.Cron,
-	})
-	w.Flush()
+	writeTasks([]*platform.Task{t})
 }
 
 // taskDeleteFlags define the Delete command
@@ -389,24 +462,7 @@ func taskDeleteF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	w := internal.NewTabWriter(os.Stdout)
-	w.WriteHeaders(
-		"ID",
-		"Name",
-		"Organization",
-		"Status",
-		"Every",
-		"Cron",
-	)
-	w.Write(map[string]interface{}{
-		"ID":           t.ID.String(),
-		"Name":         t.Name,
-		"Organization": t.Organization.String(),
-		"Status":       t.Status,
-		"Every":        t.Every,
-		"Cron":         t.Cron,
-	})
-	w.Flush()
+	writeTasks([]*platform.Task{t})
 }
 
 // taskLogFindFlags define the Delete command
@@ -491,7 +547,9 @@ type TaskRunFindFlags struct {
 	orgID      string
 	afterTime  string
 	beforeTime string
+	status     string
 	limit      int
+	offset     int
 }
 
 var taskRunFindFlags TaskRunFindFlags
@@ -508,7 +566,9 @@ func init() {
 	taskRunFindCmd.Flags().StringVarP(&taskRunFindFlags.orgID, "org-id", "", "", "organization id")
 	taskRunFindCmd.Flags().StringVarP(&taskRunFindFlags.afterTime, "after", "", "", "after time for filtering")
 	taskRunFindCmd.Flags().StringVarP(&taskRunFindFlags.beforeTime, "before", "", "", "before time for filtering")
+	taskRunFindCmd.Flags().StringVarP(&taskRunFindFlags.status, "status", "", "", "status for filtering, such as failed")
 	taskRunFindCmd.Flags().IntVarP(&taskRunFindFlags.limit, "limit", "", 0, "limit the results")
+	taskRunFindCmd.Flags().IntVarP(&taskRunFindFlags.offset, "offset", "", 0, "skip this many results")
 
 	taskRunFindCmd.MarkFlagRequired("task-id")
 	taskRunFindCmd.MarkFlagRequired("org-id")
@@ -526,6 +586,8 @@ func taskRunFindF(cmd *cobra.Command, args []string) {
 		Limit:      taskRunFindFlags.limit,
 		AfterTime:  taskRunFindFlags.afterTime,
 		BeforeTime: taskRunFindFlags.beforeTime,
+		Status:     taskRunFindFlags.status,
+		Offset:     taskRunFindFlags.offset,
 	}
 	taskID, err := platform.IDFromString(taskRunFindFlags.taskID)
 	if err != nil {
@@ -562,28 +624,7 @@ func taskRunFindF(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	w := internal.NewTabWriter(os.Stdout)
-	w.WriteHeaders(
-		"ID",
-		"TaskID",
-		"Status",
-		"ScheduledFor",
-		"StartedAt",
-		"FinishedAt",
-		"RequestedAt",
-	)
-	for _, r := range runs {
-		w.Write(map[string]interface{}{
-			"ID":           r.ID,
-			"TaskID":       r.TaskID,
-			"Status":       r.Status,
-			"ScheduledFor": r.ScheduledFor,
-			"StartedAt":    r.StartedAt,
-			"FinishedAt":   r.FinishedAt,
-			"RequestedAt":  r.RequestedAt,
-		})
-	}
-	w.Flush()
+	writeRuns(runs)
 }
 
 type RunRetryFlags struct {
@@ -630,5 +671,229 @@ func runRetryF(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if taskFlags.json {
+		writeJSON(newRun)
+		return
+	}
 	fmt.Printf("Retry for task %s's run %s queued as run %s.\n", taskID, runID, newRun.ID)
 }
+
+// TaskListFlags define the List command
+type TaskListFlags struct {
+	orgID string
+	user  string
+	limit int
+}
+
+var taskListFlags TaskListFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the tasks of an organization",
+		Run:   taskListF,
+	}
+
+	cmd.Flags().StringVarP(&taskListFlags.orgID, "org-id", "", "", "organization ID (required)")
+	cmd.Flags().StringVarP(&taskListFlags.user, "user-id", "n", "", "only list tasks owned by this user ID")
+	cmd.Flags().IntVarP(&taskListFlags.limit, "limit", "", 0, "the number of tasks to list; all of them if 0")
+	cmd.MarkFlagRequired("org-id")
+
+	taskCmd.AddCommand(cmd)
+}
+
+func taskListF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	orgID, err := platform.IDFromString(taskListFlags.orgID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	filter := platform.TaskFilter{Organization: orgID, Limit: platform.TaskMaxPageSize}
+	if taskListFlags.user != "" {
+		filter.User, err = platform.IDFromString(taskListFlags.user)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	if taskListFlags.limit < 0 {
+		fmt.Println("limit must not be negative")
+		os.Exit(1)
+	}
+
+	// Page through the tasks, so that organizations with more than a page of tasks are listed in full.
+	var tasks []*platform.Task
+	for {
+		page, _, err := s.FindTasks(context.Background(), filter)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		tasks = append(tasks, page...)
+		if len(page) < filter.Limit || (taskListFlags.limit > 0 && len(tasks) >= taskListFlags.limit) {
+			break
+		}
+		filter.After = &page[len(page)-1].ID
+	}
+	if taskListFlags.limit > 0 && len(tasks) > taskListFlags.limit {
+		tasks = tasks[:taskListFlags.limit]
+	}
+
+	writeTasks(tasks)
+}
+
+// TaskLogsFlags define the Logs command
+type TaskLogsFlags struct {
+	taskID string
+	runID  string
+}
+
+var taskLogsFlags TaskLogsFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show the log events of a task, or of one of its runs",
+		Run:   taskLogsF,
+	}
+
+	cmd.Flags().StringVarP(&taskLogsFlags.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskLogsFlags.runID, "run-id", "", "", "run id")
+	cmd.MarkFlagRequired("task-id")
+
+	taskCmd.AddCommand(cmd)
+}
+
+func taskLogsF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var filter platform.LogFilter
+	id, err := platform.IDFromString(taskLogsFlags.taskID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	filter.Task = id
+
+	if taskLogsFlags.runID != "" {
+		id, err := platform.IDFromString(taskLogsFlags.runID)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		filter.Run = id
+	}
+
+	logs, _, err := s.FindLogs(context.Background(), filter)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var events []backend.RunLogEvent
+	for _, l := range logs {
+		events = append(events, backend.ParseRunLog(*l)...)
+	}
+
+	if taskFlags.json {
+		type eventJSON struct {
+			Time time.Time `json:"time"`
+			backend.RunLogEvent
+		}
+		out := make([]eventJSON, len(events))
+		for i, e := range events {
+			out[i] = eventJSON{Time: e.Time, RunLogEvent: e}
+		}
+		writeJSON(out)
+		return
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Time",
+		"Type",
+		"Status",
+		"Code",
+		"Message",
+	)
+	for _, e := range events {
+		w.Write(map[string]interface{}{
+			"Time":    e.Time.Format(time.RFC3339),
+			"Type":    string(e.Type),
+			"Status":  e.Status,
+			"Code":    e.Code,
+			"Message": e.Message,
+		})
+	}
+	w.Flush()
+}
+
+// TaskExportFlags define the Export command
+type TaskExportFlags struct {
+	orgID string
+	file  string
+}
+
+var taskExportFlags TaskExportFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the tasks of an organization as a bundle, which can be imported into another organization",
+		Run:   taskExportF,
+	}
+
+	cmd.Flags().StringVarP(&taskExportFlags.orgID, "org-id", "", "", "organization ID (required)")
+	cmd.Flags().StringVarP(&taskExportFlags.file, "file", "f", "", "path of the file to write the bundle to; standard output if empty")
+	cmd.MarkFlagRequired("org-id")
+
+	taskCmd.AddCommand(cmd)
+}
+
+func taskExportF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	orgID, err := platform.IDFromString(taskExportFlags.orgID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	bundle, err := s.ExportTasks(context.Background(), *orgID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// The bundle is always written as JSON, the format the import endpoint reads.
+	var out io.Writer = os.Stdout
+	if taskExportFlags.file != "" {
+		f, err := os.Create(taskExportFlags.file)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bundle); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if taskExportFlags.file != "" {
+		fmt.Printf("Exported %d tasks to %s.\n", len(bundle.Tasks), taskExportFlags.file)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/storage"
+)
+
+// newAuditRecorder returns the recorder of the server's audit events.
+// Events are always kept in the bolt store, where the audit API finds them,
+// and are also written to the audit bucket and posted to the audit collector, if they are configured.
+func (m *Main) newAuditRecorder(reg *prom.Registry, pw storage.PointsWriter) (*audit.Recorder, error) {
+	r := audit.NewRecorder()
+	r.WithLogger(m.levels.Module(m.logger, "audit"))
+	reg.MustRegisterCollectors(r)
+
+	r.AddSink("store", m.boltClient)
+	if m.auditBucketID != "" {
+		var orgID, bucketID platform.ID
+		if err := orgID.DecodeFromString(m.auditOrgID); err != nil {
+			return nil, err
+		}
+		if err := bucketID.DecodeFromString(m.auditBucketID); err != nil {
+			return nil, err
+		}
+		r.AddSink("bucket", audit.NewBucketSink(orgID, bucketID, pw))
+	}
+	if m.auditURL != "" {
+		r.AddSink("http", audit.NewHTTPSink(m.auditURL, m.auditToken))
+	}
+	return r, nil
+}
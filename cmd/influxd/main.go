@@ -15,20 +15,28 @@ import (
 	"github.com/influxdata/flux/control"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
 	"github.com/influxdata/platform/bolt"
 	"github.com/influxdata/platform/chronograf/server"
 	"github.com/influxdata/platform/gather"
 	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/idgen"
 	"github.com/influxdata/platform/internal/fs"
+	"github.com/influxdata/platform/kit/cache"
+	"github.com/influxdata/platform/kit/circuitbreaker"
 	"github.com/influxdata/platform/kit/cli"
+	"github.com/influxdata/platform/kit/debug"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/kit/pool"
 	"github.com/influxdata/platform/kit/prom"
 	"github.com/influxdata/platform/kit/signals"
+	"github.com/influxdata/platform/kit/tracing"
+	"github.com/influxdata/platform/kit/transport"
 	influxlogger "github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/nats"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	pcontrol "github.com/influxdata/platform/query/control"
-	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/source"
 	"github.com/influxdata/platform/storage"
 	"github.com/influxdata/platform/storage/readservice"
@@ -37,34 +45,33 @@ import (
 	taskbolt "github.com/influxdata/platform/task/backend/bolt"
 	"github.com/influxdata/platform/task/backend/coordinator"
 	taskexecutor "github.com/influxdata/platform/task/backend/executor"
+	tasknotify "github.com/influxdata/platform/task/backend/notify"
+	"github.com/influxdata/platform/telemetry"
 	_ "github.com/influxdata/platform/tsdb/tsi1"
 	_ "github.com/influxdata/platform/tsdb/tsm1"
-	pzap "github.com/influxdata/platform/zap"
 	"github.com/opentracing/opentracing-go"
-	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// commit is the git commit influxd was built from, set at build time.
+var commit string
+
 func main() {
-	// exit with SIGINT and SIGTERM
 	ctx := context.Background()
-	ctx = signals.WithStandardSignals(ctx)
 
 	m := NewMain()
 	if err := m.Run(ctx, os.Args[1:]...); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
+	} else if m.ranCommand {
+		return
 	} else if !m.running {
 		os.Exit(1)
 	}
 
-	<-ctx.Done()
-
-	// Attempt clean shutdown.
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
-	defer cancel()
-	m.Shutdown(ctx)
+	// Shut down cleanly on SIGINT and SIGTERM.
+	m.shutdown.WaitForStandardSignals(ctx)
 }
 
 // Main represents the main program execution.
@@ -73,12 +80,45 @@ type Main struct {
 	cancel  func()
 	running bool
 
+	// ranCommand is set when a subcommand ran instead of the server.
+	ranCommand bool
+
 	logLevel        string
 	httpBindAddress string
 	boltPath        string
+	boltRestorePath string
 	natsPath        string
+	eventBusNATS    bool
 	developerMode   bool
 	enginePath      string
+	taskJitter      time.Duration
+	taskMaxFailures int
+	taskOrgRunRate  int
+	taskMinInterval time.Duration
+	taskPurgeAfter  time.Duration
+	taskClaimers    int
+	taskRunWorkers  int
+	taskKeyPath     string
+	taskMaxTasks    int
+	taskMaxRunTime  time.Duration
+	tracingType     string
+	shutdownDrain   time.Duration
+	shutdownTimeout time.Duration
+	debugToken      string
+	debugRateLimit  int
+	taskAPIRate     int
+
+	telemetry           bool
+	telemetryURL        string
+	telemetryExportPath string
+	telemetryInterval   time.Duration
+
+	idGenerator string
+
+	auditOrgID    string
+	auditBucketID string
+	auditURL      string
+	auditToken    string
 
 	boltClient *bolt.Client
 	engine     *storage.Engine
@@ -90,9 +130,13 @@ type Main struct {
 
 	natsServer *nats.Server
 
-	scheduler *taskbackend.TickScheduler
+	scheduler       *taskbackend.TickScheduler
+	taskCoordinator *coordinator.Coordinator
+
+	shutdown *signals.Shutdown
 
 	logger *zap.Logger
+	levels *influxlogger.Levels
 
 	Stdin  io.Reader
 	Stdout io.Writer
@@ -115,34 +159,40 @@ func (m *Main) URL() string {
 
 // Shutdown shuts down the HTTP server and waits for all services to clean up.
 func (m *Main) Shutdown(ctx context.Context) {
-	m.httpServer.Shutdown(ctx)
-
-	m.logger.Info("Stopping", zap.String("service", "task"))
-	m.scheduler.Stop()
-
-	m.logger.Info("Stopping", zap.String("service", "nats"))
-	m.natsServer.Close()
-
-	m.logger.Info("Stopping", zap.String("service", "bolt"))
-	if err := m.boltClient.Close(); err != nil {
-		m.logger.Info("failed closing bolt", zap.Error(err))
-	}
-
-	m.logger.Info("Stopping", zap.String("service", "query"))
-	if err := m.queryController.Shutdown(ctx); err != nil {
-		m.logger.Info("Failed closing query service", zap.Error(err))
-	}
-
-	m.logger.Info("Stopping", zap.String("service", "storage-engine"))
-	if err := m.engine.Close(); err != nil {
-		m.logger.Error("failed to close engine", zap.Error(err))
+	if err := m.shutdown.Shutdown(ctx); err != nil {
+		m.logger.Info("Failed to shut down cleanly", zap.Error(err))
 	}
-
-	m.wg.Wait()
-
 	m.logger.Sync()
 }
 
+// registerShutdown registers the services to stop on shutdown, in the order they must stop.
+// The HTTP server stops first so that no new requests reach the services behind it.
+func (m *Main) registerShutdown() {
+	m.shutdown.Register("context", func(context.Context) error {
+		m.cancel()
+		return nil
+	})
+	m.shutdown.Register("http", func(ctx context.Context) error {
+		return m.httpServer.Shutdown(ctx)
+	})
+	m.shutdown.Register("task", m.taskCoordinator.Shutdown)
+	m.shutdown.Register("nats", func(context.Context) error {
+		m.natsServer.Close()
+		return nil
+	})
+	m.shutdown.Register("bolt", func(context.Context) error {
+		return m.boltClient.Close()
+	})
+	m.shutdown.Register("query", m.queryController.Shutdown)
+	m.shutdown.Register("storage-engine", func(context.Context) error {
+		return m.engine.Close()
+	})
+	m.shutdown.Register("wait", func(context.Context) error {
+		m.wg.Wait()
+		return nil
+	})
+}
+
 // Cancel executes the context cancel on the program. Used for testing.
 func (m *Main) Cancel() { m.cancel() }
 
@@ -154,8 +204,10 @@ func (m *Main) Run(ctx context.Context, args ...string) error {
 	}
 
 	prog := &cli.Program{
-		Name: "influxd",
-		Run:  func() error { return m.run(ctx) },
+		Name:       "influxd",
+		Run:        func() error { return m.run(ctx) },
+		Validate:   m.validate,
+		ConfigFlag: "config-path",
 		Opts: []cli.Opt{
 			{
 				DestP:   &m.logLevel,
@@ -175,6 +227,12 @@ func (m *Main) Run(ctx context.Context, args ...string) error {
 				Default: filepath.Join(dir, "influxd.bolt"),
 				Desc:    "path to boltdb database",
 			},
+			{
+				DestP:   &m.boltRestorePath,
+				Flag:    "bolt-restore-path",
+				Default: "",
+				Desc:    "path to a backup from /api/v2/backup to restore over the boltdb database at startup; the replaced database is kept with the suffix .pre-restore",
+			},
 			{
 				DestP:   &m.developerMode,
 				Flag:    "developer-mode",
@@ -187,20 +245,215 @@ func (m *Main) Run(ctx context.Context, args ...string) error {
 				Default: filepath.Join(dir, "nats"),
 				Desc:    "path to NATS queue for scraping tasks",
 			},
+			{
+				DestP:   &m.eventBusNATS,
+				Flag:    "event-bus-nats",
+				Default: false,
+				Desc:    "publish internal events, such as task changes and finished runs, through the NATS streaming server so that other processes can subscribe to them; events stay in the process if false",
+			},
 			{
 				DestP:   &m.enginePath,
 				Flag:    "engine-path",
 				Default: filepath.Join(dir, "engine"),
 				Desc:    "path to persistent engine files",
 			},
+			{
+				DestP:   &m.taskJitter,
+				Flag:    "task-jitter",
+				Default: time.Duration(0),
+				Desc:    "window over which to spread the start of task runs that are due at the same time",
+			},
+			{
+				DestP:   &m.taskMaxFailures,
+				Flag:    "task-max-failures",
+				Default: 0,
+				Desc:    "number of consecutive failed runs after which a task is disabled; 0 never disables tasks",
+			},
+			{
+				DestP:   &m.taskOrgRunRate,
+				Flag:    "task-org-run-rate",
+				Default: 0,
+				Desc:    "maximum number of task runs each organization may start per second; 0 does not limit runs",
+			},
+			{
+				DestP:   &m.taskMinInterval,
+				Flag:    "task-min-interval",
+				Default: time.Duration(0),
+				Desc:    "shortest time allowed between runs of a task; 0 allows tasks to run as often as every second",
+			},
+			{
+				DestP:   &m.taskPurgeAfter,
+				Flag:    "task-purge-deleted-after",
+				Default: time.Duration(0),
+				Desc:    "how long deleted tasks are kept, and can be restored, before they are purged; 0 purges tasks as soon as they are deleted",
+			},
+			{
+				DestP:   &m.taskClaimers,
+				Flag:    "task-claim-workers",
+				Default: 8,
+				Desc:    "number of existing tasks claimed concurrently at startup; the server is not ready until every task is claimed",
+			},
+			{
+				DestP:   &m.taskRunWorkers,
+				Flag:    "task-run-workers",
+				Default: 100,
+				Desc:    "number of task runs executed at once across all tasks; further due runs wait for a free worker",
+			},
+			{
+				DestP:   &m.taskMaxTasks,
+				Flag:    "task-quota-max-tasks",
+				Default: 0,
+				Desc:    "default maximum number of tasks per organization, for organizations without a quota set through the quotas API; 0 does not limit tasks",
+			},
+			{
+				DestP:   &m.taskMaxRunTime,
+				Flag:    "task-quota-max-run-duration",
+				Default: time.Duration(0),
+				Desc:    "default longest a task run may execute before it is canceled, for organizations without a quota set through the quotas API; 0 does not limit runs",
+			},
+			{
+				DestP:   &m.taskKeyPath,
+				Flag:    "task-encryption-key-path",
+				Default: "",
+				Desc:    "path to a file holding a hex-encoded 16, 24, or 32 byte AES key, used to encrypt task scripts at rest; tasks are not encrypted if empty",
+			},
+			{
+				DestP:   &m.tracingType,
+				Flag:    "tracing-type",
+				Default: tracing.TypeLog,
+				Desc:    "supported tracer types are log, which logs each span, and none",
+			},
+			{
+				DestP:   &m.shutdownDrain,
+				Flag:    "shutdown-drain-period",
+				Default: time.Duration(0),
+				Desc:    "how long the server reports not ready after receiving SIGINT or SIGTERM, so that load balancers stop sending it requests, before it stops its services",
+			},
+			{
+				DestP:   &m.shutdownTimeout,
+				Flag:    "shutdown-timeout",
+				Default: 2 * time.Second,
+				Desc:    "how long services have to stop after the drain period, before the server exits regardless",
+			},
+			{
+				DestP:   &m.debugToken,
+				Flag:    "debug-token",
+				Default: "",
+				Desc:    "token required, as 'Authorization: Token <token>', by the /debug endpoints; they are unprotected if empty",
+			},
+			{
+				DestP:   &m.debugRateLimit,
+				Flag:    "debug-rate-limit",
+				Default: 60,
+				Desc:    "requests per minute allowed to the /debug endpoints; unlimited if 0",
+			},
+			{
+				DestP:   &m.taskAPIRate,
+				Flag:    "task-api-rate-limit",
+				Default: 0,
+				Desc:    "requests per second allowed to the task API for each token, or each IP address for requests without one; unlimited if 0",
+			},
+			{
+				DestP:   &m.telemetry,
+				Flag:    "telemetry",
+				Default: false,
+				Desc:    "report anonymous usage counters to telemetry-url or telemetry-export-path; the " + telemetry.DisableEnv + " environment variable overrides it",
+			},
+			{
+				DestP:   &m.telemetryURL,
+				Flag:    "telemetry-url",
+				Default: "",
+				Desc:    "endpoint to post usage reports to, when telemetry is on",
+			},
+			{
+				DestP:   &m.telemetryExportPath,
+				Flag:    "telemetry-export-path",
+				Default: "",
+				Desc:    "file to append usage reports to, one JSON report per line, when telemetry is on",
+			},
+			{
+				DestP:   &m.telemetryInterval,
+				Flag:    "telemetry-interval",
+				Default: telemetry.DefaultInterval,
+				Desc:    "how often to report usage, when telemetry is on",
+			},
+			{
+				DestP:   &m.idGenerator,
+				Flag:    "id-generator",
+				Default: idgen.Snowflake,
+				Desc:    fmt.Sprintf("generator of resource IDs, one of %v; ulid needs no machine ID and tolerates clock skew, and sequence gives reproducible IDs for tests, starting over on each restart, so only with a new bolt-path", idgen.Kinds),
+			},
+			{
+				DestP:   &m.auditOrgID,
+				Flag:    "audit-org-id",
+				Default: "",
+				Desc:    "organization of audit-bucket-id",
+			},
+			{
+				DestP:   &m.auditBucketID,
+				Flag:    "audit-bucket-id",
+				Default: "",
+				Desc:    "bucket to write audit events to, as well as the bolt store",
+			},
+			{
+				DestP:   &m.auditURL,
+				Flag:    "audit-url",
+				Default: "",
+				Desc:    "endpoint of an external collector to post audit events to, as well as the bolt store",
+			},
+			{
+				DestP:   &m.auditToken,
+				Flag:    "audit-token",
+				Default: "",
+				Desc:    "token to send to audit-url, in the Authorization header",
+			},
 		},
 	}
 
 	cmd := cli.NewCommand(prog)
+	cmd.AddCommand(m.newMigrateTasksCommand(ctx, filepath.Join(dir, "influxd.bolt")))
 	cmd.SetArgs(args)
 	return cmd.Execute()
 }
 
+// validate checks the options for consistency before the server starts.
+func (m *Main) validate() error {
+	if m.taskClaimers < 1 || m.taskRunWorkers < 1 {
+		return fmt.Errorf("task-claim-workers and task-run-workers must be at least 1")
+	}
+	if m.taskMaxFailures < 0 || m.taskOrgRunRate < 0 {
+		return fmt.Errorf("task-max-failures and task-org-run-rate must not be negative")
+	}
+	if m.taskMaxTasks < 0 || m.taskMaxRunTime < 0 {
+		return fmt.Errorf("task-quota-max-tasks and task-quota-max-run-duration must not be negative")
+	}
+	if m.shutdownDrain < 0 || m.shutdownTimeout < 0 {
+		return fmt.Errorf("shutdown-drain-period and shutdown-timeout must not be negative")
+	}
+	if m.debugRateLimit < 0 || m.taskAPIRate < 0 {
+		return fmt.Errorf("debug-rate-limit and task-api-rate-limit must not be negative")
+	}
+	if m.telemetry && m.telemetryURL == "" && m.telemetryExportPath == "" {
+		return fmt.Errorf("telemetry requires telemetry-url or telemetry-export-path")
+	}
+	if m.telemetryInterval <= 0 {
+		return fmt.Errorf("telemetry-interval must be positive")
+	}
+	if _, err := idgen.New(m.idGenerator); err != nil {
+		return err
+	}
+	if (m.auditOrgID == "") != (m.auditBucketID == "") {
+		return fmt.Errorf("audit-org-id and audit-bucket-id must be set together")
+	}
+	if m.auditToken != "" && m.auditURL == "" {
+		return fmt.Errorf("audit-token requires audit-url")
+	}
+	if m.boltRestorePath != "" && m.boltRestorePath == m.boltPath {
+		return fmt.Errorf("bolt-restore-path must not be the same as bolt-path")
+	}
+	return nil
+}
+
 func (m *Main) run(ctx context.Context) (err error) {
 	m.running = true
 	ctx, m.cancel = context.WithCancel(ctx)
@@ -211,28 +464,49 @@ func (m *Main) run(ctx context.Context) (err error) {
 	}
 
 	// Create top level logger
+	m.levels = influxlogger.NewLevels(lvl)
 	logconf := &influxlogger.Config{
-		Format: "auto",
-		Level:  lvl,
+		Format:  "auto",
+		Level:   lvl,
+		Version: commit,
+		Levels:  m.levels,
 	}
 	m.logger, err = logconf.New(m.Stdout)
 	if err != nil {
 		return err
 	}
 
+	m.shutdown = signals.NewShutdown(m.shutdownDrain, m.shutdownTimeout)
+	m.shutdown.WithLogger(m.logger)
+
 	// set tracing
-	tracer := new(pzap.Tracer)
-	tracer.Logger = m.logger
-	tracer.IDGenerator = snowflake.NewIDGenerator()
+	tracer, err := tracing.NewTracer(m.tracingType, m.logger)
+	if err != nil {
+		return err
+	}
 	opentracing.SetGlobalTracer(tracer)
 
 	reg := prom.NewRegistry()
-	reg.MustRegister(prometheus.NewGoCollector())
+	reg.MustRegisterStandardCollectors()
 	reg.WithLogger(m.logger)
 
+	if m.boltRestorePath != "" {
+		if err := bolt.Restore(m.boltRestorePath, m.boltPath); err != nil {
+			m.logger.Error("failed restoring bolt", zap.String("backup", m.boltRestorePath), zap.Error(err))
+			return err
+		}
+		m.logger.Info("Restored bolt from backup", zap.String("backup", m.boltRestorePath), zap.String("path", m.boltPath))
+	}
+
+	idGen, err := idgen.New(m.idGenerator)
+	if err != nil {
+		return err
+	}
+
 	m.boltClient = bolt.NewClient()
 	m.boltClient.Path = m.boltPath
-	m.boltClient.WithLogger(m.logger.With(zap.String("service", "bolt")))
+	m.boltClient.IDGenerator = idGen
+	m.boltClient.WithLogger(m.levels.Module(m.logger, "bolt"))
 
 	if err := m.boltClient.Open(ctx); err != nil {
 		m.logger.Error("failed opening bolt", zap.Error(err))
@@ -267,6 +541,11 @@ func (m *Main) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	const (
+		concurrencyQuota = 10
+		memoryBytesQuota = 1e6
+	)
+
 	var pointsWriter storage.PointsWriter
 	{
 		m.engine = storage.NewEngine(m.enginePath, storage.NewConfig(), storage.WithRetentionEnforcer(bucketSvc))
@@ -277,20 +556,24 @@ func (m *Main) run(ctx context.Context) (err error) {
 			return err
 		}
 		// The Engine's metrics must be registered after it opens.
-		reg.MustRegister(m.engine.PrometheusCollectors()...)
+		reg.MustRegisterCollectors(m.engine)
 
 		pointsWriter = m.engine
+	}
 
-		const (
-			concurrencyQuota = 10
-			memoryBytesQuota = 1e6
-		)
+	auditRecorder, err := m.newAuditRecorder(reg, pointsWriter)
+	if err != nil {
+		m.logger.Error("failed to configure audit events", zap.Error(err))
+		return err
+	}
+	authSvc = audit.NewAuthorizationService(authSvc, auditRecorder)
 
+	{
 		cc := control.Config{
 			ExecutorDependencies: make(execute.Dependencies),
 			ConcurrencyQuota:     concurrencyQuota,
 			MemoryBytesQuota:     int64(memoryBytesQuota),
-			Logger:               m.logger.With(zap.String("service", "storage-reads")),
+			Logger:               m.levels.Module(m.logger, "storage-reads"),
 		}
 
 		if err := readservice.AddControllerConfigDependencies(
@@ -301,29 +584,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 		}
 
 		m.queryController = pcontrol.New(cc)
-		reg.MustRegister(m.queryController.PrometheusCollectors()...)
-	}
-
-	var storageQueryService query.ProxyQueryService = readservice.NewProxyQueryService(m.queryController)
-	var taskSvc platform.TaskService
-	{
-		boltStore, err := taskbolt.New(m.boltClient.DB(), "tasks")
-		if err != nil {
-			m.logger.Error("failed opening task bolt", zap.Error(err))
-			return err
-		}
-
-		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, boltStore)
-
-		lw := taskbackend.NewPointLogWriter(pointsWriter)
-		m.scheduler = taskbackend.NewScheduler(boltStore, executor, lw, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger))
-		m.scheduler.Start(ctx)
-		reg.MustRegister(m.scheduler.PrometheusCollectors()...)
-
-		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
-		lr := taskbackend.NewQueryLogReader(queryService)
-		taskSvc = task.PlatformAdapter(coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, boltStore), lr, m.scheduler)
-		taskSvc = task.NewValidator(taskSvc, bucketSvc)
+		reg.MustRegisterCollectors(m.queryController)
 	}
 
 	// NATS streaming server
@@ -346,6 +607,97 @@ func (m *Main) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Subsystems publish their events, such as task changes and finished runs, on the event bus,
+	// which keeps them in the process unless they are to be sent through NATS to other processes.
+	var eventBus eventbus.Bus
+	if m.eventBusNATS {
+		natsBus := nats.NewEventBus(publisher, subscriber)
+		natsBus.Logger = m.levels.Module(m.logger, "eventbus")
+		eventBus = natsBus
+	} else {
+		localBus := eventbus.NewLocal("platform")
+		localBus.WithLogger(m.levels.Module(m.logger, "eventbus"))
+		reg.MustRegisterCollectors(localBus)
+		eventBus = localBus
+	}
+
+	var storageQueryService query.ProxyQueryService = readservice.NewProxyQueryService(m.queryController)
+	var taskSvc platform.TaskService
+	var quotaStore taskbackend.QuotaStore
+	{
+		cipher, err := loadTaskCipher(m.taskKeyPath)
+		if err != nil {
+			m.logger.Error("failed loading task encryption key", zap.Error(err))
+			return err
+		}
+		defaultQuota := taskbackend.TaskQuota{MaxTasks: m.taskMaxTasks, MaxRunDuration: m.taskMaxRunTime}
+		boltStore, err := taskbolt.New(m.boltClient.DB(), "tasks", taskbolt.WithCipher(cipher), taskbolt.WithIDGenerator(idGen), taskbolt.WithDefaultQuota(defaultQuota))
+		if err != nil {
+			m.logger.Error("failed opening task bolt", zap.Error(err))
+			return err
+		}
+		quotaStore = boltStore
+
+		// The scheduler looks up the quota of each run it starts, so keep quotas in memory; changes take effect within a minute.
+		quotas := taskbackend.NewCachedQuotas(boltStore, time.Minute)
+		reg.MustRegisterCollectors(quotas)
+
+		// Hold back task runs while the query controller is busy, rather than queueing them there.
+		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.levels.Module(m.logger, "task-executor"), m.queryController, boltStore,
+			taskexecutor.WithQueryConcurrency(concurrencyQuota),
+			taskexecutor.WithSecretService(m.boltClient),
+		)
+
+		lw := taskbackend.NewPointLogWriter(pointsWriter)
+		notifier := tasknotify.New(tasknotify.WithLogger(m.levels.Module(m.logger, "task-notify")))
+		schOpts := []taskbackend.TickSchedulerOption{
+			taskbackend.WithTicker(ctx, 100*time.Millisecond),
+			taskbackend.WithLogger(m.levels.Module(m.logger, "task-scheduler")),
+			taskbackend.WithJitter(m.taskJitter),
+			taskbackend.WithRunObserver(notifier),
+			taskbackend.WithRunObserver(taskbackend.NewRunEventPublisher(eventBus, m.levels.Module(m.logger, "task-scheduler"))),
+		}
+		runPool := pool.New("task-runs", m.taskRunWorkers)
+		runPool.WithLogger(m.levels.Module(m.logger, "task-scheduler"))
+		reg.MustRegisterCollectors(runPool)
+		schOpts = append(schOpts, taskbackend.WithRunPool(runPool), taskbackend.WithQuotas(quotas))
+		if m.taskOrgRunRate > 0 {
+			schOpts = append(schOpts, taskbackend.WithOrgRunLimiter(taskbackend.NewOrgRunLimiter(float64(m.taskOrgRunRate), m.taskOrgRunRate)))
+		}
+		m.scheduler = taskbackend.NewScheduler(boltStore, executor, lw, time.Now().UTC().Unix(), schOpts...)
+		m.scheduler.Start(ctx)
+		reg.MustRegisterCollectors(m.scheduler)
+
+		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
+		lr := taskbackend.NewQueryLogReader(queryService)
+
+		// Stop claiming and updating tasks in a scheduler that keeps failing; the reconciler catches up once it recovers.
+		schedulerBreaker := circuitbreaker.New("task-scheduler")
+		schedulerBreaker.WithLogger(m.levels.Module(m.logger, "task-coordinator"))
+		reg.MustRegisterCollectors(schedulerBreaker)
+
+		// The task API and the run endpoints look up the same tasks over and over; keep them in memory for a minute.
+		taskCache := cache.New("tasks", 10000, time.Minute)
+		reg.MustRegisterCollectors(taskCache)
+
+		m.taskCoordinator = coordinator.New(m.levels.Module(m.logger, "task-coordinator"), m.scheduler, boltStore,
+			coordinator.WithAutoDisable(m.taskMaxFailures, nil),
+			coordinator.WithReconcile(ctx, time.Minute),
+			coordinator.WithMinInterval(m.taskMinInterval),
+			coordinator.WithSoftDelete(ctx, m.taskPurgeAfter),
+			coordinator.WithClaimWorkers(m.taskClaimers),
+			coordinator.WithAuditRecorder(auditRecorder),
+			coordinator.WithSchedulerBreaker(schedulerBreaker),
+			coordinator.WithQuotas(quotas),
+			coordinator.WithTaskCache(taskCache),
+			coordinator.WithEventBus(eventBus),
+		)
+		m.scheduler.AddRunObserver(m.taskCoordinator)
+		reg.MustRegisterCollectors(m.taskCoordinator)
+		taskSvc = task.PlatformAdapter(m.taskCoordinator, lr, m.scheduler)
+		taskSvc = task.NewValidator(taskSvc, bucketSvc)
+	}
+
 	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 0, 0)
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
@@ -355,7 +707,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 	m.wg.Add(1)
 	go func(logger *zap.Logger) {
 		defer m.wg.Done()
-		logger = logger.With(zap.String("service", "scraper"))
+		logger = m.levels.Module(logger, "scraper")
 		if err := scraperScheduler.Run(ctx); err != nil {
 			logger.Error("failed scraper service", zap.Error(err))
 		}
@@ -393,19 +745,44 @@ func (m *Main) run(ctx context.Context) (err error) {
 		TelegrafService:                 telegrafSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
+		BackupService:                   m.boltClient,
+		AuditStore:                      m.boltClient,
+		QuotaStore:                      quotaStore,
+		SecretService:                   m.boltClient,
 	}
+	if m.taskAPIRate > 0 {
+		handlerConfig.TaskRateLimiter = transport.NewRateLimiter("tasks", float64(m.taskAPIRate), m.taskAPIRate, transport.KeyByFirst(transport.KeyByToken, transport.KeyByIP))
+		handlerConfig.TaskRateLimiter.WithLogger(m.logger)
+		reg.MustRegisterCollectors(handlerConfig.TaskRateLimiter)
+	}
+	handlerConfig.TaskRecoverer = transport.NewRecoverer("tasks")
+	handlerConfig.TaskRecoverer.WithLogger(m.logger)
+	reg.MustRegisterCollectors(handlerConfig.TaskRecoverer)
 
 	// HTTP server
-	httpLogger := m.logger.With(zap.String("service", "http"))
+	httpLogger := m.levels.Module(m.logger, "http")
 	platformHandler := http.NewPlatformHandler(handlerConfig)
-	reg.MustRegister(platformHandler.PrometheusCollectors()...)
+	reg.MustRegisterCollectors(platformHandler)
 
 	h := http.NewHandlerFromRegistry("platform", reg)
 	h.Handler = platformHandler
+	h.ReadyHandler = http.NewReadyHandler(map[string]func() bool{
+		"tasks":    m.taskCoordinator.Ready,
+		"shutdown": m.shutdown.Ready,
+	})
+	nethttp.DefaultServeMux.Handle(http.DebugSchedulerPath, http.NewSchedulerDebugHandler(m.scheduler))
+	// Log levels can be changed at runtime, per module, through /debug/log/levels.
+	nethttp.DefaultServeMux.Handle(http.DebugPath+"/log/levels", m.levels)
+	// The debug endpoints, pprof and those above alike, are behind the debug token and rate limit.
+	debugHandler := debug.NewHandler(m.debugToken, float64(m.debugRateLimit)/60, m.debugRateLimit, nethttp.DefaultServeMux)
+	debugHandler.WithLogger(httpLogger)
+	reg.MustRegisterCollectors(debugHandler)
+	h.DebugHandler = debugHandler
 	h.Logger = httpLogger
 	h.Tracer = opentracing.GlobalTracer()
 
-	m.httpServer.Handler = h
+	// Every API call carries a request ID, recorded in the logs and task audit entries it causes.
+	m.httpServer.Handler = http.NewRequestIDHandler(h)
 
 	ln, err := net.Listen("tcp", m.httpBindAddress)
 	if err != nil {
@@ -418,6 +795,12 @@ func (m *Main) run(ctx context.Context) (err error) {
 		m.httpPort = addr.Port
 	}
 
+	if m.telemetry {
+		m.startTelemetry(ctx, reg)
+	}
+
+	m.registerShutdown()
+
 	m.wg.Add(1)
 	go func(logger *zap.Logger) {
 		defer m.wg.Done()
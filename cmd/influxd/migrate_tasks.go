@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	taskbolt "github.com/influxdata/platform/task/backend/bolt"
+	"github.com/influxdata/platform/task/backend/migrate"
+	taskpostgres "github.com/influxdata/platform/task/backend/postgres"
+	_ "github.com/lib/pq" // Registers the "postgres" database driver.
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// migrateTasksFlags are the options to the migrate-tasks command.
+type migrateTasksFlags struct {
+	boltPath       string
+	postgresDSN    string
+	checkpointPath string
+	keyPath        string
+}
+
+// newMigrateTasksCommand returns the migrate-tasks command, which copies tasks from the bolt task store to Postgres.
+//
+// Runs and logs written by the server are kept in the storage engine, not in the task store, so they stay where they are.
+// Use the migrate package's WithRuns option to copy runs between run stores, such as to a Postgres RunStore.
+func (m *Main) newMigrateTasksCommand(ctx context.Context, defaultBoltPath string) *cobra.Command {
+	var flags migrateTasksFlags
+	cmd := &cobra.Command{
+		Use:   "migrate-tasks",
+		Short: "Copy tasks from the bolt task store to Postgres",
+		Long: `Copy every task, with its meta and script versions, from the bolt task store to a Postgres task store, keeping task IDs.
+The server should be stopped while tasks are migrated.
+Each task is verified after it is copied. If a checkpoint path is given,
+the ID of the last task copied is written to it, and a later run resumes after that task.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			m.ranCommand = true
+			return m.migrateTasks(ctx, flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.boltPath, "bolt-path", defaultBoltPath, "path to boltdb database to copy tasks from")
+	cmd.Flags().StringVar(&flags.postgresDSN, "postgres-dsn", "", "connection string of the Postgres database to copy tasks to")
+	cmd.Flags().StringVar(&flags.keyPath, "task-encryption-key-path", "", "path to the key the server encrypts task scripts with, if any; migrated scripts are encrypted with it too")
+	cmd.Flags().StringVar(&flags.checkpointPath, "checkpoint-path", "", "path to a file recording migration progress, so that an interrupted migration can be resumed")
+	return cmd
+}
+
+func (m *Main) migrateTasks(ctx context.Context, flags migrateTasksFlags) error {
+	if flags.postgresDSN == "" {
+		return fmt.Errorf("must specify --postgres-dsn")
+	}
+
+	cipher, err := loadTaskCipher(flags.keyPath)
+	if err != nil {
+		return err
+	}
+
+	logger := zap.NewNop()
+
+	boltClient := bolt.NewClient()
+	boltClient.Path = flags.boltPath
+	boltClient.WithLogger(logger)
+	if err := boltClient.Open(ctx); err != nil {
+		return fmt.Errorf("failed opening bolt: %v", err)
+	}
+	defer boltClient.Close()
+	from, err := taskbolt.New(boltClient.DB(), "tasks", taskbolt.WithCipher(cipher))
+	if err != nil {
+		return fmt.Errorf("failed opening task bolt: %v", err)
+	}
+
+	db, err := sql.Open("postgres", flags.postgresDSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	to, err := taskpostgres.New(ctx, db, taskpostgres.WithCipher(cipher))
+	if err != nil {
+		return fmt.Errorf("failed opening task postgres: %v", err)
+	}
+
+	var opts []migrate.Option
+	if flags.checkpointPath != "" {
+		after, err := readCheckpoint(flags.checkpointPath)
+		if err != nil {
+			return err
+		}
+		if after.Valid() {
+			fmt.Fprintf(m.Stdout, "Resuming after task %s\n", after)
+			opts = append(opts, migrate.ResumeAfter(after))
+		}
+		opts = append(opts, migrate.WithCheckpoint(func(id platform.ID) error {
+			return ioutil.WriteFile(flags.checkpointPath, []byte(id.String()+"\n"), 0600)
+		}))
+	}
+
+	mig, err := migrate.New(from, to, opts...)
+	if err != nil {
+		return err
+	}
+	res, err := mig.Migrate(ctx)
+	fmt.Fprintf(m.Stdout, "Migrated %d tasks\n", res.Tasks)
+	return err
+}
+
+// readCheckpoint returns the task ID recorded at path, or an invalid ID if there is no file at path.
+func readCheckpoint(path string) (platform.ID, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return platform.InvalidID(), nil
+	}
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	id, err := platform.IDFromString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return platform.InvalidID(), fmt.Errorf("invalid checkpoint in %s: %v", path, err)
+	}
+	return *id, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	taskbackend "github.com/influxdata/platform/task/backend"
+)
+
+// loadTaskCipher returns the cipher that encrypts task scripts and run logs at rest,
+// using the hex-encoded AES key in the file at path.
+// It returns a nil cipher if path is empty, so that tasks are stored unencrypted.
+func loadTaskCipher(path string) (taskbackend.Cipher, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading task encryption key: %v", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("task encryption key must be hex-encoded: %v", err)
+	}
+	c, err := taskbackend.NewAESCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task encryption key: %v", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/telemetry"
+)
+
+// startTelemetry reports anonymous usage counters until ctx is done:
+// the scheduler's task and run totals, and which optional features of the server are configured.
+func (m *Main) startTelemetry(ctx context.Context, reg *prom.Registry) {
+	r := telemetry.NewReporter(commit,
+		telemetry.WithEndpoint(m.telemetryURL),
+		telemetry.WithExportPath(m.telemetryExportPath),
+		telemetry.WithInterval(m.telemetryInterval),
+	)
+	r.WithLogger(m.levels.Module(m.logger, "telemetry"))
+	reg.MustRegisterCollectors(r)
+
+	r.Register("tasks", telemetry.PrometheusSource(reg,
+		"task_scheduler_claims_active",
+		"task_scheduler_total_runs_active",
+		"task_scheduler_total_runs_complete",
+	))
+	r.Register("config", telemetry.SourceFunc(func(context.Context) (map[string]float64, error) {
+		return map[string]float64{
+			"task_api_rate_limited": boolCounter(m.taskAPIRate > 0),
+			"debug_token":           boolCounter(m.debugToken != ""),
+			"task_encryption":       boolCounter(m.taskKeyPath != ""),
+			"task_min_interval":     m.taskMinInterval.Seconds(),
+			"task_claim_workers":    float64(m.taskClaimers),
+		}, nil
+	}))
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		r.Run(ctx)
+	}()
+}
+
+// boolCounter returns 1 if b is set, and 0 otherwise.
+func boolCounter(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
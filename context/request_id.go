@@ -0,0 +1,19 @@
+package context
+
+import (
+	"context"
+)
+
+const requestIDCtxKey = contextKey("influx/request-id/v1")
+
+// SetRequestID sets the ID of the API request being served on context.
+func SetRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey, id)
+}
+
+// GetRequestID retrieves the ID of the API request being served from context.
+// It returns the empty string if context is not serving a request.
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
@@ -17,8 +17,32 @@ const (
 	EEmptyValue  = "empty value"
 	EUnavailable = "unavailable"
 	EForbidden   = "forbidden"
+
+	EUnprocessableEntity = "unprocessable entity" // well-formed, but cannot be acted on as is
+	EQuotaExceeded       = "quota exceeded"       // the organization's quota does not allow it
+	ETooManyRequests     = "too many requests"    // rate limit exceeded
 )
 
+// Coder is implemented by errors that carry an error code without being an *Error,
+// such as a package's sentinel errors, which must keep their messages.
+// ErrorCode reports the code of a Coder.
+type Coder interface {
+	error
+	Code() string
+}
+
+// NewCodedError returns an error with the given code and message, whose Error method returns msg unchanged.
+func NewCodedError(code, msg string) error {
+	return &codedError{code: code, msg: msg}
+}
+
+type codedError struct {
+	code, msg string
+}
+
+func (e *codedError) Error() string { return e.msg }
+func (e *codedError) Code() string  { return e.code }
+
 // Error is the error struct of platform.
 //
 // Errors may have error codes, human-readable messages,
@@ -88,6 +112,8 @@ func ErrorCode(err error) string {
 		return e.Code
 	} else if ok && e.Err != nil {
 		return ErrorCode(e.Err)
+	} else if c, ok := err.(Coder); ok && c.Code() != "" {
+		return c.Code()
 	}
 	return EInternal
 }
@@ -162,6 +162,16 @@ func TestErrorCode(t *testing.T) {
 			err:  errors.New("s"),
 			want: platform.EInternal,
 		},
+		{
+			name: "coded error",
+			err:  platform.NewCodedError(platform.EQuotaExceeded, "s"),
+			want: platform.EQuotaExceeded,
+		},
+		{
+			name: "embeded coded error",
+			err:  &platform.Error{Err: platform.NewCodedError(platform.ENotFound, "s")},
+			want: platform.ENotFound,
+		},
 	}
 	for _, c := range cases {
 		if result := platform.ErrorCode(c.err); c.want != result {
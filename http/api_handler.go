@@ -5,9 +5,13 @@ import (
 	"strings"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
 	"github.com/influxdata/platform/chronograf/server"
+	"github.com/influxdata/platform/kit/transport"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -29,6 +33,16 @@ type APIHandler struct {
 	WriteHandler         *WriteHandler
 	SetupHandler         *SetupHandler
 	SessionHandler       *SessionHandler
+	BackupHandler        *BackupHandler
+	AuditHandler         *AuditHandler
+	QuotaHandler         *QuotaHandler
+
+	// TaskVersions routes task API requests to the handler of the version of the task API they ask for.
+	// It serves the TaskHandler as v2; later versions are added with TaskVersions.Handle.
+	TaskVersions *transport.VersionRouter
+
+	// tasks serves the task API: the TaskVersions, behind the task rate limiter and panic recoverer if there are any.
+	tasks http.Handler
 }
 
 // APIBackend is all services and associated parameters required to construct
@@ -62,6 +76,15 @@ type APIBackend struct {
 	TelegrafService                 platform.TelegrafConfigStore
 	ScraperTargetStoreService       platform.ScraperTargetStoreService
 	ChronografService               *server.Service
+	BackupService                   platform.BackupService
+	AuditStore                      audit.Store
+	QuotaStore                      backend.QuotaStore
+	SecretService                   platform.SecretService
+
+	// TaskRateLimiter, if set, limits the rate of requests to the task API.
+	TaskRateLimiter *transport.RateLimiter
+	// TaskRecoverer, if set, recovers panics in the task API, responding with status 500.
+	TaskRecoverer *transport.Recoverer
 }
 
 // NewAPIHandler constructs all api handlers beneath it and returns an APIHandler
@@ -82,6 +105,7 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.OrgHandler.BucketService = b.BucketService
 	h.OrgHandler.OrganizationOperationLogService = b.OrganizationOperationLogService
 	h.OrgHandler.UserService = b.UserService
+	h.OrgHandler.SecretService = b.SecretService
 
 	h.UserHandler = NewUserHandler()
 	h.UserHandler.UserService = b.UserService
@@ -117,6 +141,15 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.TaskHandler.AuthorizationService = b.AuthorizationService
 	h.TaskHandler.UserResourceMappingService = b.UserResourceMappingService
 	h.TaskHandler.UserService = b.UserService
+	h.TaskVersions = transport.NewVersionRouter("tasks", "v2", h.TaskHandler)
+	h.TaskVersions.WithLogger(b.Logger.With(zap.String("handler", "task")))
+	h.tasks = h.TaskVersions
+	if b.TaskRateLimiter != nil {
+		h.tasks = b.TaskRateLimiter.Middleware(h.tasks)
+	}
+	if b.TaskRecoverer != nil {
+		h.tasks = b.TaskRecoverer.Middleware(h.tasks)
+	}
 
 	h.TelegrafHandler = NewTelegrafHandler(
 		b.Logger.With(zap.String("handler", "telegraf")),
@@ -138,6 +171,18 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 
+	h.BackupHandler = NewBackupHandler()
+	h.BackupHandler.BackupService = b.BackupService
+	h.BackupHandler.Logger = b.Logger.With(zap.String("handler", "backup"))
+
+	h.AuditHandler = NewAuditHandler()
+	h.AuditHandler.AuditStore = b.AuditStore
+	h.AuditHandler.Logger = b.Logger.With(zap.String("handler", "audit"))
+
+	h.QuotaHandler = NewQuotaHandler()
+	h.QuotaHandler.QuotaStore = b.QuotaStore
+	h.QuotaHandler.Logger = b.Logger.With(zap.String("handler", "quota"))
+
 	return h
 }
 
@@ -157,6 +202,9 @@ var apiLinks = map[string]interface{}{
 	"tasks":          "/api/v2/tasks",
 	"macros":         "/api/v2/macros",
 	"telegrafs":      "/api/v2/telegrafs",
+	"audit":          "/api/v2/audit",
+	"quotas":         "/api/v2/quotas",
+	"backup":         "/api/v2/backup",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -182,6 +230,11 @@ func (h *APIHandler) serveLinks(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (h *APIHandler) PrometheusCollectors() []prometheus.Collector {
+	return h.TaskVersions.PrometheusCollectors()
+}
+
 // ServeHTTP delegates a request to the appropriate subhandler.
 func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	setCORSResponseHeaders(w, r)
@@ -250,8 +303,9 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if strings.HasPrefix(r.URL.Path, "/api/v2/tasks") {
-		h.TaskHandler.ServeHTTP(w, r)
+	// Tasks are served under the prefix of every version, for the TaskVersions to route.
+	if _, rest := transport.PathVersion(r.URL.Path); strings.HasPrefix(rest, "/tasks") {
+		h.tasks.ServeHTTP(w, r)
 		return
 	}
 
@@ -270,6 +324,21 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/audit") {
+		h.AuditHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/quotas") {
+		h.QuotaHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/backup") {
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.ChronografHandler.ServeHTTP(w, r)
 		return
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const auditPath = "/api/v2/audit"
+
+// AuditHandler serves the platform's audit events, for compliance review.
+type AuditHandler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	AuditStore audit.Store
+}
+
+// NewAuditHandler returns a new instance of AuditHandler.
+func NewAuditHandler() *AuditHandler {
+	h := &AuditHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+	h.HandlerFunc("GET", auditPath, h.handleGetAuditEvents)
+	return h
+}
+
+type auditEventsResponse struct {
+	Events []audit.Event `json:"events"`
+}
+
+// handleGetAuditEvents is the HTTP handler for the GET /api/v2/audit route.
+func (h *AuditHandler) handleGetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if !a.Allowed(audit.ReviewPermission) {
+		EncodeError(ctx, errors.Forbiddenf("insufficient permissions to review audit events"), w)
+		return
+	}
+
+	f, err := decodeAuditFilter(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	es, err := h.AuditStore.FindEvents(ctx, f)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if es == nil {
+		es = []audit.Event{}
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, auditEventsResponse{Events: es}); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// decodeAuditFilter decodes the filter of a request for audit events from its query parameters:
+// resourceType, resourceID, orgID, userID, after and before, as RFC3339 times, and limit.
+func decodeAuditFilter(r *http.Request) (audit.Filter, error) {
+	qp := r.URL.Query()
+	f := audit.Filter{ResourceType: qp.Get("resourceType")}
+
+	ids := []struct {
+		param string
+		dst   *platform.ID
+	}{
+		{param: "resourceID", dst: &f.ResourceID},
+		{param: "orgID", dst: &f.OrgID},
+		{param: "userID", dst: &f.UserID},
+	}
+	for _, id := range ids {
+		s := qp.Get(id.param)
+		if s == "" {
+			continue
+		}
+		if err := id.dst.DecodeFromString(s); err != nil {
+			return f, errors.MalformedDataf("invalid %s: %v", id.param, err)
+		}
+	}
+
+	times := []struct {
+		param string
+		dst   *time.Time
+	}{
+		{param: "after", dst: &f.After},
+		{param: "before", dst: &f.Before},
+	}
+	for _, t := range times {
+		s := qp.Get(t.param)
+		if s == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return f, errors.MalformedDataf("invalid %s: %v", t.param, err)
+		}
+		*t.dst = v
+	}
+	if !f.After.IsZero() && !f.Before.IsZero() && !f.Before.After(f.After) {
+		return f, errors.InvalidDataf("before must be later than after")
+	}
+
+	if s := qp.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > audit.MaxLimit {
+			return f, errors.InvalidDataf("limit must be between 1 and %d", audit.MaxLimit)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
+
+// AuditService connects to the audit endpoint of an influxd.
+type AuditService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// FindEvents returns the audit events that f selects, oldest first.
+func (s *AuditService) FindEvents(ctx context.Context, f audit.Filter) ([]audit.Event, error) {
+	u, err := newURL(s.Addr, auditPath)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := u.Query()
+	if f.ResourceType != "" {
+		qp.Set("resourceType", f.ResourceType)
+	}
+	if f.ResourceID.Valid() {
+		qp.Set("resourceID", f.ResourceID.String())
+	}
+	if f.OrgID.Valid() {
+		qp.Set("orgID", f.OrgID.String())
+	}
+	if f.UserID.Valid() {
+		qp.Set("userID", f.UserID.String())
+	}
+	if !f.After.IsZero() {
+		qp.Set("after", f.After.Format(time.RFC3339))
+	}
+	if !f.Before.IsZero() {
+		qp.Set("before", f.Before.Format(time.RFC3339))
+	}
+	if f.Limit > 0 {
+		qp.Set("limit", strconv.Itoa(f.EffectiveLimit()))
+	}
+	u.RawQuery = qp.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var res auditEventsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res.Events, nil
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	pcontext "github.com/influxdata/platform/context"
+)
+
+// fakeAuditStore is an audit.Store that holds a fixed list of events, and records the last filter it was given.
+type fakeAuditStore struct {
+	events []audit.Event
+	filter audit.Filter
+}
+
+func (s *fakeAuditStore) RecordEvent(ctx context.Context, e audit.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *fakeAuditStore) FindEvents(ctx context.Context, f audit.Filter) ([]audit.Event, error) {
+	s.filter = f
+	var es []audit.Event
+	for _, e := range s.events {
+		if f.Match(e) {
+			es = append(es, e)
+		}
+	}
+	return es, nil
+}
+
+func TestAuditHandler(t *testing.T) {
+	store := &fakeAuditStore{events: []audit.Event{
+		{ID: 1, Action: audit.Create, Resource: audit.Resource{Type: audit.TaskResourceType, ID: 10, OrgID: 2}},
+		{ID: 2, Action: audit.Delete, Resource: audit.Resource{Type: audit.AuthorizationResourceType, ID: 20}},
+	}}
+	h := NewAuditHandler()
+	h.AuditStore = store
+
+	operator := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{audit.ReviewPermission}}
+	for _, tt := range []struct {
+		name   string
+		auth   *platform.Authorization
+		query  string
+		code   int
+		events int
+	}{
+		{name: "all", auth: operator, code: http.StatusOK, events: 2},
+		{name: "by resource type", auth: operator, query: "?resourceType=task", code: http.StatusOK, events: 1},
+		{name: "by org", auth: operator, query: "?orgID=0000000000000002", code: http.StatusOK, events: 1},
+		{name: "invalid time", auth: operator, query: "?after=yesterday", code: http.StatusBadRequest},
+		{name: "invalid limit", auth: operator, query: "?limit=0", code: http.StatusUnprocessableEntity},
+		{
+			name: "without permission",
+			auth: &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{platform.ReadBucketPermission(1)}},
+			code: http.StatusForbidden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", auditPath+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), tt.auth))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+			var res auditEventsResponse
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Events) != tt.events {
+				t.Fatalf("expected %d events, got %d: %+v", tt.events, len(res.Events), res.Events)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -35,9 +37,14 @@ func NewAuthorizationHandler() *AuthorizationHandler {
 	h.HandlerFunc("GET", "/api/v2/authorizations/:id", h.handleGetAuthorization)
 	h.HandlerFunc("PATCH", "/api/v2/authorizations/:id", h.handleSetAuthorizationStatus)
 	h.HandlerFunc("DELETE", "/api/v2/authorizations/:id", h.handleDeleteAuthorization)
+	h.HandlerFunc("POST", "/api/v2/authorizations/:id/rotate", h.handleRotateAuthorization)
 	return h
 }
 
+// DefaultRotationGrace is how long the replaced token of a rotated authorization is still accepted,
+// unless the rotate request sets its grace.
+const DefaultRotationGrace = time.Hour
+
 type authResponse struct {
 	Links map[string]string `json:"links"`
 	platform.Authorization
@@ -327,6 +334,76 @@ func decodeDeleteAuthorizationRequest(ctx context.Context, r *http.Request) (*de
 	}, nil
 }
 
+// handleRotateAuthorization is the HTTP handler for the POST /api/v2/authorizations/:id/rotate route.
+func (h *AuthorizationHandler) handleRotateAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeRotateAuthorizationRequest(ctx, r)
+	if err != nil {
+		h.Logger.Info("failed to decode request", zap.String("handler", "rotateAuthorization"), zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	a, err := h.AuthorizationService.RotateAuthorization(ctx, req.ID, req.Grace)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAuthResponse(a)); err != nil {
+		h.Logger.Info("failed to encode response", zap.String("handler", "rotateAuthorization"), zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type rotateAuthorizationRequest struct {
+	ID    platform.ID
+	Grace time.Duration
+}
+
+// rotateAuthorizationBody is the body of a rotate request, whose grace is a duration such as "24h".
+type rotateAuthorizationBody struct {
+	Grace string `json:"grace,omitempty"`
+}
+
+func decodeRotateAuthorizationRequest(ctx context.Context, r *http.Request) (*rotateAuthorizationRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+
+	var i platform.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	req := &rotateAuthorizationRequest{
+		ID:    i,
+		Grace: DefaultRotationGrace,
+	}
+
+	// The body is optional.
+	var b rotateAuthorizationBody
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil && err != io.EOF {
+		return nil, kerrors.MalformedDataf("invalid request body: %v", err)
+	}
+	if b.Grace != "" {
+		grace, err := time.ParseDuration(b.Grace)
+		if err != nil {
+			return nil, kerrors.MalformedDataf("invalid grace: %v", err)
+		}
+		if grace < 0 {
+			return nil, kerrors.InvalidDataf("grace must not be negative")
+		}
+		req.Grace = grace
+	}
+
+	return req, nil
+}
+
 // AuthorizationService connects to Influx via HTTP using tokens to manage authorizations
 type AuthorizationService struct {
 	Addr               string
@@ -534,3 +611,44 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 func authorizationIDPath(id platform.ID) string {
 	return path.Join(authorizationPath, id.String())
 }
+
+// RotateAuthorization gives the authorization a new token, keeping its replaced token for grace.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	u, err := newURL(s.Addr, path.Join(authorizationIDPath(id), "rotate"))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(rotateAuthorizationBody{
+		Grace: grace.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckError(resp, true); err != nil {
+		return nil, err
+	}
+
+	var a platform.Authorization
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return &a, nil
+}
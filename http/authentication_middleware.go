@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/influxdata/platform"
 	platcontext "github.com/influxdata/platform/context"
@@ -118,6 +119,9 @@ func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *htt
 	if err != nil {
 		return ctx, err
 	}
+	if a.Expired(time.Now()) {
+		return ctx, fmt.Errorf("token has expired")
+	}
 
 	return platcontext.SetAuthorizer(ctx, a), nil
 }
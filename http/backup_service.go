@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const backupPath = "/api/v2/backup"
+
+// BackupHandler streams a backup of the platform's store, while the platform keeps running.
+type BackupHandler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	BackupService platform.BackupService
+}
+
+// NewBackupHandler returns a new instance of BackupHandler.
+func NewBackupHandler() *BackupHandler {
+	h := &BackupHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+	h.HandlerFunc("GET", backupPath, h.handleGetBackup)
+	return h
+}
+
+// handleGetBackup is the HTTP handler for the GET /api/v2/backup route.
+func (h *BackupHandler) handleGetBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if !a.Allowed(platform.BackupPermission) {
+		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for backup"), w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="influxd.bolt"`)
+	w.WriteHeader(http.StatusOK)
+
+	// Once the backup starts streaming, the status has been sent, so errors can only be logged.
+	// Clients detect a failed backup by validating it, as the server does before restoring it.
+	n, err := h.BackupService.Backup(ctx, w)
+	if err != nil {
+		h.Logger.Info("Failed to write backup", zap.Int64("bytes", n), zap.Error(err))
+		return
+	}
+	h.Logger.Info("Wrote backup", zap.Int64("bytes", n))
+}
+
+// BackupService connects to the backup endpoint of an influxd.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// Backup writes a backup of the platform's store to w, and returns the number of bytes written.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer) (int64, error) {
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := CheckError(resp); err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("unable to read backup: %v", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+)
+
+type fakeBackupService string
+
+func (f fakeBackupService) Backup(ctx context.Context, w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, string(f))
+	return int64(n), err
+}
+
+func TestBackupHandler(t *testing.T) {
+	h := NewBackupHandler()
+	h.BackupService = fakeBackupService("snapshot")
+
+	for _, tt := range []struct {
+		name string
+		auth *platform.Authorization
+		code int
+		body string
+	}{
+		{
+			name: "operator",
+			auth: &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{platform.BackupPermission}},
+			code: http.StatusOK,
+			body: "snapshot",
+		},
+		{
+			name: "without permission",
+			auth: &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{platform.ReadBucketPermission(1)}},
+			code: http.StatusForbidden,
+		},
+		{
+			name: "inactive",
+			auth: &platform.Authorization{Status: platform.Inactive, Permissions: []platform.Permission{platform.BackupPermission}},
+			code: http.StatusForbidden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", backupPath, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), tt.auth))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("expected body %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
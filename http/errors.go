@@ -132,6 +132,10 @@ func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 		_, _ = w.Write(b)
 		return
 	}
+	if ce, ok := err.(platform.Coder); ok {
+		encodeCodedError(ce, w)
+		return
+	}
 	e, ok := err.(kerrors.Error)
 	if !ok {
 		e = kerrors.Error{
@@ -142,6 +146,33 @@ func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	encodeKError(e, w)
 }
 
+// encodeCodedError encodes an error that carries a platform error code, such as a task backend error,
+// with the status code of its error code and a platform.Error body.
+// Its message is also set in the X-Influx-Error header, for clients that compare errors by message.
+func encodeCodedError(err platform.Coder, w http.ResponseWriter) {
+	code := err.Code()
+	httpCode, ok := statusCodePlatformError[code]
+	if !ok {
+		httpCode = http.StatusInternalServerError
+	}
+
+	msg := err.Error()
+	header := msg
+	if len(header) > errorHeaderMaxLength {
+		header = header[0:errorHeaderMaxLength]
+	}
+	w.Header().Set(ErrorHeader, header)
+	w.Header().Set(ReferenceHeader, strconv.Itoa(referenceCodePlatformError[code]))
+	w.Header().Set(PlatformErrorCodeHeader, code)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	b, _ := json.Marshal(&platform.Error{
+		Code: code,
+		Msg:  msg,
+	})
+	_, _ = w.Write(b)
+}
+
 func encodeKError(e kerrors.Error, w http.ResponseWriter) {
 	if e.Reference == 0 {
 		e.Reference = kerrors.InternalError
@@ -177,11 +208,26 @@ func statusCode(e kerrors.Error) int {
 		return http.StatusForbidden
 	case kerrors.NotFound:
 		return http.StatusNotFound
+	case kerrors.TooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
+// referenceCodePlatformError maps platform error codes to the reference codes of the X-Influx-Reference header.
+// Codes that are not listed have no reference code of their own, and are reported as internal errors.
+var referenceCodePlatformError = map[string]int{
+	platform.EInternal:            kerrors.InternalError,
+	platform.EInvalid:             kerrors.InvalidData,
+	platform.EEmptyValue:          kerrors.InvalidData,
+	platform.EUnprocessableEntity: kerrors.InvalidData,
+	platform.ENotFound:            kerrors.NotFound,
+	platform.EForbidden:           kerrors.Forbidden,
+	platform.EQuotaExceeded:       kerrors.Forbidden,
+	platform.ETooManyRequests:     kerrors.TooManyRequests,
+}
+
 // statusCodePlatformError is the map convert platform.Error to error
 var statusCodePlatformError = map[string]int{
 	platform.EInternal:    http.StatusInternalServerError,
@@ -191,4 +237,8 @@ var statusCodePlatformError = map[string]int{
 	platform.ENotFound:    http.StatusNotFound,
 	platform.EUnavailable: http.StatusServiceUnavailable,
 	platform.EForbidden:   http.StatusForbidden,
+
+	platform.EUnprocessableEntity: http.StatusUnprocessableEntity,
+	platform.EQuotaExceeded:       http.StatusForbidden,
+	platform.ETooManyRequests:     http.StatusTooManyRequests,
 }
@@ -10,9 +10,11 @@ import (
 	"strings"
 	"time"
 
+	platcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/tracing"
+	"github.com/influxdata/platform/kit/transport"
 	opentracing "github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/ext"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -40,6 +42,9 @@ type Handler struct {
 	ReadyHandler http.Handler
 	// HealthHandler handles health requests
 	HealthHandler http.Handler
+	// HealthVersions routes health requests to the handler of the version of the health API they ask for.
+	// If set, it is the HealthHandler; later versions are added with HealthVersions.Handle.
+	HealthVersions *transport.VersionRouter
 	// DebugHandler handles debug requests
 	DebugHandler http.Handler
 	// Handler handles all other requests
@@ -79,9 +84,10 @@ func NewHandlerFromRegistry(name string, reg *prom.Registry) *Handler {
 		name:           name,
 		MetricsHandler: reg.HTTPHandler(),
 		ReadyHandler:   http.HandlerFunc(ReadyHandler),
-		HealthHandler:  http.HandlerFunc(HealthHandler),
+		HealthVersions: newHealthVersions(),
 		DebugHandler:   http.DefaultServeMux,
 	}
+	h.HealthHandler = h.HealthVersions
 	h.initMetrics()
 	reg.MustRegister(h.PrometheusCollectors()...)
 	return h
@@ -94,24 +100,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w = statusW
 
 	if h.Tracer != nil {
-		// Extract opentracing Span
 		var serverSpan opentracing.Span
-		opName := fmt.Sprintf("%s:%s", h.name, r.URL.Path)
-		wireContext, _ := h.Tracer.Extract(
-			opentracing.HTTPHeaders,
-			opentracing.HTTPHeadersCarrier(r.Header),
-		)
-
-		// Create the span referring to the RPC client if available.
-		// If wireContext == nil, a root span will be created.
-		serverSpan = h.Tracer.StartSpan(
-			opName,
-			ext.RPCServerOption(wireContext),
-		)
+		serverSpan, r = tracing.StartHTTPServerSpan(h.Tracer, fmt.Sprintf("%s:%s", h.name, r.URL.Path), r)
 		serverSpan.LogFields(log.String("handler", h.name))
 		defer serverSpan.Finish()
-
-		r = r.WithContext(opentracing.ContextWithSpan(r.Context(), serverSpan))
 	}
 
 	// TODO: This could be problematic eventually. But for now it should be fine.
@@ -136,6 +128,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			if errStr := w.Header().Get(ErrorHeader); errStr != "" {
 				errField = zap.Error(errors.New(errStr))
 			}
+			requestIDField := zap.Skip()
+			if id := platcontext.GetRequestID(r.Context()); id != "" {
+				requestIDField = zap.String("request_id", id)
+			}
 			errReferenceField := zap.Skip()
 			if errReference := w.Header().Get(ReferenceHeader); errReference != "" {
 				errReferenceField = zap.String("reference", errReference)
@@ -149,6 +145,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				zap.Int("duration_ns", int(duration)),
 				errField,
 				errReferenceField,
+				requestIDField,
 			)
 		}
 	}(time.Now())
@@ -176,10 +173,14 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, code int, res in
 
 // PrometheusCollectors satisifies prom.PrometheusCollector.
 func (h *Handler) PrometheusCollectors() []prometheus.Collector {
-	return []prometheus.Collector{
+	cs := []prometheus.Collector{
 		h.requests,
 		h.requestDur,
 	}
+	if h.HealthVersions != nil {
+		cs = append(cs, h.HealthVersions.PrometheusCollectors()...)
+	}
+	return cs
 }
 
 func (h *Handler) initMetrics() {
@@ -205,11 +206,5 @@ func (h *Handler) initMetrics() {
 
 // InjectTrace writes any span from the request's context into the request headers.
 func InjectTrace(r *http.Request) {
-	if span := opentracing.SpanFromContext(r.Context()); span != nil {
-		span.Tracer().Inject(
-			span.Context(),
-			opentracing.HTTPHeaders,
-			opentracing.HTTPHeadersCarrier(r.Header),
-		)
-	}
+	tracing.InjectHTTP(r)
 }
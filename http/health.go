@@ -3,8 +3,15 @@ package http
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/influxdata/platform/kit/transport"
 )
 
+// newHealthVersions returns the router of the versions of the health API, which serves HealthHandler as v1.
+func newHealthVersions() *transport.VersionRouter {
+	return transport.NewVersionRouter("health", "v1", http.HandlerFunc(HealthHandler))
+}
+
 // HealthHandler returns the status of the process.
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/julienschmidt/httprouter"
 )
@@ -364,6 +365,11 @@ func (h *OrgHandler) handleGetSecrets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizeSecrets(ctx, platform.ReadSecretPermission(req.orgID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	ks, err := h.SecretService.GetSecretKeys(ctx, req.orgID)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -376,6 +382,18 @@ func (h *OrgHandler) handleGetSecrets(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// authorizeSecrets checks that the request's authorizer has perm on the secrets of an organization.
+func authorizeSecrets(ctx context.Context, perm platform.Permission) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.Allowed(perm) {
+		return kerrors.Forbiddenf("insufficient permissions for secrets")
+	}
+	return nil
+}
+
 type getSecretsRequest struct {
 	orgID platform.ID
 }
@@ -407,6 +425,11 @@ func (h *OrgHandler) handlePatchSecrets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorizeSecrets(ctx, platform.WriteSecretPermission(req.orgID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SecretService.PatchSecrets(ctx, req.orgID, req.secrets); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -452,6 +475,11 @@ func (h *OrgHandler) handleDeleteSecrets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := authorizeSecrets(ctx, platform.WriteSecretPermission(req.orgID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.SecretService.DeleteSecret(ctx, req.orgID, req.secrets...); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/inmem"
 	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
@@ -45,6 +46,14 @@ func TestOrganizationService(t *testing.T) {
 	platformtesting.OrganizationService(initOrganizationService, t)
 }
 
+// secretsAuthorizer returns an authorizer that may manage the secrets of the organization with orgID.
+func secretsAuthorizer(orgID platform.ID) *platform.Authorization {
+	return &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: []platform.Permission{platform.ReadSecretPermission(orgID), platform.WriteSecretPermission(orgID)},
+	}
+}
+
 func TestSecretService_handleGetSecrets(t *testing.T) {
 	type fields struct {
 		SecretService platform.SecretService
@@ -128,6 +137,7 @@ func TestSecretService_handleGetSecrets(t *testing.T) {
 
 			u := fmt.Sprintf("http://any.url/api/v2/orgs/%s/secrets", tt.args.orgID)
 			r := httptest.NewRequest("GET", u, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), secretsAuthorizer(tt.args.orgID)))
 			w := httptest.NewRecorder()
 
 			h.ServeHTTP(w, r)
@@ -204,6 +214,7 @@ func TestSecretService_handlePatchSecrets(t *testing.T) {
 			buf := bytes.NewReader(b)
 			u := fmt.Sprintf("http://any.url/api/v2/orgs/%s/secrets", tt.args.orgID)
 			r := httptest.NewRequest("PATCH", u, buf)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), secretsAuthorizer(tt.args.orgID)))
 			w := httptest.NewRecorder()
 
 			h.ServeHTTP(w, r)
@@ -280,6 +291,7 @@ func TestSecretService_handleDeleteSecrets(t *testing.T) {
 			buf := bytes.NewReader(b)
 			u := fmt.Sprintf("http://any.url/api/v2/orgs/%s/secrets/delete", tt.args.orgID)
 			r := httptest.NewRequest("POST", u, buf)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), secretsAuthorizer(tt.args.orgID)))
 			w := httptest.NewRecorder()
 
 			h.ServeHTTP(w, r)
@@ -301,3 +313,22 @@ func TestSecretService_handleDeleteSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretService_forbidden(t *testing.T) {
+	h := NewOrgHandler(mock.NewUserResourceMappingService(), mock.NewLabelService())
+	h.SecretService = &mock.SecretService{
+		GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+			t.Fatal("expected the secret service not to be called")
+			return nil, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/orgs/0000000000000001/secrets", nil)
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), secretsAuthorizer(2)))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d for another organization's secrets, got %d", http.StatusForbidden, w.Code)
+	}
+}
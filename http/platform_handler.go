@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/influxdata/platform/kit/transport"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -11,20 +12,25 @@ import (
 type PlatformHandler struct {
 	AssetHandler *AssetHandler
 	APIHandler   http.Handler
+
+	// api is the APIHandler behind APIHandler's authentication, for its metrics.
+	api *APIHandler
 }
 
 func setCORSResponseHeaders(w http.ResponseWriter, r *http.Request) {
 	if origin := r.Header.Get("Origin"); origin != "" {
 		w.Header().Set("Access-Control-Allow-Origin", origin)
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, "+transport.AcceptVersionHeader)
+		w.Header().Set("Access-Control-Expose-Headers", transport.ContentVersionHeader)
 	}
 }
 
 // NewPlatformHandler returns a platform handler that serves the API and associated assets.
 func NewPlatformHandler(b *APIBackend) *PlatformHandler {
+	api := NewAPIHandler(b)
 	h := NewAuthenticationHandler()
-	h.Handler = NewAPIHandler(b)
+	h.Handler = api
 	h.AuthorizationService = b.AuthorizationService
 	h.SessionService = b.SessionService
 
@@ -37,6 +43,7 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	return &PlatformHandler{
 		AssetHandler: NewAssetHandler(),
 		APIHandler:   h,
+		api:          api,
 	}
 }
 
@@ -49,8 +56,8 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
 	// of the platform API.
-	if !strings.HasPrefix(r.URL.Path, "/v1") &&
-		!strings.HasPrefix(r.URL.Path, "/api/v2") &&
+	if version, _ := transport.PathVersion(r.URL.Path); version == "" &&
+		!strings.HasPrefix(r.URL.Path, "/v1") &&
 		!strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.AssetHandler.ServeHTTP(w, r)
 		return
@@ -61,6 +68,5 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // PrometheusCollectors satisfies the prom.PrometheusCollector interface.
 func (h *PlatformHandler) PrometheusCollectors() []prometheus.Collector {
-	// TODO: collect and return relevant metrics.
-	return nil
+	return h.api.PrometheusCollectors()
 }
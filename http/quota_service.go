@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	quotasPath   = "/api/v2/quotas"
+	quotasIDPath = "/api/v2/quotas/:orgID"
+)
+
+// quotaAdminPermission is the permission required to set and delete quotas.
+// Quotas bound what an organization may use of the server, so configuring them requires the operator's permission
+// to write organizations, which the token created at onboarding has.
+var quotaAdminPermission = platform.Permission{
+	Action:   platform.WriteAction,
+	Resource: platform.OrganizationResource,
+}
+
+// QuotaHandler serves the task quotas of organizations.
+type QuotaHandler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	QuotaStore backend.QuotaStore
+}
+
+// NewQuotaHandler returns a new instance of QuotaHandler.
+func NewQuotaHandler() *QuotaHandler {
+	h := &QuotaHandler{
+		Router: httprouter.New(),
+		Logger: zap.NewNop(),
+	}
+	h.HandlerFunc("GET", quotasIDPath, h.handleGetQuota)
+	h.HandlerFunc("PUT", quotasIDPath, h.handlePutQuota)
+	h.HandlerFunc("DELETE", quotasIDPath, h.handleDeleteQuota)
+	return h
+}
+
+// taskQuota is the JSON form of an organization's backend.TaskQuota, with durations as strings such as "1m30s".
+// Zero limits are no limit.
+type taskQuota struct {
+	OrgID                 platform.ID       `json:"orgID"`
+	MaxTasks              int               `json:"maxTasks"`
+	MaxHighFrequencyTasks int               `json:"maxHighFrequencyTasks"`
+	HighFrequencyInterval string            `json:"highFrequencyInterval,omitempty"`
+	MaxRunsPerSecond      float64           `json:"maxRunsPerSecond"`
+	MaxRunDuration        string            `json:"maxRunDuration,omitempty"`
+	MaxRunHistory         int               `json:"maxRunHistory"`
+	Links                 map[string]string `json:"links"`
+}
+
+func newTaskQuota(org platform.ID, q backend.TaskQuota) taskQuota {
+	res := taskQuota{
+		OrgID:                 org,
+		MaxTasks:              q.MaxTasks,
+		MaxHighFrequencyTasks: q.MaxHighFrequencyTasks,
+		MaxRunsPerSecond:      q.MaxRunsPerSecond,
+		MaxRunHistory:         q.MaxRunHistory,
+		Links: map[string]string{
+			"self": fmt.Sprintf("%s/%s", quotasPath, org),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", org),
+		},
+	}
+	if q.HighFrequencyInterval > 0 {
+		res.HighFrequencyInterval = q.HighFrequencyInterval.String()
+	}
+	if q.MaxRunDuration > 0 {
+		res.MaxRunDuration = q.MaxRunDuration.String()
+	}
+	return res
+}
+
+// quota returns the backend.TaskQuota that q describes.
+func (q taskQuota) quota() (backend.TaskQuota, error) {
+	tq := backend.TaskQuota{
+		MaxTasks:              q.MaxTasks,
+		MaxHighFrequencyTasks: q.MaxHighFrequencyTasks,
+		MaxRunsPerSecond:      q.MaxRunsPerSecond,
+		MaxRunHistory:         q.MaxRunHistory,
+	}
+
+	durations := []struct {
+		field string
+		s     string
+		dst   *time.Duration
+	}{
+		{field: "highFrequencyInterval", s: q.HighFrequencyInterval, dst: &tq.HighFrequencyInterval},
+		{field: "maxRunDuration", s: q.MaxRunDuration, dst: &tq.MaxRunDuration},
+	}
+	for _, d := range durations {
+		if d.s == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.s)
+		if err != nil {
+			return tq, errors.MalformedDataf("invalid %s: %v", d.field, err)
+		}
+		*d.dst = v
+	}
+
+	if err := tq.Validate(); err != nil {
+		return tq, errors.InvalidDataf("%v", err)
+	}
+	return tq, nil
+}
+
+// handleGetQuota is the HTTP handler for the GET /api/v2/quotas/:orgID route.
+// Members of the organization who may read its tasks may read its quota.
+func (h *QuotaHandler) handleGetQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	org, err := decodeQuotaOrgID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := authorizeQuota(ctx, platform.TaskPermission(platform.ReadAction, org), quotaAdminPermission); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	q, err := h.QuotaStore.TaskQuota(ctx, org)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskQuota(org, q)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePutQuota is the HTTP handler for the PUT /api/v2/quotas/:orgID route.
+// It replaces the organization's quota; limits left out of the request are no limit.
+func (h *QuotaHandler) handlePutQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	org, err := decodeQuotaOrgID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := authorizeQuota(ctx, quotaAdminPermission); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var req taskQuota
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		EncodeError(ctx, errors.MalformedDataf("invalid quota: %v", err), w)
+		return
+	}
+	q, err := req.quota()
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.QuotaStore.SetTaskQuota(ctx, org, q); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	h.Logger.Info("Task quota set", zap.String("org_id", org.String()))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskQuota(org, q)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleDeleteQuota is the HTTP handler for the DELETE /api/v2/quotas/:orgID route.
+// The organization has the server's default quota again.
+func (h *QuotaHandler) handleDeleteQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	org, err := decodeQuotaOrgID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := authorizeQuota(ctx, quotaAdminPermission); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	deleted, err := h.QuotaStore.DeleteTaskQuota(ctx, org)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if !deleted {
+		EncodeError(ctx, &platform.Error{Code: platform.ENotFound, Msg: "organization has no quota of its own"}, w)
+		return
+	}
+	h.Logger.Info("Task quota deleted", zap.String("org_id", org.String()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeQuotaOrgID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	var org platform.ID
+	if err := org.DecodeFromString(params.ByName("orgID")); err != nil {
+		return org, errors.MalformedDataf("invalid orgID: %v", err)
+	}
+	return org, nil
+}
+
+// authorizeQuota returns an error unless the request's authorizer has one of perms.
+func authorizeQuota(ctx context.Context, perms ...platform.Permission) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range perms {
+		if a.Allowed(p) {
+			return nil
+		}
+	}
+	return errors.Forbiddenf("insufficient permissions for the organization's quota")
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// fakeQuotaStore is a backend.QuotaStore that holds quotas in a map, with no limit by default.
+type fakeQuotaStore struct {
+	quotas map[platform.ID]backend.TaskQuota
+}
+
+func (s *fakeQuotaStore) TaskQuota(ctx context.Context, org platform.ID) (backend.TaskQuota, error) {
+	return s.quotas[org], nil
+}
+
+func (s *fakeQuotaStore) SetTaskQuota(ctx context.Context, org platform.ID, q backend.TaskQuota) error {
+	s.quotas[org] = q
+	return nil
+}
+
+func (s *fakeQuotaStore) DeleteTaskQuota(ctx context.Context, org platform.ID) (bool, error) {
+	_, ok := s.quotas[org]
+	delete(s.quotas, org)
+	return ok, nil
+}
+
+func TestQuotaHandler(t *testing.T) {
+	org := platform.ID(2)
+	store := &fakeQuotaStore{quotas: map[platform.ID]backend.TaskQuota{}}
+	h := NewQuotaHandler()
+	h.QuotaStore = store
+
+	operator := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{quotaAdminPermission}}
+	member := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{platform.TaskPermission(platform.ReadAction, org)}}
+	other := &platform.Authorization{Status: platform.Active, Permissions: []platform.Permission{platform.TaskPermission(platform.ReadAction, platform.ID(3))}}
+
+	for _, tt := range []struct {
+		name   string
+		auth   *platform.Authorization
+		method string
+		body   string
+		code   int
+	}{
+		{name: "member cannot set", auth: member, method: "PUT", body: `{"maxTasks": 5}`, code: http.StatusForbidden},
+		{name: "invalid duration", auth: operator, method: "PUT", body: `{"maxRunDuration": "soon"}`, code: http.StatusBadRequest},
+		{name: "negative limit", auth: operator, method: "PUT", body: `{"maxTasks": -1}`, code: http.StatusUnprocessableEntity},
+		{name: "set", auth: operator, method: "PUT", body: `{"maxTasks": 5, "maxRunsPerSecond": 0.5, "maxRunDuration": "1m", "maxRunHistory": 10}`, code: http.StatusOK},
+		{name: "member reads", auth: member, method: "GET", code: http.StatusOK},
+		{name: "other org cannot read", auth: other, method: "GET", code: http.StatusForbidden},
+		{name: "delete", auth: operator, method: "DELETE", code: http.StatusNoContent},
+		{name: "delete again", auth: operator, method: "DELETE", code: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, quotasPath+"/"+org.String(), bytes.NewBufferString(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), tt.auth))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+			var res taskQuota
+			if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			q, err := res.quota()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := backend.TaskQuota{MaxTasks: 5, MaxRunsPerSecond: 0.5, MaxRunDuration: time.Minute, MaxRunHistory: 10}
+			if q != want || res.OrgID != org {
+				t.Fatalf("expected quota %+v of org %s, got %+v of org %s", want, org, q, res.OrgID)
+			}
+		})
+	}
+}
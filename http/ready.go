@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/influxdata/platform/toml"
@@ -13,16 +14,45 @@ var up = time.Now()
 
 // ReadyHandler is a default readiness handler. The default behaviour is always ready.
 func ReadyHandler(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
+	writeReadyStatus(w, nil)
+}
+
+// NewReadyHandler returns a readiness handler that is ready once every check returns true,
+// such as a task coordinator that has yet to claim its tasks at startup.
+// Until then, it responds with 503 Service Unavailable and a status of "not ready",
+// listing the names of the checks that are not ready, so that load balancers hold back traffic.
+func NewReadyHandler(checks map[string]func() bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var waiting []string
+		for name, ready := range checks {
+			if !ready() {
+				waiting = append(waiting, name)
+			}
+		}
+		sort.Strings(waiting)
+		writeReadyStatus(w, waiting)
+	})
+}
 
+// writeReadyStatus writes the readiness of the process, which is ready unless some checks are waiting.
+func writeReadyStatus(w http.ResponseWriter, waiting []string) {
 	var status = struct {
-		Status string        `json:"status"`
-		Start  time.Time     `json:"started"`
-		Up     toml.Duration `json:"up"`
+		Status  string        `json:"status"`
+		Start   time.Time     `json:"started"`
+		Up      toml.Duration `json:"up"`
+		Waiting []string      `json:"waiting,omitempty"`
 	}{
-		Status: "ready",
-		Start:  up,
-		Up:     toml.Duration(time.Since(up)),
+		Status:  "ready",
+		Start:   up,
+		Up:      toml.Duration(time.Since(up)),
+		Waiting: waiting,
+	}
+
+	if len(waiting) > 0 {
+		status.Status = "not ready"
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
 	}
 
 	enc := json.NewEncoder(w)
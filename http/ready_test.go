@@ -0,0 +1,43 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewReadyHandler(t *testing.T) {
+	tasksReady := false
+	h := NewReadyHandler(map[string]func() bool{
+		"tasks":   func() bool { return tasksReady },
+		"storage": func() bool { return true },
+	})
+
+	check := func(code int, status string, waiting []string) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", ReadyPath, nil))
+		if w.Code != code {
+			t.Fatalf("expected status code %d, got %d", code, w.Code)
+		}
+
+		var res struct {
+			Status  string   `json:"status"`
+			Waiting []string `json:"waiting"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != status || !reflect.DeepEqual(res.Waiting, waiting) {
+			t.Fatalf("expected status %q waiting on %v, got %q waiting on %v", status, waiting, res.Status, res.Waiting)
+		}
+	}
+
+	check(http.StatusServiceUnavailable, "not ready", []string{"tasks"})
+
+	tasksReady = true
+	check(http.StatusOK, "ready", nil)
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	platcontext "github.com/influxdata/platform/context"
+)
+
+// RequestIDHeader carries the ID of an API request, in both the request and its response.
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLength is the longest request ID accepted from a client.
+const maxRequestIDLength = 64
+
+// NewRequestIDHandler returns a middleware that gives each request an ID, before passing it to next.
+// A request that already carries a valid ID in its X-Request-Id header, such as one set by a proxy, keeps it;
+// any other is given a new random ID.
+// The ID is set on the request's context, where platcontext.GetRequestID finds it,
+// and in the X-Request-Id header of the response, so that a client can correlate its call with the logs and audit entries it caused.
+func NewRequestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(platcontext.SetRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID reports whether id is short and made of printable ASCII, so that it is safe to log and echo back.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit ID, hex-encoded.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms; an empty ID only loses correlation.
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
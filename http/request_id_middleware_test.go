@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	platcontext "github.com/influxdata/platform/context"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	var got string
+	h := NewRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = platcontext.GetRequestID(r.Context())
+	}))
+
+	t.Run("generated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/tasks", nil))
+		if len(got) != 32 {
+			t.Fatalf("expected a generated 32 character ID, got %q", got)
+		}
+		if hdr := w.Header().Get(RequestIDHeader); hdr != got {
+			t.Fatalf("expected response header %q, got %q", got, hdr)
+		}
+	})
+
+	t.Run("propagated", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/tasks", nil)
+		r.Header.Set(RequestIDHeader, "proxy-1234")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if got != "proxy-1234" || w.Header().Get(RequestIDHeader) != "proxy-1234" {
+			t.Fatalf("expected the request's ID to be kept, got %q in context and %q in response", got, w.Header().Get(RequestIDHeader))
+		}
+	})
+
+	t.Run("invalid replaced", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/tasks", nil)
+		r.Header.Set(RequestIDHeader, "has spaces\n")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		if got == "has spaces\n" || len(got) != 32 {
+			t.Fatalf("expected an invalid ID to be replaced, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// DebugSchedulerPath exposes the internal state of the task scheduler over /debug/tasks/scheduler.
+const DebugSchedulerPath = DebugPath + "/tasks/scheduler"
+
+// NewSchedulerDebugHandler returns a handler that reports the internal state of s as JSON:
+// the tasks it has claimed, when each is next due, how many manual runs each has queued, and the runs it is executing.
+// The optional task query parameter limits the report to the task with the given ID.
+func NewSchedulerDebugHandler(s backend.SchedulerInspector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var taskID platform.ID
+		if id := r.URL.Query().Get("task"); id != "" {
+			if err := taskID.DecodeFromString(id); err != nil {
+				EncodeError(ctx, &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  "invalid task id",
+					Err:  err,
+				}, w)
+				return
+			}
+		}
+
+		if err := encodeResponse(ctx, w, http.StatusOK, newSchedulerStateResponse(s.State(), taskID)); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	})
+}
+
+type schedulerStateResponse struct {
+	Now      time.Time           `json:"now"`
+	Draining bool                `json:"draining"`
+	Tasks    []taskStateResponse `json:"tasks"`
+}
+
+type taskStateResponse struct {
+	ID             string               `json:"id"`
+	OrganizationID string               `json:"orgID"`
+	NextDue        *time.Time           `json:"nextDue,omitempty"` // Nil if the task has no more scheduled runs.
+	QueuedRuns     int                  `json:"queuedRuns"`
+	MaxConcurrency int                  `json:"maxConcurrency"`
+	Working        int                  `json:"working"`
+	Stopped        bool                 `json:"stopped"`
+	Running        []runningRunResponse `json:"running"`
+}
+
+type runningRunResponse struct {
+	ID           string     `json:"id"`
+	ScheduledFor time.Time  `json:"scheduledFor"`
+	RequestedAt  *time.Time `json:"requestedAt,omitempty"`
+	RetryOf      string     `json:"retryOf,omitempty"`
+	TraceID      string     `json:"traceID,omitempty"`
+	StartedAt    time.Time  `json:"startedAt"`
+}
+
+// newSchedulerStateResponse returns the response for state, limited to the task with the given ID if it is valid.
+func newSchedulerStateResponse(state backend.SchedulerState, taskID platform.ID) schedulerStateResponse {
+	res := schedulerStateResponse{
+		Now:      time.Unix(state.Now, 0).UTC(),
+		Draining: state.Draining,
+		Tasks:    []taskStateResponse{},
+	}
+
+	for _, ts := range state.Tasks {
+		if taskID.Valid() && ts.TaskID != taskID {
+			continue
+		}
+
+		tr := taskStateResponse{
+			ID:             ts.TaskID.String(),
+			OrganizationID: ts.Org.String(),
+			QueuedRuns:     ts.QueuedRuns,
+			MaxConcurrency: ts.MaxConcurrency,
+			Working:        ts.Working,
+			Stopped:        ts.Stopped,
+			Running:        make([]runningRunResponse, 0, len(ts.Running)),
+		}
+		if ts.NextDue != backend.NeverDue {
+			next := time.Unix(ts.NextDue, 0).UTC()
+			tr.NextDue = &next
+		}
+
+		for _, run := range ts.Running {
+			rr := runningRunResponse{
+				ID:           run.RunID.String(),
+				ScheduledFor: time.Unix(run.Now, 0).UTC(),
+				RetryOf:      run.RetryOf.String(),
+				TraceID:      run.TraceID,
+				StartedAt:    run.StartedAt.UTC(),
+			}
+			if run.RequestedAt != 0 {
+				requested := time.Unix(run.RequestedAt, 0).UTC()
+				rr.RequestedAt = &requested
+			}
+			tr.Running = append(tr.Running, rr)
+		}
+
+		res.Tasks = append(res.Tasks, tr)
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+type fakeSchedulerInspector backend.SchedulerState
+
+func (f fakeSchedulerInspector) State() backend.SchedulerState {
+	return backend.SchedulerState(f)
+}
+
+func TestSchedulerDebugHandler(t *testing.T) {
+	started := time.Unix(125, 0)
+	h := NewSchedulerDebugHandler(fakeSchedulerInspector{
+		Now: 130,
+		Tasks: []backend.TaskState{
+			{
+				TaskID:         platform.ID(1),
+				Org:            platform.ID(2),
+				NextDue:        180,
+				MaxConcurrency: 1,
+				Running: []backend.RunningRun{
+					{QueuedRun: backend.QueuedRun{TaskID: platform.ID(1), RunID: platform.ID(3), Now: 120}, StartedAt: started},
+				},
+			},
+			{TaskID: platform.ID(4), Org: platform.ID(2), NextDue: backend.NeverDue, QueuedRuns: 2, MaxConcurrency: 1},
+		},
+	})
+
+	get := func(query string) schedulerStateResponse {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", DebugSchedulerPath+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var res schedulerStateResponse
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	res := get("")
+	if len(res.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %#v", res.Tasks)
+	}
+	if tr := res.Tasks[0]; tr.NextDue == nil || tr.NextDue.Unix() != 180 || len(tr.Running) != 1 || tr.Running[0].ID != platform.ID(3).String() || tr.Running[0].ScheduledFor.Unix() != 120 {
+		t.Fatalf("unexpected first task %#v", tr)
+	}
+	if tr := res.Tasks[1]; tr.NextDue != nil || tr.QueuedRuns != 2 || len(tr.Running) != 0 {
+		t.Fatalf("unexpected second task %#v", tr)
+	}
+
+	res = get("?task=" + platform.ID(4).String())
+	if len(res.Tasks) != 1 || res.Tasks[0].ID != platform.ID(4).String() {
+		t.Fatalf("expected only task %s, got %#v", platform.ID(4), res.Tasks)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", DebugSchedulerPath+"?task=oops", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code %d for invalid task id, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -1,21 +1,25 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/platform"
 	pcontext "github.com/influxdata/platform/context"
 	kerrors "github.com/influxdata/platform/kit/errors"
 	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 )
@@ -25,6 +29,9 @@ type TaskHandler struct {
 	*httprouter.Router
 	logger *zap.Logger
 
+	// How often to check for new log lines when following a run's log.
+	logPollInterval time.Duration
+
 	TaskService                platform.TaskService
 	AuthorizationService       platform.AuthorizationService
 	OrganizationService        platform.OrganizationService
@@ -37,6 +44,7 @@ const (
 	tasksPath              = "/api/v2/tasks"
 	tasksIDPath            = "/api/v2/tasks/:tid"
 	tasksIDLogsPath        = "/api/v2/tasks/:tid/logs"
+	tasksIDStatsPath       = "/api/v2/tasks/:tid/stats"
 	tasksIDMembersPath     = "/api/v2/tasks/:tid/members"
 	tasksIDMembersIDPath   = "/api/v2/tasks/:tid/members/:userID"
 	tasksIDOwnersPath      = "/api/v2/tasks/:tid/owners"
@@ -47,6 +55,9 @@ const (
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:tid/runs/:rid/retry"
 	tasksIDLabelsPath      = "/api/v2/tasks/:tid/labels"
 	tasksIDLabelsNamePath  = "/api/v2/tasks/:tid/labels/:name"
+
+	// tasksBundlePath is outside of tasksPath, so that it does not conflict with tasksIDPath.
+	tasksBundlePath = "/api/v2/tasks-bundle"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -55,6 +66,8 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 		logger: logger,
 		Router: httprouter.New(),
 
+		logPollInterval: time.Second,
+
 		UserResourceMappingService: mappingService,
 		LabelService:               labelService,
 	}
@@ -62,6 +75,9 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("GET", tasksPath, h.handleGetTasks)
 	h.HandlerFunc("POST", tasksPath, h.handlePostTask)
 
+	h.HandlerFunc("GET", tasksBundlePath, h.handleExportTasks)
+	h.HandlerFunc("POST", tasksBundlePath, h.handleImportTasks)
+
 	h.HandlerFunc("GET", tasksIDPath, h.handleGetTask)
 	h.HandlerFunc("PATCH", tasksIDPath, h.handleUpdateTask)
 	h.HandlerFunc("DELETE", tasksIDPath, h.handleDeleteTask)
@@ -69,6 +85,8 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
 
+	h.HandlerFunc("GET", tasksIDStatsPath, h.handleGetTaskStats)
+
 	h.HandlerFunc("POST", tasksIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, h.UserService, platform.TaskResourceType, platform.Member))
 	h.HandlerFunc("GET", tasksIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, h.UserService, platform.TaskResourceType, platform.Member))
 	h.HandlerFunc("DELETE", tasksIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
@@ -78,6 +96,7 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, labelSer
 	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
 	h.HandlerFunc("GET", tasksIDRunsPath, h.handleGetRuns)
+	h.HandlerFunc("POST", tasksIDRunsPath, h.handleForceRun)
 	h.HandlerFunc("GET", tasksIDRunsIDPath, h.handleGetRun)
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
@@ -102,16 +121,44 @@ func newTaskResponse(t platform.Task) taskResponse {
 			"owners":  fmt.Sprintf("/api/v2/tasks/%s/owners", t.ID),
 			"runs":    fmt.Sprintf("/api/v2/tasks/%s/runs", t.ID),
 			"logs":    fmt.Sprintf("/api/v2/tasks/%s/logs", t.ID),
+			"stats":   fmt.Sprintf("/api/v2/tasks/%s/stats", t.ID),
 		},
 		Task: t,
 	}
 }
 
+// taskEventResponse is the data of each server-sent event streamed when watching tasks.
+type taskEventResponse struct {
+	Type   platform.TaskEventType `json:"type"`
+	TaskID platform.ID            `json:"taskID"`
+	Task   *taskResponse          `json:"task,omitempty"`
+}
+
+func newTaskEventResponse(ev platform.TaskEvent) taskEventResponse {
+	resp := taskEventResponse{Type: ev.Type, TaskID: ev.TaskID}
+	if ev.Task != nil {
+		tr := newTaskResponse(*ev.Task)
+		resp.Task = &tr
+	}
+	return resp
+}
+
 type tasksResponse struct {
 	Links map[string]string `json:"links"`
 	Tasks []taskResponse    `json:"tasks"`
 }
 
+// nextPageLink returns the link to the page of results following the result with the given ID,
+// keeping the other query parameters of the current page.
+func nextPageLink(p string, qp url.Values, last platform.ID) string {
+	next := url.Values{}
+	for k, v := range qp {
+		next[k] = v
+	}
+	next.Set("after", last.String())
+	return p + "?" + next.Encode()
+}
+
 func newTasksResponse(ts []*platform.Task) tasksResponse {
 	// TODO: impl paging links
 	/*
@@ -155,14 +202,18 @@ type runResponse struct {
 }
 
 func newRunResponse(r platform.Run) runResponse {
+	links := map[string]string{
+		"self":  fmt.Sprintf("/api/v2/tasks/%s/runs/%s", r.TaskID, r.ID),
+		"task":  fmt.Sprintf("/api/v2/tasks/%s", r.TaskID),
+		"logs":  fmt.Sprintf("/api/v2/tasks/%s/runs/%s/logs", r.TaskID, r.ID),
+		"retry": fmt.Sprintf("/api/v2/tasks/%s/runs/%s/retry", r.TaskID, r.ID),
+	}
+	if r.RetryOf.Valid() {
+		links["retryOf"] = fmt.Sprintf("/api/v2/tasks/%s/runs/%s", r.TaskID, r.RetryOf)
+	}
 	return runResponse{
-		Links: map[string]string{
-			"self":  fmt.Sprintf("/api/v2/tasks/%s/runs/%s", r.TaskID, r.ID),
-			"task":  fmt.Sprintf("/api/v2/tasks/%s", r.TaskID),
-			"logs":  fmt.Sprintf("/api/v2/tasks/%s/runs/%s/logs", r.TaskID, r.ID),
-			"retry": fmt.Sprintf("/api/v2/tasks/%s/runs/%s/retry", r.TaskID, r.ID),
-		},
-		Run: r,
+		Links: links,
+		Run:   r,
 	}
 }
 
@@ -196,13 +247,23 @@ func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.watch {
+		h.watchTasks(w, r, req.filter)
+		return
+	}
+
 	tasks, _, err := h.TaskService.FindTasks(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, newTasksResponse(tasks)); err != nil {
+	resp := newTasksResponse(tasks)
+	if req.filter.Limit > 0 && len(tasks) == req.filter.Limit {
+		resp.Links["next"] = nextPageLink(tasksPath, r.URL.Query(), tasks[len(tasks)-1].ID)
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
@@ -210,6 +271,9 @@ func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 
 type getTasksRequest struct {
 	filter platform.TaskFilter
+
+	// Whether to stream changes to the matching tasks, rather than list them.
+	watch bool
 }
 
 func decodeGetTasksRequest(ctx context.Context, r *http.Request) (*getTasksRequest, error) {
@@ -253,12 +317,31 @@ func decodeGetTasksRequest(ctx context.Context, r *http.Request) (*getTasksReque
 		req.filter.Limit = platform.TaskDefaultPageSize
 	}
 
+	// Each label selector is given as key:value.
+	for _, label := range qp["label"] {
+		i := strings.Index(label, ":")
+		if i < 1 {
+			return nil, kerrors.InvalidDataf("label selector %q must be formatted as key:value", label)
+		}
+		if req.filter.Labels == nil {
+			req.filter.Labels = make(map[string]string)
+		}
+		req.filter.Labels[label[:i]] = label[i+1:]
+	}
+
+	req.watch = qp.Get("watch") == "true"
+
 	return req, nil
 }
 
 func (h *TaskHandler) handlePostTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if r.URL.Query().Get("dryRun") == "true" {
+		h.handleDryRunTask(w, r)
+		return
+	}
+
 	auth, err := pcontext.GetAuthorizer(ctx)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -302,17 +385,135 @@ type postTaskRequest struct {
 	Task *platform.Task
 }
 
+// IdempotencyKeyHeader is the header of a request to create a task that holds the task's idempotency key.
+// Retrying a request with the same key returns the task created by the first request, rather than creating another task.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 func decodePostTaskRequest(ctx context.Context, r *http.Request) (*postTaskRequest, error) {
 	task := &platform.Task{}
 	if err := json.NewDecoder(r.Body).Decode(task); err != nil {
 		return nil, err
 	}
+	task.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
 
 	return &postTaskRequest{
 		Task: task,
 	}, nil
 }
 
+const (
+	defaultDryRunRuns = 5
+	maxDryRunRuns     = 100
+)
+
+type dryRunTaskError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+type dryRunTaskResponse struct {
+	Valid    bool              `json:"valid"`
+	Errors   []dryRunTaskError `json:"errors,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Cron     string            `json:"cron,omitempty"`
+	Every    string            `json:"every,omitempty"`
+	Offset   string            `json:"offset,omitempty"`
+	NextRuns []string          `json:"nextRuns,omitempty"`
+}
+
+func newDryRunTaskError(err error) dryRunTaskError {
+	e := dryRunTaskError{Message: err.Error()}
+	if line, col, ok := options.ErrorPosition(err); ok {
+		e.Line, e.Column = line, col
+	}
+	return e
+}
+
+// handleDryRunTask validates the script of the posted task without creating it,
+// and reports the times of the task's next scheduled runs.
+func (h *TaskHandler) handleDryRunTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeDryRunTaskRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	resp := dryRunTaskResponse{}
+	opts, err := options.FromScript(req.Script)
+	if err != nil {
+		resp.Errors = append(resp.Errors, newDryRunTaskError(err))
+		if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+			EncodeError(ctx, err, w)
+		}
+		return
+	}
+
+	resp.Valid = true
+	resp.Name = opts.Name
+	resp.Cron = opts.Cron
+	if opts.Every != 0 {
+		resp.Every = opts.Every.String()
+	}
+	if opts.Offset != 0 {
+		resp.Offset = opts.Offset.String()
+	}
+
+	stm := backend.NewStoreTaskMeta(backend.CreateTaskRequest{ScheduleAfter: time.Now().Unix()}, opts)
+	runs, err := stm.NextScheduledRuns(req.Runs)
+	if err != nil {
+		resp.Valid = false
+		resp.Errors = append(resp.Errors, newDryRunTaskError(err))
+	}
+	for _, run := range runs {
+		resp.NextRuns = append(resp.NextRuns, time.Unix(run, 0).UTC().Format(time.RFC3339))
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type dryRunTaskRequest struct {
+	Script string
+	Runs   int
+}
+
+func decodeDryRunTaskRequest(ctx context.Context, r *http.Request) (*dryRunTaskRequest, error) {
+	task := &platform.Task{}
+	if err := json.NewDecoder(r.Body).Decode(task); err != nil {
+		return nil, err
+	}
+
+	req := &dryRunTaskRequest{
+		Script: task.Flux,
+		Runs:   defaultDryRunRuns,
+	}
+
+	if runs := r.URL.Query().Get("runs"); runs != "" {
+		n, err := strconv.Atoi(runs)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "runs must be an integer",
+				Err:  err,
+			}
+		}
+		if n < 0 || n > maxDryRunRuns {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("runs must be between 0 and %d", maxDryRunRuns),
+			}
+		}
+		req.Runs = n
+	}
+
+	return req, nil
+}
+
 func (h *TaskHandler) handleGetTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -357,6 +558,120 @@ func decodeGetTaskRequest(ctx context.Context, r *http.Request) (*getTaskRequest
 	return req, nil
 }
 
+type taskStatsResponse struct {
+	Links map[string]string `json:"links"`
+	platform.TaskStats
+}
+
+func newTaskStatsResponse(s platform.TaskStats) taskStatsResponse {
+	return taskStatsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/stats", s.TaskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", s.TaskID),
+		},
+		TaskStats: s,
+	}
+}
+
+func (h *TaskHandler) handleGetTaskStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTaskRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	stats, err := h.TaskService.FindTaskStats(ctx, req.TaskID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskStatsResponse(*stats)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleExportTasks writes all of the tasks of an organization as a platform.TaskBundle.
+func (h *TaskHandler) handleExportTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeTasksBundleOrg(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskService.ExportTasks(ctx, orgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, b); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleImportTasks creates the tasks of the posted platform.TaskBundle in an organization,
+// owned by the requesting user.
+func (h *TaskHandler) handleImportTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	orgID, err := decodeTasksBundleOrg(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var b platform.TaskBundle
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if b.Version != platform.TaskBundleVersion {
+		EncodeError(ctx, kerrors.InvalidDataf("unsupported task bundle version %d", b.Version), w)
+		return
+	}
+
+	tasks, err := h.TaskService.ImportTasks(ctx, orgID, auth.GetUserID(), &b)
+	if err != nil {
+		if e, ok := err.(AuthzError); ok {
+			h.logger.Error("failed authentication", zap.Errors("error messages", []error{err, e.AuthzError()}))
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newTasksResponse(tasks)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// decodeTasksBundleOrg returns the organization given in the query of a request to tasksBundlePath.
+func decodeTasksBundleOrg(r *http.Request) (platform.ID, error) {
+	orgID := r.URL.Query().Get("organization")
+	if orgID == "" {
+		return platform.InvalidID(), kerrors.InvalidDataf("you must provide an organization ID")
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(orgID); err != nil {
+		return platform.InvalidID(), err
+	}
+	return id, nil
+}
+
 func (h *TaskHandler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -453,6 +768,11 @@ func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.follow {
+		h.followRunLog(w, r, req.filter)
+		return
+	}
+
 	logs, _, err := h.TaskService.FindLogs(ctx, req.filter)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -465,8 +785,132 @@ func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// followRunLog streams the log of the run matching filter as server-sent events,
+// sending each log line as a message as it is written.
+// Once the run finishes, followRunLog sends an "end" event with the run's final status and returns.
+func (h *TaskHandler) followRunLog(w http.ResponseWriter, r *http.Request, filter platform.LogFilter) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "streaming is not supported by this connection",
+		}, w)
+		return
+	}
+
+	// Check that the run exists before committing to a streaming response.
+	if _, err := h.TaskService.FindRunByID(ctx, *filter.Task, *filter.Run); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.logPollInterval)
+	defer ticker.Stop()
+
+	// Only ask for the lines written since the last read, rather than rereading the whole log.
+	sent := 0
+	for {
+		// Check whether the run has finished before reading the log,
+		// so that the last read includes every line written before the run finished.
+		run, err := h.TaskService.FindRunByID(ctx, *filter.Task, *filter.Run)
+		if err != nil {
+			writeServerSentEvent(w, "error", err.Error())
+			flusher.Flush()
+			return
+		}
+		finished := backend.IsFinishedRunStatus(run.Status)
+
+		filter.Offset = sent
+		logs, _, err := h.TaskService.FindLogs(ctx, filter)
+		if err != nil {
+			writeServerSentEvent(w, "error", err.Error())
+			flusher.Flush()
+			return
+		}
+
+		for _, l := range logs {
+			if l == nil || *l == "" {
+				continue
+			}
+			for _, line := range strings.Split(string(*l), "\n") {
+				writeServerSentEvent(w, "", line)
+				sent++
+			}
+		}
+
+		if finished {
+			writeServerSentEvent(w, "end", run.Status)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchTasks streams the changes to tasks matching filter as server-sent events, until the client disconnects.
+// Each event's type is the kind of change, "created", "updated", or "deleted", and its data is a taskEventResponse.
+func (h *TaskHandler) watchTasks(w http.ResponseWriter, r *http.Request, filter platform.TaskFilter) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "streaming is not supported by this connection",
+		}, w)
+		return
+	}
+
+	events, err := h.TaskService.WatchTasks(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for ev := range events {
+		b, err := json.Marshal(newTaskEventResponse(ev))
+		if err != nil {
+			writeServerSentEvent(w, "error", err.Error())
+			flusher.Flush()
+			return
+		}
+		writeServerSentEvent(w, string(ev.Type), string(b))
+		flusher.Flush()
+	}
+}
+
+// writeServerSentEvent writes a single server-sent event with the given event type and data.
+// If event is empty, the event has the default "message" type.
+func writeServerSentEvent(w io.Writer, event, data string) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
 type getLogsRequest struct {
 	filter platform.LogFilter
+
+	// Whether to stream the run's log until the run finishes.
+	follow bool
 }
 
 func decodeGetLogsRequest(ctx context.Context, r *http.Request, orgs platform.OrganizationService) (*getLogsRequest, error) {
@@ -508,6 +952,24 @@ func decodeGetLogsRequest(ctx context.Context, r *http.Request, orgs platform.Or
 		req.filter.Run = id
 	}
 
+	if offset := qp.Get("offset"); offset != "" {
+		if req.filter.Run == nil {
+			return nil, kerrors.InvalidDataf("offset is only supported for the logs of a single run")
+		}
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return nil, kerrors.InvalidDataf("offset must be a non-negative integer")
+		}
+		req.filter.Offset = n
+	}
+
+	if qp.Get("follow") == "true" {
+		if req.filter.Run == nil {
+			return nil, kerrors.InvalidDataf("follow is only supported for the logs of a single run")
+		}
+		req.follow = true
+	}
+
 	return req, nil
 }
 
@@ -526,7 +988,15 @@ func (h *TaskHandler) handleGetRuns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := encodeResponse(ctx, w, http.StatusOK, newRunsResponse(runs, *req.filter.Task)); err != nil {
+	resp := newRunsResponse(runs, *req.filter.Task)
+	if req.filter.Limit > 0 && len(runs) == req.filter.Limit {
+		// The next page starts after the last run returned, so it no longer skips any runs.
+		qp := r.URL.Query()
+		qp.Del("offset")
+		resp.Links["next"] = nextPageLink(taskIDRunsPath(*req.filter.Task), qp, runs[len(runs)-1].ID)
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
 		EncodeError(ctx, err, w)
 		return
 	}
@@ -581,11 +1051,13 @@ func decodeGetRunsRequest(ctx context.Context, r *http.Request, orgs platform.Or
 			return nil, err
 		}
 
-		if i < 1 || i > 100 {
-			return nil, kerrors.InvalidDataf("limit must be between 1 and 100")
+		if i < 1 || i > platform.RunMaxPageSize {
+			return nil, kerrors.InvalidDataf("limit must be between 1 and %d", platform.RunMaxPageSize)
 		}
 
 		req.filter.Limit = i
+	} else {
+		req.filter.Limit = platform.RunDefaultPageSize
 	}
 
 	var at, bt string
@@ -610,6 +1082,26 @@ func decodeGetRunsRequest(ctx context.Context, r *http.Request, orgs platform.Or
 		return nil, kerrors.InvalidDataf("beforeTime must be later than afterTime")
 	}
 
+	if status := qp.Get("status"); status != "" {
+		if !backend.IsRunStatus(status) {
+			return nil, kerrors.InvalidDataf("invalid run status %q", status)
+		}
+		req.filter.Status = status
+	}
+
+	if offset := qp.Get("offset"); offset != "" {
+		i, err := strconv.Atoi(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		if i < 0 {
+			return nil, kerrors.InvalidDataf("offset must not be negative")
+		}
+
+		req.filter.Offset = i
+	}
+
 	return req, nil
 }
 
@@ -731,6 +1223,70 @@ func (h *TaskHandler) handleRetryRun(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *TaskHandler) handleForceRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeForceRunRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	run, err := h.TaskService.ForceRun(ctx, req.TaskID, req.ScheduledFor)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newRunResponse(*run)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type forceRunRequest struct {
+	TaskID       platform.ID
+	ScheduledFor int64
+}
+
+// forceRunBody is the body of a request to run a task now. ScheduledFor is an RFC3339 time, and defaults to now.
+type forceRunBody struct {
+	ScheduledFor string `json:"scheduledFor"`
+}
+
+func decodeForceRunRequest(ctx context.Context, r *http.Request) (*forceRunRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var body forceRunBody
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+			return nil, kerrors.InvalidDataf("invalid request body: %v", err)
+		}
+	}
+
+	scheduledFor := time.Now().Unix()
+	if body.ScheduledFor != "" {
+		t, err := time.Parse(time.RFC3339, body.ScheduledFor)
+		if err != nil {
+			return nil, kerrors.InvalidDataf("scheduledFor must be an RFC3339 time: %v", err)
+		}
+		scheduledFor = t.Unix()
+	}
+
+	return &forceRunRequest{
+		TaskID:       ti,
+		ScheduledFor: scheduledFor,
+	}, nil
+}
+
 type retryRunRequest struct {
 	RunID, TaskID platform.ID
 }
@@ -812,16 +1368,10 @@ func (t TaskService) FindTasks(ctx context.Context, filter platform.TaskFilter)
 		return nil, 0, err
 	}
 
-	val := url.Values{}
+	val := taskFilterValues(filter)
 	if filter.After != nil {
 		val.Add("after", filter.After.String())
 	}
-	if filter.Organization != nil {
-		val.Add("organization", filter.Organization.String())
-	}
-	if filter.User != nil {
-		val.Add("user", filter.User.String())
-	}
 	if filter.Limit != 0 {
 		val.Add("limit", strconv.Itoa(filter.Limit))
 	}
@@ -875,6 +1425,9 @@ func (t TaskService) CreateTask(ctx context.Context, tsk *platform.Task) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if tsk.IdempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, tsk.IdempotencyKey)
+	}
 	SetToken(t.Token, req)
 
 	hc := newClient(u.Scheme, t.InsecureSkipVerify)
@@ -985,6 +1538,9 @@ func (t TaskService) FindLogs(ctx context.Context, filter platform.LogFilter) ([
 	if filter.Org != nil {
 		val.Set("orgID", filter.Org.String())
 	}
+	if filter.Run != nil && filter.Offset > 0 {
+		val.Set("offset", strconv.Itoa(filter.Offset))
+	}
 	u.RawQuery = val.Encode()
 
 	req, err := http.NewRequest("GET", u.String(), nil)
@@ -1031,6 +1587,21 @@ func (t TaskService) FindRuns(ctx context.Context, filter platform.RunFilter) ([
 	if filter.After != nil {
 		val.Set("after", filter.After.String())
 	}
+	if filter.Limit != 0 {
+		val.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.AfterTime != "" {
+		val.Set("afterTime", filter.AfterTime)
+	}
+	if filter.BeforeTime != "" {
+		val.Set("beforeTime", filter.BeforeTime)
+	}
+	if filter.Status != "" {
+		val.Set("status", filter.Status)
+	}
+	if filter.Offset != 0 {
+		val.Set("offset", strconv.Itoa(filter.Offset))
+	}
 	u.RawQuery = val.Encode()
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
@@ -1138,6 +1709,55 @@ func (t TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*
 			return nil, *e
 		}
 
+		if err.Error() == backend.ErrRunRateLimited.Error() {
+			return nil, backend.ErrRunRateLimited
+		}
+
+		return nil, err
+	}
+
+	rs := &runResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(rs); err != nil {
+		return nil, err
+	}
+	return &rs.Run, nil
+}
+
+// ForceRun creates and returns a new run of the task, for its first schedule at or after scheduledFor, to start now.
+func (t TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	u, err := newURL(t.Addr, path.Join(taskIDPath(taskID), "runs"))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(forceRunBody{ScheduledFor: time.Unix(scheduledFor, 0).UTC().Format(time.RFC3339)})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		if err.Error() == backend.ErrTaskNotFound.Error() {
+			return nil, backend.ErrTaskNotFound
+		}
+		if err.Error() == backend.ErrRunRateLimited.Error() {
+			return nil, backend.ErrRunRateLimited
+		}
+		if e := backend.ParseRetryAlreadyQueuedError(err.Error()); e != nil {
+			return nil, *e
+		}
 		return nil, err
 	}
 
@@ -1148,6 +1768,118 @@ func (t TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*
 	return &rs.Run, nil
 }
 
+// FindTaskStats returns statistics about the latest runs of a task.
+func (t TaskService) FindTaskStats(ctx context.Context, taskID platform.ID) (*platform.TaskStats, error) {
+	u, err := newURL(t.Addr, path.Join(taskIDPath(taskID), "stats"))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		if err.Error() == backend.ErrTaskNotFound.Error() {
+			return nil, backend.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	var sr taskStatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+		return nil, err
+	}
+	return &sr.TaskStats, nil
+}
+
+// ExportTasks returns all of the tasks of an organization as a bundle.
+func (t TaskService) ExportTasks(ctx context.Context, orgID platform.ID) (*platform.TaskBundle, error) {
+	u, err := newURL(t.Addr, tasksBundlePath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"organization": []string{orgID.String()}}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var b platform.TaskBundle
+	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ImportTasks creates the tasks of a bundle in an organization, and returns the created tasks.
+// The tasks are owned by the user of the token; userID is ignored.
+// If importing fails, no tasks are returned, although some tasks may have been created.
+func (t TaskService) ImportTasks(ctx context.Context, orgID, userID platform.ID, bundle *platform.TaskBundle) ([]*platform.Task, error) {
+	u, err := newURL(t.Addr, tasksBundlePath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = url.Values{"organization": []string{orgID.String()}}.Encode()
+
+	bundleBytes, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(bundleBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var tr tasksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*platform.Task, len(tr.Tasks))
+	for i := range tr.Tasks {
+		tasks[i] = &tr.Tasks[i].Task
+	}
+	return tasks, nil
+}
+
 func cancelPath(taskID, runID platform.ID) string {
 	return path.Join(taskID.String(), runID.String())
 }
@@ -1180,6 +1912,86 @@ func (t TaskService) CancelRun(ctx context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+// WatchTasks streams the changes to tasks matching filter from the server, until ctx is done.
+// The returned channel is also closed if the connection to the server ends.
+func (t TaskService) WatchTasks(ctx context.Context, filter platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+	u, err := newURL(t.Addr, tasksPath)
+	if err != nil {
+		return nil, err
+	}
+
+	val := taskFilterValues(filter)
+	val.Set("watch", "true")
+	u.RawQuery = val.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckError(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	events := make(chan platform.TaskEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		// Each event is a "data: " line holding a taskEventResponse, ended by a blank line.
+		// The event's type is repeated in the data, so "event: " lines are skipped.
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			var er taskEventResponse
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &er); err != nil {
+				// The server reported an error, and will end the stream.
+				continue
+			}
+			ev := platform.TaskEvent{Type: er.Type, TaskID: er.TaskID}
+			if er.Task != nil {
+				ev.Task = &er.Task.Task
+			}
+
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// taskFilterValues returns the query parameters selecting the organization, user, and labels of filter.
+func taskFilterValues(filter platform.TaskFilter) url.Values {
+	val := url.Values{}
+	if filter.Organization != nil {
+		val.Add("organization", filter.Organization.String())
+	}
+	if filter.User != nil {
+		val.Add("user", filter.User.String())
+	}
+	for k, v := range filter.Labels {
+		val.Add("label", k+":"+v)
+	}
+	return val
+}
+
 func taskIDPath(id platform.ID) string {
 	return path.Join(tasksPath, id.String())
 }
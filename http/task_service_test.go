@@ -8,7 +8,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 	pcontext "github.com/influxdata/platform/context"
@@ -71,7 +74,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "owners": "/api/v2/tasks/0000000000000001/owners",
         "members": "/api/v2/tasks/0000000000000001/members",
         "runs": "/api/v2/tasks/0000000000000001/runs",
-        "logs": "/api/v2/tasks/0000000000000001/logs"
+        "logs": "/api/v2/tasks/0000000000000001/logs",
+        "stats": "/api/v2/tasks/0000000000000001/stats"
       },
       "id": "0000000000000001",
       "name": "task1",
@@ -89,7 +93,8 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
         "owners": "/api/v2/tasks/0000000000000002/owners",
         "members": "/api/v2/tasks/0000000000000002/members",
         "runs": "/api/v2/tasks/0000000000000002/runs",
-        "logs": "/api/v2/tasks/0000000000000002/logs"
+        "logs": "/api/v2/tasks/0000000000000002/logs",
+        "stats": "/api/v2/tasks/0000000000000002/stats"
       },
       "id": "0000000000000002",
       "name": "task2",
@@ -133,6 +138,284 @@ func TestTaskHandler_handleGetTasks(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_handleGetTasksNextPage(t *testing.T) {
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.TaskService = &mock.TaskService{
+		FindTasksFn: func(ctx context.Context, f platform.TaskFilter) ([]*platform.Task, int, error) {
+			tasks := []*platform.Task{{ID: 1, Name: "task1"}, {ID: 2, Name: "task2"}}
+			if len(tasks) > f.Limit {
+				tasks = tasks[:f.Limit]
+			}
+			return tasks, len(tasks), nil
+		},
+	}
+
+	get := func(t *testing.T, target string) tasksResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		h.handleGetTasks(w, httptest.NewRequest("GET", target, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp tasksResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := get(t, "http://any.url/api/v2/tasks?limit=1&organization=0000000000000001")
+	if exp := "/api/v2/tasks?after=0000000000000001&limit=1&organization=0000000000000001"; resp.Links["next"] != exp {
+		t.Fatalf("expected next link %q, got %q", exp, resp.Links["next"])
+	}
+
+	resp = get(t, "http://any.url/api/v2/tasks?limit=5")
+	if next, ok := resp.Links["next"]; ok {
+		t.Fatalf("expected no next link on a partial page, got %q", next)
+	}
+}
+
+func TestTaskHandler_decodeGetTasksRequestLabels(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/tasks?label=kind:downsampling&label=team:storage", nil)
+	req, err := decodeGetTasksRequest(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.filter.Labels) != 2 || req.filter.Labels["kind"] != "downsampling" || req.filter.Labels["team"] != "storage" {
+		t.Fatalf("unexpected label selector: %v", req.filter.Labels)
+	}
+
+	r = httptest.NewRequest("GET", "http://any.url/api/v2/tasks?label=downsampling", nil)
+	if _, err := decodeGetTasksRequest(context.Background(), r); err == nil {
+		t.Fatal("expected error for label selector without a value")
+	}
+}
+
+func TestTaskHandler_handleDryRunTask(t *testing.T) {
+	h := &TaskHandler{logger: logger.New(os.Stdout)}
+
+	post := func(t *testing.T, flux string) dryRunTaskResponse {
+		t.Helper()
+		b, err := json.Marshal(platform.Task{Flux: flux})
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest("POST", "http://any.url/api/v2/tasks?dryRun=true&runs=3", bytes.NewReader(b))
+		w := httptest.NewRecorder()
+		h.handlePostTask(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp dryRunTaskResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		resp := post(t, `option task = {name: "my_task", every: 1m}
+from(bucket:"b") |> range(start:-1h)`)
+		if !resp.Valid || len(resp.Errors) != 0 {
+			t.Fatalf("expected valid script, got errors %v", resp.Errors)
+		}
+		if resp.Name != "my_task" || resp.Every != "1m0s" {
+			t.Fatalf("unexpected options in response: %+v", resp)
+		}
+		if len(resp.NextRuns) != 3 {
+			t.Fatalf("expected 3 next runs, got %v", resp.NextRuns)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := post(t, `option task = {name: "my_task"}
+from(bucket:"b") |> range(start:-1h)`)
+		if resp.Valid || len(resp.Errors) == 0 {
+			t.Fatalf("expected invalid script, got %+v", resp)
+		}
+		if len(resp.NextRuns) != 0 {
+			t.Fatalf("expected no next runs for invalid script, got %v", resp.NextRuns)
+		}
+	})
+}
+
+func TestTaskHandler_followRunLog(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	returned := 0
+	logs := []string{"first"}
+	status := "started"
+
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.logPollInterval = time.Millisecond
+	h.TaskService = &mock.TaskService{
+		FindRunByIDFn: func(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			// Each poll, the run writes another line, and finishes on the third poll.
+			polls++
+			switch polls {
+			case 2:
+				logs = append(logs, "second")
+			case 3:
+				logs = append(logs, "third")
+				status = "success"
+			}
+			return &platform.Run{ID: runID, TaskID: taskID, Status: status}, nil
+		},
+		FindLogsFn: func(ctx context.Context, f platform.LogFilter) ([]*platform.Log, int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			// The handler must only ask for the lines it hasn't sent yet.
+			if f.Offset != returned {
+				t.Errorf("expected offset %d, got %d", returned, f.Offset)
+			}
+			returned = len(logs)
+			l := platform.Log(strings.Join(logs[f.Offset:], "\n"))
+			return []*platform.Log{&l}, 1, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/tasks/0000000000000001/runs/0000000000000002/logs?follow=true", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	exp := "data: first\n\ndata: second\n\ndata: third\n\nevent: end\ndata: success\n\n"
+	if got := w.Body.String(); got != exp {
+		t.Fatalf("unexpected event stream:\n%s\nwant:\n%s", got, exp)
+	}
+}
+
+func TestTaskHandler_watchTasks(t *testing.T) {
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.TaskService = &mock.TaskService{
+		WatchTasksFn: func(ctx context.Context, f platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+			if f.Organization == nil || *f.Organization != 3 {
+				t.Errorf("expected watch of organization 3, got filter %+v", f)
+			}
+			events := make(chan platform.TaskEvent, 2)
+			events <- platform.TaskEvent{Type: platform.TaskCreated, TaskID: 1, Task: &platform.Task{ID: 1, Organization: 3, Name: "a"}}
+			events <- platform.TaskEvent{Type: platform.TaskDeleted, TaskID: 2}
+			close(events)
+			return events, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/tasks?organization=0000000000000003&watch=true", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	events := strings.Split(strings.TrimSuffix(w.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got:\n%s", w.Body.String())
+	}
+	if !strings.HasPrefix(events[0], "event: created\ndata: ") || !strings.Contains(events[0], `"self":"/api/v2/tasks/0000000000000001"`) {
+		t.Fatalf("unexpected creation event:\n%s", events[0])
+	}
+	if exp := `event: deleted` + "\n" + `data: {"type":"deleted","taskID":"0000000000000002"}`; events[1] != exp {
+		t.Fatalf("unexpected deletion event:\n%s\nwant:\n%s", events[1], exp)
+	}
+}
+
+func TestTaskHandler_followRequiresRun(t *testing.T) {
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.TaskService = &mock.TaskService{}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/tasks/0000000000000001/logs?follow=true", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code == http.StatusOK {
+		t.Fatal("expected following a task's logs without a run to fail")
+	}
+}
+
+func TestTaskHandler_handleGetTaskStats(t *testing.T) {
+	h := NewTaskHandler(mock.NewUserResourceMappingService(), mock.NewLabelService(), logger.New(os.Stdout))
+	h.TaskService = &mock.TaskService{
+		FindTaskStatsFn: func(ctx context.Context, taskID platform.ID) (*platform.TaskStats, error) {
+			return &platform.TaskStats{
+				TaskID:          taskID,
+				Runs:            4,
+				Succeeded:       3,
+				Failed:          1,
+				SuccessRate:     0.75,
+				AverageDuration: 1.5,
+				MedianDuration:  1,
+				P95Duration:     3,
+				LastFailure: &platform.TaskRunFailure{
+					RunID:      2,
+					FinishedAt: "2018-12-01T17:00:13Z",
+					Error:      "bucket not found",
+				},
+				Usage: platform.TaskUsage{
+					Runs:           10,
+					ExecutionTime:  12.5,
+					RowsWritten:    300,
+					MemoryBytes:    40960,
+					MaxMemoryBytes: 8192,
+				},
+			}, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/tasks/0000000000000001/stats", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, res.StatusCode, body)
+	}
+
+	const exp = `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/stats",
+    "task": "/api/v2/tasks/0000000000000001"
+  },
+  "taskID": "0000000000000001",
+  "runs": 4,
+  "succeeded": 3,
+  "failed": 1,
+  "successRate": 0.75,
+  "averageDuration": 1.5,
+  "medianDuration": 1,
+  "p95Duration": 3,
+  "lastFailure": {
+    "runID": "0000000000000002",
+    "finishedAt": "2018-12-01T17:00:13Z",
+    "error": "bucket not found"
+  },
+  "usage": {
+    "runs": 10,
+    "executionTime": 12.5,
+    "rowsWritten": 300,
+    "memoryBytes": 40960,
+    "maxMemoryBytes": 8192
+  }
+}
+`
+	if eq, _ := jsonEqual(string(body), exp); !eq {
+		t.Fatalf("unexpected body:\n%s\nwant:\n%s", body, exp)
+	}
+}
+
 func TestTaskHandler_handlePostTasks(t *testing.T) {
 	type args struct {
 		task platform.Task
@@ -182,7 +465,8 @@ func TestTaskHandler_handlePostTasks(t *testing.T) {
     "owners": "/api/v2/tasks/0000000000000001/owners",
     "members": "/api/v2/tasks/0000000000000001/members",
     "runs": "/api/v2/tasks/0000000000000001/runs",
-    "logs": "/api/v2/tasks/0000000000000001/logs"
+    "logs": "/api/v2/tasks/0000000000000001/logs",
+    "stats": "/api/v2/tasks/0000000000000001/stats"
   },
   "id": "0000000000000001",
   "name": "task1",
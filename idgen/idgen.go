@@ -0,0 +1,112 @@
+// Package idgen provides the implementations of platform.IDGenerator the platform can be configured with:
+// snowflake IDs, the default; ULID-style IDs, for deployments of several nodes whose clocks may drift;
+// and sequential IDs, for embedded and test deployments that need reproducible IDs.
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/snowflake"
+)
+
+// Kinds of generator, as selected by New.
+const (
+	Snowflake = "snowflake"
+	ULID      = "ulid"
+	Sequence  = "sequence"
+)
+
+// Kinds lists the kinds of generator New accepts.
+var Kinds = []string{Snowflake, ULID, Sequence}
+
+// New returns a generator of the given kind, one of Kinds.
+func New(kind string) (platform.IDGenerator, error) {
+	switch kind {
+	case Snowflake, "":
+		return snowflake.NewDefaultIDGenerator(), nil
+	case ULID:
+		return NewULIDGenerator(), nil
+	case Sequence:
+		return NewSequenceGenerator(1), nil
+	default:
+		return nil, fmt.Errorf("unknown ID generator %q; expected one of %v", kind, Kinds)
+	}
+}
+
+const (
+	// ulidRandomBits is the number of low bits of a ULID-style ID that are random. The rest hold the time.
+	ulidRandomBits = 22
+	ulidRandomMask = 1<<ulidRandomBits - 1
+)
+
+// ulidEpoch is the time from which ULID-style IDs count milliseconds.
+// The 42 bits of the time last until 2157.
+var ulidEpoch = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ULIDGenerator generates IDs in the manner of ULIDs, fit to 64 bits: the milliseconds since ulidEpoch in the high 42 bits,
+// and random low bits. The IDs sort by the time they were generated.
+//
+// Unlike snowflake IDs, they need no machine ID, so nodes need no coordination to generate distinct IDs,
+// and they are monotonic: an ID generated in the same millisecond as the last, or after the clock has stepped back,
+// is the last ID plus one, rather than an ID that may repeat one already generated.
+type ULIDGenerator struct {
+	now func() time.Time
+
+	mu   sync.Mutex
+	last uint64
+}
+
+// NewULIDGenerator returns a ULIDGenerator.
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+// ID returns the next ID.
+func (g *ULIDGenerator) ID() platform.ID {
+	ms := uint64(g.now().Sub(ulidEpoch) / time.Millisecond)
+	id := ms<<ulidRandomBits | randomBits()&ulidRandomMask
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if ms <= g.last>>ulidRandomBits {
+		// Within the last ID's millisecond, or before it: count up from the last ID, carrying into its time.
+		id = g.last + 1
+	}
+	g.last = id
+	return platform.ID(id)
+}
+
+// randomBits returns 64 random bits, from the system's secure source.
+func randomBits() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// The system's source does not fail in practice; the monotonic counter keeps IDs distinct regardless.
+		return 0
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// SequenceGenerator generates sequential IDs, so that a deployment that starts from the same state generates the same IDs.
+// It suits tests and single-process embedded deployments; IDs from two generators with the same start collide.
+type SequenceGenerator struct {
+	next uint64
+}
+
+// NewSequenceGenerator returns a SequenceGenerator whose first ID is start. A start of zero, which is not a valid ID, starts at 1.
+func NewSequenceGenerator(start platform.ID) *SequenceGenerator {
+	if start == 0 {
+		start = 1
+	}
+	return &SequenceGenerator{next: uint64(start) - 1}
+}
+
+// ID returns the next ID.
+func (g *SequenceGenerator) ID() platform.ID {
+	return platform.ID(atomic.AddUint64(&g.next, 1))
+}
@@ -0,0 +1,56 @@
+package idgen_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/idgen"
+)
+
+func TestULIDGenerator_Monotonic(t *testing.T) {
+	g := idgen.NewULIDGenerator()
+
+	var last platform.ID
+	for i := 0; i < 10000; i++ {
+		id := g.ID()
+		if !id.Valid() {
+			t.Fatalf("generated invalid ID %d", id)
+		}
+		if id <= last {
+			t.Fatalf("expected IDs to increase, got %s after %s", id, last)
+		}
+		last = id
+	}
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	g := idgen.NewSequenceGenerator(0)
+	for want := platform.ID(1); want <= 3; want++ {
+		if got := g.ID(); got != want {
+			t.Fatalf("expected ID %s, got %s", want, got)
+		}
+	}
+
+	// Generators with the same start generate the same IDs.
+	a, b := idgen.NewSequenceGenerator(100), idgen.NewSequenceGenerator(100)
+	for i := 0; i < 3; i++ {
+		if x, y := a.ID(), b.ID(); x != y {
+			t.Fatalf("expected reproducible IDs, got %s and %s", x, y)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, kind := range idgen.Kinds {
+		g, err := idgen.New(kind)
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if id := g.ID(); !id.Valid() {
+			t.Fatalf("%s: generated invalid ID %d", kind, id)
+		}
+	}
+	if _, err := idgen.New("uuid"); err == nil {
+		t.Fatal("expected an error for an unknown kind")
+	}
+}
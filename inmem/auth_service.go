@@ -2,6 +2,7 @@ package inmem
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/platform"
 )
@@ -89,7 +90,7 @@ func (s *Service) FindAuthorizationByToken(ctx context.Context, t string) (*plat
 	return as[0], nil
 }
 
-func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
+func filterAuthorizationsFn(filter platform.AuthorizationFilter, now time.Time) func(a *platform.Authorization) bool {
 	if filter.ID != nil {
 		return func(a *platform.Authorization) bool {
 			return a.ID == *filter.ID
@@ -98,7 +99,7 @@ func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platfor
 
 	if filter.Token != nil {
 		return func(a *platform.Authorization) bool {
-			return a.Token == *filter.Token
+			return a.AcceptsToken(*filter.Token, now)
 		}
 	}
 
@@ -138,7 +139,7 @@ func (s *Service) FindAuthorizations(ctx context.Context, filter platform.Author
 		filter.UserID = &u.ID
 	}
 	var err error
-	filterF := filterAuthorizationsFn(filter)
+	filterF := filterAuthorizationsFn(filter, s.time())
 	s.authorizationKV.Range(func(k, v interface{}) bool {
 		a, ok := v.(platform.Authorization)
 		if !ok {
@@ -191,6 +192,7 @@ func (s *Service) CreateAuthorization(ctx context.Context, a *platform.Authoriza
 			Op:  op,
 		}
 	}
+	a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
 	a.ID = s.IDGenerator.ID()
 	a.Status = platform.Active
 	return s.PutAuthorization(ctx, a)
@@ -233,3 +235,34 @@ func (s *Service) SetAuthorizationStatus(ctx context.Context, id platform.ID, st
 	a.Status = status
 	return s.PutAuthorization(ctx, a)
 }
+
+// RotateAuthorization gives the authorization associated with id a new token, keeping its replaced token for grace.
+func (s *Service) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	op := OpPrefix + platform.OpRotateAuthorization
+	a, err := s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  op,
+		}
+	}
+
+	token, err := s.TokenGenerator.Token()
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  op,
+		}
+	}
+
+	a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	if grace > 0 {
+		expiresAt := s.time().Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	}
+	a.Token = token
+	if err := s.PutAuthorization(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
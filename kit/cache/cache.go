@@ -0,0 +1,170 @@
+// Package cache provides a concurrent least-recently-used cache whose entries expire after a fixed time,
+// for keeping the results of store lookups in memory without letting them grow stale or unbounded.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures a Cache.
+type Option func(c *Cache)
+
+// WithClock sets the Clock that entries expire by, so that tests can expire them without sleeping.
+// If not set, the cache uses clock.System.
+func WithClock(clk clock.Clock) Option {
+	return func(c *Cache) {
+		c.clock = clk
+	}
+}
+
+// Cache holds up to a fixed number of entries, each for a fixed time after it is set.
+// Once full, setting a new entry evicts the least recently used one. It is safe for concurrent use.
+type Cache struct {
+	size  int
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[interface{}]*list.Element
+	order   *list.List // Of *entry, most recently used first.
+
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions prometheus.Counter
+	length    prometheus.GaugeFunc
+}
+
+type entry struct {
+	key     interface{}
+	value   interface{}
+	expires time.Time
+}
+
+// New returns a Cache of up to size entries, at least one, each kept for ttl after it is set.
+// The name identifies the cache in metrics, such as "tasks".
+func New(name string, size int, ttl time.Duration, opts ...Option) *Cache {
+	if size < 1 {
+		size = 1
+	}
+	labels := prometheus.Labels{"cache": name}
+	c := &Cache{
+		size:    size,
+		ttl:     ttl,
+		clock:   clock.System,
+		entries: make(map[interface{}]*list.Element),
+		order:   list.New(),
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "cache",
+			Name:        "hits_total",
+			Help:        "Number of lookups that found an unexpired entry in a cache.",
+			ConstLabels: labels,
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "cache",
+			Name:        "misses_total",
+			Help:        "Number of lookups that found no entry in a cache, or an expired one.",
+			ConstLabels: labels,
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "cache",
+			Name:        "evictions_total",
+			Help:        "Number of entries evicted from a full cache to make room for another.",
+			ConstLabels: labels,
+		}),
+	}
+	c.length = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   "cache",
+		Name:        "entries",
+		Help:        "Number of entries in a cache, including expired entries not yet removed.",
+		ConstLabels: labels,
+	}, func() float64 { return float64(c.Len()) })
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (c *Cache) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{c.hits, c.misses, c.evictions, c.length}
+}
+
+// Get returns the value set for key, and whether there was one that has not expired.
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses.Inc()
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !now.Before(e.expires) {
+		c.remove(el)
+		c.misses.Inc()
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	c.hits.Inc()
+	return e.value, true
+}
+
+// Set sets the value for key, replacing any value it had, until the cache's ttl passes.
+func (c *Cache) Set(key, value interface{}) {
+	expires := c.clock.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	for c.order.Len() >= c.size {
+		c.remove(c.order.Back())
+		c.evictions.Inc()
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expires: expires})
+}
+
+// Delete removes the value for key, if there is one.
+func (c *Cache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Purge removes every entry.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[interface{}]*list.Element)
+	c.order.Init()
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet removed.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry).key)
+}
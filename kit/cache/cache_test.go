@@ -0,0 +1,64 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/cache"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+)
+
+func TestCache(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1000, 0))
+	c := cache.New("test", 2, time.Minute, cache.WithClock(clk))
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(c)
+
+	expect := func(key string, exp interface{}) {
+		t.Helper()
+		v, ok := c.Get(key)
+		if exp == nil {
+			if ok {
+				t.Fatalf("expected no value for %s, got %v", key, v)
+			}
+			return
+		}
+		if !ok || v != exp {
+			t.Fatalf("expected %v for %s, got %v, %v", exp, key, v, ok)
+		}
+	}
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	expect("a", 1)
+
+	// The cache is full, so setting c evicts b, which was used less recently than a.
+	c.Set("c", 3)
+	expect("b", nil)
+	expect("a", 1)
+	expect("c", 3)
+
+	c.Delete("a")
+	expect("a", nil)
+
+	// Entries expire once the ttl passes.
+	clk.Advance(time.Minute)
+	expect("c", nil)
+	if n := c.Len(); n != 0 {
+		t.Fatalf("expected expired entries to be removed on lookup, got %d entries", n)
+	}
+
+	c.Set("d", 4)
+	c.Purge()
+	expect("d", nil)
+
+	mfs := promtest.MustGather(t, reg)
+	labels := map[string]string{"cache": "test"}
+	for name, exp := range map[string]float64{"cache_hits_total": 3, "cache_misses_total": 4, "cache_evictions_total": 1} {
+		if got := promtest.MustFindMetric(t, mfs, name, labels).GetCounter().GetValue(); got != exp {
+			t.Fatalf("expected %v for %s, got %v", exp, name, got)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// Package circuitbreaker stops calls to a dependency that keeps failing, so that it is given time to recover
+// instead of being hammered, and callers fail fast instead of waiting on it.
+//
+// A Breaker starts closed, passing calls through. After a run of consecutive failures it opens, refusing calls
+// with ErrOpen. Once its open timeout passes, it is half-open: it lets a few trial calls through,
+// and closes again if they succeed, or opens again if any fails.
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ErrOpen is returned by Do, without calling its function, while the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a Breaker.
+type State int
+
+const (
+	// Closed passes calls through.
+	Closed State = iota
+	// Open refuses calls.
+	Open
+	// HalfOpen passes a limited number of trial calls through.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Defaults of the options of a Breaker.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+	DefaultHalfOpenCalls    = 1
+)
+
+// Option configures a Breaker.
+type Option func(b *Breaker)
+
+// WithFailureThreshold sets the number of consecutive failures that open the breaker.
+func WithFailureThreshold(n int) Option {
+	return func(b *Breaker) {
+		if n > 0 {
+			b.threshold = n
+		}
+	}
+}
+
+// WithOpenTimeout sets how long the breaker stays open before letting trial calls through.
+func WithOpenTimeout(d time.Duration) Option {
+	return func(b *Breaker) {
+		if d > 0 {
+			b.openTimeout = d
+		}
+	}
+}
+
+// WithHalfOpenCalls sets how many trial calls the half-open breaker lets through at once,
+// all of which must succeed for it to close.
+func WithHalfOpenCalls(n int) Option {
+	return func(b *Breaker) {
+		if n > 0 {
+			b.halfOpenCalls = n
+		}
+	}
+}
+
+// WithIsFailure sets the function that decides which errors count as failures of the dependency.
+// Errors it rejects, such as a caller's invalid request, are returned without counting against the breaker.
+// By default, every non-nil error is a failure.
+func WithIsFailure(fn func(error) bool) Option {
+	return func(b *Breaker) {
+		b.isFailure = fn
+	}
+}
+
+// WithStateChange adds a function called after each change of state, such as to alert when a dependency is cut off.
+// It is called without the breaker's lock held, and must not block.
+func WithStateChange(fn func(name string, from, to State)) Option {
+	return func(b *Breaker) {
+		b.onChange = append(b.onChange, fn)
+	}
+}
+
+// WithClock sets the Clock the breaker tells the time with, so that tests can control the open timeout.
+// If not set, the breaker uses clock.System.
+func WithClock(c clock.Clock) Option {
+	return func(b *Breaker) {
+		b.clock = c
+	}
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	name          string
+	threshold     int
+	openTimeout   time.Duration
+	halfOpenCalls int
+	isFailure     func(error) bool
+	onChange      []func(name string, from, to State)
+	clock         clock.Clock
+
+	mu       sync.Mutex
+	state    State
+	failures int       // Consecutive failures while closed.
+	openedAt time.Time // When the breaker last opened.
+	trials   int       // Trial calls in flight while half-open.
+	pending  []change  // Changes of state made under the lock, to report once it is released.
+
+	logger      *zap.Logger
+	stateGauge  prometheus.Gauge
+	calls       *prometheus.CounterVec
+	transitions *prometheus.CounterVec
+}
+
+// New returns a closed Breaker. The name identifies the breaker in logs and metrics, such as "scheduler".
+func New(name string, opts ...Option) *Breaker {
+	labels := prometheus.Labels{"breaker": name}
+	b := &Breaker{
+		name:          name,
+		threshold:     DefaultFailureThreshold,
+		openTimeout:   DefaultOpenTimeout,
+		halfOpenCalls: DefaultHalfOpenCalls,
+		isFailure:     func(err error) bool { return err != nil },
+		clock:         clock.System,
+		logger:        zap.NewNop(),
+		stateGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "circuitbreaker",
+			Name:        "state",
+			Help:        "State of a circuit breaker: 0 closed, 1 open, 2 half-open.",
+			ConstLabels: labels,
+		}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "circuitbreaker",
+			Name:        "calls_total",
+			Help:        "Number of calls through a circuit breaker, split out by result: success, failure or rejected.",
+			ConstLabels: labels,
+		}, []string{"result"}),
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "circuitbreaker",
+			Name:        "transitions_total",
+			Help:        "Number of changes of state of a circuit breaker, split out by the state changed to.",
+			ConstLabels: labels,
+		}, []string{"state"}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithLogger sets the logger for the Breaker.
+// The logger reports each change of state.
+func (b *Breaker) WithLogger(l *zap.Logger) {
+	b.logger = l.With(zap.String("service", "circuitbreaker"), zap.String("breaker", b.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (b *Breaker) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{b.stateGauge, b.calls, b.transitions}
+}
+
+// State returns the breaker's current state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	s := b.currentState()
+	changes := b.takeChanges()
+	b.mu.Unlock()
+
+	b.notify(changes)
+	return s
+}
+
+// Do calls fn if the breaker allows it, and records its result. It returns ErrOpen without calling fn if not.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.record(err)
+	return err
+}
+
+// currentState returns the state, moving an open breaker whose timeout has passed to half-open.
+// b.mu must be held.
+func (b *Breaker) currentState() State {
+	if b.state == Open && b.clock.Now().Sub(b.openedAt) >= b.openTimeout {
+		b.setState(HalfOpen)
+	}
+	return b.state
+}
+
+// allow reports whether a call may proceed, reserving a trial call if the breaker is half-open.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	var err error
+	switch b.currentState() {
+	case Open:
+		err = ErrOpen
+	case HalfOpen:
+		if b.trials >= b.halfOpenCalls {
+			err = ErrOpen
+		} else {
+			b.trials++
+		}
+	}
+	changes := b.takeChanges()
+	b.mu.Unlock()
+
+	b.notify(changes)
+	if err != nil {
+		b.calls.WithLabelValues("rejected").Inc()
+	}
+	return err
+}
+
+// record records the result of a call that allow let through.
+func (b *Breaker) record(err error) {
+	failed := err != nil && b.isFailure(err)
+	if failed {
+		b.calls.WithLabelValues("failure").Inc()
+	} else {
+		b.calls.WithLabelValues("success").Inc()
+	}
+
+	b.mu.Lock()
+	switch b.state {
+	case Closed:
+		if !failed {
+			b.failures = 0
+			break
+		}
+		b.failures++
+		if b.failures >= b.threshold {
+			b.setState(Open)
+		}
+	case HalfOpen:
+		if b.trials > 0 {
+			// Calls let through before the breaker last opened reserved no trial.
+			b.trials--
+		}
+		if failed {
+			b.setState(Open)
+		} else if b.trials == 0 {
+			b.setState(Closed)
+		}
+	}
+	changes := b.takeChanges()
+	b.mu.Unlock()
+
+	b.notify(changes)
+}
+
+// change is a change of state, waiting to be reported once the lock is released.
+type change struct {
+	from, to State
+}
+
+// setState changes the state, resetting the counts of the state left. b.mu must be held.
+func (b *Breaker) setState(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.failures = 0
+	b.trials = 0
+	if to == Open {
+		b.openedAt = b.clock.Now()
+	}
+	b.pending = append(b.pending, change{from: from, to: to})
+}
+
+// takeChanges returns and clears the changes of state made since it was last called. b.mu must be held.
+func (b *Breaker) takeChanges() []change {
+	cs := b.pending
+	b.pending = nil
+	return cs
+}
+
+// notify reports changes of state to the metrics, the log and the state change functions.
+func (b *Breaker) notify(changes []change) {
+	for _, c := range changes {
+		b.stateGauge.Set(float64(c.to))
+		b.transitions.WithLabelValues(c.to.String()).Inc()
+		b.logger.Info("Circuit breaker changed state", zap.Stringer("from", c.from), zap.Stringer("to", c.to))
+		for _, fn := range b.onChange {
+			fn(b.name, c.from, c.to)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package circuitbreaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/circuitbreaker"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+func fail() error    { return errUnavailable }
+func succeed() error { return nil }
+
+func TestBreaker(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1000, 0))
+	var changes []string
+	b := circuitbreaker.New("test",
+		circuitbreaker.WithFailureThreshold(2),
+		circuitbreaker.WithOpenTimeout(time.Minute),
+		circuitbreaker.WithClock(clk),
+		circuitbreaker.WithStateChange(func(name string, from, to circuitbreaker.State) {
+			changes = append(changes, from.String()+"->"+to.String())
+		}),
+	)
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(b)
+
+	// A success resets the count of consecutive failures.
+	b.Do(fail)
+	b.Do(succeed)
+	b.Do(fail)
+	if s := b.State(); s != circuitbreaker.Closed {
+		t.Fatalf("expected closed after non-consecutive failures, got %s", s)
+	}
+
+	if err := b.Do(fail); err != errUnavailable {
+		t.Fatalf("expected the call's error, got %v", err)
+	}
+	if s := b.State(); s != circuitbreaker.Open {
+		t.Fatalf("expected open after consecutive failures, got %s", s)
+	}
+
+	called := false
+	if err := b.Do(func() error { called = true; return nil }); err != circuitbreaker.ErrOpen {
+		t.Fatalf("expected ErrOpen, got %v", err)
+	}
+	if called {
+		t.Fatal("expected the open breaker not to call the function")
+	}
+
+	// After the timeout, a failed trial opens the breaker again, and a successful one closes it.
+	clk.Advance(time.Minute)
+	if s := b.State(); s != circuitbreaker.HalfOpen {
+		t.Fatalf("expected half-open after the timeout, got %s", s)
+	}
+	b.Do(fail)
+	if s := b.State(); s != circuitbreaker.Open {
+		t.Fatalf("expected open after a failed trial, got %s", s)
+	}
+	clk.Advance(time.Minute)
+	if err := b.Do(succeed); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.State(); s != circuitbreaker.Closed {
+		t.Fatalf("expected closed after a successful trial, got %s", s)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(want) {
+		t.Fatalf("expected changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("expected changes %v, got %v", want, changes)
+		}
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	if got := promtest.MustFindMetric(t, mfs, "circuitbreaker_calls_total", map[string]string{"breaker": "test", "result": "rejected"}).GetCounter().GetValue(); got != 1 {
+		t.Fatalf("expected 1 rejected call, got %v", got)
+	}
+	if got := promtest.MustFindMetric(t, mfs, "circuitbreaker_state", map[string]string{"breaker": "test"}).GetGauge().GetValue(); got != float64(circuitbreaker.Closed) {
+		t.Fatalf("expected the state gauge to read closed, got %v", got)
+	}
+}
+
+func TestBreaker_IsFailure(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	b := circuitbreaker.New("test",
+		circuitbreaker.WithFailureThreshold(1),
+		circuitbreaker.WithIsFailure(func(err error) bool { return err != errInvalid }),
+	)
+
+	if err := b.Do(func() error { return errInvalid }); err != errInvalid {
+		t.Fatalf("expected the call's error, got %v", err)
+	}
+	if s := b.State(); s != circuitbreaker.Closed {
+		t.Fatalf("expected errors that are not failures to leave the breaker closed, got %s", s)
+	}
+}
+
+func TestBreaker_HalfOpenCalls(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1000, 0))
+	b := circuitbreaker.New("test",
+		circuitbreaker.WithFailureThreshold(1),
+		circuitbreaker.WithOpenTimeout(time.Second),
+		circuitbreaker.WithClock(clk),
+	)
+	b.Do(fail)
+	clk.Advance(time.Second)
+
+	// While the one trial call is in flight, others are rejected.
+	err := b.Do(func() error {
+		if err := b.Do(succeed); err != circuitbreaker.ErrOpen {
+			t.Errorf("expected a second trial call to be rejected, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b.State(); s != circuitbreaker.Closed {
+		t.Fatalf("expected closed after the trial call, got %s", s)
+	}
+}
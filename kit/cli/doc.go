@@ -7,6 +7,9 @@
 // In this example the flags can be set with MYPROGRAM_MONITOR_HOST and
 // MYPROGRAM_NUMBER or with the flags --monitor-host and --number
 //
+// Setting ConfigFlag on the Program also reads options from a TOML or YAML file,
+// with keys named after the flags. Flags override env vars, which override the file.
+//
 // var flags struct {
 // 	monitorHost string
 // 	number int
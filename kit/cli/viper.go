@@ -35,6 +35,14 @@ type Program struct {
 	Name string
 	// Opts are the command line/env var options to the program
 	Opts []Opt
+	// ConfigFlag, if set, is the name of a flag, which can also be set with an env var,
+	// giving the path of a TOML or YAML file of option values keyed by flag name.
+	// Values on the command line take precedence over env vars, which take precedence over the file,
+	// which takes precedence over the defaults.
+	ConfigFlag string
+	// Validate, if set, is invoked on execute once the options are set, before Run.
+	// If it returns an error, Run is not invoked.
+	Validate func() error
 }
 
 // NewCommand creates a new cobra command to be executed that respects env vars.
@@ -44,10 +52,24 @@ type Program struct {
 //
 // This is to simplify the viper/cobra boilerplate.
 func NewCommand(p *Program) *cobra.Command {
+	// binds set each option from viper, which resolves the precedence of flags, env vars, config file, and defaults.
+	var binds []func()
+
 	var cmd = &cobra.Command{
 		Use:  p.Name,
 		Args: cobra.NoArgs,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := readConfigFile(p.ConfigFlag); err != nil {
+				return err
+			}
+			for _, bind := range binds {
+				bind()
+			}
+			if p.Validate != nil {
+				if err := p.Validate(); err != nil {
+					return err
+				}
+			}
 			return p.Run()
 		},
 	}
@@ -57,7 +79,14 @@ func NewCommand(p *Program) *cobra.Command {
 	// This normalizes "-" to an underscore in env names.
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
+	if p.ConfigFlag != "" {
+		cmd.Flags().String(p.ConfigFlag, "", "path to a TOML or YAML file of option values, keyed by flag name")
+		viper.BindPFlag(p.ConfigFlag, cmd.Flags().Lookup(p.ConfigFlag))
+	}
+
 	for _, o := range p.Opts {
+		o := o
+		var bind func()
 		switch o.DestP.(type) {
 		case *string:
 			if o.Default == nil {
@@ -65,41 +94,61 @@ func NewCommand(p *Program) *cobra.Command {
 			}
 			cmd.Flags().StringVar(o.DestP.(*string), o.Flag, o.Default.(string), o.Desc)
 			viper.BindPFlag(o.Flag, cmd.Flags().Lookup(o.Flag))
-			*o.DestP.(*string) = viper.GetString(o.Flag)
+			bind = func() { *o.DestP.(*string) = viper.GetString(o.Flag) }
 		case *int:
 			if o.Default == nil {
 				o.Default = 0
 			}
 			cmd.Flags().IntVar(o.DestP.(*int), o.Flag, o.Default.(int), o.Desc)
 			viper.BindPFlag(o.Flag, cmd.Flags().Lookup(o.Flag))
-			*o.DestP.(*int) = viper.GetInt(o.Flag)
+			bind = func() { *o.DestP.(*int) = viper.GetInt(o.Flag) }
 		case *bool:
 			if o.Default == nil {
 				o.Default = false
 			}
 			cmd.Flags().BoolVar(o.DestP.(*bool), o.Flag, o.Default.(bool), o.Desc)
 			viper.BindPFlag(o.Flag, cmd.Flags().Lookup(o.Flag))
-			*o.DestP.(*bool) = viper.GetBool(o.Flag)
+			bind = func() { *o.DestP.(*bool) = viper.GetBool(o.Flag) }
 		case *time.Duration:
 			if o.Default == nil {
 				o.Default = time.Duration(0)
 			}
 			cmd.Flags().DurationVar(o.DestP.(*time.Duration), o.Flag, o.Default.(time.Duration), o.Desc)
 			viper.BindPFlag(o.Flag, cmd.Flags().Lookup(o.Flag))
-			*o.DestP.(*time.Duration) = viper.GetDuration(o.Flag)
+			bind = func() { *o.DestP.(*time.Duration) = viper.GetDuration(o.Flag) }
 		case *[]string:
 			if o.Default == nil {
 				o.Default = []string{}
 			}
 			cmd.Flags().StringSliceVar(o.DestP.(*[]string), o.Flag, o.Default.([]string), o.Desc)
 			viper.BindPFlag(o.Flag, cmd.Flags().Lookup(o.Flag))
-			*o.DestP.(*[]string) = viper.GetStringSlice(o.Flag)
+			bind = func() { *o.DestP.(*[]string) = viper.GetStringSlice(o.Flag) }
 		default:
 			// if you get a panic here, sorry about that!
 			// anyway, go ahead and make a PR and add another type.
 			panic(fmt.Errorf("unknown destination type %t", o.DestP))
 		}
+		bind()
+		binds = append(binds, bind)
 	}
 
 	return cmd
 }
+
+// readConfigFile reads into viper the config file named by the value of configFlag, if there is one.
+// The file's type is taken from its extension.
+func readConfigFile(configFlag string) error {
+	if configFlag == "" {
+		return nil
+	}
+	path := viper.GetString(configFlag)
+	if path == "" {
+		return nil
+	}
+
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"testing"
 	"time"
 )
 
@@ -69,3 +73,65 @@ func ExampleNewCommand() {
 	// 1m0s
 	// [foo bar]
 }
+
+func TestNewCommand_ConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.toml")
+	config := `host = "file-host"
+number = 5
+duration = "3s"
+`
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("CONFIGPROGRAM_NUMBER", "7")
+	defer os.Unsetenv("CONFIGPROGRAM_NUMBER")
+
+	var host string
+	var number int
+	var duration time.Duration
+	var validated bool
+	prog := &Program{
+		Run:        func() error { return nil },
+		Name:       "configprogram",
+		ConfigFlag: "config-path",
+		Opts: []Opt{
+			{DestP: &host, Flag: "host", Default: "default-host"},
+			{DestP: &number, Flag: "number", Default: 1},
+			{DestP: &duration, Flag: "duration", Default: time.Second},
+		},
+		Validate: func() error {
+			validated = true
+			if number > 6 {
+				return errors.New("number must be at most 6")
+			}
+			return nil
+		},
+	}
+
+	// The flag takes precedence over the file, the env var over the file, and the file over the default.
+	cmd := NewCommand(prog)
+	cmd.SetArgs([]string{"--config-path", path, "--duration", "4s"})
+	err = cmd.Execute()
+	if err == nil || err.Error() != "number must be at most 6" {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if !validated {
+		t.Fatal("expected options to be validated")
+	}
+	if host != "file-host" {
+		t.Errorf("expected host from config file, got %q", host)
+	}
+	if number != 7 {
+		t.Errorf("expected number from env var, got %d", number)
+	}
+	if duration != 4*time.Second {
+		t.Errorf("expected duration from flag, got %v", duration)
+	}
+}
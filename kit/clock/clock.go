@@ -0,0 +1,222 @@
+// Package clock provides the Clock that time-dependent subsystems tell the time and create their tickers and timers with,
+// so that tests and simulations can drive them with a Mock clock rather than sleeping.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time, and creates tickers and timers that follow it.
+// System is used unless another Clock is supplied, such as a Mock in tests or schedule simulations.
+type Clock interface {
+	Now() time.Time
+
+	// NewTicker returns a Ticker that sends the time on its channel every d.
+	NewTicker(d time.Duration) Ticker
+
+	// NewTimer returns a Timer that sends the time on its channel once d has passed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker is the interface of a time.Ticker created by a Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker. It does not close the channel.
+	Stop()
+}
+
+// Timer is the interface of a time.Timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It reports whether the call stopped the timer,
+	// false if the timer had already fired or been stopped. It does not close or drain the channel.
+	Stop() bool
+}
+
+// System is the Clock backed by the time package.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) Ticker { return systemTicker{t: time.NewTicker(d)} }
+
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{t: time.NewTimer(d)} }
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time { return t.t.C }
+
+func (t systemTicker) Stop() { t.t.Stop() }
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.t.C }
+
+func (t systemTimer) Stop() bool { return t.t.Stop() }
+
+// Mock is a Clock whose time only changes when it is advanced,
+// so that tests can deterministically drive time-dependent behavior without sleeping.
+type Mock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+// waiter is a ticker or timer of a Mock, which is told each time the clock moves.
+type waiter interface {
+	// fire delivers a tick if one is due at now, and reports whether the waiter is done, so the clock can drop it.
+	// It is called with the clock's mutex held.
+	fire(now time.Time) (done bool)
+}
+
+var _ Clock = (*Mock)(nil)
+
+// NewMock returns a Mock set to now.
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *Mock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a Ticker that ticks each time the clock is advanced past another multiple of d since the ticker was created.
+// Like a time.Ticker, it drops ticks for slow receivers.
+func (c *Mock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for Mock.NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &mockTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.waiters = append(c.waiters, t)
+	return t
+}
+
+// NewTimer returns a Timer that fires once the clock is advanced by at least d.
+// A timer for zero or less fires immediately.
+func (c *Mock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &mockTimer{clock: c, c: make(chan time.Time, 1), when: c.now.Add(d)}
+	if !t.fire(c.now) {
+		c.waiters = append(c.waiters, t)
+	}
+	return t
+}
+
+// Advance moves the clock forward by d, and delivers any ticks that became due.
+func (c *Mock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to t, and delivers any ticks that became due. The clock must not be moved backwards.
+func (c *Mock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.Before(c.now) {
+		panic("Mock clock cannot be moved backwards")
+	}
+	c.set(t)
+}
+
+func (c *Mock) set(t time.Time) {
+	c.now = t
+
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.fire(t) {
+			continue
+		}
+		waiters = append(waiters, w)
+	}
+	c.waiters = waiters
+}
+
+// mockTicker is a Ticker created by a Mock. Its fields other than c and period are protected by clock.mu.
+type mockTicker struct {
+	clock  *Mock
+	c      chan time.Time
+	period time.Duration
+
+	next    time.Time // When the next tick is due.
+	stopped bool
+}
+
+func (t *mockTicker) C() <-chan time.Time { return t.c }
+
+func (t *mockTicker) Stop() {
+	t.clock.mu.Lock()
+	t.stopped = true
+	t.clock.mu.Unlock()
+}
+
+// fire sends a tick for now if one is due, and schedules the next tick after now.
+func (t *mockTicker) fire(now time.Time) bool {
+	if t.stopped {
+		return true
+	}
+	if now.Before(t.next) {
+		return false
+	}
+	for !now.Before(t.next) {
+		t.next = t.next.Add(t.period)
+	}
+
+	select {
+	case t.c <- now:
+	default:
+	}
+	return false
+}
+
+// mockTimer is a Timer created by a Mock. Its fields other than c are protected by clock.mu.
+type mockTimer struct {
+	clock *Mock
+	c     chan time.Time
+	when  time.Time
+
+	done bool // Whether the timer fired or was stopped.
+}
+
+func (t *mockTimer) C() <-chan time.Time { return t.c }
+
+func (t *mockTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	stopped := !t.done
+	t.done = true
+	return stopped
+}
+
+// fire sends the time if the timer is due at now.
+func (t *mockTimer) fire(now time.Time) bool {
+	if t.done {
+		return true
+	}
+	if now.Before(t.when) {
+		return false
+	}
+	t.done = true
+	t.c <- now
+	return true
+}
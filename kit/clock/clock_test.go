@@ -0,0 +1,101 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+)
+
+func TestMock_Ticker(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := clock.NewMock(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("expected time %v, got %v", start, c.Now())
+	}
+
+	ticker := c.NewTicker(10 * time.Second)
+	expectTick := func(t *testing.T, exp time.Time) {
+		t.Helper()
+		select {
+		case got := <-ticker.C():
+			if !got.Equal(exp) {
+				t.Fatalf("expected tick at %v, got %v", exp, got)
+			}
+		default:
+			t.Fatalf("expected tick at %v, got none", exp)
+		}
+	}
+	expectNoTick := func(t *testing.T) {
+		t.Helper()
+		select {
+		case got := <-ticker.C():
+			t.Fatalf("expected no tick, got %v", got)
+		default:
+		}
+	}
+
+	c.Advance(9 * time.Second)
+	expectNoTick(t)
+
+	c.Advance(time.Second)
+	expectTick(t, start.Add(10*time.Second))
+
+	// Advancing past several periods delivers a single tick, like a time.Ticker with a slow receiver.
+	c.Advance(35 * time.Second)
+	expectTick(t, start.Add(45*time.Second))
+	expectNoTick(t)
+
+	// The next tick is due on the following period boundary.
+	c.Set(start.Add(50 * time.Second))
+	expectTick(t, start.Add(50*time.Second))
+
+	ticker.Stop()
+	c.Advance(time.Minute)
+	expectNoTick(t)
+}
+
+func TestMock_Timer(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := clock.NewMock(start)
+
+	timer := c.NewTimer(10 * time.Second)
+	c.Advance(9 * time.Second)
+	select {
+	case got := <-timer.C():
+		t.Fatalf("expected no fire, got %v", got)
+	default:
+	}
+
+	c.Advance(5 * time.Second)
+	select {
+	case got := <-timer.C():
+		if exp := start.Add(14 * time.Second); !got.Equal(exp) {
+			t.Fatalf("expected fire at %v, got %v", exp, got)
+		}
+	default:
+		t.Fatal("expected the timer to fire")
+	}
+	if timer.Stop() {
+		t.Fatal("expected Stop to report that the timer already fired")
+	}
+
+	// A stopped timer never fires.
+	timer = c.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Fatal("expected Stop to stop the timer")
+	}
+	c.Advance(time.Minute)
+	select {
+	case got := <-timer.C():
+		t.Fatalf("expected no fire after Stop, got %v", got)
+	default:
+	}
+
+	// A timer for no time fires immediately.
+	select {
+	case <-c.NewTimer(0).C():
+	default:
+		t.Fatal("expected a timer for no time to fire immediately")
+	}
+}
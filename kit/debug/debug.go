@@ -0,0 +1,159 @@
+// Package debug provides an http.Handler for the profiling and debug endpoints of a service,
+// protected by a token and a rate limit, so that they are safe to leave on in production.
+package debug
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform/kit/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// PprofPath is the path of the pprof index. Each profile is served under it, as by net/http/pprof.
+	PprofPath = "/debug/pprof/"
+	// TracePath is the path of the runtime trace endpoint. Its seconds parameter sets the length of the trace.
+	TracePath = "/debug/pprof/trace"
+	// HeapDumpPath is the path of the endpoint that responds with a dump of the heap, as written by runtime/debug.WriteHeapDump.
+	HeapDumpPath = "/debug/heapdump"
+
+	// tokenScheme is the scheme of the Authorization header that carries the token, as used by the platform's API.
+	tokenScheme = "Token "
+)
+
+// Handler serves the pprof, runtime trace and heap dump endpoints.
+// Requests for any other path are passed through to the next handler,
+// so that the debug endpoints of other packages are protected alike.
+//
+// When a token is set, every request must carry it in its Authorization header, as "Token <token>".
+// Every request, passed through or not, counts against the rate limit.
+// A panic in the next handler is recovered, and fails only the request that caused it.
+type Handler struct {
+	token     string
+	limiter   *transport.RateLimiter
+	recoverer *transport.Recoverer
+	limited   http.Handler
+	next      http.Handler
+	mux       *http.ServeMux
+	logger    *zap.Logger
+}
+
+// NewHandler returns a Handler that requires token, and passes requests for other paths to next.
+// An empty token leaves the endpoints unprotected.
+// The endpoints accept a burst of up to burst requests, refilled at perSecond requests per second;
+// a perSecond of zero leaves them unlimited.
+func NewHandler(token string, perSecond float64, burst int, next http.Handler) *Handler {
+	h := &Handler{
+		token:     token,
+		recoverer: transport.NewRecoverer("debug"),
+		mux:       http.NewServeMux(),
+		logger:    zap.NewNop(),
+	}
+	if next != nil {
+		h.next = h.recoverer.Middleware(next)
+	}
+	h.limited = http.HandlerFunc(h.route)
+	if perSecond > 0 {
+		// The endpoints share a single budget, whoever calls them.
+		h.limiter = transport.NewRateLimiter("debug", perSecond, burst, func(*http.Request) string { return "debug" })
+		h.limited = h.limiter.Middleware(h.limited)
+	}
+
+	h.mux.HandleFunc(PprofPath, pprof.Index)
+	h.mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	h.mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	h.mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	h.mux.HandleFunc(TracePath, pprof.Trace)
+	h.mux.HandleFunc(HeapDumpPath, h.heapDump)
+	return h
+}
+
+// WithLogger sets the logger for the Handler.
+// The logger reports requests that are refused, and heap dumps that fail.
+func (h *Handler) WithLogger(l *zap.Logger) {
+	h.logger = l.With(zap.String("handler", "debug"))
+	h.recoverer.WithLogger(l)
+	if h.limiter != nil {
+		h.limiter.WithLogger(l)
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface, with the metrics of the rate limit
+// and of the panics recovered in the next handler.
+func (h *Handler) PrometheusCollectors() []prometheus.Collector {
+	cs := h.recoverer.PrometheusCollectors()
+	if h.limiter != nil {
+		cs = append(cs, h.limiter.PrometheusCollectors()...)
+	}
+	return cs
+}
+
+// ServeHTTP checks the request's token and the rate limit, and then serves the request
+// or passes it to the next handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		h.logger.Info("Unauthorized debug request", zap.String("path", r.URL.Path), zap.String("remote_addr", r.RemoteAddr))
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.limited.ServeHTTP(w, r)
+}
+
+// route serves the request, or passes it to the next handler if it is not for one of the handler's endpoints.
+func (h *Handler) route(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := h.mux.Handler(r); pattern != "" {
+		h.mux.ServeHTTP(w, r)
+		return
+	}
+	if h.next == nil {
+		http.NotFound(w, r)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// authorized reports whether r carries the handler's token, compared in constant time.
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, tokenScheme) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, tokenScheme)), []byte(h.token)) == 1
+}
+
+// heapDump responds with a dump of the heap.
+// runtime/debug.WriteHeapDump writes only to a file, so the dump is written to a temporary file and copied from there.
+func (h *Handler) heapDump(w http.ResponseWriter, r *http.Request) {
+	f, err := ioutil.TempFile("", "heapdump")
+	if err != nil {
+		h.logger.Info("Failed to create heap dump file", zap.Error(err))
+		http.Error(w, fmt.Sprintf("could not create heap dump: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	debug.WriteHeapDump(f.Fd())
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		h.logger.Info("Failed to read heap dump file", zap.Error(err))
+		http.Error(w, fmt.Sprintf("could not read heap dump: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="heapdump-%d"`, time.Now().Unix()))
+	io.Copy(w, f)
+}
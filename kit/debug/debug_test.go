@@ -0,0 +1,76 @@
+package debug_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform/kit/debug"
+)
+
+func TestHandler_Token(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h := debug.NewHandler("secret", 0, 0, next)
+
+	for _, tt := range []struct {
+		name string
+		path string
+		auth string
+		exp  int
+	}{
+		{name: "no token", path: debug.PprofPath, exp: http.StatusUnauthorized},
+		{name: "wrong token", path: debug.PprofPath, auth: "Token wrong", exp: http.StatusUnauthorized},
+		{name: "wrong scheme", path: debug.PprofPath, auth: "Bearer secret", exp: http.StatusUnauthorized},
+		{name: "pprof index", path: debug.PprofPath, auth: "Token secret", exp: http.StatusOK},
+		{name: "passthrough without token", path: "/debug/tasks/scheduler", exp: http.StatusUnauthorized},
+		{name: "passthrough", path: "/debug/tasks/scheduler", auth: "Token secret", exp: http.StatusTeapot},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.exp {
+				t.Fatalf("expected status %d, got %d: %s", tt.exp, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	h := debug.NewHandler("", 0.001, 2, nil)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", debug.PprofPath, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected request %d within the burst to succeed, got %d", i, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", debug.PprofPath, nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d past the burst, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header")
+	}
+}
+
+func TestHandler_HeapDump(t *testing.T) {
+	h := debug.NewHandler("", 0, 0, nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", debug.HeapDumpPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w.Body.Len() == 0 {
+		t.Fatal("expected a heap dump")
+	}
+}
@@ -18,6 +18,8 @@ const (
 	Forbidden = 4
 	// NotFound indicates a resource was not found.
 	NotFound = 5
+	// TooManyRequests indicates a rate limit was exceeded.
+	TooManyRequests = 6
 )
 
 // Error indicates an error with a reference code and an HTTP status code.
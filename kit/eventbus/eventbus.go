@@ -0,0 +1,236 @@
+// Package eventbus publishes events on named, typed topics to the subsystems subscribed to them,
+// so that a subsystem can react to another's events, such as a task being deleted or a run finishing,
+// without either depending on the other.
+//
+// Local is the in-process Bus. The nats package adapts the NATS streaming server to the Bus interface,
+// so that events also reach subscribers in other processes.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultQueueSize is how many events may wait for a Local bus's subscribers, unless set with WithQueueSize.
+const DefaultQueueSize = 1000
+
+// Topic names a kind of event, such as "task.deleted", and the type of the payload its events carry.
+// Topics are declared once, alongside the payload type, by the package that publishes them.
+type Topic struct {
+	name string
+	typ  reflect.Type
+}
+
+// NewTopic returns the topic with the given name, whose events carry payloads of the same type as payload,
+// such as TaskEvent{}. Payloads are encoded as JSON by buses that deliver them to other processes.
+func NewTopic(name string, payload interface{}) Topic {
+	return Topic{name: name, typ: reflect.TypeOf(payload)}
+}
+
+// Name returns the name of the topic.
+func (t Topic) Name() string {
+	return t.name
+}
+
+// Check returns an error unless payload is of the topic's payload type.
+func (t Topic) Check(payload interface{}) error {
+	if reflect.TypeOf(payload) != t.typ {
+		return fmt.Errorf("payload of type %T cannot be published on topic %q of %v", payload, t.name, t.typ)
+	}
+	return nil
+}
+
+// Unmarshal decodes the JSON-encoded payload data into a value of the topic's payload type.
+func (t Topic) Unmarshal(data []byte) (interface{}, error) {
+	v := reflect.New(t.typ)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid payload for topic %q: %v", t.name, err)
+	}
+	return v.Elem().Interface(), nil
+}
+
+// Event is an event delivered to the subscribers of a topic.
+type Event struct {
+	// Topic is the name of the topic the event was published on.
+	Topic string
+
+	// Time is when the event was published.
+	Time time.Time
+
+	// Payload is the value published, of the topic's payload type.
+	Payload interface{}
+}
+
+// Handler handles the events of a subscription, one at a time.
+// The ctx is the one the subscription was made with.
+type Handler func(ctx context.Context, e Event)
+
+// Bus delivers the events published on a topic to the subscribers of that topic.
+type Bus interface {
+	// Publish publishes payload on topic. It does not wait for subscribers to handle the event,
+	// and returns an error if payload is not of the topic's type, or the event could not be sent.
+	Publish(ctx context.Context, topic Topic, payload interface{}) error
+
+	// Subscribe calls h with each event published on topic after the call, until ctx is done.
+	// Subscriptions with the same name share the topic's events, so each event is handled by only one of them;
+	// subsystems that must each see every event subscribe with their own names, such as "task-cache".
+	Subscribe(ctx context.Context, topic Topic, name string, h Handler) error
+}
+
+// Option configures a Local bus.
+type Option func(b *Local)
+
+// WithQueueSize sets how many events may wait for each subscriber to handle them.
+// Events published while a subscriber's queue is full are dropped for that subscriber.
+func WithQueueSize(n int) Option {
+	return func(b *Local) {
+		if n > 0 {
+			b.queueSize = n
+		}
+	}
+}
+
+// WithClock sets the Clock that events are timestamped with. If not set, the bus uses clock.System.
+func WithClock(clk clock.Clock) Option {
+	return func(b *Local) {
+		b.clock = clk
+	}
+}
+
+// Local is a Bus that delivers events to subscribers in the same process.
+// Publishing never blocks: each subscriber handles its events on its own goroutine,
+// from a queue that drops events once full, so that a slow subscriber cannot hold up the publishers.
+type Local struct {
+	queueSize int
+	clock     clock.Clock
+
+	mu     sync.Mutex
+	groups map[string]map[string]*group // By topic, then by subscription name.
+
+	logger    *zap.Logger
+	published *prometheus.CounterVec
+	dropped   *prometheus.CounterVec
+}
+
+// group is the queue of events shared by the subscriptions to a topic with the same name.
+type group struct {
+	events chan Event
+	subs   int // Number of subscriptions reading events; the group is removed when it reaches zero.
+}
+
+var _ Bus = (*Local)(nil)
+
+// NewLocal returns a Local bus. The name identifies the bus in metrics, such as "platform".
+func NewLocal(name string, opts ...Option) *Local {
+	labels := prometheus.Labels{"bus": name}
+	b := &Local{
+		queueSize: DefaultQueueSize,
+		clock:     clock.System,
+		groups:    make(map[string]map[string]*group),
+		logger:    zap.NewNop(),
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "eventbus",
+			Name:        "published_total",
+			Help:        "Number of events published, split out by topic.",
+			ConstLabels: labels,
+		}, []string{"topic"}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "eventbus",
+			Name:        "dropped_total",
+			Help:        "Number of events dropped because a subscriber's queue was full, split out by topic and subscriber.",
+			ConstLabels: labels,
+		}, []string{"topic", "subscriber"}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithLogger sets the logger for the bus. The logger reports events dropped for slow subscribers.
+func (b *Local) WithLogger(l *zap.Logger) {
+	b.logger = l.With(zap.String("service", "eventbus"))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (b *Local) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{b.published, b.dropped}
+}
+
+// Publish queues an event with payload for each subscriber of topic, dropping it for subscribers whose queues are full.
+func (b *Local) Publish(_ context.Context, topic Topic, payload interface{}) error {
+	if err := topic.Check(payload); err != nil {
+		return err
+	}
+	e := Event{Topic: topic.name, Time: b.clock.Now(), Payload: payload}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.published.WithLabelValues(topic.name).Inc()
+	for name, g := range b.groups[topic.name] {
+		select {
+		case g.events <- e:
+		default:
+			b.dropped.WithLabelValues(topic.name, name).Inc()
+			b.logger.Warn("Dropped event for slow subscriber", zap.String("topic", topic.name), zap.String("subscriber", name))
+		}
+	}
+	return nil
+}
+
+// Subscribe calls h with each event published on topic after the call, until ctx is done.
+func (b *Local) Subscribe(ctx context.Context, topic Topic, name string, h Handler) error {
+	b.mu.Lock()
+	byName, ok := b.groups[topic.name]
+	if !ok {
+		byName = make(map[string]*group)
+		b.groups[topic.name] = byName
+	}
+	g, ok := byName[name]
+	if !ok {
+		g = &group{events: make(chan Event, b.queueSize)}
+		byName[name] = g
+	}
+	g.subs++
+	b.mu.Unlock()
+
+	go func() {
+		defer b.unsubscribe(topic.name, name, g)
+		for {
+			select {
+			case e := <-g.events:
+				if ctx.Err() != nil {
+					return
+				}
+				h(ctx, e)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// unsubscribe removes a subscription from g, and removes g from the bus once it has no subscriptions left.
+func (b *Local) unsubscribe(topic, name string, g *group) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g.subs--
+	if g.subs > 0 {
+		return
+	}
+	delete(b.groups[topic], name)
+	if len(b.groups[topic]) == 0 {
+		delete(b.groups, topic)
+	}
+}
@@ -0,0 +1,154 @@
+package eventbus_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+)
+
+type thing struct {
+	Name string `json:"name"`
+}
+
+var thingTopic = eventbus.NewTopic("thing.created", thing{})
+
+// recorder records the events it handles.
+type recorder struct {
+	mu     sync.Mutex
+	events []eventbus.Event
+}
+
+func (r *recorder) handle(_ context.Context, e eventbus.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// waitFor waits until cond is true, since events are handled on the subscribers' goroutines.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	clk := clock.NewMock(time.Unix(1000, 0))
+	bus := eventbus.NewLocal("test", eventbus.WithClock(clk))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Subscribers with different names each receive every event; subscribers sharing a name share the events.
+	var a, b1, b2 recorder
+	for _, s := range []struct {
+		name string
+		r    *recorder
+	}{{"a", &a}, {"b", &b1}, {"b", &b2}} {
+		if err := bus.Subscribe(ctx, thingTopic, s.name, s.r.handle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := bus.Publish(ctx, thingTopic, thing{Name: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, "events", func() bool { return a.len() == 10 && b1.len()+b2.len() == 10 })
+
+	e := a.events[0]
+	if e.Topic != "thing.created" || !e.Time.Equal(time.Unix(1000, 0)) || e.Payload.(thing).Name != "x" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+
+	// Payloads of other types are refused.
+	if err := bus.Publish(ctx, thingTopic, &thing{Name: "x"}); err == nil {
+		t.Fatal("expected error publishing payload of the wrong type")
+	}
+
+	// Events are no longer delivered once the subscription's context is done.
+	subCtx, unsubscribe := context.WithCancel(ctx)
+	var c recorder
+	if err := bus.Subscribe(subCtx, thingTopic, "c", c.handle); err != nil {
+		t.Fatal(err)
+	}
+	unsubscribe()
+	if err := bus.Publish(ctx, thingTopic, thing{Name: "y"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "event", func() bool { return a.len() == 11 })
+	if n := c.len(); n != 0 {
+		t.Fatalf("expected no events after unsubscribing, got %d", n)
+	}
+}
+
+func TestLocal_Dropped(t *testing.T) {
+	bus := eventbus.NewLocal("test", eventbus.WithQueueSize(1))
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(bus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The subscriber blocks on the first event, so its queue holds one more and the rest are dropped.
+	started, block := make(chan struct{}, 1), make(chan struct{})
+	var r recorder
+	if err := bus.Subscribe(ctx, thingTopic, "slow", func(ctx context.Context, e eventbus.Event) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-block
+		r.handle(ctx, e)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(ctx, thingTopic, thing{}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(ctx, thingTopic, thing{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(block)
+	waitFor(t, "queued event", func() bool { return r.len() == 2 })
+
+	mfs := promtest.MustGather(t, reg)
+	if got := promtest.MustFindMetric(t, mfs, "eventbus_published_total", map[string]string{"bus": "test", "topic": "thing.created"}).GetCounter().GetValue(); got != 4 {
+		t.Fatalf("expected 4 events published, got %v", got)
+	}
+	labels := map[string]string{"bus": "test", "topic": "thing.created", "subscriber": "slow"}
+	if got := promtest.MustFindMetric(t, mfs, "eventbus_dropped_total", labels).GetCounter().GetValue(); got != 2 {
+		t.Fatalf("expected 2 events dropped, got %v", got)
+	}
+}
+
+func TestTopic_Unmarshal(t *testing.T) {
+	v, err := thingTopic.Unmarshal([]byte(`{"name":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.(thing).Name != "x" {
+		t.Fatalf("unexpected payload: %#v", v)
+	}
+	if _, err := thingTopic.Unmarshal([]byte(`[`)); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+}
@@ -0,0 +1,226 @@
+// Package feature evaluates feature flags, so that risky changes can be rolled out gradually,
+// to some organizations before others, and turned off again without a deploy.
+//
+// A flag is declared once, with its type and default, by MakeBoolFlag, MakeIntFlag, or MakeStringFlag.
+// A Flagger decides the value of flags for an organization.
+// Annotate, or the Middleware for HTTP requests, stores the values in a context,
+// where each flag reads its own value, falling back to its default.
+package feature
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"go.uber.org/zap"
+)
+
+// Flag is a feature flag.
+type Flag interface {
+	// Key is the flag's unique name, by which its value is set.
+	Key() string
+
+	// Default is the flag's value where no Flagger sets it.
+	Default() interface{}
+}
+
+// Values are the values of flags, by key.
+type Values map[string]interface{}
+
+// Flagger decides the values of flags.
+type Flagger interface {
+	// Flags returns the values of flags for the organization org, by key.
+	// Flags without a value are left out, and take their defaults.
+	Flags(ctx context.Context, org platform.ID, flags ...Flag) (Values, error)
+}
+
+type base struct {
+	key string
+	def interface{}
+}
+
+func (b base) Key() string          { return b.key }
+func (b base) Default() interface{} { return b.def }
+
+// value returns the value of the flag in ctx, or nil if ctx has none.
+func (b base) value(ctx context.Context) interface{} {
+	return ValuesFromContext(ctx)[b.key]
+}
+
+// BoolFlag is a flag that turns a feature on or off.
+type BoolFlag struct {
+	base
+}
+
+// MakeBoolFlag returns a BoolFlag with the given key, whose value is def unless set otherwise.
+func MakeBoolFlag(key string, def bool) BoolFlag {
+	return BoolFlag{base{key: key, def: def}}
+}
+
+// Enabled reports whether the feature is on in ctx.
+func (f BoolFlag) Enabled(ctx context.Context) bool {
+	if v, ok := f.value(ctx).(bool); ok {
+		return v
+	}
+	return f.def.(bool)
+}
+
+// IntFlag is a flag that sets a number, such as a limit.
+type IntFlag struct {
+	base
+}
+
+// MakeIntFlag returns an IntFlag with the given key, whose value is def unless set otherwise.
+func MakeIntFlag(key string, def int) IntFlag {
+	return IntFlag{base{key: key, def: def}}
+}
+
+// Int returns the flag's value in ctx.
+// Values decoded from JSON or TOML as other numeric types are converted to int.
+func (f IntFlag) Int(ctx context.Context) int {
+	switch v := f.value(ctx).(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return f.def.(int)
+}
+
+// StringFlag is a flag that chooses between variants of a feature by name.
+type StringFlag struct {
+	base
+}
+
+// MakeStringFlag returns a StringFlag with the given key, whose value is def unless set otherwise.
+func MakeStringFlag(key string, def string) StringFlag {
+	return StringFlag{base{key: key, def: def}}
+}
+
+// String returns the flag's value in ctx.
+func (f StringFlag) String(ctx context.Context) string {
+	if v, ok := f.value(ctx).(string); ok {
+		return v
+	}
+	return f.def.(string)
+}
+
+type key int
+
+const valuesKey key = iota
+
+// Annotate returns ctx with the values f decides for flags in the organization org,
+// so that the flags read them from the returned context.
+func Annotate(ctx context.Context, f Flagger, org platform.ID, flags ...Flag) (context.Context, error) {
+	vs, err := f.Flags(ctx, org, flags...)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, valuesKey, vs), nil
+}
+
+// ValuesFromContext returns the values stored in ctx by Annotate, or nil if there are none.
+func ValuesFromContext(ctx context.Context) Values {
+	vs, _ := ctx.Value(valuesKey).(Values)
+	return vs
+}
+
+// Config sets the values of flags, for every organization and per organization.
+type Config struct {
+	// Values are the values of flags in every organization.
+	Values Values
+
+	// Orgs are the values of flags in each organization, which take precedence over Values.
+	Orgs map[platform.ID]Values
+}
+
+// Flags returns the values of flags in the organization org: its own values, or otherwise those of every organization.
+// Config is a Flagger whose values do not change.
+func (c Config) Flags(_ context.Context, org platform.ID, flags ...Flag) (Values, error) {
+	vs := make(Values, len(flags))
+	for _, f := range flags {
+		if v, ok := c.Orgs[org][f.Key()]; ok {
+			vs[f.Key()] = v
+		} else if v, ok := c.Values[f.Key()]; ok {
+			vs[f.Key()] = v
+		}
+	}
+	return vs, nil
+}
+
+var _ Flagger = Config{}
+
+// Source loads the configuration of flags, such as from a store.
+type Source interface {
+	Load(ctx context.Context) (Config, error)
+}
+
+// SourceFunc is a function that is a Source.
+type SourceFunc func(ctx context.Context) (Config, error)
+
+// Load calls fn.
+func (fn SourceFunc) Load(ctx context.Context) (Config, error) {
+	return fn(ctx)
+}
+
+// LiveFlagger is a Flagger whose configuration is reloaded from a Source while it runs,
+// so that flags can be changed without restarting the process.
+type LiveFlagger struct {
+	src Source
+
+	mu     sync.RWMutex
+	config Config
+}
+
+var _ Flagger = (*LiveFlagger)(nil)
+
+// NewLiveFlagger returns a LiveFlagger with the configuration loaded from src.
+func NewLiveFlagger(ctx context.Context, src Source) (*LiveFlagger, error) {
+	f := &LiveFlagger{src: src}
+	if err := f.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload loads the configuration from the source.
+// If loading fails, the previous configuration is kept.
+func (f *LiveFlagger) Reload(ctx context.Context) error {
+	c, err := f.src.Load(ctx)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.config = c
+	f.mu.Unlock()
+	return nil
+}
+
+// Run reloads the configuration every interval until ctx is done.
+// Failures to reload are logged to logger, and the previous configuration is kept.
+func (f *LiveFlagger) Run(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Reload(ctx); err != nil {
+				logger.Info("Failed to reload feature flags", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Flags returns the values of flags in the organization org, from the configuration last loaded.
+func (f *LiveFlagger) Flags(ctx context.Context, org platform.ID, flags ...Flag) (Values, error) {
+	f.mu.RLock()
+	c := f.config
+	f.mu.RUnlock()
+	return c.Flags(ctx, org, flags...)
+}
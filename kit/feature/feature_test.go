@@ -0,0 +1,108 @@
+package feature_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/feature"
+)
+
+var (
+	newQueue   = feature.MakeBoolFlag("newQueue", false)
+	queueDepth = feature.MakeIntFlag("queueDepth", 10)
+	queueMode  = feature.MakeStringFlag("queueMode", "fifo")
+)
+
+func TestConfig_Flags(t *testing.T) {
+	c := feature.Config{
+		Values: feature.Values{"queueDepth": float64(20)},
+		Orgs: map[platform.ID]feature.Values{
+			platform.ID(1): {"newQueue": true, "queueDepth": 30},
+		},
+	}
+
+	for _, tc := range []struct {
+		org      platform.ID
+		expQueue bool
+		expDepth int
+		expMode  string
+	}{
+		{org: platform.ID(1), expQueue: true, expDepth: 30, expMode: "fifo"},
+		{org: platform.ID(2), expQueue: false, expDepth: 20, expMode: "fifo"},
+	} {
+		ctx, err := feature.Annotate(context.Background(), c, tc.org, newQueue, queueDepth, queueMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := newQueue.Enabled(ctx); got != tc.expQueue {
+			t.Errorf("org %s: expected newQueue %v, got %v", tc.org, tc.expQueue, got)
+		}
+		if got := queueDepth.Int(ctx); got != tc.expDepth {
+			t.Errorf("org %s: expected queueDepth %d, got %d", tc.org, tc.expDepth, got)
+		}
+		if got := queueMode.String(ctx); got != tc.expMode {
+			t.Errorf("org %s: expected queueMode %q, got %q", tc.org, tc.expMode, got)
+		}
+	}
+
+	// Without annotation, flags take their defaults.
+	if newQueue.Enabled(context.Background()) || queueDepth.Int(context.Background()) != 10 {
+		t.Fatal("expected defaults without annotation")
+	}
+}
+
+func TestLiveFlagger_Reload(t *testing.T) {
+	enabled := false
+	src := feature.SourceFunc(func(context.Context) (feature.Config, error) {
+		return feature.Config{Values: feature.Values{"newQueue": enabled}}, nil
+	})
+	f, err := feature.NewLiveFlagger(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(exp bool) {
+		t.Helper()
+		ctx, err := feature.Annotate(context.Background(), f, platform.ID(1), newQueue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := newQueue.Enabled(ctx); got != exp {
+			t.Fatalf("expected newQueue %v, got %v", exp, got)
+		}
+	}
+
+	check(false)
+	enabled = true
+	check(false)
+	if err := f.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check(true)
+}
+
+func TestHTTP(t *testing.T) {
+	c := feature.Config{Orgs: map[platform.ID]feature.Values{platform.ID(1): {"newQueue": true}}}
+	orgParam := "?orgID=" + platform.ID(1).String()
+
+	var enabled bool
+	h := feature.Middleware(c, []feature.Flag{newQueue}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enabled = newQueue.Enabled(r.Context())
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/"+orgParam, nil))
+	if !enabled {
+		t.Fatal("expected middleware to evaluate flags for the request's organization")
+	}
+
+	w := httptest.NewRecorder()
+	feature.NewHandler(c, newQueue, queueDepth).ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/flags"+orgParam, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if exp, got := `{"newQueue":true,"queueDepth":10}`+"\n", w.Body.String(); got != exp {
+		t.Fatalf("expected body %q, got %q", exp, got)
+	}
+}
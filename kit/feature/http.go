@@ -0,0 +1,69 @@
+package feature
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform"
+)
+
+// OrgIDParam is the query parameter that identifies the organization whose flags are evaluated for an HTTP request.
+const OrgIDParam = "orgID"
+
+// orgFromRequest returns the organization named by the request's orgID parameter,
+// or the invalid ID if there is none, which evaluates the flags of every organization.
+func orgFromRequest(r *http.Request) (platform.ID, error) {
+	var org platform.ID
+	if id := r.URL.Query().Get(OrgIDParam); id != "" {
+		if err := org.DecodeFromString(id); err != nil {
+			return org, fmt.Errorf("invalid %s: %v", OrgIDParam, err)
+		}
+	}
+	return org, nil
+}
+
+// Middleware returns a handler that evaluates flags with f for each request's organization, before passing it to next,
+// so that the flags read their values from the request's context.
+// A request whose flags cannot be evaluated is passed to next with the flags at their defaults.
+func Middleware(f Flagger, flags []Flag, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if org, err := orgFromRequest(r); err == nil {
+			if ctx, err := Annotate(r.Context(), f, org, flags...); err == nil {
+				r = r.WithContext(ctx)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewHandler returns a handler that responds with the evaluated values of flags, by key, as a JSON object,
+// for the organization in the request's orgID parameter, so that clients such as the UI can follow the same flags.
+func NewHandler(f Flagger, flags ...Flag) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, err := orgFromRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		vs, err := f.Flags(r.Context(), org, flags...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		evaluated := make(Values, len(flags))
+		for _, fl := range flags {
+			if v, ok := vs[fl.Key()]; ok {
+				evaluated[fl.Key()] = v
+			} else {
+				evaluated[fl.Key()] = fl.Default()
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := json.NewEncoder(w).Encode(evaluated); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
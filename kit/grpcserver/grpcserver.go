@@ -0,0 +1,341 @@
+// Package grpcserver provides a gRPC server with the health service, metrics, panic recovery
+// and graceful stop that every platform service serving gRPC needs:
+//
+//	srv := grpcserver.New("executor", grpcserver.WithHealthCheck("engine", engine.Ready))
+//	srv.WithLogger(logger)
+//	reg.MustRegisterCollectors(srv)
+//	executorpb.RegisterExecutorServer(srv.GRPC(), executor)
+//	shutdown.Register("grpc", srv.Shutdown)
+//	if err := srv.Serve(ln); err != nil {
+//		logger.Fatal("gRPC server failed", zap.Error(err))
+//	}
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/kit/pool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultHealthInterval is how often the health checks run, unless set with WithHealthInterval.
+const DefaultHealthInterval = 5 * time.Second
+
+// HealthTopic carries a HealthEvent each time a Server using WithEventBus starts or stops serving.
+var HealthTopic = eventbus.NewTopic("health.changed", HealthEvent{})
+
+// HealthEvent is the payload of HealthTopic.
+type HealthEvent struct {
+	// Server is the name of the server, as passed to New.
+	Server string `json:"server"`
+
+	// Serving is whether the server now reports SERVING.
+	Serving bool `json:"serving"`
+
+	// Failing names the health checks that failed, if any, when the server stopped serving.
+	// It is empty when the server stopped serving because it was shut down.
+	Failing []string `json:"failing,omitempty"`
+}
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithHealthCheck adds a check that must pass for the server to report SERVING through the gRPC health service,
+// such as a store that has yet to open. The name identifies the check in logs while it fails.
+func WithHealthCheck(name string, ready func() bool) Option {
+	return func(s *Server) {
+		s.checks[name] = ready
+	}
+}
+
+// WithHealthInterval sets how often the health checks run.
+func WithHealthInterval(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.interval = d
+		}
+	}
+}
+
+// WithClock sets the Clock whose ticker runs the health checks every interval, so that tests can run them on demand.
+// If not set, the server uses clock.System.
+func WithClock(c clock.Clock) Option {
+	return func(s *Server) {
+		s.clock = c
+	}
+}
+
+// WithCheckPool runs the health checks in parallel on the workers of p, rather than in turn,
+// so that slow checks do not delay the serving status past the health interval.
+func WithCheckPool(p *pool.Pool) Option {
+	return func(s *Server) {
+		s.checkPool = p
+	}
+}
+
+// WithEventBus publishes a HealthEvent on HealthTopic through bus each time the server's status changes,
+// so that other subsystems can react to it without polling the health service.
+func WithEventBus(bus eventbus.Bus) Option {
+	return func(s *Server) {
+		s.bus = bus
+	}
+}
+
+// WithServerOptions passes opts to the underlying grpc.Server, such as grpc.Creds for TLS.
+// They must not set the unary or stream interceptor, which the Server sets itself.
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(s *Server) {
+		s.opts = append(s.opts, opts...)
+	}
+}
+
+// Server is a gRPC server that serves the standard health service alongside a service's own,
+// and records the count and duration of every call, recovering the panics of their handlers.
+type Server struct {
+	name      string
+	srv       *grpc.Server
+	health    *health.Server
+	opts      []grpc.ServerOption
+	checks    map[string]func() bool
+	checkPool *pool.Pool
+	interval  time.Duration
+	clock     clock.Clock
+	bus       eventbus.Bus
+
+	mu       sync.Mutex
+	stopping bool
+	serving  bool // Whether the health service last reported SERVING.
+	done     chan struct{}
+
+	logger   *zap.Logger
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	panics   prometheus.Counter
+}
+
+// New returns a Server. The name identifies the server in its metrics, such as "executor".
+func New(name string, opts ...Option) *Server {
+	labels := prometheus.Labels{"server": name}
+	s := &Server{
+		name:     name,
+		health:   health.NewServer(),
+		checks:   make(map[string]func() bool),
+		interval: DefaultHealthInterval,
+		clock:    clock.System,
+		done:     make(chan struct{}),
+		logger:   zap.NewNop(),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "grpc",
+			Subsystem:   "server",
+			Name:        "handled_total",
+			Help:        "Number of calls handled, split out by method and status code.",
+			ConstLabels: labels,
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   "grpc",
+			Subsystem:   "server",
+			Name:        "handling_seconds",
+			Help:        "Duration of calls, split out by method.",
+			ConstLabels: labels,
+		}, []string{"method"}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "grpc",
+			Subsystem:   "server",
+			Name:        "panics_total",
+			Help:        "Number of panics recovered in call handlers.",
+			ConstLabels: labels,
+		}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	sopts := append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	}, s.opts...)
+	s.srv = grpc.NewServer(sopts...)
+	healthpb.RegisterHealthServer(s.srv, s.health)
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// WithLogger sets the logger for the Server.
+// The logger reports failing health checks, recovered panics, and when the server starts and stops.
+func (s *Server) WithLogger(l *zap.Logger) {
+	s.logger = l.With(zap.String("service", "grpc"), zap.String("server", s.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (s *Server) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{s.handled, s.duration, s.panics}
+}
+
+// GRPC returns the underlying grpc.Server, with which services are registered before Serve.
+func (s *Server) GRPC() *grpc.Server {
+	return s.srv
+}
+
+// Serve serves calls on ln until Shutdown is called, when it returns nil.
+// It runs the health checks, and reports SERVING while they all pass.
+func (s *Server) Serve(ln net.Listener) error {
+	go s.runHealthChecks()
+
+	s.logger.Info("Listening", zap.String("transport", "grpc"), zap.String("addr", ln.Addr().String()))
+	err := s.srv.Serve(ln)
+	if s.isStopping() {
+		s.logger.Info("Stopped listening")
+		return nil
+	}
+	return err
+}
+
+// Shutdown reports NOT_SERVING, stops the server accepting calls, and waits for the calls in flight to finish.
+// If ctx is done first, the calls still in flight are cancelled. It suits signals.Shutdown.Register.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopping {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopping = true
+	close(s.done)
+	s.setServing(false, nil)
+	s.mu.Unlock()
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *Server) isStopping() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopping
+}
+
+// runHealthChecks sets the serving status from the health checks, once per interval, until shutdown.
+func (s *Server) runHealthChecks() {
+	t := s.clock.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		s.checkHealth()
+		select {
+		case <-s.done:
+			return
+		case <-t.C():
+		}
+	}
+}
+
+func (s *Server) checkHealth() {
+	var (
+		mu      sync.Mutex
+		failing []string
+	)
+	checks := make([]func(), 0, len(s.checks))
+	for name, ready := range s.checks {
+		name, ready := name, ready
+		checks = append(checks, func() {
+			if !ready() {
+				mu.Lock()
+				failing = append(failing, name)
+				mu.Unlock()
+			}
+		})
+	}
+	s.checkPool.Run(context.Background(), checks...)
+
+	serving := healthpb.HealthCheckResponse_SERVING
+	for _, name := range failing {
+		s.logger.Debug("Health check failing", zap.String("check", name))
+		serving = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	// Shutdown may have begun while the checks ran, and must not be reported SERVING afterwards.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopping {
+		return
+	}
+	s.health.SetServingStatus("", serving)
+	s.setServing(serving == healthpb.HealthCheckResponse_SERVING, failing)
+}
+
+// setServing records whether the server reports SERVING, and if that changed, publishes a HealthEvent
+// through the server's event bus, if it has one. The failing checks are reported with a change to not serving.
+// It must be called with s.mu held, so that the events are published in the order the status changed.
+func (s *Server) setServing(serving bool, failing []string) {
+	if s.serving == serving {
+		return
+	}
+	s.serving = serving
+	if s.bus == nil {
+		return
+	}
+	e := HealthEvent{Server: s.name, Serving: serving}
+	if !serving {
+		e.Failing = failing
+	}
+	if err := s.bus.Publish(context.Background(), HealthTopic, e); err != nil {
+		s.logger.Info("Failed to publish health event", zap.Error(err))
+	}
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer s.observe(info.FullMethod, time.Now(), &err)
+	defer s.recoverPanic(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer s.observe(info.FullMethod, time.Now(), &err)
+	defer s.recoverPanic(info.FullMethod, &err)
+	return handler(srv, ss)
+}
+
+// observe records a call to method that began at start, and ended with *err.
+func (s *Server) observe(method string, start time.Time, err *error) {
+	s.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	s.handled.WithLabelValues(method, status.Code(*err).String()).Inc()
+}
+
+// recoverPanic recovers a panic in the handler of method, and sets *err to an Internal error in its place.
+// It must be deferred directly, for the built-in recover to stop the panic.
+func (s *Server) recoverPanic(method string, err *error) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	s.panics.Inc()
+	s.logger.Error("Recovered from panic in gRPC handler",
+		zap.String("panic", fmt.Sprint(v)),
+		zap.String("method", method),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	*err = status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,138 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/kit/grpcserver"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServer_Health(t *testing.T) {
+	var ready int32
+	srv := grpcserver.New("test",
+		grpcserver.WithHealthCheck("store", func() bool { return atomic.LoadInt32(&ready) == 1 }),
+		grpcserver.WithHealthInterval(10*time.Millisecond),
+	)
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(srv)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	// waitFor polls the health service until it reports want, since the checks run on an interval.
+	waitFor := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if err == nil && resp.Status == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected status %v, got %v, %v", want, resp, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitFor(healthpb.HealthCheckResponse_NOT_SERVING)
+	atomic.StoreInt32(&ready, 1)
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+
+	mfs := promtest.MustGather(t, reg)
+	labels := map[string]string{"server": "test", "method": "/grpc.health.v1.Health/Check", "code": "OK"}
+	if got := promtest.MustFindMetric(t, mfs, "grpc_server_handled_total", labels).GetCounter().GetValue(); got < 2 {
+		t.Fatalf("expected the health checks to be counted, got %v", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected Serve to return nil after shutdown, got %v", err)
+	}
+}
+
+func TestServer_HealthEvents(t *testing.T) {
+	bus := eventbus.NewLocal("test")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan grpcserver.HealthEvent, 10)
+	if err := bus.Subscribe(ctx, grpcserver.HealthTopic, "test", func(_ context.Context, e eventbus.Event) {
+		events <- e.Payload.(grpcserver.HealthEvent)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ready := int32(1)
+	clk := clock.NewMock(time.Unix(1000, 0))
+	srv := grpcserver.New("test",
+		grpcserver.WithHealthCheck("store", func() bool { return atomic.LoadInt32(&ready) == 1 }),
+		grpcserver.WithClock(clk),
+		grpcserver.WithEventBus(bus),
+	)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+
+	expect := func(want grpcserver.HealthEvent) {
+		t.Helper()
+		select {
+		case got := <-events:
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected event %+v, got %+v", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %+v", want)
+		}
+	}
+
+	// The first health check passes, so the server starts serving.
+	expect(grpcserver.HealthEvent{Server: "test", Serving: true})
+
+	// A failing check stops the server serving, naming the check.
+	atomic.StoreInt32(&ready, 0)
+	clk.Advance(grpcserver.DefaultHealthInterval)
+	expect(grpcserver.HealthEvent{Server: "test", Serving: false, Failing: []string{"store"}})
+
+	// Once passing again, the server serves again, and stops when it is shut down.
+	atomic.StoreInt32(&ready, 1)
+	clk.Advance(grpcserver.DefaultHealthInterval)
+	expect(grpcserver.HealthEvent{Server: "test", Serving: true})
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected Serve to return nil after shutdown, got %v", err)
+	}
+	expect(grpcserver.HealthEvent{Server: "test", Serving: false})
+}
@@ -0,0 +1,280 @@
+// Package httpserver provides an HTTP server with the timeouts, TLS, graceful shutdown
+// and operational endpoints that every platform service needs, so that a new service gets
+// a production-grade listener in a few lines:
+//
+//	srv := httpserver.New(":8080", handler,
+//		httpserver.WithMetrics(reg),
+//		httpserver.WithReadyCheck("shutdown", shutdown.Ready),
+//	)
+//	srv.WithLogger(logger)
+//	shutdown.Register("http", srv.Shutdown)
+//	if err := srv.ListenAndServe(); err != nil {
+//		logger.Fatal("HTTP server failed", zap.Error(err))
+//	}
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform/kit/pool"
+	"github.com/influxdata/platform/kit/prom"
+	"go.uber.org/zap"
+)
+
+const (
+	// HealthPath is the path of the health endpoint, which responds with status 200 while the process is serving.
+	HealthPath = "/health"
+	// ReadyPath is the path of the readiness endpoint, which responds with status 503 until every ready check passes,
+	// and once shutdown begins.
+	ReadyPath = "/ready"
+	// MetricsPath is the path of the Prometheus metrics endpoint.
+	MetricsPath = "/metrics"
+	// DebugPath is the path under which the debug endpoints are served.
+	DebugPath = "/debug/"
+)
+
+// Default timeouts, chosen so that slow or idle clients cannot hold connections open indefinitely,
+// while leaving room for long queries.
+const (
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+	DefaultWriteTimeout      = 5 * time.Minute
+	DefaultIdleTimeout       = 2 * time.Minute
+)
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithTimeouts sets the server's read, write and idle timeouts. A zero timeout keeps its default.
+func WithTimeouts(read, write, idle time.Duration) Option {
+	return func(s *Server) {
+		if read > 0 {
+			s.srv.ReadTimeout = read
+		}
+		if write > 0 {
+			s.srv.WriteTimeout = write
+		}
+		if idle > 0 {
+			s.srv.IdleTimeout = idle
+		}
+	}
+}
+
+// WithTLS makes the server serve TLS, with the certificate and key in the given PEM files.
+// It accepts TLS 1.2 and later.
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.certFile = certFile
+		s.keyFile = keyFile
+		s.srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+}
+
+// WithMetrics serves the metrics of reg at MetricsPath.
+func WithMetrics(reg *prom.Registry) Option {
+	return func(s *Server) {
+		s.metrics = reg.HTTPHandler()
+	}
+}
+
+// WithDebug serves h under DebugPath, such as a kit/debug.Handler, which protects the endpoints with a token.
+func WithDebug(h http.Handler) Option {
+	return func(s *Server) {
+		s.debug = h
+	}
+}
+
+// WithReadyCheck adds a check that must pass for the server to report ready, such as a cache that has yet to warm up.
+// The name identifies the check in the response of the readiness endpoint while it fails.
+func WithReadyCheck(name string, ready func() bool) Option {
+	return func(s *Server) {
+		s.checks[name] = ready
+	}
+}
+
+// WithCheckPool runs the ready checks in parallel on the workers of p, rather than in turn,
+// so that slow checks do not add up in the response time of the readiness endpoint.
+func WithCheckPool(p *pool.Pool) Option {
+	return func(s *Server) {
+		s.checkPool = p
+	}
+}
+
+// Server is an HTTP server that serves the health, readiness, metrics and debug endpoints
+// alongside a service's handler, which serves every other path.
+type Server struct {
+	srv       *http.Server
+	metrics   http.Handler
+	debug     http.Handler
+	checks    map[string]func() bool
+	checkPool *pool.Pool
+	certFile  string
+	keyFile   string
+
+	mu       sync.Mutex
+	ln       net.Listener
+	stopping bool
+
+	logger *zap.Logger
+}
+
+// New returns a Server that listens on addr, and passes requests for every path but its own endpoints to h.
+func New(addr string, h http.Handler, opts ...Option) *Server {
+	s := &Server{
+		srv: &http.Server{
+			Addr:              addr,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			ReadTimeout:       DefaultReadTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+		},
+		checks: make(map[string]func() bool),
+		logger: zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, s.health)
+	mux.HandleFunc(ReadyPath, s.ready)
+	if s.metrics != nil {
+		mux.Handle(MetricsPath, s.metrics)
+	}
+	if s.debug != nil {
+		mux.Handle(DebugPath, s.debug)
+	}
+	if h != nil {
+		mux.Handle("/", h)
+	}
+	s.srv.Handler = mux
+	return s
+}
+
+// WithLogger sets the logger for the Server.
+// The logger reports when the server starts and stops listening, and the errors of the underlying http.Server.
+func (s *Server) WithLogger(l *zap.Logger) {
+	s.logger = l.With(zap.String("service", "http"))
+	s.srv.ErrorLog = zap.NewStdLog(s.logger)
+}
+
+// Listen opens the server's listener, so that its address, with the port the system picked for a port of zero,
+// is known before serving. ListenAndServe calls it if it has not been called.
+func (s *Server) Listen() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ln != nil {
+		return nil
+	}
+	ln, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Listen.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ln == nil {
+		return nil
+	}
+	return s.ln.Addr()
+}
+
+// ListenAndServe serves requests until Shutdown is called, when it returns nil.
+// It returns any other error that stops the server.
+func (s *Server) ListenAndServe() error {
+	if err := s.Listen(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	ln := s.ln
+	s.mu.Unlock()
+
+	s.logger.Info("Listening", zap.String("addr", ln.Addr().String()), zap.Bool("tls", s.certFile != ""))
+	var err error
+	if s.certFile != "" {
+		err = s.srv.ServeTLS(ln, s.certFile, s.keyFile)
+	} else {
+		err = s.srv.Serve(ln)
+	}
+	if err == http.ErrServerClosed {
+		s.logger.Info("Stopped listening")
+		return nil
+	}
+	return err
+}
+
+// Shutdown marks the server not ready, stops it accepting connections, and waits for the requests in flight to finish,
+// until ctx is done. It suits signals.Shutdown.Register; registered after a drain period, load balancers see
+// the server not ready and stop sending requests before it stops accepting them.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopping = true
+	s.mu.Unlock()
+	return s.srv.Shutdown(ctx)
+}
+
+// Ready reports whether every ready check passes, and shutdown has not begun.
+func (s *Server) Ready() bool {
+	return len(s.waiting(context.Background())) == 0
+}
+
+// waiting returns the sorted names of the ready checks that fail, including "shutdown" once shutdown has begun.
+// Checks left unrun because ctx is done count as failing.
+func (s *Server) waiting(ctx context.Context) []string {
+	names := make([]string, 0, len(s.checks))
+	for name := range s.checks {
+		names = append(names, name)
+	}
+	passed := make([]bool, len(names))
+	checks := make([]func(), len(names))
+	for i, name := range names {
+		i, ready := i, s.checks[name]
+		checks[i] = func() { passed[i] = ready() }
+	}
+	s.checkPool.Run(ctx, checks...)
+
+	var waiting []string
+	for i, name := range names {
+		if !passed[i] {
+			waiting = append(waiting, name)
+		}
+	}
+	s.mu.Lock()
+	if s.stopping {
+		waiting = append(waiting, "shutdown")
+	}
+	s.mu.Unlock()
+	sort.Strings(waiting)
+	return waiting
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	writeStatus(w, http.StatusOK, map[string]interface{}{"status": "healthy"})
+}
+
+func (s *Server) ready(w http.ResponseWriter, r *http.Request) {
+	if waiting := s.waiting(r.Context()); len(waiting) > 0 {
+		writeStatus(w, http.StatusServiceUnavailable, map[string]interface{}{"status": "not ready", "waiting": waiting})
+		return
+	}
+	writeStatus(w, http.StatusOK, map[string]interface{}{"status": "ready"})
+}
+
+func writeStatus(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
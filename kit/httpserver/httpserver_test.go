@@ -0,0 +1,104 @@
+package httpserver_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/httpserver"
+	"github.com/influxdata/platform/kit/pool"
+	"github.com/influxdata/platform/kit/prom"
+)
+
+func TestServer(t *testing.T) {
+	var ready int32
+	srv := httpserver.New("127.0.0.1:0",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "service")
+		}),
+		httpserver.WithMetrics(prom.NewRegistry()),
+		httpserver.WithReadyCheck("warmup", func() bool { return atomic.LoadInt32(&ready) == 1 }),
+	)
+	if err := srv.Listen(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.ListenAndServe() }()
+
+	base := "http://" + srv.Addr().String()
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(base + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	if code, body := get("/api/v2/anything"); code != http.StatusOK || body != "service" {
+		t.Fatalf("expected the service handler to serve other paths, got %d %q", code, body)
+	}
+	if code, _ := get(httpserver.HealthPath); code != http.StatusOK {
+		t.Fatalf("expected healthy, got %d", code)
+	}
+	if code, _ := get(httpserver.MetricsPath); code != http.StatusOK {
+		t.Fatalf("expected metrics, got %d", code)
+	}
+	if code, _ := get(httpserver.ReadyPath); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected not ready before the check passes, got %d", code)
+	}
+	atomic.StoreInt32(&ready, 1)
+	if code, _ := get(httpserver.ReadyPath); code != http.StatusOK {
+		t.Fatalf("expected ready once the check passes, got %d", code)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected ListenAndServe to return nil after shutdown, got %v", err)
+	}
+	if srv.Ready() {
+		t.Fatal("expected the server not to be ready after shutdown")
+	}
+}
+
+func TestServer_CheckPool(t *testing.T) {
+	p := pool.New("checks", 2)
+	defer p.Close()
+
+	// Each check passes only once both are running, which they can only do in parallel.
+	var started sync.WaitGroup
+	started.Add(2)
+	check := func() bool {
+		started.Done()
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return true
+		case <-time.After(time.Second):
+			return false
+		}
+	}
+	srv := httpserver.New("127.0.0.1:0", http.NotFoundHandler(),
+		httpserver.WithCheckPool(p),
+		httpserver.WithReadyCheck("a", check),
+		httpserver.WithReadyCheck("b", check),
+	)
+	if !srv.Ready() {
+		t.Fatal("expected the checks to run in parallel and pass")
+	}
+}
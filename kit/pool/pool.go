@@ -0,0 +1,211 @@
+// Package pool provides a bounded pool of workers, so that a subsystem that does work in the background,
+// such as executing task runs or health checks, runs a bounded number of goroutines rather than one per piece of work.
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ErrClosed is returned when submitting to a closed Pool.
+var ErrClosed = errors.New("pool is closed")
+
+// Option configures a Pool.
+type Option func(p *Pool)
+
+// WithQueueSize sets how many submitted functions may wait for a free worker before Submit blocks.
+// By default, the queue holds as many functions as the pool has workers.
+func WithQueueSize(n int) Option {
+	return func(p *Pool) {
+		if n >= 0 {
+			p.queueSize = n
+		}
+	}
+}
+
+// Pool calls submitted functions on a fixed number of worker goroutines, queueing them while every worker is busy.
+// It is safe for concurrent use.
+type Pool struct {
+	name      string
+	workers   int
+	queueSize int
+	queue     chan queued
+
+	mu     sync.RWMutex // Guards closed, so that nothing is sent on queue once it is closed.
+	closed bool
+	wg     sync.WaitGroup
+
+	logger    *zap.Logger
+	busy      prometheus.Gauge
+	waiting   prometheus.Gauge
+	submitted prometheus.Counter
+	saturated prometheus.Counter
+	wait      prometheus.Histogram
+}
+
+// queued is a submitted function waiting for a free worker.
+type queued struct {
+	fn       func()
+	queuedAt time.Time
+}
+
+// New returns a Pool of the given number of workers, at least one, and starts its workers.
+// The name identifies the pool in logs and metrics, such as "task-runs".
+func New(name string, workers int, opts ...Option) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	labels := prometheus.Labels{"pool": name}
+	p := &Pool{
+		name:      name,
+		workers:   workers,
+		queueSize: workers,
+		logger:    zap.NewNop(),
+		busy: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "pool",
+			Name:        "busy_workers",
+			Help:        "Number of workers of a pool calling a submitted function.",
+			ConstLabels: labels,
+		}),
+		waiting: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "pool",
+			Name:        "queue_length",
+			Help:        "Number of submitted functions waiting for a free worker of a pool.",
+			ConstLabels: labels,
+		}),
+		submitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "pool",
+			Name:        "submitted_total",
+			Help:        "Number of functions submitted to a pool.",
+			ConstLabels: labels,
+		}),
+		saturated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "pool",
+			Name:        "saturated_total",
+			Help:        "Number of submits that found a pool's queue full, and had to wait for room in it.",
+			ConstLabels: labels,
+		}),
+		wait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   "pool",
+			Name:        "queue_wait_seconds",
+			Help:        "Time submitted functions waited for a free worker of a pool.",
+			Buckets:     []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 30},
+			ConstLabels: labels,
+		}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.queue = make(chan queued, p.queueSize)
+	p.wg.Add(p.workers)
+	for i := 0; i < p.workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// WithLogger sets the logger for the Pool.
+// The logger reports when the pool is closed.
+func (p *Pool) WithLogger(l *zap.Logger) {
+	p.logger = l.With(zap.String("service", "pool"), zap.String("pool", p.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (p *Pool) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{p.busy, p.waiting, p.submitted, p.saturated, p.wait}
+}
+
+// Submit queues fn to be called by a worker, waiting for room in the queue while it is full.
+// It returns ctx's error if ctx is done before fn is queued, and ErrClosed if the pool is closed;
+// in either case, fn is not called.
+func (p *Pool) Submit(ctx context.Context, fn func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	q := queued{fn: fn, queuedAt: time.Now()}
+	p.waiting.Inc()
+	select {
+	case p.queue <- q:
+	default:
+		p.saturated.Inc()
+		select {
+		case p.queue <- q:
+		case <-ctx.Done():
+			p.waiting.Dec()
+			return ctx.Err()
+		}
+	}
+	p.submitted.Inc()
+	return nil
+}
+
+// Run calls each of fns on the pool's workers, and waits for them to return.
+// If ctx is done or the pool is closed before every function is submitted, the rest are not called,
+// and Run returns the error once the functions submitted have returned.
+//
+// A nil Pool calls fns in turn on the calling goroutine.
+// Run must not be called from a function running on the pool, which could wait on itself for a free worker.
+func (p *Pool) Run(ctx context.Context, fns ...func()) error {
+	if p == nil {
+		for _, fn := range fns {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			fn()
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, fn := range fns {
+		fn := fn
+		wg.Add(1)
+		if err := p.Submit(ctx, func() {
+			defer wg.Done()
+			fn()
+		}); err != nil {
+			wg.Done()
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the pool from accepting functions, and waits for the workers to call the functions already queued.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.logger.Info("Pool closed")
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for q := range p.queue {
+		p.waiting.Dec()
+		p.wait.Observe(time.Since(q.queuedAt).Seconds())
+		p.busy.Inc()
+		q.fn()
+		p.busy.Dec()
+	}
+}
@@ -0,0 +1,88 @@
+package pool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/kit/pool"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+)
+
+func TestPool_Bounded(t *testing.T) {
+	p := pool.New("test", 2, pool.WithQueueSize(1))
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(p)
+
+	var running, maxRunning int32
+	release := make(chan struct{})
+	work := func() {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+	}
+
+	// Two functions occupy the workers, and a third fills the queue.
+	for i := int32(1); i <= 2; i++ {
+		if err := p.Submit(context.Background(), work); err != nil {
+			t.Fatal(err)
+		}
+		for atomic.LoadInt32(&running) < i {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if err := p.Submit(context.Background(), work); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fourth waits for room in the queue, until its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Submit(ctx, work); err != context.DeadlineExceeded {
+		t.Fatalf("expected the submit to time out, got %v", err)
+	}
+
+	close(release)
+	p.Close()
+	if max := atomic.LoadInt32(&maxRunning); max != 2 {
+		t.Fatalf("expected at most 2 functions running at once, got %d", max)
+	}
+	if n := atomic.LoadInt32(&running); n != 0 {
+		t.Fatalf("expected Close to wait for the queued functions, got %d still running", n)
+	}
+	if err := p.Submit(context.Background(), work); err != pool.ErrClosed {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	if got := promtest.MustFindMetric(t, mfs, "pool_submitted_total", map[string]string{"pool": "test"}).GetCounter().GetValue(); got != 3 {
+		t.Fatalf("expected 3 submitted functions, got %v", got)
+	}
+	if got := promtest.MustFindMetric(t, mfs, "pool_saturated_total", map[string]string{"pool": "test"}).GetCounter().GetValue(); got != 1 {
+		t.Fatalf("expected 1 saturated submit, got %v", got)
+	}
+}
+
+func TestPool_Run(t *testing.T) {
+	for _, p := range []*pool.Pool{nil, pool.New("test", 2)} {
+		var n int32
+		fns := make([]func(), 5)
+		for i := range fns {
+			fns[i] = func() { atomic.AddInt32(&n, 1) }
+		}
+		if err := p.Run(context.Background(), fns...); err != nil {
+			t.Fatal(err)
+		}
+		if got := atomic.LoadInt32(&n); got != 5 {
+			t.Fatalf("expected Run to call and wait for every function, got %d calls", got)
+		}
+	}
+}
@@ -40,6 +40,24 @@ func (r *Registry) WithLogger(l *zap.Logger) {
 	r.logger = l.With(zap.String("service", "prom_registry"))
 }
 
+// MustRegisterStandardCollectors registers the collectors every service exposes:
+// the Go runtime's metrics, and the process's CPU, memory, and file descriptor metrics.
+func (r *Registry) MustRegisterStandardCollectors() {
+	r.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+}
+
+// MustRegisterCollectors registers the collectors of each PrometheusCollector,
+// so that subsystems such as the task scheduler register their metrics in one call.
+// Like MustRegister, it panics if any collector cannot be registered.
+func (r *Registry) MustRegisterCollectors(pcs ...PrometheusCollector) {
+	for _, pc := range pcs {
+		r.MustRegister(pc.PrometheusCollectors()...)
+	}
+}
+
 // HTTPHandler returns an http.Handler for the registry,
 // so that the /metrics HTTP handler is uniformly configured across all apps in the platform.
 func (r *Registry) HTTPHandler() http.Handler {
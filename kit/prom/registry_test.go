@@ -62,3 +62,33 @@ func (errorCollector) Collect(ch chan<- prometheus.Metric) {
 		errors.New("invalid metric from errorCollector"),
 	)
 }
+
+func TestRegistry_MustRegisterCollectors(t *testing.T) {
+	reg := prom.NewRegistry()
+	reg.MustRegisterStandardCollectors()
+
+	c := prometheus.NewCounter(prometheus.CounterOpts{Name: "subsystem_total", Help: "A subsystem's counter."})
+	reg.MustRegisterCollectors(collectors{c})
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"go_goroutines", "process_start_time_seconds", "subsystem_total"} {
+		found := false
+		for _, mf := range mfs {
+			if mf.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected metric %s to be registered", name)
+		}
+	}
+}
+
+// collectors is a prom.PrometheusCollector for a fixed set of collectors.
+type collectors []prometheus.Collector
+
+func (cs collectors) PrometheusCollectors() []prometheus.Collector { return cs }
@@ -0,0 +1,153 @@
+// Package retry retries operations that fail with transient errors, waiting an exponentially growing, jittered delay between attempts.
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// Backoff describes how an operation is retried.
+//
+// After the first failed attempt, the delay before the next attempt is Initial.
+// It is multiplied by Multiplier after each further failure, up to Max,
+// and each delay is shortened by a random fraction of up to Jitter, so that callers that failed together do not retry together.
+type Backoff struct {
+	// Initial is the delay after the first failed attempt.
+	Initial time.Duration
+
+	// Max is the longest delay between attempts. Zero does not limit the delay.
+	Max time.Duration
+
+	// Multiplier is the factor the delay grows by after each failed attempt. A Multiplier less than 1 is treated as 2.
+	Multiplier float64
+
+	// Jitter is the largest fraction, between 0 and 1, by which each delay is randomly shortened.
+	Jitter float64
+
+	// MaxAttempts is the number of attempts made before giving up. Zero makes attempts until the context is done.
+	MaxAttempts int
+}
+
+// DefaultBackoff makes up to five attempts, waiting from 100ms to a few seconds between them.
+var DefaultBackoff = Backoff{
+	Initial:     100 * time.Millisecond,
+	Max:         10 * time.Second,
+	Multiplier:  2,
+	Jitter:      0.2,
+	MaxAttempts: 5,
+}
+
+var (
+	randMu sync.Mutex
+	rnd    = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Delay returns the delay before the attempt after the given number of failed attempts, which starts at 1.
+func (b Backoff) Delay(failures int) time.Duration {
+	if failures < 1 {
+		return 0
+	}
+	m := b.Multiplier
+	if m < 1 {
+		m = 2
+	}
+
+	d := float64(b.Initial) * math.Pow(m, float64(failures-1))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		randMu.Lock()
+		f := rnd.Float64()
+		randMu.Unlock()
+		d -= d * math.Min(b.Jitter, 1) * f
+	}
+	return time.Duration(d)
+}
+
+// Do calls fn until it succeeds, it fails with an error that is not retryable, b.MaxAttempts attempts fail, or ctx is done.
+// It returns nil if fn succeeded, and otherwise the last error fn returned, with any Permanent wrapping removed.
+// If ctx is done while waiting to retry, Do returns the last error from fn.
+func Do(ctx context.Context, b Backoff, fn func(context.Context) error) error {
+	for failures := 1; ; failures++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if !IsRetryable(err) {
+			return unwrap(err)
+		}
+		if b.MaxAttempts > 0 && failures >= b.MaxAttempts {
+			return err
+		}
+		if !Sleep(ctx, b.Delay(failures)) {
+			return err
+		}
+	}
+}
+
+// Sleep waits for d, and reports whether it did: false if ctx was done first.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// permanentError is an error that is not retried.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+// Permanent wraps err so that Do does not retry it, and returns it unwrapped.
+// Permanent returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+func unwrap(err error) error {
+	if pe, ok := err.(permanentError); ok {
+		return pe.err
+	}
+	return err
+}
+
+// IsRetryable reports whether an operation that failed with err may succeed if it is retried.
+//
+// An error is not retryable if it was wrapped with Permanent,
+// if it has a Retryable method that returns false,
+// or if it is a *platform.Error whose code means the request itself is at fault:
+// not found, conflict, invalid, empty value, or forbidden.
+// Any other error is retryable, and a nil error is not.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(permanentError); ok {
+		return false
+	}
+	if r, ok := err.(interface{ Retryable() bool }); ok {
+		return r.Retryable()
+	}
+	if _, ok := err.(*platform.Error); ok {
+		switch platform.ErrorCode(err) {
+		case platform.ENotFound, platform.EConflict, platform.EInvalid, platform.EEmptyValue, platform.EForbidden:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/retry"
+)
+
+func TestDo(t *testing.T) {
+	b := retry.Backoff{Initial: time.Millisecond, MaxAttempts: 3}
+	errTransient := errors.New("transient")
+
+	t.Run("succeeds after retrying", func(t *testing.T) {
+		attempts := 0
+		err := retry.Do(context.Background(), b, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errTransient
+			}
+			return nil
+		})
+		if err != nil || attempts != 3 {
+			t.Fatalf("expected success on attempt 3, got %v after %d attempts", err, attempts)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		attempts := 0
+		err := retry.Do(context.Background(), b, func(context.Context) error {
+			attempts++
+			return errTransient
+		})
+		if err != errTransient || attempts != 3 {
+			t.Fatalf("expected transient error after 3 attempts, got %v after %d attempts", err, attempts)
+		}
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		attempts := 0
+		errPermanent := errors.New("permanent")
+		err := retry.Do(context.Background(), b, func(context.Context) error {
+			attempts++
+			return retry.Permanent(errPermanent)
+		})
+		if err != errPermanent || attempts != 1 {
+			t.Fatalf("expected unwrapped permanent error after 1 attempt, got %v after %d attempts", err, attempts)
+		}
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		err := retry.Do(ctx, retry.Backoff{Initial: time.Hour}, func(context.Context) error {
+			attempts++
+			cancel()
+			return errTransient
+		})
+		if err != errTransient || attempts != 1 {
+			t.Fatalf("expected transient error after 1 attempt, got %v after %d attempts", err, attempts)
+		}
+	})
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	b := retry.Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 3}
+	for failures, exp := range map[int]time.Duration{
+		0: 0,
+		1: 100 * time.Millisecond,
+		2: 300 * time.Millisecond,
+		3: 900 * time.Millisecond,
+		4: time.Second,
+	} {
+		if got := b.Delay(failures); got != exp {
+			t.Errorf("expected delay %v after %d failures, got %v", exp, failures, got)
+		}
+	}
+
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(1); d < 50*time.Millisecond || d > 100*time.Millisecond {
+			t.Fatalf("expected jittered delay between 50ms and 100ms, got %v", d)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		err error
+		exp bool
+	}{
+		{err: nil, exp: false},
+		{err: errors.New("transient"), exp: true},
+		{err: retry.Permanent(errors.New("permanent")), exp: false},
+		{err: &platform.Error{Code: platform.EInvalid}, exp: false},
+		{err: &platform.Error{Code: platform.EUnavailable}, exp: true},
+	} {
+		if got := retry.IsRetryable(tc.err); got != tc.exp {
+			t.Errorf("expected IsRetryable(%v) to be %v", tc.err, tc.exp)
+		}
+	}
+}
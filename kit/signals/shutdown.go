@@ -0,0 +1,136 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Shutdown coordinates the graceful shutdown of a process.
+//
+// When shutdown begins, Ready reports false, so that a readiness check fails and load balancers stop sending requests.
+// After the drain period, the registered shutdown funcs run one at a time, in the order they were registered,
+// all within the shutdown deadline.
+type Shutdown struct {
+	drain   time.Duration
+	timeout time.Duration
+	logger  *zap.Logger
+
+	mu       sync.Mutex
+	funcs    []shutdownFunc
+	stopping bool
+
+	once sync.Once
+	err  error
+}
+
+type shutdownFunc struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// NewShutdown returns a Shutdown that waits drain after shutdown begins before running its shutdown funcs,
+// and gives them timeout to finish. A timeout of zero gives them until the context passed to Shutdown is done.
+func NewShutdown(drain, timeout time.Duration) *Shutdown {
+	return &Shutdown{
+		drain:   drain,
+		timeout: timeout,
+		logger:  zap.NewNop(),
+	}
+}
+
+// WithLogger sets the logger for the Shutdown.
+// The logger reports each step of the shutdown, and any shutdown func that fails.
+func (s *Shutdown) WithLogger(l *zap.Logger) {
+	s.logger = l.With(zap.String("service", "shutdown"))
+}
+
+// Register adds fn to the funcs run on shutdown, after those already registered.
+// The name identifies fn in logs and errors.
+func (s *Shutdown) Register(name string, fn func(context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.funcs = append(s.funcs, shutdownFunc{name: name, fn: fn})
+}
+
+// Ready reports whether the process is ready for requests: false once shutdown has begun.
+// It is suitable as a readiness check.
+func (s *Shutdown) Ready() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.stopping
+}
+
+// WaitForSignals blocks until the process receives any signal in sigs, or ctx is done, and then shuts down.
+// It returns the error from Shutdown.
+func (s *Shutdown) WaitForSignals(ctx context.Context, sigs ...os.Signal) error {
+	<-WithSignals(ctx, sigs...).Done()
+	// ctx may be what ended the wait, so it must not cut the shutdown short.
+	return s.Shutdown(context.Background())
+}
+
+// WaitForStandardSignals is WaitForSignals on os.Interrupt and syscall.SIGTERM.
+func (s *Shutdown) WaitForStandardSignals(ctx context.Context) error {
+	<-WithStandardSignals(ctx).Done()
+	return s.Shutdown(context.Background())
+}
+
+// Shutdown marks the process not ready, waits the drain period, and runs the registered shutdown funcs in order.
+// A func that fails does not stop the funcs after it from running;
+// the returned error lists every func that failed, or that did not run because the deadline passed.
+//
+// Only the first call shuts down. Later calls wait for it to finish and return the same error.
+func (s *Shutdown) Shutdown(ctx context.Context) error {
+	s.once.Do(func() {
+		s.err = s.shutdown(ctx)
+	})
+	return s.err
+}
+
+func (s *Shutdown) shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopping = true
+	funcs := append([]shutdownFunc(nil), s.funcs...)
+	s.mu.Unlock()
+
+	if s.drain > 0 {
+		s.logger.Info("Draining before shutdown", zap.Duration("period", s.drain))
+		t := time.NewTimer(s.drain)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+		}
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var failed []string
+	for _, f := range funcs {
+		if err := ctx.Err(); err != nil {
+			s.logger.Info("Shutdown deadline passed", zap.String("func", f.name), zap.Error(err))
+			failed = append(failed, fmt.Sprintf("%s: %v", f.name, err))
+			continue
+		}
+
+		s.logger.Info("Stopping", zap.String("func", f.name))
+		if err := f.fn(ctx); err != nil {
+			s.logger.Info("Failed to stop", zap.String("func", f.name), zap.Error(err))
+			failed = append(failed, fmt.Sprintf("%s: %v", f.name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("shutdown: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
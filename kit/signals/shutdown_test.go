@@ -0,0 +1,112 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdown_Order(t *testing.T) {
+	s := NewShutdown(50*time.Millisecond, time.Second)
+
+	var order []string
+	var readyDuringDrain bool
+	s.Register("first", func(context.Context) error {
+		order = append(order, "first")
+		readyDuringDrain = s.Ready()
+		return nil
+	})
+	s.Register("failing", func(context.Context) error {
+		order = append(order, "failing")
+		return errors.New("forced failure")
+	})
+	s.Register("last", func(context.Context) error {
+		order = append(order, "last")
+		return nil
+	})
+
+	if !s.Ready() {
+		t.Fatal("expected ready before shutdown")
+	}
+
+	start := time.Now()
+	err := s.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failing: forced failure") {
+		t.Fatalf("expected error from failing func, got %v", err)
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Fatalf("expected shutdown funcs to wait for the drain period, shut down after %v", d)
+	}
+	if exp := []string{"first", "failing", "last"}; !reflect.DeepEqual(order, exp) {
+		t.Fatalf("expected funcs to run in order %v, got %v", exp, order)
+	}
+	if readyDuringDrain || s.Ready() {
+		t.Fatal("expected not ready once shutdown began")
+	}
+
+	// Shutting down again does not rerun the funcs.
+	if err2 := s.Shutdown(context.Background()); err2 != err {
+		t.Fatalf("expected repeated shutdown to return %v, got %v", err, err2)
+	}
+	if len(order) != 3 {
+		t.Fatalf("expected funcs to run once, got %v", order)
+	}
+}
+
+func TestShutdown_Deadline(t *testing.T) {
+	s := NewShutdown(0, 20*time.Millisecond)
+
+	s.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	ran := false
+	s.Register("skipped", func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	err := s.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "slow:") || !strings.Contains(err.Error(), "skipped:") {
+		t.Fatalf("expected deadline errors for both funcs, got %v", err)
+	}
+	if ran {
+		t.Fatal("expected func after the deadline not to run")
+	}
+}
+
+func TestShutdown_WaitForSignals(t *testing.T) {
+	s := NewShutdown(0, time.Second)
+	stopped := make(chan struct{})
+	s.Register("service", func(context.Context) error {
+		close(stopped)
+		return nil
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.WaitForSignals(context.Background(), syscall.SIGUSR1)
+	}()
+
+	// Give WaitForSignals time to register for the signal.
+	time.Sleep(50 * time.Millisecond)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected shutdown after signal")
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("expected registered func to run")
+	}
+}
@@ -0,0 +1,86 @@
+// Package tracing configures the tracer of a service, and traces HTTP requests between services,
+// so that every service sets up and propagates traces the same way.
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/platform/snowflake"
+	pzap "github.com/influxdata/platform/zap"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// TypeNone is the tracer type that discards spans.
+	TypeNone = "none"
+
+	// TypeLog is the tracer type that logs each span when it finishes.
+	TypeLog = "log"
+)
+
+// NewTracer returns a tracer of the given type: TypeNone, or TypeLog, which logs spans to logger.
+// Exporting spans to a tracing system such as Jaeger or Zipkin requires its client library,
+// which this module does not depend on; NewTracer returns an error for those types.
+func NewTracer(typ string, logger *zap.Logger) (opentracing.Tracer, error) {
+	switch typ {
+	case TypeNone:
+		return opentracing.NoopTracer{}, nil
+	case TypeLog, "":
+		return &pzap.Tracer{
+			Logger:      logger,
+			IDGenerator: snowflake.NewIDGenerator(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tracing type %q; supported types are %s and %s", typ, TypeNone, TypeLog)
+	}
+}
+
+// TraceID returns the ID of the trace that sp belongs to, or the empty string if sp's tracer does not report trace IDs.
+// A tracer reports trace IDs through span contexts that have a TraceID method returning a string, as zap.Tracer's do.
+func TraceID(sp opentracing.Span) string {
+	if c, ok := sp.Context().(interface{ TraceID() string }); ok {
+		return c.TraceID()
+	}
+	return ""
+}
+
+// StartHTTPServerSpan starts a span named opName with tracer for the server's handling of r,
+// as a child of any span the client injected into r's headers, or as a new trace otherwise.
+// It returns the span, and r with the span in its context. The caller must finish the span.
+func StartHTTPServerSpan(tracer opentracing.Tracer, opName string, r *http.Request) (opentracing.Span, *http.Request) {
+	wireContext, _ := tracer.Extract(
+		opentracing.HTTPHeaders,
+		opentracing.HTTPHeadersCarrier(r.Header),
+	)
+
+	// If wireContext is nil, a root span is created.
+	span := tracer.StartSpan(opName, ext.RPCServerOption(wireContext))
+	return span, r.WithContext(opentracing.ContextWithSpan(r.Context(), span))
+}
+
+// Middleware returns a handler that traces each request to next in a span named after name and the request's path.
+func Middleware(tracer opentracing.Tracer, name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span, r := StartHTTPServerSpan(tracer, fmt.Sprintf("%s:%s", name, r.URL.Path), r)
+		span.LogFields(log.String("handler", name))
+		defer span.Finish()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InjectHTTP writes any span from the request's context into the request headers,
+// so that the server handling r traces it as part of the same trace.
+func InjectHTTP(r *http.Request) {
+	if span := opentracing.SpanFromContext(r.Context()); span != nil {
+		span.Tracer().Inject(
+			span.Context(),
+			opentracing.HTTPHeaders,
+			opentracing.HTTPHeadersCarrier(r.Header),
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform/kit/tracing"
+	opentracing "github.com/opentracing/opentracing-go"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNewTracer(t *testing.T) {
+	if _, err := tracing.NewTracer(tracing.TypeNone, zaptest.NewLogger(t)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tracing.NewTracer("jaeger", zaptest.NewLogger(t)); err == nil {
+		t.Fatal("expected error for unsupported tracer type")
+	}
+}
+
+func TestMiddleware_Propagates(t *testing.T) {
+	tracer, err := tracing.NewTracer(tracing.TypeLog, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server records the trace of the span it handles each request in.
+	var serverTraceID string
+	server := httptest.NewServer(tracing.Middleware(tracer, "test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sp := opentracing.SpanFromContext(r.Context()); sp != nil {
+			serverTraceID = tracing.TraceID(sp)
+		}
+	})))
+	defer server.Close()
+
+	clientSpan := tracer.StartSpan("client")
+	defer clientSpan.Finish()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = req.WithContext(opentracing.ContextWithSpan(req.Context(), clientSpan))
+	tracing.InjectHTTP(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if exp := tracing.TraceID(clientSpan); exp == "" || serverTraceID != exp {
+		t.Fatalf("expected server span in trace %q, got %q", exp, serverTraceID)
+	}
+}
@@ -0,0 +1,210 @@
+// Package transport provides HTTP middleware shared by the platform's services.
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// KeyFunc returns the key whose budget a request is charged to, such as its token or organization.
+// Requests with an empty key are not limited.
+type KeyFunc func(r *http.Request) string
+
+// KeyByToken keys requests by the token in their Authorization header, whatever its scheme.
+func KeyByToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if i := strings.IndexByte(auth, ' '); i >= 0 {
+		return auth[i+1:]
+	}
+	return auth
+}
+
+// KeyByOrg keys requests by the organization in their orgID parameter, as used by the task API.
+func KeyByOrg(r *http.Request) string {
+	return r.URL.Query().Get("orgID")
+}
+
+// KeyByIP keys requests by the IP address they came from.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// KeyByFirst keys requests by the first of keys that returns a key,
+// such as the token, and otherwise the IP address of requests that carry none.
+func KeyByFirst(keys ...KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		for _, k := range keys {
+			if key := k(r); key != "" {
+				return key
+			}
+		}
+		return ""
+	}
+}
+
+// sweepSize is the number of keys a RateLimiter holds before it forgets the keys that have been idle long enough to be full again.
+const sweepSize = 1024
+
+// RateLimiter limits the rate of HTTP requests with a token bucket per key.
+// Requests over the limit are refused with status 429 and a Retry-After header, without reaching the handler behind the limiter.
+type RateLimiter struct {
+	name  string
+	limit rate.Limit
+	burst int
+	key   KeyFunc
+
+	mu        sync.Mutex
+	limiters  map[string]*keyLimiter
+	lastSweep time.Time
+
+	logger  *zap.Logger
+	allowed prometheus.Counter
+	limited prometheus.Counter
+}
+
+type keyLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows each key perSecond requests per second on average,
+// and up to burst requests at once. If burst is less than 1, it is set to 1.
+// The name identifies the limiter in its metrics, such as "tasks".
+func NewRateLimiter(name string, perSecond float64, burst int, key KeyFunc) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		name:     name,
+		limit:    rate.Limit(perSecond),
+		burst:    burst,
+		key:      key,
+		limiters: make(map[string]*keyLimiter),
+		logger:   zap.NewNop(),
+		allowed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "http",
+			Subsystem: "ratelimit",
+			Name:      "allowed_total",
+			Help:      "Number of requests allowed through a rate limiter.",
+			// The limiter is a constant label, so that several limiters register in one registry.
+			ConstLabels: prometheus.Labels{"limiter": name},
+		}),
+		limited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "http",
+			Subsystem:   "ratelimit",
+			Name:        "limited_total",
+			Help:        "Number of requests refused by a rate limiter.",
+			ConstLabels: prometheus.Labels{"limiter": name},
+		}),
+	}
+}
+
+// WithLogger sets the logger for the RateLimiter.
+// The logger reports each request that is refused.
+func (l *RateLimiter) WithLogger(log *zap.Logger) {
+	l.logger = log.With(zap.String("service", "ratelimit"), zap.String("limiter", l.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (l *RateLimiter) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{l.allowed, l.limited}
+}
+
+// Middleware returns a handler that passes requests within the limit of their key to next.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wait := l.reserve(l.key(r), time.Now()); wait > 0 {
+			l.limited.Inc()
+			l.logger.Info("Request rate limited", zap.String("path", r.URL.Path), zap.String("remote_addr", r.RemoteAddr))
+
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfterSeconds(wait)))
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(&platform.Error{
+				Code: platform.ETooManyRequests,
+				Msg:  "rate limit exceeded",
+			})
+			return
+		}
+		l.allowed.Inc()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reserve takes a token for key at now, and returns zero, or, if key is over its limit,
+// how long until it has a token again, without taking any.
+func (l *RateLimiter) reserve(key string, now time.Time) time.Duration {
+	if key == "" {
+		return 0
+	}
+
+	rl := l.limiter(key, now)
+	res := rl.ReserveN(now, 1)
+	if !res.OK() {
+		return time.Second
+	}
+	if wait := res.DelayFrom(now); wait > 0 {
+		res.CancelAt(now)
+		return wait
+	}
+	return 0
+}
+
+func (l *RateLimiter) limiter(key string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.limiters) >= sweepSize {
+		l.sweep(now)
+	}
+
+	kl, ok := l.limiters[key]
+	if !ok {
+		kl = &keyLimiter{Limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = kl
+	}
+	kl.lastSeen = now
+	return kl.Limiter
+}
+
+// sweep forgets the keys that have been idle long enough for their buckets to be full, since they behave as new keys.
+// It runs at most once per refill period, so that a limiter holding many active keys does not sweep on every request.
+func (l *RateLimiter) sweep(now time.Time) {
+	refill := time.Minute
+	if l.limit > 0 {
+		refill = time.Duration(float64(l.burst) / float64(l.limit) * float64(time.Second))
+	}
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+	l.lastSweep = now
+
+	for key, kl := range l.limiters {
+		if now.Sub(kl.lastSeen) >= refill {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// retryAfterSeconds returns wait in whole seconds, rounded up, for a Retry-After header.
+func retryAfterSeconds(wait time.Duration) int {
+	s := int((wait + time.Second - 1) / time.Second)
+	if s < 1 {
+		return 1
+	}
+	return s
+}
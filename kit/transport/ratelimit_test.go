@@ -0,0 +1,71 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"github.com/influxdata/platform/kit/transport"
+)
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	l := transport.NewRateLimiter("test", 0.001, 2, transport.KeyByOrg)
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(l)
+
+	h := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(orgID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v2/tasks?orgID="+orgID, nil))
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := serve("0000000000000001"); w.Code != http.StatusOK {
+			t.Fatalf("expected request %d within the burst to succeed, got %d", i, w.Code)
+		}
+	}
+	w := serve("0000000000000001")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d past the burst, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header")
+	}
+
+	// Other keys have budgets of their own, and requests without a key are not limited.
+	if w := serve("0000000000000002"); w.Code != http.StatusOK {
+		t.Fatalf("expected another org's request to succeed, got %d", w.Code)
+	}
+	for i := 0; i < 3; i++ {
+		if w := serve(""); w.Code != http.StatusOK {
+			t.Fatalf("expected unkeyed request %d to succeed, got %d", i, w.Code)
+		}
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	labels := map[string]string{"limiter": "test"}
+	if got := promtest.MustFindMetric(t, mfs, "http_ratelimit_allowed_total", labels).GetCounter().GetValue(); got != 6 {
+		t.Fatalf("expected 6 allowed requests, got %v", got)
+	}
+	if got := promtest.MustFindMetric(t, mfs, "http_ratelimit_limited_total", labels).GetCounter().GetValue(); got != 1 {
+		t.Fatalf("expected 1 limited request, got %v", got)
+	}
+}
+
+func TestKeyByFirst(t *testing.T) {
+	key := transport.KeyByFirst(transport.KeyByToken, transport.KeyByIP)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:5000"
+	if got := key(r); got != "10.0.0.1" {
+		t.Fatalf("expected IP key without a token, got %q", got)
+	}
+
+	r.Header.Set("Authorization", "Token abc")
+	if got := key(r); got != "abc" {
+		t.Fatalf("expected token key, got %q", got)
+	}
+}
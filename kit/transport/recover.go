@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// Recoverer recovers the panics of HTTP handlers, so that a panic fails the request that caused it
+// rather than the whole server. Each panic is logged with its stack and the request's ID, and counted.
+type Recoverer struct {
+	name   string
+	logger *zap.Logger
+	panics prometheus.Counter
+}
+
+// NewRecoverer returns a Recoverer. The name identifies the handlers it recovers in its metrics, such as "tasks".
+func NewRecoverer(name string) *Recoverer {
+	return &Recoverer{
+		name:   name,
+		logger: zap.NewNop(),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "http",
+			Name:      "panics_total",
+			Help:      "Number of panics recovered in HTTP handlers.",
+			// The handler is a constant label, so that several recoverers register in one registry.
+			ConstLabels: prometheus.Labels{"handler": name},
+		}),
+	}
+}
+
+// WithLogger sets the logger for the Recoverer.
+// The logger reports each panic, with its stack.
+func (rc *Recoverer) WithLogger(log *zap.Logger) {
+	rc.logger = log.With(zap.String("service", "recover"), zap.String("handler", rc.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (rc *Recoverer) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{rc.panics}
+}
+
+// Middleware returns a handler that passes requests to next, and responds with status 500 and a platform.Error body
+// if next panics. A handler that has already written its response cannot have it replaced, so its response is cut short.
+// Panics with http.ErrAbortHandler, which abort a response on purpose, are passed on to the server.
+func (rc *Recoverer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			rc.panics.Inc()
+			rc.logger.Error("Recovered from panic in HTTP handler",
+				zap.String("panic", fmt.Sprint(v)),
+				zap.String("request_id", pcontext.GetRequestID(r.Context())),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.ByteString("stack", debug.Stack()),
+			)
+
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(&platform.Error{
+				Code: platform.EInternal,
+				Msg:  "internal error",
+			})
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,67 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"github.com/influxdata/platform/kit/transport"
+)
+
+func TestRecoverer_Middleware(t *testing.T) {
+	rc := transport.NewRecoverer("test")
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(rc)
+
+	h := rc.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", path, nil)
+		h.ServeHTTP(w, r.WithContext(pcontext.SetRequestID(r.Context(), "req1")))
+		return w
+	}
+
+	if w := serve("/ok"); w.Code != http.StatusOK {
+		t.Fatalf("expected status %d without a panic, got %d", http.StatusOK, w.Code)
+	}
+
+	w := serve("/panic")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status %d after a panic, got %d", http.StatusInternalServerError, w.Code)
+	}
+	var perr platform.Error
+	if err := json.NewDecoder(w.Body).Decode(&perr); err != nil {
+		t.Fatalf("expected a JSON error body: %v", err)
+	}
+	if perr.Code != platform.EInternal {
+		t.Fatalf("expected error code %q, got %q", platform.EInternal, perr.Code)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	labels := map[string]string{"handler": "test"}
+	if got := promtest.MustFindMetric(t, mfs, "http_panics_total", labels).GetCounter().GetValue(); got != 1 {
+		t.Fatalf("expected 1 recovered panic, got %v", got)
+	}
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	h := transport.NewRecoverer("test").Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Fatalf("expected http.ErrAbortHandler to be passed on, got %v", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/platform"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// AcceptVersionHeader is the request header with which a client asks for a version of an API, such as "v3".
+	AcceptVersionHeader = "Accept-Version"
+
+	// ContentVersionHeader is the response header reporting the version of the API that served the request.
+	ContentVersionHeader = "Content-Version"
+)
+
+// PathVersion splits a path of the form /api/<version>/<rest>, such as /api/v2/tasks, into its version, v2,
+// and the rest of the path, /tasks. A path without a version, such as /health, is returned whole, with an empty version.
+func PathVersion(path string) (version, rest string) {
+	const prefix = "/api/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", path
+	}
+	v := path[len(prefix):]
+	rest = ""
+	if i := strings.IndexByte(v, '/'); i >= 0 {
+		v, rest = v[:i], v[i:]
+	}
+	if !isVersion(v) {
+		return "", path
+	}
+	return v, rest
+}
+
+// isVersion reports whether s is a version name: a "v" followed by a number, such as v2.
+func isVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// VersionRouter routes the requests to an API among the handlers of each of its versions,
+// so that the API can change, such as the format of task run logs, without breaking the clients of earlier versions.
+//
+// A request asks for a version with the Accept-Version header, such as "v3" or "3", or else with its path, as in /api/v3/tasks.
+// Requests that ask for neither are served by the default version. The path of a request is rewritten to the version
+// that serves it, so that each version's handler routes the paths under its own prefix, such as /api/v3/.
+// The version that served a request is reported in the Content-Version response header.
+type VersionRouter struct {
+	name     string
+	def      string
+	handlers map[string]http.Handler
+
+	logger   *zap.Logger
+	requests *prometheus.CounterVec
+}
+
+// NewVersionRouter returns a VersionRouter that serves the default version of an API with h.
+// The name identifies the API in logs and metrics, such as "tasks".
+func NewVersionRouter(name, defaultVersion string, h http.Handler) *VersionRouter {
+	vr := &VersionRouter{
+		name:     name,
+		def:      normalizeVersion(defaultVersion),
+		handlers: make(map[string]http.Handler),
+		logger:   zap.NewNop(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "http",
+			Subsystem:   "api_version",
+			Name:        "requests_total",
+			Help:        "Number of requests to an API, split out by the version that served them, or unsupported.",
+			ConstLabels: prometheus.Labels{"api": name},
+		}, []string{"version"}),
+	}
+	vr.handlers[vr.def] = h
+	return vr
+}
+
+// Handle serves version of the API with h, in place of any handler it had.
+// It must be called before the router serves requests.
+func (vr *VersionRouter) Handle(version string, h http.Handler) {
+	vr.handlers[normalizeVersion(version)] = h
+}
+
+// Versions returns the versions of the API the router serves, in order.
+func (vr *VersionRouter) Versions() []string {
+	vs := make([]string, 0, len(vr.handlers))
+	for v := range vr.handlers {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool {
+		if len(vs[i]) != len(vs[j]) {
+			return len(vs[i]) < len(vs[j])
+		}
+		return vs[i] < vs[j]
+	})
+	return vs
+}
+
+// WithLogger sets the logger for the VersionRouter.
+// The logger reports requests for versions the router does not serve.
+func (vr *VersionRouter) WithLogger(log *zap.Logger) {
+	vr.logger = log.With(zap.String("service", "version"), zap.String("api", vr.name))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (vr *VersionRouter) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{vr.requests}
+}
+
+// ServeHTTP passes the request to the handler of the version it asks for.
+// A request for a version the router does not serve is refused with status 406 if it asked with the Accept-Version header,
+// or 404 if it asked with its path.
+func (vr *VersionRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pathVersion, rest := PathVersion(r.URL.Path)
+
+	version, status, code := vr.def, http.StatusNotFound, platform.ENotFound
+	if pathVersion != "" {
+		version = pathVersion
+	}
+	if accept := r.Header.Get(AcceptVersionHeader); accept != "" {
+		version, status, code = normalizeVersion(accept), http.StatusNotAcceptable, platform.EInvalid
+	}
+
+	h, ok := vr.handlers[version]
+	if !ok {
+		vr.requests.WithLabelValues("unsupported").Inc()
+		vr.logger.Debug("Request for unsupported API version", zap.String("version", version), zap.String("path", r.URL.Path))
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(&platform.Error{
+			Code: code,
+			Msg:  fmt.Sprintf("API version %q is not supported; supported versions are %s", version, strings.Join(vr.Versions(), ", ")),
+		})
+		return
+	}
+
+	if pathVersion != "" && pathVersion != version {
+		r = r.WithContext(r.Context())
+		u := *r.URL
+		u.Path, u.RawPath = "/api/"+version+rest, ""
+		r.URL = &u
+	}
+
+	vr.requests.WithLabelValues(version).Inc()
+	w.Header().Set(ContentVersionHeader, version)
+	h.ServeHTTP(w, r)
+}
+
+// normalizeVersion returns the name of the version v, adding the leading "v" if v is only a number, as in "3".
+func normalizeVersion(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v != "" && v[0] != 'v' {
+		v = "v" + v
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package transport_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"github.com/influxdata/platform/kit/transport"
+)
+
+func TestPathVersion(t *testing.T) {
+	for _, tt := range []struct {
+		path, version, rest string
+	}{
+		{path: "/api/v2/tasks", version: "v2", rest: "/tasks"},
+		{path: "/api/v3", version: "v3", rest: ""},
+		{path: "/api/vx/tasks", version: "", rest: "/api/vx/tasks"},
+		{path: "/health", version: "", rest: "/health"},
+	} {
+		if version, rest := transport.PathVersion(tt.path); version != tt.version || rest != tt.rest {
+			t.Errorf("PathVersion(%q): expected (%q, %q), got (%q, %q)", tt.path, tt.version, tt.rest, version, rest)
+		}
+	}
+}
+
+func TestVersionRouter(t *testing.T) {
+	// Each version's handler responds with the path it was given.
+	handler := func(version string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(version + " " + r.URL.Path))
+		})
+	}
+	vr := transport.NewVersionRouter("tasks", "v2", handler("v2"))
+	vr.Handle("3", handler("v3"))
+	reg := prom.NewRegistry()
+	reg.MustRegisterCollectors(vr)
+
+	for _, tt := range []struct {
+		name   string
+		path   string
+		accept string
+		code   int
+		body   string
+	}{
+		{name: "default", path: "/api/v2/tasks", code: http.StatusOK, body: "v2 /api/v2/tasks"},
+		{name: "by path", path: "/api/v3/tasks", code: http.StatusOK, body: "v3 /api/v3/tasks"},
+		{name: "by header", path: "/api/v2/tasks", accept: "v3", code: http.StatusOK, body: "v3 /api/v3/tasks"},
+		{name: "by header as number", path: "/api/v3/tasks", accept: "2", code: http.StatusOK, body: "v2 /api/v2/tasks"},
+		{name: "unsupported path", path: "/api/v9/tasks", code: http.StatusNotFound},
+		{name: "unsupported header", path: "/api/v2/tasks", accept: "v9", code: http.StatusNotAcceptable},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.accept != "" {
+				r.Header.Set(transport.AcceptVersionHeader, tt.accept)
+			}
+			w := httptest.NewRecorder()
+			vr.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				var perr platform.Error
+				if err := json.NewDecoder(w.Body).Decode(&perr); err != nil {
+					t.Fatalf("expected a JSON error body: %v", err)
+				}
+				return
+			}
+			if got := w.Body.String(); got != tt.body {
+				t.Fatalf("expected response %q, got %q", tt.body, got)
+			}
+			if got, exp := w.Header().Get(transport.ContentVersionHeader), tt.body[:2]; got != exp {
+				t.Fatalf("expected Content-Version %q, got %q", exp, got)
+			}
+		})
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	for version, exp := range map[string]float64{"v2": 2, "v3": 2, "unsupported": 2} {
+		labels := map[string]string{"api": "tasks", "version": version}
+		if got := promtest.MustFindMetric(t, mfs, "http_api_version_requests_total", labels).GetCounter().GetValue(); got != exp {
+			t.Fatalf("expected %v requests for %s, got %v", exp, version, got)
+		}
+	}
+}
@@ -8,6 +8,13 @@ type Config struct {
 	Format       string        `toml:"format"`
 	Level        zapcore.Level `toml:"level"`
 	SuppressLogo bool          `toml:"suppress-logo"`
+
+	// Version, if set, is logged with every message in the version field.
+	Version string `toml:"-"`
+
+	// Levels, if set, sets the level of the logger in place of Level,
+	// so that it and the loggers of its modules can be changed while the process runs. See Levels.Module.
+	Levels *Levels `toml:"-"`
 }
 
 // NewConfig returns a new instance of Config with defaults.
@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// ServiceKey is the logging context key used for identifying the module of a process that logged a message.
+	ServiceKey = "service"
+
+	// VersionKey is the logging context key used for identifying the version of the process.
+	VersionKey = "version"
+)
+
+// Levels are the log levels of a process and its modules, which can be changed while the process runs.
+// A module logs at the process's level, unless its own level has been set.
+//
+// Levels is an http.Handler, to serve as an admin endpoint.
+// GET reports the levels as JSON, and PUT sets the level of the module given in the JSON body,
+// such as {"module": "task-scheduler", "level": "debug"}.
+// An empty module sets the process's level, and an empty level resets a module to the process's level.
+type Levels struct {
+	mu      sync.RWMutex
+	level   zapcore.Level
+	modules map[string]zapcore.Level
+}
+
+// NewLevels returns Levels with the process's level set to lvl, and no module levels.
+func NewLevels(lvl zapcore.Level) *Levels {
+	return &Levels{
+		level:   lvl,
+		modules: make(map[string]zapcore.Level),
+	}
+}
+
+// SetLevel sets the level of the process.
+func (l *Levels) SetLevel(lvl zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = lvl
+}
+
+// SetModuleLevel sets the level of module, in place of the process's level.
+func (l *Levels) SetModuleLevel(module string, lvl zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.modules[module] = lvl
+}
+
+// ResetModuleLevel sets module back to logging at the process's level.
+func (l *Levels) ResetModuleLevel(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.modules, module)
+}
+
+// enabled reports whether module logs at lvl. An empty module is the process itself.
+func (l *Levels) enabled(module string, lvl zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if ml, ok := l.modules[module]; ok && module != "" {
+		return ml.Enabled(lvl)
+	}
+	return l.level.Enabled(lvl)
+}
+
+// Module returns a logger for the named module of the process, which logs with the module's name in its service field.
+// If log was created from a Config with these Levels, the returned logger logs at the module's level.
+func (l *Levels) Module(log *zap.Logger, module string) *zap.Logger {
+	return log.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		if lc, ok := c.(*levelCore); ok {
+			return &levelCore{Core: lc.Core, enabled: func(lvl zapcore.Level) bool { return l.enabled(module, lvl) }}
+		}
+		return c
+	})).With(zap.String(ServiceKey, module))
+}
+
+// levelsJSON is the JSON representation of Levels.
+type levelsJSON struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// moduleLevelJSON is the JSON body of a request to set a level.
+type moduleLevelJSON struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+// ServeHTTP reports the levels on GET, and sets a level on PUT.
+func (l *Levels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req moduleLevelJSON
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := l.set(req.Module, req.Level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(l.json()); err != nil {
+		fmt.Fprintf(w, "Error encoding levels: %v\n", err)
+	}
+}
+
+// set sets the level of module to the level named lvl, as requested of the admin endpoint.
+func (l *Levels) set(module, lvl string) error {
+	if lvl == "" {
+		if module == "" {
+			return fmt.Errorf("level is required to set the process's level")
+		}
+		l.ResetModuleLevel(module)
+		return nil
+	}
+
+	var level zapcore.Level
+	if err := level.Set(lvl); err != nil {
+		return fmt.Errorf("unknown log level %q", lvl)
+	}
+	if module == "" {
+		l.SetLevel(level)
+	} else {
+		l.SetModuleLevel(module, level)
+	}
+	return nil
+}
+
+func (l *Levels) json() levelsJSON {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	lj := levelsJSON{Level: l.level.String(), Modules: make(map[string]string, len(l.modules))}
+	modules := make([]string, 0, len(l.modules))
+	for m := range l.modules {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	for _, m := range modules {
+		lj.Modules[m] = l.modules[m].String()
+	}
+	return lj
+}
+
+// levelCore is a zapcore.Core that logs the entries its enabled func allows,
+// in place of the level of the core it wraps.
+type levelCore struct {
+	zapcore.Core
+	enabled func(zapcore.Level) bool
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.enabled(lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabled: c.enabled}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// WithTraceID returns log with the ID of the trace of the span in ctx in its trace_id field.
+// If ctx has no span, or the span's tracer does not report trace IDs, log is returned as is.
+func WithTraceID(ctx context.Context, log *zap.Logger) *zap.Logger {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return log
+	}
+	sc, ok := span.Context().(interface{ TraceID() string })
+	if !ok {
+		return log
+	}
+	return log.With(TraceID(sc.TraceID()))
+}
@@ -0,0 +1,76 @@
+package logger_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/platform/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevels_Module(t *testing.T) {
+	var buf bytes.Buffer
+	levels := logger.NewLevels(zapcore.InfoLevel)
+	config := logger.Config{Format: "logfmt", Version: "v1", Levels: levels}
+	log, err := config.New(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched := levels.Module(log, "task-scheduler")
+
+	sched.Debug("Hidden at info")
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing logged at debug, got %q", buf.String())
+	}
+
+	levels.SetModuleLevel("task-scheduler", zapcore.DebugLevel)
+	sched.Debug("Shown at debug")
+	log.Debug("Process still at info")
+	out := buf.String()
+	if !strings.Contains(out, "Shown at debug") || !strings.Contains(out, "service=task-scheduler") || !strings.Contains(out, "version=v1") {
+		t.Fatalf("expected module's debug message with service and version, got %q", out)
+	}
+	if strings.Contains(out, "Process still at info") {
+		t.Fatalf("expected process to keep its level, got %q", out)
+	}
+
+	buf.Reset()
+	levels.ResetModuleLevel("task-scheduler")
+	levels.SetLevel(zapcore.ErrorLevel)
+	sched.Info("Hidden at error")
+	if buf.Len() != 0 {
+		t.Fatalf("expected module to follow the process's level, got %q", buf.String())
+	}
+}
+
+func TestLevels_ServeHTTP(t *testing.T) {
+	levels := logger.NewLevels(zapcore.InfoLevel)
+
+	w := httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest("PUT", "/debug/log/levels", strings.NewReader(`{"module": "http", "level": "debug"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest("GET", "/debug/log/levels", nil))
+	exp := `{
+    "level": "info",
+    "modules": {
+        "http": "debug"
+    }
+}
+`
+	if got := w.Body.String(); got != exp {
+		t.Fatalf("expected levels %s, got %s", exp, got)
+	}
+
+	w = httptest.NewRecorder()
+	levels.ServeHTTP(w, httptest.NewRequest("PUT", "/debug/log/levels", strings.NewReader(`{"module": "http", "level": "loud"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d for an unknown level, got %d", http.StatusBadRequest, w.Code)
+	}
+}
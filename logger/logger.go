@@ -41,11 +41,27 @@ func (c *Config) New(defaultOutput io.Writer) (*zap.Logger, error) {
 	if err != nil {
 		return nil, err
 	}
-	return zap.New(zapcore.NewCore(
-		encoder,
-		zapcore.Lock(zapcore.AddSync(w)),
-		c.Level,
-	), zap.Fields(zap.String("log_id", nextID()))), nil
+
+	fields := []zapcore.Field{zap.String("log_id", nextID())}
+	if c.Version != "" {
+		fields = append(fields, zap.String(VersionKey, c.Version))
+	}
+
+	if c.Levels == nil {
+		return zap.New(zapcore.NewCore(
+			encoder,
+			zapcore.Lock(zapcore.AddSync(w)),
+			c.Level,
+		), zap.Fields(fields...)), nil
+	}
+
+	// The levels decide what is logged, so the core itself logs at every level.
+	core := zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(w)), zapcore.DebugLevel)
+	levels := c.Levels
+	return zap.New(&levelCore{
+		Core:    core,
+		enabled: func(lvl zapcore.Level) bool { return levels.enabled("", lvl) },
+	}, zap.Fields(fields...)), nil
 }
 
 func newEncoder(format string) (zapcore.Encoder, error) {
@@ -19,6 +19,14 @@ type TaskService struct {
 	FindRunByIDFn  func(context.Context, platform.ID, platform.ID) (*platform.Run, error)
 	CancelRunFn    func(context.Context, platform.ID, platform.ID) error
 	RetryRunFn     func(context.Context, platform.ID, platform.ID) (*platform.Run, error)
+	ForceRunFn     func(context.Context, platform.ID, int64) (*platform.Run, error)
+
+	FindTaskStatsFn func(context.Context, platform.ID) (*platform.TaskStats, error)
+
+	ExportTasksFn func(context.Context, platform.ID) (*platform.TaskBundle, error)
+	ImportTasksFn func(context.Context, platform.ID, platform.ID, *platform.TaskBundle) ([]*platform.Task, error)
+
+	WatchTasksFn func(context.Context, platform.TaskFilter) (<-chan platform.TaskEvent, error)
 }
 
 func (s *TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
@@ -61,3 +69,23 @@ func (s *TaskService) CancelRun(ctx context.Context, taskID, runID platform.ID)
 func (s *TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
 	return s.RetryRunFn(ctx, taskID, runID)
 }
+
+func (s *TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	return s.ForceRunFn(ctx, taskID, scheduledFor)
+}
+
+func (s *TaskService) FindTaskStats(ctx context.Context, taskID platform.ID) (*platform.TaskStats, error) {
+	return s.FindTaskStatsFn(ctx, taskID)
+}
+
+func (s *TaskService) ExportTasks(ctx context.Context, orgID platform.ID) (*platform.TaskBundle, error) {
+	return s.ExportTasksFn(ctx, orgID)
+}
+
+func (s *TaskService) ImportTasks(ctx context.Context, orgID, userID platform.ID, bundle *platform.TaskBundle) ([]*platform.Task, error) {
+	return s.ImportTasksFn(ctx, orgID, userID, bundle)
+}
+
+func (s *TaskService) WatchTasks(ctx context.Context, filter platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+	return s.WatchTasksFn(ctx, filter)
+}
@@ -0,0 +1,112 @@
+package nats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/influxdata/platform/kit/eventbus"
+	"go.uber.org/zap"
+)
+
+// EventBus is an eventbus.Bus that sends events through the NATS streaming server,
+// so that they reach subscribers in every process connected to it.
+// Events are published as JSON on the subject "events.<topic>", such as "events.task.deleted".
+//
+// Subscriptions with the same name, in any process, form a durable queue group on the topic's subject,
+// so each event is handled by only one of them, and events published while none of them is running
+// are delivered once one subscribes again.
+type EventBus struct {
+	Publisher  Publisher
+	Subscriber Subscriber
+	Logger     *zap.Logger
+}
+
+var _ eventbus.Bus = (*EventBus)(nil)
+
+// NewEventBus returns an EventBus that publishes with p and subscribes with s, which must both be open.
+func NewEventBus(p Publisher, s Subscriber) *EventBus {
+	return &EventBus{
+		Publisher:  p,
+		Subscriber: s,
+		Logger:     zap.NewNop(),
+	}
+}
+
+// envelope is the JSON form of an event published through NATS.
+type envelope struct {
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func eventSubject(topic eventbus.Topic) string {
+	return "events." + topic.Name()
+}
+
+// Publish publishes payload on topic's subject.
+func (b *EventBus) Publish(_ context.Context, topic eventbus.Topic, payload interface{}) error {
+	if err := topic.Check(payload); err != nil {
+		return err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(envelope{Time: time.Now().UTC(), Payload: data})
+	if err != nil {
+		return err
+	}
+	return b.Publisher.Publish(eventSubject(topic), bytes.NewReader(msg))
+}
+
+// Subscribe calls h with each event published on topic, until ctx is done.
+// Once ctx is done, the subscription is closed when its next event arrives, and that event is left for the rest of the group.
+func (b *EventBus) Subscribe(ctx context.Context, topic eventbus.Topic, name string, h eventbus.Handler) error {
+	return b.Subscriber.Subscribe(eventSubject(topic), name, &eventHandler{
+		ctx:     ctx,
+		topic:   topic,
+		handler: h,
+		logger:  b.Logger.With(zap.String("topic", topic.Name()), zap.String("subscriber", name)),
+	})
+}
+
+// eventHandler decodes the messages of a subscription to a topic, and passes them to the subscription's handler.
+type eventHandler struct {
+	ctx     context.Context
+	topic   eventbus.Topic
+	handler eventbus.Handler
+	logger  *zap.Logger
+}
+
+func (eh *eventHandler) Process(s Subscription, m Message) {
+	if eh.ctx.Err() != nil {
+		if err := s.Close(); err != nil {
+			eh.logger.Info("Failed to close event subscription", zap.Error(err))
+		}
+		return
+	}
+
+	e, err := eh.decode(m.Data())
+	if err != nil {
+		// The message will never decode, so acknowledge it rather than have it redelivered.
+		eh.logger.Info("Dropping invalid event", zap.Error(err))
+	} else {
+		eh.handler(eh.ctx, e)
+	}
+	if err := m.Ack(); err != nil {
+		eh.logger.Info("Failed to acknowledge event", zap.Error(err))
+	}
+}
+
+func (eh *eventHandler) decode(data []byte) (eventbus.Event, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return eventbus.Event{}, err
+	}
+	payload, err := eh.topic.Unmarshal(env.Payload)
+	if err != nil {
+		return eventbus.Event{}, err
+	}
+	return eventbus.Event{Topic: eh.topic.Name(), Time: env.Time, Payload: payload}, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/query"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // orgLabel is the metric label to use in the controller
@@ -22,6 +24,13 @@ type Controller struct {
 // NewController creates a new Controller specific to platform.
 func New(config control.Config) *Controller {
 	config.MetricLabelKeys = append(config.MetricLabelKeys, orgLabel)
+	if config.Logger != nil {
+		// The controller logs the spec and plans of each query at debug level.
+		// They hold the values of the secrets a task's script references, so they are left out.
+		config.Logger = config.Logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &omitFieldsCore{Core: core, omit: map[string]bool{"spec": true, "plan": true}}
+		}))
+	}
 	c := control.New(config)
 	return &Controller{c: c}
 }
@@ -54,3 +63,34 @@ func (c *Controller) PrometheusCollectors() []prometheus.Collector {
 func (c *Controller) Shutdown(ctx context.Context) error {
 	return c.c.Shutdown(ctx)
 }
+
+// omitFieldsCore is a zapcore.Core that drops the fields whose keys are in omit.
+type omitFieldsCore struct {
+	zapcore.Core
+	omit map[string]bool
+}
+
+func (c *omitFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &omitFieldsCore{Core: c.Core.With(c.filter(fields)), omit: c.omit}
+}
+
+func (c *omitFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *omitFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.filter(fields))
+}
+
+func (c *omitFieldsCore) filter(fields []zapcore.Field) []zapcore.Field {
+	out := fields[:0:0]
+	for _, f := range fields {
+		if !c.omit[f.Key] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -7,20 +7,44 @@ import (
 const (
 	TaskDefaultPageSize = 100
 	TaskMaxPageSize     = 500
+
+	RunDefaultPageSize = 20
+	RunMaxPageSize     = 100
+
+	// TaskBundleVersion is the version of the TaskBundle format written by ExportTasks.
+	TaskBundleVersion = 1
 )
 
 // Task is a task. 🎊
+//
+// Offset is how long after each scheduled time the task's run starts, as a duration such as "10m".
+// It is read from the task's stored schedule; when creating a task, a non-empty Offset sets the offset option of Flux.
 type Task struct {
-	ID              ID     `json:"id,omitempty"`
-	Organization    ID     `json:"organizationId"`
-	Name            string `json:"name"`
-	Status          string `json:"status"`
-	Owner           User   `json:"owner"`
-	Flux            string `json:"flux"`
-	Every           string `json:"every,omitempty"`
-	Cron            string `json:"cron,omitempty"`
-	Offset          string `json:"offset,omitempty"`
-	LatestCompleted string `json:"latest_completed,omitempty"`
+	ID              ID                `json:"id,omitempty"`
+	Organization    ID                `json:"organizationId"`
+	Name            string            `json:"name"`
+	Status          string            `json:"status"`
+	Owner           User              `json:"owner"`
+	Flux            string            `json:"flux"`
+	Every           string            `json:"every,omitempty"`
+	Cron            string            `json:"cron,omitempty"`
+	Offset          string            `json:"offset,omitempty"`
+	LatestCompleted string            `json:"latest_completed,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+
+	// RunAt is the time of the only run of a one-shot task, in RFC3339 format.
+	// It is empty for a task that runs on a schedule.
+	RunAt string `json:"runAt,omitempty"`
+
+	// LastRunStatus, LastRunError, and LastRunDuration describe how the task's latest executed run ended.
+	// They are empty if no run has finished.
+	LastRunStatus   string `json:"lastRunStatus,omitempty"`
+	LastRunError    string `json:"lastRunError,omitempty"`
+	LastRunDuration string `json:"lastRunDuration,omitempty"`
+
+	// IdempotencyKey optionally identifies a request to create the task, so that retrying the request
+	// returns the task already created instead of creating a duplicate. It is not stored with the task.
+	IdempotencyKey string `json:"-"`
 }
 
 // Run is a record created when a run of a task is scheduled.
@@ -33,6 +57,85 @@ type Run struct {
 	FinishedAt   string `json:"finishedAt,omitempty"`
 	RequestedAt  string `json:"requestedAt,omitempty"`
 	Log          Log    `json:"log"`
+
+	// RetryOf is the ID of the earlier run that this run retries, if it was created by RetryRun.
+	RetryOf ID `json:"retryOf,omitempty"`
+
+	// TraceID is the ID of the trace that follows the run from when it was scheduled until it finished,
+	// if the server's tracer reports trace IDs.
+	TraceID string `json:"traceID,omitempty"`
+}
+
+// TaskStats summarizes the health of a task over its latest runs.
+type TaskStats struct {
+	TaskID ID `json:"taskID"`
+
+	// Runs is the number of latest runs covered by the statistics.
+	Runs int `json:"runs"`
+
+	// Succeeded and Failed count the covered runs that succeeded, and that failed or timed out.
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+
+	// SuccessRate is Succeeded divided by the sum of Succeeded and Failed, or zero if both are zero.
+	SuccessRate float64 `json:"successRate"`
+
+	// Durations of the covered runs' execution, in seconds.
+	AverageDuration float64 `json:"averageDuration"`
+	MedianDuration  float64 `json:"medianDuration"`
+	P95Duration     float64 `json:"p95Duration"`
+
+	// LastFailure is the latest failed run, which may be older than the covered runs.
+	LastFailure *TaskRunFailure `json:"lastFailure,omitempty"`
+
+	// Usage is the resource use of all the task's runs, not only the covered runs.
+	Usage TaskUsage `json:"usage"`
+}
+
+// TaskUsage is the cumulative resource use of a task's executed runs.
+type TaskUsage struct {
+	// Runs is the number of executed runs covered.
+	Runs int64 `json:"runs"`
+
+	// ExecutionTime is the total time the runs took to execute, in seconds.
+	ExecutionTime float64 `json:"executionTime"`
+
+	// RowsWritten is the total number of rows the runs wrote.
+	RowsWritten int64 `json:"rowsWritten"`
+
+	// MemoryBytes is the sum of the most memory each run's query allocated at once,
+	// and MaxMemoryBytes is the most memory any one run's query allocated at once.
+	MemoryBytes    int64 `json:"memoryBytes"`
+	MaxMemoryBytes int64 `json:"maxMemoryBytes"`
+}
+
+// TaskRunFailure describes a failed run of a task.
+type TaskRunFailure struct {
+	RunID      ID     `json:"runID"`
+	FinishedAt string `json:"finishedAt"`
+	Error      string `json:"error"`
+}
+
+// TaskBundle is the set of tasks of an organization, as exported by ExportTasks and imported by ImportTasks.
+type TaskBundle struct {
+	Version      int           `json:"version"`
+	Organization ID            `json:"organizationId"`
+	ExportedAt   string        `json:"exportedAt"`
+	Tasks        []BundledTask `json:"tasks"`
+}
+
+// BundledTask is a task in a TaskBundle.
+// The task's schedule is part of its script; Every, Cron, and Offset are informational.
+type BundledTask struct {
+	// ID is the task's ID in the exporting organization.
+	ID     ID                `json:"id"`
+	Name   string            `json:"name"`
+	Status string            `json:"status"`
+	Flux   string            `json:"flux"`
+	Every  string            `json:"every,omitempty"`
+	Cron   string            `json:"cron,omitempty"`
+	Offset string            `json:"offset,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Log represents a link to a log resource
@@ -70,12 +173,60 @@ type TaskService interface {
 
 	// RetryRun creates and returns a new run (which is a retry of another run).
 	RetryRun(ctx context.Context, taskID, runID ID) (*Run, error)
+
+	// ForceRun creates and returns a new run of the task, for its first schedule at or after scheduledFor, a Unix timestamp.
+	// The run starts as soon as the task may run, rather than waiting for the time it is scheduled for.
+	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// FindTaskStats returns statistics about the latest runs of a task.
+	FindTaskStats(ctx context.Context, taskID ID) (*TaskStats, error)
+
+	// ExportTasks returns all of the tasks of an organization as a bundle.
+	ExportTasks(ctx context.Context, orgID ID) (*TaskBundle, error)
+
+	// ImportTasks creates the tasks of a bundle in an organization, owned by userID, and returns the created tasks.
+	// Importing the same bundle into the same organization again does not create duplicate tasks.
+	// If creating a task fails, the tasks created before it are returned along with the error.
+	ImportTasks(ctx context.Context, orgID, userID ID, bundle *TaskBundle) ([]*Task, error)
+
+	// WatchTasks returns a channel that receives an event for each task matching filter that is created, updated,
+	// or deleted after the call. Only the Organization, User, and Labels of filter are used.
+	// The channel is closed when ctx is done.
+	WatchTasks(ctx context.Context, filter TaskFilter) (<-chan TaskEvent, error)
+}
+
+// TaskEventType is the kind of change to a task reported by a TaskEvent.
+type TaskEventType string
+
+const (
+	TaskCreated TaskEventType = "created"
+	TaskUpdated TaskEventType = "updated"
+	TaskDeleted TaskEventType = "deleted"
+)
+
+// TaskEvent describes a change to a task, as reported by TaskService.WatchTasks.
+type TaskEvent struct {
+	Type   TaskEventType `json:"type"`
+	TaskID ID            `json:"taskID"`
+
+	// Task is the task as of the change. It is nil for deleted tasks.
+	Task *Task `json:"task,omitempty"`
 }
 
 // TaskUpdate represents updates to a task
 type TaskUpdate struct {
 	Flux   *string `json:"flux,omitempty"`
 	Status *string `json:"status,omitempty"`
+
+	// Name, Every, Cron, and Offset change the task options of the script, applied to Flux if set,
+	// or else to the task's existing script. Every and Offset are durations such as "1h30m".
+	Name   *string `json:"name,omitempty"`
+	Every  *string `json:"every,omitempty"`
+	Cron   *string `json:"cron,omitempty"`
+	Offset *string `json:"offset,omitempty"`
+
+	// Labels replaces all of the task's labels, when non-nil.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // TaskFilter represents a set of filters that restrict the returned results
@@ -84,6 +235,9 @@ type TaskFilter struct {
 	Organization *ID
 	User         *ID
 	Limit        int
+
+	// Labels restricts results to tasks that have all of these labels, with matching values.
+	Labels map[string]string
 }
 
 // RunFilter represents a set of filters that restrict the returned results
@@ -94,6 +248,12 @@ type RunFilter struct {
 	Limit      int
 	AfterTime  string
 	BeforeTime string
+
+	// Status restricts results to runs with this status, such as "failed", if set.
+	Status string
+
+	// Offset is the number of matching runs to skip before the first one returned.
+	Offset int
 }
 
 // LogFilter represents a set of filters that restrict the returned results
@@ -101,4 +261,8 @@ type LogFilter struct {
 	Org  *ID
 	Task *ID
 	Run  *ID
+
+	// Offset is the number of lines to skip at the start of the log, when filtering by Run,
+	// so that a reader following a run's log only reads the lines written since its last read.
+	Offset int
 }
@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// AuditAction is the kind of change to a task recorded in an AuditEntry.
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditModify  AuditAction = "modify"
+	AuditEnable  AuditAction = "enable"
+	AuditDisable AuditAction = "disable"
+	AuditDelete  AuditAction = "delete"
+
+	// AuditRestore is recorded when a deleted task is restored. See the coordinator's WithSoftDelete.
+	AuditRestore AuditAction = "restore"
+
+	// AuditTransfer is recorded in the audit logs of both the old and the new organization of a transferred task.
+	AuditTransfer AuditAction = "transfer"
+
+	// AuditRun is recorded when runs of a task are requested manually, or a run is retried.
+	// Its reason holds the requested time range or run, matching the requestedAt of the runs' scheduled log events.
+	AuditRun AuditAction = "run"
+)
+
+// DefaultAuditLimit is the number of entries ListAuditEntries returns when AuditSearchParams.Limit is zero.
+const DefaultAuditLimit = 100
+
+// MaxAuditLimit is the largest number of entries ListAuditEntries returns.
+const MaxAuditLimit = 1000
+
+// AuditEntry records who changed a task, how, and when.
+type AuditEntry struct {
+	// The task that was changed, and the org that owns it.
+	TaskID, Org platform.ID
+
+	Action AuditAction
+
+	// The authorizer that made the change, and the user it belongs to, as found in the request context.
+	// Zero if the change was not made on behalf of an authorizer, for example when a failing task is disabled automatically.
+	AuthorizerID   platform.ID
+	AuthorizerKind string
+	UserID         platform.ID
+
+	// The ID of the API request that made the change, as found in the request context. Empty if there was none.
+	RequestID string
+
+	// Hashes of the task's script before and after the change, as returned by ScriptHash.
+	// OldScriptHash is empty for AuditCreate, and NewScriptHash is empty for AuditDelete.
+	OldScriptHash, NewScriptHash string
+
+	// Reason explains the change, such as why the task was disabled automatically, or where it was transferred. May be empty.
+	Reason string
+
+	// When the change was made.
+	Time time.Time
+}
+
+// Validate returns an error if e is missing its task ID, org ID, or action.
+func (e AuditEntry) Validate() error {
+	if !e.TaskID.Valid() || !e.Org.Valid() || e.Action == "" {
+		return errors.New("audit entry requires task ID, org ID, and action")
+	}
+	return nil
+}
+
+// AuditSearchParams filters the entries returned by ListAuditEntries.
+type AuditSearchParams struct {
+	// If valid, only entries about the task with this ID are returned.
+	TaskID platform.ID
+
+	// If not zero, only entries with a Time after this are returned.
+	After time.Time
+
+	// The maximum number of entries to return.
+	// Zero means DefaultAuditLimit, and values greater than MaxAuditLimit are capped.
+	Limit int
+}
+
+// EffectiveLimit returns the maximum number of entries to return for p.
+func (p AuditSearchParams) EffectiveLimit() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultAuditLimit
+	case p.Limit > MaxAuditLimit:
+		return MaxAuditLimit
+	default:
+		return p.Limit
+	}
+}
+
+// Match reports whether e is one of the entries p selects, not accounting for the limit.
+func (p AuditSearchParams) Match(e AuditEntry) bool {
+	if p.TaskID.Valid() && e.TaskID != p.TaskID {
+		return false
+	}
+	return p.After.IsZero() || e.Time.After(p.After)
+}
+
+// ScriptHash returns the hex-encoded SHA-256 hash of script, used to identify scripts in audit entries.
+func ScriptHash(script string) string {
+	h := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(h[:])
+}
@@ -2,24 +2,45 @@
 //
 // The data stored in bolt is structured as follows:
 //
-//    bucket(/tasks/v1/tasks) key(:task_id) -> Content of submitted task (i.e. flux code).
+//    bucket(/tasks/v1/tasks) key(:task_id) -> Content of submitted task (i.e. flux code), encrypted if the store has a cipher.
 //    bucket(/tasks/v1/task_meta) key(:task_id) -> Protocol Buffer encoded backend.StoreTaskMeta,
 //                                    so we have a consistent view of runs in progress and max concurrency.
 //    bucket(/tasks/v1/org_by_task_id) key(task_id) -> The organization ID (stored as encoded string) associated with given task.
 //    bucket(/tasks/v1/user_by_task_id) key(:task_id) -> The user ID (stored as encoded string) associated with given task.
 //    buket(/tasks/v1/name_by_task_id) key(:task_id) -> The user-supplied name of the script.
+//    bucket(/tasks/v1/labels_by_task_id) key(:task_id) -> JSON-encoded map of the task's labels. Absent if the task has no labels.
+//    bucket(/tasks/v1/options_by_task_id) key(:task_id) -> JSON-encoded options.Options parsed from the task's script.
+//                                    Absent for tasks stored before options were recorded.
+//    bucket(/tasks/v1/versions_by_task_id) key(:task_id) -> JSON-encoded list of the task's retained backend.TaskVersions, oldest first.
+//                                    Their scripts are encrypted if the store has a cipher.
+//    bucket(/tasks/v1/run_history_by_task_id) key(:task_id) -> JSON-encoded backend.TaskRunHistory. Absent if no run has been recorded.
 //    bucket(/tasks/v1/run_ids) -> Counter for run IDs
+//    bucket(/tasks/v1/task_leases) key(:task_id) -> Big-endian uint64 expiration Unix timestamp, followed by the lease owner.
+//    bucket(/tasks/v1/lease_owners) key(:owner) -> Big-endian uint64 Unix timestamp of when the owner's keep-alive expires.
+//    bucket(/tasks/v1/leader) key(leader) -> The leader lease, encoded the same as entries in task_leases.
 //    bucket(/tasks/v1/orgs).bucket(:org_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from org to tasks.
 //    bucket(/tasks/v1/users).bucket(:user_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from user to tasks.
+//    bucket(/tasks/v1/audit).bucket(:org_id) key(:seq) -> JSON-encoded backend.AuditEntry, keyed by a big-endian uint64 sequence number
+//                                    so that entries are kept in the order they were appended.
+//    bucket(/tasks/v1/idempotency_keys).bucket(:org_id) key(:idempotency_key) -> The ID of the task created with the key.
+//                                    The task may since have been deleted, in which case the key may be reused.
+//    bucket(/tasks/v1/templates) key(:template_id) -> JSON-encoded backend.TaskTemplate.
+//    bucket(/tasks/v1/template_instances).bucket(:template_id) key(:task_id) -> JSON-encoded backend.TemplateInstance.
+//    bucket(/tasks/v1/quotas) key(:org_id) -> JSON-encoded backend.TaskQuota. Absent if the org has the default quota.
 // Note that task IDs are stored big-endian uint64s for sorting purposes,
 // but presented to the users with leading 0-bytes stripped.
 // Like other components of the system, IDs presented to users may be `0f12` rather than `f12`.
 package bolt
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	bolt "github.com/coreos/bbolt"
 	"github.com/influxdata/platform"
@@ -47,23 +68,72 @@ type Store struct {
 	db     *bolt.DB
 	bucket []byte
 	idGen  platform.IDGenerator
+
+	// Reports changes to tasks to WatchTasks callers.
+	changes backend.TaskChangeFeed
+
+	// Encrypts task scripts at rest, if set. See WithCipher.
+	cipher backend.Cipher
+
+	// The quota of orgs without one of their own. See WithDefaultQuota.
+	defaultQuota backend.TaskQuota
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithCipher encrypts task scripts, including their retained versions, with c before they are written to bolt.
+// Scripts written before c was set are still read as they were, and encrypted the next time they are written.
+func WithCipher(c backend.Cipher) Option {
+	return func(s *Store) {
+		s.cipher = c
+	}
+}
+
+// WithIDGenerator sets the generator of the IDs of tasks and runs. It defaults to a snowflake generator.
+func WithIDGenerator(g platform.IDGenerator) Option {
+	return func(s *Store) {
+		s.idGen = g
+	}
+}
+
+// WithDefaultQuota sets the quota of orgs that have not been given a quota with SetTaskQuota.
+// By default, such orgs are not limited.
+func WithDefaultQuota(q backend.TaskQuota) Option {
+	return func(s *Store) {
+		s.defaultQuota = q
+	}
 }
 
 const basePath = "/tasks/v1/"
 
 var (
-	tasksPath    = []byte(basePath + "tasks")
-	orgsPath     = []byte(basePath + "orgs")
-	usersPath    = []byte(basePath + "users")
-	taskMetaPath = []byte(basePath + "task_meta")
-	orgByTaskID  = []byte(basePath + "org_by_task_id")
-	userByTaskID = []byte(basePath + "user_by_task_id")
-	nameByTaskID = []byte(basePath + "name_by_task_id")
-	runIDs       = []byte(basePath + "run_ids")
+	tasksPath          = []byte(basePath + "tasks")
+	orgsPath           = []byte(basePath + "orgs")
+	usersPath          = []byte(basePath + "users")
+	taskMetaPath       = []byte(basePath + "task_meta")
+	orgByTaskID        = []byte(basePath + "org_by_task_id")
+	userByTaskID       = []byte(basePath + "user_by_task_id")
+	nameByTaskID       = []byte(basePath + "name_by_task_id")
+	labelsByTaskID     = []byte(basePath + "labels_by_task_id")
+	optionsByTaskID    = []byte(basePath + "options_by_task_id")
+	versionsByTaskID   = []byte(basePath + "versions_by_task_id")
+	runHistoryByTaskID = []byte(basePath + "run_history_by_task_id")
+	runIDs             = []byte(basePath + "run_ids")
+	taskLeases         = []byte(basePath + "task_leases")
+	leaseOwners        = []byte(basePath + "lease_owners")
+	leaderPath         = []byte(basePath + "leader")
+	auditPath          = []byte(basePath + "audit")
+	idempotencyKeys    = []byte(basePath + "idempotency_keys")
+	templatesPath      = []byte(basePath + "templates")
+	templateInstances  = []byte(basePath + "template_instances")
+	quotasPath         = []byte(basePath + "quotas")
 )
 
+var leaderKey = []byte("leader")
+
 // New gives us a new Store based on "github.com/coreos/bbolt"
-func New(db *bolt.DB, rootBucket string) (*Store, error) {
+func New(db *bolt.DB, rootBucket string, opts ...Option) (*Store, error) {
 	if db.IsReadOnly() {
 		return nil, ErrDBReadOnly
 	}
@@ -79,7 +149,9 @@ func New(db *bolt.DB, rootBucket string) (*Store, error) {
 		for _, b := range [][]byte{
 			tasksPath, orgsPath, usersPath, taskMetaPath,
 			orgByTaskID, userByTaskID,
-			nameByTaskID, runIDs,
+			nameByTaskID, labelsByTaskID, optionsByTaskID, versionsByTaskID, runHistoryByTaskID, runIDs,
+			taskLeases, leaseOwners, leaderPath, auditPath, idempotencyKeys,
+			templatesPath, templateInstances, quotasPath,
 		} {
 			_, err := root.CreateBucketIfNotExists(b)
 			if err != nil {
@@ -91,7 +163,11 @@ func New(db *bolt.DB, rootBucket string) (*Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Store{db: db, bucket: bucket, idGen: snowflake.NewDefaultIDGenerator()}, nil
+	s := &Store{db: db, bucket: bucket, idGen: snowflake.NewDefaultIDGenerator()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // CreateTask creates a task in the boltdb task store.
@@ -112,8 +188,20 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			return err
 		}
 
+		// idempotency key
+		if req.IdempotencyKey != "" {
+			existing, err := putIdempotencyKey(b, req.Org, req.IdempotencyKey, encodedID)
+			if err != nil {
+				return err
+			}
+			if existing.Valid() {
+				id = existing
+				return backend.ErrTaskAlreadyCreated
+			}
+		}
+
 		// write script
-		err = b.Bucket(tasksPath).Put(encodedID, []byte(req.Script))
+		err = s.putScript(b, encodedID, req.Script)
 		if err != nil {
 			return err
 		}
@@ -124,6 +212,21 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			return err
 		}
 
+		// labels
+		if err := putLabels(b, encodedID, req.Labels); err != nil {
+			return err
+		}
+
+		// parsed options
+		if err := putOptions(b, encodedID, &o); err != nil {
+			return err
+		}
+
+		// first version
+		if err := s.putVersions(b, encodedID, []backend.TaskVersion{{Version: 1, Script: req.Script, CreatedAt: time.Now().Unix()}}); err != nil {
+			return err
+		}
+
 		// Encode org ID
 		encodedOrg, err := req.Org.Encode()
 		if err != nil {
@@ -177,13 +280,40 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 		return metaB.Put(encodedID, stmBytes)
 	})
 
+	if err == backend.ErrTaskAlreadyCreated {
+		return id, err
+	}
 	if err != nil {
 		return platform.InvalidID(), err
 	}
 
+	s.changes.Publish(backend.TaskChange{TaskID: id, Created: true})
 	return id, nil
 }
 
+// putIdempotencyKey records key as the idempotency key of the task being created with the given encoded ID in org,
+// unless a task created with key in org still exists, in which case that task's ID is returned and nothing is recorded.
+func putIdempotencyKey(b *bolt.Bucket, org platform.ID, key string, encodedID []byte) (platform.ID, error) {
+	encodedOrg, err := org.Encode()
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	kb, err := b.Bucket(idempotencyKeys).CreateBucketIfNotExists(encodedOrg)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	if v := kb.Get([]byte(key)); v != nil && b.Bucket(tasksPath).Get(v) != nil {
+		var existing platform.ID
+		if err := existing.Decode(v); err != nil {
+			return platform.InvalidID(), err
+		}
+		return existing, nil
+	}
+
+	return platform.InvalidID(), kb.Put([]byte(key), encodedID)
+}
+
 func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
 	var res backend.UpdateTaskResult
 	op, err := backend.StoreValidator.UpdateArgs(req)
@@ -204,23 +334,52 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		if v == nil {
 			return backend.ErrTaskNotFound
 		}
-		res.OldScript = string(v)
+		oldScript, err := backend.DecryptAtRest(s.cipher, string(v))
+		if err != nil {
+			return err
+		}
+		res.OldScript = oldScript
 
-		newScript := req.Script
-		if req.Script == "" {
-			// Need to build op from existing script.
-			op, err = options.FromScript(string(v))
+		newScript, err := req.NewScript(res.OldScript)
+		if err != nil {
+			return err
+		}
+		if newScript != req.Script {
+			// Need to build op from existing or updated script.
+			op, err = options.FromScript(newScript)
 			if err != nil {
 				return err
 			}
-			newScript = string(v)
-		} else {
-			if err := bt.Put(encodedID, []byte(req.Script)); err != nil {
+		}
+		scriptChanged := newScript != res.OldScript
+		if scriptChanged {
+			versions, err := s.getVersions(b, encodedID)
+			if err != nil {
+				return err
+			}
+			versions = backend.AppendTaskVersion(versions, res.OldScript, newScript, time.Now().Unix())
+			if err := s.putVersions(b, encodedID, versions); err != nil {
+				return err
+			}
+			if err := s.putScript(b, encodedID, newScript); err != nil {
 				return err
 			}
 			if err := b.Bucket(nameByTaskID).Put(encodedID, []byte(op.Name)); err != nil {
 				return err
 			}
+			if err := putOptions(b, encodedID, &op); err != nil {
+				return err
+			}
+		}
+
+		if req.Labels != nil {
+			if err := putLabels(b, encodedID, req.Labels); err != nil {
+				return err
+			}
+		}
+		labels, err := getLabels(b, encodedID)
+		if err != nil {
+			return err
 		}
 
 		var userID, orgID platform.ID
@@ -232,6 +391,19 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			return err
 		}
 
+		if req.Org.Valid() && req.Org != orgID {
+			if err := moveTaskIndex(b, orgsPath, orgByTaskID, encodedID, orgID, req.Org); err != nil {
+				return err
+			}
+			orgID = req.Org
+		}
+		if req.User.Valid() && req.User != userID {
+			if err := moveTaskIndex(b, usersPath, userByTaskID, encodedID, userID, req.User); err != nil {
+				return err
+			}
+			userID = req.User
+		}
+
 		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
 		if stmBytes == nil {
 			return backend.ErrTaskNotFound
@@ -241,8 +413,15 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			return err
 		}
 		res.OldStatus = backend.TaskStatus(stm.Status)
-		if req.Status != "" {
-			stm.Status = string(req.Status)
+		if scriptChanged {
+			stm.ApplyOptions(op)
+		}
+		if req.MaxConcurrency > 0 {
+			stm.MaxConcurrency = req.MaxConcurrency
+		}
+		stm.ApplyStatus(req)
+		stm.ApplyNotification(req)
+		if scriptChanged || req.Status != "" || req.MaxConcurrency > 0 || req.Notification != nil {
 			stmBytes, err = stm.Marshal()
 			if err != nil {
 				return err
@@ -254,18 +433,106 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		res.NewMeta = stm
 
 		res.NewTask = backend.StoreTask{
-			ID:     req.ID,
-			Org:    orgID,
-			User:   userID,
-			Name:   op.Name,
-			Script: newScript,
+			ID:      req.ID,
+			Org:     orgID,
+			User:    userID,
+			Name:    op.Name,
+			Script:  newScript,
+			Labels:  labels,
+			Options: &op,
 		}
 
 		return nil
 	})
+	if err == nil {
+		s.changes.Publish(backend.TaskChange{TaskID: req.ID})
+	}
 	return res, err
 }
 
+// EnableTasks sets the tasks with the given IDs to active in a single transaction, and returns the IDs of those that changed.
+func (s *Store) EnableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ids, backend.TaskActive)
+}
+
+// DisableTasks sets the tasks with the given IDs to inactive in a single transaction, and returns the IDs of those that changed.
+func (s *Store) DisableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ids, backend.TaskInactive)
+}
+
+func (s *Store) setTasksStatus(ids []platform.ID, status backend.TaskStatus) ([]platform.ID, error) {
+	var changed []platform.ID
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		changed = changed[:0]
+		mb := tx.Bucket(s.bucket).Bucket(taskMetaPath)
+		for _, id := range ids {
+			encodedID, err := id.Encode()
+			if err != nil {
+				return err
+			}
+			stmBytes := mb.Get(encodedID)
+			if stmBytes == nil {
+				continue
+			}
+			var stm backend.StoreTaskMeta
+			if err := stm.Unmarshal(stmBytes); err != nil {
+				return err
+			}
+			if stm.Status == string(status) || stm.Status == string(backend.TaskDeleted) {
+				continue
+			}
+
+			stm.ApplyStatus(backend.UpdateTaskRequest{ID: id, Status: status})
+			stmBytes, err = stm.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := mb.Put(encodedID, stmBytes); err != nil {
+				return err
+			}
+			changed = append(changed, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range changed {
+		s.changes.Publish(backend.TaskChange{TaskID: id})
+	}
+	return changed, nil
+}
+
+// moveTaskIndex moves the task with the given encoded ID from the from owner's bucket beneath path to the to owner's bucket,
+// and records to as the task's owner in the byTaskID bucket.
+// It is used for both the org and user indexes.
+func moveTaskIndex(b *bolt.Bucket, path, byTaskID, encodedID []byte, from, to platform.ID) error {
+	encodedFrom, err := from.Encode()
+	if err != nil {
+		return err
+	}
+	encodedTo, err := to.Encode()
+	if err != nil {
+		return err
+	}
+
+	if fromB := b.Bucket(path).Bucket(encodedFrom); fromB != nil {
+		if err := fromB.Delete(encodedID); err != nil {
+			return err
+		}
+	}
+	toB, err := b.Bucket(path).CreateBucketIfNotExists(encodedTo)
+	if err != nil {
+		return err
+	}
+	if err := toB.Put(encodedID, nil); err != nil {
+		return err
+	}
+
+	return b.Bucket(byTaskID).Put(encodedID, encodedTo)
+}
+
 // ListTasks lists the tasks based on a filter.
 func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTaskWithMeta, error) {
 	if params.Org.Valid() && params.User.Valid() {
@@ -318,6 +585,11 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 			}
 			c.Seek(encodedAfter)
 			for k, _ := c.Next(); k != nil && len(taskIDs) < lim; k, _ = c.Next() {
+				if ok, err := hasLabels(b, k, params.Labels); err != nil {
+					return err
+				} else if !ok {
+					continue
+				}
 				var nID platform.ID
 				if err := nID.Decode(k); err != nil {
 					return err
@@ -326,6 +598,11 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 			}
 		} else {
 			for k, _ := c.First(); k != nil && len(taskIDs) < lim; k, _ = c.Next() {
+				if ok, err := hasLabels(b, k, params.Labels); err != nil {
+					return err
+				} else if !ok {
+					continue
+				}
 				var nID platform.ID
 				if err := nID.Decode(k); err != nil {
 					return err
@@ -347,8 +624,19 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 					return err
 				}
 				tasks[i].Task.ID = taskIDs[i]
-				tasks[i].Task.Script = string(b.Bucket(tasksPath).Get(encodedID))
+				tasks[i].Task.Script, err = backend.DecryptAtRest(s.cipher, string(b.Bucket(tasksPath).Get(encodedID)))
+				if err != nil {
+					return err
+				}
 				tasks[i].Task.Name = string(b.Bucket(nameByTaskID).Get(encodedID))
+				tasks[i].Task.Labels, err = getLabels(b, encodedID)
+				if err != nil {
+					return err
+				}
+				tasks[i].Task.Options, err = getOptions(b, encodedID)
+				if err != nil {
+					return err
+				}
 			}
 		}
 		if params.Org.Valid() {
@@ -447,6 +735,8 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
 	var userID, orgID platform.ID
 	var script, name string
+	var labels map[string]string
+	var opts *options.Options
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, err
@@ -457,7 +747,11 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 		if scriptBytes == nil {
 			return backend.ErrTaskNotFound
 		}
-		script = string(scriptBytes)
+		var err error
+		script, err = backend.DecryptAtRest(s.cipher, string(scriptBytes))
+		if err != nil {
+			return err
+		}
 
 		if err := userID.Decode(b.Bucket(userByTaskID).Get(encodedID)); err != nil {
 			return err
@@ -468,18 +762,26 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
-		return nil
+
+		labels, err = getLabels(b, encodedID)
+		if err != nil {
+			return err
+		}
+		opts, err = getOptions(b, encodedID)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Labels:  labels,
+		Options: opts,
 	}, err
 }
 
@@ -508,6 +810,8 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	var stmBytes []byte
 	var userID, orgID platform.ID
 	var script, name string
+	var labels map[string]string
+	var opts *options.Options
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, nil, err
@@ -518,7 +822,11 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 		if scriptBytes == nil {
 			return backend.ErrTaskNotFound
 		}
-		script = string(scriptBytes)
+		var err error
+		script, err = backend.DecryptAtRest(s.cipher, string(scriptBytes))
+		if err != nil {
+			return err
+		}
 
 		// Assign copies of everything so we don't hold a stale reference to a bolt-maintained byte slice.
 		stmBytes = append(stmBytes, b.Bucket(taskMetaPath).Get(encodedID)...)
@@ -532,7 +840,13 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
-		return nil
+
+		labels, err = getLabels(b, encodedID)
+		if err != nil {
+			return err
+		}
+		opts, err = getOptions(b, encodedID)
+		return err
 	})
 	if err != nil {
 		return nil, nil, err
@@ -544,89 +858,746 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Labels:  labels,
+		Options: opts,
 	}, &stm, nil
 }
 
-// DeleteTask deletes the task.
-func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
-	encodedID, err := id.Encode()
+var _ backend.TaskPurger = (*Store)(nil)
+
+// RetainedTaskIDs returns the IDs of deleted tasks whose idempotency keys are still recorded.
+func (s *Store) RetainedTaskIDs(ctx context.Context) ([]platform.ID, error) {
+	var ids []platform.ID
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		seen := make(map[string]bool)
+		keys := b.Bucket(idempotencyKeys)
+		return keys.ForEach(func(org, _ []byte) error {
+			kb := keys.Bucket(org)
+			if kb == nil {
+				return nil
+			}
+			return kb.ForEach(func(_, encodedID []byte) error {
+				if seen[string(encodedID)] || b.Bucket(tasksPath).Get(encodedID) != nil {
+					return nil
+				}
+				seen[string(encodedID)] = true
+
+				var id platform.ID
+				if err := id.Decode(encodedID); err != nil {
+					return err
+				}
+				ids = append(ids, id)
+				return nil
+			})
+		})
+	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	err = s.db.Batch(func(tx *bolt.Tx) error {
+	return ids, nil
+}
+
+// PurgeTask deletes the idempotency keys recorded for the deleted task.
+// Nothing is deleted if the task still exists.
+func (s *Store) PurgeTask(ctx context.Context, taskID platform.ID) (backend.PurgeResult, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return backend.PurgeResult{}, err
+	}
+
+	var res backend.PurgeResult
+	err = s.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket(s.bucket)
-		if check := b.Bucket(tasksPath).Get(encodedID); check == nil {
-			return backend.ErrTaskNotFound
+		if b.Bucket(tasksPath).Get(encodedID) != nil {
+			// The task still exists, so its idempotency key is still in use.
+			return nil
 		}
-		if err := b.Bucket(taskMetaPath).Delete(encodedID); err != nil {
-			return err
-		}
-		if err := b.Bucket(tasksPath).Delete(encodedID); err != nil {
+
+		// Collect the keys first, since bolt does not allow deleting while iterating.
+		type orgKey struct{ org, key []byte }
+		var purge []orgKey
+		keys := b.Bucket(idempotencyKeys)
+		if err := keys.ForEach(func(org, _ []byte) error {
+			kb := keys.Bucket(org)
+			if kb == nil {
+				return nil
+			}
+			return kb.ForEach(func(k, v []byte) error {
+				if bytes.Equal(v, encodedID) {
+					purge = append(purge, orgKey{org: append([]byte(nil), org...), key: append([]byte(nil), k...)})
+				}
+				return nil
+			})
+		}); err != nil {
 			return err
 		}
-		user := b.Bucket(userByTaskID).Get(encodedID)
-		if len(user) > 0 {
-			if err := b.Bucket(usersPath).Bucket(user).Delete(encodedID); err != nil {
+
+		for _, ok := range purge {
+			if err := keys.Bucket(ok.org).Delete(ok.key); err != nil {
 				return err
 			}
+			res.Records++
+			res.Bytes += int64(len(ok.key) + len(encodedID))
 		}
-		if err := b.Bucket(userByTaskID).Delete(encodedID); err != nil {
+		return nil
+	})
+	if err != nil {
+		return backend.PurgeResult{}, err
+	}
+	return res, nil
+}
+
+var _ backend.TaskImporter = (*Store)(nil)
+
+// ImportTask stores task along with its meta and versions, replacing any task with the same ID.
+func (s *Store) ImportTask(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta, versions []backend.TaskVersion) error {
+	if err := backend.StoreValidator.ImportArgs(task); err != nil {
+		return err
+	}
+
+	encodedID, err := task.ID.Encode()
+	if err != nil {
+		return err
+	}
+	stmBytes, err := meta.Marshal()
+	if err != nil {
+		return err
+	}
+
+	var created bool
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+
+		created = b.Bucket(tasksPath).Get(encodedID) == nil
+		if err := s.putScript(b, encodedID, task.Script); err != nil {
 			return err
 		}
-		if err := b.Bucket(nameByTaskID).Delete(encodedID); err != nil {
+		if err := b.Bucket(nameByTaskID).Put(encodedID, []byte(task.Name)); err != nil {
+			return err
+		}
+		if err := putLabels(b, encodedID, task.Labels); err != nil {
+			return err
+		}
+		if err := putOptions(b, encodedID, task.Options); err != nil {
+			return err
+		}
+		if err := s.putVersions(b, encodedID, versions); err != nil {
+			return err
+		}
+		if err := b.Bucket(taskMetaPath).Put(encodedID, stmBytes); err != nil {
 			return err
 		}
 
-		org := b.Bucket(orgByTaskID).Get(encodedID)
-		if len(org) > 0 {
-			if err := b.Bucket(orgsPath).Bucket(org).Delete(encodedID); err != nil {
+		// Index the task under its org and user, moving it from those of the task it replaces, if any.
+		for _, idx := range []struct {
+			path, byTaskID []byte
+			id             platform.ID
+		}{
+			{orgsPath, orgByTaskID, task.Org},
+			{usersPath, userByTaskID, task.User},
+		} {
+			from := idx.id
+			if v := b.Bucket(idx.byTaskID).Get(encodedID); v != nil {
+				if err := from.Decode(v); err != nil {
+					return err
+				}
+			}
+			if err := moveTaskIndex(b, idx.path, idx.byTaskID, encodedID, from, idx.id); err != nil {
 				return err
 			}
 		}
-		return b.Bucket(orgByTaskID).Delete(encodedID)
+		return nil
 	})
 	if err != nil {
-		if err == backend.ErrTaskNotFound {
-			return false, nil
-		}
-		return false, err
+		return err
 	}
-	return true, nil
-}
 
-func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
-	var rc backend.RunCreation
+	s.changes.Publish(backend.TaskChange{TaskID: task.ID, Created: created})
+	return nil
+}
 
-	encodedID, err := taskID.Encode()
+// ListTaskVersions returns the retained script versions of the task, oldest first.
+func (s *Store) ListTaskVersions(ctx context.Context, id platform.ID) ([]backend.TaskVersion, error) {
+	encodedID, err := id.Encode()
 	if err != nil {
-		return rc, err
+		return nil, err
 	}
 
-	if err := s.db.Update(func(tx *bolt.Tx) error {
+	var versions []backend.TaskVersion
+	err = s.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(s.bucket)
-		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
-		if stmBytes == nil {
+		script := b.Bucket(tasksPath).Get(encodedID)
+		if script == nil {
 			return backend.ErrTaskNotFound
 		}
 
-		var stm backend.StoreTaskMeta
-		err := stm.Unmarshal(stmBytes)
+		var err error
+		versions, err = s.getVersions(b, encodedID)
 		if err != nil {
 			return err
 		}
-
-		rc, err = stm.CreateNextRun(now, func() (platform.ID, error) {
-			return s.idGen.ID(), nil
-		})
-		if err != nil {
-			return err
+		if len(versions) == 0 {
+			// Created before versions were recorded.
+			first, err := backend.DecryptAtRest(s.cipher, string(script))
+			if err != nil {
+				return err
+			}
+			versions = []backend.TaskVersion{{Version: 1, Script: first}}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// RecordRunOutcome adds o to the run history of the task, and records it as the task's last run in its meta.
+func (s *Store) RecordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	_, err := s.recordRunOutcome(taskID, o, false)
+	return err
+}
+
+// CompleteRun finishes the run o.RunID and records its outcome o, in a single transaction.
+func (s *Store) CompleteRun(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	statusChanged, err := s.recordRunOutcome(taskID, o, true)
+	if err != nil {
+		return err
+	}
+	if statusChanged {
+		// A one-shot task was disabled after its run.
+		s.changes.Publish(backend.TaskChange{TaskID: taskID})
+	}
+	return nil
+}
+
+// recordRunOutcome records o in the meta and run history of the task with the given ID,
+// first finishing the run o.RunID if finish is true. It reports whether finishing the run changed the task's status.
+func (s *Store) recordRunOutcome(taskID platform.ID, o backend.RunOutcome, finish bool) (statusChanged bool, err error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return false, err
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(b.Bucket(taskMetaPath).Get(encodedID)); err != nil {
+			return err
+		}
+		if finish {
+			oldStatus := stm.Status
+			if !stm.FinishRun(o.RunID) {
+				return ErrRunNotFound
+			}
+			statusChanged = stm.Status != oldStatus
+		}
+		stm.RecordRunOutcome(o)
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+		if err := b.Bucket(taskMetaPath).Put(encodedID, stmBytes); err != nil {
+			return err
+		}
+
+		h, err := getRunHistory(b, encodedID)
+		if err != nil {
+			return err
+		}
+		h.Add(o)
+		h.DropSucceededThrough(stm.LatestCompleted)
+
+		v, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		return b.Bucket(runHistoryByTaskID).Put(encodedID, v)
+	})
+	if err != nil {
+		return false, err
+	}
+	return statusChanged, nil
+}
+
+// FindTaskStats returns statistics computed from the run history of the task.
+func (s *Store) FindTaskStats(ctx context.Context, taskID platform.ID) (*backend.TaskStats, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var stats backend.TaskStats
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		h, err := getRunHistory(b, encodedID)
+		if err != nil {
+			return err
+		}
+		stats = h.Stats()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// RunSucceeded reports whether the run history of the task records a successful run scheduled for now.
+func (s *Store) RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return false, err
+	}
+
+	var succeeded bool
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		h, err := getRunHistory(b, encodedID)
+		if err != nil {
+			return err
+		}
+		succeeded = h.HasSucceeded(now)
+		return nil
+	})
+	return succeeded, err
+}
+
+// AppendAuditEntry adds e to the audit log of its org.
+func (s *Store) AppendAuditEntry(ctx context.Context, e backend.AuditEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	encodedOrg, err := e.Org.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		orgB, err := tx.Bucket(s.bucket).Bucket(auditPath).CreateBucketIfNotExists(encodedOrg)
+		if err != nil {
+			return err
+		}
+
+		seq, err := orgB.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		return orgB.Put(key, v)
+	})
+}
+
+// ListAuditEntries lists the entries in the audit log of the org that match params.
+func (s *Store) ListAuditEntries(ctx context.Context, orgID platform.ID, params backend.AuditSearchParams) ([]backend.AuditEntry, error) {
+	encodedOrg, err := orgID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := params.EffectiveLimit()
+	var entries []backend.AuditEntry
+	err = s.db.View(func(tx *bolt.Tx) error {
+		orgB := tx.Bucket(s.bucket).Bucket(auditPath).Bucket(encodedOrg)
+		if orgB == nil {
+			return nil
+		}
+
+		c := orgB.Cursor()
+		for k, v := c.First(); k != nil && len(entries) < limit; k, v = c.Next() {
+			var e backend.AuditEntry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			if params.Match(e) {
+				entries = append(entries, e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CreateTemplate stores a new task template.
+func (s *Store) CreateTemplate(ctx context.Context, t backend.TaskTemplate) (platform.ID, error) {
+	if err := t.Validate(); err != nil {
+		return platform.InvalidID(), err
+	}
+
+	t.ID = s.idGen.ID()
+	t.Version = 1
+	encodedID, err := t.ID.Encode()
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	v, err := json.Marshal(t)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(templatesPath).Put(encodedID, v)
+	}); err != nil {
+		return platform.InvalidID(), err
+	}
+	return t.ID, nil
+}
+
+// UpdateTemplate replaces the content of a task template, and increments its version.
+func (s *Store) UpdateTemplate(ctx context.Context, t backend.TaskTemplate) (backend.TaskTemplate, error) {
+	encodedID, err := t.ID.Encode()
+	if err != nil {
+		return backend.TaskTemplate{}, err
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(templatesPath)
+		old, err := getTemplate(b, encodedID)
+		if err != nil {
+			return err
+		}
+
+		t.Org = old.Org
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		t.Version = old.Version + 1
+
+		v, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		return b.Put(encodedID, v)
+	})
+	if err != nil {
+		return backend.TaskTemplate{}, err
+	}
+	return t, nil
+}
+
+// FindTemplateByID returns the task template with the given ID.
+func (s *Store) FindTemplateByID(ctx context.Context, id platform.ID) (*backend.TaskTemplate, error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var t *backend.TaskTemplate
+	err = s.db.View(func(tx *bolt.Tx) error {
+		t, err = getTemplate(tx.Bucket(s.bucket).Bucket(templatesPath), encodedID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTemplates returns the task templates of an org.
+func (s *Store) ListTemplates(ctx context.Context, orgID platform.ID) ([]backend.TaskTemplate, error) {
+	var out []backend.TaskTemplate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(templatesPath).ForEach(func(k, v []byte) error {
+			var t backend.TaskTemplate
+			if err := json.Unmarshal(v, &t); err != nil {
+				return err
+			}
+			if t.Org == orgID {
+				out = append(out, t)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteTemplate deletes a task template and the records of its instances.
+func (s *Store) DeleteTemplate(ctx context.Context, id platform.ID) (deleted bool, err error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return false, err
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(templatesPath).Get(encodedID) == nil {
+			return nil
+		}
+		deleted = true
+
+		if err := b.Bucket(templatesPath).Delete(encodedID); err != nil {
+			return err
+		}
+		if b.Bucket(templateInstances).Bucket(encodedID) == nil {
+			return nil
+		}
+		return b.Bucket(templateInstances).DeleteBucket(encodedID)
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
+// PutTemplateInstance records the template and version a task was rendered from.
+func (s *Store) PutTemplateInstance(ctx context.Context, inst backend.TemplateInstance) error {
+	encodedTemplateID, err := inst.TemplateID.Encode()
+	if err != nil {
+		return err
+	}
+	encodedTaskID, err := inst.TaskID.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(templatesPath).Get(encodedTemplateID) == nil {
+			return backend.ErrTemplateNotFound
+		}
+
+		instB, err := b.Bucket(templateInstances).CreateBucketIfNotExists(encodedTemplateID)
+		if err != nil {
+			return err
+		}
+		return instB.Put(encodedTaskID, v)
+	})
+}
+
+// ListTemplateInstances returns the records of the tasks instantiated from a template.
+func (s *Store) ListTemplateInstances(ctx context.Context, templateID platform.ID) ([]backend.TemplateInstance, error) {
+	encodedID, err := templateID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []backend.TemplateInstance
+	err = s.db.View(func(tx *bolt.Tx) error {
+		instB := tx.Bucket(s.bucket).Bucket(templateInstances).Bucket(encodedID)
+		if instB == nil {
+			return nil
+		}
+
+		return instB.ForEach(func(k, v []byte) error {
+			var inst backend.TemplateInstance
+			if err := json.Unmarshal(v, &inst); err != nil {
+				return err
+			}
+			out = append(out, inst)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// getTemplate returns the template with the given encoded ID from the templates bucket b.
+func getTemplate(b *bolt.Bucket, encodedID []byte) (*backend.TaskTemplate, error) {
+	v := b.Get(encodedID)
+	if v == nil {
+		return nil, backend.ErrTemplateNotFound
+	}
+
+	t := new(backend.TaskTemplate)
+	if err := json.Unmarshal(v, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+var _ backend.QuotaStore = (*Store)(nil)
+
+// TaskQuota returns the quota of an org, or the store's default quota if the org has none of its own.
+func (s *Store) TaskQuota(ctx context.Context, org platform.ID) (backend.TaskQuota, error) {
+	encodedID, err := org.Encode()
+	if err != nil {
+		return backend.TaskQuota{}, err
+	}
+
+	q := s.defaultQuota
+	err = s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(s.bucket).Bucket(quotasPath).Get(encodedID)
+		if v == nil {
+			return nil
+		}
+		return json.Unmarshal(v, &q)
+	})
+	if err != nil {
+		return backend.TaskQuota{}, err
+	}
+	return q, nil
+}
+
+// SetTaskQuota sets the quota of an org, in place of the store's default quota.
+func (s *Store) SetTaskQuota(ctx context.Context, org platform.ID, q backend.TaskQuota) error {
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	encodedID, err := org.Encode()
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(quotasPath).Put(encodedID, v)
+	})
+}
+
+// DeleteTaskQuota removes the quota of an org, so that it has the store's default quota again.
+func (s *Store) DeleteTaskQuota(ctx context.Context, org platform.ID) (deleted bool, err error) {
+	encodedID, err := org.Encode()
+	if err != nil {
+		return false, err
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket).Bucket(quotasPath)
+		if b.Get(encodedID) == nil {
+			return nil
+		}
+		deleted = true
+		return b.Delete(encodedID)
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
+// DeleteTask deletes the task.
+func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
+	encodedID, err := id.Encode()
+	if err != nil {
+		return false, err
+	}
+	err = s.db.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if check := b.Bucket(tasksPath).Get(encodedID); check == nil {
+			return backend.ErrTaskNotFound
+		}
+		if err := b.Bucket(taskMetaPath).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(tasksPath).Delete(encodedID); err != nil {
+			return err
+		}
+		user := b.Bucket(userByTaskID).Get(encodedID)
+		if len(user) > 0 {
+			if err := b.Bucket(usersPath).Bucket(user).Delete(encodedID); err != nil {
+				return err
+			}
+		}
+		if err := b.Bucket(userByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(nameByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(taskLeases).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(labelsByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(optionsByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(versionsByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(runHistoryByTaskID).Delete(encodedID); err != nil {
+			return err
+		}
+
+		org := b.Bucket(orgByTaskID).Get(encodedID)
+		if len(org) > 0 {
+			if err := b.Bucket(orgsPath).Bucket(org).Delete(encodedID); err != nil {
+				return err
+			}
+		}
+		return b.Bucket(orgByTaskID).Delete(encodedID)
+	})
+	if err != nil {
+		if err == backend.ErrTaskNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	s.changes.Publish(backend.TaskChange{TaskID: id, Deleted: true})
+	return true, nil
+}
+
+func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+	var rc backend.RunCreation
+
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return rc, err
+	}
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		var stm backend.StoreTaskMeta
+		err := stm.Unmarshal(stmBytes)
+		if err != nil {
+			return err
 		}
-		rc.Created.TaskID = taskID
+
+		rc, err = stm.CreateNextRun(now, func() (platform.ID, error) {
+			return s.idGen.ID(), nil
+		})
+		if err != nil {
+			return err
+		}
+		rc.Created.TaskID = taskID
 
 		stmBytes, err = stm.Marshal()
 		if err != nil {
@@ -647,16 +1618,19 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 		return err
 	}
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	var statusChanged bool
+	if err := s.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket(s.bucket)
 		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
 		var stm backend.StoreTaskMeta
 		if err := stm.Unmarshal(stmBytes); err != nil {
 			return err
 		}
+		oldStatus := stm.Status
 		if !stm.FinishRun(runID) {
 			return ErrRunNotFound
 		}
+		statusChanged = stm.Status != oldStatus
 
 		stmBytes, err := stm.Marshal()
 		if err != nil {
@@ -664,10 +1638,32 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 		}
 
 		return tx.Bucket(s.bucket).Bucket(taskMetaPath).Put(encodedID, stmBytes)
-	})
+	}); err != nil {
+		return err
+	}
+
+	if statusChanged {
+		// A one-shot task was disabled after its run.
+		s.changes.Publish(backend.TaskChange{TaskID: taskID})
+	}
+	return nil
 }
 
 func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	return s.queueManualRun(taskID, func(stm *backend.StoreTaskMeta, makeID func() (platform.ID, error)) error {
+		return stm.ManuallyRunTimeRange(start, end, requestedAt, makeID)
+	})
+}
+
+func (s *Store) RetryRun(_ context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	return s.queueManualRun(taskID, func(stm *backend.StoreTaskMeta, makeID func() (platform.ID, error)) error {
+		return stm.RetryRun(scheduledFor, requestedAt, runID, makeID)
+	})
+}
+
+// queueManualRun calls queue to add a manual run to the meta of the task with the given ID,
+// and returns the manual run that was added.
+func (s *Store) queueManualRun(taskID platform.ID, queue func(stm *backend.StoreTaskMeta, makeID func() (platform.ID, error)) error) (*backend.StoreTaskMetaManualRun, error) {
 	encodedID, err := taskID.Encode()
 	if err != nil {
 		return nil, err
@@ -682,7 +1678,7 @@ func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 			return err
 		}
 		makeID := func() (platform.ID, error) { return s.idGen.ID(), nil }
-		if err := stm.ManuallyRunTimeRange(start, end, requestedAt, makeID); err != nil {
+		if err := queue(&stm, makeID); err != nil {
 			return err
 		}
 
@@ -699,6 +1695,182 @@ func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return mRun, nil
 }
 
+// AcquireTaskLease acquires or renews the lease on a task for owner.
+func (s *Store) AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if check := b.Bucket(tasksPath).Get(encodedID); check == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		if v := b.Bucket(taskLeases).Get(encodedID); v != nil {
+			l, err := decodeLease(taskID, v)
+			if err != nil {
+				return err
+			}
+			if l.Owner != owner && !l.Expired(now) {
+				return backend.ErrLeaseHeld
+			}
+		}
+
+		return b.Bucket(taskLeases).Put(encodedID, encodeLease(backend.TaskLease{
+			TaskID:    taskID,
+			Owner:     owner,
+			ExpiresAt: expiresAt,
+		}))
+	})
+}
+
+// ReleaseTaskLease removes the lease on a task, if held by owner.
+func (s *Store) ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		lb := tx.Bucket(s.bucket).Bucket(taskLeases)
+		v := lb.Get(encodedID)
+		if v == nil {
+			return nil
+		}
+		l, err := decodeLease(taskID, v)
+		if err != nil {
+			return err
+		}
+		if l.Owner != owner {
+			return nil
+		}
+		return lb.Delete(encodedID)
+	})
+}
+
+// ListTaskLeases returns every task lease in the store.
+func (s *Store) ListTaskLeases(ctx context.Context) ([]backend.TaskLease, error) {
+	var leases []backend.TaskLease
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(taskLeases).ForEach(func(k, v []byte) error {
+			var id platform.ID
+			if err := id.Decode(k); err != nil {
+				return err
+			}
+			l, err := decodeLease(id, v)
+			if err != nil {
+				return err
+			}
+			leases = append(leases, l)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// KeepAliveLeaseOwner records owner as participating in task leasing until expiresAt.
+func (s *Store) KeepAliveLeaseOwner(ctx context.Context, owner string, expiresAt int64) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(expiresAt))
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(leaseOwners).Put([]byte(owner), v)
+	})
+}
+
+// ListLeaseOwners returns the owners whose keep-alive has not expired as of now.
+func (s *Store) ListLeaseOwners(ctx context.Context, now int64) ([]string, error) {
+	var owners []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Bucket(leaseOwners).ForEach(func(k, v []byte) error {
+			if len(v) != 8 {
+				return fmt.Errorf("invalid keep-alive record for lease owner %q", k)
+			}
+			if int64(binary.BigEndian.Uint64(v)) > now {
+				owners = append(owners, string(k))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// AcquireLeaderLease acquires or renews the store-wide leader lease for owner.
+func (s *Store) AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		lb := tx.Bucket(s.bucket).Bucket(leaderPath)
+		if v := lb.Get(leaderKey); v != nil {
+			l, err := decodeLease(platform.InvalidID(), v)
+			if err != nil {
+				return err
+			}
+			if l.Owner != owner && !l.Expired(now) {
+				return backend.ErrLeaseHeld
+			}
+		}
+
+		return lb.Put(leaderKey, encodeLease(backend.TaskLease{Owner: owner, ExpiresAt: expiresAt}))
+	})
+}
+
+// ReleaseLeaderLease removes the leader lease, if held by owner.
+func (s *Store) ReleaseLeaderLease(ctx context.Context, owner string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		lb := tx.Bucket(s.bucket).Bucket(leaderPath)
+		v := lb.Get(leaderKey)
+		if v == nil {
+			return nil
+		}
+		l, err := decodeLease(platform.InvalidID(), v)
+		if err != nil {
+			return err
+		}
+		if l.Owner != owner {
+			return nil
+		}
+		return lb.Delete(leaderKey)
+	})
+}
+
+func encodeLease(l backend.TaskLease) []byte {
+	buf := make([]byte, 8+len(l.Owner))
+	binary.BigEndian.PutUint64(buf, uint64(l.ExpiresAt))
+	copy(buf[8:], l.Owner)
+	return buf
+}
+
+func decodeLease(taskID platform.ID, v []byte) (backend.TaskLease, error) {
+	if len(v) < 8 {
+		return backend.TaskLease{}, fmt.Errorf("invalid lease record for task %s", taskID)
+	}
+	return backend.TaskLease{
+		TaskID:    taskID,
+		Owner:     string(v[8:]),
+		ExpiresAt: int64(binary.BigEndian.Uint64(v)),
+	}, nil
+}
+
+// Backup writes a consistent snapshot of the store's database to w, and returns the number of bytes written.
+// The snapshot is taken in a read transaction, so the scheduler keeps running while it is written.
+// The database may hold more than the store, such as the platform's other resources, which are part of the snapshot too.
+func (s *Store) Backup(ctx context.Context, w io.Writer) (int64, error) {
+	var n int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
 // Close closes the store
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -711,6 +1883,7 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 		return err
 	}
 
+	var deleted []platform.ID
 	err = s.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket(s.bucket)
 		ub := b.Bucket(usersPath).Bucket(userID)
@@ -720,6 +1893,12 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 		c := ub.Cursor()
 		i := 0
 		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			var taskID platform.ID
+			if err := taskID.Decode(k); err != nil {
+				return err
+			}
+			deleted = append(deleted, taskID)
+
 			i++
 			// check for cancelation every 256 tasks deleted
 			if i&0xFF == 0 {
@@ -744,6 +1923,18 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(taskLeases).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(labelsByTaskID).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(versionsByTaskID).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(runHistoryByTaskID).Delete(k); err != nil {
+				return err
+			}
 
 			org := b.Bucket(orgByTaskID).Get(k)
 			if len(org) > 0 {
@@ -764,8 +1955,12 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 			return b.Bucket(usersPath).DeleteBucket(userID)
 		}
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.publishDeleted(deleted)
+	return nil
 }
 
 // DeleteOrg syncronously deletes an org and all their tasks from a bolt store.
@@ -775,7 +1970,10 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 		return err
 	}
 
-	return s.db.Batch(func(tx *bolt.Tx) error {
+	var deleted []platform.ID
+	err = s.db.Batch(func(tx *bolt.Tx) error {
+		// Batch may call this function more than once.
+		deleted = deleted[:0]
 		b := tx.Bucket(s.bucket)
 		ob := b.Bucket(orgsPath).Bucket(orgID)
 		if ob == nil {
@@ -784,6 +1982,12 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 		c := ob.Cursor()
 		i := 0
 		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			var taskID platform.ID
+			if err := taskID.Decode(k); err != nil {
+				return err
+			}
+			deleted = append(deleted, taskID)
+
 			i++
 			// check for cancelation every 256 tasks deleted
 			if i&0xFF == 0 {
@@ -808,6 +2012,18 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := b.Bucket(taskLeases).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(labelsByTaskID).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(versionsByTaskID).Delete(k); err != nil {
+				return err
+			}
+			if err := b.Bucket(runHistoryByTaskID).Delete(k); err != nil {
+				return err
+			}
 			user := b.Bucket(userByTaskID).Get(k)
 			if len(user) > 0 {
 				ub := b.Bucket(usersPath).Bucket(user)
@@ -826,4 +2042,149 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			return b.Bucket(orgsPath).DeleteBucket(orgID)
 		}
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishDeleted(deleted)
+	return nil
+}
+
+// publishDeleted reports the deletion of the tasks with the given IDs to WatchTasks callers.
+func (s *Store) publishDeleted(ids []platform.ID) {
+	for _, id := range ids {
+		s.changes.Publish(backend.TaskChange{TaskID: id, Deleted: true})
+	}
+}
+
+var _ backend.TaskWatcher = (*Store)(nil)
+
+// WatchTasks reports changes to tasks made through s.
+// Changes made through other Stores opening the same database are not reported.
+func (s *Store) WatchTasks(ctx context.Context) <-chan backend.TaskChange {
+	return s.changes.WatchTasks(ctx)
+}
+
+// putLabels stores labels for the task with the given encoded ID.
+// If labels is empty, any existing labels for the task are removed.
+func putLabels(b *bolt.Bucket, encodedID []byte, labels map[string]string) error {
+	lb := b.Bucket(labelsByTaskID)
+	if len(labels) == 0 {
+		return lb.Delete(encodedID)
+	}
+
+	v, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	return lb.Put(encodedID, v)
+}
+
+// getLabels returns the labels for the task with the given encoded ID, or nil if it has none.
+func getLabels(b *bolt.Bucket, encodedID []byte) (map[string]string, error) {
+	v := b.Bucket(labelsByTaskID).Get(encodedID)
+	if v == nil {
+		return nil, nil
+	}
+
+	var labels map[string]string
+	if err := json.Unmarshal(v, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// putOptions records opts as the options parsed from the script of the task with the given encoded ID.
+// If opts is nil, any recorded options are removed, so that the script is parsed when the options are needed.
+func putOptions(b *bolt.Bucket, encodedID []byte, opts *options.Options) error {
+	ob := b.Bucket(optionsByTaskID)
+	if opts == nil {
+		return ob.Delete(encodedID)
+	}
+
+	v, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	return ob.Put(encodedID, v)
+}
+
+// getOptions returns the options recorded for the task with the given encoded ID, or nil if none were recorded.
+func getOptions(b *bolt.Bucket, encodedID []byte) (*options.Options, error) {
+	v := b.Bucket(optionsByTaskID).Get(encodedID)
+	if v == nil {
+		return nil, nil
+	}
+
+	opts := new(options.Options)
+	if err := json.Unmarshal(v, opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// hasLabels reports whether the task with the given encoded ID matches the label selector.
+func hasLabels(b *bolt.Bucket, encodedID []byte, selector map[string]string) (bool, error) {
+	if len(selector) == 0 {
+		return true, nil
+	}
+
+	labels, err := getLabels(b, encodedID)
+	if err != nil {
+		return false, err
+	}
+	return backend.LabelsMatch(labels, selector), nil
+}
+
+// putScript stores the script of the task with the given encoded ID, encrypted if s has a cipher.
+func (s *Store) putScript(b *bolt.Bucket, encodedID []byte, script string) error {
+	script, err := backend.EncryptAtRest(s.cipher, script)
+	if err != nil {
+		return err
+	}
+	return b.Bucket(tasksPath).Put(encodedID, []byte(script))
+}
+
+// putVersions stores the script versions for the task with the given encoded ID, with their scripts encrypted if s has a cipher.
+func (s *Store) putVersions(b *bolt.Bucket, encodedID []byte, versions []backend.TaskVersion) error {
+	versions, err := backend.EncryptVersionsAtRest(s.cipher, versions)
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+	return b.Bucket(versionsByTaskID).Put(encodedID, v)
+}
+
+// getVersions returns the script versions for the task with the given encoded ID, or nil if none were recorded.
+func (s *Store) getVersions(b *bolt.Bucket, encodedID []byte) ([]backend.TaskVersion, error) {
+	v := b.Bucket(versionsByTaskID).Get(encodedID)
+	if v == nil {
+		return nil, nil
+	}
+
+	var versions []backend.TaskVersion
+	if err := json.Unmarshal(v, &versions); err != nil {
+		return nil, err
+	}
+	if err := backend.DecryptVersionsAtRest(s.cipher, versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// getRunHistory returns the run history for the task with the given encoded ID, which is empty if no runs were recorded.
+func getRunHistory(b *bolt.Bucket, encodedID []byte) (backend.TaskRunHistory, error) {
+	var h backend.TaskRunHistory
+	v := b.Bucket(runHistoryByTaskID).Get(encodedID)
+	if v == nil {
+		return h, nil
+	}
+
+	if err := json.Unmarshal(v, &h); err != nil {
+		return h, err
+	}
+	return h, nil
 }
@@ -1,11 +1,16 @@
 package bolt_test
 
 import (
+	"bytes"
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	bolt "github.com/coreos/bbolt"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/idgen"
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/influxdata/platform/task/backend"
 	boltstore "github.com/influxdata/platform/task/backend/bolt"
@@ -19,6 +24,68 @@ func init() {
 }
 
 func TestBoltStore(t *testing.T) {
+	testBoltStore(t)
+}
+
+func TestBoltStore_Cipher(t *testing.T) {
+	c, err := backend.NewAESCipher(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBoltStore(t, boltstore.WithCipher(c))
+}
+
+func TestBoltStore_ScriptEncryptedAtRest(t *testing.T) {
+	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	c, err := backend.NewAESCipher(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := boltstore.New(db, "testbucket", boltstore.WithCipher(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const secret = "bucket-with-a-secret-name"
+	script := `option task = {name: "a task", every: 1h} from(bucket: "` + secret + `") |> range(start: -1h)`
+	id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("testbucket")).ForEach(func(k, _ []byte) error {
+			return tx.Bucket([]byte("testbucket")).Bucket(k).ForEach(func(_, v []byte) error {
+				if bytes.Contains(v, []byte(secret)) {
+					t.Errorf("found script in bucket %s", k)
+				}
+				return nil
+			})
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := s.FindTaskByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Script != script {
+		t.Fatalf("expected decrypted script %q, got %q", script, task.Script)
+	}
+}
+
+func testBoltStore(t *testing.T, opts ...boltstore.Option) {
 	var f *os.File
 	storetest.NewStoreTest(
 		"boltstore",
@@ -32,7 +99,7 @@ func TestBoltStore(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to open bolt db for test db %v\n", err)
 			}
-			s, err := boltstore.New(db, "testbucket")
+			s, err := boltstore.New(db, "testbucket", opts...)
 			if err != nil {
 				t.Fatalf("failed to create new bolt store %v\n", err)
 			}
@@ -49,3 +116,151 @@ func TestBoltStore(t *testing.T) {
 		},
 	)(t)
 }
+
+func TestBoltStore_Backup(t *testing.T) {
+	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	s, err := boltstore.New(db, "testbucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := `option task = {name: "a task", every: 1h} from(bucket: "b") |> range(start: -1h)`
+	id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := ioutil.TempFile("", "influx_bolt_task_store_backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(backup.Name())
+	n, err := s.Backup(context.Background(), backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backup.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Fatal("expected a non-empty backup")
+	}
+
+	restoredDB, err := bolt.Open(backup.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restoredDB.Close()
+	restored, err := boltstore.New(restoredDB, "testbucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := restored.FindTaskByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Script != script {
+		t.Fatalf("expected restored script %q, got %q", script, task.Script)
+	}
+}
+
+func TestBoltStore_IDGenerator(t *testing.T) {
+	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	s, err := boltstore.New(db, "testbucket", boltstore.WithIDGenerator(idgen.NewSequenceGenerator(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := `option task = {name: "a task", every: 1h} from(bucket: "b") |> range(start: -1h)`
+	for want := platform.ID(100); want < 102; want++ {
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != want {
+			t.Fatalf("expected task ID %s from the generator, got %s", want, id)
+		}
+	}
+}
+
+func TestBoltStore_Quotas(t *testing.T) {
+	f, err := ioutil.TempFile("", "influx_bolt_task_store_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	db, err := bolt.Open(f.Name(), os.ModeTemporary, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	def := backend.TaskQuota{MaxTasks: 10}
+	s, err := boltstore.New(db, "testbucket", boltstore.WithDefaultQuota(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := platform.ID(1)
+	if q, err := s.TaskQuota(ctx, org); err != nil {
+		t.Fatal(err)
+	} else if q != def {
+		t.Fatalf("expected the default quota %+v, got %+v", def, q)
+	}
+
+	if err := s.SetTaskQuota(ctx, org, backend.TaskQuota{MaxTasks: -1}); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected an invalid quota to be rejected, got %v", err)
+	}
+
+	want := backend.TaskQuota{MaxTasks: 2, MaxRunsPerSecond: 0.5, MaxRunDuration: time.Minute, MaxRunHistory: 20}
+	if err := s.SetTaskQuota(ctx, org, want); err != nil {
+		t.Fatal(err)
+	}
+	if q, err := s.TaskQuota(ctx, org); err != nil {
+		t.Fatal(err)
+	} else if q != want {
+		t.Fatalf("expected quota %+v, got %+v", want, q)
+	}
+	if q, err := s.TaskQuota(ctx, platform.ID(2)); err != nil {
+		t.Fatal(err)
+	} else if q != def {
+		t.Fatalf("expected another org to keep the default quota %+v, got %+v", def, q)
+	}
+
+	if deleted, err := s.DeleteTaskQuota(ctx, org); err != nil {
+		t.Fatal(err)
+	} else if !deleted {
+		t.Fatal("expected the quota to be deleted")
+	}
+	if deleted, err := s.DeleteTaskQuota(ctx, org); err != nil {
+		t.Fatal(err)
+	} else if deleted {
+		t.Fatal("expected no quota left to delete")
+	}
+	if q, err := s.TaskQuota(ctx, org); err != nil {
+		t.Fatal(err)
+	} else if q != def {
+		t.Fatalf("expected the default quota again %+v, got %+v", def, q)
+	}
+}
@@ -0,0 +1,129 @@
+package backend
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Cipher encrypts the task scripts and run logs that stores keep at rest.
+// Implementations may keep their key in memory, as NewAESCipher does, or defer to an external key management service.
+type Cipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+var (
+	// ErrNoCipher is returned when reading a value that was encrypted at rest, from a store that has no Cipher to decrypt it.
+	ErrNoCipher = errors.New("value is encrypted, but no cipher is configured")
+
+	// ErrCiphertextTooShort is returned when decrypting a value that is too short to have been encrypted by an AES cipher.
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+)
+
+// encryptedPrefix marks values encrypted by EncryptAtRest.
+// Flux scripts and run log lines cannot start with it, so values stored before encryption was enabled are told apart.
+const encryptedPrefix = "enc1:"
+
+// EncryptAtRest returns s encrypted with c, for a store to keep at rest.
+// If c is nil, s is returned unchanged.
+func EncryptAtRest(c Cipher, s string) (string, error) {
+	if c == nil {
+		return s, nil
+	}
+	b, err := c.Encrypt([]byte(s))
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + base64.RawStdEncoding.EncodeToString(b), nil
+}
+
+// DecryptAtRest returns the value that EncryptAtRest encrypted as s.
+// Values stored before encryption was enabled are returned unchanged,
+// so that a store can start encrypting without rewriting what it already holds.
+func DecryptAtRest(c Cipher, s string) (string, error) {
+	if !strings.HasPrefix(s, encryptedPrefix) {
+		return s, nil
+	}
+	if c == nil {
+		return "", ErrNoCipher
+	}
+
+	b, err := base64.RawStdEncoding.DecodeString(s[len(encryptedPrefix):])
+	if err != nil {
+		return "", err
+	}
+	b, err = c.Decrypt(b)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncryptVersionsAtRest returns a copy of versions with their scripts encrypted by EncryptAtRest.
+func EncryptVersionsAtRest(c Cipher, versions []TaskVersion) ([]TaskVersion, error) {
+	if c == nil {
+		return versions, nil
+	}
+	out := make([]TaskVersion, len(versions))
+	for i, v := range versions {
+		script, err := EncryptAtRest(c, v.Script)
+		if err != nil {
+			return nil, err
+		}
+		v.Script = script
+		out[i] = v
+	}
+	return out, nil
+}
+
+// DecryptVersionsAtRest decrypts the scripts of versions in place, as encrypted by EncryptVersionsAtRest.
+func DecryptVersionsAtRest(c Cipher, versions []TaskVersion) error {
+	for i := range versions {
+		script, err := DecryptAtRest(c, versions[i].Script)
+		if err != nil {
+			return err
+		}
+		versions[i].Script = script
+	}
+	return nil
+}
+
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher returns a Cipher that encrypts with AES-GCM, using key.
+// The key must be 16, 24, or 32 bytes long, to select AES-128, AES-192, or AES-256.
+// Each value is encrypted with a random nonce, which is stored before the ciphertext.
+func NewAESCipher(key []byte) (Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return aesCipher{aead: aead}, nil
+}
+
+func (c aesCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c aesCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	n := c.aead.NonceSize()
+	if len(ciphertext) < n {
+		return nil, ErrCiphertextTooShort
+	}
+	return c.aead.Open(nil, ciphertext[:n], ciphertext[n:], nil)
+}
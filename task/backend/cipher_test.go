@@ -0,0 +1,57 @@
+package backend_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestEncryptAtRest(t *testing.T) {
+	c, err := backend.NewAESCipher(bytes.Repeat([]byte{7}, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const script = `option task = {name: "a task", every: 1h} from(bucket: "b") |> range(start: -1h)`
+	enc, err := backend.EncryptAtRest(c, script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(enc, "bucket") {
+		t.Fatalf("expected script to be encrypted, got %q", enc)
+	}
+	if enc2, _ := backend.EncryptAtRest(c, script); enc2 == enc {
+		t.Fatal("expected each encryption to use a new nonce")
+	}
+
+	dec, err := backend.DecryptAtRest(c, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec != script {
+		t.Fatalf("expected %q, got %q", script, dec)
+	}
+
+	// Values stored before encryption was enabled are read as they are.
+	if dec, err := backend.DecryptAtRest(c, script); err != nil || dec != script {
+		t.Fatalf("expected unencrypted script to be returned unchanged, got %q, %v", dec, err)
+	}
+
+	if _, err := backend.DecryptAtRest(nil, enc); err != backend.ErrNoCipher {
+		t.Fatalf("expected ErrNoCipher, got %v", err)
+	}
+
+	other, err := backend.NewAESCipher(bytes.Repeat([]byte{8}, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.DecryptAtRest(other, enc); err == nil {
+		t.Fatal("expected decrypting with the wrong key to fail")
+	}
+
+	if _, err := backend.NewAESCipher([]byte("short")); err == nil {
+		t.Fatal("expected invalid key length to fail")
+	}
+}
@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultCompactionInterval is how often a Compactor purges the data retained about deleted tasks,
+// unless set with WithCompactionInterval.
+const DefaultCompactionInterval = 24 * time.Hour
+
+// Compactor purges the data that TaskPurgers retain about deleted tasks,
+// either periodically or as each task is deleted.
+type Compactor struct {
+	st       Store
+	purgers  []TaskPurger
+	logger   *zap.Logger
+	interval time.Duration
+
+	metrics *compactionMetrics
+}
+
+// CompactorOption is an option you can use to modify the compactor.
+type CompactorOption func(*Compactor)
+
+// WithCompactionInterval sets how often the compactor purges data retained about deleted tasks.
+func WithCompactionInterval(d time.Duration) CompactorOption {
+	return func(c *Compactor) {
+		c.interval = d
+	}
+}
+
+// WithCompactionLogger sets the logger for the compactor.
+func WithCompactionLogger(logger *zap.Logger) CompactorOption {
+	return func(c *Compactor) {
+		c.logger = logger.With(zap.String("svc", "task_compaction"))
+	}
+}
+
+// NewCompactor returns a Compactor that purges the data retained by each of purgers about tasks deleted from st.
+func NewCompactor(st Store, purgers []TaskPurger, opts ...CompactorOption) *Compactor {
+	c := &Compactor{
+		st:       st,
+		purgers:  purgers,
+		logger:   zap.NewNop(),
+		interval: DefaultCompactionInterval,
+		metrics:  newCompactionMetrics(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Run compacts every interval until ctx is done.
+func (c *Compactor) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if res, err := c.Compact(ctx); err != nil {
+				c.logger.Info("Failed to purge data of deleted tasks", zap.Error(err))
+			} else if res.Records > 0 {
+				c.logger.Info("Purged data of deleted tasks", zap.Int("records", res.Records), zap.Int64("bytes", res.Bytes))
+			}
+		}
+	}
+}
+
+// Compact purges the data retained about every task that has been deleted from the store,
+// and returns what was purged.
+func (c *Compactor) Compact(ctx context.Context) (PurgeResult, error) {
+	var total PurgeResult
+	for _, p := range c.purgers {
+		res, err := c.compact(ctx, p)
+		total.Add(res)
+		if err != nil {
+			c.metrics.Compact(total, err)
+			return total, err
+		}
+	}
+	c.metrics.Compact(total, nil)
+	return total, nil
+}
+
+// compact purges the data p retains about deleted tasks.
+func (c *Compactor) compact(ctx context.Context, p TaskPurger) (PurgeResult, error) {
+	var total PurgeResult
+	ids, err := p.RetainedTaskIDs(ctx)
+	if err != nil {
+		return total, err
+	}
+
+	for _, id := range ids {
+		if _, err := c.st.FindTaskMetaByID(ctx, id); err == nil {
+			continue
+		} else if err != ErrTaskNotFound {
+			return total, err
+		}
+
+		res, err := p.PurgeTask(ctx, id)
+		total.Add(res)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// PurgeTask purges the data retained about the task with the given ID, which must have been deleted,
+// and returns what was purged.
+func (c *Compactor) PurgeTask(ctx context.Context, taskID platform.ID) (PurgeResult, error) {
+	var total PurgeResult
+	for _, p := range c.purgers {
+		res, err := p.PurgeTask(ctx, taskID)
+		total.Add(res)
+		if err != nil {
+			c.metrics.Compact(total, err)
+			return total, err
+		}
+	}
+	c.metrics.Compact(total, nil)
+	return total, nil
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (c *Compactor) PrometheusCollectors() []prometheus.Collector {
+	return c.metrics.PrometheusCollectors()
+}
+
+// compactionMetrics is a collection of metrics relating to purging the data of deleted tasks.
+type compactionMetrics struct {
+	checks         *prometheus.CounterVec
+	recordsPurged  prometheus.Counter
+	bytesReclaimed prometheus.Counter
+}
+
+func newCompactionMetrics() *compactionMetrics {
+	const namespace = "task"
+	const subsystem = "compaction"
+
+	return &compactionMetrics{
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "checks_total",
+			Help:      "Number of times data of deleted tasks was purged, split out by success or failure.",
+		}, []string{"status"}),
+		recordsPurged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "records_purged_total",
+			Help:      "Number of records, such as runs and keys, deleted because their task was deleted.",
+		}),
+		bytesReclaimed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "bytes_reclaimed_total",
+			Help:      "Approximate size of the records deleted because their task was deleted.",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (cm *compactionMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		cm.checks,
+		cm.recordsPurged,
+		cm.bytesReclaimed,
+	}
+}
+
+// Compact adjusts the metrics to indicate the result of purging data.
+// Data purged before an error is still counted.
+func (cm *compactionMetrics) Compact(res PurgeResult, err error) {
+	cm.checks.WithLabelValues(statusString(err == nil)).Inc()
+	cm.recordsPurged.Add(float64(res.Records))
+	cm.bytesReclaimed.Add(float64(res.Bytes))
+}
@@ -0,0 +1,80 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/prom"
+	"github.com/influxdata/platform/kit/prom/promtest"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestCompactor(t *testing.T) {
+	const script = `option task = {name: "compact", every: 1m} from(bucket: "b") |> range(start: -1h)`
+	ctx := context.Background()
+
+	st := backend.NewInMemStore()
+	rw := backend.NewInMemRunReaderWriter()
+	purger, ok := st.(backend.TaskPurger)
+	if !ok {
+		t.Fatal("expected in-memory store to be a TaskPurger")
+	}
+	c := backend.NewCompactor(st, []backend.TaskPurger{purger, rw})
+	reg := prom.NewRegistry()
+	reg.MustRegister(c.PrometheusCollectors()...)
+
+	// Create a kept and a deleted task, each with a run.
+	var ids [2]platform.ID
+	for i, key := range []string{"kept", "deleted"} {
+		id, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script, IdempotencyKey: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+
+		rlb := backend.RunLogBase{Task: &backend.StoreTask{ID: id, Org: 1}, RunID: platform.ID(i + 1), RunScheduledFor: 60}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(61, 0), backend.RunSuccess); err != nil {
+			t.Fatal(err)
+		}
+		if err := rw.AddRunLog(ctx, rlb, time.Unix(61, 0), "done"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kept, deleted := ids[0], ids[1]
+	if _, err := st.DeleteTask(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.Compact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The deleted task's run and idempotency key.
+	if res.Records != 2 || res.Bytes <= 0 {
+		t.Fatalf("unexpected purge result: %#v", res)
+	}
+
+	if _, err := rw.ListRuns(ctx, platform.RunFilter{Task: &deleted}); err != backend.ErrRunNotFound {
+		t.Fatalf("expected runs of deleted task to be purged, got %v", err)
+	}
+	if runs, err := rw.ListRuns(ctx, platform.RunFilter{Task: &kept}); err != nil || len(runs) != 1 {
+		t.Fatalf("expected run of kept task to remain, got %v, %v", runs, err)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_compaction_records_purged_total", nil)
+	if got := *m.Counter.Value; got != 2 {
+		t.Fatalf("expected 2 purged records, got %v", got)
+	}
+	m = promtest.MustFindMetric(t, mfs, "task_compaction_bytes_reclaimed_total", nil)
+	if got := *m.Counter.Value; got != float64(res.Bytes) {
+		t.Fatalf("expected %d reclaimed bytes, got %v", res.Bytes, got)
+	}
+
+	// Nothing is left to purge.
+	if res, err := c.Compact(ctx); err != nil || res.Records != 0 {
+		t.Fatalf("expected nothing to purge, got %#v, %v", res, err)
+	}
+}
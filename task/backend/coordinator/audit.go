@@ -0,0 +1,139 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// WithAuditRecorder records each task change as a platform-wide audit event with r,
+// as well as in the store's task audit log.
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(c *Coordinator) {
+		c.auditRecorder = r
+	}
+}
+
+// audit completes e with the authorizer and request ID found in ctx, if any, and the current time, and appends it to the store's audit log.
+// The change e describes has already been made, so failing to record it is logged rather than returned.
+func (c *Coordinator) audit(ctx context.Context, e backend.AuditEntry) {
+	if a, err := pctx.GetAuthorizer(ctx); err == nil {
+		e.AuthorizerID = a.Identifier()
+		e.AuthorizerKind = a.Kind()
+		e.UserID = a.GetUserID()
+	}
+	e.RequestID = pctx.GetRequestID(ctx)
+	e.Time = c.clock.Now().UTC()
+
+	if err := c.Store.AppendAuditEntry(ctx, e); err != nil {
+		c.logger.Info("Failed to record task audit entry", zap.String("task_id", e.TaskID.String()), zap.String("action", string(e.Action)), zap.Error(err))
+	}
+	c.auditRecorder.Record(ctx, auditEvent(e))
+}
+
+// taskSnapshot is the snapshot of a task in a platform-wide audit event.
+// It holds the hash of the task's script rather than the script, as the store's task audit log does.
+type taskSnapshot struct {
+	ScriptHash string `json:"scriptHash"`
+}
+
+// auditEvent converts the task audit entry e to a platform-wide audit event.
+// Changes to a task's status, restores and transfers are updates, whose reason names the kind of change.
+func auditEvent(e backend.AuditEntry) audit.Event {
+	ev := audit.Event{
+		Time:      e.Time,
+		RequestID: e.RequestID,
+		Resource:  audit.Resource{Type: audit.TaskResourceType, ID: e.TaskID, OrgID: e.Org},
+		Reason:    e.Reason,
+	}
+	if e.OldScriptHash != "" {
+		ev.Before = audit.Snapshot(taskSnapshot{ScriptHash: e.OldScriptHash})
+	}
+	if e.NewScriptHash != "" {
+		ev.After = audit.Snapshot(taskSnapshot{ScriptHash: e.NewScriptHash})
+	}
+
+	switch e.Action {
+	case backend.AuditCreate:
+		ev.Action = audit.Create
+	case backend.AuditDelete:
+		ev.Action = audit.Delete
+	case backend.AuditRun:
+		ev.Action = audit.Run
+	case backend.AuditModify:
+		ev.Action = audit.Update
+	default:
+		ev.Action = audit.Update
+		if ev.Reason == "" {
+			ev.Reason = string(e.Action)
+		} else {
+			ev.Reason = fmt.Sprintf("%s: %s", e.Action, ev.Reason)
+		}
+	}
+	return ev
+}
+
+// auditUpdate records the update described by res in the audit log.
+// Updates that change the task's status are recorded as AuditEnable or AuditDisable, and others as AuditModify.
+func (c *Coordinator) auditUpdate(ctx context.Context, res backend.UpdateTaskResult) {
+	action := backend.AuditModify
+	if newStatus := backend.TaskStatus(res.NewMeta.Status); newStatus != res.OldStatus {
+		switch newStatus {
+		case backend.TaskActive:
+			action = backend.AuditEnable
+		case backend.TaskInactive:
+			action = backend.AuditDisable
+		}
+	}
+
+	c.audit(ctx, backend.AuditEntry{
+		TaskID:        res.NewTask.ID,
+		Org:           res.NewTask.Org,
+		Action:        action,
+		OldScriptHash: backend.ScriptHash(res.OldScript),
+		NewScriptHash: backend.ScriptHash(res.NewTask.Script),
+		Reason:        res.NewMeta.DisabledReason,
+	})
+}
+
+// auditDelete records the deletion of task in the audit log.
+func (c *Coordinator) auditDelete(ctx context.Context, task *backend.StoreTask) {
+	c.audit(ctx, backend.AuditEntry{TaskID: task.ID, Org: task.Org, Action: backend.AuditDelete, OldScriptHash: backend.ScriptHash(task.Script)})
+}
+
+// ManuallyRunTimeRange requests manual runs of the task with the given ID, and records the request in the audit log,
+// so that the runs can be traced back to the API request that caused them.
+func (c *Coordinator) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	mr, err := c.Store.ManuallyRunTimeRange(ctx, taskID, start, end, requestedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.auditRun(ctx, taskID, fmt.Sprintf("runs from %d to %d requested at %d", start, end, requestedAt))
+	return mr, nil
+}
+
+// RetryRun requests a retry of the run with the given ID, and records the request in the audit log.
+func (c *Coordinator) RetryRun(ctx context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	mr, err := c.Store.RetryRun(ctx, taskID, runID, scheduledFor, requestedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.auditRun(ctx, taskID, fmt.Sprintf("run %s retries run %s, requested at %d", platform.ID(mr.RunID), runID, requestedAt))
+	return mr, nil
+}
+
+// auditRun records a manual run request for the task with the given ID in the audit log.
+func (c *Coordinator) auditRun(ctx context.Context, taskID platform.ID, reason string) {
+	task, err := c.FindTaskByID(ctx, taskID)
+	if err != nil || task == nil {
+		c.logger.Info("Failed to find task to audit run request", zap.String("task_id", taskID.String()), zap.Error(err))
+		return
+	}
+	hash := backend.ScriptHash(task.Script)
+	c.audit(ctx, backend.AuditEntry{TaskID: taskID, Org: task.Org, Action: backend.AuditRun, OldScriptHash: hash, NewScriptHash: hash, Reason: reason})
+}
@@ -0,0 +1,128 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// AutoDisableEvent describes a task that the coordinator disabled after repeated failures.
+type AutoDisableEvent struct {
+	TaskID, Org platform.ID
+
+	// Failures is the number of consecutive runs that failed or timed out.
+	Failures int
+
+	// Reason is the reason recorded in the task's meta as DisabledReason.
+	Reason string
+}
+
+// WithAutoDisable makes the coordinator disable a task once maxFailures of its runs in a row fail or time out,
+// so that a task with a broken script stops using executor capacity.
+// The reason is recorded in the task's meta, and onDisable, if not nil, is called with the details.
+//
+// Failures are counted from the runs the coordinator observes, so the coordinator must be added as a
+// backend.RunObserver of its scheduler, for example with (*backend.TickScheduler).AddRunObserver.
+// The count is reset when a run succeeds, or when the task's script or status is updated through the coordinator.
+// Tasks are disabled, and onDisable called, on a goroutine owned by the coordinator, shortly after the last failed run.
+// Values of maxFailures less than 1 have no effect.
+func WithAutoDisable(maxFailures int, onDisable func(AutoDisableEvent)) Option {
+	return func(c *Coordinator) {
+		c.maxFailures = maxFailures
+		c.onAutoDisable = onDisable
+	}
+}
+
+var _ backend.RunObserver = (*Coordinator)(nil)
+
+// ObserveRun counts the consecutive failed runs of task, and disables task when the count reaches the limit
+// set with WithAutoDisable. It does nothing unless WithAutoDisable is used.
+//
+// ObserveRun is called on the scheduler's runner goroutines, which the scheduler waits for while it stops or drains.
+// Disabling the task releases it from the scheduler, so ObserveRun leaves that to c's disableFailingTasks goroutine,
+// rather than risk waiting on a scheduler that is waiting on it.
+func (c *Coordinator) ObserveRun(_ context.Context, task *backend.StoreTask, _ backend.QueuedRun, o backend.RunOutcome) {
+	if c.maxFailures < 1 {
+		return
+	}
+
+	if c.isClosing() {
+		return
+	}
+
+	c.failuresMu.Lock()
+	defer c.failuresMu.Unlock()
+
+	switch o.Status {
+	case backend.RunSuccess:
+		delete(c.failures, task.ID)
+	case backend.RunFail, backend.RunTimedOut:
+		c.failures[task.ID]++
+		failures := c.failures[task.ID]
+		if failures < c.maxFailures {
+			return
+		}
+
+		// Only one of the task's runners should disable it.
+		delete(c.failures, task.ID)
+		c.pendingDisables[task.ID] = AutoDisableEvent{
+			TaskID:   task.ID,
+			Org:      task.Org,
+			Failures: failures,
+			Reason:   fmt.Sprintf("disabled after %d consecutive failed runs; last error: %s", failures, o.Error),
+		}
+		select {
+		case c.disableSignal <- struct{}{}:
+		default:
+			// disableFailingTasks has already been signaled, and will see this task too.
+		}
+	}
+}
+
+// disableFailingTasks disables the tasks that ObserveRun found to be failing repeatedly, until Shutdown is called.
+func (c *Coordinator) disableFailingTasks() {
+	for {
+		select {
+		case <-c.disableSignal:
+		case <-c.stopping:
+			return
+		}
+
+		c.failuresMu.Lock()
+		pending := c.pendingDisables
+		c.pendingDisables = make(map[platform.ID]AutoDisableEvent)
+		c.failuresMu.Unlock()
+
+		for _, ev := range pending {
+			if c.isClosing() {
+				return
+			}
+			c.disableTask(ev)
+		}
+	}
+}
+
+// disableTask disables the repeatedly failing task described by ev, and calls the onDisable function given to WithAutoDisable.
+func (c *Coordinator) disableTask(ev AutoDisableEvent) {
+	if err := c.setTaskStatus(context.Background(), backend.UpdateTaskRequest{ID: ev.TaskID, Status: backend.TaskInactive, DisabledReason: ev.Reason}); err != nil {
+		c.logger.Info("Failed to disable repeatedly failing task", zap.String("task_id", ev.TaskID.String()), zap.Error(err))
+		return
+	}
+
+	c.logger.Info("Disabled repeatedly failing task", zap.String("task_id", ev.TaskID.String()), zap.Int("failures", ev.Failures))
+	if c.onAutoDisable != nil {
+		c.onAutoDisable(ev)
+	}
+}
+
+// resetFailures clears the count of consecutive failed runs of the task with the given ID,
+// and cancels disabling the task if it is waiting to be disabled.
+func (c *Coordinator) resetFailures(id platform.ID) {
+	c.failuresMu.Lock()
+	delete(c.failures, id)
+	delete(c.pendingDisables, id)
+	c.failuresMu.Unlock()
+}
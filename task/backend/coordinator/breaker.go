@@ -0,0 +1,88 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/circuitbreaker"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// WithSchedulerBreaker guards the coordinator's calls to claim, update, release and cancel tasks in its scheduler with b,
+// so that a scheduler that keeps failing, such as a remote scheduler that is down, is not called while it recovers.
+// While b is open, those calls fail with circuitbreaker.ErrOpen; the reconciler claims the tasks skipped in the meantime.
+//
+// Errors that report the state of a task rather than a failure of the scheduler,
+// such as backend.ErrTaskAlreadyClaimed, do not count against b.
+//
+// The breaker wraps the scheduler whether WithSchedulerBreaker is given before or after WithShards.
+func WithSchedulerBreaker(b *circuitbreaker.Breaker) Option {
+	return func(c *Coordinator) {
+		c.schedulerBreaker = b
+	}
+}
+
+// isSchedulerFailure reports whether err, returned by a scheduler, is a failure of the scheduler.
+func isSchedulerFailure(err error) bool {
+	switch err {
+	case nil, backend.ErrTaskAlreadyClaimed, backend.ErrTaskNotClaimed:
+		return false
+	}
+	switch platform.ErrorCode(err) {
+	case platform.ENotFound, platform.EConflict, platform.EInvalid:
+		return false
+	}
+	return true
+}
+
+// breakerScheduler is a backend.Scheduler whose calls for individual tasks go through a circuit breaker.
+// Calls that only read the scheduler's state, and those that start, stop and drain it, are not guarded.
+type breakerScheduler struct {
+	backend.Scheduler
+	b *circuitbreaker.Breaker
+}
+
+var _ backend.TaskRunCanceler = (*breakerScheduler)(nil)
+
+// do calls fn through the breaker, passing errors that are not failures of the scheduler through as successes.
+func (s *breakerScheduler) do(fn func() error) error {
+	var taskErr error
+	err := s.b.Do(func() error {
+		err := fn()
+		if !isSchedulerFailure(err) {
+			taskErr = err
+			return nil
+		}
+		return err
+	})
+	if taskErr != nil {
+		return taskErr
+	}
+	return err
+}
+
+func (s *breakerScheduler) ClaimTask(task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	return s.do(func() error { return s.Scheduler.ClaimTask(task, meta) })
+}
+
+func (s *breakerScheduler) UpdateTask(task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	return s.do(func() error { return s.Scheduler.UpdateTask(task, meta) })
+}
+
+func (s *breakerScheduler) ReleaseTask(taskID platform.ID) error {
+	return s.do(func() error { return s.Scheduler.ReleaseTask(taskID) })
+}
+
+func (s *breakerScheduler) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.do(func() error { return s.Scheduler.CancelRun(ctx, taskID, runID) })
+}
+
+// CancelTaskRuns implements backend.TaskRunCanceler through the wrapped scheduler.
+// If the wrapped scheduler does not implement backend.TaskRunCanceler, it does nothing.
+func (s *breakerScheduler) CancelTaskRuns(ctx context.Context, taskID platform.ID) error {
+	rc, ok := s.Scheduler.(backend.TaskRunCanceler)
+	if !ok {
+		return nil
+	}
+	return s.do(func() error { return rc.CancelTaskRuns(ctx, taskID) })
+}
@@ -0,0 +1,63 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/cache"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// WithTaskCache keeps the tasks found by FindTaskByID in tc, so that repeated lookups of a task,
+// such as by the task API and the audit log, do not each read the store.
+//
+// Only tasks are cached, not their metas, which change directly in the store with every run the scheduler creates and finishes.
+// Lookups made to change a task, such as to check its new interval or to transfer it, always read the store.
+//
+// A task changed or deleted through the coordinator is removed from tc. A task changed through another coordinator
+// sharing the store is removed when the store reports the change, if it is a backend.TaskWatcher used with WithTaskWatch,
+// and otherwise may be found as it was until its entry in tc expires.
+func WithTaskCache(tc *cache.Cache) Option {
+	return func(c *Coordinator) {
+		c.taskCache = tc
+	}
+}
+
+// FindTaskByID returns the task with the given ID, from the task cache if c uses WithTaskCache.
+// A cached task's labels and options are shared with the cache, so they must not be modified.
+func (c *Coordinator) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
+	if c.taskCache == nil {
+		return c.Store.FindTaskByID(ctx, id)
+	}
+	if v, ok := c.taskCache.Get(id); ok {
+		task := *v.(*backend.StoreTask)
+		return &task, nil
+	}
+
+	task, err := c.Store.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cached := *task
+	c.taskCache.Set(id, &cached)
+	return task, nil
+}
+
+// updateStoredTask updates the task in the store as specified by req, and removes it from the task cache.
+func (c *Coordinator) updateStoredTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
+	defer c.forgetTask(req.ID)
+	return c.Store.UpdateTask(ctx, req)
+}
+
+// deleteStoredTask deletes the task with the given ID from the store, and removes it from the task cache.
+func (c *Coordinator) deleteStoredTask(ctx context.Context, id platform.ID) (bool, error) {
+	defer c.forgetTask(id)
+	return c.Store.DeleteTask(ctx, id)
+}
+
+// forgetTask removes the task with the given ID from the task cache, if c uses WithTaskCache.
+func (c *Coordinator) forgetTask(id platform.ID) {
+	if c.taskCache != nil {
+		c.taskCache.Delete(id)
+	}
+}
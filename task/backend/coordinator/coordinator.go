@@ -3,9 +3,18 @@ package coordinator
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/audit"
+	"github.com/influxdata/platform/kit/cache"
+	"github.com/influxdata/platform/kit/circuitbreaker"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/retry"
 	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
 	"go.uber.org/zap"
 )
 
@@ -15,120 +24,783 @@ type Coordinator struct {
 	logger *zap.Logger
 	sch    backend.Scheduler
 
+	// Maximum number of tasks claimed in sch. See WithLimit.
 	limit int
+
+	// Fields used to claim the tasks in the store at startup. See WithClaimWorkers and Ready.
+	// startupClaims is guarded by ownedMu, since it counts towards the limit.
+	claimWorkers   int
+	startupClaims  int           // Number of tasks being claimed by claimExistingTasks.
+	ready          chan struct{} // Closed once the tasks have been claimed.
+	startupMetrics *startupMetrics
+
+	// Set to 1 once Shutdown is called. Must be accessed atomically.
+	closing uint32
+
+	// Fields used when the coordinator shares its store with other coordinators.
+	// See WithLeases and WithLeaderElection.
+	leaseCtx   context.Context
+	stopShared context.CancelFunc
+	sharedDone chan struct{} // Closed when the lease or leadership goroutine exits.
+	leaseOwner string
+	leaseTTL   time.Duration
+	leaseRetry retry.Backoff
+	elector    Elector
+
+	ownedMu     sync.Mutex
+	owned       map[platform.ID]string // Task ID -> script of every task claimed by c in the scheduler.
+	leader      bool                   // Whether c currently holds the leader lease.
+	leaderUntil int64                  // Unix timestamp when c's leader lease expires, if not renewed.
+
+	// Context for watching the store for task changes. See WithTaskWatch.
+	watchCtx context.Context
+
+	// Fields used to disable repeatedly failing tasks. See WithAutoDisable.
+	maxFailures     int
+	onAutoDisable   func(AutoDisableEvent)
+	failuresMu      sync.Mutex
+	failures        map[platform.ID]int              // Task ID -> number of consecutive failed runs.
+	pendingDisables map[platform.ID]AutoDisableEvent // Tasks waiting to be disabled by disableFailingTasks.
+	disableSignal   chan struct{}                    // Signals disableFailingTasks that pendingDisables is not empty.
+
+	// Fields used to claim created tasks in the background. See WithDeferredClaims.
+	// pendingClaims is guarded by ownedMu, since it counts towards the limit.
+	deferClaims   bool
+	pendingClaims map[platform.ID]struct{} // Tasks waiting to be claimed by claimDeferredTasks.
+	claimSignal   chan struct{}            // Signals claimDeferredTasks that pendingClaims is not empty.
+
+	stopping chan struct{} // Closed by Shutdown, to stop background goroutines.
+	stopOnce sync.Once
+
+	// Called after tasks are changed. See WithHooks.
+	hooks []Hooks
+
+	// Tells the time for leases and audit entries, and creates the tickers that maintain leases. See WithClock.
+	clock clock.Clock
+
+	// Shortest time allowed between runs of a task, by default and per organization.
+	// See WithMinInterval and WithOrgMinInterval.
+	minInterval     time.Duration
+	orgMinIntervals map[platform.ID]time.Duration
+
+	// Fields used to periodically reconcile the scheduler with the store. See WithReconcile.
+	reconcileCtx      context.Context
+	reconcileInterval time.Duration
+	reconcileMetrics  *reconcileMetrics
+
+	// Purges the data retained about each task after it is deleted. See WithPurgeOnDelete.
+	compactor *backend.Compactor
+
+	// Whether to cancel a task's in-flight runs before deleting it. See WithCancelRunsOnDelete.
+	cancelRunsOnDelete bool
+
+	// Fields used to delete tasks whose release from the scheduler failed. See WithTolerantDelete.
+	// pendingReleases is guarded by ownedMu.
+	tolerantDelete  bool
+	pendingReleases map[platform.ID]struct{} // Deleted tasks waiting to be released by Reconcile.
+
+	// Fields used to keep deleted tasks until they are purged, so that they can be restored. See WithSoftDelete.
+	softDeleteCtx context.Context
+	purgeAfter    time.Duration
+
+	// Limits the number of tasks in each organization. See WithQuotas.
+	quotas       backend.QuotaService
+	quotaMetrics *quotaMetrics
+
+	// Serializes lifecycle operations on each task.
+	taskLocks taskLocks
+
+	// Reports changes made through the coordinator, when the store cannot report changes itself. See WatchTasks.
+	changes backend.TaskChangeFeed
+
+	// Records task changes as platform-wide audit events, alongside the store's task audit log. See WithAuditRecorder.
+	auditRecorder *audit.Recorder
+
+	// Guards the calls to claim, update, release and cancel tasks in sch. See WithSchedulerBreaker.
+	schedulerBreaker *circuitbreaker.Breaker
+
+	// Keeps the tasks found by FindTaskByID. Nil if tasks are always read from the store. See WithTaskCache.
+	taskCache *cache.Cache
 }
 
 type Option func(*Coordinator)
 
+// WithLimit sets the maximum number of tasks the coordinator claims in its scheduler, 1000 by default.
+// Once the limit is reached, creating or enabling a task returns a backend.TaskLimitError,
+// unless the coordinator uses WithLeases, in which case the task is left for another coordinator to claim.
 func WithLimit(i int) Option {
 	return func(c *Coordinator) {
 		c.limit = i
 	}
 }
 
+// WithClock sets the Clock the coordinator uses to tell the time and to schedule lease maintenance,
+// so that tests can advance time deterministically. If not set, the coordinator uses clock.System.
+func WithClock(clk clock.Clock) Option {
+	return func(c *Coordinator) {
+		c.clock = clk
+	}
+}
+
+// WithShards splits claimed tasks across n schedulers by a hash of the task ID,
+// to reduce lock contention when many tasks are claimed in a single process.
+// The scheduler given to New is used as the first shard,
+// and newShard is called to create each of the remaining n-1 shards.
+// Values of n less than 2 have no effect.
+func WithShards(n int, newShard func(shard int) backend.Scheduler) Option {
+	return func(c *Coordinator) {
+		if n < 2 {
+			return
+		}
+
+		shards := make([]backend.Scheduler, n)
+		shards[0] = c.sch
+		for i := 1; i < n; i++ {
+			shards[i] = newShard(i)
+		}
+		c.sch = backend.NewShardedScheduler(shards...)
+	}
+}
+
+// MinLeaseTTL is the shortest lease TTL accepted by WithLeases and WithLeaderElection.
+// Leases are stored with one-second granularity, so a shorter TTL could expire as soon as it is acquired.
+const MinLeaseTTL = time.Second
+
+// defaultLeaseRetry is how the coordinator retries lease operations, unless set with WithLeaseRetry.
+var defaultLeaseRetry = retry.Backoff{
+	Initial:     10 * time.Millisecond,
+	Max:         100 * time.Millisecond,
+	Multiplier:  2,
+	Jitter:      0.2,
+	MaxAttempts: 3,
+}
+
+// WithLeases allows multiple coordinators, each with its own scheduler, to share a single store.
+// Instead of claiming every task in the store, the coordinator only claims tasks for which it holds a lease,
+// and it tries to hold an equal share of the active tasks with the other live lease owners.
+// Leases are renewed every third of ttl; if a coordinator stops renewing,
+// its peers take over its tasks once its leases expire.
+//
+// owner must uniquely identify this coordinator among all coordinators sharing the store.
+// When ctx is canceled, the coordinator stops renewing and releases its leases.
+// A ttl shorter than MinLeaseTTL is raised to MinLeaseTTL.
+func WithLeases(ctx context.Context, owner string, ttl time.Duration) Option {
+	return func(c *Coordinator) {
+		c.leaseCtx = ctx
+		c.leaseOwner = owner
+		c.leaseTTL = leaseTTL(ttl)
+	}
+}
+
+// WithLeaseRetry sets how the coordinator retries acquiring and releasing a task's lease
+// when the store fails with a transient error, when claiming and releasing the task.
+// It only applies with WithLeases.
+func WithLeaseRetry(b retry.Backoff) Option {
+	return func(c *Coordinator) {
+		c.leaseRetry = b
+	}
+}
+
+// WithLeaderElection allows multiple coordinators to share a single store,
+// with only the elected leader claiming tasks in its scheduler.
+// Standby coordinators still serve requests, but leave scheduling to the leader.
+// The leader renews its lease every third of ttl; if it stops renewing,
+// a standby takes over within roughly ttl plus a third of ttl.
+//
+// e is usually the coordinator's own store, but may be backed by an external key-value store.
+// owner must uniquely identify this coordinator among all coordinators sharing the store.
+// When ctx is canceled, the coordinator releases its tasks and steps down.
+//
+// A ttl shorter than MinLeaseTTL is raised to MinLeaseTTL.
+//
+// WithLeaderElection must not be combined with WithLeases.
+func WithLeaderElection(ctx context.Context, e Elector, owner string, ttl time.Duration) Option {
+	return func(c *Coordinator) {
+		c.leaseCtx = ctx
+		c.leaseOwner = owner
+		c.leaseTTL = leaseTTL(ttl)
+		c.elector = e
+	}
+}
+
+// leaseTTL returns ttl, or MinLeaseTTL if ttl is shorter.
+func leaseTTL(ttl time.Duration) time.Duration {
+	if ttl < MinLeaseTTL {
+		return MinLeaseTTL
+	}
+	return ttl
+}
+
+// WithPurgeOnDelete purges the data retained about each task, such as its runs and logs, as soon as the task is deleted,
+// through c's TaskPurgers, instead of leaving the data for c's periodic compaction.
+// A failure to purge is logged, but does not fail the deletion.
+func WithPurgeOnDelete(c *backend.Compactor) Option {
+	return func(co *Coordinator) {
+		co.compactor = c
+	}
+}
+
+// WithCancelRunsOnDelete sets whether deleting a task first cancels its in-flight runs through the executor,
+// waiting until they are marked canceled before the task is deleted from the store. It is enabled by default.
+// When disabled, in-flight runs are left to finish against the deleted task.
+// Runs are only canceled through a scheduler that implements backend.TaskRunCanceler.
+func WithCancelRunsOnDelete(cancel bool) Option {
+	return func(c *Coordinator) {
+		c.cancelRunsOnDelete = cancel
+	}
+}
+
+// WithTolerantDelete makes DeleteTask delete a task from the store even if releasing it from the scheduler fails,
+// as when the scheduler is restarting, rather than leaving the task undeletable until the scheduler recovers.
+// The release is retried by Reconcile, and DeleteTask returns a backend.ReleaseWarning instead of failing.
+// Use it along with WithReconcile, so that the release is retried without waiting for a manual Reconcile.
+func WithTolerantDelete() Option {
+	return func(c *Coordinator) {
+		c.tolerantDelete = true
+	}
+}
+
 func New(logger *zap.Logger, scheduler backend.Scheduler, st backend.Store, opts ...Option) *Coordinator {
 	c := &Coordinator{
-		logger: logger,
-		sch:    scheduler,
-		Store:  st,
-		limit:  1000,
+		logger:     logger,
+		sch:        scheduler,
+		Store:      st,
+		limit:      1000,
+		owned:      make(map[platform.ID]string),
+		failures:   make(map[platform.ID]int),
+		clock:      clock.System,
+		leaseRetry: defaultLeaseRetry,
+
+		claimWorkers:   defaultClaimWorkers,
+		ready:          make(chan struct{}),
+		startupMetrics: newStartupMetrics(),
+
+		cancelRunsOnDelete: true,
+		pendingReleases:    make(map[platform.ID]struct{}),
+
+		pendingDisables: make(map[platform.ID]AutoDisableEvent),
+		disableSignal:   make(chan struct{}, 1),
+		pendingClaims:   make(map[platform.ID]struct{}),
+		claimSignal:     make(chan struct{}, 1),
+		stopping:        make(chan struct{}),
+
+		orgMinIntervals:  make(map[platform.ID]time.Duration),
+		reconcileMetrics: newReconcileMetrics(),
+		quotaMetrics:     newQuotaMetrics(),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	go c.claimExistingTasks()
+	// Wrap the scheduler once every option has run, so that the breaker guards all shards set up by WithShards.
+	if c.schedulerBreaker != nil {
+		c.sch = &breakerScheduler{Scheduler: c.sch, b: c.schedulerBreaker}
+	}
 
-	return c
-}
+	if c.shared() {
+		c.leaseCtx, c.stopShared = context.WithCancel(c.leaseCtx)
+		c.sharedDone = make(chan struct{})
+	}
 
-// claimExistingTasks is called on startup to claim all tasks in the store.
-func (c *Coordinator) claimExistingTasks() {
-	tasks, err := c.Store.ListTasks(context.Background(), backend.TaskSearchParams{})
-	if err != nil {
-		c.logger.Error("failed to list tasks", zap.Error(err))
-		return
+	if c.maxFailures >= 1 {
+		go c.disableFailingTasks()
 	}
 
-	for len(tasks) > 0 {
-		for _, task := range tasks {
-			t := task // Copy to avoid mistaken closure around task value.
-			if err := c.sch.ClaimTask(&t.Task, &t.Meta); err != nil {
-				c.logger.Error("failed claim task", zap.Error(err))
-				continue
-			}
+	if c.deferClaims {
+		go c.claimDeferredTasks()
+	}
+
+	if c.watchCtx != nil {
+		if w, ok := c.Store.(backend.TaskWatcher); ok {
+			go c.watchTasks(w)
 		}
-		tasks, err = c.Store.ListTasks(context.Background(), backend.TaskSearchParams{
-			After: tasks[len(tasks)-1].Task.ID,
-		})
-		if err != nil {
-			c.logger.Error("failed list additional tasks", zap.Error(err))
-			return
+	}
+
+	switch {
+	case c.electing():
+		close(c.ready)
+		go c.maintainLeadership()
+	case c.leasing():
+		close(c.ready)
+		go c.maintainLeases()
+	default:
+		go c.claimExistingTasks()
+		if c.reconcileCtx != nil {
+			go c.reconcileTasks()
 		}
 	}
+
+	if c.softDeleting() {
+		go c.purgeDeletedTasks()
+	}
+
+	return c
+}
+
+// Shutdown gracefully stops c.
+// It stops claiming new tasks, then drains the scheduler:
+// in-flight runs are given until ctx is done to finish and record their progress in the store,
+// after which any remaining runs are canceled.
+// Finally, all claimed tasks are released, along with any leases c holds, so that other coordinators can take over.
+//
+// Shutdown does not close the underlying store.
+// Tasks created through c after Shutdown is called are stored but not scheduled.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	atomic.StoreUint32(&c.closing, 1)
+	c.stopOnce.Do(func() { close(c.stopping) })
+
+	err := c.sch.Drain(ctx)
+
+	if c.shared() {
+		c.stopShared()
+		<-c.sharedDone
+	}
+
+	return err
+}
+
+// isClosing reports whether Shutdown has been called.
+func (c *Coordinator) isClosing() bool {
+	return atomic.LoadUint32(&c.closing) == 1
+}
+
+// TaskLimit reports the number of tasks c has claimed in its scheduler, and the most it may claim.
+func (c *Coordinator) TaskLimit() (claimed, limit int) {
+	c.ownedMu.Lock()
+	defer c.ownedMu.Unlock()
+	return len(c.owned), c.limit
 }
 
+// checkLimit returns a backend.TaskLimitError if the task with the given ID is not claimed by c,
+// and c cannot claim any more tasks.
+func (c *Coordinator) checkLimit(id platform.ID) error {
+	c.ownedMu.Lock()
+	defer c.ownedMu.Unlock()
+
+	if _, ok := c.owned[id]; ok || len(c.owned) < c.limit {
+		return nil
+	}
+	return backend.TaskLimitError{Limit: c.limit}
+}
+
+// CreateTask creates the task in the store and claims it in the scheduler,
+// or queues it to be claimed in the background if c uses WithDeferredClaims.
+// If the task was already created with req's idempotency key, the existing task's ID is returned without error,
+// and the existing task is left as it is.
 func (c *Coordinator) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (platform.ID, error) {
+	if err := checkSecrets(req.Script); err != nil {
+		return platform.InvalidID(), err
+	}
+	if err := c.checkInterval(req.Org, req.Script); err != nil {
+		return platform.InvalidID(), err
+	}
+	if err := c.checkQuota(ctx, req.Org, req.Script); err != nil {
+		return platform.InvalidID(), err
+	}
+
 	id, err := c.Store.CreateTask(ctx, req)
+	if err == backend.ErrTaskAlreadyCreated {
+		return id, nil
+	}
 	if err != nil {
 		return id, err
 	}
 
-	task, meta, err := c.Store.FindTaskByIDWithMeta(ctx, id)
+	// The store accepted req, so its script's options parse.
+	// Build the new task from them, rather than reading it back from the store.
+	o, err := backend.StoreValidator.CreateArgs(req)
 	if err != nil {
 		return id, err
 	}
+	created := backend.NewStoreTaskWithMeta(id, req, o)
+	task, meta := &created.Task, &created.Meta
 
-	if err := c.sch.ClaimTask(task, meta); err != nil {
-		_, delErr := c.Store.DeleteTask(ctx, id)
+	if c.deferClaims {
+		err = c.deferClaim(id)
+	} else {
+		err = c.claim(ctx, task, meta)
+	}
+	if err != nil {
+		_, delErr := c.deleteStoredTask(ctx, id)
 		if delErr != nil {
 			return id, fmt.Errorf("schedule task failed: %s\n\tcleanup also failed: %s", err, delErr)
 		}
 		return id, err
 	}
 
+	c.audit(ctx, backend.AuditEntry{TaskID: id, Org: task.Org, Action: backend.AuditCreate, NewScriptHash: backend.ScriptHash(task.Script)})
+	c.taskCreated(ctx, task, meta)
 	return id, nil
 }
 
+// UpdateTask updates the task as specified by req, in the store and the scheduler.
+// Notification settings set through req.Notification apply to the task's runs created after the update,
+// which carry the settings to the scheduler's observers; changing them alone does not reschedule the task.
 func (c *Coordinator) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
-	res, err := c.Store.UpdateTask(ctx, req)
-	if err != nil {
-		return res, err
+	defer c.taskLocks.lock(req.ID)()
+	return c.updateTask(ctx, req)
+}
+
+// updateTask updates the task as specified by req, in the store and the scheduler.
+// The caller must hold the task's lock in c.taskLocks.
+func (c *Coordinator) updateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
+	// A deleted task must be restored with RestoreTask before it can be updated.
+	if c.softDeleting() {
+		deleted, err := c.isDeleted(ctx, req.ID)
+		if err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+		if deleted {
+			return backend.UpdateTaskResult{}, backend.ErrTaskNotFound
+		}
 	}
 
-	task, meta, err := c.Store.FindTaskByIDWithMeta(ctx, req.ID)
+	// Refuse to enable a task that couldn't be claimed, rather than leaving it active but unscheduled.
+	if req.Status == backend.TaskActive && !c.leasing() {
+		if err := c.checkLimit(req.ID); err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	// Only a new script is checked for credentials, so that tasks created before the check can still be updated.
+	if req.Script != "" {
+		if err := checkSecrets(req.Script); err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+	if err := c.checkUpdateInterval(ctx, req); err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+
+	res, err := c.updateStoredTask(ctx, req)
 	if err != nil {
 		return res, err
 	}
 
+	if req.Script != "" || !req.Options.IsZero() || req.Status != "" {
+		c.resetFailures(req.ID)
+	}
+
+	// The result holds the task and meta as the update left them, so there is no need to read them back from the store.
+	task, meta := &res.NewTask, &res.NewMeta
+
 	// If disabling the task, do so before modifying the script.
 	if req.Status == backend.TaskInactive && res.OldStatus != backend.TaskInactive {
-		if err := c.sch.ReleaseTask(req.ID); err != nil && err != backend.ErrTaskNotClaimed {
+		if err := c.release(ctx, req.ID); err != nil && err != backend.ErrTaskNotClaimed {
 			return res, err
 		}
 	}
 
-	if err := c.sch.UpdateTask(task, meta); err != nil && err != backend.ErrTaskNotClaimed {
+	if !needsReschedule(req, res) {
+		// Keep the task's queued runs and next due time; runs read the updated script from the store.
+		c.refreshOwned(task)
+	} else if err := c.sch.UpdateTask(task, meta); err == nil {
+		c.setOwned(task)
+	} else if err != backend.ErrTaskNotClaimed {
 		return res, err
 	}
 
 	// If enabling the task, claim it after modifying the script.
 	if req.Status == backend.TaskActive {
-		if err := c.sch.ClaimTask(task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			return res, err
+		}
+	}
+
+	c.auditUpdate(ctx, res)
+	c.taskModified(ctx, res)
+	return res, nil
+}
+
+// needsReschedule reports whether the update req, which produced res, changes how the scheduler runs the task.
+// An update that only changes the task's name, labels, or the body of its script,
+// leaving its schedule, offset, concurrency, jitter, and blackout windows as they were, does not.
+func needsReschedule(req backend.UpdateTaskRequest, res backend.UpdateTaskResult) bool {
+	if backend.TaskStatus(res.NewMeta.Status) != res.OldStatus || req.MaxConcurrency > 0 || req.Org.Valid() {
+		return true
+	}
+	if res.NewTask.Script == res.OldScript {
+		return false
+	}
+
+	oldOpts, err := options.FromScript(res.OldScript)
+	if err != nil {
+		return true
+	}
+	newOpts, err := res.NewTask.ScriptOptions()
+	if err != nil {
+		return true
+	}
+	return !oldOpts.ScheduleEqual(newOpts)
+}
+
+// RollbackTask restores the script of the task with the given ID to the given version, as listed by ListTaskVersions,
+// and updates the task in the scheduler.
+// The restored script is recorded as a new version, so a rollback can itself be undone.
+// If the task has no retained version matching version, backend.ErrTaskVersionNotFound is returned.
+func (c *Coordinator) RollbackTask(ctx context.Context, id platform.ID, version int) (backend.UpdateTaskResult, error) {
+	defer c.taskLocks.lock(id)()
+
+	versions, err := c.Store.ListTaskVersions(ctx, id)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+
+	for _, v := range versions {
+		if v.Version == version {
+			return c.updateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: v.Script})
+		}
+	}
+
+	return backend.UpdateTaskResult{}, backend.ErrTaskVersionNotFound
+}
+
+// DisableTasksByOrg sets every active task in the org with the given ID to inactive and releases it from the scheduler.
+// It returns the IDs of the tasks that were disabled.
+//
+// The tasks are disabled in the store together, through Store.DisableTasks, then released one by one.
+// If releasing a task fails, that task's status is restored in the store,
+// and DisableTasksByOrg returns the first such error along with the IDs of the tasks that were disabled.
+func (c *Coordinator) DisableTasksByOrg(ctx context.Context, orgID platform.ID) ([]platform.ID, error) {
+	return c.setOrgTasksStatus(ctx, orgID, backend.TaskInactive)
+}
+
+// EnableTasksByOrg sets every inactive task in the org with the given ID to active and claims it in the scheduler.
+// It returns the IDs of the tasks that were enabled.
+//
+// The tasks are enabled in the store together, through Store.EnableTasks, then claimed one by one.
+// If claiming a task fails, that task's status is restored in the store,
+// and EnableTasksByOrg returns the first such error along with the IDs of the tasks that were enabled.
+// Tasks beyond c's limit are left inactive, and a backend.TaskLimitError is returned.
+func (c *Coordinator) EnableTasksByOrg(ctx context.Context, orgID platform.ID) ([]platform.ID, error) {
+	return c.setOrgTasksStatus(ctx, orgID, backend.TaskActive)
+}
+
+func (c *Coordinator) setOrgTasksStatus(ctx context.Context, orgID platform.ID, status backend.TaskStatus) ([]platform.ID, error) {
+	listed := make(map[platform.ID]backend.StoreTaskWithMeta)
+	var ids []platform.ID
+	params := backend.TaskSearchParams{Org: orgID}
+	for {
+		tasks, err := c.Store.ListTasks(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) == 0 {
+			break
+		}
+
+		for _, t := range tasks {
+			if s := backend.TaskStatus(t.Meta.Status); s == status || s == backend.TaskDeleted {
+				continue
+			}
+			listed[t.Task.ID] = t
+			ids = append(ids, t.Task.ID)
+		}
+
+		params.After = tasks[len(tasks)-1].Task.ID
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// The tasks are listed in ID order, so their locks are taken in a consistent order.
+	for _, id := range ids {
+		defer c.taskLocks.lock(id)()
+	}
+
+	var firstErr error
+	if status == backend.TaskActive && !c.leasing() {
+		ids, firstErr = c.withinLimit(ids)
+	}
+
+	changed, err := c.setTasksStatus(ctx, ids, status)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated, failed []platform.ID
+	for _, id := range changed {
+		res, err := c.applyTaskStatus(ctx, listed[id], status)
+		if err != nil {
+			c.logger.Info("Failed to update task status in scheduler", zap.String("task_id", id.String()), zap.String("status", string(status)), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed = append(failed, id)
+			continue
+		}
+
+		c.auditUpdate(ctx, res)
+		c.taskModified(ctx, res)
+		updated = append(updated, id)
+	}
+
+	if len(failed) > 0 {
+		oldStatus := backend.TaskActive
+		if status == backend.TaskActive {
+			oldStatus = backend.TaskInactive
+		}
+		if _, rbErr := c.setTasksStatus(ctx, failed, oldStatus); rbErr != nil {
+			return updated, fmt.Errorf("updating status of tasks %v failed: %s\n\trestoring status also failed: %s", failed, firstErr, rbErr)
+		}
+	}
+	return updated, firstErr
+}
+
+// setTasksStatus sets the status of the tasks with the given IDs in the store, and returns the IDs of those that changed.
+func (c *Coordinator) setTasksStatus(ctx context.Context, ids []platform.ID, status backend.TaskStatus) ([]platform.ID, error) {
+	if status == backend.TaskActive {
+		return c.Store.EnableTasks(ctx, ids)
+	}
+	return c.Store.DisableTasks(ctx, ids)
+}
+
+// applyTaskStatus claims or releases the task t in the scheduler, after its status was set to status in the store.
+// It returns the result of the update, for the audit log and hooks.
+func (c *Coordinator) applyTaskStatus(ctx context.Context, t backend.StoreTaskWithMeta, status backend.TaskStatus) (backend.UpdateTaskResult, error) {
+	c.resetFailures(t.Task.ID)
+
+	task, meta, err := c.Store.FindTaskByIDWithMeta(ctx, t.Task.ID)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+	res := backend.UpdateTaskResult{OldScript: task.Script, OldStatus: backend.TaskStatus(t.Meta.Status), NewTask: *task, NewMeta: *meta}
+
+	if status == backend.TaskActive {
+		if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
 			return res, err
 		}
+		return res, nil
 	}
 
+	if err := c.release(ctx, task.ID); err != nil && err != backend.ErrTaskNotClaimed {
+		return res, err
+	}
 	return res, nil
 }
 
+// withinLimit returns as many of the tasks with the given IDs as c can claim, in order,
+// along with a backend.TaskLimitError if some had to be left out.
+func (c *Coordinator) withinLimit(ids []platform.ID) ([]platform.ID, error) {
+	c.ownedMu.Lock()
+	defer c.ownedMu.Unlock()
+
+	room := c.limit - len(c.owned) - len(c.pendingClaims) - c.startupClaims
+	var within []platform.ID
+	for _, id := range ids {
+		if _, ok := c.owned[id]; ok {
+			within = append(within, id)
+			continue
+		}
+		if room <= 0 {
+			return within, backend.TaskLimitError{Limit: c.limit}
+		}
+		within = append(within, id)
+		room--
+	}
+	return within, nil
+}
+
+// setTaskStatus updates the status of the task with ID req.ID in the store and the scheduler, as specified by req.
+// If the scheduler cannot be updated, the task's previous status is restored in the store.
+func (c *Coordinator) setTaskStatus(ctx context.Context, req backend.UpdateTaskRequest) error {
+	defer c.taskLocks.lock(req.ID)()
+
+	res, err := c.updateTask(ctx, req)
+	if err == nil || res.OldStatus == "" || res.OldStatus == req.Status {
+		return err
+	}
+
+	if _, rbErr := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: req.ID, Status: res.OldStatus}); rbErr != nil {
+		return fmt.Errorf("updating status of task %s failed: %s\n\trestoring status also failed: %s", req.ID, err, rbErr)
+	}
+	return err
+}
+
+// purge purges the data retained about the deleted task with the given ID, if WithPurgeOnDelete is set.
+func (c *Coordinator) purge(ctx context.Context, id platform.ID) {
+	if c.compactor == nil {
+		return
+	}
+	if _, err := c.compactor.PurgeTask(ctx, id); err != nil {
+		c.logger.Info("Failed to purge data of deleted task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// DeleteTask cancels the in-flight runs of the task with the given ID, releases it from the scheduler,
+// and deletes it from the store, or marks it as deleted if c uses WithSoftDelete.
+// It returns false if the task does not exist or has already been deleted.
 func (c *Coordinator) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
-	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+	defer c.taskLocks.lock(id)()
+
+	// Look up the task first, to record its org and script in the audit log.
+	task, err := c.Store.FindTaskByID(ctx, id)
+	if err != nil && err != backend.ErrTaskNotFound {
 		return false, err
 	}
 
-	return c.Store.DeleteTask(ctx, id)
+	if c.softDeleting() && task != nil {
+		if deleted, err := c.isDeleted(ctx, id); err != nil || deleted {
+			return false, err
+		}
+	}
+
+	if err := c.cancelRuns(ctx, id); err != nil {
+		return false, err
+	}
+	var releaseErr error
+	if err := c.release(ctx, id); err != nil && err != backend.ErrTaskNotClaimed {
+		if !c.tolerantDelete {
+			return false, err
+		}
+		releaseErr = err
+	}
+	c.resetFailures(id)
+
+	if c.softDeleting() {
+		deleted, err = c.markDeleted(ctx, id)
+	} else {
+		deleted, err = c.deleteStoredTask(ctx, id)
+	}
+	if err != nil {
+		return deleted, err
+	}
+
+	// The task was found above unless it had already been deleted, in which case deleted is false.
+	if deleted && task != nil {
+		c.auditDelete(ctx, task)
+		c.taskDeleted(ctx, id)
+		if !c.softDeleting() {
+			c.purge(ctx, id)
+		}
+	}
+
+	if releaseErr != nil {
+		c.ownedMu.Lock()
+		c.pendingReleases[id] = struct{}{}
+		c.ownedMu.Unlock()
+		c.logger.Info("Deleted task without releasing it from the scheduler; the release will be retried",
+			zap.String("task_id", id.String()), zap.Error(releaseErr))
+		return deleted, backend.ReleaseWarning{TaskID: id, Err: releaseErr}
+	}
+	return deleted, nil
+}
+
+// cancelRuns cancels the in-flight runs of the task with the given ID and waits for them to be marked canceled,
+// if WithCancelRunsOnDelete is enabled and c's scheduler implements backend.TaskRunCanceler.
+func (c *Coordinator) cancelRuns(ctx context.Context, id platform.ID) error {
+	if !c.cancelRunsOnDelete {
+		return nil
+	}
+	rc, ok := c.sch.(backend.TaskRunCanceler)
+	if !ok {
+		return nil
+	}
+	if err := rc.CancelTaskRuns(ctx, id); err != nil && err != backend.ErrTaskNotClaimed {
+		return err
+	}
+	return nil
 }
 
 func (c *Coordinator) DeleteOrg(ctx context.Context, orgID platform.ID) error {
@@ -140,12 +812,21 @@ func (c *Coordinator) DeleteOrg(ctx context.Context, orgID platform.ID) error {
 	}
 
 	for _, orgTask := range orgTasks {
-		if err := c.sch.ReleaseTask(orgTask.Task.ID); err != nil {
+		if err := c.release(ctx, orgTask.Task.ID); err != nil {
 			return err
 		}
 	}
 
-	return c.Store.DeleteOrg(ctx, orgID)
+	if err := c.Store.DeleteOrg(ctx, orgID); err != nil {
+		return err
+	}
+
+	for _, orgTask := range orgTasks {
+		c.forgetTask(orgTask.Task.ID)
+		c.auditDelete(ctx, &orgTask.Task)
+		c.taskDeleted(ctx, orgTask.Task.ID)
+	}
+	return nil
 }
 
 func (c *Coordinator) DeleteUser(ctx context.Context, userID platform.ID) error {
@@ -157,12 +838,21 @@ func (c *Coordinator) DeleteUser(ctx context.Context, userID platform.ID) error
 	}
 
 	for _, userTask := range userTasks {
-		if err := c.sch.ReleaseTask(userTask.Task.ID); err != nil {
+		if err := c.release(ctx, userTask.Task.ID); err != nil {
 			return err
 		}
 	}
 
-	return c.Store.DeleteUser(ctx, userID)
+	if err := c.Store.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, userTask := range userTasks {
+		c.forgetTask(userTask.Task.ID)
+		c.auditDelete(ctx, &userTask.Task)
+		c.taskDeleted(ctx, userTask.Task.ID)
+	}
+	return nil
 }
 
 func (c *Coordinator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
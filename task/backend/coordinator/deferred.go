@@ -0,0 +1,88 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// WithDeferredClaims makes CreateTask return once the task is stored, leaving it to a background goroutine to claim it,
+// so that bulk imports are not held up by a claim round trip per task.
+// Tasks created together are claimed together, the next time the goroutine runs.
+//
+// The coordinator's limit still applies: tasks waiting to be claimed count towards it,
+// and CreateTask returns a backend.TaskLimitError when they would exceed it.
+// A created task that then fails to be claimed is logged, and left for WithReconcile to claim.
+func WithDeferredClaims() Option {
+	return func(c *Coordinator) {
+		c.deferClaims = true
+	}
+}
+
+// deferClaim queues the task with the given ID to be claimed by claimDeferredTasks.
+// It returns a backend.TaskLimitError if c cannot claim any more tasks, counting those already queued.
+func (c *Coordinator) deferClaim(id platform.ID) error {
+	c.ownedMu.Lock()
+	if !c.leasing() && len(c.owned)+len(c.pendingClaims) >= c.limit {
+		c.ownedMu.Unlock()
+		return backend.TaskLimitError{Limit: c.limit}
+	}
+	c.pendingClaims[id] = struct{}{}
+	c.ownedMu.Unlock()
+
+	select {
+	case c.claimSignal <- struct{}{}:
+	default:
+		// claimDeferredTasks has already been signaled, and will see this task too.
+	}
+	return nil
+}
+
+// claimDeferredTasks claims the tasks queued by deferClaim, until Shutdown is called.
+func (c *Coordinator) claimDeferredTasks() {
+	for {
+		select {
+		case <-c.claimSignal:
+		case <-c.stopping:
+			return
+		}
+
+		c.ownedMu.Lock()
+		pending := c.pendingClaims
+		c.pendingClaims = make(map[platform.ID]struct{})
+		c.ownedMu.Unlock()
+
+		for id := range pending {
+			if c.isClosing() {
+				return
+			}
+			c.claimDeferred(id)
+		}
+	}
+}
+
+// claimDeferred claims the task with the given ID, if it still exists and is active.
+func (c *Coordinator) claimDeferred(id platform.ID) {
+	defer c.taskLocks.lock(id)()
+
+	ctx := context.Background()
+	task, meta, err := c.Store.FindTaskByIDWithMeta(ctx, id)
+	if err == backend.ErrTaskNotFound {
+		// Deleted before it was claimed.
+		return
+	}
+	if err != nil {
+		c.logger.Info("Failed to find created task to claim", zap.String("task_id", id.String()), zap.Error(err))
+		return
+	}
+	if backend.TaskStatus(meta.Status) != backend.TaskActive {
+		// Disabled before it was claimed.
+		return
+	}
+
+	if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		c.logger.Info("Failed to claim created task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,64 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// The topics on which a coordinator using WithEventBus publishes the changes it makes to tasks.
+var (
+	// TaskCreatedTopic carries a TaskEvent for each task created.
+	TaskCreatedTopic = eventbus.NewTopic("task.created", TaskEvent{})
+
+	// TaskUpdatedTopic carries a TaskEvent for each task updated, including enabled and disabled.
+	TaskUpdatedTopic = eventbus.NewTopic("task.updated", TaskEvent{})
+
+	// TaskDeletedTopic carries a TaskEvent for each task deleted, including when its organization or user is deleted.
+	TaskDeletedTopic = eventbus.NewTopic("task.deleted", TaskEvent{})
+)
+
+// TaskEvent is the payload of the task lifecycle topics.
+type TaskEvent struct {
+	TaskID platform.ID `json:"taskID"`
+
+	// The task's organization, and its status after the change. Not set for deleted tasks.
+	OrgID  platform.ID `json:"orgID,omitempty"`
+	Status string      `json:"status,omitempty"`
+
+	// The task's status before an update.
+	OldStatus string `json:"oldStatus,omitempty"`
+}
+
+// WithEventBus publishes the changes the coordinator makes to tasks on bus,
+// on TaskCreatedTopic, TaskUpdatedTopic, and TaskDeletedTopic, as Hooks registered with WithHooks would see them.
+// Events that fail to publish are logged and dropped.
+func WithEventBus(bus eventbus.Bus) Option {
+	return func(c *Coordinator) {
+		c.hooks = append(c.hooks, Hooks{
+			OnTaskCreated: func(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta) {
+				c.publish(ctx, bus, TaskCreatedTopic, TaskEvent{TaskID: task.ID, OrgID: task.Org, Status: meta.Status})
+			},
+			OnTaskModified: func(ctx context.Context, res backend.UpdateTaskResult) {
+				c.publish(ctx, bus, TaskUpdatedTopic, TaskEvent{
+					TaskID:    res.NewTask.ID,
+					OrgID:     res.NewTask.Org,
+					Status:    res.NewMeta.Status,
+					OldStatus: string(res.OldStatus),
+				})
+			},
+			OnTaskDeleted: func(ctx context.Context, id platform.ID) {
+				c.publish(ctx, bus, TaskDeletedTopic, TaskEvent{TaskID: id})
+			},
+		})
+	}
+}
+
+func (c *Coordinator) publish(ctx context.Context, bus eventbus.Bus, topic eventbus.Topic, e TaskEvent) {
+	if err := bus.Publish(ctx, topic, e); err != nil {
+		c.logger.Info("Failed to publish task event", zap.String("topic", topic.Name()), zap.String("task_id", e.TaskID.String()), zap.Error(err))
+	}
+}
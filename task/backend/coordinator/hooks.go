@@ -0,0 +1,86 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// Hooks are functions called after the coordinator changes a task, once both the store and the scheduler
+// have been updated successfully. Any of the functions may be nil.
+//
+// Hooks are called synchronously, on the goroutine of the operation that changed the task,
+// so they should return promptly. They are not called for changes made through other coordinators sharing the store,
+// even when those changes are applied to this coordinator's scheduler through WithTaskWatch.
+// Other operations on the changed task wait until the hooks return, so a hook must not modify that task
+// through the coordinator.
+type Hooks struct {
+	// OnTaskCreated is called after a task is created.
+	OnTaskCreated func(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta)
+
+	// OnTaskModified is called after any update to a task, including enabling and disabling it.
+	OnTaskModified func(ctx context.Context, res backend.UpdateTaskResult)
+
+	// OnTaskDeleted is called after a task is deleted, including when its organization or user is deleted.
+	OnTaskDeleted func(ctx context.Context, id platform.ID)
+
+	// OnTaskEnabled is called after an inactive task is made active, following OnTaskModified.
+	OnTaskEnabled func(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta)
+
+	// OnTaskDisabled is called after an active task is made inactive, following OnTaskModified.
+	// If the task was disabled automatically, meta.DisabledReason says why.
+	OnTaskDisabled func(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta)
+}
+
+// WithHooks registers h to be called after the coordinator changes tasks.
+// It may be used more than once; hooks are called in the order they were registered.
+func WithHooks(h Hooks) Option {
+	return func(c *Coordinator) {
+		c.hooks = append(c.hooks, h)
+	}
+}
+
+func (c *Coordinator) taskCreated(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta) {
+	c.changes.Publish(backend.TaskChange{TaskID: task.ID, Created: true})
+	for _, h := range c.hooks {
+		if h.OnTaskCreated != nil {
+			h.OnTaskCreated(ctx, *task, *meta)
+		}
+	}
+}
+
+// taskModified calls the OnTaskModified hooks with res,
+// followed by the OnTaskEnabled or OnTaskDisabled hooks if the update changed the task's status.
+func (c *Coordinator) taskModified(ctx context.Context, res backend.UpdateTaskResult) {
+	c.changes.Publish(backend.TaskChange{TaskID: res.NewTask.ID})
+	newStatus := backend.TaskStatus(res.NewMeta.Status)
+	for _, h := range c.hooks {
+		if h.OnTaskModified != nil {
+			h.OnTaskModified(ctx, res)
+		}
+
+		if newStatus == res.OldStatus {
+			continue
+		}
+		switch newStatus {
+		case backend.TaskActive:
+			if h.OnTaskEnabled != nil {
+				h.OnTaskEnabled(ctx, res.NewTask, res.NewMeta)
+			}
+		case backend.TaskInactive:
+			if h.OnTaskDisabled != nil {
+				h.OnTaskDisabled(ctx, res.NewTask, res.NewMeta)
+			}
+		}
+	}
+}
+
+func (c *Coordinator) taskDeleted(ctx context.Context, id platform.ID) {
+	c.changes.Publish(backend.TaskChange{TaskID: id, Deleted: true})
+	for _, h := range c.hooks {
+		if h.OnTaskDeleted != nil {
+			h.OnTaskDeleted(ctx, id)
+		}
+	}
+}
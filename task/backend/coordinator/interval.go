@@ -0,0 +1,80 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// WithMinInterval sets the shortest time allowed between runs of a task, for organizations without their own minimum.
+// Creating a task, or changing its schedule, so that it would run more often returns a backend.MinIntervalError.
+// For a task with the cron option, the interval is the shortest gap between its next scheduled runs.
+// Tasks that already run more often are left as they are.
+// Zero, the default, allows tasks to run as often as every second.
+func WithMinInterval(d time.Duration) Option {
+	return func(c *Coordinator) {
+		c.minInterval = d
+	}
+}
+
+// WithOrgMinInterval sets the shortest time allowed between runs of the tasks in org, in place of WithMinInterval.
+// It may be used more than once, for different organizations.
+func WithOrgMinInterval(org platform.ID, d time.Duration) Option {
+	return func(c *Coordinator) {
+		c.orgMinIntervals[org] = d
+	}
+}
+
+// MinInterval returns the shortest time allowed between runs of the tasks in the organization with the given ID,
+// or zero if there is no minimum.
+func (c *Coordinator) MinInterval(org platform.ID) time.Duration {
+	if d, ok := c.orgMinIntervals[org]; ok {
+		return d
+	}
+	return c.minInterval
+}
+
+// checkInterval returns a backend.MinIntervalError if script would run more often than org's minimum interval.
+// A script whose options cannot be parsed is left for the store to reject.
+func (c *Coordinator) checkInterval(org platform.ID, script string) error {
+	min := c.MinInterval(org)
+	if min <= 0 {
+		return nil
+	}
+
+	o, err := options.FromScript(script)
+	if err != nil {
+		return nil
+	}
+	if d := o.Interval(c.clock.Now()); d > 0 && d < min {
+		return backend.MinIntervalError{Interval: d, Min: min}
+	}
+	return nil
+}
+
+// checkUpdateInterval calls checkInterval with the script that req would give its task, if req changes the script.
+func (c *Coordinator) checkUpdateInterval(ctx context.Context, req backend.UpdateTaskRequest) error {
+	if req.Script == "" && req.Options.IsZero() {
+		return nil
+	}
+
+	task, err := c.Store.FindTaskByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	org := task.Org
+	if req.Org.Valid() {
+		org = req.Org
+	}
+	if c.MinInterval(org) <= 0 {
+		return nil
+	}
+	script, err := req.NewScript(task.Script)
+	if err != nil {
+		return err
+	}
+	return c.checkInterval(org, script)
+}
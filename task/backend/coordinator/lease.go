@@ -0,0 +1,469 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/retry"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// Elector elects a single leader among coordinators sharing a store.
+// backend.Store satisfies Elector.
+type Elector interface {
+	// AcquireLeaderLease acquires or renews the leader lease for owner, until the Unix timestamp expiresAt.
+	// It returns backend.ErrLeaseHeld if an unexpired lease is held by a different owner.
+	AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error
+
+	// ReleaseLeaderLease gives up the leader lease, if held by owner.
+	ReleaseLeaderLease(ctx context.Context, owner string) error
+}
+
+// shared reports whether c shares its store with other coordinators, via WithLeases or WithLeaderElection.
+func (c *Coordinator) shared() bool {
+	return c.leaseOwner != ""
+}
+
+// leasing reports whether c splits tasks with other coordinators, via WithLeases.
+func (c *Coordinator) leasing() bool {
+	return c.shared() && c.elector == nil
+}
+
+// electing reports whether c only claims tasks while elected leader, via WithLeaderElection.
+func (c *Coordinator) electing() bool {
+	return c.elector != nil
+}
+
+// isLeader reports whether c currently holds the leader lease.
+func (c *Coordinator) isLeader() bool {
+	c.ownedMu.Lock()
+	defer c.ownedMu.Unlock()
+	return c.leader
+}
+
+// claim claims the task in the scheduler.
+// If c is leasing, the task's lease is acquired first;
+// ErrLeaseHeld is not reported as an error, because the task is scheduled by another coordinator.
+// If c is electing but is not the leader, the task is left for the leader to claim.
+// Once c is shutting down, no task is claimed.
+// If c already claims as many tasks as its limit allows, a backend.TaskLimitError is returned,
+// except when c is leasing, in which case the task is left for a peer to claim.
+func (c *Coordinator) claim(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	if c.isClosing() || (c.electing() && !c.isLeader()) {
+		return nil
+	}
+
+	if err := c.checkLimit(task.ID); err != nil {
+		if c.leasing() {
+			return nil
+		}
+		return err
+	}
+
+	if c.leasing() {
+		err := retry.Do(ctx, c.leaseRetry, func(ctx context.Context) error {
+			now := c.clock.Now()
+			err := c.Store.AcquireTaskLease(ctx, task.ID, c.leaseOwner, now.Unix(), now.Add(c.leaseTTL).Unix())
+			if err == backend.ErrLeaseHeld || err == backend.ErrTaskNotFound {
+				return retry.Permanent(err)
+			}
+			return err
+		})
+		if err == backend.ErrLeaseHeld {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.sch.ClaimTask(task, meta); err != nil {
+		if err != backend.ErrTaskAlreadyClaimed && c.leasing() {
+			if relErr := c.releaseLease(ctx, task.ID); relErr != nil {
+				c.logger.Info("Failed to release lease after failed claim", zap.String("task_id", task.ID.String()), zap.Error(relErr))
+			}
+		}
+		return err
+	}
+
+	c.setOwned(task)
+	return nil
+}
+
+// release releases the task from the scheduler, and its lease if c is leasing.
+// The scheduler's ErrTaskNotClaimed is only returned when c does not share its store,
+// since otherwise the task may legitimately be claimed by another coordinator.
+func (c *Coordinator) release(ctx context.Context, id platform.ID) error {
+	c.ownedMu.Lock()
+	delete(c.owned, id)
+	c.ownedMu.Unlock()
+
+	err := c.sch.ReleaseTask(id)
+	if c.leasing() {
+		if relErr := c.releaseLease(ctx, id); relErr != nil && err == nil {
+			err = relErr
+		}
+	}
+	if c.shared() && err == backend.ErrTaskNotClaimed {
+		// Tasks scheduled by other coordinators are never claimed here.
+		err = nil
+	}
+	return err
+}
+
+// releaseLease releases c's lease on the task with the given ID, retrying transient store errors.
+func (c *Coordinator) releaseLease(ctx context.Context, id platform.ID) error {
+	return retry.Do(ctx, c.leaseRetry, func(ctx context.Context) error {
+		return c.Store.ReleaseTaskLease(ctx, id, c.leaseOwner)
+	})
+}
+
+// setOwned records the script of a task that is claimed in the scheduler.
+func (c *Coordinator) setOwned(task *backend.StoreTask) {
+	c.ownedMu.Lock()
+	c.owned[task.ID] = task.Script
+	c.ownedMu.Unlock()
+}
+
+// refreshOwned records task's current script as the one the scheduler has, if c owns task,
+// so that a change to the script that didn't need the scheduler updated is not later mistaken for one that did.
+func (c *Coordinator) refreshOwned(task *backend.StoreTask) {
+	c.ownedMu.Lock()
+	if _, ok := c.owned[task.ID]; ok {
+		c.owned[task.ID] = task.Script
+	}
+	c.ownedMu.Unlock()
+}
+
+// maintainLeases periodically balances c's task leases until c.leaseCtx is canceled.
+func (c *Coordinator) maintainLeases() {
+	defer close(c.sharedDone)
+
+	ticker := c.clock.NewTicker(c.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		c.balanceLeases(c.leaseCtx)
+
+		select {
+		case <-ticker.C():
+		case <-c.leaseCtx.Done():
+			c.releaseLeases()
+			return
+		}
+	}
+}
+
+// balanceLeases renews the leases held by c, acquires unleased or expired leases up to c's fair share of active tasks,
+// and sheds leases beyond its fair share so that newly started peers can pick them up.
+// The scheduler's claims are updated to match the resulting set of leases.
+func (c *Coordinator) balanceLeases(ctx context.Context) {
+	if c.isClosing() {
+		return
+	}
+
+	now := c.clock.Now()
+	nowUnix := now.Unix()
+	expiresAt := now.Add(c.leaseTTL).Unix()
+
+	if err := c.Store.KeepAliveLeaseOwner(ctx, c.leaseOwner, expiresAt); err != nil {
+		c.logger.Error("Failed to keep lease owner alive", zap.Error(err))
+		return
+	}
+
+	owners, err := c.Store.ListLeaseOwners(ctx, nowUnix)
+	if err != nil {
+		c.logger.Error("Failed to list lease owners", zap.Error(err))
+		return
+	}
+	numOwners := len(owners)
+	found := false
+	for _, o := range owners {
+		if o == c.leaseOwner {
+			found = true
+			break
+		}
+	}
+	if !found {
+		numOwners++
+	}
+
+	leases, err := c.Store.ListTaskLeases(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list task leases", zap.Error(err))
+		return
+	}
+	holders := make(map[platform.ID]string, len(leases))
+	for _, l := range leases {
+		if !l.Expired(nowUnix) {
+			holders[l.TaskID] = l.Owner
+		}
+	}
+
+	tasks, err := c.listAllTasks(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list tasks", zap.Error(err))
+		return
+	}
+	active := tasks[:0]
+	for _, t := range tasks {
+		if t.Meta.Status == string(backend.TaskActive) {
+			active = append(active, t)
+		}
+	}
+
+	// Round up, so that every task has room with some owner.
+	target := (len(active) + numOwners - 1) / numOwners
+	if target > c.limit {
+		target = c.limit
+	}
+
+	seen := make(map[platform.ID]struct{}, len(active))
+	held := 0
+	var unleased []backend.StoreTaskWithMeta
+	for _, t := range active {
+		seen[t.Task.ID] = struct{}{}
+
+		switch h, ok := holders[t.Task.ID]; {
+		case ok && h == c.leaseOwner:
+			if held >= target {
+				// Shed the lease so a less loaded peer can take over the task.
+				c.dropTask(ctx, t.Task.ID)
+				continue
+			}
+			if c.renewTask(ctx, t, nowUnix, expiresAt) {
+				held++
+			}
+		case ok:
+			// Another coordinator owns the task; make sure it isn't scheduled here too.
+			c.unclaimTask(t.Task.ID)
+		default:
+			unleased = append(unleased, t)
+		}
+	}
+
+	for _, t := range unleased {
+		if held >= target {
+			c.unclaimTask(t.Task.ID)
+			continue
+		}
+		if c.renewTask(ctx, t, nowUnix, expiresAt) {
+			held++
+		}
+	}
+
+	c.releaseStale(ctx, seen)
+}
+
+// releaseStale releases any task claimed by c that is not in active,
+// because it was deleted or disabled, possibly through another coordinator.
+func (c *Coordinator) releaseStale(ctx context.Context, active map[platform.ID]struct{}) {
+	var stale []platform.ID
+	c.ownedMu.Lock()
+	for id := range c.owned {
+		if _, ok := active[id]; !ok {
+			stale = append(stale, id)
+		}
+	}
+	c.ownedMu.Unlock()
+	for _, id := range stale {
+		c.dropTask(ctx, id)
+	}
+}
+
+// renewTask acquires or renews the lease on t, and ensures the scheduler's claim on t reflects its current script.
+// It returns true if c holds the lease and the task is claimed.
+func (c *Coordinator) renewTask(ctx context.Context, t backend.StoreTaskWithMeta, now, expiresAt int64) bool {
+	id := t.Task.ID
+	if err := c.Store.AcquireTaskLease(ctx, id, c.leaseOwner, now, expiresAt); err != nil {
+		if err != backend.ErrLeaseHeld {
+			c.logger.Info("Failed to acquire task lease", zap.String("task_id", id.String()), zap.Error(err))
+		}
+		c.unclaimTask(id)
+		return false
+	}
+
+	return c.syncClaim(t)
+}
+
+// syncClaim claims t in the scheduler if it isn't already claimed by c,
+// or updates the scheduler if t's script changed since it was claimed.
+// It returns true if t is claimed.
+func (c *Coordinator) syncClaim(t backend.StoreTaskWithMeta) bool {
+	id := t.Task.ID
+
+	c.ownedMu.Lock()
+	script, owned := c.owned[id]
+	c.ownedMu.Unlock()
+
+	switch {
+	case !owned:
+		if err := c.checkLimit(id); err != nil {
+			c.logger.Info("Failed to claim task", zap.String("task_id", id.String()), zap.Error(err))
+			return false
+		}
+		if err := c.sch.ClaimTask(&t.Task, &t.Meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			c.logger.Error("Failed to claim leased task", zap.String("task_id", id.String()), zap.Error(err))
+			return false
+		}
+	case script != t.Task.Script:
+		// The task was updated through another coordinator.
+		if err := c.sch.UpdateTask(&t.Task, &t.Meta); err != nil {
+			c.logger.Error("Failed to update leased task", zap.String("task_id", id.String()), zap.Error(err))
+			return false
+		}
+	}
+
+	c.setOwned(&t.Task)
+	return true
+}
+
+// dropTask releases the task from the scheduler and releases its lease, logging any error.
+func (c *Coordinator) dropTask(ctx context.Context, id platform.ID) {
+	if err := c.release(ctx, id); err != nil {
+		c.logger.Info("Failed to release task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// unclaimTask releases the task from the scheduler if c had claimed it, without touching its lease.
+func (c *Coordinator) unclaimTask(id platform.ID) {
+	c.ownedMu.Lock()
+	_, owned := c.owned[id]
+	delete(c.owned, id)
+	c.ownedMu.Unlock()
+
+	if !owned {
+		return
+	}
+	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+		c.logger.Info("Failed to release task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// releaseLeases releases every task owned by c, so that peers can take over without waiting for expiration.
+func (c *Coordinator) releaseLeases() {
+	c.ownedMu.Lock()
+	ids := make([]platform.ID, 0, len(c.owned))
+	for id := range c.owned {
+		ids = append(ids, id)
+	}
+	c.ownedMu.Unlock()
+
+	// c.leaseCtx is already canceled at this point.
+	ctx := context.Background()
+	for _, id := range ids {
+		c.dropTask(ctx, id)
+	}
+}
+
+// maintainLeadership periodically campaigns for the leader lease until c.leaseCtx is canceled.
+func (c *Coordinator) maintainLeadership() {
+	defer close(c.sharedDone)
+
+	ticker := c.clock.NewTicker(c.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		c.campaign(c.leaseCtx)
+
+		select {
+		case <-ticker.C():
+		case <-c.leaseCtx.Done():
+			c.stepDown()
+			// c.leaseCtx is already canceled at this point.
+			if err := c.elector.ReleaseLeaderLease(context.Background(), c.leaseOwner); err != nil {
+				c.logger.Info("Failed to release leader lease", zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// campaign acquires or renews the leader lease.
+// While c is the leader, every active task is claimed in its scheduler.
+func (c *Coordinator) campaign(ctx context.Context) {
+	if c.isClosing() {
+		return
+	}
+
+	now := c.clock.Now()
+	expiresAt := now.Add(c.leaseTTL).Unix()
+
+	err := c.elector.AcquireLeaderLease(ctx, c.leaseOwner, now.Unix(), expiresAt)
+	if err == backend.ErrLeaseHeld {
+		c.stepDown()
+		return
+	}
+	if err != nil {
+		c.logger.Error("Failed to acquire leader lease", zap.Error(err))
+
+		// Step down before the lease could expire, so that a new leader never overlaps with this one.
+		c.ownedMu.Lock()
+		expiring := now.Add(c.leaseTTL/3).Unix() >= c.leaderUntil
+		c.ownedMu.Unlock()
+		if expiring {
+			c.stepDown()
+		}
+		return
+	}
+
+	c.ownedMu.Lock()
+	if !c.leader {
+		c.logger.Info("Elected leader", zap.String("owner", c.leaseOwner))
+	}
+	c.leader = true
+	c.leaderUntil = expiresAt
+	c.ownedMu.Unlock()
+
+	tasks, err := c.listAllTasks(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list tasks", zap.Error(err))
+		return
+	}
+	active := make(map[platform.ID]struct{}, len(tasks))
+	for _, t := range tasks {
+		if t.Meta.Status != string(backend.TaskActive) {
+			continue
+		}
+		active[t.Task.ID] = struct{}{}
+		c.syncClaim(t)
+	}
+	c.releaseStale(ctx, active)
+}
+
+// stepDown releases every task claimed by c and marks c as no longer the leader.
+func (c *Coordinator) stepDown() {
+	c.ownedMu.Lock()
+	wasLeader := c.leader
+	c.leader = false
+	ids := make([]platform.ID, 0, len(c.owned))
+	for id := range c.owned {
+		ids = append(ids, id)
+	}
+	c.ownedMu.Unlock()
+
+	if wasLeader {
+		c.logger.Info("Stepping down as leader", zap.String("owner", c.leaseOwner))
+	}
+	for _, id := range ids {
+		c.unclaimTask(id)
+	}
+}
+
+// listAllTasks pages through every task in the store.
+func (c *Coordinator) listAllTasks(ctx context.Context) ([]backend.StoreTaskWithMeta, error) {
+	var all []backend.StoreTaskWithMeta
+	params := backend.TaskSearchParams{PageSize: platform.TaskMaxPageSize}
+	for {
+		tasks, err := c.Store.ListTasks(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks...)
+		if len(tasks) < params.PageSize {
+			return all, nil
+		}
+		params.After = tasks[len(tasks)-1].Task.ID
+	}
+}
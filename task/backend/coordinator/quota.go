@@ -0,0 +1,118 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WithQuotas limits the number of tasks each organization may have to the quotas found in qs.
+// Creating a task in, or transferring a task into, an organization that has reached its quota returns a backend.TaskQuotaError.
+// Tasks already over a quota, such as after the quota is lowered, are left as they are.
+// The limits of quotas on runs are enforced by the scheduler; see backend.WithQuotas.
+func WithQuotas(qs backend.QuotaService) Option {
+	return func(c *Coordinator) {
+		c.quotas = qs
+	}
+}
+
+// checkQuota returns a backend.TaskQuotaError if adding a task with script to org would exceed org's quota.
+// A script whose options cannot be parsed is left for the store to reject.
+func (c *Coordinator) checkQuota(ctx context.Context, org platform.ID, script string) error {
+	if c.quotas == nil {
+		return nil
+	}
+	q, err := c.quotas.TaskQuota(ctx, org)
+	if err != nil {
+		return err
+	}
+	if q.IsZero() {
+		return nil
+	}
+
+	now := c.clock.Now()
+	highFrequency := func(o options.Options) bool {
+		d := o.Interval(now)
+		return d > 0 && d <= q.HighFrequencyInterval
+	}
+
+	// Only count high-frequency tasks if the new task is one.
+	countHigh := false
+	if q.MaxHighFrequencyTasks > 0 && q.HighFrequencyInterval > 0 {
+		o, err := options.FromScript(script)
+		if err != nil {
+			return nil
+		}
+		countHigh = highFrequency(o)
+	}
+	if q.MaxTasks <= 0 && !countHigh {
+		return nil
+	}
+
+	var total, high int
+	params := backend.TaskSearchParams{Org: org}
+	for {
+		tasks, err := c.Store.ListTasks(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			break
+		}
+
+		total += len(tasks)
+		if countHigh {
+			for _, t := range tasks {
+				if o, err := t.Task.ScriptOptions(); err == nil && highFrequency(o) {
+					high++
+				}
+			}
+		}
+
+		params.After = tasks[len(tasks)-1].Task.ID
+	}
+
+	if q.MaxTasks > 0 && total >= q.MaxTasks {
+		c.quotaMetrics.Reject("tasks")
+		return backend.TaskQuotaError{Org: org, Limit: q.MaxTasks}
+	}
+	if countHigh && high >= q.MaxHighFrequencyTasks {
+		c.quotaMetrics.Reject("high_frequency")
+		return backend.TaskQuotaError{Org: org, Limit: q.MaxHighFrequencyTasks, HighFrequency: true}
+	}
+	return nil
+}
+
+// quotaMetrics is a collection of metrics relating to task quotas.
+type quotaMetrics struct {
+	rejections *prometheus.CounterVec
+}
+
+func newQuotaMetrics() *quotaMetrics {
+	const namespace = "task"
+	const subsystem = "coordinator"
+
+	return &quotaMetrics{
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "quota_rejections_total",
+			Help:      "Number of tasks not created because their organization reached a quota, split out by quota.",
+		}, []string{"quota"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (qm *quotaMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		qm.rejections,
+	}
+}
+
+// Reject adjusts the metrics to indicate a task rejected by the given quota.
+func (qm *quotaMetrics) Reject(quota string) {
+	qm.rejections.WithLabelValues(quota).Inc()
+}
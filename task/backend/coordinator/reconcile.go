@@ -0,0 +1,231 @@
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// WithReconcile makes the coordinator reconcile its scheduler with the store every interval, as by Reconcile,
+// so that a failure partway through creating, updating, or deleting a task does not leave the two out of sync for good.
+// The coordinator stops reconciling when ctx is canceled.
+//
+// Coordinators using WithLeases or WithLeaderElection already reconcile their claims each time they renew their lease,
+// so WithReconcile has no effect on them.
+func WithReconcile(ctx context.Context, interval time.Duration) Option {
+	return func(c *Coordinator) {
+		c.reconcileCtx = ctx
+		c.reconcileInterval = interval
+	}
+}
+
+// ReconcileResult lists the tasks that a call to Reconcile found out of sync between the store and the scheduler.
+type ReconcileResult struct {
+	// Claimed are the active tasks that were not claimed in the scheduler.
+	Claimed []platform.ID
+
+	// Updated are the claimed tasks whose script in the scheduler was out of date.
+	Updated []platform.ID
+
+	// Released are the tasks claimed in the scheduler that are inactive or no longer exist in the store,
+	// including deleted tasks whose release failed when they were deleted. See WithTolerantDelete.
+	Released []platform.ID
+}
+
+// Drift returns the number of tasks that were found out of sync.
+func (r ReconcileResult) Drift() int {
+	return len(r.Claimed) + len(r.Updated) + len(r.Released)
+}
+
+// Reconcile compares the active tasks in the store with the tasks claimed in the scheduler.
+// Active tasks that are not claimed are claimed, claimed tasks that are inactive or deleted are released,
+// and claimed tasks whose script changed are updated in the scheduler.
+//
+// Each task found out of sync is looked up again before it is corrected, so that a concurrent update is not undone.
+// If correcting a task fails, Reconcile moves on to the remaining tasks, and returns the first error
+// along with every task that was found out of sync.
+// Reconcile does nothing if the coordinator shares its store, or is shutting down.
+func (c *Coordinator) Reconcile(ctx context.Context) (ReconcileResult, error) {
+	var res ReconcileResult
+	if c.shared() || c.isClosing() {
+		return res, nil
+	}
+
+	// Take the claims before listing the store, so that a task created in between is not mistaken for a deleted one.
+	claimed := make(map[platform.ID]struct{})
+	for _, id := range c.sch.ClaimedTasks() {
+		claimed[id] = struct{}{}
+	}
+	c.ownedMu.Lock()
+	owned := make(map[platform.ID]string, len(c.owned))
+	for id, script := range c.owned {
+		owned[id] = script
+	}
+	pending := make(map[platform.ID]bool, len(c.pendingReleases)) // Task ID -> whether the task is still deleted.
+	for id := range c.pendingReleases {
+		pending[id] = true
+	}
+	c.ownedMu.Unlock()
+
+	tasks, err := c.listAllTasks(ctx)
+	if err != nil {
+		c.reconcileMetrics.ListFailed()
+		return res, err
+	}
+
+	var firstErr error
+	failed := make(map[platform.ID]struct{})
+	correct := func(id platform.ID) {
+		if err := c.applyTaskChange(ctx, backend.TaskChange{TaskID: id}); err != nil {
+			c.logger.Info("Failed to reconcile task", zap.String("task_id", id.String()), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed[id] = struct{}{}
+		}
+	}
+
+	for _, t := range tasks {
+		id := t.Task.ID
+		_, isClaimed := claimed[id]
+		script, isOwned := owned[id]
+		delete(claimed, id)
+		delete(owned, id)
+
+		active := t.Meta.Status == string(backend.TaskActive)
+		if _, ok := pending[id]; ok {
+			// A task marked as deleted is still released below, unless it is released here.
+			pending[id] = t.Meta.Status == string(backend.TaskDeleted) && !isClaimed && !isOwned
+		}
+		switch {
+		case active && !isClaimed:
+			res.Claimed = append(res.Claimed, id)
+		case active && (!isOwned || script != t.Task.Script):
+			res.Updated = append(res.Updated, id)
+		case !active && (isClaimed || isOwned):
+			res.Released = append(res.Released, id)
+		default:
+			continue
+		}
+		correct(id)
+	}
+
+	// Whatever remains was claimed, or recorded as claimed, for tasks that no longer exist.
+	// Deleted tasks whose release failed are released again, even if the scheduler no longer reports them as claimed.
+	for id := range owned {
+		claimed[id] = struct{}{}
+	}
+	for id, deleted := range pending {
+		if deleted {
+			claimed[id] = struct{}{}
+		}
+	}
+	for id := range claimed {
+		res.Released = append(res.Released, id)
+		correct(id)
+	}
+
+	// Tasks whose release failed again are retried on the next reconcile.
+	c.ownedMu.Lock()
+	for id := range pending {
+		if _, ok := failed[id]; !ok {
+			delete(c.pendingReleases, id)
+		}
+	}
+	c.ownedMu.Unlock()
+
+	c.reconcileMetrics.Reconcile(res, firstErr)
+	return res, firstErr
+}
+
+// reconcileTasks calls Reconcile every c.reconcileInterval until c.reconcileCtx is canceled.
+func (c *Coordinator) reconcileTasks() {
+	ticker := c.clock.NewTicker(c.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+		case <-c.reconcileCtx.Done():
+			return
+		}
+
+		res, err := c.Reconcile(c.reconcileCtx)
+		if err != nil {
+			c.logger.Error("Failed to reconcile tasks", zap.Int("drift", res.Drift()), zap.Error(err))
+		} else if res.Drift() > 0 {
+			c.logger.Info("Reconciled tasks", zap.Int("claimed", len(res.Claimed)), zap.Int("updated", len(res.Updated)), zap.Int("released", len(res.Released)))
+		}
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (c *Coordinator) PrometheusCollectors() []prometheus.Collector {
+	cs := append(c.reconcileMetrics.PrometheusCollectors(), c.quotaMetrics.PrometheusCollectors()...)
+	return append(cs, c.startupMetrics.PrometheusCollectors()...)
+}
+
+// reconcileMetrics is a collection of metrics relating to reconciling the store and the scheduler.
+type reconcileMetrics struct {
+	checks *prometheus.CounterVec
+	drift  prometheus.Gauge
+	tasks  *prometheus.CounterVec
+}
+
+func newReconcileMetrics() *reconcileMetrics {
+	const namespace = "task"
+	const subsystem = "coordinator"
+
+	return &reconcileMetrics{
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reconciles_total",
+			Help:      "Number of times the scheduler was reconciled with the store, split out by success or failure.",
+		}, []string{"status"}),
+		drift: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reconcile_drift",
+			Help:      "Number of tasks found out of sync between the store and the scheduler in the latest reconcile.",
+		}),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "reconciled_tasks_total",
+			Help:      "Number of tasks found out of sync between the store and the scheduler, split out by the action taken.",
+		}, []string{"action"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (rm *reconcileMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		rm.checks,
+		rm.drift,
+		rm.tasks,
+	}
+}
+
+// ListFailed adjusts the metrics to indicate a reconcile that failed before any tasks were compared.
+func (rm *reconcileMetrics) ListFailed() {
+	rm.checks.WithLabelValues("failure").Inc()
+}
+
+// Reconcile adjusts the metrics to indicate the result of a reconcile.
+// A reconcile that failed to correct some tasks still reports the drift it found.
+func (rm *reconcileMetrics) Reconcile(res ReconcileResult, err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	rm.checks.WithLabelValues(status).Inc()
+	rm.drift.Set(float64(res.Drift()))
+	rm.tasks.WithLabelValues("claim").Add(float64(len(res.Claimed)))
+	rm.tasks.WithLabelValues("update").Add(float64(len(res.Updated)))
+	rm.tasks.WithLabelValues("release").Add(float64(len(res.Released)))
+}
@@ -0,0 +1,15 @@
+package coordinator
+
+import (
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// checkSecrets returns a backend.InlineSecretError if script holds a credential,
+// such as a token or a URL with a password, that belongs in the secret service.
+func checkSecrets(script string) error {
+	if s := options.InlineSecret(script); s != "" {
+		return backend.InlineSecretError{Secret: s}
+	}
+	return nil
+}
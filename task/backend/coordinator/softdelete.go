@@ -0,0 +1,231 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// ErrTaskNotDeleted is returned by RestoreTask when the task exists but has not been deleted.
+var ErrTaskNotDeleted = platform.NewCodedError(platform.EConflict, "task is not deleted")
+
+// deletedTaskCheckInterval is how often deleted tasks are checked for purging, unless the purge window is shorter.
+const deletedTaskCheckInterval = time.Minute
+
+// WithSoftDelete makes DeleteTask mark a task as deleted instead of removing it from the store,
+// so that an accidental deletion can be undone with RestoreTask.
+// A deleted task is released from the scheduler and left out of ListTasks,
+// and it cannot be updated until it is restored.
+//
+// Deleted tasks are removed from the store for good once they have been deleted for purgeAfter,
+// as by PurgeDeletedTasks, which the coordinator calls every minute, or every purgeAfter if it is shorter,
+// until ctx is canceled. Values of purgeAfter less than or equal to zero have no effect.
+func WithSoftDelete(ctx context.Context, purgeAfter time.Duration) Option {
+	return func(c *Coordinator) {
+		if purgeAfter <= 0 {
+			return
+		}
+		c.softDeleteCtx = ctx
+		c.purgeAfter = purgeAfter
+	}
+}
+
+// softDeleting reports whether c keeps deleted tasks until they are purged. See WithSoftDelete.
+func (c *Coordinator) softDeleting() bool {
+	return c.purgeAfter > 0
+}
+
+// isDeleted reports whether the task with the given ID is marked as deleted.
+// A task that is not in the store is not reported as deleted.
+func (c *Coordinator) isDeleted(ctx context.Context, id platform.ID) (bool, error) {
+	meta, err := c.Store.FindTaskMetaByID(ctx, id)
+	if err == backend.ErrTaskNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return meta.Status == string(backend.TaskDeleted), nil
+}
+
+// markDeleted marks the task with the given ID as deleted in the store, as of now.
+// It returns false if the task does not exist.
+func (c *Coordinator) markDeleted(ctx context.Context, id platform.ID) (bool, error) {
+	_, err := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskDeleted, DeletedAt: c.clock.Now().Unix()})
+	if err == backend.ErrTaskNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListTasks lists the tasks in the store that match params, leaving out deleted tasks that have yet to be purged.
+func (c *Coordinator) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTaskWithMeta, error) {
+	if params.PageSize == 0 {
+		params.PageSize = platform.TaskDefaultPageSize
+	}
+
+	var listed []backend.StoreTaskWithMeta
+	for {
+		tasks, err := c.Store.ListTasks(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tasks {
+			if t.Meta.Status != string(backend.TaskDeleted) {
+				listed = append(listed, t)
+			}
+		}
+
+		// Keep listing past deleted tasks to fill the page.
+		// The caller continues after the last task returned, so tasks beyond a full page are listed on the next one.
+		if len(listed) >= params.PageSize {
+			return listed[:params.PageSize], nil
+		}
+		if len(tasks) < params.PageSize {
+			return listed, nil
+		}
+		params.After = tasks[len(tasks)-1].Task.ID
+	}
+}
+
+// RestoreTask restores the task with the given ID, which was deleted within the purge window set by WithSoftDelete.
+// The task is given back the status it had when it was deleted, and claimed in the scheduler if that status is active.
+// If the task cannot be claimed, it is left deleted and the error is returned.
+//
+// If the task is not in the store, because it was never created or has already been purged,
+// backend.ErrTaskNotFound is returned; if it is in the store but not deleted, ErrTaskNotDeleted is returned.
+func (c *Coordinator) RestoreTask(ctx context.Context, id platform.ID) (backend.UpdateTaskResult, error) {
+	defer c.taskLocks.lock(id)()
+
+	meta, err := c.Store.FindTaskMetaByID(ctx, id)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+	if meta.Status != string(backend.TaskDeleted) {
+		return backend.UpdateTaskResult{}, ErrTaskNotDeleted
+	}
+
+	status := backend.TaskStatus(meta.DeletedStatus)
+	if status != backend.TaskActive {
+		status = backend.TaskInactive
+	}
+	if status == backend.TaskActive && !c.leasing() {
+		if err := c.checkLimit(id); err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	res, err := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: id, Status: status})
+	if err != nil {
+		return res, err
+	}
+
+	if status == backend.TaskActive {
+		if err := c.claim(ctx, &res.NewTask, &res.NewMeta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			// Leave the task deleted, rather than active but unscheduled.
+			if _, rbErr := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskDeleted, DeletedAt: meta.DeletedAt}); rbErr != nil {
+				return backend.UpdateTaskResult{}, fmt.Errorf("claiming restored task %s failed: %s\n\tmarking it deleted again also failed: %s", id, err, rbErr)
+			}
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	c.audit(ctx, backend.AuditEntry{TaskID: id, Org: res.NewTask.Org, Action: backend.AuditRestore, NewScriptHash: backend.ScriptHash(res.NewTask.Script)})
+	c.taskCreated(ctx, &res.NewTask, &res.NewMeta)
+	return res, nil
+}
+
+// PurgeDeletedTasks removes from the store every task that has been deleted for at least the purge window set by WithSoftDelete,
+// along with the data retained about it if WithPurgeOnDelete is set.
+// It returns the IDs of the purged tasks; if purging a task fails, it moves on to the remaining tasks,
+// and returns the first error along with the tasks purged.
+// PurgeDeletedTasks does nothing unless WithSoftDelete is used.
+func (c *Coordinator) PurgeDeletedTasks(ctx context.Context) ([]platform.ID, error) {
+	if !c.softDeleting() {
+		return nil, nil
+	}
+
+	tasks, err := c.listAllTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := c.clock.Now().Add(-c.purgeAfter).Unix()
+	var purged []platform.ID
+	var firstErr error
+	for _, t := range tasks {
+		if t.Meta.Status != string(backend.TaskDeleted) || t.Meta.DeletedAt > cutoff {
+			continue
+		}
+
+		ok, err := c.purgeDeletedTask(ctx, t.Task.ID, cutoff)
+		if err != nil {
+			c.logger.Info("Failed to purge deleted task", zap.String("task_id", t.Task.ID.String()), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if ok {
+			purged = append(purged, t.Task.ID)
+		}
+	}
+	return purged, firstErr
+}
+
+// purgeDeletedTask removes the task with the given ID from the store, if it is still deleted and was deleted no later than cutoff.
+// It returns whether the task was removed.
+func (c *Coordinator) purgeDeletedTask(ctx context.Context, id platform.ID, cutoff int64) (bool, error) {
+	defer c.taskLocks.lock(id)()
+
+	// Look the task up again, in case it was restored since it was listed.
+	meta, err := c.Store.FindTaskMetaByID(ctx, id)
+	if err == backend.ErrTaskNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if meta.Status != string(backend.TaskDeleted) || meta.DeletedAt > cutoff {
+		return false, nil
+	}
+
+	deleted, err := c.deleteStoredTask(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if deleted {
+		c.purge(ctx, id)
+	}
+	return deleted, nil
+}
+
+// purgeDeletedTasks calls PurgeDeletedTasks periodically until c.softDeleteCtx is canceled.
+func (c *Coordinator) purgeDeletedTasks() {
+	interval := deletedTaskCheckInterval
+	if c.purgeAfter < interval {
+		interval = c.purgeAfter
+	}
+	ticker := c.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+		case <-c.softDeleteCtx.Done():
+			return
+		}
+
+		purged, err := c.PurgeDeletedTasks(c.softDeleteCtx)
+		if err != nil {
+			c.logger.Error("Failed to purge deleted tasks", zap.Int("purged", len(purged)), zap.Error(err))
+		} else if len(purged) > 0 {
+			c.logger.Info("Purged deleted tasks", zap.Int("purged", len(purged)))
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package coordinator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// defaultClaimWorkers is the number of tasks claimed concurrently at startup, unless set by WithClaimWorkers.
+const defaultClaimWorkers = 8
+
+// WithClaimWorkers sets the number of tasks the coordinator claims concurrently in its scheduler
+// when it starts, 8 by default. Values less than 1 are treated as 1.
+func WithClaimWorkers(n int) Option {
+	return func(c *Coordinator) {
+		if n < 1 {
+			n = 1
+		}
+		c.claimWorkers = n
+	}
+}
+
+// Ready reports whether c has finished claiming the tasks in the store when it started,
+// so that a readiness check can hold back traffic until the tasks are scheduled.
+// Claiming finishes whether or not every task was claimed; tasks that failed to be claimed are logged,
+// and left for WithReconcile to claim.
+//
+// A coordinator that uses WithLeases or WithLeaderElection claims tasks as it acquires them, and is ready as soon as it is created.
+func (c *Coordinator) Ready() bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// claimExistingTasks is called on startup to claim all tasks in the store, using c.claimWorkers goroutines.
+// It marks c ready when it returns.
+func (c *Coordinator) claimExistingTasks() {
+	defer close(c.ready)
+
+	tasks, err := c.listAllTasks(context.Background())
+	if err != nil {
+		c.logger.Error("failed to list tasks", zap.Error(err))
+		return
+	}
+
+	var claimable []backend.StoreTaskWithMeta
+	for _, t := range tasks {
+		if t.Meta.Status != string(backend.TaskDeleted) {
+			claimable = append(claimable, t)
+		}
+	}
+	c.startupMetrics.Pending(len(claimable))
+
+	queue := make(chan backend.StoreTaskWithMeta)
+	var wg sync.WaitGroup
+	for i := 0; i < c.claimWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				c.claimExistingTask(t)
+			}
+		}()
+	}
+
+	for i, t := range claimable {
+		if c.isClosing() {
+			break
+		}
+		if err := c.reserveStartupClaim(t.Task.ID); err != nil {
+			c.logger.Error("failed claim task", zap.Error(err))
+			c.startupMetrics.Skip(len(claimable) - i)
+			break
+		}
+		queue <- t
+	}
+	close(queue)
+	wg.Wait()
+	c.startupMetrics.Pending(0)
+
+	claimed, _ := c.TaskLimit()
+	c.logger.Info("Claimed existing tasks", zap.Int("claimed", claimed), zap.Int("listed", len(claimable)))
+}
+
+// claimExistingTask claims t in the scheduler, for claimExistingTasks, which has reserved room for it under c's limit.
+func (c *Coordinator) claimExistingTask(t backend.StoreTaskWithMeta) {
+	err := c.sch.ClaimTask(&t.Task, &t.Meta)
+	if err == nil {
+		c.setOwned(&t.Task)
+	}
+
+	c.ownedMu.Lock()
+	c.startupClaims--
+	c.ownedMu.Unlock()
+
+	if err != nil {
+		c.logger.Error("failed claim task", zap.String("task_id", t.Task.ID.String()), zap.Error(err))
+	}
+	c.startupMetrics.Claim(err)
+}
+
+// reserveStartupClaim counts the task with the given ID towards c's limit while claimExistingTasks claims it.
+// It returns a backend.TaskLimitError if c cannot claim any more tasks, counting those being claimed.
+func (c *Coordinator) reserveStartupClaim(id platform.ID) error {
+	c.ownedMu.Lock()
+	defer c.ownedMu.Unlock()
+
+	if _, ok := c.owned[id]; !ok && len(c.owned)+c.startupClaims >= c.limit {
+		return backend.TaskLimitError{Limit: c.limit}
+	}
+	c.startupClaims++
+	return nil
+}
+
+// startupMetrics is a collection of metrics relating to claiming tasks at startup.
+type startupMetrics struct {
+	pending prometheus.Gauge
+	claims  *prometheus.CounterVec
+}
+
+func newStartupMetrics() *startupMetrics {
+	const namespace = "task"
+	const subsystem = "coordinator"
+
+	return &startupMetrics{
+		pending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "startup_claims_pending",
+			Help:      "Number of tasks listed at startup that have yet to be claimed.",
+		}),
+		claims: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "startup_claims_total",
+			Help:      "Number of tasks listed at startup that were claimed, failed to be claimed, or skipped for the task limit, split out by status.",
+		}, []string{"status"}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (sm *startupMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		sm.pending,
+		sm.claims,
+	}
+}
+
+// Pending sets the number of tasks yet to be claimed.
+func (sm *startupMetrics) Pending(n int) {
+	sm.pending.Set(float64(n))
+}
+
+// Claim adjusts the metrics to indicate a task claimed, or that failed to be claimed with err.
+func (sm *startupMetrics) Claim(err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	sm.claims.WithLabelValues(status).Inc()
+	sm.pending.Dec()
+}
+
+// Skip adjusts the metrics to indicate n tasks left unclaimed because the limit was reached.
+func (sm *startupMetrics) Skip(n int) {
+	sm.claims.WithLabelValues("skipped").Add(float64(n))
+	sm.pending.Sub(float64(n))
+}
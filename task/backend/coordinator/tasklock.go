@@ -0,0 +1,51 @@
+package coordinator
+
+import (
+	"sync"
+
+	"github.com/influxdata/platform"
+)
+
+// taskLocks serializes operations on the same task, so that the store and scheduler calls made to update,
+// enable, disable, transfer, or delete a task are not interleaved with those of another operation on that task.
+// Operations on different tasks do not block each other.
+// The zero value is ready to use.
+type taskLocks struct {
+	mu    sync.Mutex
+	locks map[platform.ID]*taskLock
+}
+
+// taskLock is the lock for a single task, along with the number of operations holding or waiting for it.
+type taskLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other operation holds the lock for the task with the given ID, then acquires it.
+// The returned function releases the lock, and must be called exactly once.
+func (tl *taskLocks) lock(id platform.ID) (unlock func()) {
+	tl.mu.Lock()
+	if tl.locks == nil {
+		tl.locks = make(map[platform.ID]*taskLock)
+	}
+	l, ok := tl.locks[id]
+	if !ok {
+		l = new(taskLock)
+		tl.locks[id] = l
+	}
+	l.refs++
+	tl.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		tl.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			// Nothing else is waiting on this task; don't keep its lock around.
+			delete(tl.locks, id)
+		}
+		tl.mu.Unlock()
+	}
+}
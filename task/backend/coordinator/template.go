@@ -0,0 +1,139 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// ErrTemplatesNotSupported is returned by the coordinator's template methods when its store does not implement backend.TemplateStore.
+var ErrTemplatesNotSupported = platform.NewCodedError(platform.EUnprocessableEntity, "task store does not support templates")
+
+// Templates returns the coordinator's store as a backend.TemplateStore, for creating, finding, and deleting templates.
+// Templates should be updated with UpdateTemplate, and instantiated with InstantiateTemplate.
+func (c *Coordinator) Templates() (backend.TemplateStore, error) {
+	ts, ok := c.Store.(backend.TemplateStore)
+	if !ok {
+		return nil, ErrTemplatesNotSupported
+	}
+	return ts, nil
+}
+
+// InstantiateTemplate renders the template with the given ID with params, and creates a task with the resulting script,
+// in the template's org, owned by user, and with the given status. The task is claimed as by CreateTask.
+// The task is bound to the template's current version, so that later updates to the template can be rolled out to it.
+func (c *Coordinator) InstantiateTemplate(ctx context.Context, templateID, user platform.ID, params map[string]string, status backend.TaskStatus) (platform.ID, error) {
+	ts, err := c.Templates()
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	tmpl, err := ts.FindTemplateByID(ctx, templateID)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	script, err := tmpl.Render(params)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	id, err := c.CreateTask(ctx, backend.CreateTaskRequest{
+		Org:           tmpl.Org,
+		User:          user,
+		Script:        script,
+		ScheduleAfter: c.clock.Now().Unix(),
+		Status:        status,
+	})
+	if err != nil {
+		return id, err
+	}
+
+	inst := backend.TemplateInstance{TaskID: id, TemplateID: templateID, Version: tmpl.Version, Params: params}
+	if err := ts.PutTemplateInstance(ctx, inst); err != nil {
+		if _, delErr := c.DeleteTask(ctx, id); delErr != nil {
+			return id, fmt.Errorf("binding task to template failed: %s\n\tcleanup also failed: %s", err, delErr)
+		}
+		return id, err
+	}
+	return id, nil
+}
+
+// UpdateTemplate replaces the name, script, and parameters of the template with t.ID, and returns the updated template.
+// If rollout is true, the new version is then rolled out to the template's tasks, as by RolloutTemplate.
+func (c *Coordinator) UpdateTemplate(ctx context.Context, t backend.TaskTemplate, rollout bool) (backend.TaskTemplate, []platform.ID, error) {
+	ts, err := c.Templates()
+	if err != nil {
+		return backend.TaskTemplate{}, nil, err
+	}
+
+	updated, err := ts.UpdateTemplate(ctx, t)
+	if err != nil {
+		return updated, nil, err
+	}
+	if !rollout {
+		return updated, nil, nil
+	}
+
+	ids, err := c.RolloutTemplate(ctx, t.ID)
+	return updated, ids, err
+}
+
+// RolloutTemplate updates each task instantiated from an older version of the template with the given ID
+// to the template's current version, rendered with the parameter values the task was instantiated with.
+// Values of parameters that the current version no longer declares are dropped. Tasks that were deleted are skipped.
+//
+// It returns the IDs of the tasks that were updated.
+// If updating a task fails, RolloutTemplate returns the error along with the IDs of the tasks updated so far,
+// and calling it again resumes from the task that failed.
+func (c *Coordinator) RolloutTemplate(ctx context.Context, templateID platform.ID) ([]platform.ID, error) {
+	ts, err := c.Templates()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := ts.FindTemplateByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	insts, err := ts.ListTemplateInstances(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []platform.ID
+	for _, inst := range insts {
+		if inst.Version >= tmpl.Version {
+			continue
+		}
+		if _, err := c.FindTaskByID(ctx, inst.TaskID); err == backend.ErrTaskNotFound {
+			continue
+		} else if err != nil {
+			return updated, err
+		}
+
+		params := make(map[string]string, len(tmpl.Params))
+		for _, p := range tmpl.Params {
+			if v, ok := inst.Params[p.Name]; ok {
+				params[p.Name] = v
+			}
+		}
+		script, err := tmpl.Render(params)
+		if err != nil {
+			return updated, fmt.Errorf("rendering template for task %s: %v", inst.TaskID, err)
+		}
+
+		if _, err := c.UpdateTask(ctx, backend.UpdateTaskRequest{ID: inst.TaskID, Script: script}); err != nil {
+			return updated, err
+		}
+
+		inst.Version = tmpl.Version
+		inst.Params = params
+		if err := ts.PutTemplateInstance(ctx, inst); err != nil {
+			return updated, err
+		}
+		updated = append(updated, inst.TaskID)
+	}
+	return updated, nil
+}
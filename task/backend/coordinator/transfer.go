@@ -0,0 +1,117 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// ErrTransferNotPermitted is returned by TransferTask when the authorizer in the request context
+// may not remove tasks from the task's organization, or may not create tasks in the new organization.
+var ErrTransferNotPermitted = platform.NewCodedError(platform.EForbidden, "not permitted to transfer task")
+
+// TransferTask moves the task with the given ID to the organization newOrg, owned by the user newOwner.
+// The task keeps its ID, script, status, versions and statistics, so it survives a reorganization
+// that would otherwise require deleting and recreating it.
+//
+// If ctx carries an authorizer, it must be allowed to delete tasks in the task's current organization
+// and to create tasks in newOrg, or else ErrTransferNotPermitted is returned.
+//
+// An active task is released from the scheduler and claimed again, so that it is scheduled under newOrg's limits;
+// any of its runs in progress are canceled.
+// If the task cannot be claimed again, the transfer is undone and the task is claimed under its old organization,
+// so that a failed transfer never leaves an active task unscheduled.
+// A task that would run more often than newOrg's minimum interval is not transferred, and a backend.MinIntervalError is returned.
+// A task is not transferred into an organization that has reached its quota, and a backend.TaskQuotaError is returned.
+// The transfer is recorded in the audit logs of both organizations.
+func (c *Coordinator) TransferTask(ctx context.Context, id, newOrg, newOwner platform.ID) (backend.UpdateTaskResult, error) {
+	if !newOrg.Valid() || !newOwner.Valid() {
+		return backend.UpdateTaskResult{}, platform.NewCodedError(platform.EInvalid, "transferring a task requires a valid org and owner")
+	}
+
+	defer c.taskLocks.lock(id)()
+
+	task, err := c.Store.FindTaskByID(ctx, id)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+	oldOrg, oldOwner := task.Org, task.User
+
+	if a, err := pctx.GetAuthorizer(ctx); err == nil {
+		if !a.Allowed(platform.Permission{Action: platform.DeleteAction, Resource: platform.TaskResource(oldOrg)}) ||
+			!a.Allowed(platform.Permission{Action: platform.CreateAction, Resource: platform.TaskResource(newOrg)}) {
+			return backend.UpdateTaskResult{}, ErrTransferNotPermitted
+		}
+	}
+
+	if err := c.checkInterval(newOrg, task.Script); err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+	if newOrg != oldOrg {
+		if err := c.checkQuota(ctx, newOrg, task.Script); err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	meta, err := c.Store.FindTaskMetaByID(ctx, id)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+
+	// Release the task before changing the store, so that a failure to release leaves the task as it was.
+	released := false
+	if backend.TaskStatus(meta.Status) == backend.TaskActive {
+		switch err := c.release(ctx, id); err {
+		case nil:
+			released = true
+		case backend.ErrTaskNotClaimed:
+		default:
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	res, err := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: id, Org: newOrg, User: newOwner})
+	if err != nil {
+		if released {
+			c.reclaim(ctx, task, meta)
+		}
+		return res, err
+	}
+
+	if released {
+		if err := c.claim(ctx, &res.NewTask, &res.NewMeta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			// Undo the transfer, so that the task keeps running under its old organization rather than not at all.
+			if _, undoErr := c.updateStoredTask(ctx, backend.UpdateTaskRequest{ID: id, Org: oldOrg, User: oldOwner}); undoErr != nil {
+				c.logger.Error("Failed to undo task transfer after failed claim; the task will be claimed when the scheduler is reconciled",
+					zap.String("task_id", id.String()), zap.Error(undoErr))
+				return backend.UpdateTaskResult{}, err
+			}
+			c.reclaim(ctx, task, meta)
+			return backend.UpdateTaskResult{}, err
+		}
+	}
+
+	hash := backend.ScriptHash(res.NewTask.Script)
+	c.audit(ctx, backend.AuditEntry{
+		TaskID: id, Org: oldOrg, Action: backend.AuditTransfer, OldScriptHash: hash, NewScriptHash: hash,
+		Reason: fmt.Sprintf("transferred to org %s, owner %s", newOrg, newOwner),
+	})
+	c.audit(ctx, backend.AuditEntry{
+		TaskID: id, Org: newOrg, Action: backend.AuditTransfer, OldScriptHash: hash, NewScriptHash: hash,
+		Reason: fmt.Sprintf("transferred from org %s, owner %s", oldOrg, oldOwner),
+	})
+	c.taskModified(ctx, res)
+	return res, nil
+}
+
+// reclaim claims task in the scheduler again after a failed operation released it, logging any error.
+func (c *Coordinator) reclaim(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta) {
+	if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		c.logger.Error("Failed to reclaim task; it will be claimed when the scheduler is reconciled",
+			zap.String("task_id", task.ID.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,80 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// WithTaskWatch makes the coordinator react to changes made to tasks through other coordinators sharing its store,
+// by claiming, updating, or releasing those tasks in its scheduler.
+// It only has an effect if the store implements backend.TaskWatcher.
+// The coordinator stops watching when ctx is canceled.
+func WithTaskWatch(ctx context.Context) Option {
+	return func(c *Coordinator) {
+		c.watchCtx = ctx
+	}
+}
+
+var _ backend.TaskWatcher = (*Coordinator)(nil)
+
+// WatchTasks returns a channel that receives a backend.TaskChange for each task created, updated, or deleted after the call.
+// If c's store implements backend.TaskWatcher, the changes are those reported by the store,
+// including changes made through other coordinators sharing it;
+// otherwise, only changes made through c are reported.
+// The channel is closed when ctx is done.
+func (c *Coordinator) WatchTasks(ctx context.Context) <-chan backend.TaskChange {
+	if w, ok := c.Store.(backend.TaskWatcher); ok {
+		return w.WatchTasks(ctx)
+	}
+	return c.changes.WatchTasks(ctx)
+}
+
+// watchTasks applies each change received from w until the watch ends.
+func (c *Coordinator) watchTasks(w backend.TaskWatcher) {
+	for change := range w.WatchTasks(c.watchCtx) {
+		if c.isClosing() {
+			// Keep draining until the watch ends, but stop claiming tasks.
+			continue
+		}
+
+		if err := c.applyTaskChange(c.watchCtx, change); err != nil {
+			c.logger.Info("Failed to apply task change from store", zap.String("task_id", change.TaskID.String()), zap.Error(err))
+		}
+	}
+}
+
+// applyTaskChange brings the scheduler up to date with the task described by change.
+// Active tasks are updated in the scheduler, or claimed if they are not yet claimed;
+// inactive and deleted tasks are released.
+func (c *Coordinator) applyTaskChange(ctx context.Context, change backend.TaskChange) error {
+	defer c.taskLocks.lock(change.TaskID)()
+	c.forgetTask(change.TaskID)
+
+	if !change.Deleted {
+		task, meta, err := c.Store.FindTaskByIDWithMeta(ctx, change.TaskID)
+		if err != nil && err != backend.ErrTaskNotFound {
+			return err
+		}
+
+		if err == nil && meta.Status == string(backend.TaskActive) {
+			if err := c.sch.UpdateTask(task, meta); err == nil {
+				c.setOwned(task)
+				return nil
+			} else if err != backend.ErrTaskNotClaimed {
+				return err
+			}
+
+			if err := c.claim(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+				return err
+			}
+			return nil
+		}
+	}
+
+	if err := c.release(ctx, change.TaskID); err != nil && err != backend.ErrTaskNotClaimed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package etcd
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/clientv3"
+	"go.etcd.io/etcd/mvcc/mvccpb"
+)
+
+// clientKV is a KV backed by an etcd v3 cluster.
+type clientKV struct {
+	c *clientv3.Client
+}
+
+var _ KV = (*clientKV)(nil)
+
+// NewClientKV returns a KV that uses the etcd cluster c is connected to.
+// Leases on tasks are stored as values with an expiration timestamp, so no etcd leases are granted.
+// Closing a Store using the returned KV does not close c.
+func NewClientKV(c *clientv3.Client) KV {
+	return &clientKV{c: c}
+}
+
+func (kv *clientKV) Range(ctx context.Context, start, end string, limit int) ([]KeyValue, error) {
+	var opts []clientv3.OpOption
+	if end != "" {
+		opts = append(opts, clientv3.WithRange(end), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	}
+	if limit > 0 {
+		opts = append(opts, clientv3.WithLimit(int64(limit)))
+	}
+
+	resp, err := kv.c.Get(ctx, start, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]KeyValue, len(resp.Kvs))
+	for i, p := range resp.Kvs {
+		out[i] = KeyValue{Key: string(p.Key), Value: p.Value, ModRevision: p.ModRevision}
+	}
+	return out, nil
+}
+
+func (kv *clientKV) Txn(ctx context.Context, cmps []Compare, ops []Op) (bool, error) {
+	ifs := make([]clientv3.Cmp, len(cmps))
+	for i, c := range cmps {
+		// A key that does not exist has a ModRevision of zero.
+		ifs[i] = clientv3.Compare(clientv3.ModRevision(c.Key), "=", c.ModRevision)
+	}
+
+	thens := make([]clientv3.Op, len(ops))
+	for i, op := range ops {
+		if op.Delete {
+			thens[i] = clientv3.OpDelete(op.Key)
+		} else {
+			thens[i] = clientv3.OpPut(op.Key, string(op.Value))
+		}
+	}
+
+	resp, err := kv.c.Txn(ctx).If(ifs...).Then(thens...).Commit()
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+func (kv *clientKV) Watch(ctx context.Context, prefix string) <-chan []Event {
+	wch := kv.c.Watch(clientv3.WithRequireLeader(ctx), prefix, clientv3.WithPrefix())
+
+	out := make(chan []Event)
+	go func() {
+		defer close(out)
+		for resp := range wch {
+			if resp.Err() != nil {
+				// The watch was canceled, for instance because the revision it was watching from was compacted.
+				return
+			}
+
+			// A response may hold changes from several revisions; each revision is a single transaction.
+			var batch []Event
+			var rev int64
+			for _, ev := range resp.Events {
+				if len(batch) > 0 && ev.Kv.ModRevision != rev {
+					if !send(ctx, out, batch) {
+						return
+					}
+					batch = nil
+				}
+				rev = ev.Kv.ModRevision
+
+				e := Event{Key: string(ev.Kv.Key)}
+				switch ev.Type {
+				case mvccpb.PUT:
+					e.Type = EventPut
+					e.Created = ev.IsCreate()
+				case mvccpb.DELETE:
+					e.Type = EventDelete
+				}
+				batch = append(batch, e)
+			}
+			if len(batch) > 0 && !send(ctx, out, batch) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// send sends evs on ch, returning false if ctx is done first.
+func send(ctx context.Context, ch chan<- []Event, evs []Event) bool {
+	select {
+	case ch <- evs:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
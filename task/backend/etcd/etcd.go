@@ -0,0 +1,1095 @@
+// Package etcd provides an etcd-backed store implementation.
+//
+// The data stored in etcd is structured as follows, with all keys beneath the prefix given to New:
+//
+//	tasks/:task_id -> JSON-encoded task: org, user, name, script, status, labels, and retained backend.TaskVersions.
+//	                  The script and the scripts of the versions are encrypted if the store has a cipher.
+//	meta/:task_id -> Protocol Buffer encoded backend.StoreTaskMeta.
+//	run_history/:task_id -> JSON-encoded backend.TaskRunHistory. Absent if no run has been recorded.
+//	leases/:task_id -> JSON-encoded lease on the task.
+//	lease_owners/:owner -> Decimal Unix timestamp of when the owner's keep-alive expires.
+//	leader -> JSON-encoded leader lease.
+//	audit/:org_id/:entry_id -> JSON-encoded backend.AuditEntry. Entry IDs are time-ordered, so entries sort in the order they were appended.
+//	idempotency_keys/:org_id/:key -> ID of the task created with the idempotency key. The task may since have been deleted.
+//
+// The task's status is kept in both the task and its meta, so that watching tasks/ reports enabling and disabling a task,
+// without also reporting every run recorded in the meta.
+//
+// Every read-modify-write is a transaction conditioned on the revisions of the keys it read,
+// retried until it applies, so that multiple instances can safely share the same etcd cluster.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/snowflake"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// ErrRunNotFound is an error for when a run isn't found in a FinishRun method.
+var ErrRunNotFound = errors.New("run not found")
+
+const (
+	tasksDir       = "tasks/"
+	metaDir        = "meta/"
+	runHistoryDir  = "run_history/"
+	leasesDir      = "leases/"
+	leaseOwnersDir = "lease_owners/"
+	leaderKey      = "leader"
+	auditDir       = "audit/"
+	idempotencyDir = "idempotency_keys/"
+)
+
+// Store is a task store for etcd.
+type Store struct {
+	kv     KV
+	prefix string
+	idGen  platform.IDGenerator
+
+	// Encrypts task scripts at rest, if set. See WithCipher.
+	cipher backend.Cipher
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithCipher encrypts task scripts, including their retained versions, with c before they are written to etcd.
+// Scripts written before c was set are still read as they were, and encrypted the next time they are written.
+func WithCipher(c backend.Cipher) Option {
+	return func(s *Store) {
+		s.cipher = c
+	}
+}
+
+var _ backend.TaskWatcher = (*Store)(nil)
+var _ backend.TaskPurger = (*Store)(nil)
+
+// New returns a Store that keeps its data in kv, beneath prefix.
+// Stores sharing a kv must use the same prefix to share tasks.
+func New(kv KV, prefix string, opts ...Option) *Store {
+	s := &Store{kv: kv, prefix: prefix, idGen: snowflake.NewDefaultIDGenerator()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// taskRecord is the stored form of a task.
+type taskRecord struct {
+	Org      platform.ID           `json:"org"`
+	User     platform.ID           `json:"user"`
+	Name     string                `json:"name"`
+	Script   string                `json:"script"`
+	Status   string                `json:"status"`
+	Labels   map[string]string     `json:"labels,omitempty"`
+	Versions []backend.TaskVersion `json:"versions"`
+
+	// Options parsed from Script. Nil in records stored before options were recorded.
+	Options *options.Options `json:"options,omitempty"`
+}
+
+func (r *taskRecord) storeTask(id platform.ID) *backend.StoreTask {
+	return &backend.StoreTask{
+		ID:      id,
+		Org:     r.Org,
+		User:    r.User,
+		Name:    r.Name,
+		Script:  r.Script,
+		Labels:  r.Labels,
+		Options: r.Options,
+	}
+}
+
+// leaseRecord is the stored form of a task lease or the leader lease.
+type leaseRecord struct {
+	Owner     string `json:"owner"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+func (s *Store) taskKey(id platform.ID) string       { return s.prefix + tasksDir + id.String() }
+func (s *Store) metaKey(id platform.ID) string       { return s.prefix + metaDir + id.String() }
+func (s *Store) runHistoryKey(id platform.ID) string { return s.prefix + runHistoryDir + id.String() }
+func (s *Store) leaseKey(id platform.ID) string      { return s.prefix + leasesDir + id.String() }
+func (s *Store) auditDir(org platform.ID) string     { return s.prefix + auditDir + org.String() + "/" }
+func (s *Store) idempotencyKey(org platform.ID, key string) string {
+	return s.prefix + idempotencyDir + org.String() + "/" + key
+}
+
+// CreateTask creates a task in the etcd task store.
+func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (platform.ID, error) {
+	o, err := backend.StoreValidator.CreateArgs(req)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	id := s.idGen.ID()
+	stm := backend.NewStoreTaskMeta(req, o)
+
+	rec := taskRecord{
+		Org:      req.Org,
+		User:     req.User,
+		Name:     o.Name,
+		Script:   req.Script,
+		Status:   stm.Status,
+		Versions: []backend.TaskVersion{{Version: 1, Script: req.Script, CreatedAt: time.Now().Unix()}},
+		Options:  &o,
+	}
+	if len(req.Labels) > 0 {
+		rec.Labels = req.Labels
+	}
+	recBytes, err := s.encodeTask(rec)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	stmBytes, err := stm.Marshal()
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	cmps := []Compare{{Key: s.taskKey(id)}}
+	ops := []Op{{Key: s.taskKey(id), Value: recBytes}, {Key: s.metaKey(id), Value: stmBytes}}
+
+	if req.IdempotencyKey != "" {
+		return s.createTaskWithKey(ctx, id, req.Org, req.IdempotencyKey, cmps, ops)
+	}
+
+	ok, err := s.kv.Txn(ctx, cmps, ops)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	if !ok {
+		return platform.InvalidID(), fmt.Errorf("task ID %s already exists", id)
+	}
+	return id, nil
+}
+
+// createTaskWithKey applies the transaction that creates the task with the given ID,
+// recording key as its idempotency key in org.
+// If a task created with key in org still exists, that task's ID is returned along with backend.ErrTaskAlreadyCreated instead.
+func (s *Store) createTaskWithKey(ctx context.Context, id, org platform.ID, key string, cmps []Compare, ops []Op) (platform.ID, error) {
+	k := s.idempotencyKey(org, key)
+	existing := platform.InvalidID()
+	err := s.retry(ctx, func() (bool, error) {
+		kv, err := s.get(ctx, k)
+		if err != nil {
+			return false, err
+		}
+
+		var rev int64
+		if kv != nil {
+			rev = kv.ModRevision
+			var prev platform.ID
+			if err := prev.DecodeFromString(string(kv.Value)); err != nil {
+				return false, err
+			}
+			task, err := s.get(ctx, s.taskKey(prev))
+			if err != nil {
+				return false, err
+			}
+			if task != nil {
+				existing = prev
+				return true, nil
+			}
+		}
+
+		return s.kv.Txn(ctx,
+			append([]Compare{{Key: k, ModRevision: rev}}, cmps...),
+			append([]Op{{Key: k, Value: []byte(id.String())}}, ops...),
+		)
+	})
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	if existing.Valid() {
+		return existing, backend.ErrTaskAlreadyCreated
+	}
+	return id, nil
+}
+
+func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
+	var res backend.UpdateTaskResult
+	op, err := backend.StoreValidator.UpdateArgs(req)
+	if err != nil {
+		return res, err
+	}
+
+	err = s.retry(ctx, func() (bool, error) {
+		res = backend.UpdateTaskResult{}
+		rec, recRev, err := s.getTask(ctx, req.ID)
+		if err != nil {
+			return false, err
+		}
+		stm, stmRev, err := s.getMeta(ctx, req.ID)
+		if err != nil {
+			return false, err
+		}
+		res.OldScript = rec.Script
+		res.OldStatus = backend.TaskStatus(stm.Status)
+
+		script, err := req.NewScript(rec.Script)
+		if err != nil {
+			return false, err
+		}
+		if script != req.Script {
+			// Need to build op from existing or updated script.
+			op, err = options.FromScript(script)
+			if err != nil {
+				return false, err
+			}
+		}
+		if script != rec.Script {
+			rec.Versions = backend.AppendTaskVersion(rec.Versions, rec.Script, script, time.Now().Unix())
+			rec.Script = script
+			stm.ApplyOptions(op)
+		}
+		if req.MaxConcurrency > 0 {
+			stm.MaxConcurrency = req.MaxConcurrency
+		}
+		stm.ApplyNotification(req)
+		rec.Name = op.Name
+		recOpts := op
+		rec.Options = &recOpts
+
+		if req.Labels != nil {
+			rec.Labels = nil
+			if len(req.Labels) > 0 {
+				rec.Labels = req.Labels
+			}
+		}
+
+		if req.Status != "" {
+			stm.ApplyStatus(req)
+			rec.Status = stm.Status
+		}
+
+		if req.Org.Valid() {
+			rec.Org = req.Org
+		}
+		if req.User.Valid() {
+			rec.User = req.User
+		}
+
+		recBytes, err := s.encodeTask(*rec)
+		if err != nil {
+			return false, err
+		}
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return false, err
+		}
+
+		res.NewTask = *rec.storeTask(req.ID)
+		res.NewMeta = *stm
+		return s.kv.Txn(ctx,
+			[]Compare{{Key: s.taskKey(req.ID), ModRevision: recRev}, {Key: s.metaKey(req.ID), ModRevision: stmRev}},
+			[]Op{{Key: s.taskKey(req.ID), Value: recBytes}, {Key: s.metaKey(req.ID), Value: stmBytes}},
+		)
+	})
+	return res, err
+}
+
+// statusBatchSize is the most tasks whose status is set in a single transaction,
+// keeping the transaction within etcd's default limit of 128 operations.
+const statusBatchSize = 50
+
+// EnableTasks sets the tasks with the given IDs to active, in transactions of at most 50 tasks,
+// and returns the IDs of those that changed.
+func (s *Store) EnableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ctx, ids, backend.TaskActive)
+}
+
+// DisableTasks sets the tasks with the given IDs to inactive, in transactions of at most 50 tasks,
+// and returns the IDs of those that changed.
+func (s *Store) DisableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ctx, ids, backend.TaskInactive)
+}
+
+func (s *Store) setTasksStatus(ctx context.Context, ids []platform.ID, status backend.TaskStatus) ([]platform.ID, error) {
+	var changed []platform.ID
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > statusBatchSize {
+			batch = batch[:statusBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		var batchChanged []platform.ID
+		err := s.retry(ctx, func() (bool, error) {
+			batchChanged = batchChanged[:0]
+			var cmps []Compare
+			var ops []Op
+			for _, id := range batch {
+				rec, recRev, err := s.getTask(ctx, id)
+				if err == backend.ErrTaskNotFound {
+					continue
+				}
+				if err != nil {
+					return false, err
+				}
+				stm, stmRev, err := s.getMeta(ctx, id)
+				if err == backend.ErrTaskNotFound {
+					continue
+				}
+				if err != nil {
+					return false, err
+				}
+				if stm.Status == string(status) || stm.Status == string(backend.TaskDeleted) {
+					continue
+				}
+
+				stm.ApplyStatus(backend.UpdateTaskRequest{ID: id, Status: status})
+				rec.Status = stm.Status
+				recBytes, err := s.encodeTask(*rec)
+				if err != nil {
+					return false, err
+				}
+				stmBytes, err := stm.Marshal()
+				if err != nil {
+					return false, err
+				}
+
+				cmps = append(cmps, Compare{Key: s.taskKey(id), ModRevision: recRev}, Compare{Key: s.metaKey(id), ModRevision: stmRev})
+				ops = append(ops, Op{Key: s.taskKey(id), Value: recBytes}, Op{Key: s.metaKey(id), Value: stmBytes})
+				batchChanged = append(batchChanged, id)
+			}
+			if len(ops) == 0 {
+				return true, nil
+			}
+			return s.kv.Txn(ctx, cmps, ops)
+		})
+		if err != nil {
+			return changed, err
+		}
+		changed = append(changed, batchChanged...)
+	}
+	return changed, nil
+}
+
+// ListTasks lists the tasks based on a filter.
+// Tasks are scanned in ID order, so filtering by org, user, or labels reads the tasks that do not match as well.
+func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTaskWithMeta, error) {
+	if params.Org.Valid() && params.User.Valid() {
+		return nil, errors.New("ListTasks: org and user filters are mutually exclusive")
+	}
+
+	if params.PageSize < 0 {
+		return nil, errors.New("ListTasks: PageSize must be positive")
+	}
+	if params.PageSize > platform.TaskMaxPageSize {
+		return nil, fmt.Errorf("ListTasks: PageSize exceeds maximum of %d", platform.TaskMaxPageSize)
+	}
+	lim := params.PageSize
+	if lim == 0 {
+		lim = platform.TaskDefaultPageSize
+	}
+
+	dir := s.prefix + tasksDir
+	start, end := dir, prefixEnd(dir)
+	if params.After.Valid() {
+		start = s.taskKey(params.After) + "\x00"
+	}
+
+	var tasks []backend.StoreTaskWithMeta
+	for len(tasks) < lim {
+		kvs, err := s.kv.Range(ctx, start, end, lim)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kv := range kvs {
+			var id platform.ID
+			if err := id.DecodeFromString(strings.TrimPrefix(kv.Key, dir)); err != nil {
+				return nil, err
+			}
+			rec, err := s.decodeTask(kv.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			if params.Org.Valid() && rec.Org != params.Org {
+				continue
+			}
+			if params.User.Valid() && rec.User != params.User {
+				continue
+			}
+			if !backend.LabelsMatch(rec.Labels, params.Labels) {
+				continue
+			}
+
+			stm, _, err := s.getMeta(ctx, id)
+			if err == backend.ErrTaskNotFound {
+				// Deleted since the range was read.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			tasks = append(tasks, backend.StoreTaskWithMeta{Task: *rec.storeTask(id), Meta: *stm})
+			if len(tasks) == lim {
+				break
+			}
+		}
+
+		if len(kvs) < lim {
+			break
+		}
+		start = kvs[len(kvs)-1].Key + "\x00"
+	}
+
+	return tasks, nil
+}
+
+// FindTaskByID finds a task with a given an ID. It will return nil if the task does not exist.
+func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
+	rec, _, err := s.getTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return rec.storeTask(id), nil
+}
+
+func (s *Store) FindTaskMetaByID(ctx context.Context, id platform.ID) (*backend.StoreTaskMeta, error) {
+	stm, _, err := s.getMeta(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return stm, nil
+}
+
+func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*backend.StoreTask, *backend.StoreTaskMeta, error) {
+	rec, _, err := s.getTask(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	stm, _, err := s.getMeta(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec.storeTask(id), stm, nil
+}
+
+// ListTaskVersions returns the retained script versions of the task, oldest first.
+func (s *Store) ListTaskVersions(ctx context.Context, id platform.ID) ([]backend.TaskVersion, error) {
+	rec, _, err := s.getTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Versions, nil
+}
+
+// RecordRunOutcome adds o to the run history of the task, and records it as the task's last run in its meta.
+func (s *Store) RecordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	return s.recordRunOutcome(ctx, taskID, o, false)
+}
+
+// CompleteRun finishes the run o.RunID and records its outcome o, in a single transaction.
+func (s *Store) CompleteRun(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	return s.recordRunOutcome(ctx, taskID, o, true)
+}
+
+// recordRunOutcome records o in the meta and run history of the task, first finishing the run o.RunID if finish is true.
+func (s *Store) recordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome, finish bool) error {
+	return s.retry(ctx, func() (bool, error) {
+		// Condition on the task's revision too, so that the history is not recreated for a concurrently deleted task.
+		_, taskRev, err := s.getTask(ctx, taskID)
+		if err != nil {
+			return false, err
+		}
+		h, rev, err := s.getRunHistory(ctx, taskID)
+		if err != nil {
+			return false, err
+		}
+		h.Add(o)
+		stm, metaRev, err := s.getMeta(ctx, taskID)
+		if err != nil {
+			return false, err
+		}
+		if finish && !stm.FinishRun(o.RunID) {
+			return false, ErrRunNotFound
+		}
+		stm.RecordRunOutcome(o)
+		h.DropSucceededThrough(stm.LatestCompleted)
+
+		v, err := json.Marshal(h)
+		if err != nil {
+			return false, err
+		}
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return false, err
+		}
+		return s.kv.Txn(ctx,
+			[]Compare{
+				{Key: s.taskKey(taskID), ModRevision: taskRev},
+				{Key: s.runHistoryKey(taskID), ModRevision: rev},
+				{Key: s.metaKey(taskID), ModRevision: metaRev},
+			},
+			[]Op{{Key: s.runHistoryKey(taskID), Value: v}, {Key: s.metaKey(taskID), Value: stmBytes}},
+		)
+	})
+}
+
+// FindTaskStats returns statistics computed from the run history of the task.
+func (s *Store) FindTaskStats(ctx context.Context, taskID platform.ID) (*backend.TaskStats, error) {
+	if _, _, err := s.getTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	h, _, err := s.getRunHistory(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	stats := h.Stats()
+	return &stats, nil
+}
+
+// RunSucceeded reports whether the run history of the task records a successful run scheduled for now.
+func (s *Store) RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error) {
+	if _, _, err := s.getTask(ctx, taskID); err != nil {
+		return false, err
+	}
+	h, _, err := s.getRunHistory(ctx, taskID)
+	if err != nil {
+		return false, err
+	}
+	return h.HasSucceeded(now), nil
+}
+
+// AppendAuditEntry adds e to the audit log of its org.
+func (s *Store) AppendAuditEntry(ctx context.Context, e backend.AuditEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	key := s.auditDir(e.Org) + s.idGen.ID().String()
+	return s.retry(ctx, func() (bool, error) {
+		return s.kv.Txn(ctx, []Compare{{Key: key}}, []Op{{Key: key, Value: v}})
+	})
+}
+
+// ListAuditEntries lists the entries in the audit log of the org that match params.
+func (s *Store) ListAuditEntries(ctx context.Context, orgID platform.ID, params backend.AuditSearchParams) ([]backend.AuditEntry, error) {
+	lim := params.EffectiveLimit()
+	dir := s.auditDir(orgID)
+	start, end := dir, prefixEnd(dir)
+
+	var entries []backend.AuditEntry
+	for len(entries) < lim {
+		kvs, err := s.kv.Range(ctx, start, end, lim)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kv := range kvs {
+			var e backend.AuditEntry
+			if err := json.Unmarshal(kv.Value, &e); err != nil {
+				return nil, err
+			}
+			if !params.Match(e) {
+				continue
+			}
+
+			entries = append(entries, e)
+			if len(entries) == lim {
+				break
+			}
+		}
+
+		if len(kvs) < lim {
+			break
+		}
+		start = kvs[len(kvs)-1].Key + "\x00"
+	}
+
+	return entries, nil
+}
+
+// DeleteTask deletes the task, along with its meta, run history, and lease.
+func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
+	err = s.retry(ctx, func() (bool, error) {
+		_, rev, err := s.getTask(ctx, id)
+		if err == backend.ErrTaskNotFound {
+			deleted = false
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		deleted = true
+		return s.kv.Txn(ctx,
+			[]Compare{{Key: s.taskKey(id), ModRevision: rev}},
+			[]Op{
+				{Key: s.taskKey(id), Delete: true},
+				{Key: s.metaKey(id), Delete: true},
+				{Key: s.runHistoryKey(id), Delete: true},
+				{Key: s.leaseKey(id), Delete: true},
+			},
+		)
+	})
+	if err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
+func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+	var rc backend.RunCreation
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		var err error
+		rc, err = stm.CreateNextRun(now, func() (platform.ID, error) {
+			return s.idGen.ID(), nil
+		})
+		if err != nil {
+			return err
+		}
+		rc.Created.TaskID = taskID
+		return nil
+	})
+	if err != nil {
+		return backend.RunCreation{}, err
+	}
+	return rc, nil
+}
+
+// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
+func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		if !stm.FinishRun(runID) {
+			return ErrRunNotFound
+		}
+		return nil
+	})
+}
+
+func (s *Store) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	var mRun *backend.StoreTaskMetaManualRun
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		makeID := func() (platform.ID, error) { return s.idGen.ID(), nil }
+		if err := stm.ManuallyRunTimeRange(start, end, requestedAt, makeID); err != nil {
+			return err
+		}
+		mRun = stm.ManualRuns[len(stm.ManualRuns)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mRun, nil
+}
+
+func (s *Store) RetryRun(ctx context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	var mRun *backend.StoreTaskMetaManualRun
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		makeID := func() (platform.ID, error) { return s.idGen.ID(), nil }
+		if err := stm.RetryRun(scheduledFor, requestedAt, runID, makeID); err != nil {
+			return err
+		}
+		mRun = stm.ManualRuns[len(stm.ManualRuns)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mRun, nil
+}
+
+// AcquireTaskLease acquires or renews the lease on a task for owner.
+func (s *Store) AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	return s.retry(ctx, func() (bool, error) {
+		_, taskRev, err := s.getTask(ctx, taskID)
+		if err != nil {
+			return false, err
+		}
+		return s.acquireLease(ctx, s.leaseKey(taskID), owner, now, expiresAt,
+			Compare{Key: s.taskKey(taskID), ModRevision: taskRev})
+	})
+}
+
+// ReleaseTaskLease removes the lease on a task, if held by owner.
+func (s *Store) ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error {
+	return s.releaseLease(ctx, s.leaseKey(taskID), owner)
+}
+
+// RetainedTaskIDs returns the IDs of deleted tasks whose idempotency keys are still recorded.
+func (s *Store) RetainedTaskIDs(ctx context.Context) ([]platform.ID, error) {
+	dir := s.prefix + idempotencyDir
+	kvs, err := s.kv.Range(ctx, dir, prefixEnd(dir), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[platform.ID]bool)
+	var ids []platform.ID
+	for _, kv := range kvs {
+		var id platform.ID
+		if err := id.DecodeFromString(string(kv.Value)); err != nil {
+			return nil, err
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		task, err := s.get(ctx, s.taskKey(id))
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// PurgeTask deletes the idempotency keys recorded for the deleted task.
+// Nothing is deleted if the task still exists.
+func (s *Store) PurgeTask(ctx context.Context, taskID platform.ID) (backend.PurgeResult, error) {
+	var res backend.PurgeResult
+	dir := s.prefix + idempotencyDir
+	kvs, err := s.kv.Range(ctx, dir, prefixEnd(dir), 0)
+	if err != nil {
+		return res, err
+	}
+
+	for _, kv := range kvs {
+		if string(kv.Value) != taskID.String() {
+			continue
+		}
+		// Only delete the key if the task does not exist and the key has not been reused.
+		ok, err := s.kv.Txn(ctx,
+			[]Compare{{Key: s.taskKey(taskID), ModRevision: 0}, {Key: kv.Key, ModRevision: kv.ModRevision}},
+			[]Op{{Key: kv.Key, Delete: true}},
+		)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			res.Records++
+			res.Bytes += int64(len(kv.Key) + len(kv.Value))
+		}
+	}
+	return res, nil
+}
+
+// ListTaskLeases returns every task lease in the store.
+func (s *Store) ListTaskLeases(ctx context.Context) ([]backend.TaskLease, error) {
+	dir := s.prefix + leasesDir
+	kvs, err := s.kv.Range(ctx, dir, prefixEnd(dir), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	leases := make([]backend.TaskLease, 0, len(kvs))
+	for _, kv := range kvs {
+		var l leaseRecord
+		if err := json.Unmarshal(kv.Value, &l); err != nil {
+			return nil, err
+		}
+		var id platform.ID
+		if err := id.DecodeFromString(strings.TrimPrefix(kv.Key, dir)); err != nil {
+			return nil, err
+		}
+		leases = append(leases, backend.TaskLease{TaskID: id, Owner: l.Owner, ExpiresAt: l.ExpiresAt})
+	}
+	return leases, nil
+}
+
+// KeepAliveLeaseOwner records owner as participating in task leasing until expiresAt.
+func (s *Store) KeepAliveLeaseOwner(ctx context.Context, owner string, expiresAt int64) error {
+	_, err := s.kv.Txn(ctx, nil, []Op{{
+		Key:   s.prefix + leaseOwnersDir + owner,
+		Value: []byte(strconv.FormatInt(expiresAt, 10)),
+	}})
+	return err
+}
+
+// ListLeaseOwners returns the owners whose keep-alive has not expired as of now.
+func (s *Store) ListLeaseOwners(ctx context.Context, now int64) ([]string, error) {
+	dir := s.prefix + leaseOwnersDir
+	kvs, err := s.kv.Range(ctx, dir, prefixEnd(dir), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []string
+	for _, kv := range kvs {
+		expiresAt, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keep-alive record for lease owner %q", kv.Key)
+		}
+		if expiresAt > now {
+			owners = append(owners, strings.TrimPrefix(kv.Key, dir))
+		}
+	}
+	return owners, nil
+}
+
+// AcquireLeaderLease acquires or renews the store-wide leader lease for owner.
+func (s *Store) AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error {
+	return s.retry(ctx, func() (bool, error) {
+		return s.acquireLease(ctx, s.prefix+leaderKey, owner, now, expiresAt)
+	})
+}
+
+// ReleaseLeaderLease removes the leader lease, if held by owner.
+func (s *Store) ReleaseLeaderLease(ctx context.Context, owner string) error {
+	return s.releaseLease(ctx, s.prefix+leaderKey, owner)
+}
+
+// DeleteUser synchronously deletes a user and all their tasks from an etcd store.
+func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
+	return s.deleteWhere(ctx, func(rec *taskRecord) bool { return rec.User == id })
+}
+
+// DeleteOrg synchronously deletes an org and all their tasks from an etcd store.
+func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
+	return s.deleteWhere(ctx, func(rec *taskRecord) bool { return rec.Org == id })
+}
+
+// WatchTasks reports changes to tasks made through any Store sharing s's KV and prefix.
+func (s *Store) WatchTasks(ctx context.Context) <-chan backend.TaskChange {
+	dir := s.prefix + tasksDir
+	events := s.kv.Watch(ctx, dir)
+
+	changes := make(chan backend.TaskChange)
+	go func() {
+		defer close(changes)
+		for evs := range events {
+			for _, ev := range evs {
+				var id platform.ID
+				if err := id.DecodeFromString(strings.TrimPrefix(ev.Key, dir)); err != nil {
+					continue
+				}
+				select {
+				case changes <- backend.TaskChange{TaskID: id, Created: ev.Created, Deleted: ev.Type == EventDelete}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// Close closes the underlying KV, if it implements io.Closer.
+func (s *Store) Close() error {
+	if c, ok := s.kv.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// retry calls fn until it reports that its transaction applied or returns an error, or until ctx is done.
+func (s *Store) retry(ctx context.Context, fn func() (applied bool, err error)) error {
+	for {
+		applied, err := fn()
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// get returns the key-value pair for key, or nil if key does not exist.
+func (s *Store) get(ctx context.Context, key string) (*KeyValue, error) {
+	kvs, err := s.kv.Range(ctx, key, "", 1)
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	return &kvs[0], nil
+}
+
+// getTask returns the task record with the given ID and its revision.
+func (s *Store) getTask(ctx context.Context, id platform.ID) (*taskRecord, int64, error) {
+	kv, err := s.get(ctx, s.taskKey(id))
+	if err != nil {
+		return nil, 0, err
+	}
+	if kv == nil {
+		return nil, 0, backend.ErrTaskNotFound
+	}
+
+	rec, err := s.decodeTask(kv.Value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rec, kv.ModRevision, nil
+}
+
+// encodeTask returns the stored form of rec, with its scripts encrypted if s has a cipher.
+func (s *Store) encodeTask(rec taskRecord) ([]byte, error) {
+	var err error
+	if rec.Script, err = backend.EncryptAtRest(s.cipher, rec.Script); err != nil {
+		return nil, err
+	}
+	if rec.Versions, err = backend.EncryptVersionsAtRest(s.cipher, rec.Versions); err != nil {
+		return nil, err
+	}
+	return json.Marshal(rec)
+}
+
+// decodeTask returns the task record stored as v by encodeTask.
+func (s *Store) decodeTask(v []byte) (*taskRecord, error) {
+	var rec taskRecord
+	if err := json.Unmarshal(v, &rec); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if rec.Script, err = backend.DecryptAtRest(s.cipher, rec.Script); err != nil {
+		return nil, err
+	}
+	if err := backend.DecryptVersionsAtRest(s.cipher, rec.Versions); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// getMeta returns the meta of the task with the given ID and its revision.
+func (s *Store) getMeta(ctx context.Context, id platform.ID) (*backend.StoreTaskMeta, int64, error) {
+	kv, err := s.get(ctx, s.metaKey(id))
+	if err != nil {
+		return nil, 0, err
+	}
+	if kv == nil {
+		return nil, 0, backend.ErrTaskNotFound
+	}
+
+	var stm backend.StoreTaskMeta
+	if err := stm.Unmarshal(kv.Value); err != nil {
+		return nil, 0, err
+	}
+	return &stm, kv.ModRevision, nil
+}
+
+// getRunHistory returns the run history of the task with the given ID and its revision.
+// If no run has been recorded, the history is empty and the revision is zero.
+func (s *Store) getRunHistory(ctx context.Context, id platform.ID) (backend.TaskRunHistory, int64, error) {
+	var h backend.TaskRunHistory
+	kv, err := s.get(ctx, s.runHistoryKey(id))
+	if err != nil || kv == nil {
+		return h, 0, err
+	}
+
+	if err := json.Unmarshal(kv.Value, &h); err != nil {
+		return h, 0, err
+	}
+	return h, kv.ModRevision, nil
+}
+
+// updateMeta calls fn with the meta of the task with the given ID,
+// and stores the modified meta if fn returns nil and the meta was not modified concurrently.
+// If the meta was modified concurrently, fn is called again with the new meta.
+func (s *Store) updateMeta(ctx context.Context, taskID platform.ID, fn func(stm *backend.StoreTaskMeta) error) error {
+	return s.retry(ctx, func() (bool, error) {
+		stm, rev, err := s.getMeta(ctx, taskID)
+		if err != nil {
+			return false, err
+		}
+		if err := fn(stm); err != nil {
+			return false, err
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return false, err
+		}
+		return s.kv.Txn(ctx,
+			[]Compare{{Key: s.metaKey(taskID), ModRevision: rev}},
+			[]Op{{Key: s.metaKey(taskID), Value: stmBytes}},
+		)
+	})
+}
+
+// acquireLease sets the lease at key to owner and expiresAt, unless it is held by a different owner as of now.
+// The lease is only set if the additional comparisons in cmps also hold.
+func (s *Store) acquireLease(ctx context.Context, key, owner string, now, expiresAt int64, cmps ...Compare) (bool, error) {
+	kv, err := s.get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	var rev int64
+	if kv != nil {
+		var l leaseRecord
+		if err := json.Unmarshal(kv.Value, &l); err != nil {
+			return false, err
+		}
+		if l.Owner != owner && l.ExpiresAt > now {
+			return false, backend.ErrLeaseHeld
+		}
+		rev = kv.ModRevision
+	}
+
+	v, err := json.Marshal(leaseRecord{Owner: owner, ExpiresAt: expiresAt})
+	if err != nil {
+		return false, err
+	}
+	return s.kv.Txn(ctx, append(cmps, Compare{Key: key, ModRevision: rev}), []Op{{Key: key, Value: v}})
+}
+
+// releaseLease deletes the lease at key, if it is held by owner.
+func (s *Store) releaseLease(ctx context.Context, key, owner string) error {
+	return s.retry(ctx, func() (bool, error) {
+		kv, err := s.get(ctx, key)
+		if err != nil || kv == nil {
+			return true, err
+		}
+
+		var l leaseRecord
+		if err := json.Unmarshal(kv.Value, &l); err != nil {
+			return false, err
+		}
+		if l.Owner != owner {
+			return true, nil
+		}
+		return s.kv.Txn(ctx, []Compare{{Key: key, ModRevision: kv.ModRevision}}, []Op{{Key: key, Delete: true}})
+	})
+}
+
+// deleteWhere deletes every task whose record matches.
+func (s *Store) deleteWhere(ctx context.Context, match func(rec *taskRecord) bool) error {
+	dir := s.prefix + tasksDir
+	kvs, err := s.kv.Range(ctx, dir, prefixEnd(dir), 0)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range kvs {
+		var rec taskRecord
+		if err := json.Unmarshal(kv.Value, &rec); err != nil {
+			return err
+		}
+		if !match(&rec) {
+			continue
+		}
+
+		var id platform.ID
+		if err := id.DecodeFromString(strings.TrimPrefix(kv.Key, dir)); err != nil {
+			return err
+		}
+		if _, err := s.DeleteTask(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
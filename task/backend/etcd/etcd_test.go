@@ -0,0 +1,221 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/etcd"
+	"github.com/influxdata/platform/task/backend/storetest"
+	"github.com/influxdata/platform/task/options"
+	"go.etcd.io/etcd/clientv3"
+)
+
+func init() {
+	// TODO(mr): remove as part of https://github.com/influxdata/platform/issues/484.
+	options.EnableScriptCacheForTest()
+}
+
+// memKV is an in-memory etcd.KV, with the same revision semantics as etcd.
+type memKV struct {
+	mu       sync.Mutex
+	rev      int64
+	kvs      map[string]etcd.KeyValue
+	watchers map[chan []etcd.Event]string
+}
+
+func newMemKV() *memKV {
+	return &memKV{
+		kvs:      make(map[string]etcd.KeyValue),
+		watchers: make(map[chan []etcd.Event]string),
+	}
+}
+
+func (m *memKV) Range(_ context.Context, start, end string, limit int) ([]etcd.KeyValue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []etcd.KeyValue
+	for k, kv := range m.kvs {
+		if (end == "" && k == start) || (end != "" && k >= start && (end == "\x00" || k < end)) {
+			out = append(out, kv)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memKV) Txn(_ context.Context, cmps []etcd.Compare, ops []etcd.Op) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range cmps {
+		if m.kvs[c.Key].ModRevision != c.ModRevision {
+			return false, nil
+		}
+	}
+
+	m.rev++
+	var evs []etcd.Event
+	for _, op := range ops {
+		if op.Delete {
+			if _, ok := m.kvs[op.Key]; ok {
+				delete(m.kvs, op.Key)
+				evs = append(evs, etcd.Event{Type: etcd.EventDelete, Key: op.Key})
+			}
+			continue
+		}
+		_, exists := m.kvs[op.Key]
+		m.kvs[op.Key] = etcd.KeyValue{Key: op.Key, Value: op.Value, ModRevision: m.rev}
+		evs = append(evs, etcd.Event{Type: etcd.EventPut, Key: op.Key, Created: !exists})
+	}
+
+	for ch, prefix := range m.watchers {
+		var matched []etcd.Event
+		for _, ev := range evs {
+			if len(ev.Key) >= len(prefix) && ev.Key[:len(prefix)] == prefix {
+				matched = append(matched, ev)
+			}
+		}
+		if len(matched) > 0 {
+			ch <- matched
+		}
+	}
+	return true, nil
+}
+
+func (m *memKV) Watch(ctx context.Context, prefix string) <-chan []etcd.Event {
+	ch := make(chan []etcd.Event, 100)
+
+	m.mu.Lock()
+	m.watchers[ch] = prefix
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		delete(m.watchers, ch)
+		m.mu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
+
+func TestEtcdStore(t *testing.T) {
+	storetest.NewStoreTest(
+		"etcdstore",
+		func(t *testing.T) backend.Store {
+			return etcd.New(newMemKV(), "/tasks/")
+		},
+		func(t *testing.T, s backend.Store) {
+			if err := s.Close(); err != nil {
+				t.Error(err)
+			}
+		},
+	)(t)
+}
+
+// endpointsEnv names the environment variable holding a comma-separated list of etcd endpoints to test against,
+// such as "localhost:2379". The tests against a real cluster are skipped if it is unset.
+const endpointsEnv = "INFLUXDB_TASK_ETCD_ENDPOINTS"
+
+func TestEtcdStore_Client(t *testing.T) {
+	endpoints := os.Getenv(endpointsEnv)
+	if endpoints == "" {
+		t.Skipf("%s not set", endpointsEnv)
+	}
+
+	type store struct {
+		*etcd.Store
+		client *clientv3.Client
+		prefix string
+	}
+	storetest.NewStoreTest(
+		"etcdstore-client",
+		func(t *testing.T) backend.Store {
+			c, err := clientv3.New(clientv3.Config{Endpoints: strings.Split(endpoints, ","), DialTimeout: 5 * time.Second})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Give each test its own prefix, so that tests don't see each other's tasks.
+			prefix := fmt.Sprintf("/task_test_%d/", time.Now().UnixNano())
+			return &store{Store: etcd.New(etcd.NewClientKV(c), prefix), client: c, prefix: prefix}
+		},
+		func(t *testing.T, s backend.Store) {
+			st := s.(*store)
+			if err := st.Close(); err != nil {
+				t.Error(err)
+			}
+			if _, err := st.client.Delete(context.Background(), st.prefix, clientv3.WithPrefix()); err != nil {
+				t.Error(err)
+			}
+			if err := st.client.Close(); err != nil {
+				t.Error(err)
+			}
+		},
+	)(t)
+}
+
+func TestEtcdStore_WatchTasks(t *testing.T) {
+	kv := newMemKV()
+	// Two stores sharing a KV, as two instances would share an etcd cluster.
+	s1 := etcd.New(kv, "/tasks/")
+	s2 := etcd.New(kv, "/tasks/")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := s2.WatchTasks(ctx)
+
+	next := func(t *testing.T) backend.TaskChange {
+		t.Helper()
+		select {
+		case c := <-changes:
+			return c
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for task change")
+		}
+		return backend.TaskChange{}
+	}
+
+	const script = `option task = {name: "a task",every: 1m} from(bucket:"test") |> range(start:-1h)`
+	id, err := s1.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c.TaskID != id || !c.Created || c.Deleted {
+		t.Fatalf("expected creation of task %s, got %+v", id, c)
+	}
+
+	// Recording runs only changes the meta, which is not reported.
+	if _, err := s1.ManuallyRunTimeRange(ctx, id, 60, 120, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s1.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c.TaskID != id || c.Created || c.Deleted {
+		t.Fatalf("expected update of task %s, got %+v", id, c)
+	}
+
+	if _, err := s1.DeleteTask(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c.TaskID != id || !c.Deleted {
+		t.Fatalf("expected deletion of task %s, got %+v", id, c)
+	}
+
+	cancel()
+	for range changes {
+	}
+}
@@ -0,0 +1,78 @@
+package etcd
+
+import "context"
+
+// KV is the subset of the etcd v3 API used by Store.
+// It maps directly onto go.etcd.io/etcd/clientv3: Range onto Get with WithRange and WithLimit,
+// Txn onto Txn with ModRevision comparisons, and Watch onto Watch with WithPrefix.
+// NewClientKV returns a KV backed by an etcd cluster.
+type KV interface {
+	// Range returns the key-value pairs with keys in [start, end), sorted by key.
+	// If end is empty, only the key start is returned, if present.
+	// If limit is positive, at most limit pairs are returned.
+	Range(ctx context.Context, start, end string, limit int) ([]KeyValue, error)
+
+	// Txn atomically applies ops if every comparison in cmps holds.
+	// It reports whether the comparisons held and ops were applied.
+	Txn(ctx context.Context, cmps []Compare, ops []Op) (bool, error)
+
+	// Watch returns a channel that receives the changes to keys beginning with prefix made after the call.
+	// Changes applied by a single transaction are received together.
+	// The channel is closed when ctx is done.
+	Watch(ctx context.Context, prefix string) <-chan []Event
+}
+
+// KeyValue is a key and its value, as stored at ModRevision.
+type KeyValue struct {
+	Key   string
+	Value []byte
+
+	// ModRevision is the revision of the store when the key was last modified.
+	ModRevision int64
+}
+
+// Compare holds when Key was last modified at ModRevision.
+// A ModRevision of zero holds when Key does not exist.
+type Compare struct {
+	Key         string
+	ModRevision int64
+}
+
+// Op is a single write in a transaction.
+type Op struct {
+	Key   string
+	Value []byte
+
+	// Delete removes Key rather than setting it to Value.
+	Delete bool
+}
+
+// EventType is the kind of change reported by an Event.
+type EventType int
+
+const (
+	EventPut EventType = iota
+	EventDelete
+)
+
+// Event is a change to a single key.
+type Event struct {
+	Type EventType
+	Key  string
+
+	// Created is true if a put created Key, rather than replacing its value, as reported by IsCreate in etcd.
+	Created bool
+}
+
+// prefixEnd returns the end of the range of keys beginning with prefix.
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// The prefix is all 0xff bytes; there is no end.
+	return "\x00"
+}
@@ -3,15 +3,25 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
 	"go.uber.org/zap"
 )
 
@@ -37,6 +47,9 @@ func (e *queryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRu
 	if err != nil {
 		return nil, err
 	}
+	if referencesSecrets(t) {
+		return nil, backend.ErrSecretsUnsupported
+	}
 
 	return newSyncRunPromise(ctx, run, e, t), nil
 }
@@ -64,7 +77,12 @@ type syncRunPromise struct {
 var _ backend.RunPromise = (*syncRunPromise)(nil)
 
 func newSyncRunPromise(ctx context.Context, qr backend.QueuedRun, e *queryServiceExecutor, t *backend.StoreTask) *syncRunPromise {
-	ctx, cancel := context.WithCancel(ctx)
+	var cancel context.CancelFunc
+	if timeout := runTimeout(t); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 	rp := &syncRunPromise{
@@ -149,16 +167,19 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	defer it.Release()
 
 	// Drain the result iterator.
+	var rows int64
 	for it.More() {
 		// Consume the full iterator so that we don't leak outstanding iterators.
 		res := it.Next()
-		if err := exhaustResultIterators(res); err != nil {
+		n, err := exhaustResultIterators(res)
+		if err != nil {
 			p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", res.Name()))
 		}
+		rows += n
 	}
 
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: it.Err()}, nil)
+	p.finish(&runResult{err: it.Err(), rows: rows, maxMemory: it.Statistics().MaxAllocated}, nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -171,23 +192,60 @@ func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
 		// Maybe the parent context was canceled,
 		// or maybe finish was called already.
 		// If it's the latter, this call to finish will be a no-op.
-		p.finish(nil, p.ctx.Err())
+		err := p.ctx.Err()
+		if err == context.DeadlineExceeded {
+			err = backend.ErrRunTimedOut
+		}
+		p.finish(nil, err)
 	}
 }
 
 // asyncQueryServiceExecutor is an implementation of backend.Executor that depends on an AsyncQueryService.
 type asyncQueryServiceExecutor struct {
-	svc    query.AsyncQueryService
-	st     backend.Store
-	logger *zap.Logger
-	wg     sync.WaitGroup
+	svc     query.AsyncQueryService
+	st      backend.Store
+	secrets platform.SecretService // Resolves secrets referenced by task scripts. May be nil.
+	logger  *zap.Logger
+	wg      sync.WaitGroup
+
+	concurrency int   // Number of queries the query service executes at once, or 0 if unknown.
+	running     int64 // Number of runs whose queries have not finished. Must be accessed atomically.
 }
 
-var _ backend.Executor = (*asyncQueryServiceExecutor)(nil)
+var (
+	_ backend.Executor     = (*asyncQueryServiceExecutor)(nil)
+	_ backend.LoadReporter = (*asyncQueryServiceExecutor)(nil)
+)
+
+// AsyncOption configures an executor created by NewAsyncQueryServiceExecutor.
+type AsyncOption func(*asyncQueryServiceExecutor)
+
+// WithQueryConcurrency tells the executor that its query service executes at most n queries at once,
+// queueing any others.
+// The executor then reports itself saturated, through backend.LoadReporter, once n of its runs are executing,
+// so that the scheduler holds back new runs instead of adding them to the query service's queue.
+func WithQueryConcurrency(n int) AsyncOption {
+	return func(e *asyncQueryServiceExecutor) {
+		e.concurrency = n
+	}
+}
+
+// WithSecretService makes the executor resolve the secrets that a task's script references through
+// options.SecretsIdentifier, loading them from svc for the task's organization each time a run executes.
+// Without it, runs of tasks that reference secrets fail with backend.ErrSecretsUnsupported.
+func WithSecretService(svc platform.SecretService) AsyncOption {
+	return func(e *asyncQueryServiceExecutor) {
+		e.secrets = svc
+	}
+}
 
 // NewQueryServiceExecutor returns a new executor based on the given AsyncQueryService.
-func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store) backend.Executor {
-	return &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st}
+func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store, opts ...AsyncOption) backend.Executor {
+	e := &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -196,33 +254,47 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	spec, err := flux.Compile(ctx, t.Script, time.Unix(run.Now, 0))
+	compiler, err := e.compile(ctx, t, run.Now)
 	if err != nil {
 		return nil, err
 	}
 
 	req := &query.Request{
 		OrganizationID: t.Org,
-		Compiler: lang.SpecCompiler{
-			Spec: spec,
-		},
+		Compiler:       compiler,
 	}
 	q, err := e.svc.Query(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return newAsyncRunPromise(run, q, e), nil
+	return newAsyncRunPromise(run, q, e, runTimeout(t)), nil
 }
 
 func (e *asyncQueryServiceExecutor) Wait() {
 	e.wg.Wait()
 }
 
+// Load returns the number of e's runs waiting in the query service's queue, and the number of free query slots.
+// Without WithQueryConcurrency, e is never saturated.
+func (e *asyncQueryServiceExecutor) Load() backend.ExecutorLoad {
+	if e.concurrency <= 0 {
+		return backend.ExecutorLoad{Available: math.MaxInt32}
+	}
+
+	free := e.concurrency - int(atomic.LoadInt64(&e.running))
+	if free < 0 {
+		return backend.ExecutorLoad{Queued: -free}
+	}
+	return backend.ExecutorLoad{Available: free}
+}
+
 // asyncRunPromise implements backend.RunPromise for an AsyncQueryService.
 type asyncRunPromise struct {
-	qr backend.QueuedRun
-	q  flux.Query
+	qr      backend.QueuedRun
+	q       flux.Query
+	timeout time.Duration // If positive, the query is canceled after this long.
+	running *int64        // The executor's count of unfinished runs, decremented once the query is done.
 
 	logger *zap.Logger
 	logEnd func()
@@ -235,20 +307,23 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor, timeout time.Duration) *asyncRunPromise {
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 
 	p := &asyncRunPromise{
-		qr:    qr,
-		q:     q,
-		ready: make(chan struct{}),
+		qr:      qr,
+		q:       q,
+		timeout: timeout,
+		running: &e.running,
+		ready:   make(chan struct{}),
 
 		logger: log,
 		logEnd: logEnd,
 	}
 
 	e.wg.Add(1)
+	atomic.AddInt64(&e.running, 1)
 	go p.followQuery(&e.wg)
 	return p
 }
@@ -276,14 +351,26 @@ func (p *asyncRunPromise) Cancel() {
 // followQuery will return.
 func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 	defer wg.Done()
+	defer atomic.AddInt64(p.running, -1)
 	// Always need to call Done after query is finished.
 	defer p.q.Done()
 
+	// A nil channel never receives, so without a timeout the select below only waits on the query.
+	var timedOut <-chan time.Time
+	if p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		timedOut = timer.C
+	}
+
 	select {
 	case <-p.ready:
 		// The promise was finished somewhere else, so we don't need to call p.finish.
 		// But we do need to cancel the flux. This could be a no-op.
 		p.q.Cancel()
+	case <-timedOut:
+		p.finish(nil, backend.ErrRunTimedOut)
+		p.q.Cancel()
 	case results, ok := <-p.q.Ready():
 		if !ok {
 			// Something went wrong with the flux. Set the error in the run result.
@@ -294,21 +381,23 @@ func (p *asyncRunPromise) followQuery(wg *sync.WaitGroup) {
 
 		// Exhaust the results so we don't leave unfinished iterators around.
 		var wg sync.WaitGroup
+		var rows int64
 		wg.Add(len(results))
 		for _, res := range results {
 			r := res
 			go func() {
 				defer wg.Done()
-				if err := exhaustResultIterators(r); err != nil {
+				n, err := exhaustResultIterators(r)
+				if err != nil {
 					p.logger.Info("Error exhausting result iterator", zap.Error(err), zap.String("name", r.Name()))
 				}
+				atomic.AddInt64(&rows, n)
 			}()
 		}
 		wg.Wait()
 
 		// Otherwise, query was successful.
-		// TODO(mr): collect query statistics, once RunResult interface supports them?
-		p.finish(new(runResult), nil)
+		p.finish(&runResult{rows: rows, maxMemory: p.q.Statistics().MaxAllocated}, nil)
 	}
 }
 
@@ -329,21 +418,145 @@ func (p *asyncRunPromise) finish(res *runResult, err error) {
 	})
 }
 
+// compile returns the compiler for the query of the run of t scheduled at the Unix timestamp now.
+// If the script references secrets, their values are loaded for t's organization, but only substituted
+// into the spec when the query service compiles the query; see secretsCompiler.
+func (e *asyncQueryServiceExecutor) compile(ctx context.Context, t *backend.StoreTask, now int64) (flux.Compiler, error) {
+	opts, err := t.ScriptOptions()
+	if err != nil || len(opts.Secrets) == 0 {
+		spec, err := flux.Compile(ctx, t.Script, time.Unix(now, 0))
+		if err != nil {
+			return nil, err
+		}
+		return lang.SpecCompiler{Spec: spec}, nil
+	}
+	if e.secrets == nil {
+		return nil, backend.ErrSecretsUnsupported
+	}
+
+	// Evaluate the script with a placeholder in place of each secret, unique to this run
+	// so that it cannot be mistaken for any other string in the script.
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	placeholders := make(map[string]string, len(opts.Secrets))
+	vals := make(map[string]string, len(opts.Secrets))
+	for i, name := range opts.Secrets {
+		v, err := e.secrets.LoadSecret(ctx, t.Org, name)
+		if err != nil {
+			// Never include the value in the error, as it may end up in the run log.
+			return nil, fmt.Errorf("failed to load secret %q: %v", name, err)
+		}
+		placeholder := fmt.Sprintf("secret-%x-%d", nonce, i)
+		placeholders[name] = placeholder
+		vals[placeholder] = v
+	}
+
+	itrp := flux.NewInterpreter()
+	itrp.SetOption("now", nowFunc(time.Unix(now, 0)))
+	options.BindSecrets(itrp, placeholders)
+	if err := flux.Eval(itrp, t.Script); err != nil {
+		return nil, err
+	}
+	return secretsCompiler{Spec: flux.ToSpec(itrp, itrp.SideEffects()...), values: vals}, nil
+}
+
+// secretsCompiler is a flux.Compiler for a spec evaluated with placeholders in place of the values of secrets.
+// The values are only substituted into a copy of the spec when the query is compiled,
+// so that the request, which the query service may log, holds the placeholders.
+// It marshals as a lang.SpecCompiler with the placeholders.
+type secretsCompiler struct {
+	Spec *flux.Spec `json:"spec"`
+
+	values map[string]string // Secret values, keyed by placeholder.
+}
+
+var _ flux.Compiler = secretsCompiler{}
+
+func (c secretsCompiler) Compile(ctx context.Context) (*flux.Spec, error) {
+	b, err := json.Marshal(c.Spec)
+	if err != nil {
+		return nil, err
+	}
+	for placeholder, v := range c.values {
+		// Substitute the value as the contents of a JSON string, without its quotes.
+		quoted, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		b = bytes.Replace(b, []byte(placeholder), quoted[1:len(quoted)-1], -1)
+	}
+
+	var spec flux.Spec
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (c secretsCompiler) CompilerType() flux.CompilerType {
+	return lang.SpecCompilerType
+}
+
+// referencesSecrets reports whether t's script references secrets.
+func referencesSecrets(t *backend.StoreTask) bool {
+	opts, err := t.ScriptOptions()
+	return err == nil && len(opts.Secrets) > 0
+}
+
+// nowFunc returns the Flux now function that returns now, as flux.Compile sets it.
+func nowFunc(now time.Time) values.Function {
+	timeVal := values.NewTime(values.ConvertTime(now))
+	ftype := semantic.NewFunctionType(semantic.FunctionSignature{
+		Return: semantic.Time,
+	})
+	call := func(values.Object) (values.Value, error) {
+		return timeVal, nil
+	}
+	return values.NewFunction("now", ftype, call, false)
+}
+
+// runTimeout returns the value of the timeout option in t's script,
+// or 0 if the option is not set or the options cannot be parsed.
+func runTimeout(t *backend.StoreTask) time.Duration {
+	opts, err := t.ScriptOptions()
+	if err != nil {
+		return 0
+	}
+	return opts.Timeout
+}
+
 type runResult struct {
 	err       error
 	retryable bool
-}
 
-var _ backend.RunResult = (*runResult)(nil)
+	// Rows in the query's results. A task's results are the data it wrote, so this is the number of rows it wrote.
+	rows int64
+
+	// The most bytes the query allocated at once.
+	maxMemory int64
+}
 
-func (rr *runResult) Err() error        { return rr.err }
-func (rr *runResult) IsRetryable() bool { return rr.retryable }
+var (
+	_ backend.RunResult      = (*runResult)(nil)
+	_ backend.RowCounter     = (*runResult)(nil)
+	_ backend.MemoryReporter = (*runResult)(nil)
+)
 
-// exhaustResultIterators drains all the iterators from a flux query Result.
-func exhaustResultIterators(res flux.Result) error {
-	return res.Tables().Do(func(tbl flux.Table) error {
-		return tbl.Do(func(flux.ColReader) error {
+func (rr *runResult) Err() error            { return rr.err }
+func (rr *runResult) IsRetryable() bool     { return rr.retryable }
+func (rr *runResult) RowsWritten() int64    { return rr.rows }
+func (rr *runResult) MaxMemoryBytes() int64 { return rr.maxMemory }
+
+// exhaustResultIterators drains all the iterators from a flux query Result, returning the number of rows read.
+func exhaustResultIterators(res flux.Result) (int64, error) {
+	var rows int64
+	err := res.Tables().Do(func(tbl flux.Table) error {
+		return tbl.Do(func(cr flux.ColReader) error {
+			rows += int64(cr.Len())
 			return nil
 		})
 	})
+	return rows, err
 }
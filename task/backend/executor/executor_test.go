@@ -1,6 +1,7 @@
 package executor_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
 	"github.com/influxdata/platform/task/backend"
@@ -28,6 +30,7 @@ type fakeQueryService struct {
 	mu       sync.Mutex
 	queries  map[string]*fakeQuery
 	queryErr error
+	requests []*query.Request // Every request passed to Query, oldest first.
 }
 
 var _ query.AsyncQueryService = (*fakeQueryService)(nil)
@@ -61,16 +64,20 @@ func (s *fakeQueryService) Query(ctx context.Context, req *query.Request) (flux.
 		return nil, err
 	}
 
-	sc, ok := req.Compiler.(lang.SpecCompiler)
-	if !ok {
-		return nil, fmt.Errorf("fakeQueryService only supports the SpecCompiler, got %T", req.Compiler)
+	if req.Compiler.CompilerType() != lang.SpecCompilerType {
+		return nil, fmt.Errorf("fakeQueryService only supports the SpecCompiler type, got %T", req.Compiler)
 	}
+	spec, err := req.Compiler.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.requests = append(s.requests, req)
 
 	fq := &fakeQuery{
 		wait:  make(chan struct{}),
 		ready: make(chan map[string]flux.Result),
 	}
-	s.queries[makeSpecString(sc.Spec)] = fq
+	s.queries[makeSpecString(spec)] = fq
 
 	go fq.run(ctx)
 
@@ -263,6 +270,7 @@ func TestExecutor(t *testing.T) {
 		testExecutorQuerySuccess(t, fn)
 		testExecutorQueryFailure(t, fn)
 		testExecutorPromiseCancel(t, fn)
+		testExecutorTimeout(t, fn)
 		testExecutorServiceError(t, fn)
 		testExecutorWait(t, fn)
 	}
@@ -390,6 +398,45 @@ func testExecutorPromiseCancel(t *testing.T, fn createSysFn) {
 	})
 }
 
+func testExecutorTimeout(t *testing.T, fn createSysFn) {
+	var orgID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
+	var userID = platformtesting.MustIDBase16("baaaaaaaaaaaaaab")
+	sys := fn()
+	t.Run(sys.name+"/Timeout", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping test in short mode")
+		}
+		t.Parallel()
+
+		script := fmt.Sprintf(`option task = {
+			name: %q,
+			every: 1m,
+			timeout: 1s,
+		}
+		from(bucket: "one") |> toHTTP(url: "http://example.com")`, t.Name())
+		tid, err := sys.st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: userID, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123}
+		rp, err := sys.ex.Execute(context.Background(), qr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Never unblock the query, so the run must time out.
+		sys.svc.WaitForQueryLive(t, script)
+
+		res, err := rp.Wait()
+		if err != backend.ErrRunTimedOut {
+			t.Fatalf("expected ErrRunTimedOut, got %v", err)
+		}
+		if res != nil {
+			t.Fatalf("expected nil result after timeout, got %#v", res)
+		}
+	})
+}
+
 func testExecutorServiceError(t *testing.T, fn createSysFn) {
 	var orgID = platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa")
 	var userID = platformtesting.MustIDBase16("baaaaaaaaaaaaaab")
@@ -619,3 +666,113 @@ func testExecutorWait(t *testing.T, createSys createSysFn) {
 		})
 	})
 }
+
+func TestAsyncExecutor_Load(t *testing.T) {
+	svc := newFakeQueryService()
+	st := backend.NewInMemStore()
+	ex := executor.NewAsyncQueryServiceExecutor(zap.NewNop(), svc, st, executor.WithQueryConcurrency(1))
+	lr, ok := ex.(backend.LoadReporter)
+	if !ok {
+		t.Fatal("expected executor to report its load")
+	}
+
+	if l := lr.Load(); l.Saturated() || l.Available != 1 {
+		t.Fatalf("expected one available slot before executing, got %#v", l)
+	}
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	tid, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.WaitForQueryLive(t, script)
+
+	if l := lr.Load(); !l.Saturated() || l.Queued != 0 {
+		t.Fatalf("expected saturated executor with nothing queued, got %#v", l)
+	}
+
+	svc.SucceedQuery(script)
+	if _, err := rp.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	ex.Wait()
+
+	if l := lr.Load(); l.Saturated() || l.Available != 1 {
+		t.Fatalf("expected slot to be freed after the run finished, got %#v", l)
+	}
+}
+
+func TestAsyncExecutor_Secrets(t *testing.T) {
+	const orgID = platform.ID(1)
+	url := "http://example.com/" + t.Name()
+
+	svc := newFakeQueryService()
+	st := backend.NewInMemStore()
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(_ context.Context, org platform.ID, k string) (string, error) {
+		if org == orgID && k == "url" {
+			return url, nil
+		}
+		return "", errors.New("secret not found")
+	}
+	ex := executor.NewAsyncQueryServiceExecutor(zap.NewNop(), svc, st, executor.WithSecretService(secrets))
+
+	const fmtSecretScript = `option task = {
+			name: "secrets",
+			every: 1m,
+		}
+		from(bucket: "one") |> toHTTP(url: %s)`
+	script := fmt.Sprintf(fmtSecretScript, "secrets.url")
+	tid, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(1), Now: 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The query runs with the secret's value in place of the reference.
+	resolved := fmt.Sprintf(fmtSecretScript, fmt.Sprintf("%q", url))
+	svc.WaitForQueryLive(t, resolved)
+
+	// But the request, which may be logged, does not hold the value.
+	svc.mu.Lock()
+	b, err := json.Marshal(svc.requests[len(svc.requests)-1].Compiler)
+	svc.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte(url)) {
+		t.Fatalf("expected request compiler not to hold the secret's value, got %s", b)
+	}
+
+	svc.SucceedQuery(resolved)
+	if _, err := rp.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := fmt.Sprintf(fmtSecretScript, "secrets.missing")
+	tid, err = st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: orgID, User: 2, Script: missing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(2), Now: 123}); err == nil {
+		t.Fatal("expected error executing task whose secret cannot be loaded")
+	}
+	ex.Wait()
+
+	// Without a secret service, runs of the task fail outright.
+	ex = executor.NewAsyncQueryServiceExecutor(zap.NewNop(), svc, st)
+	if _, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(3), Now: 123}); err != backend.ErrSecretsUnsupported {
+		t.Fatalf("expected ErrSecretsUnsupported, got %v", err)
+	}
+	syncEx := executor.NewQueryServiceExecutor(zap.NewNop(), query.QueryServiceBridge{AsyncQueryService: svc}, st)
+	if _, err := syncEx.Execute(context.Background(), backend.QueuedRun{TaskID: tid, RunID: platform.ID(4), Now: 123}); err != backend.ErrSecretsUnsupported {
+		t.Fatalf("expected ErrSecretsUnsupported from the synchronous executor, got %v", err)
+	}
+}
@@ -0,0 +1,438 @@
+// Package remote dispatches task runs to a pool of worker processes over gRPC,
+// so that query execution can scale independently of the node running the scheduler.
+//
+// The scheduler's node uses an Executor, which also serves the Dispatcher gRPC service.
+// Each worker process runs a Worker connected to that service,
+// which claims runs, executes them, streams their progress, and reports how they ended.
+package remote
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrRunAbandoned is returned from a run's Wait when the worker that claimed the run stopped reporting progress.
+var ErrRunAbandoned = errors.New("run abandoned by its worker")
+
+// DefaultLease is how long an Executor waits for progress on a claimed run, unless set by WithLease.
+const DefaultLease = 30 * time.Second
+
+// runKey identifies a run among those an Executor has not finished.
+type runKey struct {
+	taskID, runID platform.ID
+}
+
+// Executor is a backend.Executor that hands each run to a remote worker, through the Dispatcher service it serves.
+// It reports a load, through backend.LoadReporter, of one slot for each worker waiting to claim a run,
+// so that the scheduler holds back runs while every worker is busy.
+type Executor struct {
+	st     backend.Store
+	lw     backend.LogWriter
+	logger *zap.Logger
+	lease  time.Duration
+
+	mu      sync.Mutex
+	queue   []*runPromise          // Runs waiting to be claimed, oldest first.
+	claimed map[runKey]*runPromise // Runs claimed by a worker that have not finished.
+	waiting int                    // Number of workers waiting in ClaimRun.
+	queued  chan struct{}          // Closed and replaced whenever a run is queued, to wake waiting workers.
+
+	wg sync.WaitGroup // Unfinished runs.
+}
+
+var (
+	_ backend.Executor     = (*Executor)(nil)
+	_ backend.LoadReporter = (*Executor)(nil)
+	_ DispatcherServer     = (*Executor)(nil)
+)
+
+// ExecutorOption configures an Executor.
+type ExecutorOption func(*Executor)
+
+// WithLogWriter records the log messages that workers report for each run with lw.
+// Without it, the messages are only logged by the Executor's logger.
+func WithLogWriter(lw backend.LogWriter) ExecutorOption {
+	return func(e *Executor) {
+		e.lw = lw
+	}
+}
+
+// WithLogger sets the logger for the Executor.
+func WithLogger(logger *zap.Logger) ExecutorOption {
+	return func(e *Executor) {
+		e.logger = logger
+	}
+}
+
+// WithLease sets how long the Executor waits for progress on a claimed run before failing it with ErrRunAbandoned.
+func WithLease(d time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.lease = d
+	}
+}
+
+// NewExecutor returns an Executor that looks up the tasks of the runs it executes in st.
+// Register it with a gRPC server, with RegisterDispatcherServer, for workers to claim its runs.
+func NewExecutor(st backend.Store, opts ...ExecutorOption) *Executor {
+	e := &Executor{
+		st:      st,
+		logger:  zap.NewNop(),
+		lease:   DefaultLease,
+		claimed: make(map[runKey]*runPromise),
+		queued:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Execute queues run for a worker to claim.
+// Runs of tasks whose scripts reference secrets are not queued, as workers cannot resolve them;
+// Execute returns backend.ErrSecretsUnsupported for them.
+func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
+	t, err := e.st.FindTaskByID(ctx, run.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if opts, err := t.ScriptOptions(); err == nil && len(opts.Secrets) > 0 {
+		return nil, backend.ErrSecretsUnsupported
+	}
+
+	p := &runPromise{
+		qr:      run,
+		task:    t,
+		e:       e,
+		timeout: runTimeout(t),
+		ready:   make(chan struct{}),
+		logger:  e.logger.With(zap.Stringer("task_id", run.TaskID), zap.Stringer("run_id", run.RunID)),
+	}
+	if sp := opentracing.SpanFromContext(ctx); sp != nil {
+		p.span = sp.Context()
+	}
+
+	e.wg.Add(1)
+	e.mu.Lock()
+	e.queue = append(e.queue, p)
+	close(e.queued)
+	e.queued = make(chan struct{})
+	e.mu.Unlock()
+
+	return p, nil
+}
+
+// Wait blocks until all runs created through Execute have finished.
+func (e *Executor) Wait() {
+	e.wg.Wait()
+}
+
+// Load returns the number of runs waiting to be claimed, and the number of workers waiting to claim one.
+func (e *Executor) Load() backend.ExecutorLoad {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	free := e.waiting - len(e.queue)
+	if free < 0 {
+		return backend.ExecutorLoad{Queued: -free}
+	}
+	return backend.ExecutorLoad{Available: free}
+}
+
+// ClaimRun implements DispatcherServer.
+func (e *Executor) ClaimRun(ctx context.Context, req *ClaimRunRequest) (*ClaimedRun, error) {
+	for {
+		e.mu.Lock()
+		if len(e.queue) > 0 {
+			p := e.queue[0]
+			e.queue = e.queue[1:]
+			e.claimed[p.key()] = p
+			e.mu.Unlock()
+
+			p.claim(req.WorkerID)
+			if p.span != nil {
+				p.sendSpan(ctx)
+			}
+			return &ClaimedRun{
+				TaskID:       uint64(p.qr.TaskID),
+				RunID:        uint64(p.qr.RunID),
+				OrgID:        uint64(p.task.Org),
+				Now:          p.qr.Now,
+				Script:       p.task.Script,
+				LeaseSeconds: int64(e.lease / time.Second),
+			}, nil
+		}
+		queued := e.queued
+		e.waiting++
+		e.mu.Unlock()
+
+		select {
+		case <-queued:
+		case <-ctx.Done():
+		}
+
+		e.mu.Lock()
+		e.waiting--
+		e.mu.Unlock()
+
+		if err := ctx.Err(); err == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, "no run available")
+		} else if err != nil {
+			return nil, status.Error(codes.Canceled, err.Error())
+		}
+	}
+}
+
+// StreamProgress implements DispatcherServer.
+func (e *Executor) StreamProgress(stream Dispatcher_StreamProgressServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	p, err := e.claimedRun(msg.TaskID, msg.RunID)
+	if err != nil {
+		return err
+	}
+
+	// Tell the worker if the run finishes without it, such as when the run is canceled.
+	go func() {
+		select {
+		case <-p.ready:
+			if !p.completed() {
+				_ = stream.Send(&RunControl{Cancel: true})
+			}
+		case <-stream.Context().Done():
+		}
+	}()
+
+	for {
+		p.progress(stream.Context(), msg.Log)
+
+		msg, err = stream.Recv()
+		if err != nil {
+			// The worker closed the stream, or went away. If it doesn't complete the run, its lease expires.
+			return nil
+		}
+	}
+}
+
+// CompleteRun implements DispatcherServer.
+func (e *Executor) CompleteRun(ctx context.Context, req *CompleteRunRequest) (*types.Empty, error) {
+	p, err := e.claimedRun(req.TaskID, req.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &runResult{retryable: req.Retryable}
+	if req.Error != "" {
+		res.err = errors.New(req.Error)
+	}
+	p.complete(res)
+	return &types.Empty{}, nil
+}
+
+// claimedRun returns the claimed, unfinished run with the given IDs, or a NotFound error.
+func (e *Executor) claimedRun(taskID, runID uint64) (*runPromise, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p, ok := e.claimed[runKey{taskID: platform.ID(taskID), runID: platform.ID(runID)}]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "run not claimed or already finished")
+	}
+	return p, nil
+}
+
+// remove forgets p, whether it is queued or claimed.
+func (e *Executor) remove(p *runPromise) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.claimed, p.key())
+	for i, q := range e.queue {
+		if q == p {
+			e.queue = append(e.queue[:i], e.queue[i+1:]...)
+			break
+		}
+	}
+}
+
+// runPromise implements backend.RunPromise for a run handed to a remote worker.
+type runPromise struct {
+	qr      backend.QueuedRun
+	task    *backend.StoreTask
+	e       *Executor
+	timeout time.Duration // If positive, the run times out this long after it is claimed.
+	logger  *zap.Logger
+
+	// The span the scheduler executes the run in, which the worker continues. Nil if the run is not traced.
+	span opentracing.SpanContext
+
+	mu           sync.Mutex
+	leaseTimer   *time.Timer // Fails the run as abandoned, unless reset by progress. Nil until claimed.
+	timeoutTimer *time.Timer // Times the run out. Nil until claimed, or if there is no timeout.
+	byWorker     bool        // Whether the run was finished by its worker's report.
+	finishOnce   sync.Once
+	ready        chan struct{} // Closed inside finish. Indicates Wait will no longer block.
+	res          *runResult
+	err          error
+}
+
+var _ backend.RunPromise = (*runPromise)(nil)
+
+func (p *runPromise) key() runKey {
+	return runKey{taskID: p.qr.TaskID, runID: p.qr.RunID}
+}
+
+// sendSpan sends p's span to the worker claiming p, in the headers of the ClaimRun response that ctx belongs to,
+// so that the worker's execution is traced as part of the run's trace.
+func (p *runPromise) sendSpan(ctx context.Context) {
+	carrier := opentracing.TextMapCarrier{}
+	if err := opentracing.GlobalTracer().Inject(p.span, opentracing.TextMap, carrier); err != nil {
+		p.logger.Info("Failed to inject run span", zap.Error(err))
+		return
+	}
+	if err := grpc.SetHeader(ctx, metadata.New(carrier)); err != nil {
+		p.logger.Info("Failed to send run span to worker", zap.Error(err))
+	}
+}
+
+func (p *runPromise) Run() backend.QueuedRun {
+	return p.qr
+}
+
+func (p *runPromise) Wait() (backend.RunResult, error) {
+	<-p.ready
+
+	// Need an explicit return nil to avoid the non-nil interface value issue.
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.res, nil
+}
+
+func (p *runPromise) Cancel() {
+	p.finish(nil, backend.ErrRunCanceled)
+}
+
+// claim starts p's lease and timeout, now that worker has claimed it.
+func (p *runPromise) claim(worker string) {
+	p.logger.Info("Run claimed by worker", zap.String("worker_id", worker))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.ready:
+		// Canceled while being claimed. The worker finds out when it reports progress.
+		return
+	default:
+	}
+	p.leaseTimer = time.AfterFunc(p.e.lease, func() {
+		p.finish(nil, ErrRunAbandoned)
+	})
+	if p.timeout > 0 {
+		p.timeoutTimer = time.AfterFunc(p.timeout, func() {
+			p.finish(nil, backend.ErrRunTimedOut)
+		})
+	}
+}
+
+// progress renews p's lease, and records log, if it is not empty.
+func (p *runPromise) progress(ctx context.Context, log string) {
+	p.mu.Lock()
+	if p.leaseTimer != nil {
+		p.leaseTimer.Reset(p.e.lease)
+	}
+	p.mu.Unlock()
+
+	if log == "" {
+		return
+	}
+	p.logger.Info("Run progress", zap.String("log", log))
+	if p.e.lw == nil {
+		return
+	}
+	base := backend.RunLogBase{
+		Task:            p.task,
+		RunID:           p.qr.RunID,
+		RunScheduledFor: p.qr.Now,
+		RequestedAt:     p.qr.RequestedAt,
+	}
+	ev := backend.RunLogEvent{Type: backend.RunLogMessage, Time: time.Now(), Message: log}
+	if err := backend.AddRunLogEvent(ctx, p.e.lw, base, ev); err != nil {
+		p.logger.Info("Failed to record run log", zap.Error(err))
+	}
+}
+
+// complete finishes p with the result its worker reported.
+func (p *runPromise) complete(res *runResult) {
+	p.mu.Lock()
+	p.byWorker = true
+	p.mu.Unlock()
+	p.finish(res, nil)
+}
+
+// completed reports whether p was finished by its worker's report.
+func (p *runPromise) completed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.byWorker
+}
+
+func (p *runPromise) finish(res *runResult, err error) {
+	p.finishOnce.Do(func() {
+		defer p.e.wg.Done()
+
+		p.mu.Lock()
+		if p.leaseTimer != nil {
+			p.leaseTimer.Stop()
+		}
+		if p.timeoutTimer != nil {
+			p.timeoutTimer.Stop()
+		}
+		p.mu.Unlock()
+		p.e.remove(p)
+
+		p.res, p.err = res, err
+		close(p.ready)
+
+		if err != nil {
+			p.logger.Info("Execution failed to get result", zap.Error(err))
+		} else if res.err != nil {
+			p.logger.Info("Got result with error", zap.Error(res.err))
+		} else {
+			p.logger.Info("Completed successfully")
+		}
+	})
+}
+
+// runTimeout returns the value of the timeout option in t's script,
+// or 0 if the option is not set or the options cannot be parsed.
+func runTimeout(t *backend.StoreTask) time.Duration {
+	opts, err := t.ScriptOptions()
+	if err != nil {
+		return 0
+	}
+	return opts.Timeout
+}
+
+type runResult struct {
+	err       error
+	retryable bool
+}
+
+var _ backend.RunResult = (*runResult)(nil)
+
+func (rr *runResult) Err() error        { return rr.err }
+func (rr *runResult) IsRetryable() bool { return rr.retryable }
@@ -0,0 +1,248 @@
+package remote_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/executor/remote"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+)
+
+const script = `option task = {name: "remote", every: 1m} from(bucket: "b") |> range(start: -1h)`
+
+// logWriter records the log messages added for each run.
+type logWriter struct {
+	mu   sync.Mutex
+	logs map[platform.ID][]string
+}
+
+func (lw *logWriter) UpdateRunState(context.Context, backend.RunLogBase, time.Time, backend.RunStatus) error {
+	return nil
+}
+
+func (lw *logWriter) AddRunLog(_ context.Context, base backend.RunLogBase, _ time.Time, log string) error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.logs[base.RunID] = append(lw.logs[base.RunID], log)
+	return nil
+}
+
+func (lw *logWriter) logsFor(runID platform.ID) []string {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return lw.logs[runID]
+}
+
+// runnerFunc adapts a function to a remote.Runner.
+type runnerFunc func(ctx context.Context, run *remote.ClaimedRun, progress func(string)) error
+
+func (f runnerFunc) Run(ctx context.Context, run *remote.ClaimedRun, progress func(string)) error {
+	return f(ctx, run, progress)
+}
+
+// serve serves ex's Dispatcher service on a local port,
+// and returns a client connected to it and a function to stop serving.
+func serve(t *testing.T, ex *remote.Executor) (remote.DispatcherClient, func()) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	remote.RegisterDispatcherServer(srv, ex)
+	go srv.Serve(l)
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		srv.Stop()
+		t.Fatal(err)
+	}
+	return remote.NewDispatcherClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+func waitResult(t *testing.T, rp backend.RunPromise) (backend.RunResult, error) {
+	t.Helper()
+
+	type result struct {
+		res backend.RunResult
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		res, err := rp.Wait()
+		ch <- result{res, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.res, r.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for run")
+		return nil, nil
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	st := backend.NewInMemStore()
+	lw := &logWriter{logs: make(map[platform.ID][]string)}
+	ex := remote.NewExecutor(st, remote.WithLogWriter(lw), remote.WithLogger(zaptest.NewLogger(t)))
+	client, stop := serve(t, ex)
+	defer stop()
+
+	if l := ex.Load(); !l.Saturated() {
+		t.Fatalf("expected executor without workers to be saturated, got %#v", l)
+	}
+
+	taskID, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The runner fails the run with ID 2, and holds the run with ID 3 until it is canceled.
+	runner := runnerFunc(func(ctx context.Context, run *remote.ClaimedRun, progress func(string)) error {
+		if run.Script != script || run.OrgID != 1 {
+			return errors.New("unexpected claimed run")
+		}
+		progress("running")
+		switch run.RunID {
+		case 2:
+			return errors.New("forced")
+		case 3:
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go remote.NewWorker("w1", client, runner, remote.WithWorkerLogger(zaptest.NewLogger(t))).Run(ctx)
+
+	t.Run("success", func(t *testing.T) {
+		rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 1, Now: 60})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := waitResult(t, rp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Err() != nil {
+			t.Fatalf("expected run to succeed, got %v", res.Err())
+		}
+		// Progress arrives on its own stream, so it may be recorded after the run completes.
+		for i := 0; len(lw.logsFor(1)) == 0 && i < 100; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		logs := lw.logsFor(1)
+		if len(logs) != 1 {
+			t.Fatalf("expected progress to be logged, got %v", logs)
+		}
+		if evs := backend.ParseRunLog(platform.Log(logs[0])); len(evs) != 1 || evs[0].Type != backend.RunLogMessage || evs[0].Message != "running" {
+			t.Fatalf("expected progress to be logged as a message event, got %v", logs)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 2, Now: 120})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := waitResult(t, rp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Err() == nil || res.Err().Error() != "forced" {
+			t.Fatalf("expected forced failure, got %v", res.Err())
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 3, Now: 180})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; len(lw.logsFor(3)) == 0; i++ {
+			if i == 100 {
+				t.Fatal("run was never claimed")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		rp.Cancel()
+		if _, err := waitResult(t, rp); err != backend.ErrRunCanceled {
+			t.Fatalf("expected ErrRunCanceled, got %v", err)
+		}
+
+		// The worker stops the canceled run and goes back to claiming runs.
+		rp, err = ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 4, Now: 240})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := waitResult(t, rp); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	cancel()
+	ex.Wait()
+}
+
+func TestExecutor_Abandoned(t *testing.T) {
+	st := backend.NewInMemStore()
+	ex := remote.NewExecutor(st, remote.WithLease(50*time.Millisecond))
+	client, stop := serve(t, ex)
+	defer stop()
+
+	taskID, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 1, Now: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Claim the run, then never report on it.
+	run, err := client.ClaimRun(context.Background(), &remote.ClaimRunRequest{WorkerID: "gone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if platform.ID(run.RunID) != 1 {
+		t.Fatalf("claimed unexpected run %d", run.RunID)
+	}
+
+	if _, err := waitResult(t, rp); err != remote.ErrRunAbandoned {
+		t.Fatalf("expected ErrRunAbandoned, got %v", err)
+	}
+
+	// A late report is rejected.
+	if _, err := client.CompleteRun(context.Background(), &remote.CompleteRunRequest{TaskID: run.TaskID, RunID: run.RunID}); err == nil {
+		t.Fatal("expected error completing abandoned run")
+	}
+}
+
+func TestExecutor_Secrets(t *testing.T) {
+	st := backend.NewInMemStore()
+	ex := remote.NewExecutor(st)
+
+	const secretScript = `option task = {name: "remote", every: 1m} from(bucket: secrets.bucket) |> range(start: -1h)`
+	taskID, err := st.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: secretScript})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ex.Execute(context.Background(), backend.QueuedRun{TaskID: taskID, RunID: 1, Now: 60}); err != backend.ErrSecretsUnsupported {
+		t.Fatalf("expected ErrSecretsUnsupported, got %v", err)
+	}
+	if l := ex.Load(); l.Queued != 0 {
+		t.Fatalf("expected no queued runs, got %d", l.Queued)
+	}
+}
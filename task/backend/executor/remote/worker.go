@@ -0,0 +1,257 @@
+package remote
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/retry"
+	"github.com/influxdata/platform/query"
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Runner executes the runs claimed by a Worker.
+type Runner interface {
+	// Run executes run, passing any messages for the run's log to progress, and returns once the run has ended.
+	// ctx is canceled if the run is canceled.
+	// A non-nil error fails the run; if the error has an IsRetryable method, the run may be retried when it returns true.
+	Run(ctx context.Context, run *ClaimedRun, progress func(log string)) error
+}
+
+// Worker claims runs from a Dispatcher and executes them with a Runner, one at a time.
+// Start as many workers, in as many processes, as there should be runs executing at once.
+type Worker struct {
+	id     string
+	client DispatcherClient
+	runner Runner
+	logger *zap.Logger
+
+	claimTimeout time.Duration
+	retry        retry.Backoff // How long to wait before claiming again after a failed claim.
+}
+
+// WorkerOption configures a Worker.
+type WorkerOption func(*Worker)
+
+// WithWorkerLogger sets the logger for the Worker.
+func WithWorkerLogger(logger *zap.Logger) WorkerOption {
+	return func(w *Worker) {
+		w.logger = logger
+	}
+}
+
+// NewWorker returns a Worker, identified to the dispatcher by id, that claims runs through client and executes them with r.
+func NewWorker(id string, client DispatcherClient, r Runner, opts ...WorkerOption) *Worker {
+	w := &Worker{
+		id:           id,
+		client:       client,
+		runner:       r,
+		logger:       zap.NewNop(),
+		claimTimeout: time.Minute,
+		retry: retry.Backoff{
+			Initial:    time.Second,
+			Max:        30 * time.Second,
+			Multiplier: 2,
+			Jitter:     0.2,
+		},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run claims and executes runs until ctx is canceled.
+// While the dispatcher is unreachable, the worker backs off between claims, up to 30 seconds apart.
+func (w *Worker) Run(ctx context.Context) {
+	failures := 0
+	for ctx.Err() == nil {
+		run, span, err := w.claim(ctx)
+		if err != nil {
+			if status.Code(err) != codes.DeadlineExceeded && ctx.Err() == nil {
+				failures++
+				w.logger.Info("Failed to claim run", zap.Int("failures", failures), zap.Error(err))
+				retry.Sleep(ctx, w.retry.Delay(failures))
+			}
+			continue
+		}
+
+		failures = 0
+		w.execute(ctx, run, span)
+	}
+}
+
+// claim waits for the dispatcher to assign a run to w.
+// It also returns the span the run is traced in on the dispatcher's side, or nil if the run is not traced.
+func (w *Worker) claim(ctx context.Context) (*ClaimedRun, opentracing.SpanContext, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.claimTimeout)
+	defer cancel()
+
+	var header metadata.MD
+	run, err := w.client.ClaimRun(ctx, &ClaimRunRequest{WorkerID: w.id}, grpc.Header(&header))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	carrier := opentracing.TextMapCarrier{}
+	for k, v := range header {
+		if len(v) > 0 {
+			carrier[k] = v[0]
+		}
+	}
+	span, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, carrier)
+	if err != nil {
+		// The run is not traced, or the tracers differ; trace the execution on its own.
+		span = nil
+	}
+	return run, span, nil
+}
+
+// execute runs run with w's runner, streaming its progress, and reports how it ended.
+// The execution is traced in a child of parent, which may be nil.
+func (w *Worker) execute(ctx context.Context, run *ClaimedRun, parent opentracing.SpanContext) {
+	logger := w.logger.With(zap.Stringer("task_id", platform.ID(run.TaskID)), zap.Stringer("run_id", platform.ID(run.RunID)))
+
+	sp := opentracing.StartSpan("task.run.remote", opentracing.ChildOf(parent),
+		opentracing.Tag{Key: "task_id", Value: platform.ID(run.TaskID).String()},
+		opentracing.Tag{Key: "run_id", Value: platform.ID(run.RunID).String()},
+		opentracing.Tag{Key: "worker_id", Value: w.id},
+	)
+	defer sp.Finish()
+
+	runCtx, cancel := context.WithCancel(opentracing.ContextWithSpan(ctx, sp))
+	defer cancel()
+
+	stream, err := w.client.StreamProgress(runCtx)
+	if err != nil {
+		logger.Info("Failed to open progress stream", zap.Error(err))
+		w.complete(ctx, run, err, logger)
+		return
+	}
+
+	var sendMu sync.Mutex
+	closed := false
+	send := func(log string) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if closed {
+			return
+		}
+		if err := stream.Send(&RunProgress{TaskID: run.TaskID, RunID: run.RunID, Log: log}); err != nil {
+			logger.Info("Failed to send run progress", zap.Error(err))
+		}
+	}
+
+	// Open the stream, and keep the run's lease alive while it executes.
+	send("")
+	lease := time.Duration(run.LeaseSeconds) * time.Second
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	heartbeat := time.NewTicker(lease / 3)
+	defer heartbeat.Stop()
+	go func() {
+		for {
+			select {
+			case <-heartbeat.C:
+				send("")
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// Stop executing the run if the dispatcher cancels it.
+	var canceled uint32
+	go func() {
+		for {
+			c, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if c.Cancel {
+				logger.Info("Run canceled by dispatcher")
+				atomic.StoreUint32(&canceled, 1)
+				cancel()
+				return
+			}
+		}
+	}()
+
+	err = w.runner.Run(runCtx, run, send)
+	sendMu.Lock()
+	closed = true
+	_ = stream.CloseSend()
+	sendMu.Unlock()
+
+	if atomic.LoadUint32(&canceled) == 1 {
+		// The dispatcher already finished the run.
+		return
+	}
+	w.complete(ctx, run, err, logger)
+}
+
+// complete reports to the dispatcher that run ended with err.
+func (w *Worker) complete(ctx context.Context, run *ClaimedRun, err error, logger *zap.Logger) {
+	req := &CompleteRunRequest{TaskID: run.TaskID, RunID: run.RunID}
+	if err != nil {
+		req.Error = err.Error()
+		if r, ok := err.(interface{ IsRetryable() bool }); ok {
+			req.Retryable = r.IsRetryable()
+		}
+	}
+	if _, err := w.client.CompleteRun(ctx, req); err != nil {
+		logger.Info("Failed to report run completion", zap.Error(err))
+	}
+}
+
+// queryRunner is a Runner that executes runs through a QueryService.
+type queryRunner struct {
+	svc query.QueryService
+}
+
+// NewQueryRunner returns a Runner that executes each run's script through svc.
+func NewQueryRunner(svc query.QueryService) Runner {
+	return queryRunner{svc: svc}
+}
+
+func (r queryRunner) Run(ctx context.Context, run *ClaimedRun, progress func(log string)) error {
+	spec, err := flux.Compile(ctx, run.Script, time.Unix(run.Now, 0))
+	if err != nil {
+		return err
+	}
+
+	req := &query.Request{
+		OrganizationID: platform.ID(run.OrgID),
+		Compiler: lang.SpecCompiler{
+			Spec: spec,
+		},
+	}
+	it, err := r.svc.Query(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	// Drain the result iterator.
+	for it.More() {
+		res := it.Next()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(flux.ColReader) error {
+				return nil
+			})
+		}); err != nil {
+			progress("Error reading result " + res.Name() + ": " + err.Error())
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,348 @@
+package grpcstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"google.golang.org/grpc"
+)
+
+// Client is a backend.Store that calls the Store service of a storage node.
+type Client struct {
+	conn *grpc.ClientConn
+	c    StoreClient
+}
+
+var _ backend.Store = (*Client)(nil)
+
+// NewClient returns a Client calling the Store service over conn.
+// The Client takes ownership of conn, closing it when the Client is closed.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, c: NewStoreClient(conn)}
+}
+
+func (c *Client) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (platform.ID, error) {
+	res, err := c.c.CreateTask(ctx, &CreateTaskRequest{
+		Org:            uint64(req.Org),
+		User:           uint64(req.User),
+		Script:         req.Script,
+		ScheduleAfter:  req.ScheduleAfter,
+		Status:         string(req.Status),
+		Labels:         req.Labels,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return platform.InvalidID(), fromStatus(err)
+	}
+	if res.AlreadyCreated {
+		return platform.ID(res.ID), backend.ErrTaskAlreadyCreated
+	}
+	return platform.ID(res.ID), nil
+}
+
+func (c *Client) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
+	preq := &UpdateTaskRequest{
+		ID:             uint64(req.ID),
+		Script:         req.Script,
+		Status:         string(req.Status),
+		DisabledReason: req.DisabledReason,
+		DeletedAt:      req.DeletedAt,
+		MaxConcurrency: req.MaxConcurrency,
+		Labels:         req.Labels,
+		SetLabels:      req.Labels != nil,
+		Org:            uint64(req.Org),
+		User:           uint64(req.User),
+		Notification:   req.Notification,
+	}
+	if !req.Options.IsZero() {
+		b, err := json.Marshal(req.Options)
+		if err != nil {
+			return backend.UpdateTaskResult{}, err
+		}
+		preq.Options = b
+	}
+
+	res, err := c.c.UpdateTask(ctx, preq)
+	if err != nil {
+		return backend.UpdateTaskResult{}, fromStatus(err)
+	}
+	t, err := taskFromProto(res.NewTask)
+	if err != nil {
+		return backend.UpdateTaskResult{}, err
+	}
+	return backend.UpdateTaskResult{
+		OldScript: res.OldScript,
+		OldStatus: backend.TaskStatus(res.OldStatus),
+		NewTask:   *t,
+		NewMeta:   *metaFromProto(res.NewMeta),
+	}, nil
+}
+
+func (c *Client) EnableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	res, err := c.c.EnableTasks(ctx, &TaskIDs{IDs: idsToProto(ids)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return idsFromProto(res.IDs), nil
+}
+
+func (c *Client) DisableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	res, err := c.c.DisableTasks(ctx, &TaskIDs{IDs: idsToProto(ids)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return idsFromProto(res.IDs), nil
+}
+
+func (c *Client) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTaskWithMeta, error) {
+	res, err := c.c.ListTasks(ctx, &ListTasksRequest{
+		Org:      uint64(params.Org),
+		User:     uint64(params.User),
+		After:    uint64(params.After),
+		Labels:   params.Labels,
+		PageSize: int64(params.PageSize),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	tasks := make([]backend.StoreTaskWithMeta, len(res.Tasks))
+	for i, pt := range res.Tasks {
+		t, err := taskFromProto(pt.Task)
+		if err != nil {
+			return nil, err
+		}
+		tasks[i] = backend.StoreTaskWithMeta{Task: *t, Meta: *metaFromProto(pt.Meta)}
+	}
+	return tasks, nil
+}
+
+func (c *Client) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
+	res, err := c.c.FindTaskByID(ctx, &TaskID{ID: uint64(id)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return taskFromProto(res)
+}
+
+func (c *Client) FindTaskMetaByID(ctx context.Context, id platform.ID) (*backend.StoreTaskMeta, error) {
+	res, err := c.c.FindTaskMetaByID(ctx, &TaskID{ID: uint64(id)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return res, nil
+}
+
+func (c *Client) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*backend.StoreTask, *backend.StoreTaskMeta, error) {
+	res, err := c.c.FindTaskByIDWithMeta(ctx, &TaskID{ID: uint64(id)})
+	if err != nil {
+		return nil, nil, fromStatus(err)
+	}
+	t, err := taskFromProto(res.Task)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, metaFromProto(res.Meta), nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id platform.ID) (bool, error) {
+	res, err := c.c.DeleteTask(ctx, &TaskID{ID: uint64(id)})
+	if err != nil {
+		return false, fromStatus(err)
+	}
+	return res.Deleted, nil
+}
+
+func (c *Client) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+	res, err := c.c.CreateNextRun(ctx, &CreateNextRunRequest{TaskID: uint64(taskID), Now: now})
+	if err != nil {
+		return backend.RunCreation{}, fromStatus(err)
+	}
+	if res.NotYetDue {
+		return backend.RunCreation{}, backend.RunNotYetDueError{DueAt: res.NextDue}
+	}
+	return backend.RunCreation{
+		Created:    queuedRunFromProto(res.Created),
+		NextDue:    res.NextDue,
+		HasQueue:   res.HasQueue,
+		QueuedRuns: int(res.QueuedRuns),
+	}, nil
+}
+
+func (c *Client) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	_, err := c.c.FinishRun(ctx, &RunID{TaskID: uint64(taskID), RunID: uint64(runID)})
+	return fromStatus(err)
+}
+
+func (c *Client) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	res, err := c.c.ManuallyRunTimeRange(ctx, &ManuallyRunTimeRangeRequest{
+		TaskID:      uint64(taskID),
+		Start:       start,
+		End:         end,
+		RequestedAt: requestedAt,
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return res, nil
+}
+
+func (c *Client) RetryRun(ctx context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	res, err := c.c.RetryRun(ctx, &RetryRunRequest{
+		TaskID:       uint64(taskID),
+		RunID:        uint64(runID),
+		ScheduledFor: scheduledFor,
+		RequestedAt:  requestedAt,
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return res, nil
+}
+
+func (c *Client) ListTaskVersions(ctx context.Context, id platform.ID) ([]backend.TaskVersion, error) {
+	res, err := c.c.ListTaskVersions(ctx, &TaskID{ID: uint64(id)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	versions := make([]backend.TaskVersion, len(res.Versions))
+	for i, v := range res.Versions {
+		versions[i] = backend.TaskVersion{Version: int(v.Version), Script: v.Script, CreatedAt: v.CreatedAt}
+	}
+	return versions, nil
+}
+
+func (c *Client) RecordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	_, err := c.c.RecordRunOutcome(ctx, &RunOutcomeRequest{TaskID: uint64(taskID), Outcome: runOutcomeToProto(o)})
+	return fromStatus(err)
+}
+
+func (c *Client) CompleteRun(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	_, err := c.c.CompleteRun(ctx, &RunOutcomeRequest{TaskID: uint64(taskID), Outcome: runOutcomeToProto(o)})
+	return fromStatus(err)
+}
+
+func (c *Client) FindTaskStats(ctx context.Context, taskID platform.ID) (*backend.TaskStats, error) {
+	res, err := c.c.FindTaskStats(ctx, &TaskID{ID: uint64(taskID)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	stats := &backend.TaskStats{
+		Runs:            int(res.Runs),
+		Succeeded:       int(res.Succeeded),
+		Failed:          int(res.Failed),
+		SuccessRate:     res.SuccessRate,
+		AverageDuration: time.Duration(res.AverageDuration),
+		MedianDuration:  time.Duration(res.MedianDuration),
+		P95Duration:     time.Duration(res.P95Duration),
+		Usage:           taskUsageFromProto(res.Usage),
+	}
+	if res.LastFailure != nil {
+		lf := runOutcomeFromProto(res.LastFailure)
+		stats.LastFailure = &lf
+	}
+	return stats, nil
+}
+
+func (c *Client) RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error) {
+	res, err := c.c.RunSucceeded(ctx, &RunSucceededRequest{TaskID: uint64(taskID), Now: now})
+	if err != nil {
+		return false, fromStatus(err)
+	}
+	return res.Succeeded, nil
+}
+
+func (c *Client) AppendAuditEntry(ctx context.Context, e backend.AuditEntry) error {
+	_, err := c.c.AppendAuditEntry(ctx, auditEntryToProto(e))
+	return fromStatus(err)
+}
+
+func (c *Client) ListAuditEntries(ctx context.Context, orgID platform.ID, params backend.AuditSearchParams) ([]backend.AuditEntry, error) {
+	res, err := c.c.ListAuditEntries(ctx, &ListAuditEntriesRequest{
+		Org:    uint64(orgID),
+		TaskID: uint64(params.TaskID),
+		After:  timeToProto(params.After),
+		Limit:  int64(params.Limit),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	entries := make([]backend.AuditEntry, len(res.Entries))
+	for i, e := range res.Entries {
+		entries[i] = auditEntryFromProto(e)
+	}
+	return entries, nil
+}
+
+func (c *Client) DeleteOrg(ctx context.Context, orgID platform.ID) error {
+	_, err := c.c.DeleteOrg(ctx, &OrgID{ID: uint64(orgID)})
+	return fromStatus(err)
+}
+
+func (c *Client) DeleteUser(ctx context.Context, userID platform.ID) error {
+	_, err := c.c.DeleteUser(ctx, &UserID{ID: uint64(userID)})
+	return fromStatus(err)
+}
+
+func (c *Client) AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	_, err := c.c.AcquireTaskLease(ctx, &AcquireTaskLeaseRequest{
+		TaskID:    uint64(taskID),
+		Owner:     owner,
+		Now:       now,
+		ExpiresAt: expiresAt,
+	})
+	return fromStatus(err)
+}
+
+func (c *Client) ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error {
+	_, err := c.c.ReleaseTaskLease(ctx, &ReleaseTaskLeaseRequest{TaskID: uint64(taskID), Owner: owner})
+	return fromStatus(err)
+}
+
+func (c *Client) ListTaskLeases(ctx context.Context) ([]backend.TaskLease, error) {
+	res, err := c.c.ListTaskLeases(ctx, &types.Empty{})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	leases := make([]backend.TaskLease, len(res.Leases))
+	for i, l := range res.Leases {
+		leases[i] = backend.TaskLease{TaskID: platform.ID(l.TaskID), Owner: l.Owner, ExpiresAt: l.ExpiresAt}
+	}
+	return leases, nil
+}
+
+func (c *Client) KeepAliveLeaseOwner(ctx context.Context, owner string, expiresAt int64) error {
+	_, err := c.c.KeepAliveLeaseOwner(ctx, &KeepAliveLeaseOwnerRequest{Owner: owner, ExpiresAt: expiresAt})
+	return fromStatus(err)
+}
+
+func (c *Client) ListLeaseOwners(ctx context.Context, now int64) ([]string, error) {
+	res, err := c.c.ListLeaseOwners(ctx, &ListLeaseOwnersRequest{Now: now})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return res.Owners, nil
+}
+
+func (c *Client) AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error {
+	_, err := c.c.AcquireLeaderLease(ctx, &AcquireLeaderLeaseRequest{Owner: owner, Now: now, ExpiresAt: expiresAt})
+	return fromStatus(err)
+}
+
+func (c *Client) ReleaseLeaderLease(ctx context.Context, owner string) error {
+	_, err := c.c.ReleaseLeaderLease(ctx, &ReleaseLeaderLeaseRequest{Owner: owner})
+	return fromStatus(err)
+}
+
+// Close closes the Client's connection. It does not close the storage node's Store.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
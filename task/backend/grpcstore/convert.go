@@ -0,0 +1,207 @@
+package grpcstore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+func taskToProto(t *backend.StoreTask) (*Task, error) {
+	pt := &Task{
+		ID:     uint64(t.ID),
+		Org:    uint64(t.Org),
+		User:   uint64(t.User),
+		Name:   t.Name,
+		Script: t.Script,
+		Labels: t.Labels,
+	}
+	if t.Options != nil {
+		b, err := json.Marshal(t.Options)
+		if err != nil {
+			return nil, err
+		}
+		pt.Options = b
+	}
+	return pt, nil
+}
+
+func taskFromProto(pt *Task) (*backend.StoreTask, error) {
+	if pt == nil {
+		return &backend.StoreTask{}, nil
+	}
+	t := &backend.StoreTask{
+		ID:     platform.ID(pt.ID),
+		Org:    platform.ID(pt.Org),
+		User:   platform.ID(pt.User),
+		Name:   pt.Name,
+		Script: pt.Script,
+		Labels: pt.Labels,
+	}
+	if len(pt.Options) > 0 {
+		t.Options = new(options.Options)
+		if err := json.Unmarshal(pt.Options, t.Options); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// metaFromProto returns m, or an empty meta if m is nil.
+func metaFromProto(m *backend.StoreTaskMeta) *backend.StoreTaskMeta {
+	if m == nil {
+		return &backend.StoreTaskMeta{}
+	}
+	return m
+}
+
+func idsToProto(ids []platform.ID) []uint64 {
+	if ids == nil {
+		return nil
+	}
+	pids := make([]uint64, len(ids))
+	for i, id := range ids {
+		pids[i] = uint64(id)
+	}
+	return pids
+}
+
+func idsFromProto(pids []uint64) []platform.ID {
+	if pids == nil {
+		return nil
+	}
+	ids := make([]platform.ID, len(pids))
+	for i, id := range pids {
+		ids[i] = platform.ID(id)
+	}
+	return ids
+}
+
+func queuedRunToProto(qr backend.QueuedRun) *QueuedRun {
+	return &QueuedRun{
+		TaskID:       uint64(qr.TaskID),
+		RunID:        uint64(qr.RunID),
+		RequestedAt:  qr.RequestedAt,
+		Now:          qr.Now,
+		RetryOf:      uint64(qr.RetryOf),
+		TraceID:      qr.TraceID,
+		Notification: qr.Notification,
+	}
+}
+
+func queuedRunFromProto(pqr *QueuedRun) backend.QueuedRun {
+	if pqr == nil {
+		return backend.QueuedRun{}
+	}
+	return backend.QueuedRun{
+		TaskID:       platform.ID(pqr.TaskID),
+		RunID:        platform.ID(pqr.RunID),
+		RequestedAt:  pqr.RequestedAt,
+		Now:          pqr.Now,
+		RetryOf:      platform.ID(pqr.RetryOf),
+		TraceID:      pqr.TraceID,
+		Notification: pqr.Notification,
+	}
+}
+
+func runOutcomeToProto(o backend.RunOutcome) *RunOutcome {
+	return &RunOutcome{
+		RunID:          uint64(o.RunID),
+		ScheduledFor:   o.ScheduledFor,
+		Status:         int32(o.Status),
+		StartedAt:      timeToProto(o.StartedAt),
+		FinishedAt:     timeToProto(o.FinishedAt),
+		Error:          o.Error,
+		ErrorCode:      o.ErrorCode,
+		RowsWritten:    o.RowsWritten,
+		MaxMemoryBytes: o.MaxMemoryBytes,
+	}
+}
+
+func runOutcomeFromProto(po *RunOutcome) backend.RunOutcome {
+	if po == nil {
+		return backend.RunOutcome{}
+	}
+	return backend.RunOutcome{
+		RunID:          platform.ID(po.RunID),
+		ScheduledFor:   po.ScheduledFor,
+		Status:         backend.RunStatus(po.Status),
+		StartedAt:      timeFromProto(po.StartedAt),
+		FinishedAt:     timeFromProto(po.FinishedAt),
+		Error:          po.Error,
+		ErrorCode:      po.ErrorCode,
+		RowsWritten:    po.RowsWritten,
+		MaxMemoryBytes: po.MaxMemoryBytes,
+	}
+}
+
+func taskUsageToProto(u backend.TaskUsage) *TaskUsage {
+	return &TaskUsage{
+		Runs:           u.Runs,
+		ExecutionTime:  int64(u.ExecutionTime),
+		RowsWritten:    u.RowsWritten,
+		MemoryBytes:    u.MemoryBytes,
+		MaxMemoryBytes: u.MaxMemoryBytes,
+	}
+}
+
+func taskUsageFromProto(pu *TaskUsage) backend.TaskUsage {
+	if pu == nil {
+		return backend.TaskUsage{}
+	}
+	return backend.TaskUsage{
+		Runs:           pu.Runs,
+		ExecutionTime:  time.Duration(pu.ExecutionTime),
+		RowsWritten:    pu.RowsWritten,
+		MemoryBytes:    pu.MemoryBytes,
+		MaxMemoryBytes: pu.MaxMemoryBytes,
+	}
+}
+
+func auditEntryToProto(e backend.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		TaskID:         uint64(e.TaskID),
+		Org:            uint64(e.Org),
+		Action:         string(e.Action),
+		AuthorizerID:   uint64(e.AuthorizerID),
+		AuthorizerKind: e.AuthorizerKind,
+		UserID:         uint64(e.UserID),
+		OldScriptHash:  e.OldScriptHash,
+		NewScriptHash:  e.NewScriptHash,
+		Reason:         e.Reason,
+		Time:           timeToProto(e.Time),
+	}
+}
+
+func auditEntryFromProto(pe *AuditEntry) backend.AuditEntry {
+	return backend.AuditEntry{
+		TaskID:         platform.ID(pe.TaskID),
+		Org:            platform.ID(pe.Org),
+		Action:         backend.AuditAction(pe.Action),
+		AuthorizerID:   platform.ID(pe.AuthorizerID),
+		AuthorizerKind: pe.AuthorizerKind,
+		UserID:         platform.ID(pe.UserID),
+		OldScriptHash:  pe.OldScriptHash,
+		NewScriptHash:  pe.NewScriptHash,
+		Reason:         pe.Reason,
+		Time:           timeFromProto(pe.Time),
+	}
+}
+
+// timeToProto returns t as a unix timestamp in nanoseconds, or zero if t is the zero time.
+func timeToProto(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// timeFromProto returns the time of the unix timestamp in nanoseconds n, or the zero time if n is zero.
+func timeFromProto(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
@@ -0,0 +1,85 @@
+package grpcstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/influxdata/platform/task/backend"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storeErrors are the errors a backend.Store returns that callers compare against,
+// which a Client returns in place of the status of a call that failed with one of them.
+var storeErrors = map[codes.Code][]error{
+	codes.NotFound: {
+		backend.ErrTaskNotFound,
+		backend.ErrUserNotFound,
+		backend.ErrOrgNotFound,
+		backend.ErrRunNotFound,
+		backend.ErrTaskVersionNotFound,
+	},
+	codes.ResourceExhausted: {
+		backend.ErrManualQueueFull,
+	},
+	codes.FailedPrecondition: {
+		backend.ErrOneShotManualRun,
+		backend.ErrRunNotFinished,
+		backend.ErrLeaseHeld,
+	},
+}
+
+// toStatus returns err as the status of a failed call, with err's message.
+// The Store's errors that callers compare against are given codes that fromStatus recognizes.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch err {
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	for code, errs := range storeErrors {
+		for _, e := range errs {
+			if err == e {
+				return status.Error(code, err.Error())
+			}
+		}
+	}
+	if _, ok := err.(backend.RetryAlreadyQueuedError); ok {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// fromStatus returns the error that the status err was created from by toStatus, as far as it can be recovered.
+// Errors that callers compare against are returned as the same values,
+// and other errors from the Store have the same message.
+// Errors from gRPC itself, such as when the server is unavailable, are returned unchanged.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, e := range storeErrors[st.Code()] {
+		if st.Message() == e.Error() {
+			return e
+		}
+	}
+	switch st.Code() {
+	case codes.AlreadyExists:
+		if e := backend.ParseRetryAlreadyQueuedError(st.Message()); e != nil {
+			return *e
+		}
+	case codes.Unknown:
+		return errors.New(st.Message())
+	}
+	return err
+}
@@ -0,0 +1,3 @@
+package grpcstore
+
+//go:generate protoc -I ../../../internal -I .. -I . --plugin ../../../scripts/protoc-gen-gogofaster --gogofaster_out=Mgoogle/protobuf/empty.proto=github.com/gogo/protobuf/types,Mmeta.proto=github.com/influxdata/platform/task/backend,plugins=grpc:. store.proto
@@ -0,0 +1,358 @@
+// Package grpcstore serves a backend.Store over gRPC,
+// so that coordinators and schedulers can run in a different process or on a different host from the storage node.
+//
+// The storage node registers a Server, wrapping its backend.Store, with a gRPC server.
+// Other processes use a Client, which implements backend.Store by calling that service.
+package grpcstore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is a StoreServer that serves the methods of a backend.Store.
+type Server struct {
+	st backend.Store
+}
+
+var _ StoreServer = (*Server)(nil)
+
+// NewServer returns a Server serving st.
+// Register it with a gRPC server, with RegisterStoreServer, for Clients to reach st.
+func NewServer(st backend.Store) *Server {
+	return &Server{st: st}
+}
+
+func (s *Server) CreateTask(ctx context.Context, req *CreateTaskRequest) (*CreateTaskResponse, error) {
+	id, err := s.st.CreateTask(ctx, backend.CreateTaskRequest{
+		Org:            platform.ID(req.Org),
+		User:           platform.ID(req.User),
+		Script:         req.Script,
+		ScheduleAfter:  req.ScheduleAfter,
+		Status:         backend.TaskStatus(req.Status),
+		Labels:         req.Labels,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err == backend.ErrTaskAlreadyCreated {
+		return &CreateTaskResponse{ID: uint64(id), AlreadyCreated: true}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &CreateTaskResponse{ID: uint64(id)}, nil
+}
+
+func (s *Server) UpdateTask(ctx context.Context, req *UpdateTaskRequest) (*UpdateTaskResponse, error) {
+	ureq := backend.UpdateTaskRequest{
+		ID:             platform.ID(req.ID),
+		Script:         req.Script,
+		Status:         backend.TaskStatus(req.Status),
+		DisabledReason: req.DisabledReason,
+		DeletedAt:      req.DeletedAt,
+		MaxConcurrency: req.MaxConcurrency,
+		Org:            platform.ID(req.Org),
+		User:           platform.ID(req.User),
+		Notification:   req.Notification,
+	}
+	if len(req.Options) > 0 {
+		if err := json.Unmarshal(req.Options, &ureq.Options); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid options update: %v", err)
+		}
+	}
+	if req.SetLabels {
+		ureq.Labels = req.Labels
+		if ureq.Labels == nil {
+			ureq.Labels = map[string]string{}
+		}
+	}
+
+	res, err := s.st.UpdateTask(ctx, ureq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	t, err := taskToProto(&res.NewTask)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &UpdateTaskResponse{
+		OldScript: res.OldScript,
+		OldStatus: string(res.OldStatus),
+		NewTask:   t,
+		NewMeta:   &res.NewMeta,
+	}, nil
+}
+
+func (s *Server) EnableTasks(ctx context.Context, req *TaskIDs) (*TaskIDs, error) {
+	ids, err := s.st.EnableTasks(ctx, idsFromProto(req.IDs))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &TaskIDs{IDs: idsToProto(ids)}, nil
+}
+
+func (s *Server) DisableTasks(ctx context.Context, req *TaskIDs) (*TaskIDs, error) {
+	ids, err := s.st.DisableTasks(ctx, idsFromProto(req.IDs))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &TaskIDs{IDs: idsToProto(ids)}, nil
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *ListTasksRequest) (*ListTasksResponse, error) {
+	tasks, err := s.st.ListTasks(ctx, backend.TaskSearchParams{
+		Org:      platform.ID(req.Org),
+		User:     platform.ID(req.User),
+		After:    platform.ID(req.After),
+		Labels:   req.Labels,
+		PageSize: int(req.PageSize),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ListTasksResponse{Tasks: make([]*TaskWithMeta, len(tasks))}
+	for i := range tasks {
+		t, err := taskToProto(&tasks[i].Task)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		res.Tasks[i] = &TaskWithMeta{Task: t, Meta: &tasks[i].Meta}
+	}
+	return res, nil
+}
+
+func (s *Server) FindTaskByID(ctx context.Context, req *TaskID) (*Task, error) {
+	t, err := s.st.FindTaskByID(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pt, err := taskToProto(t)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return pt, nil
+}
+
+func (s *Server) FindTaskMetaByID(ctx context.Context, req *TaskID) (*backend.StoreTaskMeta, error) {
+	m, err := s.st.FindTaskMetaByID(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return m, nil
+}
+
+func (s *Server) FindTaskByIDWithMeta(ctx context.Context, req *TaskID) (*TaskWithMeta, error) {
+	t, m, err := s.st.FindTaskByIDWithMeta(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pt, err := taskToProto(t)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &TaskWithMeta{Task: pt, Meta: m}, nil
+}
+
+func (s *Server) DeleteTask(ctx context.Context, req *TaskID) (*DeleteTaskResponse, error) {
+	deleted, err := s.st.DeleteTask(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &DeleteTaskResponse{Deleted: deleted}, nil
+}
+
+func (s *Server) CreateNextRun(ctx context.Context, req *CreateNextRunRequest) (*RunCreation, error) {
+	rc, err := s.st.CreateNextRun(ctx, platform.ID(req.TaskID), req.Now)
+	if e, ok := err.(backend.RunNotYetDueError); ok {
+		return &RunCreation{NotYetDue: true, NextDue: e.DueAt}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &RunCreation{
+		Created:    queuedRunToProto(rc.Created),
+		NextDue:    rc.NextDue,
+		HasQueue:   rc.HasQueue,
+		QueuedRuns: int64(rc.QueuedRuns),
+	}, nil
+}
+
+func (s *Server) FinishRun(ctx context.Context, req *RunID) (*types.Empty, error) {
+	if err := s.st.FinishRun(ctx, platform.ID(req.TaskID), platform.ID(req.RunID)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ManuallyRunTimeRange(ctx context.Context, req *ManuallyRunTimeRangeRequest) (*backend.StoreTaskMetaManualRun, error) {
+	mr, err := s.st.ManuallyRunTimeRange(ctx, platform.ID(req.TaskID), req.Start, req.End, req.RequestedAt)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return mr, nil
+}
+
+func (s *Server) RetryRun(ctx context.Context, req *RetryRunRequest) (*backend.StoreTaskMetaManualRun, error) {
+	mr, err := s.st.RetryRun(ctx, platform.ID(req.TaskID), platform.ID(req.RunID), req.ScheduledFor, req.RequestedAt)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return mr, nil
+}
+
+func (s *Server) ListTaskVersions(ctx context.Context, req *TaskID) (*ListTaskVersionsResponse, error) {
+	versions, err := s.st.ListTaskVersions(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ListTaskVersionsResponse{Versions: make([]*TaskVersion, len(versions))}
+	for i, v := range versions {
+		res.Versions[i] = &TaskVersion{Version: int64(v.Version), Script: v.Script, CreatedAt: v.CreatedAt}
+	}
+	return res, nil
+}
+
+func (s *Server) RecordRunOutcome(ctx context.Context, req *RunOutcomeRequest) (*types.Empty, error) {
+	if err := s.st.RecordRunOutcome(ctx, platform.ID(req.TaskID), runOutcomeFromProto(req.Outcome)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) CompleteRun(ctx context.Context, req *RunOutcomeRequest) (*types.Empty, error) {
+	if err := s.st.CompleteRun(ctx, platform.ID(req.TaskID), runOutcomeFromProto(req.Outcome)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) FindTaskStats(ctx context.Context, req *TaskID) (*TaskStats, error) {
+	stats, err := s.st.FindTaskStats(ctx, platform.ID(req.ID))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &TaskStats{
+		Runs:            int64(stats.Runs),
+		Succeeded:       int64(stats.Succeeded),
+		Failed:          int64(stats.Failed),
+		SuccessRate:     stats.SuccessRate,
+		AverageDuration: int64(stats.AverageDuration),
+		MedianDuration:  int64(stats.MedianDuration),
+		P95Duration:     int64(stats.P95Duration),
+		Usage:           taskUsageToProto(stats.Usage),
+	}
+	if stats.LastFailure != nil {
+		res.LastFailure = runOutcomeToProto(*stats.LastFailure)
+	}
+	return res, nil
+}
+
+func (s *Server) RunSucceeded(ctx context.Context, req *RunSucceededRequest) (*RunSucceededResponse, error) {
+	ok, err := s.st.RunSucceeded(ctx, platform.ID(req.TaskID), req.Now)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &RunSucceededResponse{Succeeded: ok}, nil
+}
+
+func (s *Server) AppendAuditEntry(ctx context.Context, req *AuditEntry) (*types.Empty, error) {
+	if err := s.st.AppendAuditEntry(ctx, auditEntryFromProto(req)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	entries, err := s.st.ListAuditEntries(ctx, platform.ID(req.Org), backend.AuditSearchParams{
+		TaskID: platform.ID(req.TaskID),
+		After:  timeFromProto(req.After),
+		Limit:  int(req.Limit),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ListAuditEntriesResponse{Entries: make([]*AuditEntry, len(entries))}
+	for i, e := range entries {
+		res.Entries[i] = auditEntryToProto(e)
+	}
+	return res, nil
+}
+
+func (s *Server) DeleteOrg(ctx context.Context, req *OrgID) (*types.Empty, error) {
+	if err := s.st.DeleteOrg(ctx, platform.ID(req.ID)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, req *UserID) (*types.Empty, error) {
+	if err := s.st.DeleteUser(ctx, platform.ID(req.ID)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) AcquireTaskLease(ctx context.Context, req *AcquireTaskLeaseRequest) (*types.Empty, error) {
+	if err := s.st.AcquireTaskLease(ctx, platform.ID(req.TaskID), req.Owner, req.Now, req.ExpiresAt); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ReleaseTaskLease(ctx context.Context, req *ReleaseTaskLeaseRequest) (*types.Empty, error) {
+	if err := s.st.ReleaseTaskLease(ctx, platform.ID(req.TaskID), req.Owner); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ListTaskLeases(ctx context.Context, _ *types.Empty) (*ListTaskLeasesResponse, error) {
+	leases, err := s.st.ListTaskLeases(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &ListTaskLeasesResponse{Leases: make([]*TaskLease, len(leases))}
+	for i, l := range leases {
+		res.Leases[i] = &TaskLease{TaskID: uint64(l.TaskID), Owner: l.Owner, ExpiresAt: l.ExpiresAt}
+	}
+	return res, nil
+}
+
+func (s *Server) KeepAliveLeaseOwner(ctx context.Context, req *KeepAliveLeaseOwnerRequest) (*types.Empty, error) {
+	if err := s.st.KeepAliveLeaseOwner(ctx, req.Owner, req.ExpiresAt); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ListLeaseOwners(ctx context.Context, req *ListLeaseOwnersRequest) (*ListLeaseOwnersResponse, error) {
+	owners, err := s.st.ListLeaseOwners(ctx, req.Now)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &ListLeaseOwnersResponse{Owners: owners}, nil
+}
+
+func (s *Server) AcquireLeaderLease(ctx context.Context, req *AcquireLeaderLeaseRequest) (*types.Empty, error) {
+	if err := s.st.AcquireLeaderLease(ctx, req.Owner, req.Now, req.ExpiresAt); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
+
+func (s *Server) ReleaseLeaderLease(ctx context.Context, req *ReleaseLeaderLeaseRequest) (*types.Empty, error) {
+	if err := s.st.ReleaseLeaderLease(ctx, req.Owner); err != nil {
+		return nil, toStatus(err)
+	}
+	return &types.Empty{}, nil
+}
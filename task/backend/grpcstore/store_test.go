@@ -0,0 +1,59 @@
+package grpcstore_test
+
+import (
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/grpcstore"
+	"github.com/influxdata/platform/task/backend/storetest"
+	"github.com/influxdata/platform/task/options"
+	"google.golang.org/grpc"
+)
+
+func init() {
+	// TODO(mr): remove as part of https://github.com/influxdata/platform/issues/484.
+	options.EnableScriptCacheForTest()
+}
+
+func TestClient(t *testing.T) {
+	var mu sync.Mutex
+	servers := make(map[backend.Store]*grpc.Server)
+
+	storetest.NewStoreTest(
+		"grpc store",
+		func(t *testing.T) backend.Store {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			srv := grpc.NewServer()
+			grpcstore.RegisterStoreServer(srv, grpcstore.NewServer(backend.NewInMemStore()))
+			go srv.Serve(l)
+
+			conn, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+			if err != nil {
+				srv.Stop()
+				t.Fatal(err)
+			}
+			c := grpcstore.NewClient(conn)
+
+			mu.Lock()
+			servers[c] = srv
+			mu.Unlock()
+			return c
+		},
+		func(t *testing.T, s backend.Store) {
+			if err := s.Close(); err != nil {
+				t.Error(err)
+			}
+
+			mu.Lock()
+			srv := servers[s]
+			delete(servers, s)
+			mu.Unlock()
+			srv.Stop()
+		},
+	)(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -28,7 +29,7 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 		switch status {
 		case RunStarted:
 			r.StartedAt = whenStr
-		case RunFail, RunSuccess, RunCanceled:
+		case RunFail, RunSuccess, RunCanceled, RunTimedOut, RunSkipped, RunDeduplicated:
 			r.FinishedAt = whenStr
 		}
 	}
@@ -46,6 +47,8 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 		if rlb.RequestedAt != 0 {
 			run.RequestedAt = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
 		}
+		run.RetryOf = rlb.RetryOf
+		run.TraceID = rlb.TraceID
 		timeSetter(run)
 		r.byRunID[ridStr] = run
 		tidStr := rlb.Task.ID.String()
@@ -55,6 +58,10 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 
 	timeSetter(existingRun)
 	existingRun.Status = status.String()
+	if rlb.TraceID != "" {
+		// A restarted run is traced anew.
+		existingRun.TraceID = rlb.TraceID
+	}
 	return nil
 }
 
@@ -93,6 +100,7 @@ func (r *runReaderWriter) ListRuns(ctx context.Context, runFilter platform.RunFi
 		afterID = runFilter.After.String()
 	}
 	runs := make([]*platform.Run, 0, len(ex))
+	skipped := 0
 	for _, r := range ex {
 		// Skip this entry if we would be filtering it out.
 		if runFilter.BeforeTime != "" && runFilter.BeforeTime <= r.ScheduledFor {
@@ -104,6 +112,13 @@ func (r *runReaderWriter) ListRuns(ctx context.Context, runFilter platform.RunFi
 		if r.ID.String() <= afterID {
 			continue
 		}
+		if runFilter.Status != "" && runFilter.Status != r.Status {
+			continue
+		}
+		if skipped < runFilter.Offset {
+			skipped++
+			continue
+		}
 
 		// Copy the element, to avoid a data race if the original Run is modified in UpdateRunState or AddRunLog.
 		r := *r
@@ -142,7 +157,7 @@ func (r *runReaderWriter) ListLogs(ctx context.Context, logFilter platform.LogFi
 		if !ok {
 			return nil, ErrRunNotFound
 		}
-		return []platform.Log{run.Log}, nil
+		return []platform.Log{SkipLogLines(run.Log, logFilter.Offset)}, nil
 	}
 
 	logs := []platform.Log{}
@@ -151,3 +166,96 @@ func (r *runReaderWriter) ListLogs(ctx context.Context, logFilter platform.LogFi
 	}
 	return logs, nil
 }
+
+func (r *runReaderWriter) PruneRuns(ctx context.Context, before int64, keep int, keepByTask map[platform.ID]int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := ""
+	if before != 0 {
+		cutoff = time.Unix(before, 0).UTC().Format(time.RFC3339)
+	}
+
+	pruned := 0
+	for tid, runs := range r.byTaskID {
+		cutoff, keep := cutoff, keep
+		if id, err := platform.IDFromString(tid); err == nil {
+			if n, ok := keepByTask[*id]; ok {
+				cutoff, keep = "", n
+			}
+		}
+
+		// Latest scheduled first, so that the first keep finished runs are retained.
+		sorted := make([]*platform.Run, len(runs))
+		copy(sorted, runs)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ScheduledFor > sorted[j].ScheduledFor })
+
+		remove := make(map[*platform.Run]bool)
+		finished := 0
+		for _, run := range sorted {
+			if !IsFinishedRunStatus(run.Status) {
+				continue
+			}
+			finished++
+			if (cutoff != "" && run.ScheduledFor < cutoff) || (keep > 0 && finished > keep) {
+				remove[run] = true
+			}
+		}
+		if len(remove) == 0 {
+			continue
+		}
+
+		kept := runs[:0]
+		for _, run := range runs {
+			if remove[run] {
+				delete(r.byRunID, run.ID.String())
+				continue
+			}
+			kept = append(kept, run)
+		}
+		if len(kept) == 0 {
+			delete(r.byTaskID, tid)
+		} else {
+			r.byTaskID[tid] = kept
+		}
+		pruned += len(remove)
+	}
+	return pruned, nil
+}
+
+func (r *runReaderWriter) RetainedTaskIDs(ctx context.Context) ([]platform.ID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]platform.ID, 0, len(r.byTaskID))
+	for tid := range r.byTaskID {
+		id, err := platform.IDFromString(tid)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, *id)
+	}
+	return ids, nil
+}
+
+func (r *runReaderWriter) PurgeTask(ctx context.Context, taskID platform.ID) (PurgeResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res PurgeResult
+	tid := taskID.String()
+	for _, run := range r.byTaskID[tid] {
+		delete(r.byRunID, run.ID.String())
+		res.Records++
+		res.Bytes += runSize(run)
+	}
+	delete(r.byTaskID, tid)
+	return res, nil
+}
+
+// runSize returns the approximate size of run in memory.
+func runSize(run *platform.Run) int64 {
+	const idSize = 8
+	return int64(2*idSize + len(run.Status) + len(run.ScheduledFor) + len(run.StartedAt) +
+		len(run.FinishedAt) + len(run.RequestedAt) + len(run.Log))
+}
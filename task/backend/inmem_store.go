@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/snowflake"
@@ -12,6 +14,9 @@ import (
 )
 
 var _ Store = (*inmem)(nil)
+var _ TemplateStore = (*inmem)(nil)
+var _ TaskPurger = (*inmem)(nil)
+var _ TaskWatcher = (*inmem)(nil)
 
 // inmem is an in-memory task store.
 type inmem struct {
@@ -24,15 +29,67 @@ type inmem struct {
 	tasks []StoreTask
 
 	meta map[platform.ID]StoreTaskMeta
+
+	versions map[platform.ID][]TaskVersion
+
+	runHistory map[platform.ID]TaskRunHistory
+
+	// Org ID -> audit log, oldest entry first.
+	audit map[platform.ID][]AuditEntry
+
+	// Idempotency key -> ID of the task created with it. The task may since have been deleted.
+	idempotencyKeys map[idempotencyKey]platform.ID
+
+	// Templates ordered by ID, and template ID -> task ID -> instance.
+	templates         []TaskTemplate
+	templateInstances map[platform.ID]map[platform.ID]TemplateInstance
+
+	leases map[platform.ID]TaskLease
+
+	// Lease owner -> Unix timestamp of keep-alive expiration.
+	leaseOwners map[string]int64
+
+	leader TaskLease
+
+	// Reports changes to tasks to WatchTasks callers.
+	changes TaskChangeFeed
+}
+
+// idempotencyKey is a CreateTaskRequest.IdempotencyKey, scoped to the org the task was created in.
+type idempotencyKey struct {
+	org platform.ID
+	key string
+}
+
+// InMemOption configures the store returned by NewInMemStore.
+type InMemOption func(*inmem)
+
+// WithInMemIDGenerator sets the generator of the IDs of tasks and runs in the in-memory store.
+// It defaults to a snowflake generator; tests that need reproducible IDs use a sequential one.
+func WithInMemIDGenerator(g platform.IDGenerator) InMemOption {
+	return func(s *inmem) {
+		s.idgen = g
+	}
 }
 
 // NewInMemStore returns a new in-memory store.
 // This store is not designed to be efficient, it is here for testing purposes.
-func NewInMemStore() Store {
-	return &inmem{
-		idgen: snowflake.NewIDGenerator(),
-		meta:  map[platform.ID]StoreTaskMeta{},
-	}
+func NewInMemStore(opts ...InMemOption) Store {
+	s := &inmem{
+		idgen:             snowflake.NewIDGenerator(),
+		meta:              map[platform.ID]StoreTaskMeta{},
+		versions:          map[platform.ID][]TaskVersion{},
+		runHistory:        map[platform.ID]TaskRunHistory{},
+		audit:             map[platform.ID][]AuditEntry{},
+		idempotencyKeys:   map[idempotencyKey]platform.ID{},
+		templateInstances: map[platform.ID]map[platform.ID]TemplateInstance{},
+		leases:            map[platform.ID]TaskLease{},
+		leaseOwners:       map[string]int64{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.ID, error) {
@@ -42,23 +99,25 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 	}
 
 	id := s.idgen.ID()
-
-	task := StoreTask{
-		ID: id,
-
-		Org:  req.Org,
-		User: req.User,
-
-		Name: o.Name,
-
-		Script: req.Script,
-	}
+	created := NewStoreTaskWithMeta(id, req, o)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.tasks = append(s.tasks, task)
-	s.meta[id] = NewStoreTaskMeta(req, o)
+	if req.IdempotencyKey != "" {
+		k := idempotencyKey{org: req.Org, key: req.IdempotencyKey}
+		if existing, ok := s.idempotencyKeys[k]; ok {
+			if _, ok := s.meta[existing]; ok {
+				return existing, ErrTaskAlreadyCreated
+			}
+		}
+		s.idempotencyKeys[k] = id
+	}
+
+	s.tasks = append(s.tasks, created.Task)
+	s.meta[id] = created.Meta
+	s.versions[id] = []TaskVersion{{Version: 1, Script: req.Script, CreatedAt: time.Now().Unix()}}
+	s.changes.Publish(TaskChange{TaskID: id, Created: true})
 
 	return id, nil
 }
@@ -75,7 +134,7 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	found := false
+	found, scriptChanged := false, false
 	for n, t := range s.tasks {
 		if t.ID != req.ID {
 			continue
@@ -83,15 +142,34 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 		found = true
 
 		res.OldScript = t.Script
-		if req.Script == "" {
-			op, err = options.FromScript(t.Script)
+		script, err := req.NewScript(t.Script)
+		if err != nil {
+			return res, err
+		}
+		if script != req.Script {
+			op, err = options.FromScript(script)
 			if err != nil {
 				return res, err
 			}
-		} else {
-			t.Script = req.Script
 		}
+		if script != t.Script {
+			s.versions[req.ID] = AppendTaskVersion(s.versions[req.ID], t.Script, script, time.Now().Unix())
+			scriptChanged = true
+		}
+		t.Script = script
 		t.Name = op.Name
+		t.Options = &op
+
+		if req.Labels != nil {
+			t.Labels = copyLabels(req.Labels)
+		}
+
+		if req.Org.Valid() {
+			t.Org = req.Org
+		}
+		if req.User.Valid() {
+			t.User = req.User
+		}
 
 		s.tasks[n] = t
 		res.NewTask = t
@@ -107,16 +185,51 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 	}
 	res.OldStatus = TaskStatus(stm.Status)
 
-	if req.Status != "" {
-		// Changing the status.
-		stm.Status = string(req.Status)
-		s.meta[req.ID] = stm
+	if scriptChanged {
+		stm.ApplyOptions(op)
 	}
+	if req.MaxConcurrency > 0 {
+		stm.MaxConcurrency = req.MaxConcurrency
+	}
+	stm.ApplyStatus(req)
+	stm.ApplyNotification(req)
+	s.meta[req.ID] = stm
 	res.NewMeta = stm
+	s.changes.Publish(TaskChange{TaskID: req.ID})
 
 	return res, nil
 }
 
+func (s *inmem) EnableTasks(_ context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ids, TaskActive), nil
+}
+
+func (s *inmem) DisableTasks(_ context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ids, TaskInactive), nil
+}
+
+// setTasksStatus sets the status of the tasks with the given IDs, and returns the IDs of those that changed.
+func (s *inmem) setTasksStatus(ids []platform.ID, status TaskStatus) []platform.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []platform.ID
+	for _, id := range ids {
+		stm, ok := s.meta[id]
+		if !ok || stm.Status == string(status) || stm.Status == string(TaskDeleted) {
+			continue
+		}
+		stm.ApplyStatus(UpdateTaskRequest{ID: id, Status: status})
+		s.meta[id] = stm
+		changed = append(changed, id)
+	}
+
+	for _, id := range changed {
+		s.changes.Publish(TaskChange{TaskID: id})
+	}
+	return changed
+}
+
 func (s *inmem) ListTasks(_ context.Context, params TaskSearchParams) ([]StoreTaskWithMeta, error) {
 	if params.Org.Valid() && params.User.Valid() {
 		return nil, errors.New("ListTasks: org and user filters are mutually exclusive")
@@ -159,6 +272,9 @@ func (s *inmem) ListTasks(_ context.Context, params TaskSearchParams) ([]StoreTa
 		if user.Valid() && user != t.User {
 			continue
 		}
+		if !LabelsMatch(t.Labels, params.Labels) {
+			continue
+		}
 
 		out = append(out, StoreTaskWithMeta{Task: t})
 		if len(out) >= lim {
@@ -246,9 +362,305 @@ func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err
 	// Delete entry from slice.
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
 	delete(s.meta, id)
+	delete(s.leases, id)
+	delete(s.versions, id)
+	delete(s.runHistory, id)
+	s.changes.Publish(TaskChange{TaskID: id, Deleted: true})
 	return true, nil
 }
 
+func (s *inmem) RetainedTaskIDs(_ context.Context) ([]platform.ID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[platform.ID]bool)
+	var ids []platform.ID
+	for _, id := range s.idempotencyKeys {
+		if _, ok := s.meta[id]; ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *inmem) PurgeTask(_ context.Context, taskID platform.ID) (PurgeResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res PurgeResult
+	if _, ok := s.meta[taskID]; ok {
+		// The task still exists, so its idempotency key is still in use.
+		return res, nil
+	}
+	for k, id := range s.idempotencyKeys {
+		if id == taskID {
+			delete(s.idempotencyKeys, k)
+			res.Records++
+			res.Bytes += int64(len(k.key) + 16)
+		}
+	}
+	return res, nil
+}
+
+var _ TaskImporter = (*inmem)(nil)
+
+func (s *inmem) ImportTask(_ context.Context, task StoreTask, meta StoreTaskMeta, versions []TaskVersion) error {
+	if err := StoreValidator.ImportArgs(task); err != nil {
+		return err
+	}
+	task.Labels = copyLabels(task.Labels)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep tasks ordered by ID, for paging.
+	i := sort.Search(len(s.tasks), func(i int) bool { return s.tasks[i].ID >= task.ID })
+	created := i == len(s.tasks) || s.tasks[i].ID != task.ID
+	if created {
+		s.tasks = append(s.tasks, StoreTask{})
+		copy(s.tasks[i+1:], s.tasks[i:])
+	}
+	s.tasks[i] = task
+	s.meta[task.ID] = meta
+	s.versions[task.ID] = append([]TaskVersion(nil), versions...)
+	s.changes.Publish(TaskChange{TaskID: task.ID, Created: created})
+	return nil
+}
+
+func (s *inmem) ListTaskVersions(_ context.Context, id platform.ID) ([]TaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.tasks {
+		if t.ID != id {
+			continue
+		}
+
+		versions := s.versions[id]
+		if len(versions) == 0 {
+			return []TaskVersion{{Version: 1, Script: t.Script}}, nil
+		}
+		return append([]TaskVersion(nil), versions...), nil
+	}
+
+	return nil, ErrTaskNotFound
+}
+
+func (s *inmem) RecordRunOutcome(_ context.Context, taskID platform.ID, o RunOutcome) error {
+	_, err := s.recordRunOutcome(taskID, o, false)
+	return err
+}
+
+func (s *inmem) CompleteRun(_ context.Context, taskID platform.ID, o RunOutcome) error {
+	statusChanged, err := s.recordRunOutcome(taskID, o, true)
+	if err != nil {
+		return err
+	}
+	if statusChanged {
+		// A one-shot task was disabled after its run.
+		s.changes.Publish(TaskChange{TaskID: taskID})
+	}
+	return nil
+}
+
+// recordRunOutcome records o in the meta and run history of the task with the given ID,
+// first finishing the run o.RunID if finish is true. It reports whether finishing the run changed the task's status.
+func (s *inmem) recordRunOutcome(taskID platform.ID, o RunOutcome, finish bool) (statusChanged bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return false, ErrTaskNotFound
+	}
+	if finish {
+		oldStatus := stm.Status
+		if !stm.FinishRun(o.RunID) {
+			return false, errors.New("run not found")
+		}
+		statusChanged = stm.Status != oldStatus
+	}
+	stm.RecordRunOutcome(o)
+	s.meta[taskID] = stm
+
+	h := s.runHistory[taskID]
+	h.Add(o)
+	h.DropSucceededThrough(stm.LatestCompleted)
+	s.runHistory[taskID] = h
+	return statusChanged, nil
+}
+
+func (s *inmem) FindTaskStats(_ context.Context, taskID platform.ID) (*TaskStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	stats := s.runHistory[taskID].Stats()
+	return &stats, nil
+}
+
+func (s *inmem) RunSucceeded(_ context.Context, taskID platform.ID, now int64) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return false, ErrTaskNotFound
+	}
+
+	return s.runHistory[taskID].HasSucceeded(now), nil
+}
+
+func (s *inmem) AppendAuditEntry(_ context.Context, e AuditEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.audit[e.Org] = append(s.audit[e.Org], e)
+	return nil
+}
+
+func (s *inmem) ListAuditEntries(_ context.Context, orgID platform.ID, params AuditSearchParams) ([]AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := params.EffectiveLimit()
+	var entries []AuditEntry
+	for _, e := range s.audit[orgID] {
+		if !params.Match(e) {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) == limit {
+			break
+		}
+	}
+	return entries, nil
+}
+
+func (s *inmem) CreateTemplate(_ context.Context, t TaskTemplate) (platform.ID, error) {
+	if err := t.Validate(); err != nil {
+		return platform.InvalidID(), err
+	}
+
+	t.ID = s.idgen.ID()
+	t.Version = 1
+	t.Params = append([]TemplateParam(nil), t.Params...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.templates = append(s.templates, t)
+	return t.ID, nil
+}
+
+func (s *inmem) UpdateTemplate(_ context.Context, t TaskTemplate) (TaskTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, old := range s.templates {
+		if old.ID != t.ID {
+			continue
+		}
+
+		t.Org = old.Org
+		if err := t.Validate(); err != nil {
+			return TaskTemplate{}, err
+		}
+		t.Version = old.Version + 1
+		t.Params = append([]TemplateParam(nil), t.Params...)
+		s.templates[i] = t
+		return t, nil
+	}
+	return TaskTemplate{}, ErrTemplateNotFound
+}
+
+func (s *inmem) FindTemplateByID(_ context.Context, id platform.ID) (*TaskTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.templates {
+		if t.ID == id {
+			t.Params = append([]TemplateParam(nil), t.Params...)
+			return &t, nil
+		}
+	}
+	return nil, ErrTemplateNotFound
+}
+
+func (s *inmem) ListTemplates(_ context.Context, orgID platform.ID) ([]TaskTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []TaskTemplate
+	for _, t := range s.templates {
+		if t.Org == orgID {
+			t.Params = append([]TemplateParam(nil), t.Params...)
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (s *inmem) DeleteTemplate(_ context.Context, id platform.ID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.templates {
+		if t.ID == id {
+			s.templates = append(s.templates[:i], s.templates[i+1:]...)
+			delete(s.templateInstances, id)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *inmem) PutTemplateInstance(_ context.Context, inst TemplateInstance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for _, t := range s.templates {
+		if t.ID == inst.TemplateID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrTemplateNotFound
+	}
+
+	insts := s.templateInstances[inst.TemplateID]
+	if insts == nil {
+		insts = make(map[platform.ID]TemplateInstance)
+		s.templateInstances[inst.TemplateID] = insts
+	}
+	inst.Params = copyLabels(inst.Params)
+	insts[inst.TaskID] = inst
+	return nil
+}
+
+func (s *inmem) ListTemplateInstances(_ context.Context, templateID platform.ID) ([]TemplateInstance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]TemplateInstance, 0, len(s.templateInstances[templateID]))
+	for _, inst := range s.templateInstances[templateID] {
+		inst.Params = copyLabels(inst.Params)
+		out = append(out, inst)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TaskID < out[j].TaskID })
+	return out, nil
+}
+
 func (s *inmem) Close() error {
 	return nil
 }
@@ -285,6 +697,7 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 		return errors.New("taskRunner not found")
 	}
 
+	oldStatus := stm.Status
 	if !stm.FinishRun(runID) {
 		return errors.New("run not found")
 	}
@@ -293,6 +706,10 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	s.meta[taskID] = stm
 	s.mu.Unlock()
 
+	if stm.Status != oldStatus {
+		// A one-shot task was disabled after its run.
+		s.changes.Publish(TaskChange{TaskID: taskID})
+	}
 	return nil
 }
 
@@ -314,6 +731,24 @@ func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return mr, nil
 }
 
+func (s *inmem) RetryRun(_ context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*StoreTaskMetaManualRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return nil, errors.New("task not found")
+	}
+
+	if err := stm.RetryRun(scheduledFor, requestedAt, runID, func() (platform.ID, error) { return s.idgen.ID(), nil }); err != nil {
+		return nil, err
+	}
+
+	s.meta[taskID] = stm
+	mr := stm.ManualRuns[len(stm.ManualRuns)-1]
+	return mr, nil
+}
+
 func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) platform.ID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -341,7 +776,11 @@ func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) pl
 	default:
 	}
 	for i := range deletingTasks {
-		delete(s.meta, s.tasks[i].ID)
+		delete(s.meta, deletingTasks[i])
+		delete(s.leases, deletingTasks[i])
+		delete(s.versions, deletingTasks[i])
+		delete(s.runHistory, deletingTasks[i])
+		s.changes.Publish(TaskChange{TaskID: deletingTasks[i], Deleted: true})
 	}
 	s.tasks = newTasks
 	return nil
@@ -364,3 +803,103 @@ func (s *inmem) DeleteOrg(ctx context.Context, id platform.ID) error {
 func (s *inmem) DeleteUser(ctx context.Context, id platform.ID) error {
 	return s.delete(ctx, id, getUser)
 }
+
+// WatchTasks reports changes to tasks in s.
+func (s *inmem) WatchTasks(ctx context.Context) <-chan TaskChange {
+	return s.changes.WatchTasks(ctx)
+}
+
+func (s *inmem) AcquireTaskLease(_ context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.meta[taskID]; !ok {
+		return ErrTaskNotFound
+	}
+
+	if l, ok := s.leases[taskID]; ok && l.Owner != owner && !l.Expired(now) {
+		return ErrLeaseHeld
+	}
+
+	s.leases[taskID] = TaskLease{TaskID: taskID, Owner: owner, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (s *inmem) ReleaseTaskLease(_ context.Context, taskID platform.ID, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.leases[taskID]; ok && l.Owner == owner {
+		delete(s.leases, taskID)
+	}
+	return nil
+}
+
+func (s *inmem) ListTaskLeases(_ context.Context) ([]TaskLease, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]TaskLease, 0, len(s.leases))
+	for _, l := range s.leases {
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+func (s *inmem) KeepAliveLeaseOwner(_ context.Context, owner string, expiresAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.leaseOwners[owner] = expiresAt
+	return nil
+}
+
+func (s *inmem) ListLeaseOwners(_ context.Context, now int64) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var owners []string
+	for owner, expiresAt := range s.leaseOwners {
+		if expiresAt > now {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
+func (s *inmem) AcquireLeaderLease(_ context.Context, owner string, now, expiresAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leader.Owner != "" && s.leader.Owner != owner && !s.leader.Expired(now) {
+		return ErrLeaseHeld
+	}
+
+	s.leader = TaskLease{Owner: owner, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (s *inmem) ReleaseLeaderLease(_ context.Context, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.leader.Owner == owner {
+		s.leader = TaskLease{}
+	}
+	return nil
+}
+
+// copyLabels returns a copy of labels, so that the caller's map is not shared with the store.
+// An empty map is stored as nil.
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"errors"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -12,6 +13,31 @@ import (
 
 // This file contains helper methods for the StoreTaskMeta type defined in protobuf.
 
+// NeverDue is the due time reported for a task that has no more runs to schedule,
+// such as a one-shot task whose run has already been created.
+const NeverDue int64 = math.MaxInt64
+
+// OneShotCompletedReason is the disabled reason recorded when a one-shot task is disabled after its run finishes.
+const OneShotCompletedReason = "one-shot task completed"
+
+// NewStoreTaskWithMeta returns the task with the given ID and its meta, as a store records them when creating the task from req.
+// The options o are those returned by StoreValidator.CreateArgs(req).
+// Having just created a task, callers can use it in place of reading the task back from the store.
+func NewStoreTaskWithMeta(id platform.ID, req CreateTaskRequest, o options.Options) StoreTaskWithMeta {
+	return StoreTaskWithMeta{
+		Task: StoreTask{
+			ID:      id,
+			Org:     req.Org,
+			User:    req.User,
+			Name:    o.Name,
+			Script:  req.Script,
+			Labels:  copyLabels(req.Labels),
+			Options: &o,
+		},
+		Meta: NewStoreTaskMeta(req, o),
+	}
+}
+
 // NewStoreTaskMeta returns a new StoreTaskMeta based on the given request and parsed options.
 func NewStoreTaskMeta(req CreateTaskRequest, o options.Options) StoreTaskMeta {
 	stm := StoreTaskMeta{
@@ -26,6 +52,14 @@ func NewStoreTaskMeta(req CreateTaskRequest, o options.Options) StoreTaskMeta {
 		stm.Status = string(DefaultTaskStatus)
 	}
 
+	if o.Once {
+		stm.RunAt = oneShotRunAt(o, stm.LatestCompleted)
+		if stm.LatestCompleted >= stm.RunAt {
+			// A time in the past runs as soon as possible, rather than counting as already completed.
+			stm.LatestCompleted = stm.RunAt - 1
+		}
+	}
+
 	if o.Every != 0 {
 		t := time.Unix(stm.LatestCompleted, 0).Truncate(o.Every).Unix()
 		if t == stm.LatestCompleted {
@@ -40,10 +74,90 @@ func NewStoreTaskMeta(req CreateTaskRequest, o options.Options) StoreTaskMeta {
 	return stm
 }
 
+// ApplyOptions updates the concurrency and schedule of stm from the options of an updated task script.
+// The latest completed time is kept, so the task resumes on its new schedule from where it left off.
+// A one-shot task keeps its run time unless the at option sets a new one.
+func (stm *StoreTaskMeta) ApplyOptions(o options.Options) {
+	stm.MaxConcurrency = int32(o.Concurrency)
+	stm.EffectiveCron = o.EffectiveCronString()
+	stm.Offset = int32(o.Offset / time.Second)
+
+	switch {
+	case !o.Once:
+		stm.RunAt = 0
+	case !o.At.IsZero() || stm.RunAt == 0:
+		stm.RunAt = oneShotRunAt(o, stm.LatestCompleted)
+	}
+}
+
+// ApplyStatus updates the status of stm as requested by the update req, if req sets a status.
+// Deleting the task records the time it was deleted and the status it had, so that it can be restored;
+// setting any other status clears them.
+func (stm *StoreTaskMeta) ApplyStatus(req UpdateTaskRequest) {
+	if req.Status == "" {
+		return
+	}
+
+	if req.Status == TaskDeleted {
+		if stm.Status != string(TaskDeleted) {
+			stm.DeletedStatus = stm.Status
+		}
+		stm.DeletedAt = req.DeletedAt
+	} else {
+		stm.DeletedAt = 0
+		stm.DeletedStatus = ""
+	}
+	stm.Status = string(req.Status)
+	stm.DisabledReason = req.DisabledReason
+}
+
+// oneShotRunAt returns the Unix timestamp of the run of the one-shot task with options o:
+// the at option if it is set, or else the second after latestCompleted, so the run is due as soon as possible.
+func oneShotRunAt(o options.Options, latestCompleted int64) int64 {
+	if !o.At.IsZero() {
+		return o.At.Unix()
+	}
+	return latestCompleted + 1
+}
+
+// IsOneShot reports whether stm belongs to a one-shot task, which runs once at RunAt instead of on a schedule.
+func (stm *StoreTaskMeta) IsOneShot() bool {
+	return stm.RunAt != 0
+}
+
+// oneShotPending reports whether the run of a one-shot task has yet to be created.
+func (stm *StoreTaskMeta) oneShotPending() bool {
+	if stm.LatestCompleted >= stm.RunAt {
+		return false
+	}
+	for _, cr := range stm.CurrentlyRunning {
+		if cr.Now >= stm.RunAt && cr.RangeStart == 0 && cr.RangeEnd == 0 && cr.RequestedAt == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// OffsetDuration returns the task's offset: how long after each scheduled time the run for that time is due.
+// The scheduler adds it to each schedule time to find when a run is due, so a run covering data up to its scheduled time
+// starts only once late-arriving data has had the offset to arrive.
+func (stm *StoreTaskMeta) OffsetDuration() time.Duration {
+	return time.Duration(stm.Offset) * time.Second
+}
+
+// RecordRunOutcome sets the last run status, error, and duration of stm from o, the outcome of the task's latest executed run.
+func (stm *StoreTaskMeta) RecordRunOutcome(o RunOutcome) {
+	stm.LastRunStatus = o.Status.String()
+	stm.LastRunError = o.Error
+	stm.LastRunDuration = int64(o.Duration())
+}
+
 // FinishRun removes the run matching runID from m's CurrentlyRunning slice,
 // and if that run's Now value is greater than m's LatestCompleted value,
 // updates the value of LatestCompleted to the run's Now value.
 //
+// If the finished run was the run of a one-shot task, the task is marked inactive with OneShotCompletedReason.
+//
 // If runID matched a run, FinishRun returns true. Otherwise it returns false.
 func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 	for i, runner := range stm.CurrentlyRunning {
@@ -59,6 +173,10 @@ func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 			if runner.Now > stm.LatestCompleted {
 				stm.LatestCompleted = runner.Now
 			}
+			if stm.IsOneShot() && runner.Now >= stm.RunAt {
+				stm.Status = string(TaskInactive)
+				stm.DisabledReason = OneShotCompletedReason
+			}
 		} else {
 			// It was a requested run. Check if we need to update a latest completed.
 			for _, q := range stm.ManualRuns {
@@ -77,10 +195,48 @@ func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 	return false
 }
 
+// Checkpoint is how far the scheduled runs of a task have progressed, as recorded in its meta.
+// Runs are created and finished by updating the meta in a single transaction,
+// so the checkpoint read after a restart tells exactly which windows still have to run:
+// those in progress are resumed, and those after LatestScheduled are yet to be created.
+type Checkpoint struct {
+	// Completed is the Unix timestamp through which every scheduled window of the task has finished.
+	// It is the task's latest completed window, unless an earlier window is still in progress.
+	Completed int64
+
+	// LatestScheduled is the Unix timestamp of the latest window a run has been created for, finished or not.
+	LatestScheduled int64
+
+	// InProgress are the Unix timestamps of the scheduled windows whose runs have been created but not finished, earliest first.
+	// Runs requested manually are not included.
+	InProgress []int64
+}
+
+// Checkpoint returns the checkpoint of the scheduled runs recorded in stm.
+func (stm *StoreTaskMeta) Checkpoint() Checkpoint {
+	cp := Checkpoint{Completed: stm.LatestCompleted, LatestScheduled: stm.LatestCompleted}
+	for _, cr := range stm.CurrentlyRunning {
+		if cr.RangeStart != 0 || cr.RangeEnd != 0 || cr.RequestedAt != 0 {
+			continue
+		}
+
+		cp.InProgress = append(cp.InProgress, cr.Now)
+		if cr.Now <= cp.Completed {
+			cp.Completed = cr.Now - 1
+		}
+		if cr.Now > cp.LatestScheduled {
+			cp.LatestScheduled = cr.Now
+		}
+	}
+	sort.Slice(cp.InProgress, func(i, j int) bool { return cp.InProgress[i] < cp.InProgress[j] })
+	return cp
+}
+
 // CreateNextRun attempts to update stm's CurrentlyRunning slice with a new run.
 // The new run's now is assigned the earliest possible time according to stm.EffectiveCron,
 // that is later than any in-progress run and stm's LatestCompleted timestamp.
 // If the run's now would be later than the passed-in now, CreateNextRun returns a RunNotYetDueError.
+// For a one-shot task, the only run's now is stm.RunAt, and once that run is created, the error's DueAt is NeverDue.
 //
 // makeID is a function provided by the caller to create an ID, in case we can create a run.
 // Because a StoreTaskMeta doesn't know the ID of the task it belongs to, it never sets RunCreation.Created.TaskID.
@@ -89,9 +245,13 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		return RunCreation{}, errors.New("cannot create next run when max concurrency already reached")
 	}
 
+	if stm.IsOneShot() {
+		return stm.createOneShotRun(now, makeID)
+	}
+
 	// Not calling stm.DueAt here because we reuse sch.
 	// We can definitely optimize (minimize) cron parsing at a later point in time.
-	sch, err := cron.Parse(stm.EffectiveCron)
+	sch, err := options.ParseCron(stm.EffectiveCron)
 	if err != nil {
 		return RunCreation{}, err
 	}
@@ -126,11 +286,43 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 
 	return RunCreation{
 		Created: QueuedRun{
-			RunID: id,
-			Now:   nextScheduledUnix,
+			RunID:        id,
+			Now:          nextScheduledUnix,
+			Notification: stm.runNotification(),
 		},
-		NextDue:  sch.Next(nextScheduled).Unix() + int64(stm.Offset),
-		HasQueue: len(stm.ManualRuns) > 0,
+		NextDue:    sch.Next(nextScheduled).Unix() + int64(stm.Offset),
+		HasQueue:   len(stm.ManualRuns) > 0,
+		QueuedRuns: len(stm.ManualRuns),
+	}, nil
+}
+
+// createOneShotRun creates the only run of a one-shot task, if it is due and has not been created yet.
+func (stm *StoreTaskMeta) createOneShotRun(now int64, makeID func() (platform.ID, error)) (RunCreation, error) {
+	if !stm.oneShotPending() {
+		return RunCreation{}, RunNotYetDueError{DueAt: NeverDue}
+	}
+	if stm.RunAt > now {
+		return RunCreation{}, RunNotYetDueError{DueAt: stm.RunAt}
+	}
+
+	id, err := makeID()
+	if err != nil {
+		return RunCreation{}, err
+	}
+
+	stm.CurrentlyRunning = append(stm.CurrentlyRunning, &StoreTaskMetaRun{
+		Now:   stm.RunAt,
+		Try:   1,
+		RunID: uint64(id),
+	})
+
+	return RunCreation{
+		Created: QueuedRun{
+			RunID:        id,
+			Now:          stm.RunAt,
+			Notification: stm.runNotification(),
+		},
+		NextDue: NeverDue,
 	}, nil
 }
 
@@ -174,6 +366,7 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 		RangeStart:  q.Start,
 		RangeEnd:    q.End,
 		RequestedAt: q.RequestedAt,
+		RetryOf:     q.RetryOf,
 	})
 
 	if runNow >= q.End {
@@ -183,19 +376,30 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 
 	return RunCreation{
 		Created: QueuedRun{
-			RunID:       id,
-			Now:         runNow,
-			RequestedAt: q.RequestedAt,
+			RunID:        id,
+			Now:          runNow,
+			RequestedAt:  q.RequestedAt,
+			RetryOf:      platform.ID(q.RetryOf),
+			Notification: stm.runNotification(),
 		},
-		NextDue:  nextDue,
-		HasQueue: len(stm.ManualRuns) > 0,
+		NextDue:    nextDue,
+		HasQueue:   len(stm.ManualRuns) > 0,
+		QueuedRuns: len(stm.ManualRuns),
 	}, nil
 }
 
 // NextDueRun returns the Unix timestamp of when the next call to CreateNextRun will be ready.
 // The returned timestamp reflects the task's delay, so it does not necessarily exactly match the schedule time.
+// For a one-shot task, it is stm.RunAt, or NeverDue once the task's run has been created.
 func (stm *StoreTaskMeta) NextDueRun() (int64, error) {
-	sch, err := cron.Parse(stm.EffectiveCron)
+	if stm.IsOneShot() {
+		if stm.oneShotPending() {
+			return stm.RunAt, nil
+		}
+		return NeverDue, nil
+	}
+
+	sch, err := options.ParseCron(stm.EffectiveCron)
 	if err != nil {
 		return 0, err
 	}
@@ -212,13 +416,44 @@ func (stm *StoreTaskMeta) NextDueRun() (int64, error) {
 	return sch.Next(time.Unix(latest, 0)).Unix() + int64(stm.Offset), nil
 }
 
+// NextScheduledRuns returns the Unix timestamps of the next n scheduled runs following stm's LatestCompleted value.
+// Currently running and manually queued runs are not considered,
+// and the returned timestamps are schedule times, so they do not include the task's offset.
+// A one-shot task has at most one scheduled run, at stm.RunAt, until that run completes.
+func (stm *StoreTaskMeta) NextScheduledRuns(n int) ([]int64, error) {
+	if stm.IsOneShot() {
+		if n < 1 || stm.LatestCompleted >= stm.RunAt {
+			return []int64{}, nil
+		}
+		return []int64{stm.RunAt}, nil
+	}
+
+	sch, err := options.ParseCron(stm.EffectiveCron)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]int64, 0, n)
+	t := time.Unix(stm.LatestCompleted, 0)
+	for i := 0; i < n; i++ {
+		t = sch.Next(t)
+		runs = append(runs, t.Unix())
+	}
+	return runs, nil
+}
+
 // ManuallyRunTimeRange requests a manual run covering the approximate range specified by the Unix timestamps start and end.
 // More specifically, it requests runs scheduled no earlier than start, but possibly later than start,
 // if start does not land on the task's schedule; and as late as, but not necessarily equal to, end.
 // requestedAt is the Unix timestamp indicating when this run range was requested.
 //
 // If adding the range would exceed the queue size, ManuallyRunTimeRange returns ErrManualQueueFull.
+// A one-shot task has no schedule to run a range of, so ManuallyRunTimeRange returns ErrOneShotManualRun for it.
 func (stm *StoreTaskMeta) ManuallyRunTimeRange(start, end, requestedAt int64, makeID func() (platform.ID, error)) error {
+	if stm.IsOneShot() {
+		return ErrOneShotManualRun
+	}
+
 	// Arbitrarily chosen upper limit that seems unlikely to be reached except in pathological cases.
 	const maxQueueSize = 32
 	if len(stm.ManualRuns) >= maxQueueSize {
@@ -252,6 +487,17 @@ func (stm *StoreTaskMeta) ManuallyRunTimeRange(start, end, requestedAt int64, ma
 	return nil
 }
 
+// RetryRun requests a manual run for the single schedule scheduledFor, as a retry of the earlier run with ID retryOf.
+// It is ManuallyRunTimeRange for the range from scheduledFor to scheduledFor,
+// except that the queued manual run, and the run created from it, record retryOf in their RetryOf fields.
+func (stm *StoreTaskMeta) RetryRun(scheduledFor, requestedAt int64, retryOf platform.ID, makeID func() (platform.ID, error)) error {
+	if err := stm.ManuallyRunTimeRange(scheduledFor, scheduledFor, requestedAt, makeID); err != nil {
+		return err
+	}
+	stm.ManualRuns[len(stm.ManualRuns)-1].RetryOf = uint64(retryOf)
+	return nil
+}
+
 // Equal returns true if all of stm's fields compare equal to other.
 // Note that this method operates on values, unlike the other methods which operate on pointers.
 //
@@ -262,6 +508,9 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		stm.Status != other.Status ||
 		stm.EffectiveCron != other.EffectiveCron ||
 		stm.Offset != other.Offset ||
+		stm.RunAt != other.RunAt ||
+		stm.DeletedAt != other.DeletedAt ||
+		stm.DeletedStatus != other.DeletedStatus ||
 		len(stm.CurrentlyRunning) != len(other.CurrentlyRunning) ||
 		len(stm.ManualRuns) != len(other.ManualRuns) {
 		return false
@@ -275,7 +524,8 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 			s.RunID != o.RunID ||
 			s.RangeStart != o.RangeStart ||
 			s.RangeEnd != o.RangeEnd ||
-			s.RequestedAt != o.RequestedAt {
+			s.RequestedAt != o.RequestedAt ||
+			s.RetryOf != o.RetryOf {
 			return false
 		}
 	}
@@ -286,7 +536,8 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		if s.Start != o.Start ||
 			s.End != o.End ||
 			s.LatestCompleted != o.LatestCompleted ||
-			s.RequestedAt != o.RequestedAt {
+			s.RequestedAt != o.RequestedAt ||
+			s.RetryOf != o.RetryOf {
 			return false
 		}
 	}
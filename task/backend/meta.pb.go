@@ -35,10 +35,29 @@ type StoreTaskMeta struct {
 	// effective_cron is the effective cron string as reported by the task's options.
 	EffectiveCron string `protobuf:"bytes,5,opt,name=effective_cron,json=effectiveCron,proto3" json:"effective_cron,omitempty"`
 	// Task's configured delay, in seconds.
-	Offset               int32                     `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
-	ManualRuns           []*StoreTaskMetaManualRun `protobuf:"bytes,16,rep,name=manual_runs,json=manualRuns" json:"manual_runs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Offset     int32                     `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	ManualRuns []*StoreTaskMetaManualRun `protobuf:"bytes,16,rep,name=manual_runs,json=manualRuns" json:"manual_runs,omitempty"`
+	// disabled_reason explains why the task was disabled, if it was disabled automatically.
+	DisabledReason string `protobuf:"bytes,17,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"`
+	// last_run_status is the status the task's latest executed run finished in, such as "success" or "failed".
+	// Empty if no run has finished.
+	LastRunStatus string `protobuf:"bytes,18,opt,name=last_run_status,json=lastRunStatus,proto3" json:"last_run_status,omitempty"`
+	// last_run_error describes why the latest executed run did not succeed. Empty if it succeeded.
+	LastRunError string `protobuf:"bytes,19,opt,name=last_run_error,json=lastRunError,proto3" json:"last_run_error,omitempty"`
+	// last_run_duration is how long the latest executed run took to execute, in nanoseconds.
+	LastRunDuration int64 `protobuf:"varint,20,opt,name=last_run_duration,json=lastRunDuration,proto3" json:"last_run_duration,omitempty"`
+	// run_at is the unix timestamp of the only run of a one-shot task.
+	// It is zero for a task that runs on a schedule.
+	RunAt int64 `protobuf:"varint,21,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	// deleted_at is the unix timestamp when the task was deleted, if its status is "deleted".
+	DeletedAt int64 `protobuf:"varint,22,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// deleted_status is the status the task had before it was deleted, which it is given back if it is restored.
+	DeletedStatus string `protobuf:"bytes,23,opt,name=deleted_status,json=deletedStatus,proto3" json:"deleted_status,omitempty"`
+	// notification holds the task's notification preferences.
+	// Each run created for the task carries a copy, so that its outcome can be dispatched without looking the task up.
+	Notification         *StoreTaskMetaNotification `protobuf:"bytes,24,opt,name=notification" json:"notification,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *StoreTaskMeta) Reset()         { *m = StoreTaskMeta{} }
@@ -123,6 +142,62 @@ func (m *StoreTaskMeta) GetManualRuns() []*StoreTaskMetaManualRun {
 	return nil
 }
 
+func (m *StoreTaskMeta) GetDisabledReason() string {
+	if m != nil {
+		return m.DisabledReason
+	}
+	return ""
+}
+
+func (m *StoreTaskMeta) GetLastRunStatus() string {
+	if m != nil {
+		return m.LastRunStatus
+	}
+	return ""
+}
+
+func (m *StoreTaskMeta) GetLastRunError() string {
+	if m != nil {
+		return m.LastRunError
+	}
+	return ""
+}
+
+func (m *StoreTaskMeta) GetLastRunDuration() int64 {
+	if m != nil {
+		return m.LastRunDuration
+	}
+	return 0
+}
+
+func (m *StoreTaskMeta) GetRunAt() int64 {
+	if m != nil {
+		return m.RunAt
+	}
+	return 0
+}
+
+func (m *StoreTaskMeta) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return 0
+}
+
+func (m *StoreTaskMeta) GetDeletedStatus() string {
+	if m != nil {
+		return m.DeletedStatus
+	}
+	return ""
+}
+
+func (m *StoreTaskMeta) GetNotification() *StoreTaskMetaNotification {
+	if m != nil {
+		return m.Notification
+	}
+	return nil
+}
+
 type StoreTaskMetaRun struct {
 	// now is the unix timestamp of the "now" value for the run.
 	Now   int64  `protobuf:"varint,1,opt,name=now,proto3" json:"now,omitempty"`
@@ -134,7 +209,9 @@ type StoreTaskMetaRun struct {
 	RangeEnd int64 `protobuf:"varint,5,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
 	// requested_at is the unix timestamp indicating when this run was requested.
 	// It is the same value as the "parent" StoreTaskMetaManualRun, if this run was the result of a manual request.
-	RequestedAt          int64    `protobuf:"varint,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	RequestedAt int64 `protobuf:"varint,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// retry_of is the ID of the earlier run that this run retries, if it is a retry of an individual run.
+	RetryOf              uint64   `protobuf:"varint,7,opt,name=retry_of,json=retryOf,proto3" json:"retry_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -214,6 +291,13 @@ func (m *StoreTaskMetaRun) GetRequestedAt() int64 {
 	return 0
 }
 
+func (m *StoreTaskMetaRun) GetRetryOf() uint64 {
+	if m != nil {
+		return m.RetryOf
+	}
+	return 0
+}
+
 // StoreTaskMetaManualRun indicates a manually requested run for a time range.
 // It has a start and end pair of unix timestamps indicating the time range covered by the request.
 type StoreTaskMetaManualRun struct {
@@ -226,7 +310,9 @@ type StoreTaskMetaManualRun struct {
 	// requested_at is the unix timestamp indicating when this run was requested.
 	RequestedAt int64 `protobuf:"varint,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
 	// run_id is set ahead of time for retries of individual runs. Manually run time ranges do not receive an ID.
-	RunID                uint64   `protobuf:"varint,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	RunID uint64 `protobuf:"varint,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// retry_of is the ID of the earlier run that this manual run retries, if it is a retry of an individual run.
+	RetryOf              uint64   `protobuf:"varint,6,opt,name=retry_of,json=retryOf,proto3" json:"retry_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -299,10 +385,94 @@ func (m *StoreTaskMetaManualRun) GetRunID() uint64 {
 	return 0
 }
 
+func (m *StoreTaskMetaManualRun) GetRetryOf() uint64 {
+	if m != nil {
+		return m.RetryOf
+	}
+	return 0
+}
+
+// StoreTaskMetaNotification holds the events a task's owner wants to be notified of, and where to send the notifications.
+type StoreTaskMetaNotification struct {
+	// on_failure requests a notification when a run fails or times out.
+	OnFailure bool `protobuf:"varint,1,opt,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	// on_sla_miss requests a notification when a run is still executing past the deadline set by the task's sla option.
+	OnSLAMiss bool `protobuf:"varint,2,opt,name=on_sla_miss,json=onSlaMiss,proto3" json:"on_sla_miss,omitempty"`
+	// on_recovery requests a notification when a run succeeds after the task's previous run failed.
+	OnRecovery bool `protobuf:"varint,3,opt,name=on_recovery,json=onRecovery,proto3" json:"on_recovery,omitempty"`
+	// target is either an http or https webhook URL, or the ID of an endpoint registered with the notifier.
+	// If empty, notifications go to the target set by the task's notify option, or else its organization's target.
+	Target               string   `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreTaskMetaNotification) Reset()         { *m = StoreTaskMetaNotification{} }
+func (m *StoreTaskMetaNotification) String() string { return proto.CompactTextString(m) }
+func (*StoreTaskMetaNotification) ProtoMessage()    {}
+func (*StoreTaskMetaNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meta_d42b29c328506298, []int{3}
+}
+func (m *StoreTaskMetaNotification) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreTaskMetaNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreTaskMetaNotification.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *StoreTaskMetaNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreTaskMetaNotification.Merge(dst, src)
+}
+func (m *StoreTaskMetaNotification) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreTaskMetaNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreTaskMetaNotification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreTaskMetaNotification proto.InternalMessageInfo
+
+func (m *StoreTaskMetaNotification) GetOnFailure() bool {
+	if m != nil {
+		return m.OnFailure
+	}
+	return false
+}
+
+func (m *StoreTaskMetaNotification) GetOnSLAMiss() bool {
+	if m != nil {
+		return m.OnSLAMiss
+	}
+	return false
+}
+
+func (m *StoreTaskMetaNotification) GetOnRecovery() bool {
+	if m != nil {
+		return m.OnRecovery
+	}
+	return false
+}
+
+func (m *StoreTaskMetaNotification) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*StoreTaskMeta)(nil), "com.influxdata.platform.task.backend.StoreTaskMeta")
 	proto.RegisterType((*StoreTaskMetaRun)(nil), "com.influxdata.platform.task.backend.StoreTaskMetaRun")
 	proto.RegisterType((*StoreTaskMetaManualRun)(nil), "com.influxdata.platform.task.backend.StoreTaskMetaManualRun")
+	proto.RegisterType((*StoreTaskMetaNotification)(nil), "com.influxdata.platform.task.backend.StoreTaskMetaNotification")
 }
 func (m *StoreTaskMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -372,6 +542,71 @@ func (m *StoreTaskMeta) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.DisabledReason) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.DisabledReason)))
+		i += copy(dAtA[i:], m.DisabledReason)
+	}
+	if len(m.LastRunStatus) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.LastRunStatus)))
+		i += copy(dAtA[i:], m.LastRunStatus)
+	}
+	if len(m.LastRunError) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.LastRunError)))
+		i += copy(dAtA[i:], m.LastRunError)
+	}
+	if m.LastRunDuration != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.LastRunDuration))
+	}
+	if m.RunAt != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.RunAt))
+	}
+	if m.DeletedAt != 0 {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.DeletedAt))
+	}
+	if len(m.DeletedStatus) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.DeletedStatus)))
+		i += copy(dAtA[i:], m.DeletedStatus)
+	}
+	if m.Notification != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.Notification.Size()))
+		n1, err := m.Notification.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
 	return i, nil
 }
 
@@ -420,6 +655,11 @@ func (m *StoreTaskMetaRun) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.RequestedAt))
 	}
+	if m.RetryOf != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.RetryOf))
+	}
 	return i, nil
 }
 
@@ -463,6 +703,65 @@ func (m *StoreTaskMetaManualRun) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.RunID))
 	}
+	if m.RetryOf != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.RetryOf))
+	}
+	return i, nil
+}
+
+func (m *StoreTaskMetaNotification) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreTaskMetaNotification) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.OnFailure {
+		dAtA[i] = 0x8
+		i++
+		if m.OnFailure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.OnSLAMiss {
+		dAtA[i] = 0x10
+		i++
+		if m.OnSLAMiss {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.OnRecovery {
+		dAtA[i] = 0x18
+		i++
+		if m.OnRecovery {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Target) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(len(m.Target)))
+		i += copy(dAtA[i:], m.Target)
+	}
 	return i, nil
 }
 
@@ -507,6 +806,35 @@ func (m *StoreTaskMeta) Size() (n int) {
 			n += 2 + l + sovMeta(uint64(l))
 		}
 	}
+	l = len(m.DisabledReason)
+	if l > 0 {
+		n += 2 + l + sovMeta(uint64(l))
+	}
+	l = len(m.LastRunStatus)
+	if l > 0 {
+		n += 2 + l + sovMeta(uint64(l))
+	}
+	l = len(m.LastRunError)
+	if l > 0 {
+		n += 2 + l + sovMeta(uint64(l))
+	}
+	if m.LastRunDuration != 0 {
+		n += 2 + sovMeta(uint64(m.LastRunDuration))
+	}
+	if m.RunAt != 0 {
+		n += 2 + sovMeta(uint64(m.RunAt))
+	}
+	if m.DeletedAt != 0 {
+		n += 2 + sovMeta(uint64(m.DeletedAt))
+	}
+	l = len(m.DeletedStatus)
+	if l > 0 {
+		n += 2 + l + sovMeta(uint64(l))
+	}
+	if m.Notification != nil {
+		l = m.Notification.Size()
+		n += 2 + l + sovMeta(uint64(l))
+	}
 	return n
 }
 
@@ -531,6 +859,9 @@ func (m *StoreTaskMetaRun) Size() (n int) {
 	if m.RequestedAt != 0 {
 		n += 1 + sovMeta(uint64(m.RequestedAt))
 	}
+	if m.RetryOf != 0 {
+		n += 1 + sovMeta(uint64(m.RetryOf))
+	}
 	return n
 }
 
@@ -552,6 +883,28 @@ func (m *StoreTaskMetaManualRun) Size() (n int) {
 	if m.RunID != 0 {
 		n += 1 + sovMeta(uint64(m.RunID))
 	}
+	if m.RetryOf != 0 {
+		n += 1 + sovMeta(uint64(m.RetryOf))
+	}
+	return n
+}
+
+func (m *StoreTaskMetaNotification) Size() (n int) {
+	var l int
+	_ = l
+	if m.OnFailure {
+		n += 2
+	}
+	if m.OnSLAMiss {
+		n += 2
+	}
+	if m.OnRecovery {
+		n += 2
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovMeta(uint64(l))
+	}
 	return n
 }
 
@@ -774,6 +1127,212 @@ func (m *StoreTaskMeta) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRunStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastRunStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRunError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastRunError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRunDuration", wireType)
+			}
+			m.LastRunDuration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastRunDuration |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAt", wireType)
+			}
+			m.RunAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RunAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedAt", wireType)
+			}
+			m.DeletedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeletedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeletedStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notification", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Notification == nil {
+				m.Notification = &StoreTaskMetaNotification{}
+			}
+			if err := m.Notification.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -938,6 +1497,25 @@ func (m *StoreTaskMetaRun) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryOf", wireType)
+			}
+			m.RetryOf = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetryOf |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1083,6 +1661,164 @@ func (m *StoreTaskMetaManualRun) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryOf", wireType)
+			}
+			m.RetryOf = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetryOf |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMeta(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMeta
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreTaskMetaNotification) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMeta
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreTaskMetaNotification: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreTaskMetaNotification: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnFailure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OnFailure = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnSLAMiss", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OnSLAMiss = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnRecovery", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OnRecovery = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1212,35 +1948,50 @@ var (
 func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_d42b29c328506298) }
 
 var fileDescriptor_meta_d42b29c328506298 = []byte{
-	// 474 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xc1, 0x6e, 0x13, 0x31,
-	0x10, 0x86, 0xd9, 0x6e, 0x36, 0x90, 0x09, 0x69, 0x17, 0xab, 0xaa, 0x16, 0x90, 0xd2, 0x25, 0x02,
-	0x11, 0x2e, 0x8b, 0x04, 0x12, 0x27, 0x2e, 0x34, 0x70, 0xe8, 0xa1, 0x17, 0x97, 0x13, 0x12, 0x5a,
-	0xb9, 0x5e, 0x6f, 0x14, 0x65, 0xd7, 0x2e, 0xf6, 0x18, 0x92, 0xb7, 0xe0, 0x51, 0xb8, 0xf2, 0x06,
-	0x1c, 0x79, 0x02, 0x84, 0xc2, 0x7b, 0x20, 0x64, 0x3b, 0x0d, 0x50, 0x72, 0x40, 0xbd, 0xcd, 0x7c,
-	0x5a, 0x8f, 0xff, 0xff, 0xdf, 0x31, 0x40, 0x2b, 0x90, 0x15, 0xe7, 0x5a, 0xa1, 0x22, 0xf7, 0xb9,
-	0x6a, 0x8b, 0x99, 0xac, 0x1b, 0xbb, 0xa8, 0x98, 0xa3, 0x0d, 0xc3, 0x5a, 0xe9, 0xb6, 0x40, 0x66,
-	0xe6, 0xc5, 0x19, 0xe3, 0x73, 0x21, 0xab, 0x3b, 0xfb, 0x53, 0x35, 0x55, 0xfe, 0xc0, 0x63, 0x57,
-	0x85, 0xb3, 0xa3, 0x9f, 0x3b, 0x30, 0x38, 0x45, 0xa5, 0xc5, 0x6b, 0x66, 0xe6, 0x27, 0x02, 0x19,
-	0x79, 0x08, 0x7b, 0x2d, 0x5b, 0x94, 0x5c, 0x49, 0x6e, 0xb5, 0x16, 0x92, 0x2f, 0xb3, 0x28, 0x8f,
-	0xc6, 0x09, 0xdd, 0x6d, 0xd9, 0x62, 0xf2, 0x9b, 0x92, 0x47, 0x90, 0x36, 0x0c, 0x85, 0xc1, 0x92,
-	0xab, 0xf6, 0xbc, 0x11, 0x28, 0xaa, 0x6c, 0x27, 0x8f, 0xc6, 0x31, 0xdd, 0x0b, 0x7c, 0x72, 0x81,
-	0xc9, 0x01, 0x74, 0x0d, 0x32, 0xb4, 0x26, 0x8b, 0xf3, 0x68, 0xdc, 0xa3, 0xeb, 0x8e, 0x70, 0xb8,
-	0x15, 0xc6, 0x61, 0xb3, 0x2c, 0xb5, 0x95, 0x72, 0x26, 0xa7, 0x59, 0x27, 0x8f, 0xc7, 0xfd, 0x27,
-	0xcf, 0x8a, 0xff, 0x71, 0x55, 0xfc, 0xa5, 0x9d, 0x5a, 0x49, 0xd3, 0xcd, 0x40, 0x1a, 0xe6, 0x91,
-	0x07, 0xb0, 0x2b, 0xea, 0x5a, 0x70, 0x9c, 0xbd, 0x17, 0x25, 0xd7, 0x4a, 0x66, 0x89, 0x17, 0x31,
-	0xd8, 0xd0, 0x89, 0x56, 0xd2, 0x69, 0x54, 0x75, 0x6d, 0x04, 0x66, 0x5d, 0x6f, 0x77, 0xdd, 0x91,
-	0xb7, 0xd0, 0x6f, 0x99, 0xb4, 0xac, 0x71, 0x02, 0x4d, 0x96, 0x7a, 0x75, 0xcf, 0xaf, 0xa0, 0xee,
-	0xc4, 0x4f, 0x71, 0x1a, 0xa1, 0xbd, 0x28, 0xcd, 0xe8, 0x73, 0x04, 0xe9, 0x65, 0x13, 0x24, 0x85,
-	0x58, 0xaa, 0x0f, 0x3e, 0xf7, 0x98, 0xba, 0xd2, 0x11, 0xd4, 0x4b, 0x9f, 0xef, 0x80, 0xba, 0x92,
-	0xe4, 0xd0, 0xd5, 0x56, 0x96, 0xb3, 0xca, 0x67, 0xda, 0x39, 0xea, 0xad, 0xbe, 0x1d, 0x26, 0xd4,
-	0xca, 0xe3, 0x97, 0x34, 0xd1, 0x56, 0x1e, 0x57, 0xe4, 0x10, 0xfa, 0x9a, 0xc9, 0xa9, 0x28, 0x0d,
-	0x32, 0x8d, 0x59, 0xc7, 0x4f, 0x03, 0x8f, 0x4e, 0x1d, 0x21, 0x77, 0xa1, 0x17, 0x3e, 0x10, 0xb2,
-	0xf2, 0xa1, 0xc4, 0xf4, 0x86, 0x07, 0xaf, 0x64, 0x45, 0xee, 0xc1, 0x4d, 0x2d, 0xde, 0x59, 0x61,
-	0x50, 0x54, 0x25, 0x0b, 0xa9, 0xc4, 0xb4, 0xbf, 0x61, 0x2f, 0x70, 0xf4, 0x29, 0x82, 0x83, 0xed,
-	0x16, 0xc9, 0x3e, 0x24, 0xe1, 0xd6, 0xe0, 0x21, 0x34, 0xce, 0x85, 0xbb, 0x2a, 0x6c, 0x89, 0x2b,
-	0xb7, 0x2e, 0x51, 0xbc, 0x7d, 0x89, 0x2e, 0x0b, 0xea, 0xfc, 0x23, 0xe8, 0x8f, 0x4c, 0x92, 0xed,
-	0x99, 0x1c, 0xdd, 0xfe, 0xb2, 0x1a, 0x46, 0x5f, 0x57, 0xc3, 0xe8, 0xfb, 0x6a, 0x18, 0x7d, 0xfc,
-	0x31, 0xbc, 0xf6, 0xe6, 0xfa, 0xfa, 0x67, 0x9d, 0x75, 0xfd, 0x8b, 0x78, 0xfa, 0x2b, 0x00, 0x00,
-	0xff, 0xff, 0x93, 0x87, 0xe2, 0xd0, 0x5b, 0x03, 0x00, 0x00,
+	// 705 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x54, 0x5d, 0x4f, 0x14, 0x31,
+	0x14, 0x75, 0x1d, 0x66, 0xd9, 0xbd, 0xcb, 0x2e, 0x4b, 0x05, 0x1c, 0x34, 0x02, 0x12, 0x54, 0x34,
+	0x71, 0x4d, 0x30, 0xf1, 0xc9, 0xc4, 0xf0, 0x65, 0x42, 0x22, 0x92, 0x74, 0x7d, 0x32, 0x31, 0x93,
+	0x32, 0xd3, 0xd9, 0x4c, 0x98, 0x69, 0xb1, 0xd3, 0x41, 0xf8, 0x17, 0xfe, 0x06, 0x7f, 0x8d, 0x89,
+	0x2f, 0x3e, 0xf8, 0x6c, 0x0c, 0xfc, 0x11, 0x6f, 0xdb, 0xd9, 0xe5, 0x43, 0x48, 0x0c, 0x0f, 0x93,
+	0xdc, 0x9e, 0x7b, 0xe6, 0xf6, 0xdc, 0xd3, 0xdb, 0x02, 0xe4, 0x5c, 0xb3, 0xde, 0x81, 0x92, 0x5a,
+	0x92, 0xe5, 0x48, 0xe6, 0xbd, 0x54, 0x24, 0x59, 0x79, 0x14, 0x33, 0x83, 0x66, 0x4c, 0x27, 0x52,
+	0xe5, 0x3d, 0xcd, 0x8a, 0xfd, 0xde, 0x1e, 0x8b, 0xf6, 0xb9, 0x88, 0xef, 0x4d, 0x0f, 0xe4, 0x40,
+	0xda, 0x1f, 0x5e, 0x98, 0xc8, 0xfd, 0xbb, 0x74, 0xea, 0x43, 0xbb, 0xaf, 0xa5, 0xe2, 0x1f, 0x90,
+	0xbb, 0x83, 0x35, 0xc9, 0x13, 0x98, 0xcc, 0xd9, 0x51, 0x18, 0x49, 0x11, 0x95, 0x4a, 0x71, 0x11,
+	0x1d, 0x07, 0xb5, 0xc5, 0xda, 0x8a, 0x4f, 0x3b, 0x08, 0x6f, 0x9c, 0xa1, 0xe4, 0x29, 0x74, 0x71,
+	0x23, 0x5e, 0x68, 0xe4, 0xe6, 0x07, 0x19, 0xd7, 0x3c, 0x0e, 0x6e, 0x23, 0xd3, 0xa3, 0x93, 0x0e,
+	0xdf, 0x18, 0xc2, 0x64, 0x16, 0xea, 0x85, 0x66, 0xba, 0x2c, 0x02, 0x0f, 0x09, 0x4d, 0x5a, 0xad,
+	0x48, 0x04, 0x53, 0xae, 0x9c, 0xce, 0x8e, 0x43, 0x55, 0x0a, 0x91, 0x8a, 0x41, 0x30, 0xb6, 0xe8,
+	0xad, 0xb4, 0x56, 0x5f, 0xf5, 0xfe, 0xa7, 0xab, 0xde, 0x05, 0xed, 0xb4, 0x14, 0xb4, 0x3b, 0x2a,
+	0x48, 0x5d, 0x3d, 0xf2, 0x08, 0x3a, 0x3c, 0x49, 0x78, 0xa4, 0xd3, 0x43, 0x1e, 0x46, 0x4a, 0x8a,
+	0xc0, 0xb7, 0x22, 0xda, 0x23, 0x74, 0x03, 0x41, 0xa3, 0x51, 0x26, 0x49, 0xc1, 0x75, 0x50, 0xb7,
+	0xed, 0x56, 0x2b, 0xf2, 0x09, 0x5a, 0x39, 0x13, 0x25, 0xcb, 0x8c, 0xc0, 0x22, 0xe8, 0x5a, 0x75,
+	0xaf, 0x6f, 0xa0, 0x6e, 0xc7, 0x56, 0x31, 0x1a, 0x21, 0x1f, 0x86, 0x85, 0xb1, 0x3b, 0x4e, 0x0b,
+	0xb6, 0x97, 0xf1, 0x38, 0x54, 0x9c, 0x15, 0x28, 0x6f, 0xca, 0xca, 0xeb, 0x0c, 0x61, 0x6a, 0x51,
+	0xf2, 0x18, 0xd0, 0x56, 0x34, 0x1b, 0x55, 0x84, 0x95, 0x99, 0xc4, 0xf5, 0x61, 0x60, 0xac, 0xd5,
+	0x77, 0x9e, 0x2e, 0x43, 0x67, 0xc4, 0xe3, 0x4a, 0x49, 0x15, 0xdc, 0xb1, 0xb4, 0x89, 0x8a, 0xb6,
+	0x65, 0x30, 0xf2, 0x0c, 0xa6, 0x46, 0xac, 0xb8, 0x54, 0x4c, 0xa7, 0xb8, 0xf1, 0xf4, 0xf0, 0xf4,
+	0x2c, 0x71, 0xb3, 0x82, 0xc9, 0x0c, 0xd4, 0x0d, 0x8d, 0xe9, 0x60, 0xc6, 0x12, 0x7c, 0x5c, 0xad,
+	0x69, 0xf2, 0x00, 0x20, 0xe6, 0xf6, 0x7c, 0x4d, 0x6a, 0xd6, 0xa6, 0x9a, 0x15, 0x82, 0x69, 0xb4,
+	0x7d, 0x98, 0xae, 0xe4, 0xde, 0x75, 0x72, 0x2b, 0xb4, 0x3f, 0x1c, 0x81, 0x09, 0x21, 0x75, 0x9a,
+	0xa4, 0x91, 0xd3, 0x10, 0x20, 0xa9, 0xb5, 0xfa, 0xe6, 0x06, 0xfe, 0xbe, 0x3f, 0x57, 0x86, 0x5e,
+	0x28, 0xba, 0xf4, 0xab, 0x06, 0xdd, 0xcb, 0x93, 0x42, 0xba, 0xe0, 0x09, 0xf9, 0xc5, 0x0e, 0xb7,
+	0x47, 0x4d, 0x68, 0x10, 0xad, 0x8e, 0xed, 0x10, 0xb7, 0xa9, 0x09, 0xc9, 0xa2, 0x6b, 0x3d, 0x8d,
+	0xed, 0xe0, 0x8e, 0xad, 0x37, 0x4f, 0x7e, 0x2f, 0xf8, 0xf8, 0xf3, 0xf6, 0xa6, 0x75, 0x61, 0x3b,
+	0x26, 0x0b, 0xd0, 0x52, 0x4c, 0x0c, 0xb8, 0x69, 0x52, 0x69, 0x1c, 0x5e, 0x53, 0x0d, 0x2c, 0xd4,
+	0x37, 0x08, 0xb9, 0x0f, 0x4d, 0x47, 0x40, 0xc1, 0x76, 0xf2, 0x3c, 0xda, 0xb0, 0xc0, 0x96, 0x88,
+	0xc9, 0x43, 0x98, 0x50, 0xfc, 0x73, 0x89, 0x97, 0xc5, 0xb9, 0x58, 0xb7, 0xf9, 0xd6, 0x08, 0x43,
+	0x1f, 0xe7, 0xa0, 0xa1, 0x38, 0x6a, 0x09, 0x65, 0x12, 0x8c, 0x1b, 0x11, 0x74, 0xdc, 0xae, 0x77,
+	0x93, 0xa5, 0x1f, 0x35, 0x98, 0xbd, 0x7a, 0xc4, 0xc8, 0x34, 0xf8, 0x4e, 0x90, 0x6b, 0xcf, 0x2d,
+	0x4c, 0x83, 0x46, 0x85, 0xbb, 0xa5, 0x26, 0xbc, 0xf2, 0x12, 0x7b, 0x57, 0x5f, 0xe2, 0xcb, 0x5a,
+	0xc7, 0xfe, 0xd5, 0x7a, 0x66, 0x97, 0x7f, 0x8d, 0x5d, 0xe7, 0xbb, 0xa9, 0x5f, 0xec, 0xe6, 0x5b,
+	0x0d, 0xe6, 0xae, 0x3d, 0x50, 0x33, 0x6d, 0x52, 0x84, 0x09, 0x4b, 0xb3, 0x52, 0x71, 0xdb, 0x55,
+	0x83, 0x36, 0xa5, 0x78, 0xeb, 0x00, 0xf2, 0x1c, 0x5a, 0x98, 0x2e, 0x32, 0x16, 0xe6, 0x69, 0x51,
+	0xd8, 0x0e, 0x1b, 0xeb, 0x6d, 0xdc, 0xbe, 0xb9, 0x2b, 0xfa, 0xef, 0xd6, 0x76, 0x10, 0x34, 0xf4,
+	0x7e, 0xc6, 0x4c, 0x68, 0x4e, 0x0d, 0xe9, 0x8a, 0x47, 0xf2, 0x90, 0xe3, 0x89, 0x7b, 0xb6, 0x1c,
+	0x6e, 0x40, 0x2b, 0xc4, 0xbc, 0x06, 0xe8, 0xd8, 0x80, 0xbb, 0x36, 0xf1, 0xc5, 0x72, 0xab, 0xf5,
+	0xb9, 0xef, 0x27, 0xf3, 0xb5, 0x9f, 0xf8, 0xfd, 0xc1, 0xef, 0xeb, 0xe9, 0xfc, 0xad, 0x8f, 0xe3,
+	0xd5, 0x30, 0xee, 0xd5, 0xed, 0x8b, 0xfa, 0xf2, 0x2f, 0xe2, 0x0d, 0xa7, 0x56, 0x9b, 0x05, 0x00,
+	0x00,
 }
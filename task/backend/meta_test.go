@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
 )
 
 var idGen = snowflake.NewIDGenerator()
@@ -213,6 +214,100 @@ func TestMeta_CreateNextRun_Delay(t *testing.T) {
 	}
 }
 
+func TestMeta_CreateNextRun_OneShot(t *testing.T) {
+	at := time.Unix(600, 0)
+	stm := backend.NewStoreTaskMeta(
+		backend.CreateTaskRequest{ScheduleAfter: 30},
+		options.Options{Once: true, At: at, Concurrency: 1},
+	)
+	if !stm.IsOneShot() || stm.RunAt != 600 || stm.EffectiveCron != "" {
+		t.Fatalf("expected one-shot meta running at 600, got %#v", stm)
+	}
+
+	if due, err := stm.NextDueRun(); err != nil || due != 600 {
+		t.Fatalf("expected next due at 600, got %d (%v)", due, err)
+	}
+
+	_, err := stm.CreateNextRun(599, makeID)
+	if e, ok := err.(backend.RunNotYetDueError); !ok {
+		t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
+	} else if e.DueAt != 600 {
+		t.Fatalf("expected run due at 600, got %d", e.DueAt)
+	}
+
+	if err := stm.ManuallyRunTimeRange(0, 60, 30, nil); err != backend.ErrOneShotManualRun {
+		t.Fatalf("expected ErrOneShotManualRun, got %v", err)
+	}
+
+	rc, err := stm.CreateNextRun(900, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 600 {
+		t.Fatalf("expected created run to have time 600, got %d", rc.Created.Now)
+	}
+	if rc.NextDue != backend.NeverDue {
+		t.Fatalf("expected no next due run, got %d", rc.NextDue)
+	}
+
+	stm.MaxConcurrency = 2
+	_, err = stm.CreateNextRun(900, makeID)
+	if e, ok := err.(backend.RunNotYetDueError); !ok || e.DueAt != backend.NeverDue {
+		t.Fatalf("expected RunNotYetDueError due never, got %v", err)
+	}
+
+	if !stm.FinishRun(rc.Created.RunID) {
+		t.Fatal("expected to finish run")
+	}
+	if stm.Status != string(backend.TaskInactive) || stm.DisabledReason != backend.OneShotCompletedReason {
+		t.Fatalf("expected one-shot task to be disabled after its run, got status %q and reason %q", stm.Status, stm.DisabledReason)
+	}
+	if due, err := stm.NextDueRun(); err != nil || due != backend.NeverDue {
+		t.Fatalf("expected no next due run, got %d (%v)", due, err)
+	}
+	if runs, err := stm.NextScheduledRuns(3); err != nil || len(runs) != 0 {
+		t.Fatalf("expected no scheduled runs, got %v (%v)", runs, err)
+	}
+
+	// Without a time, the task runs as soon as possible.
+	asap := backend.NewStoreTaskMeta(
+		backend.CreateTaskRequest{ScheduleAfter: 30},
+		options.Options{Once: true, Concurrency: 1},
+	)
+	if rc, err := asap.CreateNextRun(31, makeID); err != nil || rc.Created.Now != 31 {
+		t.Fatalf("expected run created at 31, got %d (%v)", rc.Created.Now, err)
+	}
+}
+
+func TestMeta_NextScheduledRuns(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		Offset:          5,
+		LatestCompleted: 30,
+	}
+
+	runs, err := stm.NextScheduledRuns(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []int64{60, 120, 180}
+	if len(runs) != len(exp) {
+		t.Fatalf("expected %d runs, got %d", len(exp), len(runs))
+	}
+	for i := range exp {
+		if runs[i] != exp[i] {
+			t.Fatalf("expected run %d at %d, got %d", i, exp[i], runs[i])
+		}
+	}
+
+	stm.EffectiveCron = "not a cron"
+	if _, err := stm.NextScheduledRuns(1); err == nil {
+		t.Fatal("expected error for invalid cron")
+	}
+}
+
 func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 	now := time.Now().Unix()
 	stm := backend.StoreTaskMeta{
@@ -279,3 +374,86 @@ func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 
 	// Not currently enforcing one way or another when a newly requested time range overlaps with an existing one.
 }
+
+func TestMeta_RetryRun(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 3000,
+	}
+
+	const retryOf = platform.ID(77)
+	if err := stm.RetryRun(2940, 3005, retryOf, makeID); err != nil {
+		t.Fatal(err)
+	}
+	if mr := stm.ManualRuns[0]; mr.Start != 2940 || mr.End != 2940 || platform.ID(mr.RetryOf) != retryOf {
+		t.Fatalf("unexpected manual run: %#v", mr)
+	}
+
+	// The run created from the queue is linked to the run it retries.
+	rc, err := stm.CreateNextRun(3010, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 2940 || rc.Created.RetryOf != retryOf {
+		t.Fatalf("expected retry of %s for 2940, got %#v", retryOf, rc.Created)
+	}
+	if cr := stm.CurrentlyRunning[0]; platform.ID(cr.RetryOf) != retryOf {
+		t.Fatalf("expected currently running retry of %s, got %#v", retryOf, cr)
+	}
+
+	// A retry is rejected like any other manual run for the same window.
+	if err := stm.RetryRun(3060, 3065, retryOf, makeID); err != nil {
+		t.Fatal(err)
+	}
+	if exp, err := (backend.RetryAlreadyQueuedError{Start: 3060, End: 3060}), stm.RetryRun(3060, 3066, retryOf, makeID); err != exp {
+		t.Fatalf("expected %v, got %v", exp, err)
+	}
+}
+
+func TestMeta_Checkpoint(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  3,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 60,
+	}
+
+	if cp := stm.Checkpoint(); cp.Completed != 60 || cp.LatestScheduled != 60 || len(cp.InProgress) != 0 {
+		t.Fatalf("unexpected checkpoint with no runs: %#v", cp)
+	}
+
+	// Two scheduled windows and one manual run in progress.
+	for i := 0; i < 2; i++ {
+		if _, err := stm.CreateNextRun(200, makeID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stm.ManuallyRunTimeRange(0, 0, 200, makeID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stm.CreateNextRun(200, makeID); err != nil {
+		t.Fatal(err)
+	}
+
+	cp := stm.Checkpoint()
+	if cp.Completed != 60 || cp.LatestScheduled != 180 {
+		t.Fatalf("expected completed 60 and latest scheduled 180, got %#v", cp)
+	}
+	if len(cp.InProgress) != 2 || cp.InProgress[0] != 120 || cp.InProgress[1] != 180 {
+		t.Fatalf("expected windows 120 and 180 in progress, got %v", cp.InProgress)
+	}
+
+	// Finishing the later window moves LatestCompleted past the earlier one, which is still in progress.
+	if !stm.FinishRun(platform.ID(stm.CurrentlyRunning[1].RunID)) {
+		t.Fatal("expected to finish run")
+	}
+	cp = stm.Checkpoint()
+	if cp.Completed != 119 || cp.LatestScheduled != 180 {
+		t.Fatalf("expected completed 119 and latest scheduled 180, got %#v", cp)
+	}
+	if len(cp.InProgress) != 1 || cp.InProgress[0] != 120 {
+		t.Fatalf("expected window 120 in progress, got %v", cp.InProgress)
+	}
+}
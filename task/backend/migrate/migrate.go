@@ -0,0 +1,373 @@
+// Package migrate copies tasks, along with their runs and logs, from one task store to another,
+// so that the storage backend of an existing installation can be changed without losing run history.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// runPageSize is how many runs of a task are requested from the run source at a time.
+const runPageSize = 100
+
+// RunStore is a store of runs and their logs that runs can be copied to.
+type RunStore interface {
+	backend.LogWriter
+	backend.LogReader
+}
+
+// Result summarizes a migration.
+type Result struct {
+	// Tasks is the number of tasks copied and verified.
+	Tasks int
+
+	// Runs is the number of runs copied and verified.
+	Runs int
+
+	// LastTask is the ID of the last task copied and verified.
+	// Passing it to ResumeAfter continues an interrupted migration from the next task.
+	LastTask platform.ID
+}
+
+// Migrator copies every task in one store to another.
+//
+// Tasks are copied in ID order, with their meta and retained script versions, keeping their IDs.
+// If WithRuns is given, each task's runs and logs are copied too.
+// After a task is copied, it is read back from the destination and compared with the source;
+// a mismatch stops the migration with an error.
+//
+// Copying a task again replaces it, and only the log lines a run is missing in the destination are appended,
+// so a migration that was interrupted can safely be run again, or resumed with ResumeAfter.
+// The source should not be written to while it is being migrated.
+//
+// Audit entries and other data kept outside the task store and run store are not copied.
+type Migrator struct {
+	from     backend.Store
+	to       backend.Store
+	importer backend.TaskImporter
+
+	fromRuns backend.LogReader
+	toRuns   RunStore
+
+	logger     *zap.Logger
+	after      platform.ID
+	checkpoint func(platform.ID) error
+}
+
+// Option is a option you can use to modify the migrator's behavior.
+type Option func(*Migrator)
+
+// WithRuns makes the migrator copy each task's runs and logs from src to dst.
+func WithRuns(src backend.LogReader, dst RunStore) Option {
+	return func(m *Migrator) {
+		m.fromRuns = src
+		m.toRuns = dst
+	}
+}
+
+// WithLogger sets the logger for the migrator.
+func WithLogger(logger *zap.Logger) Option {
+	return func(m *Migrator) {
+		m.logger = logger
+	}
+}
+
+// ResumeAfter makes the migrator skip tasks with IDs up to and including id,
+// which were copied by an earlier migration.
+func ResumeAfter(id platform.ID) Option {
+	return func(m *Migrator) {
+		m.after = id
+	}
+}
+
+// WithCheckpoint sets a function to call with each task's ID once the task is copied and verified.
+// If the function returns an error, the migration stops with that error.
+func WithCheckpoint(fn func(platform.ID) error) Option {
+	return func(m *Migrator) {
+		m.checkpoint = fn
+	}
+}
+
+// New returns a Migrator that copies tasks from the store from to the store to.
+// The destination store must implement backend.TaskImporter, so that tasks keep their IDs.
+func New(from, to backend.Store, opts ...Option) (*Migrator, error) {
+	imp, ok := to.(backend.TaskImporter)
+	if !ok {
+		return nil, errors.New("destination store does not support importing tasks")
+	}
+
+	m := &Migrator{
+		from:     from,
+		to:       to,
+		importer: imp,
+		logger:   zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// Migrate copies and verifies every task after the one given to ResumeAfter, if any.
+// On error, the returned Result describes the tasks that were copied and verified before the error.
+func (m *Migrator) Migrate(ctx context.Context) (Result, error) {
+	var res Result
+	after := m.after
+	for {
+		tasks, err := m.from.ListTasks(ctx, backend.TaskSearchParams{After: after, PageSize: platform.TaskMaxPageSize})
+		if err != nil {
+			return res, fmt.Errorf("failed to list tasks: %v", err)
+		}
+		if len(tasks) == 0 {
+			return res, nil
+		}
+
+		for _, t := range tasks {
+			runs, err := m.migrateTask(ctx, t)
+			if err != nil {
+				return res, fmt.Errorf("failed to migrate task %s: %v", t.Task.ID, err)
+			}
+			if m.checkpoint != nil {
+				if err := m.checkpoint(t.Task.ID); err != nil {
+					return res, err
+				}
+			}
+
+			res.Tasks++
+			res.Runs += runs
+			res.LastTask = t.Task.ID
+			m.logger.Info("Migrated task", zap.String("task_id", t.Task.ID.String()), zap.Int("runs", runs))
+		}
+		after = tasks[len(tasks)-1].Task.ID
+	}
+}
+
+// migrateTask copies and verifies a single task and its runs, returning the number of runs copied.
+func (m *Migrator) migrateTask(ctx context.Context, t backend.StoreTaskWithMeta) (int, error) {
+	versions, err := m.from.ListTaskVersions(ctx, t.Task.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list versions: %v", err)
+	}
+	if err := m.importer.ImportTask(ctx, t.Task, t.Meta, versions); err != nil {
+		return 0, fmt.Errorf("failed to import: %v", err)
+	}
+	if err := m.verifyTask(ctx, t, versions); err != nil {
+		return 0, err
+	}
+
+	if m.fromRuns == nil {
+		return 0, nil
+	}
+	n := 0
+	var after *platform.ID
+	for {
+		runs, err := m.fromRuns.ListRuns(ctx, platform.RunFilter{Org: &t.Task.Org, Task: &t.Task.ID, After: after, Limit: runPageSize})
+		if err == backend.ErrRunNotFound {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("failed to list runs: %v", err)
+		}
+		if len(runs) == 0 {
+			return n, nil
+		}
+
+		for _, r := range runs {
+			if err := m.migrateRun(ctx, &t.Task, r); err != nil {
+				return n, fmt.Errorf("failed to migrate run %s: %v", r.ID, err)
+			}
+			n++
+		}
+		after = &runs[len(runs)-1].ID
+	}
+}
+
+// verifyTask returns an error if the destination's copy of t differs from t.
+func (m *Migrator) verifyTask(ctx context.Context, t backend.StoreTaskWithMeta, versions []backend.TaskVersion) error {
+	task, meta, err := m.to.FindTaskByIDWithMeta(ctx, t.Task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read back task: %v", err)
+	}
+	if task.Org != t.Task.Org || task.User != t.Task.User || task.Name != t.Task.Name || task.Script != t.Task.Script ||
+		!sameLabels(task.Labels, t.Task.Labels) {
+		return errors.New("task differs after migration")
+	}
+	if !meta.Equal(t.Meta) || meta.DisabledReason != t.Meta.DisabledReason {
+		return errors.New("task meta differs after migration")
+	}
+
+	got, err := m.to.ListTaskVersions(ctx, t.Task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read back versions: %v", err)
+	}
+	if len(got) != len(versions) || (len(got) > 0 && !reflect.DeepEqual(got, versions)) {
+		return errors.New("task versions differ after migration")
+	}
+	return nil
+}
+
+// migrateRun copies a single run to the destination, appending whichever of its log lines the destination is missing,
+// and verifies the copy.
+func (m *Migrator) migrateRun(ctx context.Context, task *backend.StoreTask, r *platform.Run) error {
+	status, err := parseRunStatus(r.Status)
+	if err != nil {
+		return err
+	}
+	sf, err := time.Parse(time.RFC3339, r.ScheduledFor)
+	if err != nil {
+		return fmt.Errorf("invalid scheduled time: %v", err)
+	}
+	rlb := backend.RunLogBase{Task: task, RunID: r.ID, RunScheduledFor: sf.Unix(), RetryOf: r.RetryOf, TraceID: r.TraceID}
+	if r.RequestedAt != "" {
+		ra, err := time.Parse(time.RFC3339, r.RequestedAt)
+		if err != nil {
+			return fmt.Errorf("invalid requested time: %v", err)
+		}
+		rlb.RequestedAt = ra.Unix()
+	}
+
+	// Record the start first, so that the final state, set second, keeps both times.
+	when := sf
+	if r.StartedAt != "" {
+		if when, err = time.Parse(time.RFC3339Nano, r.StartedAt); err != nil {
+			return fmt.Errorf("invalid start time: %v", err)
+		}
+		if err := m.toRuns.UpdateRunState(ctx, rlb, when, backend.RunStarted); err != nil {
+			return err
+		}
+	}
+	if r.FinishedAt != "" {
+		if when, err = time.Parse(time.RFC3339Nano, r.FinishedAt); err != nil {
+			return fmt.Errorf("invalid finish time: %v", err)
+		}
+	}
+	if status != backend.RunStarted || r.StartedAt == "" {
+		if err := m.toRuns.UpdateRunState(ctx, rlb, when, status); err != nil {
+			return err
+		}
+	}
+
+	srcLog, err := runLog(ctx, m.fromRuns, task.Org, r.ID)
+	if err != nil {
+		return err
+	}
+	dstLog, err := runLog(ctx, m.toRuns, task.Org, r.ID)
+	if err != nil {
+		return err
+	}
+	src, err := splitLog(srcLog)
+	if err != nil {
+		return err
+	}
+	dst, err := splitLog(dstLog)
+	if err != nil {
+		return err
+	}
+	if len(dst) > len(src) || !reflect.DeepEqual(dst, src[:len(dst)]) {
+		return errors.New("destination log is not a prefix of the source log")
+	}
+	for _, l := range src[len(dst):] {
+		if err := m.toRuns.AddRunLog(ctx, rlb, l.when, l.msg); err != nil {
+			return err
+		}
+	}
+
+	return m.verifyRun(ctx, task.Org, r, srcLog)
+}
+
+// verifyRun returns an error if the destination's copy of r differs from r, or its log differs from log.
+func (m *Migrator) verifyRun(ctx context.Context, orgID platform.ID, r *platform.Run, log platform.Log) error {
+	got, err := m.toRuns.FindRunByID(ctx, orgID, r.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read back run: %v", err)
+	}
+	if got.TaskID != r.TaskID || got.Status != r.Status || got.ScheduledFor != r.ScheduledFor ||
+		got.StartedAt != r.StartedAt || got.FinishedAt != r.FinishedAt || got.RequestedAt != r.RequestedAt {
+		return errors.New("run differs after migration")
+	}
+
+	gotLog, err := runLog(ctx, m.toRuns, orgID, r.ID)
+	if err != nil {
+		return err
+	}
+	if gotLog != log {
+		return errors.New("run log differs after migration")
+	}
+	return nil
+}
+
+// runLog returns the log of the run with the given ID, or an empty log if the run does not exist.
+func runLog(ctx context.Context, lr backend.LogReader, orgID, runID platform.ID) (platform.Log, error) {
+	logs, err := lr.ListLogs(ctx, platform.LogFilter{Org: &orgID, Run: &runID})
+	if err == backend.ErrRunNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to list logs: %v", err)
+	}
+	var lines []string
+	for _, l := range logs {
+		if l != "" {
+			lines = append(lines, string(l))
+		}
+	}
+	return platform.Log(strings.Join(lines, "\n")), nil
+}
+
+// logLine is a single message added to a run's log.
+type logLine struct {
+	when time.Time
+	msg  string
+}
+
+// splitLog splits a run's log, as written by backend.LogWriter.AddRunLog, into the lines that were added to it.
+// A line that does not start with a timestamp continues the message on the line before.
+func splitLog(log platform.Log) ([]logLine, error) {
+	if log == "" {
+		return nil, nil
+	}
+
+	var lines []logLine
+	for _, s := range strings.Split(string(log), "\n") {
+		if i := strings.Index(s, ": "); i > 0 {
+			if when, err := time.Parse(time.RFC3339Nano, s[:i]); err == nil {
+				lines = append(lines, logLine{when: when, msg: s[i+2:]})
+				continue
+			}
+		}
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("log does not start with a timestamp: %q", s)
+		}
+		lines[len(lines)-1].msg += "\n" + s
+	}
+	return lines, nil
+}
+
+// parseRunStatus returns the RunStatus whose string form is s.
+func parseRunStatus(s string) (backend.RunStatus, error) {
+	for _, r := range []backend.RunStatus{
+		backend.RunStarted, backend.RunSuccess, backend.RunFail, backend.RunCanceled,
+		backend.RunScheduled, backend.RunTimedOut, backend.RunSkipped, backend.RunDeduplicated,
+	} {
+		if s == r.String() {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown run status: %q", s)
+}
+
+// sameLabels reports whether a and b hold the same labels, treating nil and empty as the same.
+func sameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return backend.LabelsMatch(a, b)
+}
@@ -0,0 +1,179 @@
+package migrate_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/migrate"
+	"github.com/influxdata/platform/task/options"
+)
+
+const script = `option task = {
+	name: "a task",
+	every: 1m,
+}
+
+from(bucket:"test") |> range(start:-1h)`
+
+func TestMigrator_Migrate(t *testing.T) {
+	ctx := context.Background()
+	from, fromRuns := backend.NewInMemStore(), backend.NewInMemRunReaderWriter()
+
+	// Three tasks, the second with a changed script and two runs with logs.
+	var ids []platform.ID
+	for i := 1; i <= 3; i++ {
+		id, err := from.CreateTask(ctx, backend.CreateTaskRequest{
+			Org:    platform.ID(i),
+			User:   platform.ID(10 + i),
+			Script: script,
+			Labels: map[string]string{"n": strconv.Itoa(i)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	name := "renamed"
+	if _, err := from.UpdateTask(ctx, backend.UpdateTaskRequest{ID: ids[1], Options: options.Update{Name: &name}}); err != nil {
+		t.Fatal(err)
+	}
+	task, err := from.FindTaskByID(ctx, ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 2; i++ {
+		rlb := backend.RunLogBase{Task: task, RunID: platform.ID(100 + i), RunScheduledFor: int64(i * 60)}
+		if err := fromRuns.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor+1, 0), backend.RunStarted); err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < 3; j++ {
+			if err := fromRuns.AddRunLog(ctx, rlb, time.Unix(rlb.RunScheduledFor+2, int64(j)).UTC(), "line\nwrapped"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if i == 1 {
+			if err := fromRuns.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor+3, 0), backend.RunFail); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	to, toRuns := backend.NewInMemStore(), backend.NewInMemRunReaderWriter()
+
+	// A previous, interrupted migration copied the first run with only part of its log.
+	rlb := backend.RunLogBase{Task: task, RunID: platform.ID(101), RunScheduledFor: 60}
+	if err := toRuns.UpdateRunState(ctx, rlb, time.Unix(61, 0), backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+	if err := toRuns.AddRunLog(ctx, rlb, time.Unix(62, 0).UTC(), "line\nwrapped"); err != nil {
+		t.Fatal(err)
+	}
+
+	var checkpoints []platform.ID
+	m, err := migrate.New(from, to,
+		migrate.WithRuns(fromRuns, toRuns),
+		migrate.WithCheckpoint(func(id platform.ID) error {
+			checkpoints = append(checkpoints, id)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := m.Migrate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Tasks != 3 || res.Runs != 2 || res.LastTask != ids[2] {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if len(checkpoints) != 3 || checkpoints[2] != ids[2] {
+		t.Fatalf("unexpected checkpoints: %v", checkpoints)
+	}
+
+	got, err := to.FindTaskByID(ctx, ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "renamed" || got.Script != task.Script || got.Org != task.Org {
+		t.Fatalf("unexpected migrated task: %+v", got)
+	}
+	versions, err := to.ListTaskVersions(ctx, ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	for _, runID := range []platform.ID{101, 102} {
+		want, err := fromRuns.FindRunByID(ctx, task.Org, runID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := toRuns.FindRunByID(ctx, task.Org, runID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *got != *want {
+			t.Fatalf("run %s: expected %+v, got %+v", runID, want, got)
+		}
+	}
+
+	// Running again, or resuming after the last task, copies nothing new.
+	if _, err := m.Migrate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	run, err := toRuns.FindRunByID(ctx, task.Org, 101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Log != "1970-01-01T00:01:02Z: line\nwrapped\n1970-01-01T00:01:02.000000001Z: line\nwrapped\n1970-01-01T00:01:02.000000002Z: line\nwrapped" {
+		t.Fatalf("unexpected log after migrating twice: %q", run.Log)
+	}
+	m, err = migrate.New(from, to, migrate.ResumeAfter(res.LastTask))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := m.Migrate(ctx); err != nil || res.Tasks != 0 {
+		t.Fatalf("expected no tasks after resuming at the end, got %+v, %v", res, err)
+	}
+}
+
+func TestMigrator_DivergedLog(t *testing.T) {
+	ctx := context.Background()
+	from, fromRuns := backend.NewInMemStore(), backend.NewInMemRunReaderWriter()
+	to, toRuns := backend.NewInMemStore(), backend.NewInMemRunReaderWriter()
+
+	id, err := from.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := from.FindTaskByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rlb := backend.RunLogBase{Task: task, RunID: platform.ID(5), RunScheduledFor: 60}
+	for _, rw := range []backend.LogWriter{fromRuns, toRuns} {
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(61, 0), backend.RunStarted); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fromRuns.AddRunLog(ctx, rlb, time.Unix(62, 0).UTC(), "source"); err != nil {
+		t.Fatal(err)
+	}
+	if err := toRuns.AddRunLog(ctx, rlb, time.Unix(62, 0).UTC(), "destination"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := migrate.New(from, to, migrate.WithRuns(fromRuns, toRuns))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := m.Migrate(ctx); err == nil || res.Tasks != 0 {
+		t.Fatalf("expected an error and no migrated tasks, got %+v, %v", res, err)
+	}
+}
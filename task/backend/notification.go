@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/influxdata/platform/task/options"
+)
+
+// IsZero reports whether n requests no notifications.
+func (n *StoreTaskMetaNotification) IsZero() bool {
+	return n == nil || (!n.OnFailure && !n.OnSLAMiss && !n.OnRecovery)
+}
+
+// Validate returns an error if n has an invalid target.
+// Targets are set by task owners rather than the server's operator,
+// so webhook URLs are held to the same rules as the notify option in a task's script.
+func (n *StoreTaskMetaNotification) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if err := options.ValidateNotifyTarget(n.Target); err != nil {
+		return fmt.Errorf("notification target %s", err.Error())
+	}
+	return nil
+}
+
+// ApplyNotification replaces the notification settings of stm as requested by the update req, if req sets them.
+// Settings that request no notifications remove any existing settings.
+func (stm *StoreTaskMeta) ApplyNotification(req UpdateTaskRequest) {
+	if req.Notification == nil {
+		return
+	}
+
+	if req.Notification.IsZero() {
+		stm.Notification = nil
+		return
+	}
+	stm.Notification = req.Notification.clone()
+}
+
+// runNotification returns a copy of stm's notification settings for a run created from stm, or nil if it has none.
+func (stm *StoreTaskMeta) runNotification() *StoreTaskMetaNotification {
+	if stm.Notification.IsZero() {
+		return nil
+	}
+	return stm.Notification.clone()
+}
+
+// clone returns a copy of n, so that the copy is unaffected by changes to n.
+func (n *StoreTaskMetaNotification) clone() *StoreTaskMetaNotification {
+	return &StoreTaskMetaNotification{
+		OnFailure:  n.OnFailure,
+		OnSLAMiss:  n.OnSLAMiss,
+		OnRecovery: n.OnRecovery,
+		Target:     n.Target,
+	}
+}
@@ -0,0 +1,448 @@
+// Package notify sends notifications about failed, late, and recovered task runs to webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultTimeout is how long a notification may take to deliver, unless set with WithTimeout.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultWorkers is how many notifications are delivered at once, unless set with WithWorkers.
+	DefaultWorkers = 4
+
+	// DefaultQueueSize is how many notifications may wait for delivery, unless set with WithQueueSize.
+	DefaultQueueSize = 1000
+)
+
+// RunFailure is the event posted, JSON-encoded, when a run fails, times out, or misses its task's SLA,
+// or, for tasks whose notification settings ask for it, when a run succeeds after the task's previous run failed.
+type RunFailure struct {
+	TaskID   platform.ID `json:"taskID"`
+	TaskName string      `json:"taskName"`
+	OrgID    platform.ID `json:"orgID"`
+	RunID    platform.ID `json:"runID"`
+
+	// Status is "failed", "timedout", "overdue" for a run that missed its SLA, or "recovered" for a run that recovered.
+	Status string `json:"status"`
+
+	// ScheduledFor is the time the run was scheduled for, which is the end of the window of data it covers.
+	ScheduledFor string `json:"scheduledFor"`
+	// RequestedAt is set when the run was manually requested.
+	RequestedAt string `json:"requestedAt,omitempty"`
+	// StartedAt and FinishedAt are not set on overdue events.
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	// Deadline is when an overdue run was expected to have finished by.
+	Deadline string `json:"deadline,omitempty"`
+
+	Error string `json:"error"`
+}
+
+const (
+	// StatusOverdue is the status of the RunFailure posted when a run misses its task's SLA.
+	StatusOverdue = "overdue"
+
+	// StatusRecovered is the status of the RunFailure posted when a run succeeds after the task's previous run failed.
+	StatusRecovered = "recovered"
+)
+
+// Notifier posts a RunFailure to the task's notification target whenever a run fails, times out,
+// or is still running past the deadline set by its task's sla option.
+// A task's target is set with the notify option in its script;
+// tasks without the option use their organization's target, if one is set.
+// A target is either an http or https webhook URL, or the ID of an endpoint registered with WithEndpoint.
+//
+// A task with notification settings in its meta, which each run carries as its QueuedRun's Notification,
+// is instead notified of only the events the settings ask for, at the settings' target if they have one:
+// failures, SLA misses, and recoveries, when a run succeeds after the task's previous run failed.
+// Recoveries are detected from the runs the Notifier observes,
+// so the first run of a task that a Notifier observes is never reported as a recovery.
+//
+// Webhook URLs in task scripts and notification settings are written by task authors rather than the server's operator,
+// so they are only delivered to public addresses, as reported by options.PublicIP.
+// Endpoints and organization targets are configured on the server, and may be on any address.
+//
+// Notifications are delivered by a bounded number of workers from a bounded queue;
+// when the queue is full, further notifications are dropped and logged.
+type Notifier struct {
+	logger    *zap.Logger
+	client    *http.Client // Used for server-configured targets.
+	urlClient *http.Client // Used for webhook URLs in task scripts.
+	timeout   time.Duration
+
+	mu         sync.RWMutex
+	endpoints  map[string]string      // Endpoint ID -> URL.
+	orgTargets map[platform.ID]string // Org ID -> target.
+
+	failingMu sync.Mutex
+	failing   map[platform.ID]bool // IDs of tasks with recovery notifications whose latest observed run failed.
+
+	queue      chan delivery
+	maxWorkers int
+
+	workersMu sync.Mutex
+	workers   int // Number of running workers.
+
+	wg sync.WaitGroup
+}
+
+// delivery is a notification waiting in the queue.
+type delivery struct {
+	client *http.Client
+	url    string
+	ev     RunFailure
+}
+
+var _ backend.SLAObserver = (*Notifier)(nil)
+
+// Option is a option you can use to modify the notifier's behavior.
+type Option func(*Notifier)
+
+// WithLogger sets the logger for the notifier.
+func WithLogger(logger *zap.Logger) Option {
+	return func(n *Notifier) {
+		n.logger = logger
+	}
+}
+
+// WithHTTPClient sets the client used to post notifications to endpoints and organization targets.
+func WithHTTPClient(c *http.Client) Option {
+	return func(n *Notifier) {
+		n.client = c
+	}
+}
+
+// WithURLClient sets the client used to post notifications to webhook URLs in task scripts.
+// The default client refuses to connect to addresses that are not public;
+// a client set here is responsible for doing the same.
+func WithURLClient(c *http.Client) Option {
+	return func(n *Notifier) {
+		n.urlClient = c
+	}
+}
+
+// WithWorkers sets how many notifications are delivered at once.
+// Values less than 1 have no effect.
+func WithWorkers(workers int) Option {
+	return func(n *Notifier) {
+		if workers > 0 {
+			n.maxWorkers = workers
+		}
+	}
+}
+
+// WithQueueSize sets how many notifications may wait for delivery before further notifications are dropped.
+// Values less than 1 have no effect.
+func WithQueueSize(size int) Option {
+	return func(n *Notifier) {
+		if size > 0 {
+			n.queue = make(chan delivery, size)
+		}
+	}
+}
+
+// WithTimeout sets how long a notification may take to deliver.
+func WithTimeout(d time.Duration) Option {
+	return func(n *Notifier) {
+		n.timeout = d
+	}
+}
+
+// WithEndpoint registers the webhook URL for the endpoint ID id,
+// so that tasks and organizations can refer to it by ID.
+func WithEndpoint(id, url string) Option {
+	return func(n *Notifier) {
+		n.endpoints[id] = url
+	}
+}
+
+// WithOrgTarget sets the notification target of the organization with the given ID.
+func WithOrgTarget(orgID platform.ID, target string) Option {
+	return func(n *Notifier) {
+		n.orgTargets[orgID] = target
+	}
+}
+
+// New returns a Notifier.
+func New(opts ...Option) *Notifier {
+	n := &Notifier{
+		logger:     zap.NewNop(),
+		client:     http.DefaultClient,
+		urlClient:  publicClient(),
+		timeout:    DefaultTimeout,
+		endpoints:  make(map[string]string),
+		orgTargets: make(map[platform.ID]string),
+		failing:    make(map[platform.ID]bool),
+		queue:      make(chan delivery, DefaultQueueSize),
+		maxWorkers: DefaultWorkers,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// SetOrgTarget sets the notification target of the organization with the given ID.
+// An empty target removes the organization's target.
+func (n *Notifier) SetOrgTarget(orgID platform.ID, target string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if target == "" {
+		delete(n.orgTargets, orgID)
+		return
+	}
+	n.orgTargets[orgID] = target
+}
+
+// ObserveRun posts a RunFailure to the task's notification target, if the run failed or timed out,
+// or if it recovered and the run's notification settings ask for recoveries.
+// The notification is queued for delivery on a separate goroutine; use Wait to block until delivery is done.
+func (n *Notifier) ObserveRun(_ context.Context, task *backend.StoreTask, qr backend.QueuedRun, o backend.RunOutcome) {
+	failed := o.Status == backend.RunFail || o.Status == backend.RunTimedOut
+	status := o.Status.String()
+	if settings := qr.Notification; settings != nil {
+		recovered := n.trackRecovery(task.ID, settings, o.Status)
+		switch {
+		case failed && settings.OnFailure:
+		case recovered:
+			status = StatusRecovered
+		default:
+			return
+		}
+	} else if !failed {
+		return
+	}
+
+	ev := RunFailure{
+		TaskID:       task.ID,
+		TaskName:     task.Name,
+		OrgID:        task.Org,
+		RunID:        qr.RunID,
+		Status:       status,
+		ScheduledFor: time.Unix(qr.Now, 0).UTC().Format(time.RFC3339),
+		StartedAt:    o.StartedAt.UTC().Format(time.RFC3339Nano),
+		FinishedAt:   o.FinishedAt.UTC().Format(time.RFC3339Nano),
+		Error:        o.Error,
+	}
+	if qr.RequestedAt != 0 {
+		ev.RequestedAt = time.Unix(qr.RequestedAt, 0).UTC().Format(time.RFC3339)
+	}
+	n.enqueue(task, qr.Notification, ev)
+}
+
+// trackRecovery records whether the latest run of the task with the given ID, which finished with status, failed,
+// if settings ask for recoveries. It reports whether the run succeeded after the task's previous observed run failed.
+func (n *Notifier) trackRecovery(taskID platform.ID, settings *backend.StoreTaskMetaNotification, status backend.RunStatus) bool {
+	n.failingMu.Lock()
+	defer n.failingMu.Unlock()
+
+	if !settings.OnRecovery {
+		delete(n.failing, taskID)
+		return false
+	}
+
+	switch status {
+	case backend.RunFail, backend.RunTimedOut:
+		n.failing[taskID] = true
+	case backend.RunSuccess:
+		if n.failing[taskID] {
+			delete(n.failing, taskID)
+			return true
+		}
+	}
+	return false
+}
+
+// ObserveSLAMiss posts a RunFailure with status StatusOverdue to the task's notification target,
+// when the run qr did not finish by the Unix timestamp deadline, unless the run's notification settings do not ask for SLA misses.
+// Like ObserveRun, the notification is queued for delivery on a separate goroutine.
+func (n *Notifier) ObserveSLAMiss(_ context.Context, task *backend.StoreTask, qr backend.QueuedRun, deadline int64) {
+	if qr.Notification != nil && !qr.Notification.OnSLAMiss {
+		return
+	}
+
+	ev := RunFailure{
+		TaskID:       task.ID,
+		TaskName:     task.Name,
+		OrgID:        task.Org,
+		RunID:        qr.RunID,
+		Status:       StatusOverdue,
+		ScheduledFor: time.Unix(qr.Now, 0).UTC().Format(time.RFC3339),
+		Deadline:     time.Unix(deadline, 0).UTC().Format(time.RFC3339),
+		Error:        "run did not finish by the deadline set by the task's sla option",
+	}
+	if qr.RequestedAt != 0 {
+		ev.RequestedAt = time.Unix(qr.RequestedAt, 0).UTC().Format(time.RFC3339)
+	}
+	n.enqueue(task, qr.Notification, ev)
+}
+
+// enqueue queues ev for delivery to the notification target of task, which has the notification settings settings,
+// if it has a target, and starts a worker if one is free.
+func (n *Notifier) enqueue(task *backend.StoreTask, settings *backend.StoreTaskMetaNotification, ev RunFailure) {
+	url, fromTask, ok := n.resolve(task, settings)
+	if !ok {
+		return
+	}
+	client := n.client
+	if fromTask {
+		client = n.urlClient
+	}
+
+	n.wg.Add(1)
+	select {
+	case n.queue <- delivery{client: client, url: url, ev: ev}:
+	default:
+		n.wg.Done()
+		n.logger.Info("Dropped run notification; delivery queue is full",
+			zap.String("task_id", task.ID.String()), zap.String("run_id", ev.RunID.String()), zap.String("status", ev.Status))
+		return
+	}
+
+	n.workersMu.Lock()
+	if n.workers < n.maxWorkers {
+		n.workers++
+		go n.work()
+	}
+	n.workersMu.Unlock()
+}
+
+// work delivers queued notifications until the queue is empty.
+func (n *Notifier) work() {
+	for {
+		select {
+		case d := <-n.queue:
+			if err := n.post(d.client, d.url, d.ev); err != nil {
+				n.logger.Info("Failed to send run notification",
+					zap.String("task_id", d.ev.TaskID.String()), zap.String("run_id", d.ev.RunID.String()), zap.Error(err))
+			}
+			n.wg.Done()
+		default:
+			// Check the queue again while holding the lock, so that enqueue starts a new worker
+			// for anything queued after this worker exits.
+			n.workersMu.Lock()
+			if len(n.queue) == 0 {
+				n.workers--
+				n.workersMu.Unlock()
+				return
+			}
+			n.workersMu.Unlock()
+		}
+	}
+}
+
+// Wait blocks until all queued notifications are delivered or have failed.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// resolve returns the webhook URL to notify about runs of task, which has the notification settings settings,
+// and whether the URL was set on the task, by its script or its settings, rather than configured on the server.
+func (n *Notifier) resolve(task *backend.StoreTask, settings *backend.StoreTaskMetaNotification) (url string, fromTask bool, ok bool) {
+	var target string
+	if settings != nil {
+		target = settings.Target
+	}
+	if target == "" {
+		if opts, err := task.ScriptOptions(); err == nil {
+			target = opts.Notify
+		}
+	}
+	fromTask = target != ""
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if target == "" {
+		target = n.orgTargets[task.Org]
+	}
+	if target == "" {
+		return "", false, false
+	}
+	if strings.Contains(target, "://") {
+		return target, fromTask, true
+	}
+
+	url, ok = n.endpoints[target]
+	if !ok {
+		n.logger.Info("Unknown notification endpoint", zap.String("task_id", task.ID.String()), zap.String("endpoint", target))
+	}
+	// The endpoint's URL is configured on the server, even when the task names the endpoint.
+	return url, false, ok
+}
+
+// publicClient returns a client that refuses to connect to addresses that are not public,
+// checked when dialing so that neither DNS answers nor redirects can lead it to an internal address.
+func publicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !options.PublicIP(ip) {
+				return fmt.Errorf("refusing to send notification to non-public address %s", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 nil, // A proxy would make the connection on the client's behalf, bypassing the check.
+			DialContext:           dialer.DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+}
+
+// post sends ev to url using client.
+func (n *Notifier) post(client *http.Client, url string, ev RunFailure) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification endpoint responded with status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/notify"
+	"github.com/influxdata/platform/task/options"
+)
+
+// recorder is a webhook that records the events posted to each path.
+type recorder struct {
+	mu     sync.Mutex
+	events map[string][]notify.RunFailure
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var ev notify.RunFailure
+	if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.events[r.URL.Path] = append(rec.events[r.URL.Path], ev)
+}
+
+func (rec *recorder) eventsFor(path string) []notify.RunFailure {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.events[path]
+}
+
+// clientFor returns a client that sends every request to srv, whatever the host in its URL,
+// standing in for the public webhooks that task scripts may notify.
+func clientFor(srv *httptest.Server) *http.Client {
+	addr := srv.Listener.Addr().String()
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+func TestNotifier_ObserveRun(t *testing.T) {
+	rec := &recorder{events: make(map[string][]notify.RunFailure)}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	const org = platform.ID(1)
+	n := notify.New(
+		notify.WithEndpoint("ops", srv.URL+"/ops"),
+		notify.WithOrgTarget(org, srv.URL+"/org"),
+		notify.WithURLClient(clientFor(srv)),
+	)
+
+	script := func(notifyOpt string) string {
+		if notifyOpt == "" {
+			return `option task = {name: "x", every: 1m}
+from(bucket: "b") |> range(start: -1m)`
+		}
+		return `option task = {name: "x", every: 1m, notify: "` + notifyOpt + `"}
+from(bucket: "b") |> range(start: -1m)`
+	}
+
+	start := time.Unix(1000, 0)
+	failed := backend.RunOutcome{RunID: 10, Status: backend.RunFail, StartedAt: start, FinishedAt: start.Add(time.Second), Error: "boom"}
+	qr := backend.QueuedRun{TaskID: 2, RunID: 10, Now: 960}
+
+	// Task with a webhook URL.
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 2, Org: org, Name: "x", Script: script("http://hooks.example.com/task")}, qr, failed)
+	// Task with an endpoint ID.
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 3, Org: org, Name: "x", Script: script("ops")}, qr, failed)
+	// Task without a target falls back to its org's.
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 4, Org: org, Name: "x", Script: script("")}, qr, failed)
+	// Successful runs are not notified.
+	ok := failed
+	ok.Status, ok.Error = backend.RunSuccess, ""
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 5, Org: org, Name: "x", Script: script("http://hooks.example.com/ok")}, qr, ok)
+	// Tasks in other orgs without a target are not notified.
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 6, Org: 99, Name: "x", Script: script("")}, qr, failed)
+	n.Wait()
+
+	evs := rec.eventsFor("/task")
+	if len(evs) != 1 {
+		t.Fatalf("expected 1 event for task webhook, got %d", len(evs))
+	}
+	exp := notify.RunFailure{
+		TaskID:       2,
+		TaskName:     "x",
+		OrgID:        org,
+		RunID:        10,
+		Status:       "failed",
+		ScheduledFor: "1970-01-01T00:16:00Z",
+		StartedAt:    "1970-01-01T00:16:40Z",
+		FinishedAt:   "1970-01-01T00:16:41Z",
+		Error:        "boom",
+	}
+	if evs[0] != exp {
+		t.Fatalf("unexpected event: got %#v, want %#v", evs[0], exp)
+	}
+
+	if evs := rec.eventsFor("/ops"); len(evs) != 1 || evs[0].TaskID != 3 {
+		t.Fatalf("expected 1 event for task 3 at endpoint, got %#v", evs)
+	}
+	if evs := rec.eventsFor("/org"); len(evs) != 1 || evs[0].TaskID != 4 {
+		t.Fatalf("expected 1 event for task 4 at org target, got %#v", evs)
+	}
+	if evs := rec.eventsFor("/ok"); len(evs) != 0 {
+		t.Fatalf("expected no events for successful run, got %#v", evs)
+	}
+
+	// Removing the org target stops fallback notifications.
+	n.SetOrgTarget(org, "")
+	n.ObserveRun(context.Background(), &backend.StoreTask{ID: 4, Org: org, Name: "x", Script: script("")}, qr, failed)
+	n.Wait()
+	if evs := rec.eventsFor("/org"); len(evs) != 1 {
+		t.Fatalf("expected no more events after removing org target, got %#v", evs)
+	}
+}
+
+func TestNotifier_ObserveSLAMiss(t *testing.T) {
+	rec := &recorder{events: make(map[string][]notify.RunFailure)}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := notify.New(notify.WithEndpoint("ops", srv.URL+"/ops"))
+
+	task := &backend.StoreTask{ID: 2, Org: 1, Name: "x", Script: `option task = {name: "x", every: 1h, sla: 30m, notify: "ops"}
+from(bucket: "b") |> range(start: -1h)`}
+	n.ObserveSLAMiss(context.Background(), task, backend.QueuedRun{TaskID: 2, RunID: 10, Now: 3600}, 5400)
+	n.Wait()
+
+	evs := rec.eventsFor("/ops")
+	if len(evs) != 1 {
+		t.Fatalf("expected 1 event for endpoint, got %d", len(evs))
+	}
+	exp := notify.RunFailure{
+		TaskID:       2,
+		TaskName:     "x",
+		OrgID:        1,
+		RunID:        10,
+		Status:       notify.StatusOverdue,
+		ScheduledFor: "1970-01-01T01:00:00Z",
+		Deadline:     "1970-01-01T01:30:00Z",
+		Error:        "run did not finish by the deadline set by the task's sla option",
+	}
+	if evs[0] != exp {
+		t.Fatalf("unexpected event: got %#v, want %#v", evs[0], exp)
+	}
+}
+
+func TestNotifier_Settings(t *testing.T) {
+	rec := &recorder{events: make(map[string][]notify.RunFailure)}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	const org = platform.ID(1)
+	n := notify.New(
+		notify.WithEndpoint("ops", srv.URL+"/ops"),
+		notify.WithOrgTarget(org, srv.URL+"/org"),
+		notify.WithURLClient(clientFor(srv)),
+	)
+
+	// The settings take precedence over the notify option in the script.
+	task := &backend.StoreTask{ID: 2, Org: org, Name: "x", Script: `option task = {name: "x", every: 1m, notify: "ops"}
+from(bucket: "b") |> range(start: -1m)`}
+	settings := &backend.StoreTaskMetaNotification{OnRecovery: true, OnSLAMiss: false, Target: "http://hooks.example.com/settings"}
+
+	start := time.Unix(1000, 0)
+	outcome := func(status backend.RunStatus) backend.RunOutcome {
+		return backend.RunOutcome{RunID: 10, Status: status, StartedAt: start, FinishedAt: start.Add(time.Second)}
+	}
+	qr := backend.QueuedRun{TaskID: 2, RunID: 10, Now: 960, Notification: settings}
+
+	// The first success is not a recovery, and failures are not notified without OnFailure.
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunSuccess))
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunFail))
+	n.Wait()
+	if evs := rec.eventsFor("/settings"); len(evs) != 0 {
+		t.Fatalf("expected no events before recovery, got %#v", evs)
+	}
+
+	// Canceled runs neither fail nor recover.
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunCanceled))
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunSuccess))
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunSuccess))
+	n.ObserveSLAMiss(context.Background(), task, qr, 1020)
+	n.Wait()
+	evs := rec.eventsFor("/settings")
+	if len(evs) != 1 || evs[0].Status != notify.StatusRecovered {
+		t.Fatalf("expected 1 recovery event, got %#v", evs)
+	}
+	if evs := rec.eventsFor("/ops"); len(evs) != 0 {
+		t.Fatalf("expected no events at the script's target, got %#v", evs)
+	}
+
+	// Settings without a target fall back to the script's.
+	qr.Notification = &backend.StoreTaskMetaNotification{OnFailure: true, OnSLAMiss: true}
+	n.ObserveRun(context.Background(), task, qr, outcome(backend.RunTimedOut))
+	n.ObserveSLAMiss(context.Background(), task, qr, 1020)
+	n.Wait()
+	if evs := rec.eventsFor("/ops"); len(evs) != 2 || evs[0].Status != "timedout" || evs[1].Status != notify.StatusOverdue {
+		t.Fatalf("expected timed out and overdue events at the script's target, got %#v", evs)
+	}
+}
+
+func TestNotifier_NonPublicURL(t *testing.T) {
+	rec := &recorder{events: make(map[string][]notify.RunFailure)}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	n := notify.New()
+
+	// The task's options were stored before URLs on non-public addresses were rejected,
+	// so the notifier must refuse to connect to the loopback address itself.
+	task := &backend.StoreTask{ID: 2, Org: 1, Name: "x", Options: &options.Options{Notify: srv.URL + "/task"}}
+	start := time.Unix(1000, 0)
+	failed := backend.RunOutcome{RunID: 10, Status: backend.RunFail, StartedAt: start, FinishedAt: start.Add(time.Second), Error: "boom"}
+	n.ObserveRun(context.Background(), task, backend.QueuedRun{TaskID: 2, RunID: 10, Now: 960}, failed)
+	n.Wait()
+
+	if evs := rec.eventsFor("/task"); len(evs) != 0 {
+		t.Fatalf("expected no events at loopback webhook, got %#v", evs)
+	}
+}
+
+func TestNotifier_Queue(t *testing.T) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	delivered := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		mu.Lock()
+		delivered++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	const org = platform.ID(1)
+	n := notify.New(notify.WithOrgTarget(org, srv.URL), notify.WithWorkers(1), notify.WithQueueSize(1))
+
+	task := &backend.StoreTask{ID: 2, Org: org, Name: "x", Script: `option task = {name: "x", every: 1m}
+from(bucket: "b") |> range(start: -1m)`}
+	start := time.Unix(1000, 0)
+	failed := backend.RunOutcome{RunID: 10, Status: backend.RunFail, StartedAt: start, FinishedAt: start.Add(time.Second), Error: "boom"}
+	qr := backend.QueuedRun{TaskID: 2, RunID: 10, Now: 960}
+
+	// The only worker picks up the first notification, and the second waits in the queue.
+	n.ObserveRun(context.Background(), task, qr, failed)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for first notification")
+	}
+	n.ObserveRun(context.Background(), task, qr, failed)
+
+	// The queue is full, so the third notification is dropped.
+	n.ObserveRun(context.Background(), task, qr, failed)
+
+	close(release)
+	n.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if delivered != 2 {
+		t.Fatalf("expected 2 notifications delivered, got %d", delivered)
+	}
+}
@@ -0,0 +1,145 @@
+package backend
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/options"
+	"go.uber.org/zap"
+)
+
+// overlapTracker tracks which scheduled runs of a task are executing, and when its latest finished one executed,
+// to apply the task's overlap policy to scheduled runs as they come due.
+// Times are the scheduler's ticks, as Unix timestamps.
+type overlapTracker struct {
+	mu sync.Mutex
+
+	// The tick at which each executing scheduled run started, by run ID.
+	executing map[platform.ID]int64
+
+	// The latest scheduled run to finish, and the ticks at which it started and finished.
+	last               platform.ID
+	lastStart, lastEnd int64
+}
+
+func newOverlapTracker() *overlapTracker {
+	return &overlapTracker{
+		executing: make(map[platform.ID]int64),
+		lastStart: math.MinInt64,
+		lastEnd:   math.MinInt64,
+	}
+}
+
+// isScheduled reports whether qr was created by the task's schedule, rather than manually requested or retried.
+// Overlap policies only apply to scheduled runs.
+func isScheduled(qr QueuedRun) bool {
+	return qr.RequestedAt == 0 && !qr.RetryOf.Valid()
+}
+
+// start records that qr began executing at the tick now.
+func (t *overlapTracker) start(qr QueuedRun, now int64) {
+	if !isScheduled(qr) {
+		return
+	}
+	t.mu.Lock()
+	t.executing[qr.RunID] = now
+	t.mu.Unlock()
+}
+
+// finish records that the run with the given ID stopped executing at the tick now.
+func (t *overlapTracker) finish(runID platform.ID, now int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start, ok := t.executing[runID]
+	if !ok {
+		// Not a scheduled run, or one already canceled for overlapping.
+		return
+	}
+	delete(t.executing, runID)
+	t.last, t.lastStart, t.lastEnd = runID, start, now
+}
+
+// overlapping returns the ID of a scheduled run, other than qr, that was executing when qr came due at the tick due:
+// one still executing, or the latest to finish if it executed through due.
+// It returns false if no such run exists.
+func (t *overlapTracker) overlapping(qr QueuedRun, due int64) (platform.ID, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id := range t.executing {
+		if id != qr.RunID {
+			return id, true
+		}
+	}
+	if t.last.Valid() && due >= t.lastStart && due <= t.lastEnd {
+		return t.last, true
+	}
+	return platform.InvalidID(), false
+}
+
+// takeExecuting stops tracking the executing scheduled runs other than except, and returns their IDs,
+// so that they can be canceled for overlapping a newer run.
+func (t *overlapTracker) takeExecuting(except platform.ID) []platform.ID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var ids []platform.ID
+	for id := range t.executing {
+		if id != except {
+			ids = append(ids, id)
+			delete(t.executing, id)
+		}
+	}
+	return ids
+}
+
+// dueTick returns the tick at which the scheduled run qr came due: the time it was scheduled for, plus the task's offset.
+func (ts *taskScheduler) dueTick(qr QueuedRun) int64 {
+	return qr.Now + int64(ts.opts.Offset/time.Second)
+}
+
+// skipOverlapping reports whether the scheduled run qr should not be executed under the task's overlap policy,
+// because it came due while a previous scheduled run was executing.
+// If so, it also returns the message to record in qr's log.
+func (ts *taskScheduler) skipOverlapping(qr QueuedRun) (string, bool) {
+	if ts.opts.OverlapPolicy() != options.OverlapSkip || !isScheduled(qr) {
+		return "", false
+	}
+	prev, ok := ts.overlap.overlapping(qr, ts.dueTick(qr))
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("Skipped: run %s was still executing when this run came due", prev), true
+}
+
+// cancelOverlapped cancels the task's scheduled runs that are still executing when a newer scheduled run comes due,
+// if the task's overlap policy is to cancel them, adding why to each canceled run's log.
+// The newer run, with ID newRunID if it has been created, is described by cause and never canceled itself.
+func (r *runner) cancelOverlapped(newRunID platform.ID, cause string) {
+	ts := r.ts
+	if ts.opts.OverlapPolicy() != options.OverlapCancel {
+		return
+	}
+
+	for _, id := range ts.overlap.takeExecuting(newRunID) {
+		ts.runningMu.Lock()
+		rc, ok := ts.running[id]
+		ts.runningMu.Unlock()
+		if !ok {
+			continue
+		}
+
+		runLogger := r.logger.With(zap.String("run_id", id.String()), zap.Int64("now", rc.run.Now))
+		runLogger.Info("Canceling run overlapped by a newer scheduled run")
+		r.addRunLogEvent(r.runLogBase(rc.run), RunLogEvent{
+			Type:    RunLogMessage,
+			Time:    ts.clock.Now(),
+			Message: fmt.Sprintf("Canceled: %s while this run was executing", cause),
+		}, runLogger)
+		rc.CancelFunc()
+	}
+}
@@ -14,6 +14,8 @@ const (
 	runIDField        = "runID"
 	scheduledForField = "scheduledFor"
 	requestedAtField  = "requestedAt"
+	retryOfField      = "retryOf"
+	traceIDField      = "traceID"
 
 	taskIDTag = "taskID"
 	statusTag = "status"
@@ -49,6 +51,12 @@ func (p *PointLogWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, whe
 	if rlb.RequestedAt != 0 {
 		fields[requestedAtField] = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
 	}
+	if rlb.RetryOf.Valid() {
+		fields[retryOfField] = rlb.RetryOf.String()
+	}
+	if rlb.TraceID != "" {
+		fields[traceIDField] = rlb.TraceID
+	}
 
 	pt, err := models.NewPoint("records", tags, fields, when)
 	if err != nil {
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migrations are the schema changes applied by Migrate, in order.
+// The version of a migration is its index in the slice plus one.
+// Released migrations must never be edited or reordered; add a new migration instead.
+var migrations = []string{
+	// 1: tasks, with their metadata, versions and leases.
+	`CREATE TABLE tasks (
+		id       CHAR(16) PRIMARY KEY,
+		org_id   CHAR(16) NOT NULL,
+		user_id  CHAR(16) NOT NULL,
+		name     TEXT NOT NULL,
+		script   TEXT NOT NULL,
+		labels   JSONB,
+		versions TEXT NOT NULL,
+		meta     BYTEA NOT NULL
+	);
+	CREATE INDEX tasks_org_id_idx ON tasks (org_id, id);
+	CREATE INDEX tasks_user_id_idx ON tasks (user_id, id);
+	CREATE INDEX tasks_labels_idx ON tasks USING GIN (labels);
+
+	CREATE TABLE task_leases (
+		task_id    CHAR(16) PRIMARY KEY REFERENCES tasks (id) ON DELETE CASCADE,
+		owner      TEXT NOT NULL,
+		expires_at BIGINT NOT NULL
+	);
+
+	CREATE TABLE task_lease_owners (
+		owner      TEXT PRIMARY KEY,
+		expires_at BIGINT NOT NULL
+	);
+
+	CREATE TABLE task_leader (
+		id         INTEGER PRIMARY KEY CHECK (id = 1),
+		owner      TEXT NOT NULL,
+		expires_at BIGINT NOT NULL
+	);`,
+
+	// 2: runs and their logs.
+	`CREATE TABLE task_runs (
+		id            CHAR(16) PRIMARY KEY,
+		task_id       CHAR(16) NOT NULL,
+		org_id        CHAR(16) NOT NULL,
+		status        TEXT NOT NULL,
+		scheduled_for TEXT NOT NULL,
+		requested_at  TEXT NOT NULL DEFAULT '',
+		started_at    TEXT NOT NULL DEFAULT '',
+		finished_at   TEXT NOT NULL DEFAULT '',
+		log           TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX task_runs_task_id_idx ON task_runs (task_id, id);`,
+
+	// 3: run history, for task statistics.
+	`ALTER TABLE tasks ADD COLUMN run_history TEXT NOT NULL DEFAULT '';`,
+
+	// 4: audit log. Entries outlive their tasks, so task_id does not reference tasks.
+	`CREATE TABLE task_audit (
+		seq     BIGSERIAL PRIMARY KEY,
+		org_id  CHAR(16) NOT NULL,
+		task_id CHAR(16) NOT NULL,
+		at      BIGINT NOT NULL,
+		entry   TEXT NOT NULL
+	);
+	CREATE INDEX task_audit_org_id_idx ON task_audit (org_id, seq);`,
+
+	// 5: idempotency keys of created tasks, deleted along with their tasks.
+	`CREATE TABLE task_idempotency_keys (
+		org_id  CHAR(16) NOT NULL,
+		key     TEXT NOT NULL,
+		task_id CHAR(16) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+		PRIMARY KEY (org_id, key)
+	);`,
+
+	// 6: options parsed from task scripts. Empty for tasks stored before the column was added.
+	`ALTER TABLE tasks ADD COLUMN options TEXT NOT NULL DEFAULT '';`,
+
+	// 7: the run that each run retries, if any.
+	`ALTER TABLE task_runs ADD COLUMN retry_of TEXT NOT NULL DEFAULT '';`,
+
+	// 8: the trace that follows each run, if the tracer reports trace IDs.
+	`ALTER TABLE task_runs ADD COLUMN trace_id TEXT NOT NULL DEFAULT '';`,
+}
+
+// Migrate brings the task schema in db up to date, applying any migrations that have not yet been applied.
+// Each migration is applied in its own transaction, so a failed migration leaves the schema at the previous version.
+// It is safe to call Migrate concurrently from multiple processes.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS task_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at BIGINT NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	for i, m := range migrations {
+		if err := migrate(ctx, db, i+1, m); err != nil {
+			return fmt.Errorf("task schema migration %d failed: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// migrate applies the migration m with the given version, unless it has already been applied.
+func migrate(ctx context.Context, db *sql.DB, version int, m string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Serialize migrations across processes for the rest of the transaction.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE task_migrations IN EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	var applied bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM task_migrations WHERE version = $1)`, version).Scan(&applied); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, m); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO task_migrations (version, applied_at) VALUES ($1, $2)`, version, time.Now().Unix()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
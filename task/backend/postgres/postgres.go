@@ -0,0 +1,1007 @@
+// Package postgres provides a PostgreSQL-backed store implementation.
+//
+// The data stored in Postgres is structured as follows:
+//
+//	table(tasks) row(:task_id) -> The task's org, user, name, script, JSONB labels,
+//	                              (the script, and those of its versions, are encrypted if the store has a cipher),
+//	                              JSON-encoded retained backend.TaskVersions, Protocol Buffer encoded backend.StoreTaskMeta,
+//	                              JSON-encoded backend.TaskRunHistory, which is empty if no run has been recorded,
+//	                              and the JSON-encoded options parsed from the script, which are empty if not recorded.
+//	table(task_leases) row(:task_id) -> The lease owner and its expiration Unix timestamp. Deleted along with the task.
+//	table(task_lease_owners) row(:owner) -> Unix timestamp of when the owner's keep-alive expires.
+//	table(task_leader) row(1) -> The leader lease, if any.
+//	table(task_runs) row(:run_id) -> The run's task, org, status, times, and log, whose lines are encrypted if the RunStore has a cipher.
+//	                                  See RunStore.
+//	table(task_idempotency_keys) row(:org_id, :key) -> The ID of the task created with the idempotency key. Deleted along with the task.
+//	table(task_migrations) row(:version) -> Schema migrations that have been applied. See Migrate.
+//
+// IDs are stored as their 16-character hex strings, so that they sort the same way as the IDs themselves.
+//
+// The package does not import a database driver.
+// Programs using it must import one, such as github.com/lib/pq, and pass the opened *sql.DB to New.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/snowflake"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// ErrRunNotFound is an error for when a run isn't found in a FinishRun method.
+var ErrRunNotFound = errors.New("run not found")
+
+// Store is a task store for PostgreSQL.
+type Store struct {
+	db    *sql.DB
+	idGen platform.IDGenerator
+
+	// Encrypts task scripts at rest, if set. See WithCipher.
+	cipher backend.Cipher
+}
+
+// config holds the settings shared by Store and RunStore.
+type config struct {
+	cipher backend.Cipher
+}
+
+// Option configures a Store or a RunStore.
+type Option func(*config)
+
+// WithCipher encrypts task scripts, including their retained versions, and run logs with c before they are written to Postgres.
+// Values written before c was set are still read as they were.
+func WithCipher(c backend.Cipher) Option {
+	return func(cfg *config) {
+		cfg.cipher = c
+	}
+}
+
+func newConfig(opts []Option) config {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// New returns a Store using db, after migrating the task schema in db to the latest version.
+func New(ctx context.Context, db *sql.DB, opts ...Option) (*Store, error) {
+	if err := Migrate(ctx, db); err != nil {
+		return nil, err
+	}
+	cfg := newConfig(opts)
+	return &Store{db: db, idGen: snowflake.NewDefaultIDGenerator(), cipher: cfg.cipher}, nil
+}
+
+// CreateTask creates a task in the Postgres task store.
+func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (platform.ID, error) {
+	o, err := backend.StoreValidator.CreateArgs(req)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	id := s.idGen.ID()
+
+	labels, err := encodeLabels(req.Labels)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	storedScript, err := backend.EncryptAtRest(s.cipher, req.Script)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	versions, err := s.encodeVersions([]backend.TaskVersion{{Version: 1, Script: req.Script, CreatedAt: time.Now().Unix()}})
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	stm := backend.NewStoreTaskMeta(req, o)
+	stmBytes, err := stm.Marshal()
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	optBytes, err := encodeOptions(&o)
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO tasks (id, org_id, user_id, name, script, labels, versions, meta, options) VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8, $9)`,
+			id.String(), req.Org.String(), req.User.String(), o.Name, storedScript, labels, versions, stmBytes, optBytes,
+		); err != nil {
+			return err
+		}
+
+		if req.IdempotencyKey == "" {
+			return nil
+		}
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO task_idempotency_keys (org_id, key, task_id) VALUES ($1, $2, $3) ON CONFLICT (org_id, key) DO NOTHING`,
+			req.Org.String(), req.IdempotencyKey, id.String(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n > 0 {
+			return err
+		}
+
+		// A task was already created with the key; roll back this one and report the existing task.
+		var existing string
+		if err := tx.QueryRowContext(ctx,
+			`SELECT task_id FROM task_idempotency_keys WHERE org_id = $1 AND key = $2`, req.Org.String(), req.IdempotencyKey,
+		).Scan(&existing); err != nil {
+			return err
+		}
+		if err := id.DecodeFromString(existing); err != nil {
+			return err
+		}
+		return backend.ErrTaskAlreadyCreated
+	})
+	if err == backend.ErrTaskAlreadyCreated {
+		return id, err
+	}
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+
+	return id, nil
+}
+
+func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (backend.UpdateTaskResult, error) {
+	var res backend.UpdateTaskResult
+	op, err := backend.StoreValidator.UpdateArgs(req)
+	if err != nil {
+		return res, err
+	}
+
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		t, stm, versions, err := s.findTaskForUpdate(ctx, tx, req.ID)
+		if err != nil {
+			return err
+		}
+		res.OldScript = t.Script
+		res.OldStatus = backend.TaskStatus(stm.Status)
+
+		script, err := req.NewScript(t.Script)
+		if err != nil {
+			return err
+		}
+		if script != req.Script {
+			// Need to build op from existing or updated script.
+			op, err = options.FromScript(script)
+			if err != nil {
+				return err
+			}
+		}
+		if script != t.Script {
+			versions = backend.AppendTaskVersion(versions, t.Script, script, time.Now().Unix())
+			t.Script = script
+			stm.ApplyOptions(op)
+			opts := op
+			t.Options = &opts
+		}
+		if req.MaxConcurrency > 0 {
+			stm.MaxConcurrency = req.MaxConcurrency
+		}
+		t.Name = op.Name
+
+		if req.Labels != nil {
+			t.Labels = nil
+			if len(req.Labels) > 0 {
+				t.Labels = req.Labels
+			}
+		}
+
+		stm.ApplyStatus(req)
+		stm.ApplyNotification(req)
+
+		if req.Org.Valid() && req.Org != t.Org {
+			// Move the task's runs along with it, so they can still be found through the new org.
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE task_runs SET org_id = $2 WHERE task_id = $1`, req.ID.String(), req.Org.String(),
+			); err != nil {
+				return err
+			}
+			t.Org = req.Org
+		}
+		if req.User.Valid() {
+			t.User = req.User
+		}
+
+		labels, err := encodeLabels(t.Labels)
+		if err != nil {
+			return err
+		}
+		storedScript, err := backend.EncryptAtRest(s.cipher, t.Script)
+		if err != nil {
+			return err
+		}
+		versionsJSON, err := s.encodeVersions(versions)
+		if err != nil {
+			return err
+		}
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+		optBytes, err := encodeOptions(t.Options)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET name = $2, script = $3, labels = $4::jsonb, versions = $5, meta = $6, org_id = $7, user_id = $8, options = $9 WHERE id = $1`,
+			req.ID.String(), t.Name, storedScript, labels, versionsJSON, stmBytes, t.Org.String(), t.User.String(), optBytes,
+		); err != nil {
+			return err
+		}
+
+		res.NewTask = *t
+		res.NewMeta = *stm
+		return nil
+	})
+	return res, err
+}
+
+// EnableTasks sets the tasks with the given IDs to active in a single transaction, and returns the IDs of those that changed.
+func (s *Store) EnableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ctx, ids, backend.TaskActive)
+}
+
+// DisableTasks sets the tasks with the given IDs to inactive in a single transaction, and returns the IDs of those that changed.
+func (s *Store) DisableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error) {
+	return s.setTasksStatus(ctx, ids, backend.TaskInactive)
+}
+
+func (s *Store) setTasksStatus(ctx context.Context, ids []platform.ID, status backend.TaskStatus) ([]platform.ID, error) {
+	var changed []platform.ID
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		changed = changed[:0]
+		for _, id := range ids {
+			var stmBytes []byte
+			err := tx.QueryRowContext(ctx, `SELECT meta FROM tasks WHERE id = $1 FOR UPDATE`, id.String()).Scan(&stmBytes)
+			if err == sql.ErrNoRows {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			var stm backend.StoreTaskMeta
+			if err := stm.Unmarshal(stmBytes); err != nil {
+				return err
+			}
+			if stm.Status == string(status) || stm.Status == string(backend.TaskDeleted) {
+				continue
+			}
+
+			stm.ApplyStatus(backend.UpdateTaskRequest{ID: id, Status: status})
+			stmBytes, err = stm.Marshal()
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE tasks SET meta = $2 WHERE id = $1`, id.String(), stmBytes); err != nil {
+				return err
+			}
+			changed = append(changed, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// ListTasks lists the tasks based on a filter.
+func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams) ([]backend.StoreTaskWithMeta, error) {
+	if params.Org.Valid() && params.User.Valid() {
+		return nil, errors.New("ListTasks: org and user filters are mutually exclusive")
+	}
+
+	if params.PageSize < 0 {
+		return nil, errors.New("ListTasks: PageSize must be positive")
+	}
+	if params.PageSize > platform.TaskMaxPageSize {
+		return nil, fmt.Errorf("ListTasks: PageSize exceeds maximum of %d", platform.TaskMaxPageSize)
+	}
+	lim := params.PageSize
+	if lim == 0 {
+		lim = platform.TaskDefaultPageSize
+	}
+
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if params.Org.Valid() {
+		where = append(where, "org_id = "+arg(params.Org.String()))
+	}
+	if params.User.Valid() {
+		where = append(where, "user_id = "+arg(params.User.String()))
+	}
+	if params.After.Valid() {
+		where = append(where, "id > "+arg(params.After.String()))
+	}
+	if len(params.Labels) > 0 {
+		selector, err := encodeLabels(params.Labels)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, "labels @> "+arg(selector)+"::jsonb")
+	}
+
+	q := `SELECT id, org_id, user_id, name, script, labels, meta, options FROM tasks`
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY id LIMIT " + arg(lim)
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []backend.StoreTaskWithMeta
+	for rows.Next() {
+		var twm backend.StoreTaskWithMeta
+		var id, org, user, opts string
+		var labels, stmBytes []byte
+		if err := rows.Scan(&id, &org, &user, &twm.Task.Name, &twm.Task.Script, &labels, &stmBytes, &opts); err != nil {
+			return nil, err
+		}
+		if err := s.decodeTask(&twm.Task, id, org, user, labels, opts); err != nil {
+			return nil, err
+		}
+		if err := twm.Meta.Unmarshal(stmBytes); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, twm)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindTaskByID finds a task with a given an ID. It will return nil if the task does not exist.
+func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
+	t, _, err := s.FindTaskByIDWithMeta(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *Store) FindTaskMetaByID(ctx context.Context, id platform.ID) (*backend.StoreTaskMeta, error) {
+	var stmBytes []byte
+	err := s.db.QueryRowContext(ctx, `SELECT meta FROM tasks WHERE id = $1`, id.String()).Scan(&stmBytes)
+	if err == sql.ErrNoRows {
+		return nil, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stm backend.StoreTaskMeta
+	if err := stm.Unmarshal(stmBytes); err != nil {
+		return nil, err
+	}
+	return &stm, nil
+}
+
+func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*backend.StoreTask, *backend.StoreTaskMeta, error) {
+	var t backend.StoreTask
+	var org, user, opts string
+	var labels, stmBytes []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT org_id, user_id, name, script, labels, meta, options FROM tasks WHERE id = $1`, id.String(),
+	).Scan(&org, &user, &t.Name, &t.Script, &labels, &stmBytes, &opts)
+	if err == sql.ErrNoRows {
+		return nil, nil, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.decodeTask(&t, id.String(), org, user, labels, opts); err != nil {
+		return nil, nil, err
+	}
+	var stm backend.StoreTaskMeta
+	if err := stm.Unmarshal(stmBytes); err != nil {
+		return nil, nil, err
+	}
+	return &t, &stm, nil
+}
+
+var _ backend.TaskImporter = (*Store)(nil)
+
+// ImportTask stores task along with its meta and versions, replacing any task with the same ID.
+func (s *Store) ImportTask(ctx context.Context, task backend.StoreTask, meta backend.StoreTaskMeta, versions []backend.TaskVersion) error {
+	if err := backend.StoreValidator.ImportArgs(task); err != nil {
+		return err
+	}
+
+	labels, err := encodeLabels(task.Labels)
+	if err != nil {
+		return err
+	}
+	storedScript, err := backend.EncryptAtRest(s.cipher, task.Script)
+	if err != nil {
+		return err
+	}
+	versionsJSON, err := s.encodeVersions(versions)
+	if err != nil {
+		return err
+	}
+	stmBytes, err := meta.Marshal()
+	if err != nil {
+		return err
+	}
+	optBytes, err := encodeOptions(task.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO tasks (id, org_id, user_id, name, script, labels, versions, meta, options) VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			org_id = EXCLUDED.org_id, user_id = EXCLUDED.user_id, name = EXCLUDED.name, script = EXCLUDED.script,
+			labels = EXCLUDED.labels, versions = EXCLUDED.versions, meta = EXCLUDED.meta, options = EXCLUDED.options`,
+		task.ID.String(), task.Org.String(), task.User.String(), task.Name, storedScript, labels, versionsJSON, stmBytes, optBytes,
+	)
+	return err
+}
+
+// ListTaskVersions returns the retained script versions of the task, oldest first.
+func (s *Store) ListTaskVersions(ctx context.Context, id platform.ID) ([]backend.TaskVersion, error) {
+	var v string
+	err := s.db.QueryRowContext(ctx, `SELECT versions FROM tasks WHERE id = $1`, id.String()).Scan(&v)
+	if err == sql.ErrNoRows {
+		return nil, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeVersions(v)
+}
+
+// RecordRunOutcome adds o to the run history of the task, and records it as the task's last run in its meta.
+func (s *Store) RecordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	return s.recordRunOutcome(ctx, taskID, o, false)
+}
+
+// CompleteRun finishes the run o.RunID and records its outcome o, in a single transaction.
+func (s *Store) CompleteRun(ctx context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	return s.recordRunOutcome(ctx, taskID, o, true)
+}
+
+// recordRunOutcome records o in the meta and run history of the task, first finishing the run o.RunID if finish is true.
+func (s *Store) recordRunOutcome(ctx context.Context, taskID platform.ID, o backend.RunOutcome, finish bool) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var v string
+		var stmBytes []byte
+		err := tx.QueryRowContext(ctx, `SELECT run_history, meta FROM tasks WHERE id = $1 FOR UPDATE`, taskID.String()).Scan(&v, &stmBytes)
+		if err == sql.ErrNoRows {
+			return backend.ErrTaskNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		h, err := decodeRunHistory(v)
+		if err != nil {
+			return err
+		}
+		h.Add(o)
+
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		if finish && !stm.FinishRun(o.RunID) {
+			return ErrRunNotFound
+		}
+		stm.RecordRunOutcome(o)
+		h.DropSucceededThrough(stm.LatestCompleted)
+
+		b, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		stmBytes, err = stm.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE tasks SET run_history = $2, meta = $3 WHERE id = $1`, taskID.String(), string(b), stmBytes)
+		return err
+	})
+}
+
+// FindTaskStats returns statistics computed from the run history of the task.
+func (s *Store) FindTaskStats(ctx context.Context, taskID platform.ID) (*backend.TaskStats, error) {
+	var v string
+	err := s.db.QueryRowContext(ctx, `SELECT run_history FROM tasks WHERE id = $1`, taskID.String()).Scan(&v)
+	if err == sql.ErrNoRows {
+		return nil, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := decodeRunHistory(v)
+	if err != nil {
+		return nil, err
+	}
+	stats := h.Stats()
+	return &stats, nil
+}
+
+// RunSucceeded reports whether the run history of the task records a successful run scheduled for now.
+func (s *Store) RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error) {
+	var v string
+	err := s.db.QueryRowContext(ctx, `SELECT run_history FROM tasks WHERE id = $1`, taskID.String()).Scan(&v)
+	if err == sql.ErrNoRows {
+		return false, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	h, err := decodeRunHistory(v)
+	if err != nil {
+		return false, err
+	}
+	return h.HasSucceeded(now), nil
+}
+
+// AppendAuditEntry adds e to the audit log of its org.
+func (s *Store) AppendAuditEntry(ctx context.Context, e backend.AuditEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO task_audit (org_id, task_id, at, entry) VALUES ($1, $2, $3, $4)`,
+		e.Org.String(), e.TaskID.String(), e.Time.UnixNano(), string(b),
+	)
+	return err
+}
+
+// ListAuditEntries lists the entries in the audit log of the org that match params.
+func (s *Store) ListAuditEntries(ctx context.Context, orgID platform.ID, params backend.AuditSearchParams) ([]backend.AuditEntry, error) {
+	args := []interface{}{orgID.String()}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	q := `SELECT entry FROM task_audit WHERE org_id = $1`
+	if params.TaskID.Valid() {
+		q += " AND task_id = " + arg(params.TaskID.String())
+	}
+	if !params.After.IsZero() {
+		q += " AND at > " + arg(params.After.UnixNano())
+	}
+	q += " ORDER BY seq LIMIT " + arg(params.EffectiveLimit())
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []backend.AuditEntry
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		var e backend.AuditEntry
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// DeleteTask deletes the task.
+func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE id = $1`, id.String())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+	var rc backend.RunCreation
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		var err error
+		rc, err = stm.CreateNextRun(now, func() (platform.ID, error) {
+			return s.idGen.ID(), nil
+		})
+		if err != nil {
+			return err
+		}
+		rc.Created.TaskID = taskID
+		return nil
+	})
+	if err != nil {
+		return backend.RunCreation{}, err
+	}
+	return rc, nil
+}
+
+// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
+func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		if !stm.FinishRun(runID) {
+			return ErrRunNotFound
+		}
+		return nil
+	})
+}
+
+func (s *Store) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	var mRun *backend.StoreTaskMetaManualRun
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		makeID := func() (platform.ID, error) { return s.idGen.ID(), nil }
+		if err := stm.ManuallyRunTimeRange(start, end, requestedAt, makeID); err != nil {
+			return err
+		}
+		mRun = stm.ManualRuns[len(stm.ManualRuns)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mRun, nil
+}
+
+func (s *Store) RetryRun(ctx context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	var mRun *backend.StoreTaskMetaManualRun
+	err := s.updateMeta(ctx, taskID, func(stm *backend.StoreTaskMeta) error {
+		makeID := func() (platform.ID, error) { return s.idGen.ID(), nil }
+		if err := stm.RetryRun(scheduledFor, requestedAt, runID, makeID); err != nil {
+			return err
+		}
+		mRun = stm.ManualRuns[len(stm.ManualRuns)-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mRun, nil
+}
+
+// AcquireTaskLease acquires or renews the lease on a task for owner.
+func (s *Store) AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)`, taskID.String()).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return backend.ErrTaskNotFound
+		}
+
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO task_leases (task_id, owner, expires_at) VALUES ($1, $2, $3)
+			ON CONFLICT (task_id) DO UPDATE SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+			WHERE task_leases.owner = EXCLUDED.owner OR task_leases.expires_at <= $4`,
+			taskID.String(), owner, expiresAt, now,
+		)
+		if err != nil {
+			return err
+		}
+		return leaseResult(res)
+	})
+}
+
+// ReleaseTaskLease removes the lease on a task, if held by owner.
+func (s *Store) ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM task_leases WHERE task_id = $1 AND owner = $2`, taskID.String(), owner)
+	return err
+}
+
+// ListTaskLeases returns every task lease in the store.
+func (s *Store) ListTaskLeases(ctx context.Context) ([]backend.TaskLease, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT task_id, owner, expires_at FROM task_leases ORDER BY task_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leases []backend.TaskLease
+	for rows.Next() {
+		var id string
+		var l backend.TaskLease
+		if err := rows.Scan(&id, &l.Owner, &l.ExpiresAt); err != nil {
+			return nil, err
+		}
+		if err := l.TaskID.DecodeFromString(id); err != nil {
+			return nil, err
+		}
+		leases = append(leases, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// KeepAliveLeaseOwner records owner as participating in task leasing until expiresAt.
+func (s *Store) KeepAliveLeaseOwner(ctx context.Context, owner string, expiresAt int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO task_lease_owners (owner, expires_at) VALUES ($1, $2)
+		ON CONFLICT (owner) DO UPDATE SET expires_at = EXCLUDED.expires_at`,
+		owner, expiresAt,
+	)
+	return err
+}
+
+// ListLeaseOwners returns the owners whose keep-alive has not expired as of now.
+func (s *Store) ListLeaseOwners(ctx context.Context, now int64) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT owner FROM task_lease_owners WHERE expires_at > $1 ORDER BY owner`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []string
+	for rows.Next() {
+		var owner string
+		if err := rows.Scan(&owner); err != nil {
+			return nil, err
+		}
+		owners = append(owners, owner)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// AcquireLeaderLease acquires or renews the store-wide leader lease for owner.
+func (s *Store) AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO task_leader (id, owner, expires_at) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+		WHERE task_leader.owner = EXCLUDED.owner OR task_leader.expires_at <= $3`,
+		owner, expiresAt, now,
+	)
+	if err != nil {
+		return err
+	}
+	return leaseResult(res)
+}
+
+// ReleaseLeaderLease removes the leader lease, if held by owner.
+func (s *Store) ReleaseLeaderLease(ctx context.Context, owner string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM task_leader WHERE owner = $1`, owner)
+	return err
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DeleteUser synchronously deletes a user and all their tasks from a Postgres store.
+func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE user_id = $1`, id.String())
+	return err
+}
+
+// DeleteOrg synchronously deletes an org and all their tasks from a Postgres store.
+func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM tasks WHERE org_id = $1`, id.String())
+	return err
+}
+
+// withTx calls fn in a transaction, which is committed if fn returns nil and rolled back otherwise.
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return withTx(ctx, s.db, fn)
+}
+
+// withTx calls fn in a transaction on db, which is committed if fn returns nil and rolled back otherwise.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateMeta calls fn with the meta of the task with the given ID, locked for the duration of the call,
+// and stores the modified meta if fn returns nil.
+func (s *Store) updateMeta(ctx context.Context, taskID platform.ID, fn func(stm *backend.StoreTaskMeta) error) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var stmBytes []byte
+		err := tx.QueryRowContext(ctx, `SELECT meta FROM tasks WHERE id = $1 FOR UPDATE`, taskID.String()).Scan(&stmBytes)
+		if err == sql.ErrNoRows {
+			return backend.ErrTaskNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		if err := fn(&stm); err != nil {
+			return err
+		}
+
+		stmBytes, err = stm.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE tasks SET meta = $2 WHERE id = $1`, taskID.String(), stmBytes)
+		return err
+	})
+}
+
+// findTaskForUpdate returns the task with the given ID along with its meta and versions,
+// locking the task's row until tx ends.
+func (s *Store) findTaskForUpdate(ctx context.Context, tx *sql.Tx, id platform.ID) (*backend.StoreTask, *backend.StoreTaskMeta, []backend.TaskVersion, error) {
+	var t backend.StoreTask
+	var org, user, versionsJSON, opts string
+	var labels, stmBytes []byte
+	err := tx.QueryRowContext(ctx,
+		`SELECT org_id, user_id, name, script, labels, versions, meta, options FROM tasks WHERE id = $1 FOR UPDATE`, id.String(),
+	).Scan(&org, &user, &t.Name, &t.Script, &labels, &versionsJSON, &stmBytes, &opts)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil, backend.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := s.decodeTask(&t, id.String(), org, user, labels, opts); err != nil {
+		return nil, nil, nil, err
+	}
+	var stm backend.StoreTaskMeta
+	if err := stm.Unmarshal(stmBytes); err != nil {
+		return nil, nil, nil, err
+	}
+	versions, err := s.decodeVersions(versionsJSON)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return &t, &stm, versions, nil
+}
+
+// decodeTask decrypts the script of t, and sets its IDs, labels, and options from their stored representations.
+func (s *Store) decodeTask(t *backend.StoreTask, id, org, user string, labels []byte, opts string) error {
+	script, err := backend.DecryptAtRest(s.cipher, t.Script)
+	if err != nil {
+		return err
+	}
+	t.Script = script
+
+	if err := t.ID.DecodeFromString(id); err != nil {
+		return err
+	}
+	if err := t.Org.DecodeFromString(org); err != nil {
+		return err
+	}
+	if err := t.User.DecodeFromString(user); err != nil {
+		return err
+	}
+
+	t.Options = nil
+	if opts != "" {
+		t.Options = new(options.Options)
+		if err := json.Unmarshal([]byte(opts), t.Options); err != nil {
+			return err
+		}
+	}
+
+	t.Labels = nil
+	if labels == nil {
+		return nil
+	}
+	return json.Unmarshal(labels, &t.Labels)
+}
+
+// encodeVersions returns the JSON encoding of versions, with their scripts encrypted if s has a cipher.
+func (s *Store) encodeVersions(versions []backend.TaskVersion) (string, error) {
+	versions, err := backend.EncryptVersionsAtRest(s.cipher, versions)
+	if err != nil {
+		return "", err
+	}
+	v, err := json.Marshal(versions)
+	if err != nil {
+		return "", err
+	}
+	return string(v), nil
+}
+
+// decodeVersions decodes versions encoded by encodeVersions.
+func (s *Store) decodeVersions(v string) ([]backend.TaskVersion, error) {
+	var versions []backend.TaskVersion
+	if err := json.Unmarshal([]byte(v), &versions); err != nil {
+		return nil, err
+	}
+	if err := backend.DecryptVersionsAtRest(s.cipher, versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// encodeOptions returns the JSON encoding of opts, or the empty string if opts is nil.
+func encodeOptions(opts *options.Options) (string, error) {
+	if opts == nil {
+		return "", nil
+	}
+	v, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	return string(v), nil
+}
+
+// encodeLabels returns the JSON encoding of labels, or nil if labels is empty, so that the column is stored as NULL.
+func encodeLabels(labels map[string]string) (interface{}, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	v, err := json.Marshal(labels)
+	if err != nil {
+		return nil, err
+	}
+	return string(v), nil
+}
+
+// leaseResult returns backend.ErrLeaseHeld if the conditional lease upsert that produced res did not modify any rows,
+// meaning the lease is held by another owner.
+func leaseResult(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return backend.ErrLeaseHeld
+	}
+	return nil
+}
+
+// decodeRunHistory decodes a run history as stored in the tasks table.
+func decodeRunHistory(v string) (backend.TaskRunHistory, error) {
+	var h backend.TaskRunHistory
+	if v == "" {
+		return h, nil
+	}
+	err := json.Unmarshal([]byte(v), &h)
+	return h, err
+}
@@ -0,0 +1,126 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/postgres"
+	"github.com/influxdata/platform/task/backend/storetest"
+	"github.com/influxdata/platform/task/options"
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	// TODO(mr): remove as part of https://github.com/influxdata/platform/issues/484.
+	options.EnableScriptCacheForTest()
+}
+
+// dsnEnv names the environment variable holding a key/value connection string for a Postgres server to test against,
+// such as "host=localhost user=postgres sslmode=disable".
+// The tests are skipped if it is unset.
+const dsnEnv = "INFLUXDB_TASK_POSTGRES_DSN"
+
+var schemaSeq int64
+
+// openTestDB opens a connection to the test server that uses a new, empty schema,
+// and returns the connection along with a function to drop the schema.
+func openTestDB(t *testing.T) (*sql.DB, func()) {
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", dsnEnv)
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := fmt.Sprintf("task_test_%d_%d", time.Now().UnixNano(), atomic.AddInt64(&schemaSeq, 1))
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", dsn+" search_path="+schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Error(err)
+		}
+		admin.Close()
+	}
+}
+
+func TestPostgresStore(t *testing.T) {
+	type store struct {
+		*postgres.Store
+		drop func()
+	}
+	storetest.NewStoreTest(
+		"postgres",
+		func(t *testing.T) backend.Store {
+			db, drop := openTestDB(t)
+			s, err := postgres.New(context.Background(), db)
+			if err != nil {
+				drop()
+				t.Fatalf("failed to create new postgres store %v\n", err)
+			}
+			return &store{Store: s, drop: drop}
+		},
+		func(t *testing.T, s backend.Store) {
+			if err := s.Close(); err != nil {
+				t.Error(err)
+			}
+			s.(*store).drop()
+		},
+	)(t)
+}
+
+func TestPostgresRunStore(t *testing.T) {
+	type store struct {
+		*postgres.RunStore
+		db   *sql.DB
+		drop func()
+	}
+	storetest.NewRunStoreTest(
+		"postgres",
+		func(t *testing.T) (backend.LogWriter, backend.LogReader) {
+			db, drop := openTestDB(t)
+			if err := postgres.Migrate(context.Background(), db); err != nil {
+				drop()
+				t.Fatal(err)
+			}
+			s := &store{RunStore: postgres.NewRunStore(db), db: db, drop: drop}
+			return s, s
+		},
+		func(t *testing.T, w backend.LogWriter, r backend.LogReader) {
+			s := w.(*store)
+			if err := s.db.Close(); err != nil {
+				t.Error(err)
+			}
+			s.drop()
+		},
+	)(t)
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	db, drop := openTestDB(t)
+	defer drop()
+	defer db.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := postgres.Migrate(context.Background(), db); err != nil {
+			t.Fatalf("migration attempt %d: %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+// RunStore records task runs and their logs in Postgres.
+// It implements both backend.LogWriter and backend.LogReader.
+type RunStore struct {
+	db *sql.DB
+
+	// Encrypts log lines at rest, if set. See WithCipher.
+	cipher backend.Cipher
+}
+
+var (
+	_ backend.LogWriter  = (*RunStore)(nil)
+	_ backend.LogReader  = (*RunStore)(nil)
+	_ backend.RunPruner  = (*RunStore)(nil)
+	_ backend.TaskPurger = (*RunStore)(nil)
+)
+
+// NewRunStore returns a RunStore using db.
+// The task schema in db must already be migrated; see Migrate.
+func NewRunStore(db *sql.DB, opts ...Option) *RunStore {
+	cfg := newConfig(opts)
+	return &RunStore{db: db, cipher: cfg.cipher}
+}
+
+// UpdateRunState sets the run state and the respective time, creating the run if it does not yet exist.
+func (s *RunStore) UpdateRunState(ctx context.Context, rlb backend.RunLogBase, when time.Time, status backend.RunStatus) error {
+	var startedAt, finishedAt string
+	whenStr := when.UTC().Format(time.RFC3339Nano)
+	switch status {
+	case backend.RunStarted:
+		startedAt = whenStr
+	case backend.RunFail, backend.RunSuccess, backend.RunCanceled, backend.RunTimedOut, backend.RunSkipped, backend.RunDeduplicated:
+		finishedAt = whenStr
+	}
+
+	var requestedAt, retryOf string
+	if rlb.RequestedAt != 0 {
+		requestedAt = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
+	}
+	if rlb.RetryOf.Valid() {
+		retryOf = rlb.RetryOf.String()
+	}
+
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO task_runs (id, task_id, org_id, status, scheduled_for, requested_at, started_at, finished_at, retry_of, trace_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			started_at = CASE WHEN EXCLUDED.started_at = '' THEN task_runs.started_at ELSE EXCLUDED.started_at END,
+			finished_at = CASE WHEN EXCLUDED.finished_at = '' THEN task_runs.finished_at ELSE EXCLUDED.finished_at END,
+			trace_id = CASE WHEN EXCLUDED.trace_id = '' THEN task_runs.trace_id ELSE EXCLUDED.trace_id END`,
+		rlb.RunID.String(), rlb.Task.ID.String(), rlb.Task.Org.String(), status.String(),
+		time.Unix(rlb.RunScheduledFor, 0).UTC().Format(time.RFC3339), requestedAt, startedAt, finishedAt, retryOf, rlb.TraceID,
+	)
+	return err
+}
+
+// AddRunLog adds a log line to the run.
+// The time of the line is stored in the clear, and the rest of the line is encrypted if s has a cipher.
+func (s *RunStore) AddRunLog(ctx context.Context, rlb backend.RunLogBase, when time.Time, log string) error {
+	log, err := backend.EncryptAtRest(s.cipher, log)
+	if err != nil {
+		return err
+	}
+	log = fmt.Sprintf("%s: %s", when.Format(time.RFC3339Nano), log)
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE task_runs SET log = CASE WHEN log = '' THEN $2 ELSE log || E'\n' || $2 END WHERE id = $1`,
+		rlb.RunID.String(), log,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return backend.ErrRunNotFound
+	}
+	return nil
+}
+
+// PruneRuns deletes finished runs scheduled before the Unix time before, if before is nonzero,
+// and, if keep is positive, all but the latest keep finished runs of each task.
+// The tasks in keepByTask instead keep only their latest keepByTask[id] finished runs, regardless of age.
+func (s *RunStore) PruneRuns(ctx context.Context, before int64, keep int, keepByTask map[platform.ID]int) (int, error) {
+	// Runs of the tasks in keepByTask are excluded from the server-wide limits.
+	where := []string{`finished_at <> ''`}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if len(keepByTask) > 0 {
+		ids := make([]string, 0, len(keepByTask))
+		for id := range keepByTask {
+			ids = append(ids, arg(id.String()))
+		}
+		where = append(where, "task_id NOT IN ("+strings.Join(ids, ", ")+")")
+	}
+
+	var pruned int64
+	exec := func(tx *sql.Tx, q string, args ...interface{}) error {
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		pruned += n
+		return nil
+	}
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		if before != 0 {
+			q := `DELETE FROM task_runs WHERE ` + strings.Join(where, " AND ") + ` AND scheduled_for < $` + fmt.Sprint(len(args)+1)
+			if err := exec(tx, q, append(args, time.Unix(before, 0).UTC().Format(time.RFC3339))...); err != nil {
+				return err
+			}
+		}
+
+		if keep > 0 {
+			q := `DELETE FROM task_runs WHERE id IN (
+				SELECT id FROM (
+					SELECT id, ROW_NUMBER() OVER (PARTITION BY task_id ORDER BY scheduled_for DESC, id DESC) AS n
+					FROM task_runs WHERE ` + strings.Join(where, " AND ") + `
+				) AS ranked WHERE n > $` + fmt.Sprint(len(args)+1) + `
+			)`
+			if err := exec(tx, q, append(args, keep)...); err != nil {
+				return err
+			}
+		}
+
+		for id, n := range keepByTask {
+			if err := exec(tx,
+				`DELETE FROM task_runs WHERE id IN (
+					SELECT id FROM (
+						SELECT id, ROW_NUMBER() OVER (ORDER BY scheduled_for DESC, id DESC) AS n
+						FROM task_runs WHERE task_id = $1 AND finished_at <> ''
+					) AS ranked WHERE n > $2
+				)`,
+				id.String(), n,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(pruned), nil
+}
+
+// RetainedTaskIDs returns the IDs of the tasks that runs are recorded for.
+func (s *RunStore) RetainedTaskIDs(ctx context.Context) ([]platform.ID, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT task_id FROM task_runs`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []platform.ID
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		id, err := platform.IDFromString(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, *id)
+	}
+	return ids, rows.Err()
+}
+
+// PurgeTask deletes the runs of the task, along with their logs.
+func (s *RunStore) PurgeTask(ctx context.Context, taskID platform.ID) (backend.PurgeResult, error) {
+	var res backend.PurgeResult
+	err := s.db.QueryRowContext(ctx,
+		`WITH purged AS (
+			DELETE FROM task_runs WHERE task_id = $1 RETURNING pg_column_size(task_runs.*) AS size
+		)
+		SELECT COUNT(*), COALESCE(SUM(size), 0) FROM purged`,
+		taskID.String(),
+	).Scan(&res.Records, &res.Bytes)
+	if err != nil {
+		return backend.PurgeResult{}, err
+	}
+	return res, nil
+}
+
+const runColumns = `id, task_id, status, scheduled_for, requested_at, started_at, finished_at, log, retry_of, trace_id`
+
+// ListRuns returns the runs of a task, ordered by run ID.
+func (s *RunStore) ListRuns(ctx context.Context, runFilter platform.RunFilter) ([]*platform.Run, error) {
+	if runFilter.Task == nil {
+		return nil, errors.New("task is required")
+	}
+
+	where := []string{"task_id = $1"}
+	args := []interface{}{runFilter.Task.String()}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if runFilter.After != nil {
+		where = append(where, "id > "+arg(runFilter.After.String()))
+	}
+	if runFilter.AfterTime != "" {
+		where = append(where, "scheduled_for > "+arg(runFilter.AfterTime))
+	}
+	if runFilter.BeforeTime != "" {
+		where = append(where, "scheduled_for < "+arg(runFilter.BeforeTime))
+	}
+	if runFilter.Status != "" {
+		where = append(where, "status = "+arg(runFilter.Status))
+	}
+
+	q := `SELECT ` + runColumns + ` FROM task_runs WHERE ` + strings.Join(where, " AND ") + ` ORDER BY id`
+	if runFilter.Limit > 0 {
+		q += " LIMIT " + arg(runFilter.Limit)
+	}
+	if runFilter.Offset > 0 {
+		q += " OFFSET " + arg(runFilter.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*platform.Run
+	for rows.Next() {
+		r, err := s.scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM task_runs WHERE task_id = $1)`, runFilter.Task.String()).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, backend.ErrRunNotFound
+		}
+	}
+	return runs, nil
+}
+
+// FindRunByID finds a run given a orgID and runID.
+func (s *RunStore) FindRunByID(ctx context.Context, orgID, runID platform.ID) (*platform.Run, error) {
+	r, err := s.scanRun(s.db.QueryRowContext(ctx, `SELECT `+runColumns+` FROM task_runs WHERE id = $1`, runID.String()))
+	if err == sql.ErrNoRows {
+		return nil, backend.ErrRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListLogs lists logs for a task or a specified run of a task.
+func (s *RunStore) ListLogs(ctx context.Context, logFilter platform.LogFilter) ([]platform.Log, error) {
+	if logFilter.Task == nil && logFilter.Run == nil {
+		return nil, errors.New("task or run is required")
+	}
+
+	if logFilter.Run != nil {
+		// Skip the lines already read by the caller in the database, rather than sending the whole log.
+		var log string
+		err := s.db.QueryRowContext(ctx,
+			`SELECT array_to_string((string_to_array(log, E'\n'))[$2::integer + 1:], E'\n') FROM task_runs WHERE id = $1`,
+			logFilter.Run.String(), logFilter.Offset,
+		).Scan(&log)
+		if err == sql.ErrNoRows {
+			return nil, backend.ErrRunNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		log, err = s.decryptLog(log)
+		if err != nil {
+			return nil, err
+		}
+		return []platform.Log{platform.Log(log)}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT log FROM task_runs WHERE task_id = $1 ORDER BY id`, logFilter.Task.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []platform.Log{}
+	for rows.Next() {
+		var log string
+		if err := rows.Scan(&log); err != nil {
+			return nil, err
+		}
+		log, err := s.decryptLog(log)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, platform.Log(log))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// scanRun reads a run selected with runColumns.
+func (s *RunStore) scanRun(row interface{ Scan(...interface{}) error }) (*platform.Run, error) {
+	var r platform.Run
+	var id, taskID, log, retryOf string
+	if err := row.Scan(&id, &taskID, &r.Status, &r.ScheduledFor, &r.RequestedAt, &r.StartedAt, &r.FinishedAt, &log, &retryOf, &r.TraceID); err != nil {
+		return nil, err
+	}
+	if err := r.ID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	if err := r.TaskID.DecodeFromString(taskID); err != nil {
+		return nil, err
+	}
+	if retryOf != "" {
+		if err := r.RetryOf.DecodeFromString(retryOf); err != nil {
+			return nil, err
+		}
+	}
+	log, err := s.decryptLog(log)
+	if err != nil {
+		return nil, err
+	}
+	r.Log = platform.Log(log)
+	return &r, nil
+}
+
+// decryptLog decrypts the lines of a log written by AddRunLog.
+func (s *RunStore) decryptLog(log string) (string, error) {
+	if log == "" {
+		return log, nil
+	}
+
+	lines := strings.Split(log, "\n")
+	for i, line := range lines {
+		prefix := ""
+		if j := strings.Index(line, ": "); j >= 0 {
+			prefix, line = line[:j+2], line[j+2:]
+		}
+		plain, err := backend.DecryptAtRest(s.cipher, line)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = prefix + plain
+	}
+	return strings.Join(lines, "\n"), nil
+}
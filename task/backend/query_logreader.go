@@ -79,6 +79,9 @@ func (qlr *QueryLogReader) ListLogs(ctx context.Context, logFilter platform.LogF
 	logs := make([]platform.Log, len(runs))
 	for i, r := range runs {
 		logs[i] = r.Log
+		if logFilter.Run != nil {
+			logs[i] = SkipLogLines(r.Log, logFilter.Offset)
+		}
 	}
 	return logs, nil
 }
@@ -95,6 +98,10 @@ func (qlr *QueryLogReader) ListRuns(ctx context.Context, runFilter platform.RunF
 	if runFilter.Limit > 0 {
 		limit = fmt.Sprintf("|> limit(n: %d)\n", runFilter.Limit)
 	}
+	if runFilter.Status != "" || runFilter.Offset > 0 {
+		// A run's status is only known once its records are read, so the runs are filtered and limited afterwards.
+		limit = ""
+	}
 
 	afterID := ""
 	if runFilter.After != nil {
@@ -123,6 +130,7 @@ main = from(bucketID: "000000000000000a")
 
 join(tables: {main: main, supl: supl}, on: ["_start", "_stop", "orgID", "taskID", "runID", "_measurement"])
   |> group(columns: ["_measurement"])
+  |> sort(columns: ["runID"])
   %s
   |> yield(name: "result")
   `, runFilter.Task.String(), scheduledBefore, scheduledAfter, runFilter.Task.String(), afterID, limit)
@@ -141,7 +149,40 @@ join(tables: {main: main, supl: supl}, on: ["_start", "_stop", "orgID", "taskID"
 		return nil, err
 	}
 
-	return queryIttrToRuns(ittr)
+	runs, err := queryIttrToRuns(ittr)
+	if err != nil {
+		return nil, err
+	}
+	if runFilter.Status != "" || runFilter.Offset > 0 {
+		runs = filterRuns(runs, runFilter)
+	}
+	return runs, nil
+}
+
+// filterRuns returns the runs with the status set by filter, if any, skipping the first filter.Offset of them,
+// and returning at most filter.Limit, or 100 if it is not set.
+func filterRuns(runs []*platform.Run, filter platform.RunFilter) []*platform.Run {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var filtered []*platform.Run
+	skipped := 0
+	for _, r := range runs {
+		if filter.Status != "" && r.Status != filter.Status {
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		filtered = append(filtered, r)
+		if len(filtered) == limit {
+			break
+		}
+	}
+	return filtered
 }
 
 func (qlr *QueryLogReader) FindRunByID(ctx context.Context, orgID, runID platform.ID) (*platform.Run, error) {
@@ -266,8 +307,18 @@ func (re *runExtractor) extractRecord(cr flux.ColReader) error {
 			switch col.Label {
 			case requestedAtField:
 				r.RequestedAt = cr.Strings(j)[i]
+			case retryOfField:
+				if s := cr.Strings(j)[i]; s != "" {
+					id, err := platform.IDFromString(s)
+					if err != nil {
+						return err
+					}
+					r.RetryOf = *id
+				}
 			case scheduledForField:
 				r.ScheduledFor = cr.Strings(j)[i]
+			case traceIDField:
+				r.TraceID = cr.Strings(j)[i]
 			case "status":
 				r.Status = cr.Strings(j)[i]
 			case "runID":
@@ -284,7 +335,7 @@ func (re *runExtractor) extractRecord(cr flux.ColReader) error {
 				r.TaskID = *id
 			case RunStarted.String():
 				r.StartedAt = cr.Times(j)[i].Time().Format(time.RFC3339Nano)
-			case RunSuccess.String(), RunFail.String(), RunCanceled.String():
+			case RunSuccess.String(), RunFail.String(), RunCanceled.String(), RunTimedOut.String(), RunSkipped.String(), RunDeduplicated.String():
 				r.FinishedAt = cr.Times(j)[i].Time().Format(time.RFC3339Nano)
 			}
 		}
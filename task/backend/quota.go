@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TaskQuota limits the tasks of an organization: how many it may have, and how much its tasks may run.
+type TaskQuota struct {
+	// MaxTasks is the most tasks the organization may have, regardless of status.
+	// Zero means there is no limit.
+	MaxTasks int
+
+	// MaxHighFrequencyTasks is the most tasks the organization may have that run at least as often as
+	// HighFrequencyInterval, as measured by (*options.Options).Interval.
+	// Zero means there is no limit.
+	MaxHighFrequencyTasks int
+
+	// HighFrequencyInterval is the longest time between runs for a task to count towards MaxHighFrequencyTasks.
+	HighFrequencyInterval time.Duration
+
+	// MaxRunsPerSecond is the most runs of the organization's tasks that may start per second, on average,
+	// in place of the scheduler's own rate limit. See OrgRunLimiter.WithQuotas.
+	// Zero means there is no limit beyond the scheduler's.
+	MaxRunsPerSecond float64
+
+	// MaxRunDuration is the longest a run of one of the organization's tasks may execute
+	// before the scheduler cancels it as timed out, with a RunDurationQuotaError.
+	// Zero means there is no limit.
+	MaxRunDuration time.Duration
+
+	// MaxRunHistory is the most finished runs kept for each of the organization's tasks. See WithRetentionQuotas.
+	// Zero means there is no limit beyond the run retention's own.
+	MaxRunHistory int
+}
+
+// IsZero reports whether q places no limit on an organization's tasks.
+func (q TaskQuota) IsZero() bool {
+	return q.MaxTasks <= 0 && (q.MaxHighFrequencyTasks <= 0 || q.HighFrequencyInterval <= 0) &&
+		q.MaxRunsPerSecond <= 0 && q.MaxRunDuration <= 0 && q.MaxRunHistory <= 0
+}
+
+// Validate returns an error if any of q's limits is negative.
+func (q TaskQuota) Validate() error {
+	if q.MaxTasks < 0 || q.MaxHighFrequencyTasks < 0 || q.HighFrequencyInterval < 0 ||
+		q.MaxRunsPerSecond < 0 || q.MaxRunDuration < 0 || q.MaxRunHistory < 0 {
+		return platform.NewCodedError(platform.EInvalid, "quota limits must not be negative")
+	}
+	return nil
+}
+
+// QuotaService looks up the task quotas of organizations.
+type QuotaService interface {
+	// TaskQuota returns the task quota of the organization with the given ID.
+	TaskQuota(ctx context.Context, org platform.ID) (TaskQuota, error)
+}
+
+// QuotaStore is a QuotaService whose quotas can be configured, such as through the quotas API.
+// Organizations without a quota of their own have the store's default quota.
+type QuotaStore interface {
+	QuotaService
+
+	// SetTaskQuota sets the quota of the organization with the given ID, in place of the default.
+	// It returns an error if q is not valid.
+	SetTaskQuota(ctx context.Context, org platform.ID, q TaskQuota) error
+
+	// DeleteTaskQuota removes the quota of the organization with the given ID, so that it has the default quota again.
+	// It reports whether the organization had a quota of its own.
+	DeleteTaskQuota(ctx context.Context, org platform.ID) (deleted bool, err error)
+}
+
+// StaticQuotas is a QuotaService with fixed quotas, for configuring quotas at startup.
+type StaticQuotas struct {
+	// Default is the quota of organizations not in Orgs.
+	Default TaskQuota
+
+	// Orgs holds the quotas of organizations that don't use Default. May be nil.
+	Orgs map[platform.ID]TaskQuota
+}
+
+var _ QuotaService = StaticQuotas{}
+
+// TaskQuota returns the quota in q.Orgs for org, or q.Default if there is none.
+func (q StaticQuotas) TaskQuota(_ context.Context, org platform.ID) (TaskQuota, error) {
+	if quota, ok := q.Orgs[org]; ok {
+		return quota, nil
+	}
+	return q.Default, nil
+}
+
+// DefaultCachedQuotas is the number of organizations whose quotas a CachedQuotas keeps.
+const DefaultCachedQuotas = 10000
+
+// CachedQuotas is a QuotaService that keeps the quotas it finds in another for a fixed time,
+// so that the scheduler can look up the quota of each run it starts without reading the store each time.
+// A change to an organization's quota takes effect once its cached quota expires.
+type CachedQuotas struct {
+	qs    QuotaService
+	cache *cache.Cache
+}
+
+var _ QuotaService = (*CachedQuotas)(nil)
+
+// NewCachedQuotas returns a CachedQuotas that keeps the quotas of up to DefaultCachedQuotas organizations found in qs for ttl.
+func NewCachedQuotas(qs QuotaService, ttl time.Duration) *CachedQuotas {
+	return &CachedQuotas{
+		qs:    qs,
+		cache: cache.New("task-quotas", DefaultCachedQuotas, ttl),
+	}
+}
+
+// TaskQuota returns the cached quota of org, looking it up again once it has expired.
+// Failed lookups are not cached.
+func (c *CachedQuotas) TaskQuota(ctx context.Context, org platform.ID) (TaskQuota, error) {
+	if q, ok := c.cache.Get(org); ok {
+		return q.(TaskQuota), nil
+	}
+
+	q, err := c.qs.TaskQuota(ctx, org)
+	if err != nil {
+		return TaskQuota{}, err
+	}
+	c.cache.Set(org, q)
+	return q, nil
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (c *CachedQuotas) PrometheusCollectors() []prometheus.Collector {
+	return c.cache.PrometheusCollectors()
+}
+
+// TaskQuotaError is returned when creating a task would give an organization more tasks than its quota allows.
+type TaskQuotaError struct {
+	// The organization whose quota was reached.
+	Org platform.ID
+
+	// The number of tasks allowed by the quota.
+	Limit int
+
+	// Whether the limit is MaxHighFrequencyTasks, rather than MaxTasks.
+	HighFrequency bool
+}
+
+func (e TaskQuotaError) Error() string {
+	if e.HighFrequency {
+		return fmt.Sprintf("organization %s has reached its quota of %d high-frequency tasks", e.Org, e.Limit)
+	}
+	return fmt.Sprintf("organization %s has reached its quota of %d tasks", e.Org, e.Limit)
+}
+
+// Code returns platform.EQuotaExceeded.
+func (e TaskQuotaError) Code() string {
+	return platform.EQuotaExceeded
+}
+
+// RunDurationQuotaError is the error of a run that the scheduler canceled
+// because it executed for longer than its organization's quota allows.
+type RunDurationQuotaError struct {
+	// The organization whose quota was exceeded.
+	Org platform.ID
+
+	// The longest a run is allowed to execute by the quota.
+	Limit time.Duration
+}
+
+func (e RunDurationQuotaError) Error() string {
+	return fmt.Sprintf("run exceeded the quota of organization %s of %s per run", e.Org, e.Limit)
+}
+
+// Code returns platform.EQuotaExceeded.
+func (e RunDurationQuotaError) Code() string {
+	return platform.EQuotaExceeded
+}
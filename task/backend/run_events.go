@@ -0,0 +1,63 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/eventbus"
+	"go.uber.org/zap"
+)
+
+// RunFinishedTopic carries a RunFinishedEvent for each run whose outcome a RunEventPublisher observes.
+var RunFinishedTopic = eventbus.NewTopic("task.run.finished", RunFinishedEvent{})
+
+// RunFinishedEvent is the payload of RunFinishedTopic.
+type RunFinishedEvent struct {
+	TaskID platform.ID `json:"taskID"`
+	OrgID  platform.ID `json:"orgID"`
+	RunID  platform.ID `json:"runID"`
+
+	// Status is the status the run finished in, such as "success" or "failed".
+	Status string `json:"status"`
+
+	ScheduledFor time.Time `json:"scheduledFor"`
+	StartedAt    time.Time `json:"startedAt"`
+	FinishedAt   time.Time `json:"finishedAt"`
+
+	// Error and ErrorCode are set for runs that did not succeed.
+	Error     string `json:"error,omitempty"`
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+// RunEventPublisher is a RunObserver that publishes the outcome of each run on RunFinishedTopic,
+// so that other subsystems can react to finished runs without the scheduler knowing about them.
+type RunEventPublisher struct {
+	bus    eventbus.Bus
+	logger *zap.Logger
+}
+
+var _ RunObserver = (*RunEventPublisher)(nil)
+
+// NewRunEventPublisher returns a RunEventPublisher that publishes on bus, and reports events that fail to publish to logger.
+func NewRunEventPublisher(bus eventbus.Bus, logger *zap.Logger) *RunEventPublisher {
+	return &RunEventPublisher{bus: bus, logger: logger}
+}
+
+// ObserveRun publishes the outcome o of the run qr of task.
+func (p *RunEventPublisher) ObserveRun(ctx context.Context, task *StoreTask, qr QueuedRun, o RunOutcome) {
+	e := RunFinishedEvent{
+		TaskID:       task.ID,
+		OrgID:        task.Org,
+		RunID:        qr.RunID,
+		Status:       o.Status.String(),
+		ScheduledFor: time.Unix(o.ScheduledFor, 0).UTC(),
+		StartedAt:    o.StartedAt,
+		FinishedAt:   o.FinishedAt,
+		Error:        o.Error,
+		ErrorCode:    o.ErrorCode,
+	}
+	if err := p.bus.Publish(ctx, RunFinishedTopic, e); err != nil {
+		p.logger.Info("Failed to publish run event", zap.String("task_id", task.ID.String()), zap.String("run_id", qr.RunID.String()), zap.Error(err))
+	}
+}
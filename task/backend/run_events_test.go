@@ -0,0 +1,59 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/eventbus"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRunEventPublisher(t *testing.T) {
+	bus := eventbus.NewLocal("test")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan eventbus.Event, 1)
+	if err := bus.Subscribe(ctx, backend.RunFinishedTopic, "test", func(_ context.Context, e eventbus.Event) {
+		events <- e
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	p := backend.NewRunEventPublisher(bus, zaptest.NewLogger(t))
+	task := &backend.StoreTask{ID: platform.ID(1), Org: platform.ID(2)}
+	start := time.Unix(1000, 0)
+	p.ObserveRun(ctx, task, backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(3), Now: 900}, backend.RunOutcome{
+		RunID:        platform.ID(3),
+		ScheduledFor: 900,
+		Status:       backend.RunFail,
+		StartedAt:    start,
+		FinishedAt:   start.Add(time.Second),
+		Error:        "forced failure",
+		ErrorCode:    platform.EInternal,
+	})
+
+	select {
+	case e := <-events:
+		got := e.Payload.(backend.RunFinishedEvent)
+		want := backend.RunFinishedEvent{
+			TaskID:       platform.ID(1),
+			OrgID:        platform.ID(2),
+			RunID:        platform.ID(3),
+			Status:       backend.RunFail.String(),
+			ScheduledFor: time.Unix(900, 0).UTC(),
+			StartedAt:    start,
+			FinishedAt:   start.Add(time.Second),
+			Error:        "forced failure",
+			ErrorCode:    platform.EInternal,
+		}
+		if got != want {
+			t.Fatalf("unexpected event:\ngot  %+v\nwant %+v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for run event")
+	}
+}
@@ -0,0 +1,113 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"golang.org/x/time/rate"
+)
+
+// ErrRunRateLimited is returned when a run is requested for an organization that has used up its budget of run starts.
+var ErrRunRateLimited = platform.NewCodedError(platform.ETooManyRequests, "run rate limit exceeded for organization")
+
+// OrgRunLimiter limits the rate at which each organization's runs start, with a token bucket per organization.
+// A single OrgRunLimiter may be shared by several schedulers, so that the budget applies across all of them.
+type OrgRunLimiter struct {
+	limit rate.Limit
+	burst int
+
+	quotasMu sync.RWMutex
+	quotas   QuotaService
+
+	mu       sync.Mutex
+	limiters map[platform.ID]*rate.Limiter
+}
+
+// NewOrgRunLimiter returns an OrgRunLimiter that allows each organization perSecond run starts per second on average,
+// and up to burst run starts at once. If burst is less than 1, it is set to 1.
+// A perSecond of zero or less allows any rate, for limiting only the organizations whose quota sets a rate.
+func NewOrgRunLimiter(perSecond float64, burst int) *OrgRunLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	limit := rate.Limit(perSecond)
+	if perSecond <= 0 {
+		limit = rate.Inf
+	}
+	return &OrgRunLimiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: make(map[platform.ID]*rate.Limiter),
+	}
+}
+
+// WithQuotas allows each organization whose quota sets MaxRunsPerSecond that rate of run starts, in place of l's own.
+// The quota is looked up on each run start, so qs should keep quotas in memory, as CachedQuotas does.
+// If the lookup fails, the organization is limited to l's own rate.
+func (l *OrgRunLimiter) WithQuotas(qs QuotaService) {
+	l.quotasMu.Lock()
+	defer l.quotasMu.Unlock()
+	l.quotas = qs
+}
+
+// limiter returns the token bucket of org, set to the rate allowed by org's quota as of now.
+func (l *OrgRunLimiter) limiter(org platform.ID, now time.Time) *rate.Limiter {
+	limit := l.orgLimit(org)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rl, ok := l.limiters[org]
+	if !ok {
+		rl = rate.NewLimiter(limit, l.burst)
+		l.limiters[org] = rl
+	} else if rl.Limit() != limit {
+		rl.SetLimitAt(now, limit)
+	}
+	return rl
+}
+
+// orgLimit returns the rate of run starts allowed to org: its quota's MaxRunsPerSecond if set, or l's own rate.
+func (l *OrgRunLimiter) orgLimit(org platform.ID) rate.Limit {
+	l.quotasMu.RLock()
+	qs := l.quotas
+	l.quotasMu.RUnlock()
+	if qs == nil {
+		return l.limit
+	}
+
+	q, err := qs.TaskQuota(context.Background(), org)
+	if err != nil || q.MaxRunsPerSecond <= 0 {
+		return l.limit
+	}
+	return rate.Limit(q.MaxRunsPerSecond)
+}
+
+// Allow reports whether a run for org may start now, and if so, uses one of org's tokens.
+func (l *OrgRunLimiter) Allow(org platform.ID) bool {
+	return l.AllowAt(org, time.Now())
+}
+
+// AllowAt is like Allow, with the current time given as now.
+func (l *OrgRunLimiter) AllowAt(org platform.ID, now time.Time) bool {
+	return l.limiter(org, now).AllowN(now, 1)
+}
+
+// Check returns ErrRunRateLimited if a run for org could not start now, without using any of org's tokens.
+// It is used to reject manual runs up front, rather than queueing runs that would only be throttled when started.
+func (l *OrgRunLimiter) Check(org platform.ID) error {
+	return l.CheckAt(org, time.Now())
+}
+
+// CheckAt is like Check, with the current time given as now.
+func (l *OrgRunLimiter) CheckAt(org platform.ID, now time.Time) error {
+	r := l.limiter(org, now).ReserveN(now, 1)
+	ok := r.OK() && r.DelayFrom(now) == 0
+	r.CancelAt(now)
+	if !ok {
+		return ErrRunRateLimited
+	}
+	return nil
+}
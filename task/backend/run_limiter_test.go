@@ -0,0 +1,64 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestOrgRunLimiter(t *testing.T) {
+	l := backend.NewOrgRunLimiter(1.0/3600, 2)
+	org1, org2 := platform.ID(1), platform.ID(2)
+
+	// Checking doesn't use any of the budget.
+	for i := 0; i < 5; i++ {
+		if err := l.Check(org1); err != nil {
+			t.Fatalf("check %d: expected no error, got %v", i, err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if !l.Allow(org1) {
+			t.Fatalf("expected run %d to be allowed", i)
+		}
+	}
+	if l.Allow(org1) {
+		t.Fatal("expected run beyond burst to be throttled")
+	}
+	if err := l.Check(org1); err != backend.ErrRunRateLimited {
+		t.Fatalf("expected ErrRunRateLimited, got %v", err)
+	}
+
+	// Each org has its own budget.
+	if !l.Allow(org2) {
+		t.Fatal("expected run for other org to be allowed")
+	}
+}
+
+func TestOrgRunLimiter_Quotas(t *testing.T) {
+	org1, org2 := platform.ID(1), platform.ID(2)
+	l := backend.NewOrgRunLimiter(0, 1)
+	l.WithQuotas(backend.StaticQuotas{
+		Orgs: map[platform.ID]backend.TaskQuota{org1: {MaxRunsPerSecond: 1}},
+	})
+
+	now := time.Unix(1000, 0)
+	if !l.AllowAt(org1, now) {
+		t.Fatal("expected first run to be allowed")
+	}
+	if l.AllowAt(org1, now) {
+		t.Fatal("expected run beyond the quota's rate to be throttled")
+	}
+	if !l.AllowAt(org1, now.Add(time.Second)) {
+		t.Fatal("expected run to be allowed once the quota's rate allows it")
+	}
+
+	// An org without a quota is not limited by a zero rate.
+	for i := 0; i < 10; i++ {
+		if !l.AllowAt(org2, now) {
+			t.Fatalf("expected run %d for org without a quota to be allowed", i)
+		}
+	}
+}
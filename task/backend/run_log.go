@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// RunLogEventType identifies what a RunLogEvent records.
+type RunLogEventType string
+
+const (
+	// RunLogScheduled records that a run was created for the time it is scheduled for.
+	RunLogScheduled RunLogEventType = "scheduled"
+
+	// RunLogStarted records that the execution of a run started.
+	RunLogStarted RunLogEventType = "started"
+
+	// RunLogError records the error that ended a run, along with its error code.
+	RunLogError RunLogEventType = "error"
+
+	// RunLogFinished records that a run finished executing, along with its final status and how long it executed.
+	RunLogFinished RunLogEventType = "finished"
+
+	// RunLogSkipped records that a run finished without executing, along with its final status.
+	RunLogSkipped RunLogEventType = "skipped"
+
+	// RunLogMessage is a free-form message, such as the progress a remote worker reports,
+	// or a line written before run logs were structured.
+	RunLogMessage RunLogEventType = "message"
+)
+
+// RunLogEvent is an entry in the log of a run.
+// Each event is written through LogWriter.AddRunLog as a single line of JSON, and read back with ParseRunLog,
+// so that tooling can tell the causes of failures and the durations of runs from their logs.
+type RunLogEvent struct {
+	Type RunLogEventType `json:"type"`
+
+	// Time is when the event happened. It is passed to AddRunLog rather than encoded with the event.
+	Time time.Time `json:"-"`
+
+	// Message describes the event for people.
+	Message string `json:"message,omitempty"`
+
+	// ScheduledFor and RequestedAt are the Unix timestamps the run was scheduled for, and requested at if it was run manually.
+	// They are set on scheduled events.
+	ScheduledFor int64 `json:"scheduledFor,omitempty"`
+	RequestedAt  int64 `json:"requestedAt,omitempty"`
+
+	// RetryOf is the ID of the earlier run that the run retries, if any. It is set on started events.
+	RetryOf platform.ID `json:"retryOf,omitempty"`
+
+	// TraceID is the ID of the trace that follows the run, if the tracer reports trace IDs. It is set on started events.
+	TraceID string `json:"traceId,omitempty"`
+
+	// Code is the platform error code of the error, such as platform.EInvalid. It is set on error events.
+	Code string `json:"code,omitempty"`
+
+	// Status is the final status of the run. It is set on finished and skipped events.
+	Status string `json:"status,omitempty"`
+
+	// DurationMS is how long the run executed, in milliseconds. It is set on finished events.
+	DurationMS int64 `json:"durationMs,omitempty"`
+}
+
+// AddRunLogEvent writes e to the log of the run identified by base, through lw.
+func AddRunLogEvent(ctx context.Context, lw LogWriter, base RunLogBase, e RunLogEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return lw.AddRunLog(ctx, base, e.Time, string(b))
+}
+
+// ParseRunLog returns the events in the log of a run, as listed by a LogReader, oldest first.
+// Log stores prefix each line with the time it was written, in RFC3339 format, which sets the event's Time.
+// A line that is not a JSON event, such as one written before run logs were structured,
+// is returned as a RunLogMessage event holding the line.
+func ParseRunLog(log platform.Log) []RunLogEvent {
+	if log == "" {
+		return nil
+	}
+
+	lines := strings.Split(string(log), "\n")
+	events := make([]RunLogEvent, 0, len(lines))
+	for _, line := range lines {
+		var when time.Time
+		if i := strings.Index(line, ": "); i >= 0 {
+			if t, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+				when, line = t, line[i+2:]
+			}
+		}
+
+		var e RunLogEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil || e.Type == "" {
+			e = RunLogEvent{Type: RunLogMessage, Message: line}
+		}
+		e.Time = when
+		events = append(events, e)
+	}
+	return events
+}
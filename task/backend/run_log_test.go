@@ -0,0 +1,55 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestParseRunLog(t *testing.T) {
+	rw := backend.NewInMemRunReaderWriter()
+	ctx := context.Background()
+	rlb := backend.RunLogBase{Task: &backend.StoreTask{ID: 1, Org: 2}, RunID: 3, RunScheduledFor: 60}
+	when := time.Unix(100, 0).UTC()
+	if err := rw.UpdateRunState(ctx, rlb, when, backend.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+
+	// A line from before logs were structured.
+	if err := rw.AddRunLog(ctx, rlb, when, "Started task from script"); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []backend.RunLogEvent{
+		{Type: backend.RunLogError, Time: when.Add(time.Second), Message: "boom", Code: platform.EInvalid},
+		{Type: backend.RunLogFinished, Time: when.Add(2 * time.Second), Status: backend.RunFail.String(), DurationMS: 2000},
+	} {
+		if err := backend.AddRunLogEvent(ctx, rw, rlb, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run, err := rw.FindRunByID(ctx, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := backend.ParseRunLog(run.Log)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %#v", events)
+	}
+	if e := events[0]; e.Type != backend.RunLogMessage || e.Message != "Started task from script" || !e.Time.Equal(when) {
+		t.Fatalf("unexpected message event %#v", e)
+	}
+	if e := events[1]; e.Type != backend.RunLogError || e.Code != platform.EInvalid || e.Message != "boom" || !e.Time.Equal(when.Add(time.Second)) {
+		t.Fatalf("unexpected error event %#v", e)
+	}
+	if e := events[2]; e.Type != backend.RunLogFinished || e.Status != backend.RunFail.String() || e.DurationMS != 2000 {
+		t.Fatalf("unexpected finished event %#v", e)
+	}
+
+	if events := backend.ParseRunLog(""); len(events) != 0 {
+		t.Fatalf("expected no events in empty log, got %#v", events)
+	}
+}
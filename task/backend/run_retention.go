@@ -0,0 +1,240 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DefaultRunRetentionInterval is how often a RunRetention prunes runs, unless set with WithRetentionInterval.
+const DefaultRunRetentionInterval = time.Hour
+
+// RunRetention periodically deletes old runs and their logs from a RunPruner.
+type RunRetention struct {
+	pruner   RunPruner
+	tasks    Store
+	quotas   QuotaService
+	logger   *zap.Logger
+	maxAge   time.Duration
+	maxRuns  int
+	interval time.Duration
+
+	metrics *runRetentionMetrics
+}
+
+// RunRetentionOption is a option you can use to modify the run retention service.
+type RunRetentionOption func(*RunRetention)
+
+// WithMaxRunAge sets how long after its scheduled time a finished run is retained.
+// Zero, the default, retains runs regardless of age.
+func WithMaxRunAge(d time.Duration) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.maxAge = d
+	}
+}
+
+// WithMaxRunsPerTask sets how many of each task's latest finished runs are retained.
+// Zero, the default, retains runs regardless of how many a task has.
+func WithMaxRunsPerTask(n int) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.maxRuns = n
+	}
+}
+
+// WithTaskRetention sets the store whose tasks may set their own retention with the retainRuns option.
+// A task with retainRuns set keeps that many of its latest finished runs, in place of the limits of WithMaxRunAge and WithMaxRunsPerTask.
+func WithTaskRetention(s Store) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.tasks = s
+	}
+}
+
+// WithRetentionQuotas limits each task to the MaxRunHistory of its organization's quota in qs,
+// when that keeps fewer runs than the task's retainRuns option or WithMaxRunsPerTask would.
+// Like a task with retainRuns set, a task limited by its quota is not subject to WithMaxRunAge.
+// It has no effect without WithTaskRetention, which lists the tasks to limit.
+func WithRetentionQuotas(qs QuotaService) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.quotas = qs
+	}
+}
+
+// WithRetentionInterval sets how often runs are pruned.
+func WithRetentionInterval(d time.Duration) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.interval = d
+	}
+}
+
+// WithRetentionLogger sets the logger for the run retention service.
+func WithRetentionLogger(logger *zap.Logger) RunRetentionOption {
+	return func(r *RunRetention) {
+		r.logger = logger.With(zap.String("svc", "task_run_retention"))
+	}
+}
+
+// NewRunRetention returns a RunRetention that prunes runs from p.
+// Unless WithMaxRunAge, WithMaxRunsPerTask, or WithTaskRetention is given, no runs are pruned.
+func NewRunRetention(p RunPruner, opts ...RunRetentionOption) *RunRetention {
+	r := &RunRetention{
+		pruner:   p,
+		logger:   zap.NewNop(),
+		interval: DefaultRunRetentionInterval,
+		metrics:  newRunRetentionMetrics(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run prunes runs every interval until ctx is done.
+func (r *RunRetention) Run(ctx context.Context) {
+	if r.maxAge <= 0 && r.maxRuns <= 0 && r.tasks == nil {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if n, err := r.Prune(ctx, now); err != nil {
+				r.logger.Info("Failed to prune task runs", zap.Error(err))
+			} else if n > 0 {
+				r.logger.Info("Pruned task runs", zap.Int("runs", n))
+			}
+		}
+	}
+}
+
+// Prune deletes the runs that are outside the retention limits as of now,
+// and returns the number of runs deleted.
+func (r *RunRetention) Prune(ctx context.Context, now time.Time) (int, error) {
+	var before int64
+	if r.maxAge > 0 {
+		before = now.Add(-r.maxAge).Unix()
+	}
+
+	keepByTask, err := r.taskRetention(ctx)
+	if err != nil {
+		r.metrics.Prune(0, err)
+		return 0, err
+	}
+
+	n, err := r.pruner.PruneRuns(ctx, before, r.maxRuns, keepByTask)
+	r.metrics.Prune(n, err)
+	return n, err
+}
+
+// taskRetention returns the number of runs to keep for each task that sets the retainRuns option,
+// or whose organization's quota keeps fewer runs than the task otherwise would.
+// A task whose script no longer parses keeps to the server's limits.
+func (r *RunRetention) taskRetention(ctx context.Context) (map[platform.ID]int, error) {
+	if r.tasks == nil {
+		return nil, nil
+	}
+
+	keepByTask := make(map[platform.ID]int)
+	historyByOrg := make(map[platform.ID]int)
+	params := TaskSearchParams{PageSize: platform.TaskMaxPageSize}
+	for {
+		tasks, err := r.tasks.ListTasks(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			keep := r.maxRuns
+			opts, err := t.Task.ScriptOptions()
+			if err != nil {
+				r.logger.Info("Failed to read task options for run retention", zap.String("task_id", t.Task.ID.String()), zap.Error(err))
+			} else if opts.RetainRuns > 0 {
+				keep = int(opts.RetainRuns)
+				keepByTask[t.Task.ID] = keep
+			}
+
+			history, err := r.maxRunHistory(ctx, t.Task.Org, historyByOrg)
+			if err != nil {
+				return nil, err
+			}
+			if history > 0 && (keep <= 0 || keep > history) {
+				keepByTask[t.Task.ID] = history
+			}
+		}
+		if len(tasks) < params.PageSize {
+			return keepByTask, nil
+		}
+		params.After = tasks[len(tasks)-1].Task.ID
+	}
+}
+
+// maxRunHistory returns the MaxRunHistory of org's quota, or zero if there is no quota.
+// Quotas found are kept in seen, so that each organization's quota is looked up once per pruning.
+func (r *RunRetention) maxRunHistory(ctx context.Context, org platform.ID, seen map[platform.ID]int) (int, error) {
+	if r.quotas == nil {
+		return 0, nil
+	}
+	if n, ok := seen[org]; ok {
+		return n, nil
+	}
+
+	q, err := r.quotas.TaskQuota(ctx, org)
+	if err != nil {
+		return 0, err
+	}
+	seen[org] = q.MaxRunHistory
+	return q.MaxRunHistory, nil
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (r *RunRetention) PrometheusCollectors() []prometheus.Collector {
+	return r.metrics.PrometheusCollectors()
+}
+
+// runRetentionMetrics is a collection of metrics relating to run retention.
+type runRetentionMetrics struct {
+	checks     *prometheus.CounterVec
+	runsPruned prometheus.Counter
+}
+
+func newRunRetentionMetrics() *runRetentionMetrics {
+	const namespace = "task"
+	const subsystem = "run_retention"
+
+	return &runRetentionMetrics{
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "checks_total",
+			Help:      "Number of times runs were pruned, split out by success or failure.",
+		}, []string{"status"}),
+		runsPruned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_pruned_total",
+			Help:      "Number of runs and their logs deleted because they were outside the retention limits.",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (rm *runRetentionMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		rm.checks,
+		rm.runsPruned,
+	}
+}
+
+// Prune adjusts the metrics to indicate the result of pruning runs.
+func (rm *runRetentionMetrics) Prune(n int, err error) {
+	rm.checks.WithLabelValues(statusString(err == nil)).Inc()
+	rm.runsPruned.Add(float64(n))
+}
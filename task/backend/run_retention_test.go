@@ -0,0 +1,217 @@
+package backend_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestRunRetention_Prune(t *testing.T) {
+	ctx := context.Background()
+	task := &backend.StoreTask{ID: platform.ID(1), Org: platform.ID(2)}
+
+	// newRuns returns a run store holding four finished runs scheduled at 60s intervals, and one unfinished run.
+	newRuns := func(t *testing.T) (backend.LogReader, backend.RunPruner) {
+		t.Helper()
+		rw := backend.NewInMemRunReaderWriter()
+		for i := 1; i <= 5; i++ {
+			rlb := backend.RunLogBase{Task: task, RunID: platform.ID(i), RunScheduledFor: int64(i * 60)}
+			if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor, 0), backend.RunStarted); err != nil {
+				t.Fatal(err)
+			}
+			if i == 5 {
+				continue
+			}
+			if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor+1, 0), backend.RunSuccess); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rw, rw
+	}
+
+	remaining := func(t *testing.T, r backend.LogReader) []platform.ID {
+		t.Helper()
+		runs, err := r.ListRuns(ctx, platform.RunFilter{Org: &task.Org, Task: &task.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]platform.ID, len(runs))
+		for i, run := range runs {
+			ids[i] = run.ID
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name      string
+		opts      []backend.RunRetentionOption
+		expPruned int
+		expRunIDs []platform.ID
+	}{
+		{
+			name:      "no limits",
+			expRunIDs: []platform.ID{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "max age",
+			opts:      []backend.RunRetentionOption{backend.WithMaxRunAge(3 * time.Minute)},
+			expPruned: 1,
+			expRunIDs: []platform.ID{2, 3, 4, 5},
+		},
+		{
+			name:      "max runs per task",
+			opts:      []backend.RunRetentionOption{backend.WithMaxRunsPerTask(1)},
+			expPruned: 3,
+			expRunIDs: []platform.ID{4, 5},
+		},
+		{
+			name:      "max age and runs per task",
+			opts:      []backend.RunRetentionOption{backend.WithMaxRunAge(3 * time.Minute), backend.WithMaxRunsPerTask(2)},
+			expPruned: 2,
+			expRunIDs: []platform.ID{3, 4, 5},
+		},
+		{
+			name:      "unfinished runs are retained",
+			opts:      []backend.RunRetentionOption{backend.WithMaxRunAge(time.Second)},
+			expPruned: 4,
+			expRunIDs: []platform.ID{5},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, p := newRuns(t)
+			n, err := backend.NewRunRetention(p, tc.opts...).Prune(ctx, time.Unix(6*60, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != tc.expPruned {
+				t.Fatalf("expected %d runs pruned, got %d", tc.expPruned, n)
+			}
+
+			ids := remaining(t, r)
+			if len(ids) != len(tc.expRunIDs) {
+				t.Fatalf("expected runs %v to remain, got %v", tc.expRunIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tc.expRunIDs[i] {
+					t.Fatalf("expected runs %v to remain, got %v", tc.expRunIDs, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestRunRetention_TaskRetention(t *testing.T) {
+	ctx := context.Background()
+	st := backend.NewInMemStore()
+
+	const script = `option task = {
+	name: "retained",
+	every: 1m,
+	retainRuns: 3,
+}
+
+from(bucket: "b") |> range(start: -1m)`
+	taskID, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: platform.ID(2), User: platform.ID(3), Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := st.FindTaskByID(ctx, taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rw := backend.NewInMemRunReaderWriter()
+	for i := 1; i <= 5; i++ {
+		rlb := backend.RunLogBase{Task: task, RunID: platform.ID(i), RunScheduledFor: int64(i * 60)}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor, 0), backend.RunStarted); err != nil {
+			t.Fatal(err)
+		}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor+1, 0), backend.RunSuccess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The task's own limit applies in place of the server's, whether the server's would retain more or fewer runs.
+	r := backend.NewRunRetention(rw, backend.WithTaskRetention(st), backend.WithMaxRunAge(time.Second), backend.WithMaxRunsPerTask(4))
+	n, err := r.Prune(ctx, time.Unix(6*60, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 runs pruned, got %d", n)
+	}
+
+	runs, err := rw.ListRuns(ctx, platform.RunFilter{Org: &task.Org, Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expRunIDs := []platform.ID{3, 4, 5}
+	if len(runs) != len(expRunIDs) {
+		t.Fatalf("expected %d runs to remain, got %d", len(expRunIDs), len(runs))
+	}
+	for i, run := range runs {
+		if run.ID != expRunIDs[i] {
+			t.Fatalf("expected run %s to remain at position %d, got %s", expRunIDs[i], i, run.ID)
+		}
+	}
+}
+
+func TestRunRetention_Quotas(t *testing.T) {
+	ctx := context.Background()
+	st := backend.NewInMemStore()
+
+	const script = `option task = {
+	name: "quota",
+	every: 1m,
+	retainRuns: 4,
+}
+
+from(bucket: "b") |> range(start: -1m)`
+	taskID, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: platform.ID(2), User: platform.ID(3), Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := st.FindTaskByID(ctx, taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rw := backend.NewInMemRunReaderWriter()
+	for i := 1; i <= 5; i++ {
+		rlb := backend.RunLogBase{Task: task, RunID: platform.ID(i), RunScheduledFor: int64(i * 60)}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor, 0), backend.RunStarted); err != nil {
+			t.Fatal(err)
+		}
+		if err := rw.UpdateRunState(ctx, rlb, time.Unix(rlb.RunScheduledFor+1, 0), backend.RunSuccess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The organization's quota keeps fewer runs than the task's own limit, so it applies instead.
+	quotas := backend.StaticQuotas{Orgs: map[platform.ID]backend.TaskQuota{task.Org: {MaxRunHistory: 2}}}
+	r := backend.NewRunRetention(rw, backend.WithTaskRetention(st), backend.WithRetentionQuotas(quotas))
+	n, err := r.Prune(ctx, time.Unix(6*60, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 runs pruned, got %d", n)
+	}
+
+	runs, err := rw.ListRuns(ctx, platform.RunFilter{Org: &task.Org, Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expRunIDs := []platform.ID{4, 5}
+	if len(runs) != len(expRunIDs) {
+		t.Fatalf("expected %d runs to remain, got %d", len(expRunIDs), len(runs))
+	}
+	for i, run := range runs {
+		if run.ID != expRunIDs[i] {
+			t.Fatalf("expected run %s to remain at position %d, got %s", expRunIDs[i], i, run.ID)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package backend
+
+import (
+	"sort"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// MaxRunOutcomes is the number of latest run outcomes retained for each task, from which its TaskStats are computed.
+const MaxRunOutcomes = 100
+
+// MaxSucceededWindows is the most schedule times of successful runs retained for each task,
+// against which the scheduler deduplicates runs. The latest schedule times are kept.
+// Stores also drop the schedule times the task has already completed, so in practice only
+// windows that a backfill ran ahead of the schedule are retained.
+const MaxSucceededWindows = 1000
+
+// RunOutcome records how an executed run of a task ended.
+type RunOutcome struct {
+	RunID platform.ID
+
+	// ScheduledFor is the Unix timestamp the run was scheduled for, i.e. the "now" of its QueuedRun.
+	ScheduledFor int64
+
+	// Status is the status the run finished in: RunSuccess, RunFail, RunCanceled, or RunTimedOut.
+	Status RunStatus
+
+	// When execution of the run started and finished.
+	StartedAt, FinishedAt time.Time
+
+	// Error describes why the run did not succeed. Empty if the run succeeded.
+	Error string
+
+	// ErrorCode is the platform error code of the error that ended the run. Empty if the run succeeded.
+	ErrorCode string
+
+	// RowsWritten is how many rows the run wrote, if its executor reports it through a RowCounter.
+	RowsWritten int64
+
+	// MaxMemoryBytes is the most memory the run's query allocated at once, if its executor reports it through a MemoryReporter.
+	MaxMemoryBytes int64
+}
+
+// Duration returns how long the run took to execute.
+func (r RunOutcome) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// failed reports whether r counts as a failure in a task's statistics.
+// Canceled runs are neither successes nor failures.
+func (r RunOutcome) failed() bool {
+	return r.Status == RunFail || r.Status == RunTimedOut
+}
+
+// TaskRunHistory holds the latest run outcomes of a task.
+// Stores persist a TaskRunHistory for each task, updated through RecordRunOutcome.
+type TaskRunHistory struct {
+	// Outcomes holds the latest MaxRunOutcomes outcomes, oldest first.
+	Outcomes []RunOutcome
+
+	// LastFailure is the latest failed run, which may have been dropped from Outcomes. Nil if no run has failed.
+	LastFailure *RunOutcome
+
+	// Succeeded holds the schedule times of successful runs, in ascending order,
+	// limited to the latest MaxSucceededWindows times after the task's latest completed time.
+	Succeeded []int64
+
+	// Usage is the resource use of every run recorded for the task, including those dropped from Outcomes.
+	Usage TaskUsage
+}
+
+// TaskUsage is the cumulative resource use of a task's executed runs,
+// by which operators can attribute the cost of tasks to their organizations.
+type TaskUsage struct {
+	// Runs is the number of executed runs covered.
+	Runs int64
+
+	// ExecutionTime is the total wall time the runs took to execute.
+	ExecutionTime time.Duration
+
+	// RowsWritten is the total number of rows the runs wrote.
+	RowsWritten int64
+
+	// MemoryBytes is the sum of the most memory each run's query allocated at once,
+	// and MaxMemoryBytes is the most memory any one run's query allocated at once.
+	MemoryBytes, MaxMemoryBytes int64
+}
+
+// add adds the resource use of the run that ended with the outcome o to u.
+func (u *TaskUsage) add(o RunOutcome) {
+	u.Runs++
+	if d := o.Duration(); d > 0 {
+		u.ExecutionTime += d
+	}
+	u.RowsWritten += o.RowsWritten
+	u.MemoryBytes += o.MaxMemoryBytes
+	if o.MaxMemoryBytes > u.MaxMemoryBytes {
+		u.MaxMemoryBytes = o.MaxMemoryBytes
+	}
+}
+
+// Add records o as the latest outcome, dropping the oldest outcomes so that no more than MaxRunOutcomes remain.
+func (h *TaskRunHistory) Add(o RunOutcome) {
+	h.Outcomes = append(h.Outcomes, o)
+	if n := len(h.Outcomes) - MaxRunOutcomes; n > 0 {
+		h.Outcomes = append([]RunOutcome(nil), h.Outcomes[n:]...)
+	}
+
+	if o.failed() {
+		h.LastFailure = &o
+	}
+	h.Usage.add(o)
+
+	if o.Status == RunSuccess && o.ScheduledFor != 0 {
+		h.addSucceeded(o.ScheduledFor)
+	}
+}
+
+// addSucceeded records that a run scheduled for now succeeded,
+// dropping the earliest schedule times so that no more than MaxSucceededWindows remain.
+func (h *TaskRunHistory) addSucceeded(now int64) {
+	i := sort.Search(len(h.Succeeded), func(i int) bool { return h.Succeeded[i] >= now })
+	if i < len(h.Succeeded) && h.Succeeded[i] == now {
+		return
+	}
+	h.Succeeded = append(h.Succeeded, 0)
+	copy(h.Succeeded[i+1:], h.Succeeded[i:])
+	h.Succeeded[i] = now
+
+	if n := len(h.Succeeded) - MaxSucceededWindows; n > 0 {
+		h.Succeeded = append([]int64(nil), h.Succeeded[n:]...)
+	}
+}
+
+// DropSucceededThrough drops the schedule times at or before latestCompleted.
+// The scheduler does not schedule those windows again, so there is no run to deduplicate against them.
+func (h *TaskRunHistory) DropSucceededThrough(latestCompleted int64) {
+	i := sort.Search(len(h.Succeeded), func(i int) bool { return h.Succeeded[i] > latestCompleted })
+	if i == 0 {
+		return
+	}
+	if i == len(h.Succeeded) {
+		h.Succeeded = nil
+		return
+	}
+	h.Succeeded = append([]int64(nil), h.Succeeded[i:]...)
+}
+
+// HasSucceeded reports whether a run scheduled for the Unix timestamp now is known to have succeeded.
+func (h TaskRunHistory) HasSucceeded(now int64) bool {
+	i := sort.Search(len(h.Succeeded), func(i int) bool { return h.Succeeded[i] >= now })
+	return i < len(h.Succeeded) && h.Succeeded[i] == now
+}
+
+// Stats computes the task statistics over the outcomes in h.
+func (h TaskRunHistory) Stats() TaskStats {
+	stats := TaskStats{Runs: len(h.Outcomes), Usage: h.Usage}
+	if h.LastFailure != nil {
+		lf := *h.LastFailure
+		stats.LastFailure = &lf
+	}
+	if len(h.Outcomes) == 0 {
+		return stats
+	}
+
+	durations := make([]time.Duration, len(h.Outcomes))
+	var total time.Duration
+	for i, o := range h.Outcomes {
+		switch {
+		case o.Status == RunSuccess:
+			stats.Succeeded++
+		case o.failed():
+			stats.Failed++
+		}
+		durations[i] = o.Duration()
+		total += durations[i]
+	}
+
+	if n := stats.Succeeded + stats.Failed; n > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(n)
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.AverageDuration = total / time.Duration(len(durations))
+	stats.MedianDuration = durationPercentile(durations, 50)
+	stats.P95Duration = durationPercentile(durations, 95)
+	return stats
+}
+
+// durationPercentile returns the p-th percentile of sorted, using the nearest-rank method.
+func durationPercentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// TaskStats summarizes the health of a task over its latest runs.
+type TaskStats struct {
+	// Runs is the number of runs covered by the statistics, at most MaxRunOutcomes.
+	Runs int
+
+	// Succeeded and Failed count the covered runs that succeeded, and that failed or timed out.
+	// Canceled runs are counted in neither.
+	Succeeded, Failed int
+
+	// SuccessRate is Succeeded divided by the sum of Succeeded and Failed, or zero if both are zero.
+	SuccessRate float64
+
+	// Durations of the covered runs' execution.
+	AverageDuration, MedianDuration, P95Duration time.Duration
+
+	// LastFailure is the latest failed run, which may be older than the covered runs. Nil if no run has failed.
+	LastFailure *RunOutcome
+
+	// Usage is the resource use of all the task's recorded runs, not only the covered runs.
+	Usage TaskUsage
+}
@@ -0,0 +1,105 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestTaskRunHistory(t *testing.T) {
+	start := time.Unix(1000, 0)
+	outcome := func(id int, s backend.RunStatus, d time.Duration) backend.RunOutcome {
+		return backend.RunOutcome{RunID: platform.ID(id), Status: s, StartedAt: start, FinishedAt: start.Add(d)}
+	}
+
+	var h backend.TaskRunHistory
+	if stats := h.Stats(); stats.Runs != 0 || stats.SuccessRate != 0 || stats.LastFailure != nil {
+		t.Fatalf("expected empty stats, got %#v", stats)
+	}
+
+	h.Add(outcome(1, backend.RunFail, time.Second))
+	// Enough successes to push the failure out of the retained outcomes, with durations 1s to 100s.
+	for i := 1; i <= backend.MaxRunOutcomes; i++ {
+		h.Add(outcome(i+1, backend.RunSuccess, time.Duration(i)*time.Second))
+	}
+
+	if len(h.Outcomes) != backend.MaxRunOutcomes {
+		t.Fatalf("expected %d retained outcomes, got %d", backend.MaxRunOutcomes, len(h.Outcomes))
+	}
+
+	stats := h.Stats()
+	if stats.Runs != backend.MaxRunOutcomes || stats.Succeeded != backend.MaxRunOutcomes || stats.Failed != 0 {
+		t.Fatalf("unexpected counts: %#v", stats)
+	}
+	if stats.SuccessRate != 1 {
+		t.Fatalf("expected success rate 1, got %v", stats.SuccessRate)
+	}
+	if stats.AverageDuration != 50500*time.Millisecond {
+		t.Fatalf("expected average duration 50.5s, got %v", stats.AverageDuration)
+	}
+	if stats.MedianDuration != 50*time.Second || stats.P95Duration != 95*time.Second {
+		t.Fatalf("expected median 50s and p95 95s, got %v and %v", stats.MedianDuration, stats.P95Duration)
+	}
+	if stats.LastFailure == nil || stats.LastFailure.RunID != 1 {
+		t.Fatalf("expected last failure to be retained after its outcome was dropped, got %#v", stats.LastFailure)
+	}
+	// Usage covers every recorded run, including those dropped from the outcomes: 1s, then 1s to 100s.
+	if u := stats.Usage; u.Runs != backend.MaxRunOutcomes+1 || u.ExecutionTime != 5051*time.Second {
+		t.Fatalf("expected usage of %d runs taking 5051s, got %#v", backend.MaxRunOutcomes+1, u)
+	}
+
+	// Canceled runs count toward neither successes nor failures.
+	h = backend.TaskRunHistory{}
+	h.Add(outcome(1, backend.RunSuccess, time.Second))
+	h.Add(outcome(2, backend.RunTimedOut, time.Second))
+	h.Add(outcome(3, backend.RunCanceled, time.Second))
+	stats = h.Stats()
+	if stats.Runs != 3 || stats.Succeeded != 1 || stats.Failed != 1 || stats.SuccessRate != 0.5 {
+		t.Fatalf("unexpected stats: %#v", stats)
+	}
+	if stats.LastFailure == nil || stats.LastFailure.RunID != 2 {
+		t.Fatalf("expected timed out run to be the last failure, got %#v", stats.LastFailure)
+	}
+}
+
+func TestTaskRunHistory_Succeeded(t *testing.T) {
+	var h backend.TaskRunHistory
+	h.Add(backend.RunOutcome{RunID: 1, ScheduledFor: 120, Status: backend.RunSuccess})
+	h.Add(backend.RunOutcome{RunID: 2, ScheduledFor: 60, Status: backend.RunFail})
+	// A backfill, succeeding for windows in any order.
+	h.Add(backend.RunOutcome{RunID: 3, ScheduledFor: 60, Status: backend.RunSuccess})
+	h.Add(backend.RunOutcome{RunID: 4, ScheduledFor: 120, Status: backend.RunSuccess})
+
+	if !h.HasSucceeded(60) || !h.HasSucceeded(120) || h.HasSucceeded(180) {
+		t.Fatalf("unexpected succeeded windows: %v", h.Succeeded)
+	}
+	if len(h.Succeeded) != 2 || h.Succeeded[0] != 60 || h.Succeeded[1] != 120 {
+		t.Fatalf("expected sorted windows without duplicates, got %v", h.Succeeded)
+	}
+
+	// Only the latest windows are retained.
+	for i := 0; i < backend.MaxSucceededWindows; i++ {
+		h.Add(backend.RunOutcome{ScheduledFor: int64(180 + 60*i), Status: backend.RunSuccess})
+	}
+	if len(h.Succeeded) != backend.MaxSucceededWindows {
+		t.Fatalf("expected %d retained windows, got %d", backend.MaxSucceededWindows, len(h.Succeeded))
+	}
+	if h.HasSucceeded(60) || h.HasSucceeded(120) || !h.HasSucceeded(180) {
+		t.Fatal("expected the earliest windows to be dropped")
+	}
+
+	// Windows the task has completed are dropped.
+	h.DropSucceededThrough(240)
+	if h.HasSucceeded(180) || h.HasSucceeded(240) || !h.HasSucceeded(300) {
+		t.Fatalf("expected windows through 240 to be dropped, got first window %d", h.Succeeded[0])
+	}
+	if len(h.Succeeded) != backend.MaxSucceededWindows-2 {
+		t.Fatalf("expected %d retained windows, got %d", backend.MaxSucceededWindows-2, len(h.Succeeded))
+	}
+	h.DropSucceededThrough(1 << 40)
+	if len(h.Succeeded) != 0 {
+		t.Fatalf("expected no retained windows, got %d", len(h.Succeeded))
+	}
+}
@@ -0,0 +1,108 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"go.uber.org/zap"
+)
+
+// RunStatsMeasurement is the measurement of the points written by a RunStatsWriter.
+const RunStatsMeasurement = "runs"
+
+const (
+	durationField    = "durationMS"
+	rowsWrittenField = "rowsWritten"
+	errorCodeField   = "errorCode"
+
+	// defaultRunStatsTimeout is how long a RunStatsWriter waits for each write, unless set with WithRunStatsTimeout.
+	defaultRunStatsTimeout = time.Second
+)
+
+// RunStatsWriter is a RunObserver that writes the outcome of each run as a point to a bucket,
+// so that the health of tasks can be queried with Flux like any other data.
+//
+// Each point is in the runs measurement of the bucket, in the organization of the run's task, at the time the run finished.
+// It is tagged with the task's ID and the run's final status,
+// and has the fields runID, scheduledFor, durationMS, rowsWritten, and, for runs that did not succeed, errorCode.
+type RunStatsWriter struct {
+	ws      platform.WriteService
+	bucket  platform.ID
+	logger  *zap.Logger
+	timeout time.Duration
+}
+
+var _ RunObserver = (*RunStatsWriter)(nil)
+
+// RunStatsWriterOption is an option for NewRunStatsWriter.
+type RunStatsWriterOption func(*RunStatsWriter)
+
+// WithRunStatsLogger sets the logger to which a RunStatsWriter reports failed writes.
+func WithRunStatsLogger(logger *zap.Logger) RunStatsWriterOption {
+	return func(w *RunStatsWriter) {
+		w.logger = logger
+	}
+}
+
+// WithRunStatsTimeout sets how long a RunStatsWriter waits for each write.
+// Writes happen on the run's goroutine, so a slow write service delays the task's next run by up to d.
+func WithRunStatsTimeout(d time.Duration) RunStatsWriterOption {
+	return func(w *RunStatsWriter) {
+		w.timeout = d
+	}
+}
+
+// NewRunStatsWriter returns a RunStatsWriter that writes through ws to the bucket with the given ID.
+func NewRunStatsWriter(ws platform.WriteService, bucket platform.ID, opts ...RunStatsWriterOption) *RunStatsWriter {
+	w := &RunStatsWriter{
+		ws:      ws,
+		bucket:  bucket,
+		logger:  zap.NewNop(),
+		timeout: defaultRunStatsTimeout,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// ObserveRun writes the outcome o of the run qr of task.
+// A failed write is logged, and does not affect the run.
+func (w *RunStatsWriter) ObserveRun(ctx context.Context, task *StoreTask, qr QueuedRun, o RunOutcome) {
+	pt, err := runStatsPoint(task, o)
+	if err != nil {
+		w.logger.Info("Failed to encode run statistics", zap.String("task_id", task.ID.String()), zap.String("run_id", o.RunID.String()), zap.Error(err))
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(pt.String())
+	buf.WriteByte('\n')
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	if err := w.ws.Write(ctx, task.Org, w.bucket, &buf); err != nil {
+		w.logger.Info("Failed to write run statistics", zap.String("task_id", task.ID.String()), zap.String("run_id", o.RunID.String()), zap.Error(err))
+	}
+}
+
+// runStatsPoint returns the point that records the outcome o of a run of task.
+func runStatsPoint(task *StoreTask, o RunOutcome) (models.Point, error) {
+	tags := models.NewTags(map[string]string{
+		taskIDTag: task.ID.String(),
+		statusTag: o.Status.String(),
+	})
+	fields := map[string]interface{}{
+		runIDField:        o.RunID.String(),
+		scheduledForField: time.Unix(o.ScheduledFor, 0).UTC().Format(time.RFC3339),
+		durationField:     int64(o.Duration() / time.Millisecond),
+		rowsWrittenField:  o.RowsWritten,
+	}
+	if o.ErrorCode != "" {
+		fields[errorCodeField] = o.ErrorCode
+	}
+	return models.NewPoint(RunStatsMeasurement, tags, fields, o.FinishedAt)
+}
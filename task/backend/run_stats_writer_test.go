@@ -0,0 +1,93 @@
+package backend_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/task/backend"
+)
+
+func TestRunStatsWriter(t *testing.T) {
+	var gotOrg, gotBucket platform.ID
+	var lines []byte
+	ws := &mock.WriteService{
+		WriteF: func(ctx context.Context, org, bucket platform.ID, r io.Reader) error {
+			gotOrg, gotBucket = org, bucket
+			var err error
+			lines, err = ioutil.ReadAll(r)
+			return err
+		},
+	}
+	w := backend.NewRunStatsWriter(ws, platform.ID(10))
+
+	task := &backend.StoreTask{ID: platform.ID(1), Org: platform.ID(2)}
+	start := time.Unix(1000, 0)
+	w.ObserveRun(context.Background(), task, backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(3), Now: 900}, backend.RunOutcome{
+		RunID:        platform.ID(3),
+		ScheduledFor: 900,
+		Status:       backend.RunFail,
+		StartedAt:    start,
+		FinishedAt:   start.Add(1500 * time.Millisecond),
+		Error:        "forced failure",
+		ErrorCode:    platform.EInternal,
+		RowsWritten:  7,
+	})
+
+	if gotOrg != task.Org || gotBucket != platform.ID(10) {
+		t.Fatalf("expected write to org %s and bucket %s, got org %s and bucket %s", task.Org, platform.ID(10), gotOrg, gotBucket)
+	}
+	pts, err := models.ParsePoints(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pts) != 1 {
+		t.Fatalf("expected 1 point, got %d: %q", len(pts), lines)
+	}
+
+	pt := pts[0]
+	if got := string(pt.Name()); got != backend.RunStatsMeasurement {
+		t.Fatalf("expected measurement %q, got %q", backend.RunStatsMeasurement, got)
+	}
+	if got := pt.Tags().GetString("taskID"); got != task.ID.String() {
+		t.Fatalf("expected taskID tag %q, got %q", task.ID.String(), got)
+	}
+	if got := pt.Tags().GetString("status"); got != backend.RunFail.String() {
+		t.Fatalf("expected status tag %q, got %q", backend.RunFail.String(), got)
+	}
+	if !pt.Time().Equal(start.Add(1500 * time.Millisecond)) {
+		t.Fatalf("expected point at the time the run finished, got %v", pt.Time())
+	}
+
+	fields, err := pt.Fields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := models.Fields{
+		"runID":        platform.ID(3).String(),
+		"scheduledFor": "1970-01-01T00:15:00Z",
+		"durationMS":   int64(1500),
+		"rowsWritten":  int64(7),
+		"errorCode":    platform.EInternal,
+	}
+	for k, v := range exp {
+		if fields[k] != v {
+			t.Errorf("expected field %s=%v, got %v", k, v, fields[k])
+		}
+	}
+	if len(fields) != len(exp) {
+		t.Errorf("expected fields %v, got %v", exp, fields)
+	}
+
+	// A failed write does not panic or otherwise affect the caller.
+	ws.WriteF = func(context.Context, platform.ID, platform.ID, io.Reader) error {
+		return errors.New("write failed")
+	}
+	w.ObserveRun(context.Background(), task, backend.QueuedRun{TaskID: task.ID, RunID: platform.ID(4)}, backend.RunOutcome{RunID: platform.ID(4), Status: backend.RunSuccess, StartedAt: start, FinishedAt: start})
+}
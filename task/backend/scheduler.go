@@ -1,15 +1,22 @@
 package backend
 
 import (
+	"container/heap"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/pool"
+	"github.com/influxdata/platform/task/options"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -19,13 +26,86 @@ var (
 	// ErrRunCanceled is returned from the RunResult when a Run is Canceled.  It is used mostly internally.
 	ErrRunCanceled = errors.New("run canceled")
 
+	// ErrRunTimedOut is returned from the RunResult when a Run exceeds its task's timeout option.
+	ErrRunTimedOut = errors.New("run timed out")
+
 	// ErrTaskNotClaimed is returned when attempting to operate against a task that must be claimed but is not.
 	ErrTaskNotClaimed = errors.New("task not claimed")
 
 	// ErrTaskAlreadyClaimed is returned when attempting to operate against a task that must not be claimed but is.
-	ErrTaskAlreadyClaimed = errors.New("task already claimed")
+	ErrTaskAlreadyClaimed = platform.NewCodedError(platform.EConflict, "task already claimed")
+
+	// ErrSchedulerDraining is returned when attempting to claim a task while the scheduler is draining.
+	ErrSchedulerDraining = errors.New("scheduler is draining")
+
+	// ErrSecretsUnsupported is returned when executing a run of a task whose script references secrets,
+	// with an Executor that cannot resolve them.
+	ErrSecretsUnsupported = errors.New("task script references secrets, which this executor cannot resolve")
 )
 
+// TaskLimitError is returned when a task cannot be claimed because as many tasks as allowed are already claimed.
+type TaskLimitError struct {
+	// The maximum number of claimed tasks.
+	Limit int
+}
+
+func (e TaskLimitError) Error() string {
+	return fmt.Sprintf("limit of %d claimed tasks reached", e.Limit)
+}
+
+// Code returns platform.EUnprocessableEntity.
+func (e TaskLimitError) Code() string {
+	return platform.EUnprocessableEntity
+}
+
+// ReleaseWarning is returned by Coordinator.DeleteTask when the task was deleted from the store,
+// but releasing it from the scheduler failed, as when the scheduler is restarting.
+// It is a warning rather than a failure: the release is retried, and the task stops running once it succeeds.
+type ReleaseWarning struct {
+	TaskID platform.ID
+
+	// The error releasing the task.
+	Err error
+}
+
+func (e ReleaseWarning) Error() string {
+	return fmt.Sprintf("task %s was deleted, but releasing it from the scheduler failed and will be retried: %v", e.TaskID, e.Err)
+}
+
+// MinIntervalError is returned when a task's schedule would run it more often than its organization allows.
+type MinIntervalError struct {
+	// The shortest time between the task's runs.
+	Interval time.Duration
+
+	// The shortest time allowed between runs of the organization's tasks.
+	Min time.Duration
+}
+
+func (e MinIntervalError) Error() string {
+	return fmt.Sprintf("task would run every %s, more often than the minimum interval of %s", e.Interval, e.Min)
+}
+
+// Code returns platform.EUnprocessableEntity.
+func (e MinIntervalError) Code() string {
+	return platform.EUnprocessableEntity
+}
+
+// InlineSecretError is returned when a task's script holds a credential,
+// rather than referencing it from the secret service through options.SecretsIdentifier.
+type InlineSecretError struct {
+	// What the script holds, as described by options.InlineSecret.
+	Secret string
+}
+
+func (e InlineSecretError) Error() string {
+	return fmt.Sprintf("task script inlines a secret (%s): store it as a secret of the organization and reference it with %s.get(key: ...)", e.Secret, options.SecretsIdentifier)
+}
+
+// Code returns platform.EInvalid.
+func (e InlineSecretError) Code() string {
+	return platform.EInvalid
+}
+
 // DesiredState persists the desired state of a run.
 type DesiredState interface {
 	// CreateNextRun requests the next run from the desired state, delegating to (*StoreTaskMeta).CreateNextRun.
@@ -39,6 +119,26 @@ type DesiredState interface {
 	// FinishRun indicates that the given run is no longer intended to be executed.
 	// This may be called after a successful or failed execution, or upon cancellation.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// RecordRunOutcome records how an executed run ended, for the task's statistics.
+	// It is called once for each run that was executed, when the run succeeds, fails, is canceled, or times out,
+	// unless the outcome is recorded through CompleteRun.
+	RecordRunOutcome(ctx context.Context, taskID platform.ID, o RunOutcome) error
+
+	// CompleteRun finishes the run o.RunID, as by FinishRun, and records its outcome o, as by RecordRunOutcome,
+	// in a single transaction, so that the task's checkpoint never shows a finished run as still in progress.
+	CompleteRun(ctx context.Context, taskID platform.ID, o RunOutcome) error
+
+	// RunSucceeded reports whether a run of the task scheduled for the Unix timestamp now has already succeeded,
+	// such as when a manual run overlapped the task's schedule. The scheduler skips such runs.
+	RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error)
+}
+
+// RunObserver is notified of how each executed run ended.
+type RunObserver interface {
+	// ObserveRun is called after the outcome o of the run qr of task is recorded.
+	// It is called on the run's goroutine, so it should return promptly.
+	ObserveRun(ctx context.Context, task *StoreTask, qr QueuedRun, o RunOutcome)
 }
 
 // Executor handles execution of a run.
@@ -55,6 +155,29 @@ type Executor interface {
 	Wait()
 }
 
+// ExecutorLoad describes how busy an executor is.
+type ExecutorLoad struct {
+	// Queued is the number of runs the executor has accepted but not yet begun executing.
+	Queued int
+
+	// Available is the number of further runs the executor can begin executing immediately.
+	Available int
+}
+
+// Saturated reports whether a new run given to the executor would have to wait for a free slot.
+func (l ExecutorLoad) Saturated() bool {
+	return l.Available <= 0
+}
+
+// LoadReporter is implemented by an Executor that can report how busy it is.
+// While the executor is saturated, the scheduler does not create new runs;
+// due runs are created on a later tick once the executor has a free slot,
+// rather than piling up in the executor waiting for one.
+type LoadReporter interface {
+	// Load returns the executor's current load. It is called before each run is created, so it should be cheap.
+	Load() ExecutorLoad
+}
+
 // QueuedRun is a task run that has been assigned an ID,
 // but whose execution has not necessarily started.
 type QueuedRun struct {
@@ -66,6 +189,18 @@ type QueuedRun struct {
 	// The Unix timestamp (seconds since January 1, 1970 UTC) that will be set
 	// as the "now" option when executing the task.
 	Now int64
+
+	// The ID of the earlier run that this run retries, if it is a retry of an individual run. Invalid otherwise.
+	RetryOf platform.ID
+
+	// The ID of the trace the scheduler started for the run, set once the run starts.
+	// Empty if the tracer does not report trace IDs.
+	TraceID string
+
+	// A copy of the notification settings in the task's meta when the run was created,
+	// so that observers can dispatch notifications about the run without looking the task up.
+	// Nil if the task has no notification settings.
+	Notification *StoreTaskMetaNotification
 }
 
 // RunPromise represents an in-progress run whose result is not yet known.
@@ -95,6 +230,18 @@ type RunResult interface {
 	// TODO(mr): add more detail here like number of points written, execution time, etc.
 }
 
+// RowCounter is implemented by RunResults that report how many rows their run wrote.
+// The count is recorded as the RowsWritten of the run's outcome.
+type RowCounter interface {
+	RowsWritten() int64
+}
+
+// MemoryReporter is implemented by RunResults that report the most memory their run's query allocated at once.
+// The amount is recorded as the MaxMemoryBytes of the run's outcome.
+type MemoryReporter interface {
+	MaxMemoryBytes() int64
+}
+
 // Scheduler accepts tasks and handles their scheduling.
 //
 // TODO(mr): right now the methods on Scheduler are synchronous.
@@ -108,6 +255,13 @@ type Scheduler interface {
 	// Stop a scheduler from ticking.
 	Stop()
 
+	// Drain stops the scheduler from claiming tasks or starting new runs,
+	// and blocks until in-flight runs finish or ctx is done, whichever happens first.
+	// Runs still in progress when ctx is done are canceled.
+	// Once in-flight runs are finished, all tasks are released and the scheduler is stopped.
+	// If ctx was done before in-flight runs finished, ctx.Err() is returned.
+	Drain(ctx context.Context) error
+
 	// ClaimTask begins control of task execution in this scheduler.
 	ClaimTask(task *StoreTask, meta *StoreTaskMeta) error
 
@@ -118,37 +272,42 @@ type Scheduler interface {
 	// and releases any resources related to management of that task.
 	ReleaseTask(taskID platform.ID) error
 
+	// ClaimedTasks returns the IDs of the tasks currently claimed in this scheduler, in no particular order.
+	ClaimedTasks() []platform.ID
+
 	// Cancel stops an executing run.
 	CancelRun(ctx context.Context, taskID, runID platform.ID) error
 }
 
+// TaskRunCanceler is implemented by a Scheduler that can cancel all of a task's in-flight runs at once.
+type TaskRunCanceler interface {
+	// CancelTaskRuns stops the task with the given ID from starting new runs, cancels its in-flight runs,
+	// and blocks until each canceled run is marked canceled or ctx is done, whichever happens first.
+	// The task remains claimed; release it to discard it.
+	// If the task is not claimed, ErrTaskNotClaimed is returned.
+	CancelTaskRuns(ctx context.Context, taskID platform.ID) error
+}
+
 // TickSchedulerOption is a option you can use to modify the schedulers behavior.
 type TickSchedulerOption func(*TickScheduler)
 
-// WithTicker sets a time.Ticker with period d,
+// WithTicker sets a ticker with period d, created by the scheduler's Clock,
 // and calls TickScheduler.Tick when the ticker rolls over to a new second.
 // With a sub-second d, TickScheduler.Tick should be called roughly no later than d after a second:
 // this can help ensure tasks happen early with a second window.
+// The ticker stops when ctx is done.
 func WithTicker(ctx context.Context, d time.Duration) TickSchedulerOption {
 	return func(s *TickScheduler) {
-		ticker := time.NewTicker(d)
-
-		go func() {
-			prev := time.Now().Unix() - 1
-			for {
-				select {
-				case t := <-ticker.C:
-					u := t.Unix()
-					if u > prev {
-						prev = u
-						go s.Tick(u)
-					}
-				case <-ctx.Done():
-					ticker.Stop()
-					return
-				}
-			}
-		}()
+		s.tickerCtx = ctx
+		s.tickerPeriod = d
+	}
+}
+
+// WithClock sets the Clock the scheduler uses to tell the time, and to create the ticker set by WithTicker.
+// If not set, the scheduler uses clock.System.
+func WithClock(c clock.Clock) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.clock = c
 	}
 }
 
@@ -160,6 +319,52 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// WithJitter delays the start of each task's runs by up to window,
+// so that tasks sharing a schedule (such as every: 1h) do not all start at the same instant.
+// Each task is delayed by a fixed amount within the window, derived from its ID;
+// the time a run is scheduled for is unchanged.
+// A task's own jitter option takes precedence over window.
+func WithJitter(window time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.jitter = window
+	}
+}
+
+// WithRunObserver adds o to the observers notified of each executed run's outcome.
+func WithRunObserver(o RunObserver) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.observers = append(s.observers, o)
+	}
+}
+
+// WithOrgRunLimiter limits the rate at which each organization's runs start to the rate allowed by l.
+// Runs that would exceed the rate are held back, and started on a later tick once the organization's budget allows.
+func WithOrgRunLimiter(l *OrgRunLimiter) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.limiter = l
+	}
+}
+
+// WithQuotas enforces the run limits of the organizations' quotas found in qs:
+// runs start no faster than MaxRunsPerSecond, and runs executing for longer than MaxRunDuration are canceled as timed out.
+// Quotas are looked up on each run start, so qs should keep quotas in memory, as CachedQuotas does.
+// The rate is enforced through the OrgRunLimiter set by WithOrgRunLimiter, or one that only limits organizations with a quota.
+func WithQuotas(qs QuotaService) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.quotas = qs
+	}
+}
+
+// WithRunPool executes runs on the workers of p, rather than each on its own goroutine,
+// bounding the number of runs executing at once across every task.
+// Once p's workers are busy and its queue is full, starting a run waits for room in the queue, holding back the tick.
+// The scheduler does not close p.
+func WithRunPool(p *pool.Pool) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.runPool = p
+	}
+}
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -171,15 +376,54 @@ func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, no
 		logger:         zap.NewNop(),
 		wg:             &sync.WaitGroup{},
 		metrics:        newSchedulerMetrics(),
+		clock:          clock.System,
+	}
+	o.sla = newSLATracker(o.metrics, o.observeSLAMiss)
+	if l, ok := executor.(LoadReporter); ok {
+		o.load = l
 	}
 
 	for _, opt := range opts {
 		opt(o)
 	}
 
+	if o.quotas != nil {
+		if o.limiter == nil {
+			o.limiter = NewOrgRunLimiter(0, 1)
+		}
+		o.limiter.WithQuotas(o.quotas)
+	}
+
+	if o.tickerCtx != nil {
+		o.startTicker(o.tickerCtx, o.tickerPeriod)
+	}
+
 	return o
 }
 
+// startTicker starts calling s.Tick each time a ticker with period d rolls over to a new second, until ctx is done.
+// The ticker is created before startTicker returns, so that a clock.Mock advanced afterwards fires it.
+func (s *TickScheduler) startTicker(ctx context.Context, d time.Duration) {
+	ticker := s.clock.NewTicker(d)
+	prev := s.clock.Now().Unix() - 1
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case t := <-ticker.C():
+				u := t.Unix()
+				if u > prev {
+					prev = u
+					go s.Tick(u)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
 type TickScheduler struct {
 	desiredState DesiredState
 	executor     Executor
@@ -188,6 +432,35 @@ type TickScheduler struct {
 	now    int64
 	logger *zap.Logger
 
+	// Tells the time, and creates the ticker set by WithTicker.
+	clock        clock.Clock
+	tickerCtx    context.Context
+	tickerPeriod time.Duration
+
+	// Default jitter window for tasks that don't set the jitter option.
+	jitter time.Duration
+
+	observersMu sync.RWMutex  // Protects observers.
+	observers   []RunObserver // Notified of each executed run's outcome.
+
+	// Limits the rate of run starts per organization. Nil if runs are not limited.
+	limiter *OrgRunLimiter
+
+	// Executes runs on a bounded pool of workers. Nil if each run executes on its own goroutine.
+	runPool *pool.Pool
+
+	// The quotas limiting the runs of each organization. Nil if runs are not limited by quotas.
+	quotas QuotaService
+
+	// The executor's load, if it reports one. Nil otherwise.
+	load LoadReporter
+
+	// Tracks executing runs of tasks with the sla option.
+	sla *slaTracker
+
+	// Set to 1 while draining. Must be accessed atomically.
+	draining uint32
+
 	metrics *schedulerMetrics
 
 	ctx    context.Context
@@ -196,6 +469,15 @@ type TickScheduler struct {
 
 	schedulerMu    sync.Mutex                     // Protects access and modification of taskSchedulers map.
 	taskSchedulers map[platform.ID]*taskScheduler // task ID -> task scheduler.
+
+	// Claimed task schedulers, ordered by when they are next due,
+	// so that each tick only visits the tasks that are due rather than every claimed task.
+	dueMu sync.Mutex // Protects due, and the dueAt and dueIndex fields of each task scheduler.
+	due   dueQueue
+
+	// The organization whose tasks were worked first on the previous tick. Protected by schedulerMu.
+	// Each tick starts with the next organization by ID, so that no organization is always first to the executor.
+	firstOrg platform.ID
 }
 
 // CancelRun cancels a run, it has the unused Context argument so that it can implement a task.RunController
@@ -219,14 +501,49 @@ func (s *TickScheduler) CancelRun(_ context.Context, taskID, runID platform.ID)
 	return nil
 }
 
+// CancelTaskRuns implements TaskRunCanceler.
+func (s *TickScheduler) CancelTaskRuns(ctx context.Context, taskID platform.ID) error {
+	s.schedulerMu.Lock()
+	ts, ok := s.taskSchedulers[taskID]
+	s.schedulerMu.Unlock()
+	if !ok {
+		return ErrTaskNotClaimed
+	}
+
+	atomic.StoreUint32(&ts.stopped, 1)
+
+	ts.runningMu.Lock()
+	runs := make([]runCtx, 0, len(ts.running))
+	for _, rc := range ts.running {
+		runs = append(runs, rc)
+	}
+	ts.runningMu.Unlock()
+
+	for _, rc := range runs {
+		rc.CancelFunc()
+	}
+	for _, rc := range runs {
+		select {
+		case <-rc.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // Tick updates the time of the scheduler.
 // Any owned tasks who are due to execute and who have a free concurrency slot,
 // will begin a new execution.
+//
+// Due tasks are worked in round-robin order across organizations, rather than in the order they became due,
+// so that while the executor is saturated, a burst of runs from one organization
+// does not hold back every other organization's runs until the burst is through.
 func (s *TickScheduler) Tick(now int64) {
 	s.schedulerMu.Lock()
 	defer s.schedulerMu.Unlock()
 
-	if s.ctx == nil {
+	if s.ctx == nil || s.isDraining() {
 		return
 	}
 
@@ -239,13 +556,26 @@ func (s *TickScheduler) Tick(now int64) {
 
 	atomic.StoreInt64(&s.now, now)
 
+	// Take the due task schedulers off the queue before working them, since working a task scheduler reschedules it.
+	var due []*taskScheduler
+	s.dueMu.Lock()
+	for len(s.due) > 0 && s.due[0].dueAt <= now {
+		due = append(due, heap.Pop(&s.due).(*taskScheduler))
+	}
+	s.dueMu.Unlock()
+	due = s.fairOrder(due)
+
 	affected := 0
-	for _, ts := range s.taskSchedulers {
+	for _, ts := range due {
 		if nextDue, hasQueue := ts.NextDue(); now >= nextDue || hasQueue {
 			ts.Work()
 			affected++
 		}
+		// A task that is still due, such as one whose runners are all busy, is checked again on the next tick.
+		s.queueDue(ts, now+1)
 	}
+
+	s.sla.check(s.ctx, now)
 	// TODO(mr): find a way to emit a more useful / less annoying tick message, maybe aggregated over the past 10s or 30s?
 	s.logger.Debug("Ticked", zap.Int64("now", now), zap.Int("tasks_affected", affected))
 }
@@ -255,6 +585,7 @@ func (s *TickScheduler) Start(ctx context.Context) {
 	defer s.schedulerMu.Unlock()
 
 	s.ctx, s.cancel = context.WithCancel(ctx)
+	atomic.StoreUint32(&s.draining, 0)
 }
 
 func (s *TickScheduler) Stop() {
@@ -274,6 +605,12 @@ func (s *TickScheduler) Stop() {
 		delete(s.taskSchedulers, id)
 		s.metrics.ReleaseTask(id.String())
 	}
+	s.dueMu.Lock()
+	for _, ts := range s.due {
+		ts.dueIndex = -1
+	}
+	s.due = nil
+	s.dueMu.Unlock()
 
 	// Wait for schedulers to clean up.
 	s.wg.Wait()
@@ -282,6 +619,40 @@ func (s *TickScheduler) Stop() {
 	s.executor.Wait()
 }
 
+// Drain stops claiming tasks and starting runs, waits for in-flight runs to finish, and then stops s.
+func (s *TickScheduler) Drain(ctx context.Context) error {
+	s.schedulerMu.Lock()
+	if s.cancel == nil {
+		// Never started, so nothing to drain.
+		s.schedulerMu.Unlock()
+		return nil
+	}
+	atomic.StoreUint32(&s.draining, 1)
+	s.schedulerMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Info("Deadline reached while draining; canceling in-flight runs", zap.Error(ctx.Err()))
+		err = ctx.Err()
+	}
+
+	// Stop cancels anything still running, releases all tasks, and waits for cleanup.
+	s.Stop()
+	return err
+}
+
+func (s *TickScheduler) isDraining() bool {
+	return atomic.LoadUint32(&s.draining) == 1
+}
+
 func (s *TickScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) (err error) {
 	s.schedulerMu.Lock()
 	defer s.schedulerMu.Unlock()
@@ -289,6 +660,10 @@ func (s *TickScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) (err err
 		return errors.New("can not claim tasks when i've not been started")
 	}
 
+	if s.isDraining() {
+		return ErrSchedulerDraining
+	}
+
 	select {
 	case <-s.ctx.Done():
 		return errors.New("can not claim a task if not started")
@@ -309,6 +684,7 @@ func (s *TickScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) (err err
 	}
 
 	s.taskSchedulers[task.ID] = ts
+	s.queueDue(ts, math.MinInt64)
 
 	if len(meta.CurrentlyRunning) > 0 {
 		if err := ts.WorkCurrentlyRunning(meta); err != nil {
@@ -338,11 +714,13 @@ func (s *TickScheduler) UpdateTask(task *StoreTask, meta *StoreTaskMeta) error {
 		return err
 	}
 
+	s.removeDue(ts)
 	s.taskSchedulers[task.ID] = nts
+	s.queueDue(nts, math.MinInt64)
 
-	next, hasQueue := ts.NextDue()
+	next, hasQueue := nts.NextDue()
 	if now := atomic.LoadInt64(&s.now); now >= next || hasQueue {
-		ts.Work()
+		nts.Work()
 	}
 
 	return nil
@@ -359,12 +737,146 @@ func (s *TickScheduler) ReleaseTask(taskID platform.ID) error {
 
 	t.Cancel()
 	delete(s.taskSchedulers, taskID)
+	s.removeDue(t)
 
 	s.metrics.ReleaseTask(taskID.String())
 
 	return nil
 }
 
+// fairOrder reorders due, which is in the order the task schedulers became due, to alternate between organizations:
+// each organization's earliest due task, then each organization's next task, and so on.
+// Organizations take turns in order of ID, starting after the organization that went first on the previous call.
+func (s *TickScheduler) fairOrder(due []*taskScheduler) []*taskScheduler {
+	byOrg := make(map[platform.ID][]*taskScheduler)
+	var orgs []platform.ID
+	for _, ts := range due {
+		org := ts.task.Org
+		if _, ok := byOrg[org]; !ok {
+			orgs = append(orgs, org)
+		}
+		byOrg[org] = append(byOrg[org], ts)
+	}
+	if len(orgs) < 2 {
+		return due
+	}
+
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i] < orgs[j] })
+	start := sort.Search(len(orgs), func(i int) bool { return orgs[i] > s.firstOrg }) % len(orgs)
+	orgs = append(orgs[start:], orgs[:start]...)
+	s.firstOrg = orgs[0]
+
+	fair := make([]*taskScheduler, 0, len(due))
+	for len(fair) < len(due) {
+		for _, org := range orgs {
+			if q := byOrg[org]; len(q) > 0 {
+				fair = append(fair, q[0])
+				byOrg[org] = q[1:]
+			}
+		}
+	}
+	return fair
+}
+
+// queueDue adds ts to s's due queue, or moves it within the queue, according to when it is next due,
+// but no earlier than min.
+func (s *TickScheduler) queueDue(ts *taskScheduler, min int64) {
+	at := ts.dueKey()
+	if at < min {
+		at = min
+	}
+
+	s.dueMu.Lock()
+	defer s.dueMu.Unlock()
+	ts.dueAt = at
+	if ts.dueIndex >= 0 {
+		heap.Fix(&s.due, ts.dueIndex)
+		return
+	}
+	heap.Push(&s.due, ts)
+}
+
+// rescheduleDue moves ts within s's due queue after its next due time changes.
+// A task scheduler that is not in the queue is left out; Tick queues it again once it has been worked.
+func (s *TickScheduler) rescheduleDue(ts *taskScheduler) {
+	s.dueMu.Lock()
+	defer s.dueMu.Unlock()
+	if ts.dueIndex < 0 {
+		return
+	}
+	ts.dueAt = ts.dueKey()
+	heap.Fix(&s.due, ts.dueIndex)
+}
+
+// removeDue removes ts from s's due queue, if it is there.
+func (s *TickScheduler) removeDue(ts *taskScheduler) {
+	s.dueMu.Lock()
+	defer s.dueMu.Unlock()
+	if ts.dueIndex >= 0 {
+		heap.Remove(&s.due, ts.dueIndex)
+	}
+}
+
+func (s *TickScheduler) ClaimedTasks() []platform.ID {
+	s.schedulerMu.Lock()
+	defer s.schedulerMu.Unlock()
+
+	ids := make([]platform.ID, 0, len(s.taskSchedulers))
+	for id := range s.taskSchedulers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// AddRunObserver adds o to the observers notified of each executed run's outcome,
+// including runs of tasks that are already claimed.
+// It is for observers that can only be created after s, such as a coordinator; otherwise, prefer WithRunObserver.
+func (s *TickScheduler) AddRunObserver(o RunObserver) {
+	s.observersMu.Lock()
+	defer s.observersMu.Unlock()
+
+	s.observers = append(s.observers, o)
+}
+
+// observeRun notifies each of s's observers of the outcome o of the run qr of task.
+func (s *TickScheduler) observeRun(ctx context.Context, task *StoreTask, qr QueuedRun, o RunOutcome) {
+	s.observersMu.RLock()
+	observers := s.observers
+	s.observersMu.RUnlock()
+
+	for _, obs := range observers {
+		obs.ObserveRun(ctx, task, qr, o)
+	}
+}
+
+// observeSLAMiss notifies each of s's observers that implement SLAObserver that the run qr of task missed its deadline.
+func (s *TickScheduler) observeSLAMiss(ctx context.Context, task *StoreTask, qr QueuedRun, deadline int64) {
+	s.observersMu.RLock()
+	observers := s.observers
+	s.observersMu.RUnlock()
+
+	for _, obs := range observers {
+		if so, ok := obs.(SLAObserver); ok {
+			so.ObserveSLAMiss(ctx, task, qr, deadline)
+		}
+	}
+}
+
+// OverdueRuns returns the runs that are still executing past the deadline set by their task's sla option,
+// earliest deadline first.
+func (s *TickScheduler) OverdueRuns() []OverdueRun {
+	return s.sla.overdue()
+}
+
+// CheckRunLimit returns ErrRunRateLimited if org has used up its budget of run starts, without using any of it.
+// It returns nil if the scheduler does not limit runs.
+func (s *TickScheduler) CheckRunLimit(org platform.ID) error {
+	if s.limiter == nil {
+		return nil
+	}
+	return s.limiter.CheckAt(org, s.clock.Now())
+}
+
 func (s *TickScheduler) PrometheusCollectors() []prometheus.Collector {
 	return s.metrics.PrometheusCollectors()
 }
@@ -372,6 +884,20 @@ func (s *TickScheduler) PrometheusCollectors() []prometheus.Collector {
 type runCtx struct {
 	Context    context.Context
 	CancelFunc context.CancelFunc
+
+	done chan struct{} // Closed once the run has finished and its final state is recorded.
+
+	// The run being executed, and when its execution started. See TickScheduler.State.
+	run       QueuedRun
+	startedAt time.Time
+
+	// Traces the run from when it was created until it finishes. Context carries it to the executor.
+	span opentracing.Span
+}
+
+func newRunCtx(parent context.Context) runCtx {
+	ctx, cancel := context.WithCancel(parent)
+	return runCtx{Context: ctx, CancelFunc: cancel, done: make(chan struct{})}
 }
 
 // taskScheduler is a lightweight wrapper around a collection of runners.
@@ -379,9 +905,19 @@ type taskScheduler struct {
 	// Reference to outerScheduler.now. Must be accessed atomically.
 	now *int64
 
+	// Reference to outerScheduler.draining. Must be accessed atomically.
+	draining *uint32
+
+	// Set to 1 once the task's runs are canceled through CancelTaskRuns, to stop new runs from starting.
+	// Must be accessed atomically.
+	stopped uint32
+
 	// Task we are scheduling for.
 	task *StoreTask
 
+	// Options parsed from the task's script, or the zero value if the script could not be parsed.
+	opts options.Options
+
 	// CancelFunc for context passed to runners, to enable Cancel method.
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -395,10 +931,43 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Reference to outerScheduler.limiter.
+	limiter *OrgRunLimiter
+
+	// Reference to outerScheduler.runPool.
+	runPool *pool.Pool
+
+	// Reference to outerScheduler.quotas.
+	quotas QuotaService
+
+	// Reference to outerScheduler.load.
+	load LoadReporter
+
+	// Reference to outerScheduler.sla.
+	sla *slaTracker
+
+	// Reference to outerScheduler.clock.
+	clock clock.Clock
+
+	// Reference to outerScheduler.observeRun.
+	observeRun func(ctx context.Context, task *StoreTask, qr QueuedRun, o RunOutcome)
+
+	// Reference to outerScheduler.rescheduleDue.
+	rescheduleDue func(ts *taskScheduler)
+
+	// Tracks the task's executing scheduled runs, for its overlap policy.
+	overlap *overlapTracker
+
+	// Position in the outer scheduler's due queue. Protected by outerScheduler.dueMu.
+	dueAt    int64 // Unix timestamp the task is queued to be checked at.
+	dueIndex int   // Index in the queue, or -1 if the task is not queued.
+
 	nextDueMu     sync.RWMutex // Protects following fields.
-	nextDue       int64        // Unix timestamp of next due.
+	nextDue       int64        // Unix timestamp of next due, before applying jitter.
+	jitter        int64        // Seconds to delay each scheduled run past its due time.
 	nextDueSource int64        // Run time that produced nextDue.
 	hasQueue      bool         // Whether there is a queue of manual runs.
+	queuedRuns    int          // Number of manual runs queued.
 }
 
 func newTaskScheduler(
@@ -414,19 +983,43 @@ func newTaskScheduler(
 		return nil, err
 	}
 
+	opts, err := task.ScriptOptions()
+	if err != nil {
+		opts = options.Options{}
+	}
+
+	jitterWindow := s.jitter
+	if opts.Jitter > 0 {
+		jitterWindow = opts.Jitter
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	ts := &taskScheduler{
 		now:           &s.now,
+		draining:      &s.draining,
 		task:          task,
+		opts:          opts,
 		cancel:        cancel,
 		wg:            wg,
 		runners:       make([]*runner, meta.MaxConcurrency),
 		running:       make(map[platform.ID]runCtx, meta.MaxConcurrency),
 		logger:        s.logger.With(zap.String("task_id", task.ID.String())),
 		metrics:       s.metrics,
+		limiter:       s.limiter,
+		runPool:       s.runPool,
+		quotas:        s.quotas,
+		load:          s.load,
+		sla:           s.sla,
+		clock:         s.clock,
+		observeRun:    s.observeRun,
+		rescheduleDue: s.rescheduleDue,
+		overlap:       newOverlapTracker(),
+		dueIndex:      -1,
 		nextDue:       firstDue,
+		jitter:        jitterDelay(task.ID, jitterWindow),
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(meta.ManualRuns) > 0,
+		queuedRuns:    len(meta.ManualRuns),
 	}
 
 	for i := range ts.runners {
@@ -439,21 +1032,31 @@ func newTaskScheduler(
 
 // Work begins a work cycle on the taskScheduler.
 // As many runners are started as possible.
+// If every runner is busy when a scheduled run is due, and the task's overlap policy is to cancel previous runs,
+// its executing scheduled runs are canceled, so that their runners start the due run.
 func (ts *taskScheduler) Work() {
 	for _, r := range ts.runners {
 		r.Start()
 		if r.IsIdle() {
 			// Ran out of jobs to start.
-			break
+			return
 		}
 	}
+
+	if len(ts.runners) == 0 || atomic.LoadUint32(ts.draining) == 1 || atomic.LoadUint32(&ts.stopped) == 1 {
+		return
+	}
+	now := atomic.LoadInt64(ts.now)
+	if nextDue, _ := ts.NextDue(); now >= nextDue && !ts.deferredAt(now) {
+		ts.runners[0].cancelOverlapped(platform.InvalidID(), "a newer scheduled run came due")
+	}
 }
 
 func (ts *taskScheduler) WorkCurrentlyRunning(meta *StoreTaskMeta) error {
 	for _, cr := range meta.CurrentlyRunning {
 		foundWorker := false
 		for _, r := range ts.runners {
-			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now}
+			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now, RetryOf: platform.ID(cr.RetryOf), Notification: meta.runNotification()}
 			if r.RestartRun(qr) {
 				foundWorker = true
 				break
@@ -473,22 +1076,103 @@ func (ts *taskScheduler) Cancel() {
 	ts.cancel()
 }
 
-// NextDue returns the next due timestamp, and whether there is a queue.
+// NextDue returns the next due timestamp, delayed by the task's jitter, and whether there is a queue.
 func (ts *taskScheduler) NextDue() (int64, bool) {
 	ts.nextDueMu.RLock()
 	defer ts.nextDueMu.RUnlock()
-	return ts.nextDue, ts.hasQueue
+	if ts.nextDue == NeverDue {
+		// Don't overflow by adding jitter.
+		return NeverDue, ts.hasQueue
+	}
+	return ts.nextDue + ts.jitter, ts.hasQueue
 }
 
-// SetNextDue sets the next due timestamp and whether the task has a queue,
+// dueKey returns the Unix timestamp at which ts should next be checked for work:
+// its next due time, or the earliest possible time if it has a queue of manual runs.
+func (ts *taskScheduler) dueKey() int64 {
+	next, hasQueue := ts.NextDue()
+	if hasQueue {
+		return math.MinInt64
+	}
+	return next
+}
+
+// dueQueue is a heap of task schedulers, ordered by dueAt, implementing heap.Interface.
+type dueQueue []*taskScheduler
+
+func (q dueQueue) Len() int           { return len(q) }
+func (q dueQueue) Less(i, j int) bool { return q[i].dueAt < q[j].dueAt }
+
+func (q dueQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].dueIndex = i
+	q[j].dueIndex = j
+}
+
+func (q *dueQueue) Push(x interface{}) {
+	ts := x.(*taskScheduler)
+	ts.dueIndex = len(*q)
+	*q = append(*q, ts)
+}
+
+func (q *dueQueue) Pop() interface{} {
+	old := *q
+	ts := old[len(old)-1]
+	old[len(old)-1] = nil
+	ts.dueIndex = -1
+	*q = old[:len(old)-1]
+	return ts
+}
+
+// jitterDelay returns the number of seconds, less than window, to delay runs of the given task.
+// The delay is derived from the task ID, so it is stable across restarts and schedulers.
+func jitterDelay(taskID platform.ID, window time.Duration) int64 {
+	secs := int64(window / time.Second)
+	if secs <= 1 {
+		return 0
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(taskID))
+	h := fnv.New32a()
+	h.Write(b[:])
+	return int64(h.Sum32() % uint32(secs))
+}
+
+// deferredAt reports whether runs should be held back at now, due to a blackout window with the defer policy.
+func (ts *taskScheduler) deferredAt(now int64) bool {
+	if ts.opts.BlackoutPolicy != options.BlackoutDefer {
+		return false
+	}
+	_, in := ts.opts.InBlackout(time.Unix(now, 0).UTC())
+	return in
+}
+
+// skipRun reports whether qr should not be executed,
+// because it was scheduled during a blackout window with the skip policy.
+// Manually requested runs are never skipped.
+func (ts *taskScheduler) skipRun(qr QueuedRun) bool {
+	if ts.opts.BlackoutPolicy != options.BlackoutSkip || qr.RequestedAt != 0 {
+		return false
+	}
+	_, in := ts.opts.InBlackout(time.Unix(qr.Now, 0).UTC())
+	return in
+}
+
+// SetNextDue sets the next due timestamp, whether the task has a queue and how many manual runs it holds,
 // and records the source (the now value of the run who reported nextDue).
-func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64) {
+func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, queuedRuns int, source int64) {
 	// TODO(mr): we may need some logic around source to handle if SetNextDue is called out of order.
 	ts.nextDueMu.Lock()
-	defer ts.nextDueMu.Unlock()
 	ts.nextDue = nextDue
 	ts.nextDueSource = source
 	ts.hasQueue = hasQueue
+	ts.queuedRuns = queuedRuns
+	ts.nextDueMu.Unlock()
+
+	if ts.rescheduleDue != nil {
+		ts.rescheduleDue(ts)
+	}
 }
 
 // A runner is one eligible "concurrency slot" for a given task.
@@ -574,27 +1258,82 @@ func (r *runner) RestartRun(qr QueuedRun) bool {
 	r.wg.Add(1)
 	r.ts.runningMu.Lock()
 	rCtx, ok := r.ts.running[qr.RunID]
-	if !ok {
-		ctx, cancel := context.WithCancel(context.TODO())
-		rCtx = runCtx{Context: ctx, CancelFunc: cancel}
-		r.ts.running[qr.RunID] = rCtx
+	if ok {
+		// The earlier execution of the run closes the done channel it was given; this execution needs its own.
+		rCtx.done = make(chan struct{})
+	} else {
+		rCtx = newRunCtx(context.TODO())
 	}
+	r.traceRun(&rCtx, &qr)
+	rCtx.run, rCtx.startedAt = qr, r.ts.clock.Now()
+	r.ts.running[qr.RunID] = rCtx
 	r.ts.runningMu.Unlock()
-	go r.executeAndWait(rCtx.Context, qr, runLogger)
+	r.ts.overlap.start(qr, atomic.LoadInt64(r.ts.now))
+	r.execute(rCtx, qr, runLogger)
 
 	r.updateRunState(qr, RunStarted, runLogger)
 	return true
 }
 
+// alreadySucceeded reports whether qr is a scheduled run for a window that a run of the task already succeeded for.
+// Manual runs and retries are never reported, as they were requested for windows that may well have succeeded.
+func (r *runner) alreadySucceeded(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) bool {
+	if qr.RequestedAt != 0 || qr.RetryOf.Valid() {
+		return false
+	}
+
+	succeeded, err := r.desiredState.RunSucceeded(ctx, qr.TaskID, qr.Now)
+	if err != nil {
+		// Better to compute the window twice than not at all.
+		runLogger.Info("Failed to check for earlier successful run; executing anyway", zap.Error(err))
+		return false
+	}
+	return succeeded
+}
+
 // startFromWorking attempts to create a run if one is due, and then begins execution on a separate goroutine.
 // r.state must be runnerWorking when this is called.
 func (r *runner) startFromWorking(now int64) {
+	if atomic.LoadUint32(r.ts.draining) == 1 {
+		// Don't start anything new while the scheduler drains.
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
+	if atomic.LoadUint32(&r.ts.stopped) == 1 {
+		// The task's runs were canceled, usually because it is being deleted.
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
 	if nextDue, hasQueue := r.ts.NextDue(); now < nextDue && !hasQueue {
 		// Not ready for a new run. Go idle again.
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
 	}
-	ctx, cancel := context.WithCancel(r.ctx)
+
+	if r.ts.deferredAt(now) {
+		// In a blackout window. Any due runs will be created once the window ends.
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
+	if r.ts.limiter != nil && !r.ts.limiter.AllowAt(r.task.Org, r.ts.clock.Now()) {
+		// The task's organization has used up its budget of run starts. Try again on a later tick.
+		r.ts.metrics.ThrottleRun(r.task.Org.String())
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
+	if r.ts.load != nil && r.ts.load.Load().Saturated() {
+		// The executor has no free slot for another run. Try again on a later tick.
+		r.ts.metrics.DelayRun()
+		atomic.StoreUint32(r.state, runnerIdle)
+		return
+	}
+
+	rCtx := newRunCtx(r.ctx)
+	ctx, cancel := rCtx.Context, rCtx.CancelFunc
 	rc, err := r.desiredState.CreateNextRun(ctx, r.task.ID, now)
 	if err != nil {
 		r.logger.Info("Failed to create run", zap.Error(err))
@@ -603,23 +1342,91 @@ func (r *runner) startFromWorking(now int64) {
 		return
 	}
 	qr := rc.Created
-	r.ts.runningMu.Lock()
-	r.ts.running[qr.RunID] = runCtx{Context: ctx, CancelFunc: cancel}
-	r.ts.runningMu.Unlock()
-	r.ts.SetNextDue(rc.NextDue, rc.HasQueue, qr.Now)
+	r.ts.SetNextDue(rc.NextDue, rc.HasQueue, rc.QueuedRuns, qr.Now)
+	r.traceRun(&rCtx, &qr)
+	ctx = rCtx.Context
 
 	// Create a new child logger for the individual run.
 	// We can't do r.logger = r.logger.With(zap.String("run_id", qr.RunID.String()) because zap doesn't deduplicate fields,
 	// and we'll quickly end up with many run_ids associated with the log.
 	runLogger := r.logger.With(zap.String("run_id", qr.RunID.String()), zap.Int64("now", qr.Now))
 
+	if r.ts.skipRun(qr) {
+		cancel()
+		rCtx.span.Finish()
+		runLogger.Info("Skipping run scheduled during blackout window")
+		if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+			runLogger.Info("Failed to finish skipped run", zap.Error(err))
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
+		r.updateSkippedRunState(qr, RunSkipped, "Skipped: scheduled during blackout window", runLogger)
+
+		// Move on to the next execution, for a skipped run.
+		r.startFromWorking(atomic.LoadInt64(r.ts.now))
+		return
+	}
+
+	if msg, skip := r.ts.skipOverlapping(qr); skip {
+		cancel()
+		rCtx.span.Finish()
+		runLogger.Info("Skipping run that came due while a previous run was executing")
+		if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+			runLogger.Info("Failed to finish skipped run", zap.Error(err))
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
+		r.updateSkippedRunState(qr, RunSkipped, msg, runLogger)
+
+		// Move on to the next execution, for a skipped run.
+		r.startFromWorking(atomic.LoadInt64(r.ts.now))
+		return
+	}
+
+	if r.alreadySucceeded(ctx, qr, runLogger) {
+		cancel()
+		rCtx.span.Finish()
+		runLogger.Info("Skipping run for window that already succeeded")
+		if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+			runLogger.Info("Failed to finish deduplicated run", zap.Error(err))
+			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
+		r.updateSkippedRunState(qr, RunDeduplicated, "Skipped: a run for this window already succeeded", runLogger)
+
+		// Move on to the next execution, for a deduplicated run.
+		r.startFromWorking(atomic.LoadInt64(r.ts.now))
+		return
+	}
+
+	rCtx.run, rCtx.startedAt = qr, r.ts.clock.Now()
+	r.ts.runningMu.Lock()
+	r.ts.running[qr.RunID] = rCtx
+	r.ts.runningMu.Unlock()
+	r.ts.overlap.start(qr, now)
+	if isScheduled(qr) {
+		r.cancelOverlapped(qr.RunID, fmt.Sprintf("run %s came due", qr.RunID))
+	}
+
 	runLogger.Info("Created run; beginning execution")
 	r.wg.Add(1)
-	go r.executeAndWait(ctx, qr, runLogger)
+	r.execute(rCtx, qr, runLogger)
 
 	r.updateRunState(qr, RunStarted, runLogger)
 }
 
+// traceRun starts the span that traces qr until it finishes, carrying it in rc's context, and sets qr's trace ID.
+// A restarted run is traced anew, since the span of its earlier execution is finished by that execution.
+func (r *runner) traceRun(rc *runCtx, qr *QueuedRun) {
+	rc.span = opentracing.StartSpan("task.run",
+		opentracing.Tag{Key: "task_id", Value: qr.TaskID.String()},
+		opentracing.Tag{Key: "run_id", Value: qr.RunID.String()},
+		opentracing.Tag{Key: "scheduled_for", Value: qr.Now},
+	)
+	rc.Context = opentracing.ContextWithSpan(rc.Context, rc.span)
+	qr.TraceID = TraceID(rc.span)
+}
+
 func (r *runner) clearRunning(id platform.ID) {
 	r.ts.runningMu.Lock()
 	r.ts.running[id].CancelFunc() // cleanup
@@ -627,21 +1434,83 @@ func (r *runner) clearRunning(id platform.ID) {
 	r.ts.runningMu.Unlock()
 }
 
-func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) {
+// execute executes the run on the scheduler's run pool, if it has one, or on its own goroutine.
+// A run that cannot be submitted to the pool, because the pool is closed or the task was released,
+// still executes on its own goroutine, so that it is finished rather than left started in the store.
+func (r *runner) execute(rc runCtx, qr QueuedRun, runLogger *zap.Logger) {
+	run := func() { r.executeAndWait(rc, qr, runLogger) }
+	if r.ts.runPool != nil {
+		err := r.ts.runPool.Submit(r.ctx, run)
+		if err == nil {
+			return
+		}
+		runLogger.Debug("Failed to submit run to the run pool; executing it on its own goroutine", zap.Error(err))
+	}
+	go run()
+}
+
+// startNext moves on to the task's next run, once the run executing on r has finished.
+// With a run pool, it does so on its own goroutine, so that the worker that executed the run is free:
+// submitting the next run from the worker could wait on a full pool for a worker that never frees up.
+func (r *runner) startNext() {
+	if r.ts.runPool == nil {
+		r.startFromWorking(atomic.LoadInt64(r.ts.now))
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.startFromWorking(atomic.LoadInt64(r.ts.now))
+	}()
+}
+
+// runDurationQuota returns the longest the task's runs may execute by its organization's quota, or zero if there is no limit.
+// A failed lookup is logged, and leaves the run unlimited.
+func (ts *taskScheduler) runDurationQuota(ctx context.Context) time.Duration {
+	if ts.quotas == nil {
+		return 0
+	}
+	q, err := ts.quotas.TaskQuota(ctx, ts.task.Org)
+	if err != nil {
+		ts.logger.Info("Failed to look up task quota", zap.Error(err))
+		return 0
+	}
+	return q.MaxRunDuration
+}
+
+func (r *runner) executeAndWait(rc runCtx, qr QueuedRun, runLogger *zap.Logger) {
 	defer r.wg.Done()
+	defer close(rc.done)
+	defer rc.span.Finish()
+	ctx := rc.Context
 
 	sp, spCtx := opentracing.StartSpanFromContext(ctx, "task.run.execution")
 	defer sp.Finish()
 
+	startedAt := r.ts.clock.Now()
+	r.ts.sla.start(r.task, qr, r.ts.opts.SLA)
 	rp, err := r.executor.Execute(spCtx, qr)
 
 	if err != nil {
+		r.ts.overlap.finish(qr.RunID, atomic.LoadInt64(r.ts.now))
 		// TODO(mr): retry? and log error.
 		atomic.StoreUint32(r.state, runnerIdle)
 		r.updateRunState(qr, RunFail, runLogger)
+		r.recordOutcome(qr, RunFail, startedAt, err, runLogger)
 		return
 	}
 
+	// Cancel the run as timed out once it has executed for as long as its organization's quota allows.
+	var exceeded uint32 // Set to 1 once the run is canceled for exceeding the quota. Must be accessed atomically.
+	var quotaC <-chan time.Time
+	durationQuota := r.ts.runDurationQuota(ctx)
+	if durationQuota > 0 {
+		t := r.ts.clock.NewTimer(durationQuota)
+		defer t.Stop()
+		quotaC = t.C()
+	}
+
 	ready := make(chan struct{})
 	go func() {
 		// If the runner's context is canceled, cancel the RunPromise.
@@ -653,6 +1522,11 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 		case <-r.ctx.Done():
 			r.clearRunning(qr.RunID)
 			rp.Cancel()
+		// Executed for longer than the quota allows.
+		case <-quotaC:
+			atomic.StoreUint32(&exceeded, 1)
+			r.clearRunning(qr.RunID)
+			rp.Cancel()
 		// Wait finished.
 		case <-ready:
 			r.clearRunning(qr.RunID)
@@ -660,61 +1534,214 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 	}()
 
 	// TODO(mr): handle res.IsRetryable().
-	_, err = rp.Wait()
+	res, err := rp.Wait()
 	close(ready)
+	r.ts.overlap.finish(qr.RunID, atomic.LoadInt64(r.ts.now))
 	if err != nil {
+		if atomic.LoadUint32(&exceeded) == 1 {
+			runLogger.Info("Execution exceeded the organization's run duration quota", zap.Duration("quota", durationQuota))
+			r.completeOrRecordRun(qr, RunTimedOut, startedAt, RunDurationQuotaError{Org: r.task.Org, Limit: durationQuota}, runLogger)
+
+			// Move on to the next execution, for a timed out run.
+			r.startNext()
+			return
+		}
+
 		if err == ErrRunCanceled {
-			_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
-			r.updateRunState(qr, RunCanceled, runLogger)
+			r.completeOrRecordRun(qr, RunCanceled, startedAt, err, runLogger)
 
 			// Move on to the next execution, for a canceled run.
-			r.startFromWorking(atomic.LoadInt64(r.ts.now))
+			r.startNext()
+			return
+		}
+
+		if err == ErrRunTimedOut {
+			runLogger.Info("Execution exceeded task timeout")
+			r.completeOrRecordRun(qr, RunTimedOut, startedAt, err, runLogger)
+
+			// Move on to the next execution, for a timed out run.
+			r.startNext()
 			return
 		}
 
 		runLogger.Info("Failed to wait for execution result", zap.Error(err))
 		// TODO(mr): retry?
 		r.updateRunState(qr, RunFail, runLogger)
+		r.recordOutcome(qr, RunFail, startedAt, err, runLogger)
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
 	}
 
-	if err := r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
+	status, resErr := RunSuccess, error(nil)
+	if res != nil && res.Err() != nil {
+		status, resErr = RunFail, res.Err()
+	}
+
+	if err := r.completeRun(qr, status, startedAt, res, resErr, runLogger); err != nil {
 		runLogger.Info("Failed to finish run", zap.Error(err))
 		// TODO(mr): retry?
 		// Need to think about what it means if there was an error finishing a run.
 		atomic.StoreUint32(r.state, runnerIdle)
 		r.updateRunState(qr, RunFail, runLogger)
+		r.recordOutcome(qr, RunFail, startedAt, err, runLogger)
+		return
+	}
+
+	if resErr != nil {
+		runLogger.Info("Execution failed", zap.Error(resErr))
+
+		// Move on to the next execution, for a failed run.
+		r.startNext()
 		return
 	}
-	r.updateRunState(qr, RunSuccess, runLogger)
+
 	runLogger.Info("Execution succeeded")
 
 	// Check again if there is a new run available, without returning to idle state.
-	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+	r.startNext()
 }
 
-func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
-	rlb := RunLogBase{
+// completeRun finishes the run in the desired state, and records that it began executing at startedAt and ended in status s
+// with the result res, if it has one, because of err, in a single transaction, so that a restart neither executes the run's window again nor loses its outcome.
+// It then updates the run's state and adds the error, if any, and the run's final status to the run's log.
+// If the run cannot be finished, nothing is recorded and the error is returned.
+func (r *runner) completeRun(qr QueuedRun, s RunStatus, startedAt time.Time, res RunResult, err error, runLogger *zap.Logger) error {
+	o := r.newRunOutcome(qr, s, startedAt, res, err)
+	if err := r.desiredState.CompleteRun(r.ctx, qr.TaskID, o); err != nil {
+		return err
+	}
+
+	r.updateRunState(qr, s, runLogger)
+	r.reportOutcome(qr, o, err, runLogger)
+	return nil
+}
+
+// completeOrRecordRun completes the run as by completeRun, or if the run cannot be finished,
+// records its outcome without finishing it.
+func (r *runner) completeOrRecordRun(qr QueuedRun, s RunStatus, startedAt time.Time, err error, runLogger *zap.Logger) {
+	if finishErr := r.completeRun(qr, s, startedAt, nil, err, runLogger); finishErr != nil {
+		runLogger.Info("Failed to finish run", zap.Error(finishErr))
+		r.updateRunState(qr, s, runLogger)
+		r.recordOutcome(qr, s, startedAt, err, runLogger)
+	}
+}
+
+// recordOutcome records in the desired state that the run, which began executing at startedAt, ended in status s because of err.
+// It also adds the error, if any, and the run's final status to the run's log.
+func (r *runner) recordOutcome(qr QueuedRun, s RunStatus, startedAt time.Time, err error, runLogger *zap.Logger) {
+	o := r.newRunOutcome(qr, s, startedAt, nil, err)
+	if err := r.desiredState.RecordRunOutcome(r.ctx, qr.TaskID, o); err != nil {
+		runLogger.Info("Failed to record run outcome", zap.Error(err))
+	}
+	r.reportOutcome(qr, o, err, runLogger)
+}
+
+// newRunOutcome returns the outcome of the run qr, which began executing at startedAt and ended in status s
+// with the result res, or nil if it has none, because of err.
+func (r *runner) newRunOutcome(qr QueuedRun, s RunStatus, startedAt time.Time, res RunResult, err error) RunOutcome {
+	o := RunOutcome{
+		RunID:        qr.RunID,
+		ScheduledFor: qr.Now,
+		Status:       s,
+		StartedAt:    startedAt,
+		FinishedAt:   r.ts.clock.Now(),
+	}
+	if err != nil {
+		o.Error, o.ErrorCode = err.Error(), platform.ErrorCode(err)
+	}
+	if rc, ok := res.(RowCounter); ok {
+		o.RowsWritten = rc.RowsWritten()
+	}
+	if mr, ok := res.(MemoryReporter); ok {
+		o.MaxMemoryBytes = mr.MaxMemoryBytes()
+	}
+	return o
+}
+
+// reportOutcome adds err, the error that ended the run qr, if any, and the run's final status from its recorded outcome o,
+// to the run's log, and notifies the scheduler's observers of o.
+func (r *runner) reportOutcome(qr QueuedRun, o RunOutcome, err error, runLogger *zap.Logger) {
+	rlb := r.runLogBase(qr)
+	if err != nil {
+		r.addRunLogEvent(rlb, RunLogEvent{Type: RunLogError, Time: o.FinishedAt, Message: err.Error(), Code: platform.ErrorCode(err)}, runLogger)
+	}
+	r.addRunLogEvent(rlb, RunLogEvent{
+		Type:       RunLogFinished,
+		Time:       o.FinishedAt,
+		Message:    finishedMessage(o.Status),
+		Status:     o.Status.String(),
+		DurationMS: int64(o.Duration() / time.Millisecond),
+	}, runLogger)
+
+	r.ts.sla.finish(r.ctx, qr, o.FinishedAt.Unix())
+
+	r.ts.observeRun(r.ctx, r.task, qr, o)
+}
+
+// finishedMessage returns the message of the finished event in the log of a run that ended in status s.
+func finishedMessage(s RunStatus) string {
+	switch s {
+	case RunSuccess:
+		return "Completed successfully"
+	case RunCanceled:
+		return "Canceled"
+	case RunTimedOut:
+		return "Timed out"
+	default:
+		return "Failed"
+	}
+}
+
+func (r *runner) runLogBase(qr QueuedRun) RunLogBase {
+	return RunLogBase{
 		Task:            r.task,
 		RunID:           qr.RunID,
 		RunScheduledFor: qr.Now,
 		RequestedAt:     qr.RequestedAt,
+		RetryOf:         qr.RetryOf,
+		TraceID:         qr.TraceID,
+	}
+}
+
+// addRunLogEvent adds e to the log of the run identified by rlb. A failure to write the event is only logged.
+func (r *runner) addRunLogEvent(rlb RunLogBase, e RunLogEvent, runLogger *zap.Logger) {
+	if err := AddRunLogEvent(r.ctx, r.logWriter, rlb, e); err != nil {
+		runLogger.Info("Failed to add run log event", zap.String("event", string(e.Type)), zap.Error(err))
+	}
+}
+
+// updateSkippedRunState records that qr finished in the state s, RunSkipped or RunDeduplicated, without executing,
+// and adds an event to the run's log with message to explain why.
+func (r *runner) updateSkippedRunState(qr QueuedRun, s RunStatus, message string, runLogger *zap.Logger) {
+	rlb := r.runLogBase(qr)
+	now := r.ts.clock.Now()
+
+	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := r.logWriter.UpdateRunState(ctx, rlb, now, s); err != nil {
+		runLogger.Info("Error updating run state", zap.Stringer("state", s), zap.Error(err))
 	}
+	r.addRunLogEvent(rlb, RunLogEvent{Type: RunLogSkipped, Time: now, Message: message, Status: s.String()}, runLogger)
+}
+
+// updateRunState records the run's state s through the log writer, and adds an event for it to the run's log.
+// The events of finished runs are added by recordOutcome.
+func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
+	rlb := r.runLogBase(qr)
 
+	now := r.ts.clock.Now()
+	var events []RunLogEvent
 	switch s {
 	case RunStarted:
 		r.ts.metrics.StartRun(r.task.ID.String())
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), fmt.Sprintf("Started task from script: %q", r.task.Script))
+		events = []RunLogEvent{
+			{Type: RunLogScheduled, Time: now, Message: "Scheduled", ScheduledFor: qr.Now, RequestedAt: qr.RequestedAt},
+			{Type: RunLogStarted, Time: now, Message: fmt.Sprintf("Started task from script: %q", r.task.Script), RetryOf: qr.RetryOf, TraceID: qr.TraceID},
+		}
 	case RunSuccess:
 		r.ts.metrics.FinishRun(r.task.ID.String(), true)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Completed successfully")
-	case RunFail:
-		r.ts.metrics.FinishRun(r.task.ID.String(), false)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Failed")
-	case RunCanceled:
+	case RunFail, RunCanceled, RunTimedOut:
 		r.ts.metrics.FinishRun(r.task.ID.String(), false)
-		r.logWriter.AddRunLog(r.ctx, rlb, time.Now(), "Canceled")
 	default: // We are deliberately not handling RunQueued yet.
 		// There is not really a notion of being queued in this runner architecture.
 		runLogger.Warn("Unhandled run state", zap.Stringer("state", s))
@@ -724,7 +1751,12 @@ func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger
 	// If we start seeing errors from this, we know the time limit is too short or the system is overloaded.
 	ctx, cancel := context.WithTimeout(r.ctx, 10*time.Millisecond)
 	defer cancel()
-	if err := r.logWriter.UpdateRunState(ctx, rlb, time.Now(), s); err != nil {
+	if err := r.logWriter.UpdateRunState(ctx, rlb, now, s); err != nil {
 		runLogger.Info("Error updating run state", zap.Stringer("state", s), zap.Error(err))
 	}
+
+	// Add the events after recording the state, so that a log writer that keeps logs with their runs has the run to add them to.
+	for _, e := range events {
+		r.addRunLogEvent(rlb, e, runLogger)
+	}
 }
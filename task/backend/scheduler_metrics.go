@@ -12,6 +12,12 @@ type schedulerMetrics struct {
 	runsComplete *prometheus.CounterVec
 	runsActive   *prometheus.GaugeVec
 
+	runsThrottled *prometheus.CounterVec
+	runsDelayed   prometheus.Counter
+
+	runsOverdue prometheus.Gauge
+	slaMisses   *prometheus.CounterVec
+
 	claimsComplete *prometheus.CounterVec
 	claimsActive   prometheus.Gauge
 }
@@ -47,6 +53,32 @@ func newSchedulerMetrics() *schedulerMetrics {
 			Help:      "Total number of runs that have started but not yet completed, split out by task ID.",
 		}, []string{"task_id"}),
 
+		runsThrottled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_throttled",
+			Help:      "Number of times a due run was held back by the organization's run rate limit, split out by org ID.",
+		}, []string{"org_id"}),
+		runsDelayed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_delayed",
+			Help:      "Number of times a due run was held back because the executor was saturated.",
+		}),
+
+		runsOverdue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "runs_overdue",
+			Help:      "Number of runs still executing past the deadline set by their task's sla option.",
+		}),
+		slaMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "sla_misses",
+			Help:      "Number of runs that did not finish by the deadline set by their task's sla option, split out by task ID.",
+		}, []string{"task_id"}),
+
 		claimsComplete: prometheus.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: subsystem,
@@ -69,6 +101,10 @@ func (sm *schedulerMetrics) PrometheusCollectors() []prometheus.Collector {
 		sm.totalRunsActive,
 		sm.runsComplete,
 		sm.runsActive,
+		sm.runsThrottled,
+		sm.runsDelayed,
+		sm.runsOverdue,
+		sm.slaMisses,
 		sm.claimsComplete,
 		sm.claimsActive,
 	}
@@ -91,6 +127,32 @@ func (sm *schedulerMetrics) FinishRun(tid string, succeeded bool) {
 	sm.runsComplete.WithLabelValues(tid, status).Inc()
 }
 
+// ThrottleRun adjusts the metrics to indicate a run for the given org ID was held back by the run rate limit.
+func (sm *schedulerMetrics) ThrottleRun(orgID string) {
+	sm.runsThrottled.WithLabelValues(orgID).Inc()
+}
+
+// DelayRun adjusts the metrics to indicate a due run was held back because the executor was saturated.
+func (sm *schedulerMetrics) DelayRun() {
+	sm.runsDelayed.Inc()
+}
+
+// StartOverdueRun adjusts the metrics to indicate a run for the given task ID missed its SLA and is still executing.
+func (sm *schedulerMetrics) StartOverdueRun(tid string) {
+	sm.runsOverdue.Inc()
+	sm.slaMisses.WithLabelValues(tid).Inc()
+}
+
+// FinishOverdueRun adjusts the metrics to indicate a run that missed its SLA is no longer executing.
+func (sm *schedulerMetrics) FinishOverdueRun() {
+	sm.runsOverdue.Dec()
+}
+
+// MissSLA adjusts the metrics to indicate a run for the given task ID finished after the deadline set by its SLA.
+func (sm *schedulerMetrics) MissSLA(tid string) {
+	sm.slaMisses.WithLabelValues(tid).Inc()
+}
+
 // ClaimTask adjusts the metrics to indicate the result of an attempted claim.
 func (sm *schedulerMetrics) ClaimTask(succeeded bool) {
 	status := statusString(succeeded)
@@ -108,6 +170,7 @@ func (sm *schedulerMetrics) ReleaseTask(tid string) {
 	sm.runsActive.DeleteLabelValues(tid)
 	sm.runsComplete.DeleteLabelValues(tid, statusString(true))
 	sm.runsComplete.DeleteLabelValues(tid, statusString(false))
+	sm.slaMisses.DeleteLabelValues(tid)
 }
 
 func statusString(succeeded bool) string {
@@ -0,0 +1,151 @@
+package backend
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// SchedulerState is a snapshot of a scheduler's internal state,
+// for operators to tell why a task has or has not run.
+type SchedulerState struct {
+	// Now is the Unix timestamp of the scheduler's latest tick.
+	Now int64
+
+	// Draining reports whether the scheduler is draining, and so starts no new runs.
+	Draining bool
+
+	// Tasks are the tasks claimed in the scheduler, ordered by ID.
+	Tasks []TaskState
+}
+
+// TaskState is a snapshot of the state of a task claimed in a scheduler.
+type TaskState struct {
+	TaskID platform.ID
+	Org    platform.ID
+
+	// NextDue is the Unix timestamp the task's next scheduled run is due, delayed by its jitter,
+	// or NeverDue if the task has no more scheduled runs.
+	NextDue int64
+
+	// QueuedRuns is the number of manual runs queued for the task, each of which may cover several scheduled times.
+	QueuedRuns int
+
+	// MaxConcurrency is the most runs of the task that may execute at once.
+	MaxConcurrency int
+
+	// Working is the number of the task's runners that are busy, either executing a run or deciding whether to start one.
+	// Once every working runner's run is executing, Working is the number of the task's runs in flight.
+	Working int
+
+	// Stopped reports whether the task's runs were canceled, so that it starts no new runs.
+	Stopped bool
+
+	// Running are the runs of the task being executed, earliest scheduled first.
+	Running []RunningRun
+}
+
+// RunningRun is a run being executed by a scheduler.
+type RunningRun struct {
+	QueuedRun
+
+	// StartedAt is when the run's execution started.
+	StartedAt time.Time
+}
+
+// SchedulerInspector is implemented by Schedulers that can report their internal state, such as *TickScheduler.
+type SchedulerInspector interface {
+	// State returns a snapshot of the scheduler's internal state.
+	State() SchedulerState
+}
+
+var (
+	_ SchedulerInspector = (*TickScheduler)(nil)
+	_ SchedulerInspector = (*ShardedScheduler)(nil)
+)
+
+// State returns a snapshot of s's internal state.
+// The snapshot is not taken atomically across tasks, so a task's runs may move on while it is taken.
+func (s *TickScheduler) State() SchedulerState {
+	s.schedulerMu.Lock()
+	tss := make([]*taskScheduler, 0, len(s.taskSchedulers))
+	for _, ts := range s.taskSchedulers {
+		tss = append(tss, ts)
+	}
+	s.schedulerMu.Unlock()
+
+	state := SchedulerState{
+		Now:      atomic.LoadInt64(&s.now),
+		Draining: s.isDraining(),
+		Tasks:    make([]TaskState, 0, len(tss)),
+	}
+	for _, ts := range tss {
+		state.Tasks = append(state.Tasks, ts.state())
+	}
+	sortTaskStates(state.Tasks)
+	return state
+}
+
+// state returns a snapshot of ts's state.
+func (ts *taskScheduler) state() TaskState {
+	next, _ := ts.NextDue()
+	ts.nextDueMu.RLock()
+	queued := ts.queuedRuns
+	ts.nextDueMu.RUnlock()
+
+	state := TaskState{
+		TaskID:         ts.task.ID,
+		Org:            ts.task.Org,
+		NextDue:        next,
+		QueuedRuns:     queued,
+		MaxConcurrency: len(ts.runners),
+		Stopped:        atomic.LoadUint32(&ts.stopped) == 1,
+	}
+	for _, r := range ts.runners {
+		if atomic.LoadUint32(r.state) == runnerWorking {
+			state.Working++
+		}
+	}
+
+	ts.runningMu.Lock()
+	for _, rc := range ts.running {
+		state.Running = append(state.Running, RunningRun{QueuedRun: rc.run, StartedAt: rc.startedAt})
+	}
+	ts.runningMu.Unlock()
+
+	sort.Slice(state.Running, func(i, j int) bool {
+		if state.Running[i].Now != state.Running[j].Now {
+			return state.Running[i].Now < state.Running[j].Now
+		}
+		return state.Running[i].RunID < state.Running[j].RunID
+	})
+	return state
+}
+
+// State returns a snapshot of the internal state of every shard that implements SchedulerInspector.
+// Now is the latest tick across the shards, and Draining reports whether any shard is draining.
+func (s *ShardedScheduler) State() SchedulerState {
+	var state SchedulerState
+	for _, shard := range s.shards {
+		i, ok := shard.(SchedulerInspector)
+		if !ok {
+			continue
+		}
+
+		ss := i.State()
+		if ss.Now > state.Now {
+			state.Now = ss.Now
+		}
+		state.Draining = state.Draining || ss.Draining
+		state.Tasks = append(state.Tasks, ss.Tasks...)
+	}
+	sortTaskStates(state.Tasks)
+	return state
+}
+
+// sortTaskStates sorts tasks by task ID.
+func sortTaskStates(tasks []TaskState) {
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].TaskID < tasks[j].TaskID })
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/kit/pool"
 	"github.com/influxdata/platform/kit/prom"
 	"github.com/influxdata/platform/kit/prom/promtest"
 	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/task/backend"
 	"github.com/influxdata/platform/task/mock"
+	pzap "github.com/influxdata/platform/zap"
+	"github.com/opentracing/opentracing-go"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -302,6 +309,59 @@ func TestScheduler_UpdateTask(t *testing.T) {
 	p[0].Finish(mock.NewRunResult(nil, false), nil)
 }
 
+func TestScheduler_CancelTaskRuns(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1)}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		EffectiveCron:   "@every 1m",
+		LatestCompleted: 0,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(120)
+	if _, err := e.PollForNumberRunning(task.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.CancelTaskRuns(context.Background(), task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both runs are marked canceled by the time CancelTaskRuns returns.
+	outcomes := d.OutcomesFor(task.ID)
+	if len(outcomes) != 2 {
+		t.Fatalf("expected 2 recorded outcomes, got %d", len(outcomes))
+	}
+	for _, o := range outcomes {
+		if o.Status != backend.RunCanceled {
+			t.Fatalf("expected canceled run, got %v", o.Status)
+		}
+	}
+	if created := d.CreatedFor(task.ID); len(created) != 0 {
+		t.Fatalf("expected canceled runs to be finished, got %d unfinished", len(created))
+	}
+
+	// No new runs start for the task.
+	s.Tick(240)
+	time.Sleep(10 * time.Millisecond)
+	if created := d.CreatedFor(task.ID); len(created) != 0 {
+		t.Fatalf("expected no new runs, got %d", len(created))
+	}
+
+	if err := s.CancelTaskRuns(context.Background(), platform.ID(2)); err != backend.ErrTaskNotClaimed {
+		t.Fatalf("expected ErrTaskNotClaimed for unclaimed task, got %v", err)
+	}
+}
+
 func TestScheduler_Queue(t *testing.T) {
 	d := mock.NewDesiredState()
 	e := mock.NewExecutor()
@@ -491,6 +551,30 @@ func TestScheduler_RunLog(t *testing.T) {
 
 	pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunFail.String())
 
+	// The log of the failed run records why it failed.
+	runs, err = rl.ListRuns(context.Background(), platform.RunFilter{Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := backend.ParseRunLog(runs[1].Log)
+	var types []backend.RunLogEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	expTypes := []backend.RunLogEventType{backend.RunLogScheduled, backend.RunLogStarted, backend.RunLogError, backend.RunLogFinished}
+	if !reflect.DeepEqual(types, expTypes) {
+		t.Fatalf("expected log events %v, got %v", expTypes, types)
+	}
+	if ev := events[0]; ev.ScheduledFor != 7 {
+		t.Fatalf("expected run scheduled for 7, got %d", ev.ScheduledFor)
+	}
+	if ev := events[2]; ev.Code != platform.EInternal || ev.Message != "forced failure" {
+		t.Fatalf("unexpected error event %#v", ev)
+	}
+	if ev := events[3]; ev.Status != backend.RunFail.String() {
+		t.Fatalf("expected finished event with status %s, got %#v", backend.RunFail, ev)
+	}
+
 	// One more run, but cancel this time.
 	s.Tick(8)
 	promises, err = e.PollForNumberRunning(task.ID, 1)
@@ -509,6 +593,286 @@ func TestScheduler_RunLog(t *testing.T) {
 	pollForRunStatus(t, rl, task.ID, 3, 2, backend.RunCanceled.String())
 }
 
+func TestScheduler_RunTrace(t *testing.T) {
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(&pzap.Tracer{Logger: zaptest.NewLogger(t), IDGenerator: snowflake.NewIDGenerator()})
+	defer opentracing.SetGlobalTracer(prev)
+
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1)}
+	meta := &backend.StoreTaskMeta{MaxConcurrency: 1, EffectiveCron: "@every 1s", LatestCompleted: 5}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traceID := promises[0].Run().TraceID
+	if traceID == "" {
+		t.Fatal("expected run to be executed with a trace ID")
+	}
+
+	// The trace ID is recorded in the run and in its started event.
+	runs, err := rl.ListRuns(context.Background(), platform.RunFilter{Task: &task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs[0].TraceID != traceID {
+		t.Fatalf("expected run to record trace ID %q, got %q", traceID, runs[0].TraceID)
+	}
+	var found bool
+	for _, ev := range backend.ParseRunLog(runs[0].Log) {
+		if ev.Type == backend.RunLogStarted {
+			found = ev.TraceID == traceID
+		}
+	}
+	if !found {
+		t.Fatalf("expected started event with trace ID %q in log %q", traceID, runs[0].Log)
+	}
+
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunSuccess.String())
+}
+
+// slaRecorder is a backend.SLAObserver that records the IDs of the runs that missed their SLA.
+type slaRecorder struct {
+	mu     sync.Mutex
+	missed []platform.ID
+}
+
+func (r *slaRecorder) ObserveRun(context.Context, *backend.StoreTask, backend.QueuedRun, backend.RunOutcome) {
+}
+
+func (r *slaRecorder) ObserveSLAMiss(_ context.Context, _ *backend.StoreTask, qr backend.QueuedRun, _ int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.missed = append(r.missed, qr.RunID)
+}
+
+func (r *slaRecorder) Missed() []platform.ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]platform.ID(nil), r.missed...)
+}
+
+func TestScheduler_SLA(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	rec := &slaRecorder{}
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRunObserver(rec))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	task := &backend.StoreTask{ID: platform.ID(1), Script: `option task = {name: "x", every: 1s, sla: 2s}
+from(bucket: "b") |> range(start: -1s)`}
+	meta := &backend.StoreTaskMeta{MaxConcurrency: 1, EffectiveCron: "@every 1s", LatestCompleted: 5}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run scheduled for 6 is due to finish by 8.
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+
+	s.Tick(8)
+	if runs := s.OverdueRuns(); len(runs) != 0 {
+		t.Fatalf("expected no overdue runs at the deadline, got %#v", runs)
+	}
+
+	s.Tick(9)
+	exp := []backend.OverdueRun{{TaskID: task.ID, RunID: runID, ScheduledFor: 6, Deadline: 8}}
+	if runs := s.OverdueRuns(); !reflect.DeepEqual(runs, exp) {
+		t.Fatalf("expected overdue runs %#v, got %#v", exp, runs)
+	}
+	// The miss is only reported once.
+	s.Tick(10)
+	if missed := rec.Missed(); !reflect.DeepEqual(missed, []platform.ID{runID}) {
+		t.Fatalf("expected miss of run %s to be observed once, got %v", runID, missed)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_overdue", nil)
+	if got := *m.Gauge.Value; got != 1 {
+		t.Fatalf("expected 1 overdue run, got %v", got)
+	}
+	m = promtest.MustFindMetric(t, mfs, "task_scheduler_sla_misses", map[string]string{"task_id": task.ID.String()})
+	if got := *m.Counter.Value; got != 1 {
+		t.Fatalf("expected 1 SLA miss, got %v", got)
+	}
+
+	// Once the run finishes, it is no longer overdue.
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	for i := 0; len(s.OverdueRuns()) != 0; i++ {
+		if i == 50 {
+			t.Fatalf("expected no overdue runs after the run finished, got %#v", s.OverdueRuns())
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	mfs = promtest.MustGather(t, reg)
+	m = promtest.MustFindMetric(t, mfs, "task_scheduler_runs_overdue", nil)
+	if got := *m.Gauge.Value; got != 0 {
+		t.Fatalf("expected no overdue runs, got %v", got)
+	}
+}
+
+func TestScheduler_State(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1), Org: platform.ID(2)}
+	meta := &backend.StoreTaskMeta{MaxConcurrency: 2, EffectiveCron: "@every 1s", LatestCompleted: 5}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := backend.SchedulerState{
+		Now:   5,
+		Tasks: []backend.TaskState{{TaskID: task.ID, Org: task.Org, NextDue: 6, MaxConcurrency: 2}},
+	}
+	if state := s.State(); !reflect.DeepEqual(state, exp) {
+		t.Fatalf("expected state %#v, got %#v", exp, state)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := s.State()
+	if state.Now != 6 || len(state.Tasks) != 1 {
+		t.Fatalf("expected one task at 6, got %#v", state)
+	}
+	ts := state.Tasks[0]
+	if ts.NextDue != 7 {
+		t.Fatalf("expected next due 7, got %d", ts.NextDue)
+	}
+	if len(ts.Running) != 1 || ts.Running[0].RunID != promises[0].Run().RunID || ts.Running[0].Now != 6 || ts.Running[0].StartedAt.IsZero() {
+		t.Fatalf("expected run for 6 to be running, got %#v", ts.Running)
+	}
+
+	// Once the run finishes, it is no longer reported as running.
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	for i := 0; len(s.State().Tasks[0].Running) != 0; i++ {
+		if i == 50 {
+			t.Fatalf("expected no running runs after the run finished, got %#v", s.State().Tasks[0].Running)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	if err := s.ReleaseTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if state := s.State(); len(state.Tasks) != 0 {
+		t.Fatalf("expected no tasks after release, got %#v", state.Tasks)
+	}
+}
+
+func TestScheduler_RunOutcomes(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	pollForOutcomes := func(t *testing.T, n int) []backend.RunOutcome {
+		t.Helper()
+		var outcomes []backend.RunOutcome
+		for i := 0; i < 50; i++ {
+			outcomes = d.OutcomesFor(task.ID)
+			if len(outcomes) == n {
+				return outcomes
+			}
+			time.Sleep(2 * time.Millisecond)
+		}
+		t.Fatalf("expected %d run outcomes, got %d", n, len(outcomes))
+		return nil
+	}
+
+	// Succeed.
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	pollForOutcomes(t, 1)
+
+	// Fail with an error in the result.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(errors.New("query failed"), false), nil)
+	pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunFail.String())
+	pollForOutcomes(t, 2)
+
+	// Cancel.
+	s.Tick(8)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Cancel()
+	outcomes := pollForOutcomes(t, 3)
+
+	for i, exp := range []struct {
+		status backend.RunStatus
+		err    string
+	}{
+		{status: backend.RunSuccess},
+		{status: backend.RunFail, err: "query failed"},
+		{status: backend.RunCanceled, err: backend.ErrRunCanceled.Error()},
+	} {
+		o := outcomes[i]
+		if o.Status != exp.status || o.Error != exp.err {
+			t.Fatalf("expected outcome %d to be %s with error %q, got %s with error %q", i, exp.status, exp.err, o.Status, o.Error)
+		}
+		if o.FinishedAt.Before(o.StartedAt) {
+			t.Fatalf("expected outcome %d to finish after it started, got %#v", i, o)
+		}
+	}
+}
+
 func TestScheduler_Metrics(t *testing.T) {
 	d := mock.NewDesiredState()
 	e := mock.NewExecutor()
@@ -715,3 +1079,810 @@ func TestScheduler_WithTicker(t *testing.T) {
 		t.Fatalf("expected 1 run queued, but got %d", len(x))
 	}
 }
+
+func TestScheduler_WithClock(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := clock.NewMock(time.Unix(1000, 0))
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 1000, backend.WithLogger(zaptest.NewLogger(t)),
+		backend.WithClock(clock), backend.WithTicker(ctx, 100*time.Millisecond))
+
+	o.Start(ctx)
+	defer o.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  5,
+		EffectiveCron:   "@every 1m",
+		LatestCompleted: 1000,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := o.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// Until the clock reaches the next run, nothing is created, no matter how much real time passes.
+	clock.Advance(59 * time.Second)
+	time.Sleep(50 * time.Millisecond)
+	if n := len(d.CreatedFor(task.ID)); n != 0 {
+		t.Fatalf("expected no runs created before the clock reached the next run, got %d", n)
+	}
+
+	clock.Advance(time.Second)
+	if x, err := d.PollForNumberCreated(task.ID, 1); err != nil {
+		t.Fatalf("expected 1 run queued, but got %d", len(x))
+	}
+}
+
+func TestScheduler_Drain(t *testing.T) {
+	t.Parallel()
+
+	newScheduler := func(t *testing.T) (*backend.TickScheduler, *mock.DesiredState, *mock.Executor, *backend.StoreTask) {
+		d := mock.NewDesiredState()
+		e := mock.NewExecutor()
+		o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+		o.Start(context.Background())
+
+		task := &backend.StoreTask{
+			ID: platform.ID(1),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: 4,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := o.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+		return o, d, e, task
+	}
+
+	t.Run("in-flight runs finish", func(t *testing.T) {
+		o, d, e, task := newScheduler(t)
+
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		drained := make(chan error, 1)
+		go func() {
+			drained <- o.Drain(ctx)
+		}()
+
+		select {
+		case err := <-drained:
+			t.Fatalf("drain returned before in-flight run finished: %v", err)
+		case <-time.After(20 * time.Millisecond):
+			// Okay.
+		}
+
+		// Ticking while draining must not create new runs.
+		o.Tick(10)
+		promises[0].Finish(mock.NewRunResult(nil, false), nil)
+
+		if err := <-drained; err != nil {
+			t.Fatal(err)
+		}
+		if n := len(d.CreatedFor(task.ID)); n != 0 {
+			t.Fatalf("expected no outstanding runs after drain, got %d", n)
+		}
+
+		meta := &backend.StoreTaskMeta{MaxConcurrency: 1, EffectiveCron: "@every 1s"}
+		if err := o.ClaimTask(&backend.StoreTask{ID: platform.ID(2)}, meta); err != backend.ErrSchedulerDraining {
+			t.Fatalf("expected ErrSchedulerDraining when claiming after drain, got %v", err)
+		}
+	})
+
+	t.Run("deadline cancels runs", func(t *testing.T) {
+		o, d, e, task := newScheduler(t)
+
+		if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := o.Drain(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if _, err := d.PollForNumberCreated(task.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestScheduler_Jitter(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithJitter(time.Minute))
+	o.Start(context.Background())
+	defer o.Stop()
+
+	const numTasks = 20
+	for i := 1; i <= numTasks; i++ {
+		task := &backend.StoreTask{
+			ID: platform.ID(i),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1m",
+			LatestCompleted: 0,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := o.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every task is due at 60, but the starts should be spread over the following minute.
+	o.Tick(60)
+	startedAtDue := 0
+	for i := 1; i <= numTasks; i++ {
+		startedAtDue += len(d.CreatedFor(platform.ID(i)))
+	}
+	if startedAtDue == numTasks {
+		t.Fatalf("expected jitter to delay some of %d tasks, but all started at their due time", numTasks)
+	}
+
+	o.Tick(119)
+	for i := 1; i <= numTasks; i++ {
+		created := d.CreatedFor(platform.ID(i))
+		if len(created) != 1 {
+			t.Fatalf("expected task %d to have started 1 run by the end of the jitter window, got %d", i, len(created))
+		}
+		if created[0].Now != 60 {
+			t.Fatalf("expected jitter to keep the scheduled time of 60, got %d", created[0].Now)
+		}
+	}
+}
+
+func TestScheduler_SubMinute(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5)
+	o.Start(context.Background())
+	defer o.Stop()
+
+	// Odd task IDs run every second, even task IDs every minute.
+	const numTasks = 1000
+	for i := 1; i <= numTasks; i++ {
+		task := &backend.StoreTask{
+			ID: platform.ID(i),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  3,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: 5,
+		}
+		if i%2 == 0 {
+			meta.EffectiveCron = "@every 1m"
+			meta.LatestCompleted = 0
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := o.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	created := func(id int) int { return len(d.CreatedFor(platform.ID(id))) }
+
+	for now := int64(6); now <= 8; now++ {
+		o.Tick(now)
+		for i := 1; i <= numTasks; i += 2 {
+			if got := created(i); got != int(now-5) {
+				t.Fatalf("expected task %d to have created %d runs at %d, got %d", i, now-5, now, got)
+			}
+		}
+	}
+	for i := 2; i <= numTasks; i += 2 {
+		if got := created(i); got != 0 {
+			t.Fatalf("expected task %d to have created no runs before its due time, got %d", i, got)
+		}
+	}
+
+	// Every-second tasks are at their concurrency limit, so only the every-minute tasks start a run.
+	o.Tick(60)
+	for i := 1; i <= numTasks; i++ {
+		exp := 3
+		if i%2 == 0 {
+			exp = 1
+		}
+		if got := created(i); got != exp {
+			t.Fatalf("expected task %d to have created %d runs, got %d", i, exp, got)
+		}
+	}
+}
+
+func TestScheduler_Blackout(t *testing.T) {
+	// Every hour, from the top of the hour until 2 minutes past, is a blackout window.
+	const fmtBlackoutScript = `option task = {
+	name: "blackout",
+	every: 1m,
+	blackout: "0 * * * *",
+	blackoutDuration: 2m,
+	blackoutPolicy: %q,
+}
+
+from(bucket: "b") |> range(start: -1h)`
+
+	t.Run("skip", func(t *testing.T) {
+		d := mock.NewDesiredState()
+		e := mock.NewExecutor()
+		rl := backend.NewInMemRunReaderWriter()
+		s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+		s.Start(context.Background())
+		defer s.Stop()
+
+		task := &backend.StoreTask{
+			ID:     platform.ID(1),
+			Script: fmt.Sprintf(fmtBlackoutScript, "skip"),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1m",
+			LatestCompleted: 0,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		// The run for 60 is in the blackout window, so it is skipped and the run for 120 starts.
+		s.Tick(120)
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := promises[0].Run().Now; got != 120 {
+			t.Fatalf("expected run for 120 to be executing, got run for %d", got)
+		}
+
+		pollForRunStatus(t, rl, task.ID, 2, 0, backend.RunSkipped.String())
+	})
+
+	t.Run("defer", func(t *testing.T) {
+		d := mock.NewDesiredState()
+		e := mock.NewExecutor()
+		s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+		s.Start(context.Background())
+		defer s.Stop()
+
+		task := &backend.StoreTask{
+			ID:     platform.ID(1),
+			Script: fmt.Sprintf(fmtBlackoutScript, "defer"),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1m",
+			LatestCompleted: 0,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		s.Tick(60)
+		s.Tick(119)
+		if got := len(d.CreatedFor(task.ID)); got != 0 {
+			t.Fatalf("expected no runs during blackout window, got %d", got)
+		}
+
+		// Once the window ends, the deferred run starts with its original scheduled time.
+		s.Tick(120)
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := promises[0].Run().Now; got != 60 {
+			t.Fatalf("expected deferred run for 60 to be executing, got run for %d", got)
+		}
+	})
+}
+
+func TestScheduler_Overlap(t *testing.T) {
+	const fmtOverlapScript = `option task = {
+	name: "overlap",
+	every: 1m,
+	overlap: %q,
+}
+
+from(bucket: "b") |> range(start: -1h)`
+
+	// logHas reports whether the log of the given run includes an event with the given message.
+	logHas := func(t *testing.T, rl backend.LogReader, taskID platform.ID, i int, msg string) bool {
+		t.Helper()
+		runs, err := rl.ListRuns(context.Background(), platform.RunFilter{Task: &taskID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ev := range backend.ParseRunLog(runs[i].Log) {
+			if ev.Message == msg {
+				return true
+			}
+		}
+		t.Logf("log of run %d: %q", i, runs[i].Log)
+		return false
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		d := mock.NewDesiredState()
+		e := mock.NewExecutor()
+		rl := backend.NewInMemRunReaderWriter()
+		s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+		s.Start(context.Background())
+		defer s.Stop()
+
+		task := &backend.StoreTask{
+			ID:     platform.ID(1),
+			Script: fmt.Sprintf(fmtOverlapScript, "skip"),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1m",
+			LatestCompleted: 0,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		s.Tick(60)
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prev := promises[0].Run().RunID
+
+		// The run for 120 comes due while the run for 60 is executing, so it is skipped once that run finishes.
+		s.Tick(120)
+		promises[0].Finish(mock.NewRunResult(nil, false), nil)
+		pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunSkipped.String())
+		if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !logHas(t, rl, task.ID, 1, fmt.Sprintf("Skipped: run %s was still executing when this run came due", prev)) {
+			t.Fatal("expected skipped run to log why it was skipped")
+		}
+
+		// The run for 180 does not overlap, so it executes.
+		s.Tick(180)
+		promises, err = e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := promises[0].Run().Now; got != 180 {
+			t.Fatalf("expected run for 180 to be executing, got run for %d", got)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		d := mock.NewDesiredState()
+		e := mock.NewExecutor()
+		rl := backend.NewInMemRunReaderWriter()
+		s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+		s.Start(context.Background())
+		defer s.Stop()
+
+		task := &backend.StoreTask{
+			ID:     platform.ID(1),
+			Script: fmt.Sprintf(fmtOverlapScript, "cancel"),
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1m",
+			LatestCompleted: 0,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		s.Tick(60)
+		if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		// The run for 120 comes due while the run for 60 is executing, so the run for 60 is canceled to make way for it.
+		s.Tick(120)
+		pollForRunStatus(t, rl, task.ID, 2, 0, backend.RunCanceled.String())
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := promises[0].Run().Now; got != 120 {
+			t.Fatalf("expected run for 120 to be executing, got run for %d", got)
+		}
+		if !logHas(t, rl, task.ID, 0, "Canceled: a newer scheduled run came due while this run was executing") {
+			t.Fatal("expected canceled run to log why it was canceled")
+		}
+	})
+}
+
+func TestScheduler_OrgRunLimit(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	// Allow one run start per org, with the budget refilling too slowly to matter during the test.
+	l := backend.NewOrgRunLimiter(1.0/3600, 1)
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithOrgRunLimiter(l))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	org1, org2 := platform.ID(10), platform.ID(20)
+	tasks := []*backend.StoreTask{
+		{ID: platform.ID(1), Org: org1},
+		{ID: platform.ID(2), Org: org1},
+		{ID: platform.ID(3), Org: org2},
+	}
+	for _, task := range tasks {
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: 5,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.Tick(6)
+
+	// Only one of org1's tasks may start a run; org2 has its own budget.
+	if got := len(d.CreatedFor(tasks[0].ID)) + len(d.CreatedFor(tasks[1].ID)); got != 1 {
+		t.Fatalf("expected 1 run created for org1, got %d", got)
+	}
+	if got := len(d.CreatedFor(tasks[2].ID)); got != 1 {
+		t.Fatalf("expected 1 run created for org2, got %d", got)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_throttled", map[string]string{"org_id": org1.String()})
+	if got := *m.Counter.Value; got != 1 {
+		t.Fatalf("expected 1 throttled run for org1, got %v", got)
+	}
+	if m := promtest.FindMetric(mfs, "task_scheduler_runs_throttled", map[string]string{"org_id": org2.String()}); m != nil {
+		t.Fatalf("expected no throttled runs for org2, got %v", *m.Counter.Value)
+	}
+
+	if err := s.CheckRunLimit(org1); err != backend.ErrRunRateLimited {
+		t.Fatalf("expected ErrRunRateLimited for org1, got %v", err)
+	}
+	if err := s.CheckRunLimit(org2); err != backend.ErrRunRateLimited {
+		t.Fatalf("expected ErrRunRateLimited for org2, got %v", err)
+	}
+	if err := s.CheckRunLimit(platform.ID(30)); err != nil {
+		t.Fatalf("expected no error for unused org, got %v", err)
+	}
+}
+
+func TestScheduler_ExecutorBackpressure(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	e.WithSlots(1)
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	// Fill the executor's only slot with a run from elsewhere.
+	busy, err := e.Execute(context.Background(), backend.QueuedRun{TaskID: platform.ID(99), RunID: platform.ID(99)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := &backend.StoreTask{ID: platform.ID(1)}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	if _, err := e.PollForNumberRunning(task.ID, 1); err == nil {
+		t.Fatal("expected no run to start while the executor is saturated")
+	}
+	if got := len(d.CreatedFor(task.ID)); got != 0 {
+		t.Fatalf("expected no run to be created while the executor is saturated, got %d", got)
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_delayed", nil)
+	if got := *m.Counter.Value; got < 1 {
+		t.Fatalf("expected a delayed run, got %v", got)
+	}
+
+	// Once the slot frees up, the due run starts on the next tick.
+	busy.(*mock.RunPromise).Finish(mock.NewRunResult(nil, false), nil)
+	if _, err := e.PollForNumberRunning(platform.ID(99), 0); err != nil {
+		t.Fatal(err)
+	}
+	s.Tick(7)
+	if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScheduler_RunPool(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	p := pool.New("task-runs", 1)
+	defer p.Close()
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRunPool(p))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	ids := []platform.ID{1, 2}
+	for _, id := range ids {
+		task := &backend.StoreTask{ID: id}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: 5,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both tasks create a run, but the pool's one worker executes only one of them at a time.
+	s.Tick(6)
+	running := func() []*mock.RunPromise {
+		var rps []*mock.RunPromise
+		for _, id := range ids {
+			rps = append(rps, e.RunningFor(id)...)
+		}
+		return rps
+	}
+	for i := 0; i < 20 && len(running()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	first := running()
+	if len(first) != 1 {
+		t.Fatalf("expected 1 run executing, got %d", len(first))
+	}
+	for _, id := range ids {
+		if got := len(d.CreatedFor(id)); got != 1 {
+			t.Fatalf("expected task %s to have created a run, got %d", id, got)
+		}
+	}
+
+	// Once the first run finishes, the worker executes the queued one.
+	first[0].Finish(mock.NewRunResult(nil, false), nil)
+	var other platform.ID = 1
+	if first[0].Run().TaskID == 1 {
+		other = 2
+	}
+	rps, err := e.PollForNumberRunning(other, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rps[0].Finish(mock.NewRunResult(nil, false), nil)
+}
+
+func TestScheduler_RunDurationQuota(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	quotas := backend.StaticQuotas{Default: backend.TaskQuota{MaxRunDuration: 20 * time.Millisecond}}
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithQuotas(quotas))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1), Org: platform.ID(2)}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run never finishes on its own, so the scheduler cancels it once it exceeds the quota.
+	s.Tick(6)
+	if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	var outcomes []backend.RunOutcome
+	for i := 0; i < 50 && len(outcomes) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		outcomes = d.OutcomesFor(task.ID)
+	}
+	if len(outcomes) != 1 {
+		t.Fatalf("expected 1 run outcome, got %d", len(outcomes))
+	}
+	if o := outcomes[0]; o.Status != backend.RunTimedOut || o.ErrorCode != platform.EQuotaExceeded {
+		t.Fatalf("expected the run to time out with a quota error, got status %v with code %q", o.Status, o.ErrorCode)
+	}
+}
+
+// createdLoad reports the load of an executor with the given number of slots, each taken by a run created in d
+// for one of ids, so that the load changes as soon as the scheduler creates a run, rather than when the run begins.
+type createdLoad struct {
+	*mock.Executor
+	d     *mock.DesiredState
+	ids   []platform.ID
+	slots int
+}
+
+func (l createdLoad) Load() backend.ExecutorLoad {
+	n := 0
+	for _, id := range l.ids {
+		n += len(l.d.CreatedFor(id))
+	}
+	return backend.ExecutorLoad{Available: l.slots - n}
+}
+
+func TestScheduler_FairAcrossOrgs(t *testing.T) {
+	d := mock.NewDesiredState()
+	const org1, org2 = platform.ID(1), platform.ID(2)
+	tasks := []*backend.StoreTask{
+		{ID: platform.ID(1), Org: org1},
+		{ID: platform.ID(2), Org: org1},
+		{ID: platform.ID(3), Org: org1},
+		{ID: platform.ID(4), Org: org2},
+	}
+	e := createdLoad{Executor: mock.NewExecutor(), d: d, slots: 2}
+	for _, task := range tasks {
+		e.ids = append(e.ids, task.ID)
+	}
+
+	// Claim the tasks before any of them are due.
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 2, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	for _, task := range tasks {
+		// org1's tasks became due before org2's.
+		latest := int64(3)
+		if task.Org == org2 {
+			latest = 5
+		}
+		meta := &backend.StoreTaskMeta{
+			MaxConcurrency:  1,
+			EffectiveCron:   "@every 1s",
+			LatestCompleted: latest,
+		}
+		d.SetTaskMeta(task.ID, *meta)
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// With only two slots, org2 gets one of them, despite org1's earlier burst.
+	s.Tick(6)
+	if got := len(d.CreatedFor(tasks[3].ID)); got != 1 {
+		t.Fatalf("expected 1 run created for org2, got %d", got)
+	}
+	if got := len(d.CreatedFor(tasks[0].ID)) + len(d.CreatedFor(tasks[1].ID)) + len(d.CreatedFor(tasks[2].ID)); got != 1 {
+		t.Fatalf("expected 1 run created for org1, got %d", got)
+	}
+}
+
+func TestScheduler_Deduplicate(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1)}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1m",
+		LatestCompleted: 0,
+	}
+	d.SetTaskMeta(task.ID, *meta)
+
+	// A backfill already computed the window for 60.
+	if err := d.RecordRunOutcome(context.Background(), task.ID, backend.RunOutcome{RunID: platform.ID(99), ScheduledFor: 60, Status: backend.RunSuccess}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run for 60 is deduplicated, and the run for 120 starts.
+	s.Tick(120)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := promises[0].Run().Now; got != 120 {
+		t.Fatalf("expected run for 120 to be executing, got run for %d", got)
+	}
+
+	pollForRunStatus(t, rl, task.ID, 2, 0, backend.RunDeduplicated.String())
+}
+
+func TestScheduler_DeduplicateSkipsManualRuns(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	s := backend.NewScheduler(d, e, backend.NopLogWriter{}, 3059, backend.WithLogger(zaptest.NewLogger(t)))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{ID: platform.ID(1)}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1m",
+		LatestCompleted: 3000,
+		ManualRuns: []*backend.StoreTaskMetaManualRun{
+			{Start: 120, End: 120, LatestCompleted: 119, RequestedAt: 3001},
+		},
+	}
+	d.SetTaskMeta(task.ID, *meta)
+
+	// The window for 120 already succeeded, but the operator asked to run it again.
+	if err := d.RecordRunOutcome(context.Background(), task.ID, backend.RunOutcome{RunID: platform.ID(99), ScheduledFor: 120, Status: backend.RunSuccess}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := promises[0].Run().Now; got != 120 {
+		t.Fatalf("expected manual run for 120 to be executing, got run for %d", got)
+	}
+}
+
+// BenchmarkScheduler_Tick measures ticks that find no task due, with increasing numbers of claimed tasks.
+// The cost of such a tick should not grow with the number of claimed tasks.
+func BenchmarkScheduler_Tick(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("claimed=%d", n), func(b *testing.B) {
+			const now = 3600
+			d := mock.NewDesiredState()
+			e := mock.NewExecutor()
+			s := backend.NewScheduler(d, e, backend.NopLogWriter{}, now)
+			s.Start(context.Background())
+			defer s.Stop()
+
+			for i := 1; i <= n; i++ {
+				task := &backend.StoreTask{ID: platform.ID(i)}
+				meta := &backend.StoreTaskMeta{
+					MaxConcurrency:  1,
+					EffectiveCron:   "@every 1h",
+					LatestCompleted: now,
+				}
+				if err := s.ClaimTask(task, meta); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Tick(now + 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+// Package schedulertest simulates the scheduling of a task, by driving a real backend.TickScheduler with a simulated clock,
+// to report the runs the scheduler would execute and when.
+//
+// Users can preview the schedule of a task before creating it.
+// Implementers of backend.Store can check that the scheduler executes the same runs against their store as against the in-memory store,
+// and changes to the scheduler can be checked against the runs it executed before.
+package schedulertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/kit/clock"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
+)
+
+// settleTimeout is how long Simulate waits, in real time, for the scheduler to act on a simulated tick.
+const settleTimeout = 10 * time.Second
+
+// Config describes a simulation.
+type Config struct {
+	// Script is the task's script, whose options determine its schedule.
+	Script string
+
+	// Start and End bound the simulated time. The task is created at Start, and the simulation stops at End.
+	Start, End time.Time
+
+	// RunDuration returns how long, in simulated time, the run scheduled for the given time executes.
+	// Runs that take longer than the task's timeout option time out.
+	// If RunDuration is nil, every run finishes on the tick it starts.
+	RunDuration func(scheduledFor time.Time) time.Duration
+
+	// Jitter is the scheduler's jitter window, as set by backend.WithJitter.
+	Jitter time.Duration
+
+	// Store holds the simulated task. If it is nil, a new in-memory store is used.
+	// The task is created in the store, and left there when the simulation stops.
+	Store backend.Store
+}
+
+// Run is a run of the task created during a simulation.
+type Run struct {
+	RunID platform.ID
+
+	// ScheduledFor is the time the run was scheduled for, which is the end of the window of data it covers.
+	ScheduledFor time.Time
+
+	// StartedAt is when the scheduler started executing the run, or for a run it skipped, when it decided to skip it.
+	StartedAt time.Time
+
+	// FinishedAt is when the run finished executing.
+	// It is zero for skipped runs, and for runs still executing at the end of the simulation.
+	FinishedAt time.Time
+
+	// Status is the run's final status: RunSuccess, RunCanceled, or RunTimedOut for runs that executed,
+	// RunSkipped or RunDeduplicated for runs the scheduler skipped, or RunStarted for runs still executing at the end of the simulation.
+	Status backend.RunStatus
+
+	// Concurrent is the number of the task's runs executing once the run started, including itself. It is zero for skipped runs.
+	Concurrent int
+}
+
+// Simulate creates the task described by cfg, and reports the runs a scheduler created for it between cfg.Start and cfg.End,
+// in the order they started.
+//
+// The scheduler is ticked once a simulated second, skipping the seconds in which nothing can happen.
+// On each tick, Simulate waits for the scheduler to finish acting on it before moving the clock on,
+// so that a simulation always reports the same runs.
+func Simulate(ctx context.Context, cfg Config) ([]Run, error) {
+	if cfg.End.Before(cfg.Start) {
+		return nil, errors.New("simulation ends before it starts")
+	}
+	opts, err := options.FromScript(cfg.Script)
+	if err != nil {
+		return nil, err
+	}
+
+	st := cfg.Store
+	if st == nil {
+		st = backend.NewInMemStore()
+	}
+
+	clock := clock.NewMock(cfg.Start)
+	sim := &simulation{
+		clock:    clock,
+		timeout:  opts.Timeout,
+		duration: cfg.RunDuration,
+		byID:     make(map[platform.ID]*Run),
+	}
+
+	taskID, err := st.CreateTask(ctx, backend.CreateTaskRequest{
+		Org:           platform.ID(1),
+		User:          platform.ID(1),
+		Script:        cfg.Script,
+		ScheduleAfter: cfg.Start.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	task, meta, err := st.FindTaskByIDWithMeta(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := backend.NewScheduler(st, sim, sim, cfg.Start.Unix(), backend.WithClock(clock), backend.WithJitter(cfg.Jitter))
+	s.Start(ctx)
+	defer s.Stop()
+
+	if err := s.ClaimTask(task, meta); err != nil {
+		return nil, err
+	}
+
+	for now := cfg.Start.Unix(); now <= cfg.End.Unix(); now = sim.next(s.State(), now) {
+		clock.Set(time.Unix(now, 0))
+		s.Tick(now)
+		if err := sim.settle(s, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return sim.runs(), nil
+}
+
+// simulation is the backend.Executor and backend.LogWriter of a simulated scheduler.
+// It executes each run for the simulated time given by duration, and records the runs the scheduler creates.
+type simulation struct {
+	clock    *clock.Mock
+	timeout  time.Duration
+	duration func(scheduledFor time.Time) time.Duration
+
+	mu       sync.Mutex
+	promises []*promise
+	byID     map[platform.ID]*Run
+	order    []*Run // In the order their runs started.
+}
+
+var (
+	_ backend.Executor  = (*simulation)(nil)
+	_ backend.LogWriter = (*simulation)(nil)
+)
+
+// Execute starts executing run, to finish at the tick its duration has passed.
+func (sim *simulation) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
+	now := sim.clock.Now()
+	scheduledFor := time.Unix(run.Now, 0).UTC()
+
+	var d time.Duration
+	if sim.duration != nil {
+		d = sim.duration(scheduledFor)
+	}
+	var err error
+	if sim.timeout > 0 && d > sim.timeout {
+		d, err = sim.timeout, backend.ErrRunTimedOut
+	}
+
+	p := &promise{ctx: ctx, qr: run, end: now.Add(d), err: err, done: make(chan struct{})}
+
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+
+	sim.promises = append(sim.promises, p)
+	r := &Run{RunID: run.RunID, ScheduledFor: scheduledFor, StartedAt: now.UTC(), Status: backend.RunStarted}
+	for _, other := range sim.promises {
+		if other.executing() {
+			r.Concurrent++
+		}
+	}
+	sim.byID[run.RunID] = r
+	sim.order = append(sim.order, r)
+
+	if !p.end.After(now) {
+		p.finish(nil)
+	}
+	return p, nil
+}
+
+// Wait is a no-op, as every run is finished by the simulation.
+func (sim *simulation) Wait() {}
+
+// UpdateRunState records the final status of runs, including runs the scheduler skips without executing.
+func (sim *simulation) UpdateRunState(_ context.Context, base backend.RunLogBase, when time.Time, state backend.RunStatus) error {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+
+	switch state {
+	case backend.RunSkipped, backend.RunDeduplicated:
+		r := &Run{RunID: base.RunID, ScheduledFor: time.Unix(base.RunScheduledFor, 0).UTC(), StartedAt: when.UTC(), Status: state}
+		sim.byID[base.RunID] = r
+		sim.order = append(sim.order, r)
+	case backend.RunSuccess, backend.RunFail, backend.RunCanceled, backend.RunTimedOut:
+		if r, ok := sim.byID[base.RunID]; ok {
+			r.Status, r.FinishedAt = state, when.UTC()
+		}
+	}
+	return nil
+}
+
+// AddRunLog is a no-op; simulated runs have no logs.
+func (sim *simulation) AddRunLog(context.Context, backend.RunLogBase, time.Time, string) error {
+	return nil
+}
+
+// settle finishes the runs whose time is up at the tick now, and waits until the scheduler has finished acting on the tick:
+// when every runner the scheduler keeps busy is executing a run that has not finished.
+func (sim *simulation) settle(s *backend.TickScheduler, now int64) error {
+	deadline := time.Now().Add(settleTimeout)
+	for {
+		sim.finishDue(time.Unix(now, 0))
+
+		working := 0
+		for _, ts := range s.State().Tasks {
+			working += ts.Working
+		}
+		if working == sim.executing() {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("scheduler did not settle on tick %d", now)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// finishDue finishes the executing runs whose time is up at now.
+func (sim *simulation) finishDue(now time.Time) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+
+	for _, p := range sim.promises {
+		if !p.finished() && !p.end.After(now) {
+			p.finish(p.err)
+		}
+	}
+}
+
+// executing returns the number of runs that are executing: neither finished nor canceled.
+func (sim *simulation) executing() int {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+
+	n := 0
+	for _, p := range sim.promises {
+		if p.executing() {
+			n++
+		}
+	}
+	return n
+}
+
+// next returns the tick after now at which something may happen:
+// the next run comes due, or an executing run finishes.
+func (sim *simulation) next(state backend.SchedulerState, now int64) int64 {
+	next := backend.NeverDue
+	for _, ts := range state.Tasks {
+		if ts.NextDue < next {
+			next = ts.NextDue
+		}
+		if ts.QueuedRuns > 0 {
+			next = now
+		}
+	}
+
+	sim.mu.Lock()
+	for _, p := range sim.promises {
+		if !p.finished() && p.end.Unix() < next {
+			next = p.end.Unix()
+		}
+	}
+	sim.mu.Unlock()
+
+	// A run may be held back while it is due, such as during a blackout window, so move on by at least a second.
+	if next <= now {
+		return now + 1
+	}
+	return next
+}
+
+// runs returns the runs created in the simulation, in the order they started.
+func (sim *simulation) runs() []Run {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+
+	runs := make([]Run, 0, len(sim.order))
+	for _, r := range sim.order {
+		runs = append(runs, *r)
+	}
+
+	// Runs that started on the same tick may have been started concurrently, in any order.
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].StartedAt.Equal(runs[j].StartedAt) {
+			return runs[i].StartedAt.Before(runs[j].StartedAt)
+		}
+		return runs[i].ScheduledFor.Before(runs[j].ScheduledFor)
+	})
+	return runs
+}
+
+// promise is a backend.RunPromise for a simulated run, which finishes at the tick end, unless the scheduler cancels it first.
+type promise struct {
+	ctx context.Context
+	qr  backend.QueuedRun
+	end time.Time
+	err error // The error the run finishes with.
+
+	once sync.Once
+	done chan struct{}
+	res  error // The error the run finished with.
+}
+
+var _ backend.RunPromise = (*promise)(nil)
+
+func (p *promise) Run() backend.QueuedRun { return p.qr }
+
+func (p *promise) Wait() (backend.RunResult, error) {
+	<-p.done
+	if p.res != nil {
+		return nil, p.res
+	}
+	return result{}, nil
+}
+
+// Cancel finishes the run as canceled.
+func (p *promise) Cancel() {
+	p.finish(backend.ErrRunCanceled)
+}
+
+func (p *promise) finish(err error) {
+	p.once.Do(func() {
+		p.res = err
+		close(p.done)
+	})
+}
+
+func (p *promise) finished() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// executing reports whether the run is still executing.
+// A run whose context the scheduler canceled is about to be canceled, so it no longer counts.
+func (p *promise) executing() bool {
+	return !p.finished() && p.ctx.Err() == nil
+}
+
+// result is the backend.RunResult of a simulated run that succeeded.
+type result struct{}
+
+func (result) Err() error        { return nil }
+func (result) IsRetryable() bool { return false }
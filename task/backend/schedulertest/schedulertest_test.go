@@ -0,0 +1,140 @@
+package schedulertest_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/schedulertest"
+)
+
+// simRun is the part of a schedulertest.Run that does not depend on run IDs, with times as Unix timestamps.
+type simRun struct {
+	scheduledFor, startedAt, finishedAt int64
+	status                              backend.RunStatus
+}
+
+func simRuns(runs []schedulertest.Run) []simRun {
+	out := make([]simRun, 0, len(runs))
+	for _, r := range runs {
+		sr := simRun{scheduledFor: r.ScheduledFor.Unix(), startedAt: r.StartedAt.Unix(), status: r.Status}
+		if !r.FinishedAt.IsZero() {
+			sr.finishedAt = r.FinishedAt.Unix()
+		}
+		out = append(out, sr)
+	}
+	return out
+}
+
+func TestSimulate(t *testing.T) {
+	const fmtScript = `option task = {
+	name: "simulated",
+	every: 1m,
+	overlap: %q,
+}
+
+from(bucket: "b") |> range(start: -1m)`
+
+	for _, tc := range []struct {
+		name     string
+		overlap  string
+		duration time.Duration
+		exp      []simRun
+	}{
+		{
+			name: "instant runs",
+			exp: []simRun{
+				{3660, 3660, 3660, backend.RunSuccess},
+				{3720, 3720, 3720, backend.RunSuccess},
+				{3780, 3780, 3780, backend.RunSuccess},
+				{3840, 3840, 3840, backend.RunSuccess},
+				{3900, 3900, 3900, backend.RunSuccess},
+			},
+		},
+		{
+			name:     "queue slow runs",
+			overlap:  "queue",
+			duration: 90 * time.Second,
+			exp: []simRun{
+				{3660, 3660, 3750, backend.RunSuccess},
+				{3720, 3750, 3840, backend.RunSuccess},
+				{3780, 3840, 0, backend.RunStarted},
+			},
+		},
+		{
+			name:     "skip slow runs",
+			overlap:  "skip",
+			duration: 90 * time.Second,
+			exp: []simRun{
+				{3660, 3660, 3750, backend.RunSuccess},
+				{3720, 3750, 0, backend.RunSkipped},
+				{3780, 3780, 3870, backend.RunSuccess},
+				{3840, 3870, 0, backend.RunSkipped},
+				{3900, 3900, 0, backend.RunStarted},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			overlap := tc.overlap
+			if overlap == "" {
+				overlap = "queue"
+			}
+			runs, err := schedulertest.Simulate(context.Background(), schedulertest.Config{
+				Script: fmt.Sprintf(fmtScript, overlap),
+				Start:  time.Unix(3600, 0),
+				End:    time.Unix(3900, 0),
+				RunDuration: func(time.Time) time.Duration {
+					return tc.duration
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := simRuns(runs); !reflect.DeepEqual(got, tc.exp) {
+				t.Fatalf("expected runs %v, got %v", tc.exp, got)
+			}
+		})
+	}
+}
+
+func TestSimulate_Repeatable(t *testing.T) {
+	const script = `option task = {
+	name: "simulated",
+	every: 1m,
+	concurrency: 3,
+}
+
+from(bucket: "b") |> range(start: -1m)`
+
+	cfg := schedulertest.Config{
+		Script: script,
+		Start:  time.Unix(3600, 0),
+		End:    time.Unix(7200, 0),
+		RunDuration: func(scheduledFor time.Time) time.Duration {
+			// Every third run takes several minutes, so that runs overlap.
+			if scheduledFor.Unix()%180 == 0 {
+				return 150 * time.Second
+			}
+			return 10 * time.Second
+		},
+	}
+
+	first, err := schedulertest.Simulate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := schedulertest.Simulate(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(simRuns(first), simRuns(second)) {
+		t.Fatalf("expected repeated simulations to report the same runs, got %v and %v", simRuns(first), simRuns(second))
+	}
+	if len(first) != 60 {
+		t.Fatalf("expected 60 runs in an hour, got %d", len(first))
+	}
+}
@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"sync"
+
+	"github.com/influxdata/platform"
+)
+
+// ShardedScheduler is a Scheduler that distributes tasks across a fixed set of Schedulers,
+// by a hash of the task ID.
+// With many claimed tasks, this keeps unrelated tasks from contending on a single scheduler's locks.
+type ShardedScheduler struct {
+	shards []Scheduler
+}
+
+var (
+	_ Scheduler       = (*ShardedScheduler)(nil)
+	_ TaskRunCanceler = (*ShardedScheduler)(nil)
+)
+
+// NewShardedScheduler returns a ShardedScheduler that distributes tasks across shards.
+// The order of shards determines which shard owns a task, so it must be consistent across calls.
+// NewShardedScheduler panics if no shards are given.
+func NewShardedScheduler(shards ...Scheduler) *ShardedScheduler {
+	if len(shards) == 0 {
+		panic("NewShardedScheduler: at least one shard is required")
+	}
+	return &ShardedScheduler{shards: shards}
+}
+
+// ShardFor returns the index of the shard responsible for the task with the given ID.
+func (s *ShardedScheduler) ShardFor(taskID platform.ID) int {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(taskID))
+
+	h := fnv.New64a()
+	h.Write(b[:])
+	return int(h.Sum64() % uint64(len(s.shards)))
+}
+
+func (s *ShardedScheduler) shard(taskID platform.ID) Scheduler {
+	return s.shards[s.ShardFor(taskID)]
+}
+
+// Start starts every shard.
+func (s *ShardedScheduler) Start(ctx context.Context) {
+	for _, sh := range s.shards {
+		sh.Start(ctx)
+	}
+}
+
+// Stop stops every shard concurrently, and returns once they have all stopped.
+func (s *ShardedScheduler) Stop() {
+	var wg sync.WaitGroup
+	wg.Add(len(s.shards))
+	for _, sh := range s.shards {
+		go func(sh Scheduler) {
+			defer wg.Done()
+			sh.Stop()
+		}(sh)
+	}
+	wg.Wait()
+}
+
+// Drain drains every shard concurrently.
+// If any shard returns an error, one of those errors is returned.
+func (s *ShardedScheduler) Drain(ctx context.Context) error {
+	errs := make([]error, len(s.shards))
+	var wg sync.WaitGroup
+	wg.Add(len(s.shards))
+	for i, sh := range s.shards {
+		go func(i int, sh Scheduler) {
+			defer wg.Done()
+			errs[i] = sh.Drain(ctx)
+		}(i, sh)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShardedScheduler) ClaimTask(task *StoreTask, meta *StoreTaskMeta) error {
+	return s.shard(task.ID).ClaimTask(task, meta)
+}
+
+func (s *ShardedScheduler) UpdateTask(task *StoreTask, meta *StoreTaskMeta) error {
+	return s.shard(task.ID).UpdateTask(task, meta)
+}
+
+func (s *ShardedScheduler) ReleaseTask(taskID platform.ID) error {
+	return s.shard(taskID).ReleaseTask(taskID)
+}
+
+// ClaimedTasks returns the IDs of the tasks claimed across all shards.
+func (s *ShardedScheduler) ClaimedTasks() []platform.ID {
+	var ids []platform.ID
+	for _, shard := range s.shards {
+		ids = append(ids, shard.ClaimedTasks()...)
+	}
+	return ids
+}
+
+// OverdueRuns returns the overdue runs across all shards that implement OverdueRunLister, earliest deadline first.
+func (s *ShardedScheduler) OverdueRuns() []OverdueRun {
+	var runs []OverdueRun
+	for _, shard := range s.shards {
+		if l, ok := shard.(OverdueRunLister); ok {
+			runs = append(runs, l.OverdueRuns()...)
+		}
+	}
+	sortOverdueRuns(runs)
+	return runs
+}
+
+func (s *ShardedScheduler) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
+	return s.shard(taskID).CancelRun(ctx, taskID, runID)
+}
+
+// CancelTaskRuns implements TaskRunCanceler through the task's shard.
+// If the shard does not implement TaskRunCanceler, it does nothing.
+func (s *ShardedScheduler) CancelTaskRuns(ctx context.Context, taskID platform.ID) error {
+	c, ok := s.shard(taskID).(TaskRunCanceler)
+	if !ok {
+		return nil
+	}
+	return c.CancelTaskRuns(ctx, taskID)
+}
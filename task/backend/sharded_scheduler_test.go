@@ -0,0 +1,57 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/mock"
+)
+
+func TestShardedScheduler(t *testing.T) {
+	const numShards = 4
+	shards := make([]*mock.Scheduler, numShards)
+	schedulers := make([]backend.Scheduler, numShards)
+	for i := range shards {
+		shards[i] = mock.NewScheduler()
+		schedulers[i] = shards[i]
+	}
+	s := backend.NewShardedScheduler(schedulers...)
+
+	const numTasks = 100
+	meta := &backend.StoreTaskMeta{MaxConcurrency: 1, EffectiveCron: "@every 1m"}
+	for i := 1; i <= numTasks; i++ {
+		task := &backend.StoreTask{ID: platform.ID(i), Script: "script"}
+		if err := s.ClaimTask(task, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	perShard := make([]int, numShards)
+	for i := 1; i <= numTasks; i++ {
+		id := platform.ID(i)
+		owner := s.ShardFor(id)
+		for j, sh := range shards {
+			claimed := sh.TaskFor(id) != nil
+			if claimed != (j == owner) {
+				t.Fatalf("task %s: expected only shard %d to have claimed it, but shard %d claimed=%v", id, owner, j, claimed)
+			}
+		}
+		perShard[owner]++
+	}
+	for i, n := range perShard {
+		if n == 0 {
+			t.Fatalf("expected every shard to receive some tasks, but shard %d received none: %v", i, perShard)
+		}
+	}
+
+	for i := 1; i <= numTasks; i++ {
+		id := platform.ID(i)
+		if err := s.ReleaseTask(id); err != nil {
+			t.Fatal(err)
+		}
+		if task := shards[s.ShardFor(id)].TaskFor(id); task != nil {
+			t.Fatalf("task %s still claimed after release", id)
+		}
+	}
+}
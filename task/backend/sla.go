@@ -0,0 +1,155 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// OverdueRun is a run that was still executing past the deadline set by its task's sla option.
+type OverdueRun struct {
+	TaskID platform.ID
+	RunID  platform.ID
+
+	// ScheduledFor is the Unix timestamp the run was scheduled for.
+	ScheduledFor int64
+
+	// Deadline is the Unix timestamp the run was expected to have finished by:
+	// ScheduledFor plus the task's sla option.
+	Deadline int64
+}
+
+// SLAObserver is a RunObserver that is also notified when a run misses its task's SLA.
+// Observers added to a TickScheduler that implement SLAObserver are notified of SLA misses as well as outcomes.
+type SLAObserver interface {
+	RunObserver
+
+	// ObserveSLAMiss is called once for each run qr of task that has not finished by its deadline:
+	// either on the first tick past the deadline, or when the run finishes if it finished late between ticks.
+	// It may be called with the scheduler's lock held, so it should return promptly.
+	ObserveSLAMiss(ctx context.Context, task *StoreTask, qr QueuedRun, deadline int64)
+}
+
+// OverdueRunLister is implemented by Schedulers that track the deadlines set by the sla option, such as *TickScheduler.
+type OverdueRunLister interface {
+	// OverdueRuns returns the runs that are still executing past their deadline, earliest deadline first.
+	OverdueRuns() []OverdueRun
+}
+
+var (
+	_ OverdueRunLister = (*TickScheduler)(nil)
+	_ OverdueRunLister = (*ShardedScheduler)(nil)
+)
+
+// slaRun is an executing run of a task with the sla option.
+type slaRun struct {
+	task     *StoreTask
+	qr       QueuedRun
+	deadline int64
+	missed   bool // Whether the miss has been reported.
+}
+
+// slaTracker tracks the executing runs of tasks with the sla option, and reports those that miss their deadline.
+type slaTracker struct {
+	mu   sync.Mutex
+	runs map[platform.ID]*slaRun // Run ID -> executing run.
+
+	metrics *schedulerMetrics
+
+	// Notifies the scheduler's SLA observers of a miss.
+	observe func(ctx context.Context, task *StoreTask, qr QueuedRun, deadline int64)
+}
+
+func newSLATracker(metrics *schedulerMetrics, observe func(ctx context.Context, task *StoreTask, qr QueuedRun, deadline int64)) *slaTracker {
+	return &slaTracker{
+		runs:    make(map[platform.ID]*slaRun),
+		metrics: metrics,
+		observe: observe,
+	}
+}
+
+// start begins tracking the run qr of task, which must finish within sla of the time it is scheduled for.
+// A zero sla means the run has no deadline, and is not tracked.
+func (t *slaTracker) start(task *StoreTask, qr QueuedRun, sla time.Duration) {
+	if sla <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.runs[qr.RunID]; ok {
+		// The run was restarted while it was tracked; it keeps its deadline.
+		return
+	}
+	t.runs[qr.RunID] = &slaRun{task: task, qr: qr, deadline: qr.Now + int64(sla/time.Second)}
+}
+
+// finish stops tracking the run qr, which finished at the Unix timestamp finishedAt,
+// reporting it as a miss if it finished past its deadline without having been reported yet.
+func (t *slaTracker) finish(ctx context.Context, qr QueuedRun, finishedAt int64) {
+	t.mu.Lock()
+	r, ok := t.runs[qr.RunID]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.runs, qr.RunID)
+	t.mu.Unlock()
+
+	if r.missed {
+		t.metrics.FinishOverdueRun()
+		return
+	}
+	if finishedAt > r.deadline {
+		t.metrics.MissSLA(qr.TaskID.String())
+		t.observe(ctx, r.task, r.qr, r.deadline)
+	}
+}
+
+// check reports each tracked run that is still executing past its deadline as of the Unix timestamp now,
+// the first time it is found overdue.
+func (t *slaTracker) check(ctx context.Context, now int64) {
+	var missed []*slaRun
+	t.mu.Lock()
+	for _, r := range t.runs {
+		if !r.missed && now > r.deadline {
+			r.missed = true
+			missed = append(missed, r)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, r := range missed {
+		t.metrics.StartOverdueRun(r.qr.TaskID.String())
+		t.observe(ctx, r.task, r.qr, r.deadline)
+	}
+}
+
+// overdue returns the tracked runs that have been found overdue, earliest deadline first.
+func (t *slaTracker) overdue() []OverdueRun {
+	t.mu.Lock()
+	var runs []OverdueRun
+	for _, r := range t.runs {
+		if r.missed {
+			runs = append(runs, OverdueRun{TaskID: r.qr.TaskID, RunID: r.qr.RunID, ScheduledFor: r.qr.Now, Deadline: r.deadline})
+		}
+	}
+	t.mu.Unlock()
+
+	sortOverdueRuns(runs)
+	return runs
+}
+
+// sortOverdueRuns sorts runs by deadline, then by run ID.
+func sortOverdueRuns(runs []OverdueRun) {
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].Deadline != runs[j].Deadline {
+			return runs[i].Deadline < runs[j].Deadline
+		}
+		return runs[i].RunID < runs[j].RunID
+	})
+}
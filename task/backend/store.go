@@ -15,42 +15,65 @@ import (
 	"github.com/influxdata/platform/task/options"
 )
 
+// The errors returned by a Store carry platform error codes, so that platform.ErrorCode reports them,
+// and the HTTP API responds to them with the matching status.
 var (
 	// ErrTaskNotFound indicates no task could be found for given parameters.
-	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskNotFound = platform.NewCodedError(platform.ENotFound, "task not found")
 
 	// ErrUserNotFound is an error for when we can't find a user
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound = platform.NewCodedError(platform.ENotFound, "user not found")
 
 	// ErrOrgNotFound is an error for when we can't find an org
-	ErrOrgNotFound = errors.New("org not found")
+	ErrOrgNotFound = platform.NewCodedError(platform.ENotFound, "org not found")
 
 	// ErrManualQueueFull is returned when a manual run request cannot be completed.
-	ErrManualQueueFull = errors.New("manual queue at capacity")
+	ErrManualQueueFull = platform.NewCodedError(platform.ETooManyRequests, "manual queue at capacity")
+
+	// ErrOneShotManualRun is returned when requesting a manual run or retry of a one-shot task.
+	ErrOneShotManualRun = platform.NewCodedError(platform.EUnprocessableEntity, "cannot manually run a one-shot task")
 
 	// ErrRunNotFound is returned when searching for a run that doesn't exist.
-	ErrRunNotFound = errors.New("run not found")
+	ErrRunNotFound = platform.NewCodedError(platform.ENotFound, "run not found")
 
 	// ErrRunNotFinished is returned when a retry is invalid due to the run not being finished yet.
-	ErrRunNotFinished = errors.New("run is still in progress")
+	ErrRunNotFinished = platform.NewCodedError(platform.EConflict, "run is still in progress")
+
+	// ErrLeaseHeld is returned when attempting to acquire a task lease that is held, and not yet expired, by another owner.
+	ErrLeaseHeld = platform.NewCodedError(platform.EConflict, "task lease held by another owner")
+
+	// ErrTaskVersionNotFound is returned when a task has no retained script version matching the requested version.
+	ErrTaskVersionNotFound = platform.NewCodedError(platform.ENotFound, "task version not found")
+
+	// ErrTaskAlreadyCreated is returned by CreateTask, along with the existing task's ID,
+	// when a task was already created with the request's idempotency key.
+	ErrTaskAlreadyCreated = platform.NewCodedError(platform.EConflict, "task already created with idempotency key")
 )
 
+// MaxTaskVersions is the number of script versions retained for each task, including its current script.
+const MaxTaskVersions = 10
+
 type TaskStatus string
 
 const (
 	TaskActive   TaskStatus = "active"
 	TaskInactive TaskStatus = "inactive"
 
+	// TaskDeleted is the status of a task that was deleted, but is kept in the store so that it can be restored.
+	// Deleted tasks are not scheduled. See the coordinator's WithSoftDelete.
+	TaskDeleted TaskStatus = "deleted"
+
 	DefaultTaskStatus TaskStatus = TaskActive
 )
 
 // validate returns an error if s is not a known task status.
-func (s TaskStatus) validate(allowEmpty bool) error {
+// TaskDeleted is only valid if allowDeleted is true.
+func (s TaskStatus) validate(allowEmpty, allowDeleted bool) error {
 	if allowEmpty && s == "" {
 		return nil
 	}
 
-	if s == TaskActive || s == TaskInactive {
+	if s == TaskActive || s == TaskInactive || (allowDeleted && s == TaskDeleted) {
 		return nil
 	}
 
@@ -65,6 +88,9 @@ const (
 	RunFail
 	RunCanceled
 	RunScheduled
+	RunTimedOut
+	RunSkipped
+	RunDeduplicated
 )
 
 func (r RunStatus) String() string {
@@ -79,10 +105,32 @@ func (r RunStatus) String() string {
 		return "canceled"
 	case RunScheduled:
 		return "scheduled"
+	case RunTimedOut:
+		return "timedout"
+	case RunSkipped:
+		return "skipped"
+	case RunDeduplicated:
+		return "deduplicated"
 	}
 	panic(fmt.Sprintf("unknown RunStatus: %d", r))
 }
 
+// IsRunStatus reports whether status is the string form of a RunStatus, as recorded on a platform.Run.
+func IsRunStatus(status string) bool {
+	return status == RunStarted.String() || status == RunScheduled.String() || IsFinishedRunStatus(status)
+}
+
+// IsFinishedRunStatus reports whether status, the string form of a RunStatus as recorded on a platform.Run,
+// is one that a run ends in.
+func IsFinishedRunStatus(status string) bool {
+	for _, r := range []RunStatus{RunSuccess, RunFail, RunCanceled, RunTimedOut, RunSkipped, RunDeduplicated} {
+		if status == r.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // RunNotYetDueError is returned from CreateNextRun if a run is not yet due.
 type RunNotYetDueError struct {
 	// DueAt is the unix timestamp of when the next run is due.
@@ -140,6 +188,9 @@ type RunCreation struct {
 	// Whether there are any manual runs queued for this task.
 	// If so, the scheduler should begin executing them after handling real-time tasks.
 	HasQueue bool
+
+	// Number of manual runs queued for this task, each of which may cover several scheduled times.
+	QueuedRuns int
 }
 
 // CreateTaskRequest encapsulates state of a new task to be created.
@@ -158,8 +209,20 @@ type CreateTaskRequest struct {
 	// The initial task status.
 	// If empty, will be treated as DefaultTaskStatus.
 	Status TaskStatus
+
+	// Key/value labels to attach to the task. May be nil.
+	Labels map[string]string
+
+	// Optional client-chosen key identifying this request among the org's requests to create tasks,
+	// so that a retried request does not create a duplicate task.
+	// If a task created with the same key in the same org still exists,
+	// CreateTask returns that task's ID and ErrTaskAlreadyCreated, without creating another task.
+	IdempotencyKey string
 }
 
+// MaxIdempotencyKeyLength is the maximum length, in bytes, of CreateTaskRequest.IdempotencyKey.
+const MaxIdempotencyKeyLength = 256
+
 // UpdateTaskRequest encapsulates requested changes to a task.
 type UpdateTaskRequest struct {
 	// ID of the task.
@@ -169,9 +232,54 @@ type UpdateTaskRequest struct {
 	// If empty, do not modify the existing script.
 	Script string
 
+	// Changes to the task options of the script, such as its name or schedule,
+	// applied to Script if set, or else to the existing script.
+	// Options allows rescheduling or renaming a task without resubmitting its script.
+	Options options.Update
+
 	// The new desired task status.
 	// If empty, do not modify the existing status.
 	Status TaskStatus
+
+	// Why the task is being disabled, recorded in the task's meta as DisabledReason.
+	// Only valid when Status is TaskInactive.
+	// Whenever Status is set, any previously recorded reason is replaced.
+	DisabledReason string
+
+	// Unix timestamp when the task was deleted, recorded in the task's meta as DeletedAt.
+	// Required when Status is TaskDeleted, and only valid then.
+	DeletedAt int64
+
+	// New limit on the number of runs of the task that may execute at once, recorded in its meta without modifying its script.
+	// If zero, do not modify the existing limit.
+	// A later change to the script resets the limit to the script's concurrency option.
+	MaxConcurrency int32
+
+	// New labels for the task, replacing all of its existing labels.
+	// If nil, do not modify the existing labels.
+	// To remove all labels, use a non-nil, empty map.
+	Labels map[string]string
+
+	// New organization and user that own the task.
+	// If not valid, do not modify the existing owner.
+	Org, User platform.ID
+
+	// New notification settings for the task, recorded in its meta, replacing any existing settings.
+	// If nil, do not modify the existing settings.
+	// To remove the settings, use a non-nil value that requests no notifications.
+	Notification *StoreTaskMetaNotification
+}
+
+// NewScript returns the script of the task after applying req to a task whose script is current.
+func (req UpdateTaskRequest) NewScript(current string) (string, error) {
+	script := current
+	if req.Script != "" {
+		script = req.Script
+	}
+	if req.Options.IsZero() {
+		return script, nil
+	}
+	return req.Options.Apply(script)
 }
 
 // UpdateTaskResult describes the result of modifying a single task.
@@ -196,6 +304,16 @@ type Store interface {
 	// If the returned error is not nil, the returned result should not be inspected.
 	UpdateTask(ctx context.Context, req UpdateTaskRequest) (UpdateTaskResult, error)
 
+	// EnableTasks sets each of the tasks with the given IDs to active, in a single transaction where the store supports one,
+	// and returns the IDs of the tasks whose status changed.
+	// Tasks that do not exist, are deleted, or are already active are left as they are.
+	EnableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error)
+
+	// DisableTasks sets each of the tasks with the given IDs to inactive, in a single transaction where the store supports one,
+	// and returns the IDs of the tasks whose status changed.
+	// Tasks that do not exist, are deleted, or are already inactive are left as they are.
+	DisableTasks(ctx context.Context, ids []platform.ID) ([]platform.ID, error)
+
 	// ListTasks lists the tasks in the store that match the search params.
 	ListTasks(ctx context.Context, params TaskSearchParams) ([]StoreTaskWithMeta, error)
 
@@ -211,7 +329,7 @@ type Store interface {
 	FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*StoreTask, *StoreTaskMeta, error)
 
 	// DeleteTask returns whether an entry matching the given ID was deleted.
-	// If err is non-nil, deleted is false.
+	// If err is non-nil, deleted is false, unless err is a ReleaseWarning.
 	// If err is nil, deleted is false if no entry matched the ID,
 	// or deleted is true if there was a matching entry and it was deleted.
 	DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error)
@@ -228,16 +346,116 @@ type Store interface {
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
 	ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*StoreTaskMetaManualRun, error)
 
+	// RetryRun enqueues a request to run the task with the given ID again for the schedule scheduledFor (a Unix timestamp),
+	// as a retry of the earlier run with ID runID, which the new run is linked to through its RetryOf field.
+	// requestedAt is the Unix timestamp when the request was initiated.
+	// RetryRun must delegate to an underlying StoreTaskMeta's RetryRun method.
+	RetryRun(ctx context.Context, taskID, runID platform.ID, scheduledFor, requestedAt int64) (*StoreTaskMetaManualRun, error)
+
+	// ListTaskVersions returns the retained script versions of the task with the given ID, oldest first.
+	// The last version is the task's current script.
+	// A new version is recorded each time UpdateTask changes the script,
+	// and only the latest MaxTaskVersions versions are retained.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	ListTaskVersions(ctx context.Context, id platform.ID) ([]TaskVersion, error)
+
+	// RecordRunOutcome adds o to the run history of the task with the given ID,
+	// and records it as the task's last run in the task's meta.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	RecordRunOutcome(ctx context.Context, taskID platform.ID, o RunOutcome) error
+
+	// CompleteRun finishes the run o.RunID of the task with the given ID, as by FinishRun,
+	// and records its outcome o, as by RecordRunOutcome, in a single transaction.
+	// If the run is not in progress, nothing is recorded and an error is returned.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	CompleteRun(ctx context.Context, taskID platform.ID, o RunOutcome) error
+
+	// FindTaskStats returns statistics computed from the run history of the task with the given ID.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	FindTaskStats(ctx context.Context, taskID platform.ID) (*TaskStats, error)
+
+	// RunSucceeded reports whether the run history of the task with the given ID
+	// records a successful run scheduled for the Unix timestamp now.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	RunSucceeded(ctx context.Context, taskID platform.ID, now int64) (bool, error)
+
+	// AppendAuditEntry adds e to the end of the audit log of the org e.Org.
+	// Audit entries are retained after the task or org they describe is deleted.
+	AppendAuditEntry(ctx context.Context, e AuditEntry) error
+
+	// ListAuditEntries returns the entries in the audit log of the org with the given ID that match params,
+	// in the order they were appended.
+	// If more entries match than the limit in params, the earliest entries are returned.
+	ListAuditEntries(ctx context.Context, orgID platform.ID, params AuditSearchParams) ([]AuditEntry, error)
+
 	// DeleteOrg deletes the org.
 	DeleteOrg(ctx context.Context, orgID platform.ID) error
 
 	// DeleteUser deletes a user with userID.
 	DeleteUser(ctx context.Context, userID platform.ID) error
 
+	// AcquireTaskLease acquires or renews the lease on the task with the given ID for owner,
+	// so that the lease expires at the Unix timestamp expiresAt.
+	// If the lease is held by a different owner and does not expire until after the Unix timestamp now,
+	// ErrLeaseHeld is returned and the existing lease is left untouched.
+	// If no task matches the ID, ErrTaskNotFound is returned.
+	AcquireTaskLease(ctx context.Context, taskID platform.ID, owner string, now, expiresAt int64) error
+
+	// ReleaseTaskLease removes the lease on the task with the given ID, if it is held by owner.
+	// Releasing a lease that does not exist, or that is held by a different owner, is a no-op.
+	ReleaseTaskLease(ctx context.Context, taskID platform.ID, owner string) error
+
+	// ListTaskLeases returns all task leases in the store, including expired leases.
+	ListTaskLeases(ctx context.Context) ([]TaskLease, error)
+
+	// KeepAliveLeaseOwner records that owner is participating in task leasing until the Unix timestamp expiresAt.
+	// Lease owners that do not hold any leases still need to be visible to their peers, so that work can be split evenly.
+	KeepAliveLeaseOwner(ctx context.Context, owner string, expiresAt int64) error
+
+	// ListLeaseOwners returns the owners whose keep-alive has not expired as of the Unix timestamp now.
+	ListLeaseOwners(ctx context.Context, now int64) ([]string, error)
+
+	// AcquireLeaderLease acquires or renews the single, store-wide leader lease for owner,
+	// so that it expires at the Unix timestamp expiresAt.
+	// If the lease is held by a different owner and does not expire until after the Unix timestamp now,
+	// ErrLeaseHeld is returned.
+	AcquireLeaderLease(ctx context.Context, owner string, now, expiresAt int64) error
+
+	// ReleaseLeaderLease gives up the leader lease, if it is held by owner.
+	ReleaseLeaderLease(ctx context.Context, owner string) error
+
 	// Close closes the store for usage and cleans up running processes.
 	Close() error
 }
 
+// TaskChange describes a change to a task in a Store.
+type TaskChange struct {
+	TaskID platform.ID
+
+	// Created is true if the task was created, rather than updated or deleted.
+	Created bool
+
+	// Deleted is true if the task was deleted, and false if it was created or updated.
+	Deleted bool
+}
+
+// TaskImporter is implemented by stores that can store a task exactly as it exists in another store,
+// keeping its ID, meta, and script versions, so that tasks can be migrated between stores.
+type TaskImporter interface {
+	// ImportTask stores task along with its meta and versions, replacing any task with the same ID.
+	// The script is not parsed again, since it was already accepted by the store the task is imported from.
+	ImportTask(ctx context.Context, task StoreTask, meta StoreTaskMeta, versions []TaskVersion) error
+}
+
+// TaskWatcher is implemented by stores that can report changes to tasks,
+// including changes made through other instances sharing the same underlying data.
+type TaskWatcher interface {
+	// WatchTasks returns a channel that receives a TaskChange for each task created, updated, or deleted after the call.
+	// Changes to a task's meta alone, such as when runs are created or finished, are not reported.
+	// The channel is closed when ctx is done.
+	WatchTasks(ctx context.Context) <-chan TaskChange
+}
+
 // RunLogBase is the base information for a logs about an individual run.
 type RunLogBase struct {
 	// The parent task that owns the run.
@@ -251,6 +469,12 @@ type RunLogBase struct {
 
 	// When the log is requested, should be ignored when it is zero.
 	RequestedAt int64
+
+	// The ID of the earlier run that this run retries, if it is a retry of an individual run. Invalid otherwise.
+	RetryOf platform.ID
+
+	// The ID of the trace the scheduler started for the run. Empty if the tracer does not report trace IDs.
+	TraceID string
 }
 
 // LogWriter writes task logs and task state changes to a store.
@@ -259,6 +483,7 @@ type LogWriter interface {
 	UpdateRunState(ctx context.Context, base RunLogBase, when time.Time, state RunStatus) error
 
 	// AddRunLog adds a log line to the run.
+	// The scheduler writes each line as a JSON-encoded RunLogEvent, through AddRunLogEvent.
 	AddRunLog(ctx context.Context, base RunLogBase, when time.Time, log string) error
 }
 
@@ -284,9 +509,61 @@ type LogReader interface {
 	FindRunByID(ctx context.Context, orgID, runID platform.ID) (*platform.Run, error)
 
 	// ListLogs lists logs for a task or a specified run of a task.
+	// When listing the log of a run, the first logFilter.Offset lines are skipped.
 	ListLogs(ctx context.Context, logFilter platform.LogFilter) ([]platform.Log, error)
 }
 
+// SkipLogLines returns log without its first n lines.
+func SkipLogLines(log platform.Log, n int) platform.Log {
+	s := string(log)
+	for ; n > 0; n-- {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			return ""
+		}
+		s = s[i+1:]
+	}
+	return platform.Log(s)
+}
+
+// RunPruner is implemented by run stores that can delete old runs along with their logs.
+// Runs that have not finished are never deleted.
+type RunPruner interface {
+	// PruneRuns deletes finished runs scheduled before the Unix timestamp before,
+	// and if keep is positive, all but the keep latest scheduled finished runs of each task.
+	// If before is zero, runs are not deleted by age.
+	// The tasks whose IDs are in keepByTask instead keep only their keepByTask[id] latest scheduled finished runs,
+	// regardless of age and keep. Each count in keepByTask is positive.
+	// PruneRuns returns the number of runs deleted.
+	PruneRuns(ctx context.Context, before int64, keep int, keepByTask map[platform.ID]int) (int, error)
+}
+
+// PurgeResult describes the data deleted by a TaskPurger.
+type PurgeResult struct {
+	// Records is the number of records deleted, such as runs or keys.
+	Records int
+
+	// Bytes is the approximate size of the deleted records.
+	Bytes int64
+}
+
+// Add adds the records and bytes of o to r.
+func (r *PurgeResult) Add(o PurgeResult) {
+	r.Records += o.Records
+	r.Bytes += o.Bytes
+}
+
+// TaskPurger is implemented by stores that retain data about a task after DeleteTask,
+// such as the task's runs and their logs, or the idempotency key the task was created with.
+type TaskPurger interface {
+	// RetainedTaskIDs returns the IDs of the tasks that data is retained about.
+	// It may include tasks that have not been deleted.
+	RetainedTaskIDs(ctx context.Context) ([]platform.ID, error)
+
+	// PurgeTask deletes the data retained about the task with the given ID, which must have been deleted.
+	PurgeTask(ctx context.Context, taskID platform.ID) (PurgeResult, error)
+}
+
 // NopLogWriter is a LogWriter that doesn't do anything when its methods are called.
 // This is useful for test, but not much else.
 type NopLogReader struct{}
@@ -314,6 +591,9 @@ type TaskSearchParams struct {
 	// Return tasks starting after this ID.
 	After platform.ID
 
+	// Return only tasks that have all of these labels, with matching values. May be nil.
+	Labels map[string]string
+
 	// Size of each page. Must be non-negative.
 	// If zero, the implementation picks an appropriate default page size.
 	// Valid page sizes are implementation-dependent.
@@ -332,6 +612,83 @@ type StoreTask struct {
 
 	// The script content of the task.
 	Script string
+
+	// Key/value labels used to group tasks. May be nil.
+	Labels map[string]string
+
+	// Options are the options parsed from Script when the task was created or its script last changed.
+	// Nil if the store did not record them, such as for a task stored before options were recorded.
+	// The options may be shared with the store, so they must not be modified.
+	Options *options.Options
+}
+
+// ScriptOptions returns the options of t's script,
+// from t.Options if the store recorded them, or by parsing t.Script otherwise.
+func (t *StoreTask) ScriptOptions() (options.Options, error) {
+	if t.Options != nil {
+		return *t.Options, nil
+	}
+	return options.FromScript(t.Script)
+}
+
+// LabelsMatch reports whether labels contains every key in selector, with the same value.
+// An empty selector matches any labels.
+func LabelsMatch(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// TaskVersion is a script that a task has had at some point.
+type TaskVersion struct {
+	// Version is 1 for the script the task was created with,
+	// and increases by 1 each time the script changes.
+	Version int
+
+	Script string
+
+	// Unix timestamp of when the task's script was set to Script.
+	// Zero if unknown, such as for the first version of a task created before versions were recorded.
+	CreatedAt int64
+}
+
+// AppendTaskVersion returns versions with script added as the next version, created at the Unix timestamp now.
+// If versions is empty, oldScript is first recorded as version 1.
+// The oldest versions are dropped so that no more than MaxTaskVersions remain.
+func AppendTaskVersion(versions []TaskVersion, oldScript, script string, now int64) []TaskVersion {
+	if len(versions) == 0 {
+		versions = []TaskVersion{{Version: 1, Script: oldScript}}
+	}
+
+	versions = append(versions, TaskVersion{
+		Version:   versions[len(versions)-1].Version + 1,
+		Script:    script,
+		CreatedAt: now,
+	})
+	if n := len(versions) - MaxTaskVersions; n > 0 {
+		versions = append([]TaskVersion(nil), versions[n:]...)
+	}
+	return versions
+}
+
+// TaskLease records which coordinator currently owns scheduling of a task.
+// Leases allow multiple coordinators to share a single store without executing the same task twice.
+type TaskLease struct {
+	TaskID platform.ID
+
+	// Owner is an opaque identifier of the coordinator holding the lease.
+	Owner string
+
+	// Unix timestamp of when the lease expires, if not renewed.
+	ExpiresAt int64
+}
+
+// Expired returns true if the lease has expired as of the Unix timestamp now.
+func (l TaskLease) Expired(now int64) bool {
+	return l.ExpiresAt <= now
 }
 
 // StoreTaskWithMeta is a single struct with a StoreTask and a StoreTaskMeta.
@@ -374,7 +731,15 @@ func (StoreValidation) CreateArgs(req CreateTaskRequest) (options.Options, error
 		return o, fmt.Errorf("missing required fields to create task: %s", strings.Join(missing, ", "))
 	}
 
-	if err := req.Status.validate(true); err != nil {
+	if err := req.Status.validate(true, false); err != nil {
+		return o, err
+	}
+
+	if len(req.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return o, fmt.Errorf("idempotency key exceeds maximum length of %d", MaxIdempotencyKeyLength)
+	}
+
+	if err := validateLabels(req.Labels); err != nil {
 		return o, err
 	}
 
@@ -382,14 +747,15 @@ func (StoreValidation) CreateArgs(req CreateTaskRequest) (options.Options, error
 }
 
 // UpdateArgs validates the UpdateTaskRequest.
-// If the update only includes a new status (i.e. req.Script is empty), the returned options are zero.
-// If the update contains neither a new script nor a new status, or if the script is invalid, an error is returned.
+// If the update does not include a new script, the returned options are zero.
+// If the update contains no new script, options, status, labels, owner, or notification settings,
+// or if the script, options, or notification settings are invalid, an error is returned.
 func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error) {
 	var missing []string
 	var o options.Options
 
-	if req.Script == "" && req.Status == "" {
-		missing = append(missing, "script or status")
+	if req.Script == "" && req.Options.IsZero() && req.Status == "" && req.MaxConcurrency == 0 && req.Labels == nil && !req.Org.Valid() && !req.User.Valid() && req.Notification == nil {
+		missing = append(missing, "script, options, status, concurrency, labels, owner, or notification settings")
 	} else {
 		if req.Script != "" {
 			var err error
@@ -398,7 +764,28 @@ func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error
 				return o, err
 			}
 		}
-		if err := req.Status.validate(true); err != nil {
+		if err := req.Options.Validate(); err != nil {
+			return o, err
+		}
+		if err := req.Status.validate(true, true); err != nil {
+			return o, err
+		}
+		if req.DisabledReason != "" && req.Status != TaskInactive {
+			return o, errors.New("disabled reason requires inactive status")
+		}
+		if req.Status == TaskDeleted && req.DeletedAt == 0 {
+			return o, errors.New("deleted status requires deletion time")
+		}
+		if req.DeletedAt != 0 && req.Status != TaskDeleted {
+			return o, errors.New("deletion time requires deleted status")
+		}
+		if req.MaxConcurrency < 0 || req.MaxConcurrency > options.MaxConcurrency {
+			return o, fmt.Errorf("concurrency must be between 1 and %d", options.MaxConcurrency)
+		}
+		if err := validateLabels(req.Labels); err != nil {
+			return o, err
+		}
+		if err := req.Notification.Validate(); err != nil {
 			return o, err
 		}
 	}
@@ -413,3 +800,22 @@ func (StoreValidation) UpdateArgs(req UpdateTaskRequest) (options.Options, error
 
 	return o, nil
 }
+
+// ImportArgs returns an error if task cannot be imported, because it is missing its ID, owners, or script,
+// or because it has invalid labels.
+func (StoreValidation) ImportArgs(task StoreTask) error {
+	if !task.ID.Valid() || !task.Org.Valid() || !task.User.Valid() || task.Script == "" {
+		return errors.New("missing required fields to import task: task ID, org ID, user ID, and script")
+	}
+	return validateLabels(task.Labels)
+}
+
+// validateLabels returns an error if any label has an empty key.
+func validateLabels(labels map[string]string) error {
+	for k := range labels {
+		if k == "" {
+			return errors.New("task labels must have a non-empty key")
+		}
+	}
+	return nil
+}
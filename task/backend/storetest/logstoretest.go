@@ -179,6 +179,7 @@ func listRunsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc)
 
 	now := time.Now().UTC()
 	const nRuns = 150
+	const failEvery = 10
 	runs := make([]platform.Run, nRuns)
 	for i := 0; i < len(runs); i++ {
 		// Scheduled for times ascending with IDs.
@@ -199,6 +200,14 @@ func listRunsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc)
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		// Fail every tenth run, to filter by status.
+		if i%failEvery == 0 {
+			runs[i].Status = backend.RunFail.String()
+			if err := writer.UpdateRunState(ctx, rlb, scheduledFor.Add(2*time.Second), backend.RunFail); err != nil {
+				t.Fatal(err)
+			}
+		}
 	}
 
 	if _, err := reader.ListRuns(ctx, platform.RunFilter{}); err == nil {
@@ -276,6 +285,44 @@ func listRunsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc)
 	if len(listRuns) != beforeTimeIdx {
 		t.Fatalf("retrieved: %d, expected: %d", len(listRuns), beforeTimeIdx)
 	}
+
+	listRuns, err = reader.ListRuns(ctx, platform.RunFilter{
+		Task:   &task.ID,
+		Org:    &task.Org,
+		Status: backend.RunFail.String(),
+		Limit:  2 * nRuns,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(listRuns) != nRuns/failEvery {
+		t.Fatalf("retrieved: %d failed runs, expected: %d", len(listRuns), nRuns/failEvery)
+	}
+	for _, r := range listRuns {
+		if r.Status != backend.RunFail.String() {
+			t.Fatalf("expected only failed runs, got run %s with status %q", r.ID, r.Status)
+		}
+	}
+
+	const offset = 5
+	listRuns, err = reader.ListRuns(ctx, platform.RunFilter{
+		Task:   &task.ID,
+		Org:    &task.Org,
+		Status: backend.RunFail.String(),
+		Offset: offset,
+		Limit:  3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(listRuns) != 3 {
+		t.Fatalf("retrieved: %d, expected: %d", len(listRuns), 3)
+	}
+	if exp := runs[offset*failEvery].ID; listRuns[0].ID != exp {
+		t.Fatalf("expected offset listing to start at run %s, got %s", exp, listRuns[0].ID)
+	}
 }
 
 func findRunByIDTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc) {
@@ -397,4 +444,25 @@ func listLogsTest(t *testing.T, crf CreateRunStoreFunc, drf DestroyRunStoreFunc)
 	if len(logs) != len(runs) {
 		t.Fatal("not all logs retrieved")
 	}
+
+	// An offset skips the lines already read from a run's log.
+	rlb := backend.RunLogBase{
+		Task:            task,
+		RunID:           runs[targetRun].ID,
+		RunScheduledFor: now.Add(time.Duration(targetRun-nRuns) * time.Second).Unix(),
+	}
+	when := now.Add(time.Duration(targetRun-nRuns)*time.Second + 3*time.Millisecond)
+	if err := writer.AddRunLog(ctx, rlb, when, "log4b"); err != nil {
+		t.Fatal(err)
+	}
+	logs, err = reader.ListLogs(ctx, platform.LogFilter{Run: &runs[targetRun].ID, Org: &task.Org, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	if exp := when.Format(time.RFC3339Nano) + ": log4b"; string(logs[0]) != exp {
+		t.Fatalf("expected: %q, got: %q", exp, string(logs[0]))
+	}
 }
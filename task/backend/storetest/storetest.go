@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/options"
 )
 
 var idGen = snowflake.NewIDGenerator()
@@ -25,6 +27,7 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		funcNames = []string{
 			"CreateTask",
 			"UpdateTask",
+			"TaskStatusBatch",
 			"ListTasks",
 			"FindTask",
 			"FindMeta",
@@ -33,11 +36,21 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"CreateNextRun",
 			"FinishRun",
 			"ManuallyRunTimeRange",
+			"TaskLeases",
+			"LeaderLease",
+			"Labels",
+			"TaskVersions",
+			"TaskStats",
+			"AuditLog",
+			"Templates",
+			"ImportTask",
+			"WatchTasks",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
 		"CreateTask":           testStoreCreate,
 		"UpdateTask":           testStoreUpdate,
+		"TaskStatusBatch":      testStoreTaskStatusBatch,
 		"ListTasks":            testStoreListTasks,
 		"FindTask":             testStoreFindTask,
 		"FindMeta":             testStoreFindMeta,
@@ -48,6 +61,15 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
 		"DeleteOrg":            testStoreDeleteOrg,
 		"DeleteUser":           testStoreDeleteUser,
+		"TaskLeases":           testStoreTaskLeases,
+		"LeaderLease":          testStoreLeaderLease,
+		"Labels":               testStoreLabels,
+		"TaskVersions":         testStoreTaskVersions,
+		"TaskStats":            testStoreTaskStats,
+		"AuditLog":             testStoreAuditLog,
+		"Templates":            testStoreTemplates,
+		"ImportTask":           testStoreImportTask,
+		"WatchTasks":           testStoreWatchTasks,
 	}
 
 	return func(t *testing.T) {
@@ -113,6 +135,51 @@ from(bucket:"test") |> range(start:-1h)`
 			}
 		})
 	}
+
+	t.Run("idempotency key", func(t *testing.T) {
+		req := backend.CreateTaskRequest{Org: 10, User: 2, Script: script, IdempotencyKey: "retry-me"}
+		id, err := s.CreateTask(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Repeating the request returns the existing task.
+		again, err := s.CreateTask(context.Background(), req)
+		if err != backend.ErrTaskAlreadyCreated {
+			t.Fatalf("expected ErrTaskAlreadyCreated, got %v", err)
+		}
+		if again != id {
+			t.Fatalf("expected existing task ID %s, got %s", id, again)
+		}
+		tasks, err := s.ListTasks(context.Background(), backend.TaskSearchParams{Org: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 {
+			t.Fatalf("expected 1 task, got %d", len(tasks))
+		}
+
+		// Keys are scoped to the org.
+		other := req
+		other.Org = 11
+		if otherID, err := s.CreateTask(context.Background(), other); err != nil {
+			t.Fatal(err)
+		} else if otherID == id {
+			t.Fatal("expected a new task for the same key in a different org")
+		}
+
+		// Once the task is deleted, the key can be used again.
+		if _, err := s.DeleteTask(context.Background(), id); err != nil {
+			t.Fatal(err)
+		}
+		newID, err := s.CreateTask(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if newID == id {
+			t.Fatal("expected a new task after deleting the task created with the key")
+		}
+	})
 }
 
 func testStoreUpdate(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
@@ -219,6 +286,286 @@ from(bucket:"y") |> range(start:-1h)`
 		}
 	})
 
+	t.Run("disabled reason", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, DisabledReason: "broken"}); err == nil {
+			t.Fatal("expected error when setting disabled reason without inactive status")
+		}
+
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive, DisabledReason: "broken"}); err != nil {
+			t.Fatal(err)
+		}
+		_, meta, err := s.FindTaskByIDWithMeta(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Status != string(backend.TaskInactive) || meta.DisabledReason != "broken" {
+			t.Fatalf("expected inactive status with disabled reason, got %q and %q", meta.Status, meta.DisabledReason)
+		}
+
+		// Changing the status clears the reason.
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskActive}); err != nil {
+			t.Fatal(err)
+		}
+		_, meta, err = s.FindTaskByIDWithMeta(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DisabledReason != "" {
+			t.Fatalf("expected disabled reason to be cleared, got %q", meta.DisabledReason)
+		}
+	})
+
+	t.Run("deleted status", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskDeleted}); err == nil {
+			t.Fatal("expected error when setting deleted status without deletion time")
+		}
+
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskDeleted, DeletedAt: 1000}); err != nil {
+			t.Fatal(err)
+		}
+		meta, err := s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Status != string(backend.TaskDeleted) || meta.DeletedAt != 1000 || meta.DeletedStatus != string(backend.TaskActive) {
+			t.Fatalf("expected deleted status with deletion time and previous status, got %#v", meta)
+		}
+
+		// Changing the status clears the deletion.
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Status: backend.TaskActive}); err != nil {
+			t.Fatal(err)
+		}
+		meta, err = s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DeletedAt != 0 || meta.DeletedStatus != "" {
+			t.Fatalf("expected deletion to be cleared, got %#v", meta)
+		}
+	})
+
+	t.Run("max concurrency", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, MaxConcurrency: -1}); err == nil {
+			t.Fatal("expected error setting negative concurrency")
+		}
+
+		res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, MaxConcurrency: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.NewTask.Script != script {
+			t.Fatalf("expected script to be unchanged, got:\n%s", res.NewTask.Script)
+		}
+		task, meta, err := s.FindTaskByIDWithMeta(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Script != script || meta.MaxConcurrency != 4 {
+			t.Fatalf("expected concurrency 4 with unchanged script, got %d", meta.MaxConcurrency)
+		}
+
+		// Changing the script resets the limit to the script's option.
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Script: script2}); err != nil {
+			t.Fatal(err)
+		}
+		meta, err = s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.MaxConcurrency != 1 {
+			t.Fatalf("expected concurrency to be reset to 1, got %d", meta.MaxConcurrency)
+		}
+	})
+
+	t.Run("notification settings", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script, ScheduleAfter: 6000})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bad := &backend.StoreTaskMetaNotification{OnFailure: true, Target: "ftp://example.com/hook"}
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Notification: bad}); err == nil {
+			t.Fatal("expected error setting invalid notification target")
+		}
+
+		settings := backend.StoreTaskMetaNotification{OnFailure: true, OnRecovery: true, Target: "ops"}
+		res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Notification: &settings})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.NewTask.Script != script {
+			t.Fatalf("expected script to be unchanged, got:\n%s", res.NewTask.Script)
+		}
+		meta, err := s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Notification == nil || *meta.Notification != settings {
+			t.Fatalf("expected notification settings %v, got %v", settings, meta.Notification)
+		}
+
+		// Runs carry the settings.
+		rc, err := s.CreateNextRun(context.Background(), id, 6065)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Notification == nil || *rc.Created.Notification != settings {
+			t.Fatalf("expected run to carry notification settings %v, got %v", settings, rc.Created.Notification)
+		}
+
+		// Updates that leave the settings out keep them.
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Script: script2}); err != nil {
+			t.Fatal(err)
+		}
+		meta, err = s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Notification == nil || *meta.Notification != settings {
+			t.Fatalf("expected notification settings %v to be kept, got %v", settings, meta.Notification)
+		}
+
+		// Settings that request nothing remove them.
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Notification: &backend.StoreTaskMetaNotification{}}); err != nil {
+			t.Fatal(err)
+		}
+		meta, err = s.FindTaskMetaByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Notification != nil {
+			t.Fatalf("expected notification settings to be removed, got %v", meta.Notification)
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := "renamed"
+		every := 5 * time.Minute
+		offset := 10 * time.Second
+		res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{
+			ID:      id,
+			Options: options.Update{Name: &name, Every: &every, Offset: &offset},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		o, err := options.FromScript(res.NewTask.Script)
+		if err != nil {
+			t.Fatalf("updated script is invalid: %v\n%s", err, res.NewTask.Script)
+		}
+		if o.Name != name || o.Every != every || o.Cron != "" || o.Offset != offset {
+			t.Fatalf("unexpected options after update: %#v", o)
+		}
+		if res.OldScript != script {
+			t.Fatalf("expected old script to be returned, got %q", res.OldScript)
+		}
+
+		task, meta, err := s.FindTaskByIDWithMeta(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Name != name || task.Script != res.NewTask.Script {
+			t.Fatalf("updated task not stored, got name %q and script:\n%s", task.Name, task.Script)
+		}
+		if meta.EffectiveCron != "@every 5m0s" || meta.Offset != 10 {
+			t.Fatalf("expected meta schedule to follow the updated options, got cron %q and offset %d", meta.EffectiveCron, meta.Offset)
+		}
+		if task.Options == nil || task.Options.Name != name || task.Options.Every != every || task.Options.Offset != offset {
+			t.Fatalf("expected recorded options to follow the update, got %#v", task.Options)
+		}
+
+		// Both every and cron cannot be set.
+		cron := "0 * * * *"
+		if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Options: options.Update{Every: &every, Cron: &cron}}); err == nil {
+			t.Fatal("expected error updating both every and cron")
+		}
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		s := create(t)
+		defer destroy(t, s)
+
+		id, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: id, Org: 3, User: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.NewTask.Org != 3 || res.NewTask.User != 4 {
+			t.Fatalf("expected new task to have org 3 and user 4, got org %s and user %s", res.NewTask.Org, res.NewTask.User)
+		}
+		if res.NewTask.Script != script {
+			t.Fatalf("expected script to be unchanged, got %q", res.NewTask.Script)
+		}
+
+		task, err := s.FindTaskByID(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Org != 3 || task.User != 4 {
+			t.Fatalf("expected stored task to have org 3 and user 4, got org %s and user %s", task.Org, task.User)
+		}
+
+		// The task is listed under its new org and user only.
+		for _, p := range []backend.TaskSearchParams{{Org: 1}, {User: 2}} {
+			ts, err := s.ListTasks(context.Background(), p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ts) != 0 {
+				t.Fatalf("expected no tasks for %+v, got %d", p, len(ts))
+			}
+		}
+		for _, p := range []backend.TaskSearchParams{{Org: 3}, {User: 4}} {
+			ts, err := s.ListTasks(context.Background(), p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ts) != 1 || ts[0].Task.ID != id {
+				t.Fatalf("expected task %s for %+v, got %v", id, p, ts)
+			}
+		}
+	})
+
 	for _, args := range []struct {
 		caseName string
 		req      backend.UpdateTaskRequest
@@ -254,6 +601,77 @@ from(bucket:"y") |> range(start:-1h)`
 	})
 }
 
+func testStoreTaskStatusBatch(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	var ids []platform.ID
+	for i := 0; i < 3; i++ {
+		id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: ids[1], Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkStatus := func(exp backend.TaskStatus, ids ...platform.ID) {
+		t.Helper()
+		for _, id := range ids {
+			meta, err := s.FindTaskMetaByID(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta.Status != string(exp) {
+				t.Fatalf("expected task %s to be %s, got %s", id, exp, meta.Status)
+			}
+		}
+	}
+
+	// Only the tasks whose status changes are returned, and missing tasks are skipped.
+	missing := platform.ID(1)
+	changed, err := s.DisableTasks(ctx, append(ids, missing))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []platform.ID{ids[0], ids[2]}; !reflect.DeepEqual(changed, exp) {
+		t.Fatalf("expected tasks %v disabled, got %v", exp, changed)
+	}
+	checkStatus(backend.TaskInactive, ids...)
+
+	changed, err = s.EnableTasks(ctx, ids[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []platform.ID{ids[0], ids[1]}; !reflect.DeepEqual(changed, exp) {
+		t.Fatalf("expected tasks %v enabled, got %v", exp, changed)
+	}
+	checkStatus(backend.TaskActive, ids[:2]...)
+	checkStatus(backend.TaskInactive, ids[2])
+
+	// Deleted tasks are left as they are.
+	if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: ids[2], Status: backend.TaskDeleted, DeletedAt: 100}); err != nil {
+		t.Fatal(err)
+	}
+	changed, err = s.EnableTasks(ctx, ids[2:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no tasks enabled, got %v", changed)
+	}
+	checkStatus(backend.TaskDeleted, ids[2])
+}
+
 func testStoreListTasks(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	const scriptFmt = `option task = {
 		name: "testStoreListTasks %d",
@@ -681,6 +1099,9 @@ from(bucket:"test") |> range(start:-1h)`
 	if task.Script != script {
 		t.Fatalf("unexpected script %q", task.Script)
 	}
+	if task.Options == nil || task.Options.Name != "a task" || task.Options.Cron != "* * * * *" || task.Options.Offset != 5*time.Second {
+		t.Fatalf("unexpected recorded options: %#v", task.Options)
+	}
 
 	if meta.MaxConcurrency != 3 {
 		t.Fatal("failed to set max concurrency")
@@ -945,13 +1366,53 @@ from(bucket:"test") |> range(start:-1h)`
 	if err := s.FinishRun(context.Background(), task, rc.Created.RunID); err == nil {
 		t.Fatal("expected failure when removing run that doesnt exist")
 	}
-}
-
-func testStoreManuallyRunTimeRange(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
-	const script = `option task = {
-		name: "a task",
-		cron: "* * * * *",
-	}
+
+	t.Run("with outcome", func(t *testing.T) {
+		rc, err := s.CreateNextRun(context.Background(), task, 120)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Unix(1000, 0).UTC()
+		o := backend.RunOutcome{RunID: rc.Created.RunID, ScheduledFor: rc.Created.Now, Status: backend.RunSuccess, StartedAt: start, FinishedAt: start.Add(time.Second)}
+		if err := s.CompleteRun(context.Background(), task, o); err != nil {
+			t.Fatal(err)
+		}
+
+		meta, err := s.FindTaskMetaByID(context.Background(), task)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(meta.CurrentlyRunning) != 0 || meta.LatestCompleted != rc.Created.Now {
+			t.Fatalf("expected run to be finished, got %#v", meta)
+		}
+		stats, err := s.FindTaskStats(context.Background(), task)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Runs != 1 || stats.Succeeded != 1 {
+			t.Fatalf("expected one successful run recorded, got %#v", stats)
+		}
+
+		// Completing the run again fails without recording the outcome twice.
+		if err := s.CompleteRun(context.Background(), task, o); err == nil {
+			t.Fatal("expected failure when completing run that doesnt exist")
+		}
+		stats, err = s.FindTaskStats(context.Background(), task)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Runs != 1 {
+			t.Fatalf("expected one run recorded, got %#v", stats)
+		}
+	})
+}
+
+func testStoreManuallyRunTimeRange(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
 
 from(bucket:"test") |> range(start:-1h)`
 	s := create(t)
@@ -984,6 +1445,410 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreTaskLeases(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	task, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AcquireTaskLease(ctx, task, "a", 100, 200); err != nil {
+		t.Fatal(err)
+	}
+	// Renewing by the same owner succeeds.
+	if err := s.AcquireTaskLease(ctx, task, "a", 150, 250); err != nil {
+		t.Fatal(err)
+	}
+	// A different owner can't take an unexpired lease.
+	if err := s.AcquireTaskLease(ctx, task, "b", 249, 349); err != backend.ErrLeaseHeld {
+		t.Fatalf("expected ErrLeaseHeld, got %v", err)
+	}
+	// Releasing someone else's lease is a no-op.
+	if err := s.ReleaseTaskLease(ctx, task, "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	leases, err := s.ListTaskLeases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leases) != 1 {
+		t.Fatalf("expected 1 lease, got %d", len(leases))
+	}
+	if l := leases[0]; l.TaskID != task || l.Owner != "a" || l.ExpiresAt != 250 {
+		t.Fatalf("unexpected lease: %#v", l)
+	}
+
+	// Once expired, the lease can be taken over.
+	if err := s.AcquireTaskLease(ctx, task, "b", 250, 350); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ReleaseTaskLease(ctx, task, "b"); err != nil {
+		t.Fatal(err)
+	}
+	leases, err = s.ListTaskLeases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leases) != 0 {
+		t.Fatalf("expected no leases after release, got %#v", leases)
+	}
+
+	if err := s.AcquireTaskLease(ctx, platform.ID(1234), "a", 100, 200); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for unknown task, got %v", err)
+	}
+
+	// Deleting the task removes its lease.
+	if err := s.AcquireTaskLease(ctx, task, "a", 400, 500); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	leases, err = s.ListTaskLeases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leases) != 0 {
+		t.Fatalf("expected no leases after deleting task, got %#v", leases)
+	}
+
+	if err := s.KeepAliveLeaseOwner(ctx, "a", 200); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.KeepAliveLeaseOwner(ctx, "b", 300); err != nil {
+		t.Fatal(err)
+	}
+	owners, err := s.ListLeaseOwners(ctx, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owners) != 1 || owners[0] != "b" {
+		t.Fatalf("expected only owner b to be alive, got %v", owners)
+	}
+}
+
+func testStoreLeaderLease(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	if err := s.AcquireLeaderLease(ctx, "a", 100, 200); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcquireLeaderLease(ctx, "a", 150, 250); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcquireLeaderLease(ctx, "b", 200, 300); err != backend.ErrLeaseHeld {
+		t.Fatalf("expected ErrLeaseHeld, got %v", err)
+	}
+
+	// Releasing as a non-leader is a no-op.
+	if err := s.ReleaseLeaderLease(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcquireLeaderLease(ctx, "b", 200, 300); err != backend.ErrLeaseHeld {
+		t.Fatalf("expected ErrLeaseHeld after non-leader release, got %v", err)
+	}
+
+	// Expired leases can be taken over.
+	if err := s.AcquireLeaderLease(ctx, "b", 250, 350); err != nil {
+		t.Fatal(err)
+	}
+
+	// Released leases can be acquired immediately.
+	if err := s.ReleaseLeaderLease(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcquireLeaderLease(ctx, "a", 260, 360); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testStoreLabels(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const scriptFmt = `option task = {
+		name: "testStoreLabels %d",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	orgID := platform.ID(1)
+	userID := platform.ID(2)
+
+	downsample, err := s.CreateTask(ctx, backend.CreateTaskRequest{
+		Org: orgID, User: userID, Script: fmt.Sprintf(scriptFmt, 0),
+		Labels: map[string]string{"kind": "downsampling", "team": "storage"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alert, err := s.CreateTask(ctx, backend.CreateTaskRequest{
+		Org: orgID, User: userID, Script: fmt.Sprintf(scriptFmt, 1),
+		Labels: map[string]string{"kind": "alerting", "team": "storage"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: orgID, User: userID, Script: fmt.Sprintf(scriptFmt, 2)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CreateTask(ctx, backend.CreateTaskRequest{
+		Org: orgID, User: userID, Script: fmt.Sprintf(scriptFmt, 3),
+		Labels: map[string]string{"": "x"},
+	}); err == nil {
+		t.Fatal("expected error for label with empty key")
+	}
+
+	task, err := s.FindTaskByID(ctx, downsample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(task.Labels) != 2 || task.Labels["kind"] != "downsampling" || task.Labels["team"] != "storage" {
+		t.Fatalf("unexpected labels on found task: %v", task.Labels)
+	}
+
+	for _, c := range []struct {
+		name   string
+		params backend.TaskSearchParams
+		expIDs []platform.ID
+	}{
+		{name: "no selector", params: backend.TaskSearchParams{Org: orgID}, expIDs: nil},
+		{name: "single label", params: backend.TaskSearchParams{Labels: map[string]string{"kind": "alerting"}}, expIDs: []platform.ID{alert}},
+		{name: "shared label", params: backend.TaskSearchParams{User: userID, Labels: map[string]string{"team": "storage"}}, expIDs: []platform.ID{downsample, alert}},
+		{name: "all labels must match", params: backend.TaskSearchParams{Org: orgID, Labels: map[string]string{"kind": "alerting", "team": "compute"}}, expIDs: []platform.ID{}},
+	} {
+		ts, err := s.ListTasks(ctx, c.params)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if c.expIDs == nil {
+			if len(ts) != 3 {
+				t.Fatalf("%s: expected 3 tasks, got %d", c.name, len(ts))
+			}
+			continue
+		}
+		if len(ts) != len(c.expIDs) {
+			t.Fatalf("%s: expected %d tasks, got %d", c.name, len(c.expIDs), len(ts))
+		}
+		for i, id := range c.expIDs {
+			if ts[i].Task.ID != id {
+				t.Fatalf("%s: expected task %d to be %s, got %s", c.name, i, id, ts[i].Task.ID)
+			}
+		}
+	}
+
+	// Updating without labels leaves them alone.
+	res, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: alert, Status: backend.TaskInactive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Labels["kind"] != "alerting" {
+		t.Fatalf("expected labels to be unchanged by status update, got %v", res.NewTask.Labels)
+	}
+
+	// Updating with an empty, non-nil map removes them.
+	res, err = s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: alert, Labels: map[string]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.NewTask.Labels) != 0 {
+		t.Fatalf("expected labels to be removed, got %v", res.NewTask.Labels)
+	}
+	ts, err := s.ListTasks(ctx, backend.TaskSearchParams{Labels: map[string]string{"team": "storage"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ts) != 1 || ts[0].Task.ID != downsample {
+		t.Fatalf("expected only the downsampling task to match after removing labels, got %d tasks", len(ts))
+	}
+}
+
+func testStoreTaskVersions(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const scriptFmt = `option task = {
+		name: "testStoreTaskVersions",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test %d") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: fmt.Sprintf(scriptFmt, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := s.ListTaskVersions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || versions[0].Version != 1 || versions[0].Script != fmt.Sprintf(scriptFmt, 0) {
+		t.Fatalf("unexpected versions for new task: %#v", versions)
+	}
+
+	// Status-only updates, and updates to the same script, don't add versions.
+	if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: fmt.Sprintf(scriptFmt, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	versions, err = s.ListTaskVersions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("expected 1 version after updates without script changes, got %d", len(versions))
+	}
+
+	// Change the script more times than versions are retained.
+	const numUpdates = backend.MaxTaskVersions + 2
+	for i := 1; i <= numUpdates; i++ {
+		if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Script: fmt.Sprintf(scriptFmt, i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err = s.ListTaskVersions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != backend.MaxTaskVersions {
+		t.Fatalf("expected %d retained versions, got %d", backend.MaxTaskVersions, len(versions))
+	}
+	last := versions[len(versions)-1]
+	if last.Version != numUpdates+1 || last.Script != fmt.Sprintf(scriptFmt, numUpdates) {
+		t.Fatalf("expected last version to be the current script, got %#v", last)
+	}
+	for i := 1; i < len(versions); i++ {
+		if versions[i].Version != versions[i-1].Version+1 {
+			t.Fatalf("expected consecutive versions, got %d after %d", versions[i].Version, versions[i-1].Version)
+		}
+	}
+
+	if _, err := s.ListTaskVersions(ctx, platform.ID(math.MaxUint64)); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for missing task, got %v", err)
+	}
+}
+
+func testStoreTaskStats(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "testStoreTaskStats",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := s.FindTaskStats(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Runs != 0 || stats.LastFailure != nil {
+		t.Fatalf("expected empty stats for new task, got %#v", stats)
+	}
+
+	start := time.Unix(1000, 0).UTC()
+	outcomes := []backend.RunOutcome{
+		{RunID: 1, ScheduledFor: 60, Status: backend.RunSuccess, StartedAt: start, FinishedAt: start.Add(time.Second), RowsWritten: 10, MaxMemoryBytes: 1024},
+		{RunID: 2, ScheduledFor: 120, Status: backend.RunFail, StartedAt: start, FinishedAt: start.Add(2 * time.Second), Error: "oops", ErrorCode: platform.EInternal},
+		{RunID: 3, ScheduledFor: 180, Status: backend.RunSuccess, StartedAt: start, FinishedAt: start.Add(3 * time.Second), RowsWritten: 5, MaxMemoryBytes: 4096},
+	}
+	for _, o := range outcomes {
+		if err := s.RecordRunOutcome(ctx, id, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err = s.FindTaskStats(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Runs != 3 || stats.Succeeded != 2 || stats.Failed != 1 {
+		t.Fatalf("expected 3 runs with 2 successes and 1 failure, got %#v", stats)
+	}
+	if stats.AverageDuration != 2*time.Second || stats.MedianDuration != 2*time.Second || stats.P95Duration != 3*time.Second {
+		t.Fatalf("unexpected durations: %#v", stats)
+	}
+	if lf := stats.LastFailure; lf == nil || lf.RunID != 2 || lf.Error != "oops" || lf.ErrorCode != platform.EInternal || !lf.FinishedAt.Equal(start.Add(2*time.Second)) {
+		t.Fatalf("unexpected last failure: %#v", lf)
+	}
+	expUsage := backend.TaskUsage{Runs: 3, ExecutionTime: 6 * time.Second, RowsWritten: 15, MemoryBytes: 5120, MaxMemoryBytes: 4096}
+	if stats.Usage != expUsage {
+		t.Fatalf("expected usage %#v, got %#v", expUsage, stats.Usage)
+	}
+
+	// The meta reflects the last recorded run.
+	_, meta, err := s.FindTaskByIDWithMeta(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.LastRunStatus != backend.RunSuccess.String() || meta.LastRunError != "" || meta.LastRunDuration != int64(3*time.Second) {
+		t.Fatalf("unexpected last run in meta: %#v", meta)
+	}
+	if err := s.RecordRunOutcome(ctx, id, outcomes[1]); err != nil {
+		t.Fatal(err)
+	}
+	meta, err = s.FindTaskMetaByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.LastRunStatus != backend.RunFail.String() || meta.LastRunError != "oops" || meta.LastRunDuration != int64(2*time.Second) {
+		t.Fatalf("unexpected last run in meta after failure: %#v", meta)
+	}
+
+	// Only the windows of successful runs are reported as succeeded.
+	for now, want := range map[int64]bool{60: true, 120: false, 180: true, 240: false} {
+		got, err := s.RunSucceeded(ctx, id, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("expected RunSucceeded(%d) to be %v, got %v", now, want, got)
+		}
+	}
+
+	if _, err := s.FindTaskStats(ctx, platform.ID(math.MaxUint64)); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for missing task, got %v", err)
+	}
+	if err := s.RecordRunOutcome(ctx, platform.ID(math.MaxUint64), outcomes[0]); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound recording outcome for missing task, got %v", err)
+	}
+	if _, err := s.RunSucceeded(ctx, platform.ID(math.MaxUint64), 60); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound checking run of missing task, got %v", err)
+	}
+
+	// Deleting the task deletes its history.
+	if _, err := s.DeleteTask(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindTaskStats(ctx, id); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected ErrTaskNotFound for deleted task, got %v", err)
+	}
+}
+
 func testStoreDeleteUser(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	s := create(t)
 	defer destroy(t, s)
@@ -1058,3 +1923,348 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 	return ids
 }
+
+func testStoreAuditLog(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	ctx := context.Background()
+	const org, otherOrg = platform.ID(1), platform.ID(2)
+	start := time.Unix(1000, 0).UTC()
+
+	var exp []backend.AuditEntry
+	for i, action := range []backend.AuditAction{backend.AuditCreate, backend.AuditModify, backend.AuditDisable, backend.AuditEnable, backend.AuditDelete} {
+		e := backend.AuditEntry{
+			TaskID:         platform.ID(10 + i%2),
+			Org:            org,
+			Action:         action,
+			AuthorizerID:   3,
+			AuthorizerKind: "authorization",
+			UserID:         4,
+			OldScriptHash:  backend.ScriptHash("old"),
+			NewScriptHash:  backend.ScriptHash("new"),
+			Time:           start.Add(time.Duration(i) * time.Second),
+		}
+		if err := s.AppendAuditEntry(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		exp = append(exp, e)
+	}
+	if err := s.AppendAuditEntry(ctx, backend.AuditEntry{TaskID: 12, Org: otherOrg, Action: backend.AuditCreate, Time: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AppendAuditEntry(ctx, backend.AuditEntry{Org: org, Action: backend.AuditCreate}); err == nil {
+		t.Fatal("expected error appending entry without task ID")
+	}
+
+	check := func(t *testing.T, params backend.AuditSearchParams, exp []backend.AuditEntry) {
+		t.Helper()
+		entries, err := s.ListAuditEntries(ctx, org, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != len(exp) {
+			t.Fatalf("expected %d entries, got %d: %#v", len(exp), len(entries), entries)
+		}
+		for i := range exp {
+			if !entries[i].Time.Equal(exp[i].Time) {
+				t.Fatalf("entry %d: expected time %v, got %v", i, exp[i].Time, entries[i].Time)
+			}
+			entries[i].Time = exp[i].Time
+			if entries[i] != exp[i] {
+				t.Fatalf("entry %d: expected %#v, got %#v", i, exp[i], entries[i])
+			}
+		}
+	}
+
+	t.Run("all", func(t *testing.T) {
+		check(t, backend.AuditSearchParams{}, exp)
+	})
+	t.Run("by task", func(t *testing.T) {
+		check(t, backend.AuditSearchParams{TaskID: 11}, []backend.AuditEntry{exp[1], exp[3]})
+	})
+	t.Run("after and limit", func(t *testing.T) {
+		check(t, backend.AuditSearchParams{After: exp[1].Time, Limit: 2}, exp[2:4])
+	})
+	t.Run("other org", func(t *testing.T) {
+		entries, err := s.ListAuditEntries(ctx, otherOrg, backend.AuditSearchParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].TaskID != 12 {
+			t.Fatalf("expected only the other org's entry, got %#v", entries)
+		}
+	})
+	t.Run("unknown org", func(t *testing.T) {
+		entries, err := s.ListAuditEntries(ctx, 99, backend.AuditSearchParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected no entries, got %#v", entries)
+		}
+	})
+}
+
+func testStoreTemplates(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	ts, ok := s.(backend.TemplateStore)
+	if !ok {
+		t.Skip("store does not implement backend.TemplateStore")
+	}
+
+	ctx := context.Background()
+	const org, otherOrg = platform.ID(1), platform.ID(2)
+	tmpl := backend.TaskTemplate{
+		Org:  org,
+		Name: "downsample",
+		Script: `option task = {name: "downsample ${bucket}", every: ${every}}
+from(bucket:"${bucket}") |> range(start:-1h)`,
+		Params: []backend.TemplateParam{{Name: "bucket"}, {Name: "every", Default: "1h"}},
+	}
+
+	if _, err := ts.CreateTemplate(ctx, backend.TaskTemplate{Org: org, Name: "bad", Script: "${undeclared}"}); err == nil {
+		t.Fatal("expected error creating template with undeclared parameter")
+	}
+
+	id, err := ts.CreateTemplate(ctx, tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.CreateTemplate(ctx, backend.TaskTemplate{Org: otherOrg, Name: "other", Script: "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl.ID = id
+	tmpl.Version = 1
+	found, err := ts.FindTemplateByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*found, tmpl) {
+		t.Fatalf("expected template %#v, got %#v", tmpl, *found)
+	}
+	if _, err := ts.FindTemplateByID(ctx, platform.ID(9999)); err != backend.ErrTemplateNotFound {
+		t.Fatalf("expected %v finding missing template, got %v", backend.ErrTemplateNotFound, err)
+	}
+
+	list, err := ts.ListTemplates(ctx, org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != id {
+		t.Fatalf("expected only template %s in org, got %#v", id, list)
+	}
+
+	upd := tmpl
+	upd.Org = otherOrg
+	upd.Script = `option task = {name: "downsample ${bucket}", every: ${every}}
+from(bucket:"${bucket}") |> range(start:-2h)`
+	updated, err := ts.UpdateTemplate(ctx, upd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 2 || updated.Org != org || updated.Script != upd.Script {
+		t.Fatalf("unexpected updated template %#v", updated)
+	}
+	if _, err := ts.UpdateTemplate(ctx, backend.TaskTemplate{ID: 9999, Name: "x", Script: "x"}); err != backend.ErrTemplateNotFound {
+		t.Fatalf("expected %v updating missing template, got %v", backend.ErrTemplateNotFound, err)
+	}
+
+	insts := []backend.TemplateInstance{
+		{TaskID: 10, TemplateID: id, Version: 1, Params: map[string]string{"bucket": "a"}},
+		{TaskID: 11, TemplateID: id, Version: 1, Params: map[string]string{"bucket": "b", "every": "5m"}},
+	}
+	for _, inst := range insts {
+		if err := ts.PutTemplateInstance(ctx, inst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	insts[0].Version = 2
+	if err := ts.PutTemplateInstance(ctx, insts[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.PutTemplateInstance(ctx, backend.TemplateInstance{TaskID: 12, TemplateID: 9999, Version: 1}); err != backend.ErrTemplateNotFound {
+		t.Fatalf("expected %v recording instance of missing template, got %v", backend.ErrTemplateNotFound, err)
+	}
+
+	gotInsts, err := ts.ListTemplateInstances(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotInsts, insts) {
+		t.Fatalf("expected instances %#v, got %#v", insts, gotInsts)
+	}
+
+	deleted, err := ts.DeleteTemplate(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleted {
+		t.Fatal("expected template to be deleted")
+	}
+	if deleted, err := ts.DeleteTemplate(ctx, id); err != nil || deleted {
+		t.Fatalf("expected deleting template again to report false, got %v, %v", deleted, err)
+	}
+	if _, err := ts.FindTemplateByID(ctx, id); err != backend.ErrTemplateNotFound {
+		t.Fatalf("expected %v after delete, got %v", backend.ErrTemplateNotFound, err)
+	}
+	gotInsts, err = ts.ListTemplateInstances(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotInsts) != 0 {
+		t.Fatalf("expected no instances after delete, got %#v", gotInsts)
+	}
+}
+
+func testStoreImportTask(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	ti, ok := s.(backend.TaskImporter)
+	if !ok {
+		t.Skip("store does not implement backend.TaskImporter")
+	}
+
+	ctx := context.Background()
+	const script = `option task = {name: "imported", every: 1m} from(bucket:"b") |> range(start:-1h)`
+	task := backend.StoreTask{
+		ID:     platform.ID(0x1000),
+		Org:    platform.ID(1),
+		User:   platform.ID(2),
+		Name:   "imported",
+		Script: script,
+		Labels: map[string]string{"env": "prod"},
+	}
+	meta := backend.StoreTaskMeta{
+		MaxConcurrency:  3,
+		Status:          string(backend.TaskInactive),
+		LatestCompleted: 1200,
+		EffectiveCron:   "@every 1m0s",
+		DisabledReason:  "paused",
+	}
+	versions := []backend.TaskVersion{
+		{Version: 4, Script: "old script", CreatedAt: 100},
+		{Version: 5, Script: script, CreatedAt: 200},
+	}
+
+	if err := ti.ImportTask(ctx, backend.StoreTask{ID: task.ID, Script: script}, meta, versions); err == nil {
+		t.Fatal("expected error importing task without owners")
+	}
+
+	if err := ti.ImportTask(ctx, task, meta, versions); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(want backend.StoreTask) {
+		t.Helper()
+		got, gotMeta, err := s.FindTaskByIDWithMeta(ctx, want.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*got, want) {
+			t.Fatalf("expected imported task %#v, got %#v", want, *got)
+		}
+		if !gotMeta.Equal(meta) || gotMeta.DisabledReason != meta.DisabledReason {
+			t.Fatalf("expected imported meta %#v, got %#v", meta, *gotMeta)
+		}
+		gotVersions, err := s.ListTaskVersions(ctx, want.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(gotVersions, versions) {
+			t.Fatalf("expected imported versions %#v, got %#v", versions, gotVersions)
+		}
+
+		tasks, err := s.ListTasks(ctx, backend.TaskSearchParams{Org: want.Org})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 || tasks[0].Task.ID != want.ID {
+			t.Fatalf("expected imported task to be listed in its org, got %#v", tasks)
+		}
+	}
+	check(task)
+
+	// Importing again replaces the task, including its owners.
+	task.Org, task.User = platform.ID(3), platform.ID(4)
+	task.Labels = nil
+	if err := ti.ImportTask(ctx, task, meta, versions); err != nil {
+		t.Fatal(err)
+	}
+	check(task)
+	if tasks, err := s.ListTasks(ctx, backend.TaskSearchParams{Org: platform.ID(1)}); err != nil {
+		t.Fatal(err)
+	} else if len(tasks) != 0 {
+		t.Fatalf("expected no tasks left in the previous org, got %#v", tasks)
+	}
+}
+
+func testStoreWatchTasks(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	s := create(t)
+	defer destroy(t, s)
+
+	w, ok := s.(backend.TaskWatcher)
+	if !ok {
+		t.Skip("store does not implement backend.TaskWatcher")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := w.WatchTasks(ctx)
+
+	next := func(t *testing.T) backend.TaskChange {
+		t.Helper()
+		select {
+		case c := <-changes:
+			return c
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for task change")
+		}
+		return backend.TaskChange{}
+	}
+
+	const script = `option task = {name: "a task", every: 1m} from(bucket:"test") |> range(start:-1h)`
+	id, err := s.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c != (backend.TaskChange{TaskID: id, Created: true}) {
+		t.Fatalf("expected creation of task %s, got %+v", id, c)
+	}
+
+	if _, err := s.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c != (backend.TaskChange{TaskID: id}) {
+		t.Fatalf("expected update of task %s, got %+v", id, c)
+	}
+
+	if _, err := s.DeleteTask(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c != (backend.TaskChange{TaskID: id, Deleted: true}) {
+		t.Fatalf("expected deletion of task %s, got %+v", id, c)
+	}
+
+	// Each task deleted along with its org is reported.
+	id, err = s.CreateTask(ctx, backend.CreateTaskRequest{Org: 3, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next(t)
+	if err := s.DeleteOrg(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if c := next(t); c != (backend.TaskChange{TaskID: id, Deleted: true}) {
+		t.Fatalf("expected deletion of task %s with its org, got %+v", id, c)
+	}
+
+	cancel()
+	for range changes {
+	}
+}
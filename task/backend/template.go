@@ -0,0 +1,135 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/influxdata/platform"
+)
+
+// ErrTemplateNotFound is returned when a task template with the requested ID does not exist.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// TaskTemplate is a Flux script with declared parameters, from which concrete tasks are instantiated.
+type TaskTemplate struct {
+	ID, Org platform.ID
+
+	Name string
+
+	// Script is the Flux script of the template.
+	// Each parameter is referenced in the script as ${name}, and is replaced by its value, as is, when the template is rendered.
+	Script string
+
+	Params []TemplateParam
+
+	// Version is 1 when the template is created, and is incremented each time the template is updated.
+	Version int
+}
+
+// TemplateParam declares a parameter of a TaskTemplate.
+type TemplateParam struct {
+	Name string
+
+	// Default is the value of the parameter when it is not given a value.
+	// A parameter is required if Default is empty.
+	Default string
+}
+
+// TemplateInstance binds a task to the template and version it was rendered from.
+type TemplateInstance struct {
+	TaskID, TemplateID platform.ID
+
+	// Version of the template the task's script was last rendered from.
+	Version int
+
+	// Params are the parameter values the task was instantiated with.
+	Params map[string]string
+}
+
+var (
+	templateParamName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	templateParamRef  = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// Validate returns an error if t is missing its org, name, or script,
+// or if its parameters are not declared exactly once each.
+func (t TaskTemplate) Validate() error {
+	if !t.Org.Valid() || t.Name == "" || t.Script == "" {
+		return errors.New("template requires org ID, name, and script")
+	}
+
+	declared := make(map[string]bool, len(t.Params))
+	for _, p := range t.Params {
+		if !templateParamName.MatchString(p.Name) {
+			return fmt.Errorf("invalid template parameter name %q", p.Name)
+		}
+		if declared[p.Name] {
+			return fmt.Errorf("template parameter %q declared more than once", p.Name)
+		}
+		declared[p.Name] = true
+	}
+
+	for _, m := range templateParamRef.FindAllStringSubmatch(t.Script, -1) {
+		if !declared[m[1]] {
+			return fmt.Errorf("template script references undeclared parameter %q", m[1])
+		}
+	}
+	return nil
+}
+
+// Render returns the script of t with its parameters replaced by values, or their defaults.
+// It returns an error if a required parameter has no value, or if values has a parameter t does not declare.
+func (t TaskTemplate) Render(values map[string]string) (string, error) {
+	resolved := make(map[string]string, len(t.Params))
+	for _, p := range t.Params {
+		v, ok := values[p.Name]
+		if !ok || v == "" {
+			v = p.Default
+		}
+		if v == "" {
+			return "", fmt.Errorf("template parameter %q requires a value", p.Name)
+		}
+		resolved[p.Name] = v
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return "", fmt.Errorf("template has no parameter %q", name)
+		}
+	}
+
+	return templateParamRef.ReplaceAllStringFunc(t.Script, func(ref string) string {
+		return resolved[templateParamRef.FindStringSubmatch(ref)[1]]
+	}), nil
+}
+
+// TemplateStore is implemented by stores that can hold task templates, and which tasks were instantiated from them.
+type TemplateStore interface {
+	// CreateTemplate validates and stores t, with version 1, and returns its new ID.
+	CreateTemplate(ctx context.Context, t TaskTemplate) (platform.ID, error)
+
+	// UpdateTemplate replaces the name, script, and parameters of the template with t.ID,
+	// increments its version, and returns the updated template.
+	// The template's org cannot be changed.
+	UpdateTemplate(ctx context.Context, t TaskTemplate) (TaskTemplate, error)
+
+	// FindTemplateByID returns the template with the given ID, or ErrTemplateNotFound.
+	FindTemplateByID(ctx context.Context, id platform.ID) (*TaskTemplate, error)
+
+	// ListTemplates returns the templates of an org, ordered by ID.
+	ListTemplates(ctx context.Context, orgID platform.ID) ([]TaskTemplate, error)
+
+	// DeleteTemplate deletes the template with the given ID, and the records of its instances.
+	// Tasks instantiated from the template are not deleted.
+	// It returns false if no template had the ID.
+	DeleteTemplate(ctx context.Context, id platform.ID) (deleted bool, err error)
+
+	// PutTemplateInstance records that a task was instantiated from, or re-rendered from, a template.
+	// It replaces any previous record for the task.
+	PutTemplateInstance(ctx context.Context, inst TemplateInstance) error
+
+	// ListTemplateInstances returns the records of the tasks instantiated from a template, ordered by task ID.
+	// Records are kept after their task is deleted.
+	ListTemplateInstances(ctx context.Context, templateID platform.ID) ([]TemplateInstance, error)
+}
@@ -0,0 +1,12 @@
+package backend
+
+import (
+	"github.com/influxdata/platform/kit/tracing"
+	"github.com/opentracing/opentracing-go"
+)
+
+// TraceID returns the ID of the trace that sp belongs to, or the empty string if sp's tracer does not report trace IDs.
+// See tracing.TraceID.
+func TraceID(sp opentracing.Span) string {
+	return tracing.TraceID(sp)
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"context"
+	"sync"
+)
+
+// TaskChangeFeed delivers published TaskChanges to every watcher,
+// for stores whose tasks are only changed through a single process.
+// Publishing never blocks: changes are queued for each watcher until it receives them.
+// The zero value is ready to use.
+type TaskChangeFeed struct {
+	mu       sync.Mutex
+	watchers map[*feedWatcher]struct{}
+}
+
+// feedWatcher holds the changes published to a single watcher and not yet received.
+type feedWatcher struct {
+	mu      sync.Mutex
+	pending []TaskChange
+
+	// Signaled when pending becomes non-empty.
+	ready chan struct{}
+}
+
+// WatchTasks returns a channel that receives each change published to f after the call, in order.
+// The channel is closed when ctx is done.
+func (f *TaskChangeFeed) WatchTasks(ctx context.Context) <-chan TaskChange {
+	w := &feedWatcher{ready: make(chan struct{}, 1)}
+
+	f.mu.Lock()
+	if f.watchers == nil {
+		f.watchers = make(map[*feedWatcher]struct{})
+	}
+	f.watchers[w] = struct{}{}
+	f.mu.Unlock()
+
+	changes := make(chan TaskChange)
+	go func() {
+		defer close(changes)
+		defer func() {
+			f.mu.Lock()
+			delete(f.watchers, w)
+			f.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-w.ready:
+			case <-ctx.Done():
+				return
+			}
+
+			w.mu.Lock()
+			pending := w.pending
+			w.pending = nil
+			w.mu.Unlock()
+
+			for _, c := range pending {
+				select {
+				case changes <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// Publish queues change for every current watcher of f.
+func (f *TaskChangeFeed) Publish(change TaskChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for w := range f.watchers {
+		w.mu.Lock()
+		w.pending = append(w.pending, change)
+		w.mu.Unlock()
+
+		select {
+		case w.ready <- struct{}{}:
+		default:
+			// The watcher has already been signaled, and will find change when it next drains pending.
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -59,6 +60,16 @@ func (s *Scheduler) Start(context.Context) {}
 
 func (s *Scheduler) Stop() {}
 
+// Drain releases every claimed task.
+func (s *Scheduler) Drain(context.Context) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.claims = map[string]*Task{}
+	s.meta = map[string]backend.StoreTaskMeta{}
+	return nil
+}
+
 func (s *Scheduler) ClaimTask(task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
 	if s.claimError != nil {
 		return s.claimError
@@ -128,6 +139,21 @@ func (s *Scheduler) ReleaseTask(taskID platform.ID) error {
 	return nil
 }
 
+func (s *Scheduler) ClaimedTasks() []platform.ID {
+	s.Lock()
+	defer s.Unlock()
+
+	ids := make([]platform.ID, 0, len(s.claims))
+	for id := range s.claims {
+		var tid platform.ID
+		if err := tid.DecodeFromString(id); err != nil {
+			panic(err)
+		}
+		ids = append(ids, tid)
+	}
+	return ids
+}
+
 func (s *Scheduler) TaskFor(id platform.ID) *Task {
 	s.Lock()
 	defer s.Unlock()
@@ -172,15 +198,19 @@ type DesiredState struct {
 
 	// Map of stringified task ID to task meta.
 	meta map[string]backend.StoreTaskMeta
+
+	// Map of stringified task ID to recorded run outcomes.
+	outcomes map[string][]backend.RunOutcome
 }
 
 var _ backend.DesiredState = (*DesiredState)(nil)
 
 func NewDesiredState() *DesiredState {
 	return &DesiredState{
-		runIDs:  make(map[string]uint64),
-		created: make(map[string]backend.QueuedRun),
-		meta:    make(map[string]backend.StoreTaskMeta),
+		runIDs:   make(map[string]uint64),
+		created:  make(map[string]backend.QueuedRun),
+		meta:     make(map[string]backend.StoreTaskMeta),
+		outcomes: make(map[string][]backend.RunOutcome),
 	}
 }
 
@@ -228,6 +258,11 @@ func (d *DesiredState) FinishRun(_ context.Context, taskID, runID platform.ID) e
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	return d.finishRun(taskID, runID)
+}
+
+// finishRun finishes the run with the given ID. The caller must hold d.mu.
+func (d *DesiredState) finishRun(taskID, runID platform.ID) error {
 	tid := taskID.String()
 	rid := runID.String()
 	m := d.meta[tid]
@@ -243,6 +278,47 @@ func (d *DesiredState) FinishRun(_ context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+func (d *DesiredState) RecordRunOutcome(_ context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tid := taskID.String()
+	d.outcomes[tid] = append(d.outcomes[tid], o)
+	return nil
+}
+
+func (d *DesiredState) CompleteRun(_ context.Context, taskID platform.ID, o backend.RunOutcome) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.finishRun(taskID, o.RunID); err != nil {
+		return err
+	}
+	tid := taskID.String()
+	d.outcomes[tid] = append(d.outcomes[tid], o)
+	return nil
+}
+
+func (d *DesiredState) RunSucceeded(_ context.Context, taskID platform.ID, now int64) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, o := range d.outcomes[taskID.String()] {
+		if o.Status == backend.RunSuccess && o.ScheduledFor == now {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// OutcomesFor returns the run outcomes recorded for the given task, oldest first.
+func (d *DesiredState) OutcomesFor(taskID platform.ID) []backend.RunOutcome {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]backend.RunOutcome(nil), d.outcomes[taskID.String()]...)
+}
+
 func (d *DesiredState) CreatedFor(taskID platform.ID) []backend.QueuedRun {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -280,6 +356,9 @@ type Executor struct {
 	mu         sync.Mutex
 	hangingFor time.Duration
 
+	// Number of runs Load reports that e can execute at once, or 0 if unlimited.
+	slots int
+
 	// Map of stringified, concatenated task and run ID, to runs that have begun execution but have not finished.
 	running map[string]*RunPromise
 
@@ -289,7 +368,10 @@ type Executor struct {
 	wg sync.WaitGroup
 }
 
-var _ backend.Executor = (*Executor)(nil)
+var (
+	_ backend.Executor     = (*Executor)(nil)
+	_ backend.LoadReporter = (*Executor)(nil)
+)
 
 func NewExecutor() *Executor {
 	return &Executor{
@@ -325,6 +407,30 @@ func (e *Executor) WithHanging(dt time.Duration) {
 	e.hangingFor = dt
 }
 
+// WithSlots sets the number of runs e reports, through Load, that it can execute at once.
+// Runs beyond that number still execute, but Load reports them as queued.
+func (e *Executor) WithSlots(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.slots = n
+}
+
+// Load reports the free slots and queued runs of e, according to the number of its unfinished runs.
+// Unless WithSlots was called, e is never saturated.
+func (e *Executor) Load() backend.ExecutorLoad {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.slots == 0 {
+		return backend.ExecutorLoad{Available: math.MaxInt32}
+	}
+	free := e.slots - len(e.running)
+	if free < 0 {
+		return backend.ExecutorLoad{Queued: -free}
+	}
+	return backend.ExecutorLoad{Available: free}
+}
+
 // RunningFor returns the run promises for the given task.
 func (e *Executor) RunningFor(taskID platform.ID) []*RunPromise {
 	e.mu.Lock()
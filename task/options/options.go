@@ -4,13 +4,16 @@ package options
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/semantic"
-	cron "gopkg.in/robfig/cron.v2"
 )
 
 // optionCache is enabled for tests, to work around https://github.com/influxdata/platform/issues/484.
@@ -25,9 +28,36 @@ func EnableScriptCacheForTest() {
 	optionCache = make(map[string]Options)
 }
 
-const maxConcurrency = 100
+// MaxConcurrency is the largest allowed value of the concurrency option.
+const MaxConcurrency = 100
+
 const maxRetry = 10
 
+// MaxRetainRuns is the largest allowed value of the retainRuns option.
+const MaxRetainRuns = 100000
+
+// Values for the blackoutPolicy option.
+const (
+	// BlackoutSkip drops runs scheduled during a blackout window; they are recorded as skipped.
+	BlackoutSkip = "skip"
+
+	// BlackoutDefer holds runs scheduled during a blackout window until the window ends.
+	BlackoutDefer = "defer"
+)
+
+// Values for the overlap option.
+const (
+	// OverlapQueue holds a scheduled run that comes due while a previous scheduled run is executing,
+	// starting it once a run slot is free. It is the default.
+	OverlapQueue = "queue"
+
+	// OverlapSkip drops a scheduled run that comes due while a previous scheduled run is executing; it is recorded as skipped.
+	OverlapSkip = "skip"
+
+	// OverlapCancel cancels the previous scheduled runs still executing when a scheduled run comes due, and starts the new run.
+	OverlapCancel = "cancel"
+)
+
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
 	// Name is a non optional name designator for each task.
@@ -36,15 +66,72 @@ type Options struct {
 	// Cron is a cron style time schedule that can be used in place of Every.
 	Cron string
 
+	// Timezone is the name of the IANA timezone, such as America/New_York, whose wall-clock time Cron follows,
+	// across daylight saving transitions. An empty Timezone means the server's local time.
+	Timezone string
+
 	// Every represents a fixed period to repeat execution.
 	Every time.Duration
 
+	// Once is true for a one-shot task, which runs a single time instead of repeating on a schedule.
+	// It is set by the at option, or by the once option for a task that runs as soon as it is created.
+	Once bool
+
+	// At is the time of the only run of a one-shot task. It is zero if the task runs as soon as it is created.
+	At time.Time
+
 	// Offset represents a delay before execution.
 	Offset time.Duration
 
 	Concurrency int64
 
 	Retry int64
+
+	// Timeout is the maximum duration of a single run.
+	// A run that exceeds Timeout is canceled and recorded as timed out.
+	// A zero Timeout means runs may take as long as they need.
+	Timeout time.Duration
+
+	// Jitter is the width of the window over which the start of each run may be delayed,
+	// so that tasks sharing a schedule don't all start at the same instant.
+	// The time a run is scheduled for is not affected.
+	// A zero Jitter defers to the scheduler's default.
+	Jitter time.Duration
+
+	// SLA is how long after the time a run is scheduled for it is expected to have finished,
+	// such as 30m for an hourly task that must be done half an hour after each hour.
+	// The scheduler reports runs that are still running past that deadline, and runs that finished late.
+	// A zero SLA means runs have no deadline.
+	SLA time.Duration
+
+	// Blackout is a cron expression for the start of each window during which runs are not executed,
+	// such as a maintenance window. Each window lasts for BlackoutDuration.
+	Blackout string
+
+	// BlackoutDuration is the length of each blackout window.
+	BlackoutDuration time.Duration
+
+	// BlackoutPolicy determines what happens to runs scheduled during a blackout window:
+	// either BlackoutSkip or BlackoutDefer.
+	BlackoutPolicy string
+
+	// Overlap determines what happens when a scheduled run comes due while a previous scheduled run is still executing:
+	// either OverlapQueue, OverlapSkip, or OverlapCancel. Empty means OverlapQueue.
+	// Manually requested runs are always queued, and never canceled by a scheduled run.
+	Overlap string
+
+	// RetainRuns is how many of the task's latest finished runs are retained,
+	// in place of the server's limits on the age and number of runs retained for each task.
+	// Zero means the server's limits apply.
+	RetainRuns int64
+
+	// Notify is where to send a notification when a run fails:
+	// either an http or https webhook URL on a public address, or the ID of a notification endpoint configured on the server.
+	Notify string
+
+	// Secrets are the names of the secrets the script references through SecretsIdentifier, in sorted order.
+	// They are not an option in the task record; the executor loads them for the task's organization when a run executes.
+	Secrets []string
 }
 
 // FromScript extracts Options from a Flux script.
@@ -59,9 +146,17 @@ func FromScript(script string) (Options, error) {
 		}
 	}
 
-	opt := Options{Retry: 1, Concurrency: 1}
+	opt := Options{Retry: 1, Concurrency: 1, Secrets: secretNames(script)}
 
 	inter := flux.NewInterpreter()
+	if len(opt.Secrets) > 0 {
+		// The values are only known when a run executes, but the script must evaluate without them.
+		placeholders := make(map[string]string, len(opt.Secrets))
+		for _, name := range opt.Secrets {
+			placeholders[name] = ""
+		}
+		BindSecrets(inter, placeholders)
+	}
 	if err := flux.Eval(inter, script); err != nil {
 		return opt, err
 	}
@@ -88,8 +183,25 @@ func FromScript(script string) (Options, error) {
 	if cronOK && everyOK {
 		return opt, errors.New("cannot use both cron and every in task options")
 	}
-	if !cronOK && !everyOK {
-		return opt, errors.New("cron or every is required")
+
+	if atVal, ok := optObject.Get("at"); ok {
+		if err := checkNature(atVal.PolyType().Nature(), semantic.Time); err != nil {
+			return opt, err
+		}
+		opt.At = atVal.Time().Time()
+		opt.Once = true
+	}
+
+	if onceVal, ok := optObject.Get("once"); ok {
+		if err := checkNature(onceVal.PolyType().Nature(), semantic.Bool); err != nil {
+			return opt, err
+		}
+		// Validate rejects once: false along with at.
+		opt.Once = onceVal.Bool()
+	}
+
+	if !cronOK && !everyOK && !opt.Once {
+		return opt, errors.New("cron, every, at, or once is required")
 	}
 
 	if cronOK {
@@ -99,6 +211,13 @@ func FromScript(script string) (Options, error) {
 		opt.Cron = crVal.Str()
 	}
 
+	if tzVal, ok := optObject.Get("timezone"); ok {
+		if err := checkNature(tzVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Timezone = tzVal.Str()
+	}
+
 	if everyOK {
 		if err := checkNature(everyVal.PolyType().Nature(), semantic.Duration); err != nil {
 			return opt, err
@@ -127,6 +246,70 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = retryVal.Int()
 	}
 
+	if timeoutVal, ok := optObject.Get("timeout"); ok {
+		if err := checkNature(timeoutVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		opt.Timeout = timeoutVal.Duration().Duration()
+	}
+
+	if jitterVal, ok := optObject.Get("jitter"); ok {
+		if err := checkNature(jitterVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		opt.Jitter = jitterVal.Duration().Duration()
+	}
+
+	if slaVal, ok := optObject.Get("sla"); ok {
+		if err := checkNature(slaVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		opt.SLA = slaVal.Duration().Duration()
+	}
+
+	if blackoutVal, ok := optObject.Get("blackout"); ok {
+		if err := checkNature(blackoutVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Blackout = blackoutVal.Str()
+		opt.BlackoutPolicy = BlackoutSkip
+	}
+
+	if durVal, ok := optObject.Get("blackoutDuration"); ok {
+		if err := checkNature(durVal.PolyType().Nature(), semantic.Duration); err != nil {
+			return opt, err
+		}
+		opt.BlackoutDuration = durVal.Duration().Duration()
+	}
+
+	if policyVal, ok := optObject.Get("blackoutPolicy"); ok {
+		if err := checkNature(policyVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.BlackoutPolicy = policyVal.Str()
+	}
+
+	if overlapVal, ok := optObject.Get("overlap"); ok {
+		if err := checkNature(overlapVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Overlap = overlapVal.Str()
+	}
+
+	if retainVal, ok := optObject.Get("retainRuns"); ok {
+		if err := checkNature(retainVal.PolyType().Nature(), semantic.Int); err != nil {
+			return opt, err
+		}
+		opt.RetainRuns = retainVal.Int()
+	}
+
+	if notifyVal, ok := optObject.Get("notify"); ok {
+		if err := checkNature(notifyVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Notify = notifyVal.Str()
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -149,12 +332,20 @@ func (o *Options) Validate() error {
 
 	cronPresent := o.Cron != ""
 	everyPresent := o.Every != 0
-	if cronPresent == everyPresent {
+	if o.Once {
+		if cronPresent || everyPresent {
+			errs = append(errs, "a one-shot task cannot also use cron or every")
+		}
+		if o.Offset != 0 {
+			errs = append(errs, "a one-shot task cannot use offset")
+		}
+	} else if !o.At.IsZero() {
+		errs = append(errs, "at requires a one-shot task")
+	} else if cronPresent == everyPresent {
 		// They're both present or both missing.
 		errs = append(errs, "must specify exactly one of either cron or every")
 	} else if cronPresent {
-		_, err := cron.Parse(o.Cron)
-		if err != nil {
+		if _, err := ParseCron(o.Cron); err != nil {
 			errs = append(errs, "cron invalid: "+err.Error())
 		}
 	} else if everyPresent {
@@ -165,6 +356,17 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	if o.Timezone != "" {
+		if !cronPresent {
+			errs = append(errs, "timezone option requires cron")
+		} else if strings.HasPrefix(o.Cron, timezonePrefix) {
+			errs = append(errs, "cannot use both the timezone option and a timezone in cron")
+		}
+		if _, err := time.LoadLocation(o.Timezone); err != nil {
+			errs = append(errs, "timezone invalid: "+err.Error())
+		}
+	}
+
 	if o.Offset.Truncate(time.Second) != o.Offset {
 		// For now, allowing negative offset delays. Maybe they're useful for forecasting?
 		errs = append(errs, "offset option must be expressible as whole seconds")
@@ -172,8 +374,8 @@ func (o *Options) Validate() error {
 
 	if o.Concurrency < 1 {
 		errs = append(errs, "concurrency must be at least 1")
-	} else if o.Concurrency > maxConcurrency {
-		errs = append(errs, fmt.Sprintf("concurrency exceeded max of %d", maxConcurrency))
+	} else if o.Concurrency > MaxConcurrency {
+		errs = append(errs, fmt.Sprintf("concurrency exceeded max of %d", MaxConcurrency))
 	}
 
 	if o.Retry < 1 {
@@ -182,6 +384,56 @@ func (o *Options) Validate() error {
 		errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 	}
 
+	if o.Timeout < 0 {
+		errs = append(errs, "timeout option must not be negative")
+	} else if o.Timeout.Truncate(time.Second) != o.Timeout {
+		errs = append(errs, "timeout option must be expressible as whole seconds")
+	}
+
+	if o.Jitter < 0 {
+		errs = append(errs, "jitter option must not be negative")
+	} else if o.Jitter.Truncate(time.Second) != o.Jitter {
+		errs = append(errs, "jitter option must be expressible as whole seconds")
+	}
+
+	if o.SLA < 0 {
+		errs = append(errs, "sla option must not be negative")
+	} else if o.SLA.Truncate(time.Second) != o.SLA {
+		errs = append(errs, "sla option must be expressible as whole seconds")
+	}
+
+	if o.Blackout != "" {
+		if _, err := ParseCron(o.Blackout); err != nil {
+			errs = append(errs, "blackout invalid: "+err.Error())
+		}
+		if o.BlackoutDuration < time.Second {
+			errs = append(errs, "blackoutDuration option must be at least 1 second")
+		} else if o.BlackoutDuration.Truncate(time.Second) != o.BlackoutDuration {
+			errs = append(errs, "blackoutDuration option must be expressible as whole seconds")
+		}
+		if o.BlackoutPolicy != BlackoutSkip && o.BlackoutPolicy != BlackoutDefer {
+			errs = append(errs, fmt.Sprintf("blackoutPolicy must be %q or %q", BlackoutSkip, BlackoutDefer))
+		}
+	} else if o.BlackoutDuration != 0 || o.BlackoutPolicy != "" {
+		errs = append(errs, "blackoutDuration and blackoutPolicy require blackout")
+	}
+
+	switch o.Overlap {
+	case "", OverlapQueue, OverlapSkip, OverlapCancel:
+	default:
+		errs = append(errs, fmt.Sprintf("overlap must be %q, %q, or %q", OverlapQueue, OverlapSkip, OverlapCancel))
+	}
+
+	if o.RetainRuns < 0 {
+		errs = append(errs, "retainRuns must not be negative")
+	} else if o.RetainRuns > MaxRetainRuns {
+		errs = append(errs, fmt.Sprintf("retainRuns exceeded max of %d", MaxRetainRuns))
+	}
+
+	if err := ValidateNotifyTarget(o.Notify); err != nil {
+		errs = append(errs, "notify option "+err.Error())
+	}
+
 	if len(errs) == 0 {
 		return nil
 	}
@@ -189,13 +441,33 @@ func (o *Options) Validate() error {
 	return fmt.Errorf("invalid options: %s", strings.Join(errs, ", "))
 }
 
-// EffectiveCronString returns the effective cron string of the options.
-// If the cron option was specified, it is returned.
+// ValidateNotifyTarget returns an error if target, set by a task's author as where to send notifications about its runs,
+// is neither an http or https URL on a public address nor a notification endpoint ID.
+// An empty target is valid.
+func ValidateNotifyTarget(target string) error {
+	if strings.Contains(target, "://") {
+		if u, err := url.Parse(target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("must be an http or https URL, or a notification endpoint ID")
+		} else if host := u.Hostname(); strings.EqualFold(host, "localhost") || (net.ParseIP(host) != nil && !PublicIP(net.ParseIP(host))) {
+			return errors.New("must not be a URL on a loopback, private, or link-local address; use a notification endpoint ID")
+		}
+	} else if strings.TrimSpace(target) != target {
+		return errors.New("must not have leading or trailing spaces")
+	}
+	return nil
+}
+
+// EffectiveCronString returns the effective cron string of the options, which ParseCron parses.
+// If the cron option was specified, it is returned, prefixed with the timezone option if that was specified,
+// as in "TZ=America/New_York 0 9 * * *".
 // If the every option was specified, it is converted into a cron string using "@every".
-// Otherwise, the empty string is returned.
+// Otherwise, such as for a one-shot task, the empty string is returned.
 // The value of the offset option is not considered.
 func (o *Options) EffectiveCronString() string {
 	if o.Cron != "" {
+		if o.Timezone != "" {
+			return timezonePrefix + o.Timezone + " " + o.Cron
+		}
 		return o.Cron
 	}
 	if o.Every > 0 {
@@ -204,6 +476,107 @@ func (o *Options) EffectiveCronString() string {
 	return ""
 }
 
+// ScheduleEqual reports whether o and other schedule runs the same way:
+// whether they have the same schedule, offset, concurrency, jitter, SLA, blackout windows, and overlap policy.
+// Options that are only read when a run executes, such as Name, Timeout, and Notify, are not compared.
+func (o *Options) ScheduleEqual(other Options) bool {
+	return o.EffectiveCronString() == other.EffectiveCronString() &&
+		o.Once == other.Once &&
+		o.At.Equal(other.At) &&
+		o.Offset == other.Offset &&
+		o.Concurrency == other.Concurrency &&
+		o.Jitter == other.Jitter &&
+		o.SLA == other.SLA &&
+		o.Blackout == other.Blackout &&
+		o.BlackoutDuration == other.BlackoutDuration &&
+		o.BlackoutPolicy == other.BlackoutPolicy &&
+		o.OverlapPolicy() == other.OverlapPolicy()
+}
+
+// OverlapPolicy returns the value of the overlap option, or OverlapQueue if it is not set.
+func (o *Options) OverlapPolicy() string {
+	if o.Overlap == "" {
+		return OverlapQueue
+	}
+	return o.Overlap
+}
+
+// intervalSamples is how many consecutive scheduled times Interval inspects for a cron schedule.
+const intervalSamples = 100
+
+// Interval returns the shortest time between consecutive scheduled runs of the task, as of now.
+// For the every option, that is its value.
+// For the cron option, it is the shortest gap among the next scheduled times after now.
+// If neither option is set, as for a one-shot task, or the cron option is invalid, Interval returns 0.
+func (o *Options) Interval(now time.Time) time.Duration {
+	if o.Every > 0 {
+		return o.Every
+	}
+	if o.Cron == "" {
+		return 0
+	}
+
+	sch, err := ParseCron(o.EffectiveCronString())
+	if err != nil {
+		return 0
+	}
+	var min time.Duration
+	prev := sch.Next(now)
+	for i := 0; i < intervalSamples; i++ {
+		next := sch.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if d := next.Sub(prev); min == 0 || d < min {
+			min = d
+		}
+		prev = next
+	}
+	return min
+}
+
+// InBlackout reports whether t falls within one of the blackout windows described by o,
+// and if so, returns the time when that window ends.
+// If o has no valid blackout, InBlackout returns false.
+func (o *Options) InBlackout(t time.Time) (end time.Time, ok bool) {
+	if o.Blackout == "" || o.BlackoutDuration <= 0 {
+		return time.Time{}, false
+	}
+
+	sch, err := ParseCron(o.Blackout)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// The only window that can contain t is the first one starting after t-BlackoutDuration.
+	start := sch.Next(t.Add(-o.BlackoutDuration))
+	if start.After(t) {
+		return time.Time{}, false
+	}
+	return start.Add(o.BlackoutDuration), true
+}
+
+// errPosition matches the line and column prefix of Flux parse and semantic errors,
+// such as "1:5 (4): ..." or "error @2:3-2:9: ...".
+var errPosition = regexp.MustCompile(`^(?:[^@:]*@)?(\d+):(\d+)`)
+
+// ErrorPosition returns the 1-based line and column in the script that err refers to.
+// If err does not carry a position, ok is false.
+func ErrorPosition(err error) (line, column int, ok bool) {
+	if err == nil {
+		return 0, 0, false
+	}
+
+	m := errPosition.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, 0, false
+	}
+
+	line, _ = strconv.Atoi(m[1])
+	column, _ = strconv.Atoi(m[2])
+	return line, column, true
+}
+
 // checkNature returns a clean error of got and expected dont match.
 func checkNature(got, exp semantic.Nature) error {
 	if got != exp {
@@ -211,3 +584,39 @@ func checkNature(got, exp semantic.Nature) error {
 	}
 	return nil
 }
+
+// nonPublicNets are the address ranges, besides loopback, link-local, multicast, and unspecified addresses,
+// that are not publicly routable.
+var nonPublicNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",      // "This" network.
+		"10.0.0.0/8",     // Private.
+		"100.64.0.0/10",  // Shared address space.
+		"172.16.0.0/12",  // Private.
+		"192.168.0.0/16", // Private.
+		"fc00::/7",       // Unique local.
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// PublicIP reports whether ip is a publicly routable address, to which a task's notify option may send webhooks.
+// Loopback, link-local, private, shared, unspecified, and multicast addresses are not public.
+func PublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package options
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// timezonePrefix begins a cron expression that is evaluated in a timezone, as in "TZ=America/New_York 0 9 * * *".
+const timezonePrefix = "TZ="
+
+// ParseCron returns the schedule described by the cron expression spec,
+// which may begin with a timezone, as in "TZ=America/New_York 0 9 * * *".
+//
+// A schedule with a timezone follows the wall-clock time of that timezone across daylight saving transitions.
+// A time that a transition skips is scheduled when the clock would have shown it had it not changed,
+// such as 3:30 for 2:30 when clocks spring forward from 2:00 to 3:00;
+// a time that a transition repeats is scheduled once, at its first occurrence.
+// A schedule without a timezone is evaluated in the server's local time.
+func ParseCron(spec string) (cron.Schedule, error) {
+	if !strings.HasPrefix(spec, timezonePrefix) {
+		return cron.Parse(spec)
+	}
+
+	i := strings.IndexByte(spec, ' ')
+	if i < 0 {
+		return nil, errors.New("missing cron expression after timezone")
+	}
+	loc, err := time.LoadLocation(spec[len(timezonePrefix):i])
+	if err != nil {
+		return nil, err
+	}
+
+	// Evaluate the expression in UTC, which has no transitions, against the wall-clock time in loc.
+	sch, err := cron.Parse(timezonePrefix + "UTC " + strings.TrimSpace(spec[i:]))
+	if err != nil {
+		return nil, err
+	}
+	return wallClockSchedule{sch: sch, loc: loc}, nil
+}
+
+// wallClockSchedule is a schedule evaluated against the wall-clock time in a timezone.
+type wallClockSchedule struct {
+	sch cron.Schedule // Evaluated in UTC.
+	loc *time.Location
+}
+
+func (s wallClockSchedule) Next(t time.Time) time.Time {
+	wall := wallClock(t.In(s.loc))
+	for {
+		wall = s.sch.Next(wall)
+		if wall.IsZero() {
+			return wall
+		}
+
+		next := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, s.loc)
+		if got := wallClock(next); !got.Equal(wall) {
+			// The wall-clock time was skipped by a transition. Schedule it as if the clock had not changed.
+			next = next.Add(wall.Sub(got))
+		}
+		// A wall-clock time repeated by a transition maps to its first occurrence, which may not be after t.
+		if next.After(t) {
+			return next.In(t.Location())
+		}
+	}
+}
+
+// wallClock returns the time in UTC that shows the same wall-clock time as t.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,198 @@
+package options
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// SecretsIdentifier is the identifier through which a task script references secrets,
+// as secrets.get(key: "name"), secrets.name or secrets["name"].
+// The values of the secrets are bound to it when a run executes, so they never appear in the stored script.
+const SecretsIdentifier = "secrets"
+
+// secretsGet is the name of the function of SecretsIdentifier that returns a secret by key.
+// A secret named get can only be referenced through the function.
+const secretsGet = "get"
+
+// secretNames returns the sorted, distinct names of the secrets that script references,
+// or nil if it references none.
+func secretNames(script string) []string {
+	prog, err := parser.NewAST(script)
+	if err != nil {
+		// Let evaluating the script report the error.
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.MemberExpression:
+			if !isSecrets(n.Object) {
+				return
+			}
+			switch p := n.Property.(type) {
+			case *ast.Identifier:
+				if p.Name != secretsGet {
+					seen[p.Name] = struct{}{}
+				}
+			case *ast.StringLiteral:
+				seen[p.Value] = struct{}{}
+			}
+		case *ast.CallExpression:
+			if key, ok := secretsGetKey(n); ok {
+				seen[key] = struct{}{}
+			}
+		}
+	}), prog)
+
+	if len(seen) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isSecrets reports whether e is SecretsIdentifier.
+func isSecrets(e ast.Expression) bool {
+	id, ok := e.(*ast.Identifier)
+	return ok && id.Name == SecretsIdentifier
+}
+
+// secretsGetKey returns the key of a call to secrets.get, if call is one with a string literal key.
+func secretsGetKey(call *ast.CallExpression) (string, bool) {
+	m, ok := call.Callee.(*ast.MemberExpression)
+	if !ok || !isSecrets(m.Object) {
+		return "", false
+	}
+	if p, ok := m.Property.(*ast.Identifier); !ok || p.Name != secretsGet {
+		return "", false
+	}
+	if len(call.Arguments) != 1 {
+		return "", false
+	}
+	args, ok := call.Arguments[0].(*ast.ObjectExpression)
+	if !ok {
+		return "", false
+	}
+	for _, p := range args.Properties {
+		if p.Key.Name != "key" {
+			continue
+		}
+		if s, ok := p.Value.(*ast.StringLiteral); ok {
+			return s.Value, true
+		}
+	}
+	return "", false
+}
+
+// BindSecrets binds SecretsIdentifier in itrp to a record of the given secret values, keyed by secret name,
+// along with its get function, which returns the value of the secret with the given key.
+// It must be called before evaluating a script that references secrets.
+func BindSecrets(itrp *interpreter.Interpreter, secrets map[string]string) {
+	vals := make(map[string]values.Value, len(secrets)+1)
+	for name, v := range secrets {
+		vals[name] = values.NewString(v)
+	}
+
+	get := func(args values.Object) (values.Value, error) {
+		key, ok := args.Get("key")
+		if !ok || key.Type() != semantic.String {
+			return nil, fmt.Errorf("%s.%s requires a string key", SecretsIdentifier, secretsGet)
+		}
+		v, ok := secrets[key.Str()]
+		if !ok {
+			// Only the secrets named by string literals are loaded for a run.
+			return nil, fmt.Errorf("secret %q is not referenced by a string literal key", key.Str())
+		}
+		return values.NewString(v), nil
+	}
+	vals[secretsGet] = values.NewFunction(secretsGet, semantic.NewFunctionType(semantic.FunctionSignature{
+		Parameters: map[string]semantic.Type{"key": semantic.String},
+		Required:   []string{"key"},
+		Return:     semantic.String,
+	}), get, false)
+
+	itrp.SetVar(SecretsIdentifier, values.NewObjectWithValues(vals))
+}
+
+// credentialNames are the lowercased names, with underscores and dashes removed, of the variables and
+// record properties whose string values are taken for credentials.
+var credentialNames = map[string]bool{
+	"password":      true,
+	"passwd":        true,
+	"secret":        true,
+	"token":         true,
+	"apikey":        true,
+	"apitoken":      true,
+	"accesskey":     true,
+	"secretkey":     true,
+	"authorization": true,
+}
+
+// credentialPatterns match string literals in the formats of well-known credentials.
+var credentialPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{name: "AWS access key", re: regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{name: "Slack token", re: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}`)},
+	{name: "GitHub token", re: regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36}\b`)},
+	{name: "private key", re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{name: "password in URL", re: regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`)},
+}
+
+// InlineSecret returns a description of the first credential that script holds in a string literal,
+// such as a token assigned to a property named token, or a URL with a password,
+// or the empty string if it holds none. Such credentials belong in the secret service,
+// from which a script references them through SecretsIdentifier.
+// A script that does not parse holds no credentials, as far as InlineSecret can tell.
+func InlineSecret(script string) string {
+	prog, err := parser.NewAST(script)
+	if err != nil {
+		return ""
+	}
+
+	var found string
+	check := func(name string, e ast.Expression) {
+		if found != "" {
+			return
+		}
+		s, ok := e.(*ast.StringLiteral)
+		if !ok || s.Value == "" {
+			return
+		}
+		if name != "" && credentialNames[strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))] {
+			found = fmt.Sprintf("string literal assigned to %s", name)
+			return
+		}
+		for _, p := range credentialPatterns {
+			if p.re.MatchString(s.Value) {
+				found = p.name
+				return
+			}
+		}
+	}
+	ast.Walk(ast.CreateVisitor(func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.VariableAssignment:
+			check(n.ID.Name, n.Init)
+		case *ast.Property:
+			check(n.Key.Name, n.Value)
+		case *ast.StringLiteral:
+			check("", n)
+		}
+	}), prog)
+	return found
+}
@@ -0,0 +1,278 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Update is a change to some of the task options of a Flux script, applied with Apply.
+// Nil fields leave the corresponding options unchanged.
+type Update struct {
+	Name *string
+
+	// Every replaces the task's schedule with a fixed period, removing any cron option.
+	Every *time.Duration
+
+	// Cron replaces the task's schedule with a cron expression, removing any every option.
+	Cron *string
+
+	// Offset replaces the task's offset. A zero offset removes the option.
+	Offset *time.Duration
+}
+
+// IsZero reports whether u changes no options.
+func (u Update) IsZero() bool {
+	return u.Name == nil && u.Every == nil && u.Cron == nil && u.Offset == nil
+}
+
+// Validate returns an error if u cannot be applied to any script.
+func (u Update) Validate() error {
+	if u.Every != nil && u.Cron != nil {
+		return errors.New("cannot update both every and cron")
+	}
+	if u.Name != nil && *u.Name == "" {
+		return errors.New("task name cannot be empty")
+	}
+	if u.Every != nil && *u.Every < time.Second {
+		return errors.New("every must be at least 1 second")
+	}
+	if u.Offset != nil && *u.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	return nil
+}
+
+// Apply returns script with the properties of its `option task = {...}` record changed according to u.
+// Properties that u does not change keep their order and values, and new properties are added at the end of the record.
+// Apply does not check that the resulting script is valid; use FromScript for that.
+func (u Update) Apply(script string) (string, error) {
+	if err := u.Validate(); err != nil {
+		return "", err
+	}
+
+	start, end, err := findTaskRecord(script)
+	if err != nil {
+		return "", err
+	}
+	props, err := splitRecord(script[start+1 : end])
+	if err != nil {
+		return "", err
+	}
+
+	if u.Name != nil {
+		props = setProperty(props, "name", quoteString(*u.Name))
+	}
+	if u.Every != nil {
+		props = setProperty(props, "every", formatDuration(*u.Every))
+		props = removeProperty(props, "cron")
+	}
+	if u.Cron != nil {
+		props = setProperty(props, "cron", quoteString(*u.Cron))
+		props = removeProperty(props, "every")
+	}
+	if u.Offset != nil {
+		if *u.Offset == 0 {
+			props = removeProperty(props, "offset")
+		} else {
+			props = setProperty(props, "offset", formatDuration(*u.Offset))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(script[:start])
+	b.WriteString("{\n")
+	for _, p := range props {
+		fmt.Fprintf(&b, "\t%s: %s,\n", p.key, p.value)
+	}
+	b.WriteString("}")
+	b.WriteString(script[end+1:])
+	return b.String(), nil
+}
+
+// recordProperty is a property of a Flux record, with its value as it appears in the script.
+type recordProperty struct {
+	key, value string
+}
+
+func setProperty(props []recordProperty, key, value string) []recordProperty {
+	for i := range props {
+		if props[i].key == key {
+			props[i].value = value
+			return props
+		}
+	}
+	return append(props, recordProperty{key: key, value: value})
+}
+
+func removeProperty(props []recordProperty, key string) []recordProperty {
+	for i := range props {
+		if props[i].key == key {
+			return append(props[:i], props[i+1:]...)
+		}
+	}
+	return props
+}
+
+// findTaskRecord returns the positions of the opening and closing braces of the record assigned by `option task = {...}`.
+func findTaskRecord(script string) (start, end int, err error) {
+	s := scanner{src: script}
+	for s.next() {
+		if s.tok != "option" {
+			continue
+		}
+		if !s.next() || s.tok != "task" || !s.next() || s.tok != "=" || !s.next() || s.tok != "{" {
+			continue
+		}
+
+		start = s.pos - 1
+		depth := 1
+		for depth > 0 && s.next() {
+			switch s.tok {
+			case "{", "(", "[":
+				depth++
+			case "}", ")", "]":
+				depth--
+			}
+		}
+		if depth > 0 {
+			return 0, 0, errors.New("unterminated task option record")
+		}
+		return start, s.pos - 1, nil
+	}
+	return 0, 0, errors.New("script has no task option record")
+}
+
+// splitRecord splits the body of a record, between its braces, into its properties.
+func splitRecord(body string) ([]recordProperty, error) {
+	var props []recordProperty
+	s := scanner{src: body}
+	depth := 0
+	propStart, propEnd := -1, -1 // Bounds of the tokens of the current property.
+	addProp := func() error {
+		if propStart < 0 {
+			return nil
+		}
+		text := body[propStart:propEnd]
+		propStart, propEnd = -1, -1
+
+		i := strings.Index(text, ":")
+		if i < 1 {
+			return fmt.Errorf("invalid task option %q", text)
+		}
+		props = append(props, recordProperty{key: strings.TrimSpace(text[:i]), value: strings.TrimSpace(text[i+1:])})
+		return nil
+	}
+
+	for s.next() {
+		if s.tok == "," && depth == 0 {
+			if err := addProp(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch s.tok {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			depth--
+		}
+		if propStart < 0 {
+			propStart = s.pos - len(s.tok)
+		}
+		propEnd = s.pos
+	}
+	if err := addProp(); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+// scanner splits Flux source into just enough tokens to find the task option record:
+// identifiers, string literals, and single punctuation characters. Comments and whitespace are skipped.
+type scanner struct {
+	src string
+	pos int    // Offset just past the current token.
+	tok string // The current token.
+}
+
+// next advances to the next token, returning false at the end of the source.
+func (s *scanner) next() bool {
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s.pos++
+		case strings.HasPrefix(s.src[s.pos:], "//"):
+			if i := strings.IndexByte(s.src[s.pos:], '\n'); i >= 0 {
+				s.pos += i + 1
+			} else {
+				s.pos = len(s.src)
+			}
+		case c == '"':
+			start := s.pos
+			s.pos++
+			for s.pos < len(s.src) && s.src[s.pos] != '"' {
+				if s.src[s.pos] == '\\' {
+					s.pos++
+				}
+				s.pos++
+			}
+			s.pos++
+			if s.pos > len(s.src) {
+				s.pos = len(s.src)
+			}
+			s.tok = s.src[start:s.pos]
+			return true
+		case isIdentChar(c):
+			start := s.pos
+			for s.pos < len(s.src) && isIdentChar(s.src[s.pos]) {
+				s.pos++
+			}
+			s.tok = s.src[start:s.pos]
+			return true
+		default:
+			s.pos++
+			s.tok = string(c)
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// quoteString returns s as a Flux string literal.
+func quoteString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// formatDuration formats d as a Flux duration literal, such as 1h30m.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	var b strings.Builder
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "us"},
+		{time.Nanosecond, "ns"},
+	} {
+		if n := d / unit.d; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.name)
+			d -= n * unit.d
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package options_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/task/options"
+)
+
+func TestUpdate_Apply(t *testing.T) {
+	const script = `// Copies data hourly.
+option task = {
+	name: "copy", // the name
+	cron: "0 * * * *",
+	concurrency: 2,
+	retry: 1,
+}
+
+from(bucket: "a") |> range(start: -1h) |> to(bucket: "b")`
+
+	name := `copy "a"`
+	every := 90 * time.Minute
+	cron := "*/5 * * * *"
+	offset := 5 * time.Second
+	noOffset := time.Duration(0)
+
+	for _, tc := range []struct {
+		name string
+		u    options.Update
+		exp  options.Options
+	}{
+		{
+			name: "name",
+			u:    options.Update{Name: &name},
+			exp:  options.Options{Name: name, Cron: "0 * * * *", Concurrency: 2, Retry: 1},
+		},
+		{
+			name: "every replaces cron",
+			u:    options.Update{Every: &every},
+			exp:  options.Options{Name: "copy", Every: every, Concurrency: 2, Retry: 1},
+		},
+		{
+			name: "cron and offset",
+			u:    options.Update{Cron: &cron, Offset: &offset},
+			exp:  options.Options{Name: "copy", Cron: cron, Offset: offset, Concurrency: 2, Retry: 1},
+		},
+		{
+			name: "zero offset",
+			u:    options.Update{Offset: &noOffset},
+			exp:  options.Options{Name: "copy", Cron: "0 * * * *", Concurrency: 2, Retry: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			updated, err := tc.u.Apply(script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := options.FromScript(updated)
+			if err != nil {
+				t.Fatalf("updated script is invalid: %v\n%s", err, updated)
+			}
+			if o != tc.exp {
+				t.Fatalf("expected options %#v, got %#v from script:\n%s", tc.exp, o, updated)
+			}
+		})
+	}
+
+	if _, err := (options.Update{Every: &every, Cron: &cron}).Apply(script); err == nil {
+		t.Fatal("expected error updating both every and cron")
+	}
+	if _, err := (options.Update{Name: &name}).Apply(`from(bucket: "a") |> range(start: -1h)`); err == nil {
+		t.Fatal("expected error updating script without task option")
+	}
+}
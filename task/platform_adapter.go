@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -15,9 +16,16 @@ type RunController interface {
 	//TODO: add retry run to this.
 }
 
+// RunLimitChecker is implemented by RunControllers that limit the rate at which each organization's runs start,
+// such as *backend.TickScheduler.
+type RunLimitChecker interface {
+	// CheckRunLimit returns backend.ErrRunRateLimited if org may not start another run now.
+	CheckRunLimit(org platform.ID) error
+}
+
 // PlatformAdapter wraps a task.Store into the platform.TaskService interface.
 func PlatformAdapter(s backend.Store, r backend.LogReader, rc RunController) platform.TaskService {
-	return pAdapter{s: s, r: r}
+	return pAdapter{s: s, rc: rc, r: r}
 }
 
 type pAdapter struct {
@@ -53,6 +61,7 @@ func (p pAdapter) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]
 	if filter.After != nil {
 		params.After = *filter.After
 	}
+	params.Labels = filter.Labels
 	ts, err := p.s.ListTasks(ctx, params)
 	if err != nil {
 		return nil, 0, err
@@ -70,6 +79,18 @@ func (p pAdapter) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]
 }
 
 func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
+	if t.Offset != "" {
+		offset, err := time.ParseDuration(t.Offset)
+		if err != nil {
+			return fmt.Errorf("invalid offset: %v", err)
+		}
+		script, err := options.Update{Offset: &offset}.Apply(t.Flux)
+		if err != nil {
+			return err
+		}
+		t.Flux = script
+	}
+
 	opts, err := options.FromScript(t.Flux)
 	if err != nil {
 		return err
@@ -83,11 +104,13 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 	}
 
 	req := backend.CreateTaskRequest{
-		Org:           t.Organization,
-		User:          t.Owner.ID,
-		Script:        t.Flux,
-		ScheduleAfter: scheduleAfter,
-		Status:        backend.TaskStatus(t.Status),
+		Org:            t.Organization,
+		User:           t.Owner.ID,
+		Script:         t.Flux,
+		ScheduleAfter:  scheduleAfter,
+		Status:         backend.TaskStatus(t.Status),
+		Labels:         t.Labels,
+		IdempotencyKey: t.IdempotencyKey,
 	}
 
 	id, err := p.s.CreateTask(ctx, req)
@@ -97,19 +120,39 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 	t.ID = id
 	t.Every = opts.Every.String()
 	t.Cron = opts.Cron
+	t.Offset = ""
+	if opts.Offset != 0 {
+		t.Offset = opts.Offset.String()
+	}
 
 	return nil
 }
 
 func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
-	if upd.Flux == nil && upd.Status == nil {
+	if upd.Flux == nil && upd.Name == nil && upd.Every == nil && upd.Cron == nil && upd.Offset == nil && upd.Status == nil && upd.Labels == nil {
 		return nil, errors.New("cannot update task without content")
 	}
 
-	req := backend.UpdateTaskRequest{ID: id}
+	req := backend.UpdateTaskRequest{ID: id, Labels: upd.Labels}
 	if upd.Flux != nil {
 		req.Script = *upd.Flux
 	}
+	req.Options.Name = upd.Name
+	req.Options.Cron = upd.Cron
+	if upd.Every != nil {
+		every, err := time.ParseDuration(*upd.Every)
+		if err != nil {
+			return nil, fmt.Errorf("invalid every: %v", err)
+		}
+		req.Options.Every = &every
+	}
+	if upd.Offset != nil {
+		offset, err := time.ParseDuration(*upd.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset: %v", err)
+		}
+		req.Options.Offset = &offset
+	}
 	if upd.Status != nil {
 		req.Status = backend.TaskStatus(*upd.Status)
 	}
@@ -118,7 +161,7 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 		return nil, err
 	}
 
-	opts, err := options.FromScript(res.NewTask.Script)
+	opts, err := res.NewTask.ScriptOptions()
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +174,10 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 		Flux:   res.NewTask.Script,
 		Every:  opts.Every.String(),
 		Cron:   opts.Cron,
-		Offset: opts.Offset.String(),
+		Labels: res.NewTask.Labels,
+	}
+	if offset := res.NewMeta.OffsetDuration(); offset != 0 {
+		task.Offset = offset.String()
 	}
 
 	t, err := p.s.FindTaskByID(ctx, id)
@@ -147,6 +193,10 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 func (p pAdapter) DeleteTask(ctx context.Context, id platform.ID) error {
 	_, err := p.s.DeleteTask(ctx, id)
 	// TODO(mr): Store.DeleteTask returns false, nil if ID didn't match; do we want to handle that case?
+	if _, ok := err.(backend.ReleaseWarning); ok {
+		// The task was deleted. The coordinator logged the failed release, and retries it.
+		return nil
+	}
 	return err
 }
 
@@ -186,13 +236,19 @@ func (p pAdapter) RetryRun(ctx context.Context, taskID, id platform.ID) (*platfo
 		return nil, backend.ErrRunNotFinished
 	}
 
+	if c, ok := p.rc.(RunLimitChecker); ok {
+		if err := c.CheckRunLimit(task.Org); err != nil {
+			return nil, err
+		}
+	}
+
 	scheduledTime, err := time.Parse(time.RFC3339, run.ScheduledFor)
 	if err != nil {
 		return nil, err
 	}
 	t := scheduledTime.UTC().Unix()
 	requestedAt := time.Now().Unix()
-	m, err := p.s.ManuallyRunTimeRange(ctx, run.TaskID, t, t, requestedAt)
+	m, err := p.s.RetryRun(ctx, run.TaskID, run.ID, t, requestedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +258,46 @@ func (p pAdapter) RetryRun(ctx context.Context, taskID, id platform.ID) (*platfo
 		RequestedAt:  time.Unix(requestedAt, 0).Format(time.RFC3339),
 		Status:       backend.RunScheduled.String(),
 		ScheduledFor: run.ScheduledFor,
+		RetryOf:      run.ID,
+	}, nil
+}
+
+func (p pAdapter) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	task, meta, err := p.s.FindTaskByIDWithMeta(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, backend.ErrTaskNotFound
+	}
+	if meta.IsOneShot() {
+		return nil, backend.ErrOneShotManualRun
+	}
+
+	if c, ok := p.rc.(RunLimitChecker); ok {
+		if err := c.CheckRunLimit(task.Org); err != nil {
+			return nil, err
+		}
+	}
+
+	// Request the single schedule the run is for, so that the run gets its ID up front, as a retry does.
+	sch, err := options.ParseCron(meta.EffectiveCron)
+	if err != nil {
+		return nil, err
+	}
+	runFor := sch.Next(time.Unix(scheduledFor-1, 0)).Unix()
+
+	requestedAt := time.Now().Unix()
+	m, err := p.s.ManuallyRunTimeRange(ctx, taskID, runFor, runFor, requestedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &platform.Run{
+		ID:           platform.ID(m.RunID),
+		TaskID:       taskID,
+		RequestedAt:  time.Unix(requestedAt, 0).Format(time.RFC3339),
+		Status:       backend.RunScheduled.String(),
+		ScheduledFor: time.Unix(runFor, 0).UTC().Format(time.RFC3339),
 	}, nil
 }
 
@@ -209,8 +305,220 @@ func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) erro
 	return p.rc.CancelRun(ctx, taskID, runID)
 }
 
+func (p pAdapter) FindTaskStats(ctx context.Context, taskID platform.ID) (*platform.TaskStats, error) {
+	stats, err := p.s.FindTaskStats(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &platform.TaskStats{
+		TaskID:          taskID,
+		Runs:            stats.Runs,
+		Succeeded:       stats.Succeeded,
+		Failed:          stats.Failed,
+		SuccessRate:     stats.SuccessRate,
+		AverageDuration: stats.AverageDuration.Seconds(),
+		MedianDuration:  stats.MedianDuration.Seconds(),
+		P95Duration:     stats.P95Duration.Seconds(),
+		Usage: platform.TaskUsage{
+			Runs:           stats.Usage.Runs,
+			ExecutionTime:  stats.Usage.ExecutionTime.Seconds(),
+			RowsWritten:    stats.Usage.RowsWritten,
+			MemoryBytes:    stats.Usage.MemoryBytes,
+			MaxMemoryBytes: stats.Usage.MaxMemoryBytes,
+		},
+	}
+	if lf := stats.LastFailure; lf != nil {
+		ps.LastFailure = &platform.TaskRunFailure{
+			RunID:      lf.RunID,
+			FinishedAt: lf.FinishedAt.UTC().Format(time.RFC3339Nano),
+			Error:      lf.Error,
+		}
+	}
+	return ps, nil
+}
+
+func (p pAdapter) ExportTasks(ctx context.Context, orgID platform.ID) (*platform.TaskBundle, error) {
+	b := &platform.TaskBundle{
+		Version:      platform.TaskBundleVersion,
+		Organization: orgID,
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		Tasks:        []platform.BundledTask{},
+	}
+
+	params := backend.TaskSearchParams{Org: orgID, PageSize: platform.TaskMaxPageSize}
+	for {
+		ts, err := p.s.ListTasks(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range ts {
+			pt, err := toPlatformTask(t.Task, &t.Meta)
+			if err != nil {
+				return nil, err
+			}
+			b.Tasks = append(b.Tasks, platform.BundledTask{
+				ID:     pt.ID,
+				Name:   pt.Name,
+				Status: pt.Status,
+				Flux:   pt.Flux,
+				Every:  pt.Every,
+				Cron:   pt.Cron,
+				Offset: pt.Offset,
+				Labels: pt.Labels,
+			})
+		}
+
+		if len(ts) < platform.TaskMaxPageSize {
+			return b, nil
+		}
+		params.After = ts[len(ts)-1].Task.ID
+	}
+}
+
+func (p pAdapter) ImportTasks(ctx context.Context, orgID, userID platform.ID, bundle *platform.TaskBundle) ([]*platform.Task, error) {
+	if bundle.Version != platform.TaskBundleVersion {
+		return nil, fmt.Errorf("unsupported task bundle version %d", bundle.Version)
+	}
+
+	created := make([]*platform.Task, 0, len(bundle.Tasks))
+	for _, bt := range bundle.Tasks {
+		t := &platform.Task{
+			Organization: orgID,
+			Owner:        platform.User{ID: userID},
+			Status:       bt.Status,
+			Flux:         bt.Flux,
+			Labels:       bt.Labels,
+		}
+		if bundle.Organization.Valid() && bt.ID.Valid() {
+			// Key each task by where it was exported from, so that importing the bundle again returns the existing tasks.
+			t.IdempotencyKey = bundleTaskKey(bundle.Organization, bt.ID)
+		}
+		if err := p.CreateTask(ctx, t); err != nil {
+			return created, err
+		}
+
+		t, err := p.FindTaskByID(ctx, t.ID)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, t)
+	}
+	return created, nil
+}
+
+// ErrWatchNotSupported is returned by WatchTasks when the task store does not implement backend.TaskWatcher.
+var ErrWatchNotSupported = errors.New("task store does not support watching tasks")
+
+// WatchTasks reports the changes to tasks matching filter that the store reports.
+// A deleted task is reported if it matched filter when the watch started, or when it was last created or updated.
+// A task that stops matching filter, such as when it is transferred to another organization, is not reported again.
+func (p pAdapter) WatchTasks(ctx context.Context, filter platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+	w, ok := p.s.(backend.TaskWatcher)
+	if !ok {
+		return nil, ErrWatchNotSupported
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Start watching before listing the tasks that already match, so that no change is missed in between.
+	changes := w.WatchTasks(ctx)
+	filtered := filter.Organization != nil || filter.User != nil || len(filter.Labels) > 0
+	matched := make(map[platform.ID]bool)
+	if filtered {
+		params := backend.TaskSearchParams{Labels: filter.Labels}
+		if filter.Organization != nil {
+			params.Org = *filter.Organization
+		}
+		if filter.User != nil {
+			params.User = *filter.User
+		}
+		for {
+			ts, err := p.s.ListTasks(ctx, params)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			if len(ts) == 0 {
+				break
+			}
+			for _, t := range ts {
+				matched[t.Task.ID] = true
+			}
+			params.After = ts[len(ts)-1].Task.ID
+		}
+	}
+
+	events := make(chan platform.TaskEvent)
+	go func() {
+		defer cancel()
+		defer close(events)
+
+		for c := range changes {
+			ev := platform.TaskEvent{TaskID: c.TaskID}
+			if c.Deleted {
+				if filtered && !matched[c.TaskID] {
+					continue
+				}
+				delete(matched, c.TaskID)
+				ev.Type = platform.TaskDeleted
+			} else {
+				t, m, err := p.s.FindTaskByIDWithMeta(ctx, c.TaskID)
+				if err != nil || t == nil {
+					// The task was deleted since the change, which is reported next.
+					continue
+				}
+				if !taskMatches(*t, filter) {
+					delete(matched, c.TaskID)
+					continue
+				}
+				if filtered {
+					matched[c.TaskID] = true
+				}
+
+				if ev.Task, err = toPlatformTask(*t, m); err != nil {
+					continue
+				}
+				ev.Type = platform.TaskUpdated
+				if c.Created {
+					ev.Type = platform.TaskCreated
+				}
+			}
+
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// taskMatches reports whether t has the organization, user, and labels required by filter.
+func taskMatches(t backend.StoreTask, filter platform.TaskFilter) bool {
+	if filter.Organization != nil && t.Org != *filter.Organization {
+		return false
+	}
+	if filter.User != nil && t.User != *filter.User {
+		return false
+	}
+	for k, v := range filter.Labels {
+		if t.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// bundleTaskKey returns the idempotency key for importing the task with the given ID, exported from orgID.
+func bundleTaskKey(orgID, taskID platform.ID) string {
+	return "import:" + orgID.String() + "/" + taskID.String()
+}
+
 func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Task, error) {
-	opts, err := options.FromScript(t.Script)
+	opts, err := t.ScriptOptions()
 	if err != nil {
 		return nil, err
 	}
@@ -223,18 +531,29 @@ func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Ta
 			ID:   t.User,
 			Name: "", // TODO(mr): how to get owner name?
 		},
-		Flux: t.Script,
-		Cron: opts.Cron,
+		Flux:   t.Script,
+		Cron:   opts.Cron,
+		Labels: t.Labels,
 	}
 	if opts.Every != 0 {
 		pt.Every = opts.Every.String()
 	}
-	if opts.Offset != 0 {
-		pt.Offset = opts.Offset.String()
-	}
 	if m != nil {
 		pt.Status = string(m.Status)
 		pt.LatestCompleted = time.Unix(m.LatestCompleted, 0).Format(time.RFC3339)
+		if offset := m.OffsetDuration(); offset != 0 {
+			pt.Offset = offset.String()
+		}
+		if m.IsOneShot() {
+			pt.RunAt = time.Unix(m.RunAt, 0).UTC().Format(time.RFC3339)
+		}
+		if m.LastRunStatus != "" {
+			pt.LastRunStatus = m.LastRunStatus
+			pt.LastRunError = m.LastRunError
+			pt.LastRunDuration = time.Duration(m.LastRunDuration).String()
+		}
+	} else if opts.Offset != 0 {
+		pt.Offset = opts.Offset.String()
 	}
 	return pt, nil
 }
@@ -76,6 +76,16 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory) {
 			t.Parallel()
 			testMetaUpdate(t, sys)
 		})
+
+		t.Run("Task Import and Export", func(t *testing.T) {
+			t.Parallel()
+			testTaskImportExport(t, sys)
+		})
+
+		t.Run("Task Watch", func(t *testing.T) {
+			t.Parallel()
+			testTaskWatch(t, sys)
+		})
 	})
 }
 
@@ -219,6 +229,21 @@ func testTaskCRUD(t *testing.T, sys *System) {
 		t.Fatalf("expected task status to be inactive, got %q", f.Status)
 	}
 
+	// Update task: offset only, which is reported from the task's schedule.
+	newOffset := "1m"
+	f, err = sys.ts.UpdateTask(sys.Ctx, origID, platform.TaskUpdate{Offset: &newOffset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Offset != "1m0s" {
+		t.Fatalf(`wrong offset from update; want "1m0s", got %q`, f.Offset)
+	}
+	if f, err = sys.ts.FindTaskByID(sys.Ctx, origID); err != nil {
+		t.Fatal(err)
+	} else if f.Offset != "1m0s" {
+		t.Fatalf(`wrong offset after update; want "1m0s", got %q`, f.Offset)
+	}
+
 	// Delete task.
 	if err := sys.ts.DeleteTask(sys.Ctx, origID); err != nil {
 		t.Fatal(err)
@@ -228,6 +253,170 @@ func testTaskCRUD(t *testing.T, sys *System) {
 	if _, err := sys.ts.FindTaskByID(sys.Ctx, origID); err != backend.ErrTaskNotFound {
 		t.Fatalf("expected %v, got %v", backend.ErrTaskNotFound, err)
 	}
+
+	// Create a task with an offset, which overrides the offset option of its script.
+	task = &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 1), Offset: "30s"}
+	if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if f, err = sys.ts.FindTaskByID(sys.Ctx, task.ID); err != nil {
+		t.Fatal(err)
+	} else if f.Offset != "30s" {
+		t.Fatalf(`wrong offset for task created with an offset; want "30s", got %q`, f.Offset)
+	}
+}
+
+func testTaskImportExport(t *testing.T, sys *System) {
+	_, userID, _ := creds(t, sys)
+	srcOrgID := idGen.ID()
+	dstOrgID := idGen.ID()
+
+	active := &platform.Task{Organization: srcOrgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+	inactive := &platform.Task{
+		Organization: srcOrgID,
+		Owner:        platform.User{ID: userID},
+		Status:       string(backend.TaskInactive),
+		Flux:         fmt.Sprintf(scriptFmt, 1),
+		Labels:       map[string]string{"env": "staging"},
+	}
+	for _, task := range []*platform.Task{active, inactive} {
+		if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := sys.ts.ExportTasks(sys.Ctx, srcOrgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Version != platform.TaskBundleVersion {
+		t.Fatalf("expected bundle version %d, got %d", platform.TaskBundleVersion, b.Version)
+	}
+	if b.Organization != srcOrgID {
+		t.Fatalf("expected bundle organization %s, got %s", srcOrgID, b.Organization)
+	}
+	if len(b.Tasks) != 2 {
+		t.Fatalf("expected 2 exported tasks, got %d: %#v", len(b.Tasks), b.Tasks)
+	}
+	if b.Tasks[0].ID != active.ID || b.Tasks[1].ID != inactive.ID {
+		t.Fatalf("exported wrong tasks: %#v", b.Tasks)
+	}
+
+	imported, err := sys.ts.ImportTasks(sys.Ctx, dstOrgID, userID, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("expected 2 imported tasks, got %d", len(imported))
+	}
+	for i, want := range []*platform.Task{active, inactive} {
+		got := imported[i]
+		if got.ID == want.ID {
+			t.Fatalf("imported task has the same ID as the exported task %s", want.ID)
+		}
+		if got.Organization != dstOrgID {
+			t.Fatalf("expected imported task in organization %s, got %s", dstOrgID, got.Organization)
+		}
+		if got.Owner.ID != userID {
+			t.Fatalf("expected imported task owned by %s, got %s", userID, got.Owner.ID)
+		}
+		if got.Flux != want.Flux {
+			t.Fatalf("expected imported flux %q, got %q", want.Flux, got.Flux)
+		}
+		if got.Name != fmt.Sprintf("task #%d", i) {
+			t.Fatalf("expected imported name %q, got %q", fmt.Sprintf("task #%d", i), got.Name)
+		}
+	}
+	if imported[0].Status != string(backend.TaskActive) || imported[1].Status != string(backend.TaskInactive) {
+		t.Fatalf("imported tasks have wrong statuses: %q, %q", imported[0].Status, imported[1].Status)
+	}
+	if diff := cmp.Diff(inactive.Labels, imported[1].Labels); diff != "" {
+		t.Fatalf("imported task has wrong labels: %s", diff)
+	}
+
+	// Importing the bundle again must not duplicate the tasks.
+	again, err := sys.ts.ImportTasks(sys.Ctx, dstOrgID, userID, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 2 || again[0].ID != imported[0].ID || again[1].ID != imported[1].ID {
+		t.Fatalf("re-importing created new tasks: %#v", again)
+	}
+	fs, _, err := sys.ts.FindTasks(sys.Ctx, platform.TaskFilter{Organization: &dstOrgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) != 2 {
+		t.Fatalf("expected 2 tasks in the destination organization, got %d", len(fs))
+	}
+}
+
+func testTaskWatch(t *testing.T, sys *System) {
+	_, userID, _ := creds(t, sys)
+	orgID := idGen.ID()
+
+	// A task that exists before the watch starts.
+	existing := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+	if err := sys.ts.CreateTask(sys.Ctx, existing); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(sys.Ctx)
+	defer cancel()
+	events, err := sys.ts.WatchTasks(ctx, platform.TaskFilter{Organization: &orgID})
+	if err == task.ErrWatchNotSupported {
+		t.Skip("store does not support watching tasks")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := func(t *testing.T) platform.TaskEvent {
+		t.Helper()
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatal("watch ended unexpectedly")
+			}
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for task event")
+		}
+		return platform.TaskEvent{}
+	}
+
+	// Tasks in other organizations are not reported.
+	other := &platform.Task{Organization: idGen.ID(), Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 1)}
+	if err := sys.ts.CreateTask(sys.Ctx, other); err != nil {
+		t.Fatal(err)
+	}
+
+	created := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 2)}
+	if err := sys.ts.CreateTask(sys.Ctx, created); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(t); ev.Type != platform.TaskCreated || ev.TaskID != created.ID || ev.Task == nil || ev.Task.Flux != created.Flux {
+		t.Fatalf("expected creation of task %s, got %#v", created.ID, ev)
+	}
+
+	inactive := string(backend.TaskInactive)
+	if _, err := sys.ts.UpdateTask(sys.Ctx, created.ID, platform.TaskUpdate{Status: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(t); ev.Type != platform.TaskUpdated || ev.TaskID != created.ID || ev.Task == nil || ev.Task.Status != inactive {
+		t.Fatalf("expected update of task %s, got %#v", created.ID, ev)
+	}
+
+	if err := sys.ts.DeleteTask(sys.Ctx, existing.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(t); ev.Type != platform.TaskDeleted || ev.TaskID != existing.ID || ev.Task != nil {
+		t.Fatalf("expected deletion of task %s, got %#v", existing.ID, ev)
+	}
+
+	cancel()
+	for range events {
+	}
 }
 
 func testMetaUpdate(t *testing.T, sys *System) {
@@ -470,6 +659,9 @@ func testTaskRuns(t *testing.T, sys *System) {
 		if nowTime.Unix() != rc.Created.Now {
 			t.Fatalf("wrong scheduledFor on task: got %s, want %s", m.ScheduledFor, time.Unix(rc.Created.Now, 0).Format(time.RFC3339))
 		}
+		if m.RetryOf != rlb.RunID {
+			t.Fatalf("wrong retryOf on retried run: got %s, want %s", m.RetryOf, rlb.RunID)
+		}
 
 		// Ensure the retry is added on the store task meta.
 		meta, err := sys.S.FindTaskMetaByID(sys.Ctx, task.ID)
@@ -479,7 +671,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 
 		found := false
 		for _, mr := range meta.ManualRuns {
-			if mr.Start == mr.End && mr.Start == rc.Created.Now {
+			if mr.Start == mr.End && mr.Start == rc.Created.Now && platform.ID(mr.RetryOf) == rlb.RunID {
 				found = true
 				break
 			}
@@ -496,6 +688,48 @@ func testTaskRuns(t *testing.T, sys *System) {
 		}
 	})
 
+	t.Run("ForceRun", func(t *testing.T) {
+		t.Parallel()
+
+		task := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+		if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+			t.Fatal(err)
+		}
+
+		// The script runs every minute, so a run requested for 30 seconds past a minute is for the next minute.
+		scheduledFor := time.Now().Add(time.Hour).Truncate(time.Minute).Add(30 * time.Second)
+		r, err := sys.ts.ForceRun(sys.Ctx, task.ID, scheduledFor.Unix())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.TaskID != task.ID || r.Status != "scheduled" || !r.ID.Valid() {
+			t.Fatalf("unexpected forced run %#v", r)
+		}
+		if exp := scheduledFor.Add(30 * time.Second).UTC().Format(time.RFC3339); r.ScheduledFor != exp {
+			t.Fatalf("wrong scheduledFor on forced run: got %s, want %s", r.ScheduledFor, exp)
+		}
+
+		meta, err := sys.S.FindTaskMetaByID(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, mr := range meta.ManualRuns {
+			if platform.ID(mr.RunID) == r.ID && mr.Start == mr.End {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("didn't find matching manual run after successful ForceRun call; got: %v", meta.ManualRuns)
+		}
+
+		// Forcing the same schedule again, before its run started, should be rejected.
+		if _, err := sys.ts.ForceRun(sys.Ctx, task.ID, scheduledFor.Unix()); err == nil {
+			t.Fatal("expected forcing an already queued run to fail")
+		}
+	})
+
 	t.Run("FindLogs", func(t *testing.T) {
 		t.Parallel()
 
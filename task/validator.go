@@ -22,8 +22,25 @@ func (ae *authError) AuthzError() error {
 	return fmt.Errorf("permission failed for auth (%s): %s", ae.auth.Identifier().String(), ae.perm.String())
 }
 
+// Code reports the platform error code of the error, so that it is encoded as forbidden.
+func (ae *authError) Code() string {
+	return platform.EForbidden
+}
+
 var ErrFailedPermission = errors.New("unauthorized")
 
+// ErrWatchRequiresOrg is returned when watching tasks without naming the organization whose tasks to watch.
+var ErrWatchRequiresOrg = &platform.Error{
+	Code: platform.EInvalid,
+	Msg:  "watching tasks requires an organization",
+}
+
+// ErrRequiresTask is returned when finding runs or logs without naming the task whose runs or logs to find.
+var ErrRequiresTask = &platform.Error{
+	Code: platform.EInvalid,
+	Msg:  "finding runs or logs requires a task",
+}
+
 type taskServiceValidator struct {
 	platform.TaskService
 	preAuth query.PreAuthorizer
@@ -48,7 +65,199 @@ func (ts *taskServiceValidator) CreateTask(ctx context.Context, t *platform.Task
 	return ts.TaskService.CreateTask(ctx, t)
 }
 
-// TODO(lh): add permission checking for the all the platform.TaskService functions.
+func (ts *taskServiceValidator) ImportTasks(ctx context.Context, orgID, userID platform.ID, bundle *platform.TaskBundle) ([]*platform.Task, error) {
+	if err := validatePermission(ctx, platform.Permission{Action: platform.CreateAction, Resource: platform.TaskResource(orgID)}); err != nil {
+		return nil, err
+	}
+
+	for _, t := range bundle.Tasks {
+		if err := validateBucket(ctx, t.Flux, ts.preAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	return ts.TaskService.ImportTasks(ctx, orgID, userID, bundle)
+}
+
+func (ts *taskServiceValidator) ExportTasks(ctx context.Context, orgID platform.ID) (*platform.TaskBundle, error) {
+	if err := validatePermission(ctx, platform.Permission{Action: platform.ReadAction, Resource: platform.TaskResource(orgID)}); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.ExportTasks(ctx, orgID)
+}
+
+func (ts *taskServiceValidator) WatchTasks(ctx context.Context, filter platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+	if filter.Organization == nil {
+		return nil, ErrWatchRequiresOrg
+	}
+	if err := validatePermission(ctx, platform.Permission{Action: platform.ReadAction, Resource: platform.TaskResource(*filter.Organization)}); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.WatchTasks(ctx, filter)
+}
+
+func (ts *taskServiceValidator) FindTaskStats(ctx context.Context, taskID platform.ID) (*platform.TaskStats, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskStats(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
+	task, err := ts.TaskService.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTaskPermission(ctx, platform.ReadAction, task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// FindTasks leaves out the tasks that the authorizer may not read, unless it may read all of the filtered organization's tasks.
+// It keeps reading pages of tasks until it has found filter.Limit readable ones or there are no more,
+// so that a page short of the limit still means it is the last one.
+func (ts *taskServiceValidator) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]*platform.Task, int, error) {
+	auth, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Organization != nil && auth.Allowed(platform.TaskPermission(platform.ReadAction, *filter.Organization)) {
+		return ts.TaskService.FindTasks(ctx, filter)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = platform.TaskDefaultPageSize
+	}
+	filter.Limit = limit
+
+	var allowed []*platform.Task
+	for {
+		tasks, _, err := ts.TaskService.FindTasks(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, t := range tasks {
+			if platform.AllowedTask(auth, platform.ReadAction, t.Organization, t.ID) {
+				allowed = append(allowed, t)
+				if len(allowed) == limit {
+					return allowed, len(allowed), nil
+				}
+			}
+		}
+		if len(tasks) < limit {
+			return allowed, len(allowed), nil
+		}
+		after := tasks[len(tasks)-1].ID
+		filter.After = &after
+	}
+}
+
+func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	if err := ts.validateTask(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+	if upd.Flux != nil {
+		if err := validateBucket(ctx, *upd.Flux, ts.preAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	return ts.TaskService.UpdateTask(ctx, id, upd)
+}
+
+func (ts *taskServiceValidator) DeleteTask(ctx context.Context, id platform.ID) error {
+	if err := ts.validateTask(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return ts.TaskService.DeleteTask(ctx, id)
+}
+
+func (ts *taskServiceValidator) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+	if filter.Task == nil {
+		return nil, 0, ErrRequiresTask
+	}
+	if err := ts.validateTask(ctx, platform.ReadAction, *filter.Task); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindLogs(ctx, filter)
+}
+
+func (ts *taskServiceValidator) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
+	if filter.Task == nil {
+		return nil, 0, ErrRequiresTask
+	}
+	if err := ts.validateTask(ctx, platform.ReadAction, *filter.Task); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindRuns(ctx, filter)
+}
+
+func (ts *taskServiceValidator) FindRunByID(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindRunByID(ctx, taskID, runID)
+}
+
+func (ts *taskServiceValidator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
+	if err := ts.validateTask(ctx, platform.RunAction, taskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CancelRun(ctx, taskID, runID)
+}
+
+func (ts *taskServiceValidator) RetryRun(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	if err := ts.validateTask(ctx, platform.RunAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.RetryRun(ctx, taskID, runID)
+}
+
+func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
+	if err := ts.validateTask(ctx, platform.RunAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
+}
+
+// validateTask checks that the authorizer may take action on the task with the given ID,
+// by a permission for the task alone or for the tasks of its organization.
+func (ts *taskServiceValidator) validateTask(ctx context.Context, a platform.Action, taskID platform.ID) error {
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return validateTaskPermission(ctx, a, task)
+}
+
+// validateTaskPermission checks that the authorizer may take action on task.
+func validateTaskPermission(ctx context.Context, a platform.Action, task *platform.Task) error {
+	auth, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !platform.AllowedTask(auth, a, task.Organization, task.ID) {
+		perm := platform.TaskIDPermission(a, task.ID)
+		return &authError{error: ErrFailedPermission, perm: perm, auth: auth}
+	}
+
+	return nil
+}
 
 func validatePermission(ctx context.Context, perm platform.Permission) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
@@ -57,7 +266,7 @@ func validatePermission(ctx context.Context, perm platform.Permission) error {
 	}
 
 	if !auth.Allowed(perm) {
-		return authError{error: ErrFailedPermission, perm: perm, auth: auth}
+		return &authError{error: ErrFailedPermission, perm: perm, auth: auth}
 	}
 
 	return nil
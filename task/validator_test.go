@@ -0,0 +1,281 @@
+package task_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/task"
+)
+
+func TestValidator_ReadPermissions(t *testing.T) {
+	const org, otherOrg = platform.ID(1), platform.ID(2)
+
+	ts := &mock.TaskService{
+		FindTaskByIDFn: func(_ context.Context, id platform.ID) (*platform.Task, error) {
+			return &platform.Task{ID: id, Organization: otherOrg}, nil
+		},
+		FindTaskStatsFn: func(context.Context, platform.ID) (*platform.TaskStats, error) {
+			return &platform.TaskStats{}, nil
+		},
+		ExportTasksFn: func(context.Context, platform.ID) (*platform.TaskBundle, error) {
+			return &platform.TaskBundle{}, nil
+		},
+		WatchTasksFn: func(context.Context, platform.TaskFilter) (<-chan platform.TaskEvent, error) {
+			return make(chan platform.TaskEvent), nil
+		},
+	}
+	v := task.NewValidator(ts, mock.NewBucketService())
+
+	// The authorizer may only read the tasks of org.
+	auth := &platform.Authorization{ID: 3, UserID: 4, Status: platform.Active, Permissions: []platform.Permission{
+		{Action: platform.ReadAction, Resource: platform.TaskResource(org)},
+	}}
+	ctx := pctx.SetAuthorizer(context.Background(), auth)
+
+	if _, err := v.ExportTasks(ctx, org); err != nil {
+		t.Fatalf("expected export of readable org to succeed, got %v", err)
+	}
+	if _, err := v.ExportTasks(ctx, otherOrg); err == nil {
+		t.Fatal("expected export of another org's tasks to fail")
+	}
+
+	orgID, otherOrgID := org, otherOrg
+	if _, err := v.WatchTasks(ctx, platform.TaskFilter{Organization: &orgID}); err != nil {
+		t.Fatalf("expected watch of readable org to succeed, got %v", err)
+	}
+	if _, err := v.WatchTasks(ctx, platform.TaskFilter{Organization: &otherOrgID}); err == nil {
+		t.Fatal("expected watch of another org's tasks to fail")
+	}
+	if _, err := v.WatchTasks(ctx, platform.TaskFilter{}); err != task.ErrWatchRequiresOrg {
+		t.Fatalf("expected ErrWatchRequiresOrg, got %v", err)
+	}
+
+	// The task belongs to otherOrg.
+	if _, err := v.FindTaskStats(ctx, 5); err == nil {
+		t.Fatal("expected stats of another org's task to fail")
+	}
+	auth.Permissions = append(auth.Permissions, platform.Permission{Action: platform.ReadAction, Resource: platform.TaskResource(otherOrg)})
+	if _, err := v.FindTaskStats(ctx, 5); err != nil {
+		t.Fatalf("expected stats of readable task to succeed, got %v", err)
+	}
+}
+
+func TestValidator_TaskPermissions(t *testing.T) {
+	const org, taskID, otherTaskID = platform.ID(1), platform.ID(2), platform.ID(3)
+
+	ts := &mock.TaskService{
+		FindTaskByIDFn: func(_ context.Context, id platform.ID) (*platform.Task, error) {
+			return &platform.Task{ID: id, Organization: org}, nil
+		},
+		FindTasksFn: func(context.Context, platform.TaskFilter) ([]*platform.Task, int, error) {
+			return []*platform.Task{{ID: taskID, Organization: org}, {ID: otherTaskID, Organization: org}}, 2, nil
+		},
+		ForceRunFn: func(_ context.Context, id platform.ID, _ int64) (*platform.Run, error) {
+			return &platform.Run{TaskID: id}, nil
+		},
+		DeleteTaskFn: func(context.Context, platform.ID) error {
+			return nil
+		},
+	}
+	v := task.NewValidator(ts, mock.NewBucketService())
+
+	// The authorizer may only read and run a single task.
+	auth := &platform.Authorization{ID: 4, UserID: 5, Status: platform.Active, Permissions: []platform.Permission{
+		platform.TaskIDPermission(platform.ReadAction, taskID),
+		platform.TaskIDPermission(platform.RunAction, taskID),
+	}}
+	ctx := pctx.SetAuthorizer(context.Background(), auth)
+
+	if _, err := v.FindTaskByID(ctx, taskID); err != nil {
+		t.Fatalf("expected reading the task to succeed, got %v", err)
+	}
+	if _, err := v.FindTaskByID(ctx, otherTaskID); err == nil {
+		t.Fatal("expected reading another task to fail")
+	}
+	if _, err := v.ForceRun(ctx, taskID, 0); err != nil {
+		t.Fatalf("expected running the task to succeed, got %v", err)
+	}
+	if _, err := v.ForceRun(ctx, otherTaskID, 0); err == nil {
+		t.Fatal("expected running another task to fail")
+	}
+	err := v.DeleteTask(ctx, taskID)
+	if err == nil {
+		t.Fatal("expected deleting the task without permission to fail")
+	}
+	if code := platform.ErrorCode(err); code != platform.EForbidden {
+		t.Fatalf("expected error code %q, got %q", platform.EForbidden, code)
+	}
+
+	orgID := org
+	tasks, n, err := v.FindTasks(ctx, platform.TaskFilter{Organization: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(tasks) != 1 || tasks[0].ID != taskID {
+		t.Fatalf("expected only the readable task, got %d tasks: %v", n, tasks)
+	}
+
+	// A permission for the organization's tasks applies to each of them.
+	auth.Permissions = append(auth.Permissions, platform.TaskPermission(platform.DeleteAction, org))
+	if err := v.DeleteTask(ctx, otherTaskID); err != nil {
+		t.Fatalf("expected deleting a task of the organization to succeed, got %v", err)
+	}
+}
+
+func TestValidator_SessionOwnerMapping(t *testing.T) {
+	const org, taskID, userID = platform.ID(1), platform.ID(2), platform.ID(3)
+
+	ts := &mock.TaskService{
+		FindTaskByIDFn: func(_ context.Context, id platform.ID) (*platform.Task, error) {
+			return &platform.Task{ID: id, Organization: org}, nil
+		},
+		ForceRunFn: func(_ context.Context, id platform.ID, _ int64) (*platform.Run, error) {
+			return &platform.Run{TaskID: id}, nil
+		},
+		RetryRunFn: func(_ context.Context, id, _ platform.ID) (*platform.Run, error) {
+			return &platform.Run{TaskID: id}, nil
+		},
+		CancelRunFn: func(context.Context, platform.ID, platform.ID) error {
+			return nil
+		},
+	}
+	v := task.NewValidator(ts, mock.NewBucketService())
+
+	// Sessions take their permissions from the user's resource mappings.
+	m := &platform.UserResourceMapping{
+		ResourceID:   taskID,
+		ResourceType: platform.TaskResourceType,
+		UserID:       userID,
+		UserType:     platform.Owner,
+	}
+	s := &platform.Session{ID: 4, UserID: userID, ExpiresAt: time.Now().Add(time.Hour), Permissions: m.ToPermissions()}
+	ctx := pctx.SetAuthorizer(context.Background(), s)
+
+	if _, err := v.FindTaskByID(ctx, taskID); err != nil {
+		t.Fatalf("expected the owner to read the task, got %v", err)
+	}
+	if _, err := v.ForceRun(ctx, taskID, 0); err != nil {
+		t.Fatalf("expected the owner to force a run, got %v", err)
+	}
+	if _, err := v.RetryRun(ctx, taskID, 5); err != nil {
+		t.Fatalf("expected the owner to retry a run, got %v", err)
+	}
+	if err := v.CancelRun(ctx, taskID, 5); err != nil {
+		t.Fatalf("expected the owner to cancel a run, got %v", err)
+	}
+
+	// A member may read the task, but not run it.
+	m.UserType = platform.Member
+	s.Permissions = m.ToPermissions()
+	if _, err := v.FindTaskByID(ctx, taskID); err != nil {
+		t.Fatalf("expected a member to read the task, got %v", err)
+	}
+	if _, err := v.ForceRun(ctx, taskID, 0); err == nil {
+		t.Fatal("expected a member forcing a run to fail")
+	}
+}
+
+func TestValidator_SessionOrgMapping(t *testing.T) {
+	const org, taskID, userID = platform.ID(1), platform.ID(2), platform.ID(3)
+
+	ts := &mock.TaskService{
+		FindTaskByIDFn: func(_ context.Context, id platform.ID) (*platform.Task, error) {
+			return &platform.Task{ID: id, Organization: org}, nil
+		},
+		DeleteTaskFn: func(context.Context, platform.ID) error {
+			return nil
+		},
+	}
+	v := task.NewValidator(ts, mock.NewBucketService())
+
+	m := &platform.UserResourceMapping{
+		ResourceID:   org,
+		ResourceType: platform.OrgResourceType,
+		UserID:       userID,
+		UserType:     platform.Member,
+	}
+	s := &platform.Session{ID: 4, UserID: userID, ExpiresAt: time.Now().Add(time.Hour), Permissions: m.ToPermissions()}
+	ctx := pctx.SetAuthorizer(context.Background(), s)
+
+	// Members of the organization may read its tasks.
+	if _, err := v.FindTaskByID(ctx, taskID); err != nil {
+		t.Fatalf("expected an org member to read the task, got %v", err)
+	}
+	if err := v.DeleteTask(ctx, taskID); err == nil {
+		t.Fatal("expected an org member deleting the task to fail")
+	}
+
+	// Owners of the organization manage its tasks.
+	m.UserType = platform.Owner
+	s.Permissions = m.ToPermissions()
+	if err := v.DeleteTask(ctx, taskID); err != nil {
+		t.Fatalf("expected an org owner to delete the task, got %v", err)
+	}
+}
+
+func TestValidator_FindTasksPages(t *testing.T) {
+	const org = platform.ID(1)
+
+	// The store holds tasks 10 to 19, of which the authorizer may read only the even ones.
+	var stored []*platform.Task
+	auth := &platform.Authorization{ID: 2, UserID: 3, Status: platform.Active}
+	for id := platform.ID(10); id < 20; id++ {
+		stored = append(stored, &platform.Task{ID: id, Organization: org})
+		if id%2 == 0 {
+			auth.Permissions = append(auth.Permissions, platform.TaskIDPermission(platform.ReadAction, id))
+		}
+	}
+
+	ts := &mock.TaskService{
+		FindTasksFn: func(_ context.Context, f platform.TaskFilter) ([]*platform.Task, int, error) {
+			var page []*platform.Task
+			for _, t := range stored {
+				if f.After != nil && t.ID <= *f.After {
+					continue
+				}
+				if len(page) == f.Limit {
+					break
+				}
+				page = append(page, t)
+			}
+			return page, len(page), nil
+		},
+	}
+	v := task.NewValidator(ts, mock.NewBucketService())
+	ctx := pctx.SetAuthorizer(context.Background(), auth)
+
+	var got []platform.ID
+	filter := platform.TaskFilter{Limit: 2}
+	for {
+		tasks, n, err := v.FindTasks(ctx, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(tasks) {
+			t.Fatalf("expected count %d to match the %d tasks returned", n, len(tasks))
+		}
+		for _, tsk := range tasks {
+			got = append(got, tsk.ID)
+		}
+		// A page short of the limit is the last one.
+		if len(tasks) < filter.Limit {
+			break
+		}
+		after := tasks[len(tasks)-1].ID
+		filter.After = &after
+	}
+
+	want := []platform.ID{10, 12, 14, 16, 18}
+	if len(got) != len(want) {
+		t.Fatalf("expected tasks %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected tasks %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Package telemetry periodically reports anonymous usage counters, such as how many tasks are scheduled and how many runs
+// have completed, so that the platform's developers can see how it is used.
+//
+// Reporting is opt-in. A Reporter collects counters from the subsystems registered with it, and sends each report
+// to an HTTP endpoint, or appends it to a local file for installations without outbound network access, or both.
+// Reports hold counts alone: never names, IDs, scripts, or anything else that identifies a user or their data.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DisableEnv is the environment variable that, when set to any value, stops every Reporter from reporting,
+// whatever it is configured to do.
+const DisableEnv = "INFLUX_TELEMETRY_DISABLED"
+
+// DefaultInterval is how often a Reporter reports, unless set with WithInterval.
+const DefaultInterval = 24 * time.Hour
+
+// Source is implemented by the subsystems that report usage counters.
+type Source interface {
+	// TelemetryCounters returns the subsystem's current counters, keyed by name, such as "tasks".
+	// They must be anonymous: counts and totals, never names, IDs or other values taken from user data.
+	TelemetryCounters(ctx context.Context) (map[string]float64, error)
+}
+
+// SourceFunc is a function that is a Source.
+type SourceFunc func(ctx context.Context) (map[string]float64, error)
+
+// TelemetryCounters calls f.
+func (f SourceFunc) TelemetryCounters(ctx context.Context) (map[string]float64, error) {
+	return f(ctx)
+}
+
+// PrometheusSource returns a Source whose counters are the values of the named metrics of g,
+// keyed by metric name. The values of a metric with labels are summed, so that the labels,
+// which may identify tasks or organizations, are not reported.
+func PrometheusSource(g prometheus.Gatherer, names ...string) Source {
+	want := make(map[string]bool, len(names))
+	for _, n := range names {
+		want[n] = true
+	}
+
+	return SourceFunc(func(ctx context.Context) (map[string]float64, error) {
+		mfs, err := g.Gather()
+		if err != nil {
+			return nil, err
+		}
+
+		counters := make(map[string]float64, len(names))
+		for _, mf := range mfs {
+			if !want[mf.GetName()] {
+				continue
+			}
+			var sum float64
+			for _, m := range mf.GetMetric() {
+				switch {
+				case m.Counter != nil:
+					sum += m.GetCounter().GetValue()
+				case m.Gauge != nil:
+					sum += m.GetGauge().GetValue()
+				case m.Untyped != nil:
+					sum += m.GetUntyped().GetValue()
+				}
+			}
+			counters[mf.GetName()] = sum
+		}
+		return counters, nil
+	})
+}
+
+// Report is a single report of usage counters.
+type Report struct {
+	// InstanceID identifies the process that made the report, so that its reports can be told apart from other processes'.
+	// It is random, and not derived from anything about the host or its users.
+	InstanceID string `json:"instanceID"`
+
+	Version string    `json:"version"`
+	OS      string    `json:"os"`
+	Arch    string    `json:"arch"`
+	Time    time.Time `json:"time"`
+
+	// Uptime is how long the process had been running when it made the report, in seconds.
+	// Along with the counters' previous values, it gives the rates they grow at, such as the rate of runs.
+	Uptime float64 `json:"uptimeSeconds"`
+
+	// Counters are the counters of every registered source, keyed by the source's name and the counter's name, as "tasks.runs".
+	Counters map[string]float64 `json:"counters"`
+}
+
+// Option configures a Reporter.
+type Option func(r *Reporter)
+
+// WithEndpoint makes a Reporter send each report to url, in an HTTP POST with a JSON body.
+// An endpoint that responds with status 410 Gone stops the Reporter from reporting, so that reporting can be switched off remotely.
+func WithEndpoint(url string) Option {
+	return func(r *Reporter) {
+		r.endpoint = url
+	}
+}
+
+// WithExportPath makes a Reporter append each report to the file at path, as a line of JSON,
+// so that air-gapped installations can review the reports and send them on by other means.
+func WithExportPath(path string) Option {
+	return func(r *Reporter) {
+		r.exportPath = path
+	}
+}
+
+// WithInterval sets how often a Reporter reports. It defaults to DefaultInterval.
+func WithInterval(d time.Duration) Option {
+	return func(r *Reporter) {
+		if d > 0 {
+			r.interval = d
+		}
+	}
+}
+
+// WithHTTPClient sets the client a Reporter sends reports with. It defaults to a client with a 30 second timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(r *Reporter) {
+		r.client = c
+	}
+}
+
+// Reporter periodically collects the counters of the registered sources, and reports them.
+type Reporter struct {
+	instanceID string
+	version    string
+	started    time.Time
+	interval   time.Duration
+	endpoint   string
+	exportPath string
+	client     *http.Client
+
+	mu      sync.Mutex
+	sources map[string]Source
+	stopped bool // Set when the endpoint asks for reports to stop.
+
+	logger  *zap.Logger
+	reports *prometheus.CounterVec
+}
+
+// NewReporter returns a Reporter for a process of the given version.
+// Without WithEndpoint or WithExportPath, it collects reports without sending them anywhere.
+func NewReporter(version string, opts ...Option) *Reporter {
+	r := &Reporter{
+		instanceID: newInstanceID(),
+		version:    version,
+		started:    time.Now(),
+		interval:   DefaultInterval,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		sources:    make(map[string]Source),
+		logger:     zap.NewNop(),
+		reports: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telemetry",
+			Name:      "reports_total",
+			Help:      "Number of usage reports made, split out by destination and result.",
+		}, []string{"destination", "result"}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// WithLogger sets the logger for the Reporter.
+// The logger reports each report made, and each that fails.
+func (r *Reporter) WithLogger(l *zap.Logger) {
+	r.logger = l.With(zap.String("service", "telemetry"))
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (r *Reporter) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{r.reports}
+}
+
+// Register adds the counters of s to each report, under name, such as "tasks".
+// Registering a second source under the same name replaces the first.
+func (r *Reporter) Register(name string, s Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[name] = s
+}
+
+// Collect returns a report of the current counters of every registered source.
+// A source that fails to report is logged and left out of the report.
+func (r *Reporter) Collect(ctx context.Context) Report {
+	// Copy the sources, so that they are not called with the lock held.
+	r.mu.Lock()
+	names := make([]string, 0, len(r.sources))
+	sources := make(map[string]Source, len(r.sources))
+	for name, s := range r.sources {
+		names = append(names, name)
+		sources[name] = s
+	}
+	r.mu.Unlock()
+	sort.Strings(names)
+
+	now := time.Now()
+	rep := Report{
+		InstanceID: r.instanceID,
+		Version:    r.version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Time:       now.UTC(),
+		Uptime:     now.Sub(r.started).Seconds(),
+		Counters:   make(map[string]float64),
+	}
+	for _, name := range names {
+		counters, err := sources[name].TelemetryCounters(ctx)
+		if err != nil {
+			r.logger.Info("Failed to collect telemetry counters", zap.String("source", name), zap.Error(err))
+			continue
+		}
+		for k, v := range counters {
+			rep.Counters[name+"."+k] = v
+		}
+	}
+	return rep
+}
+
+// Run reports once per interval until ctx is done, starting one interval after it is called.
+// It returns at once if DisableEnv is set.
+func (r *Reporter) Run(ctx context.Context) {
+	if Disabled() {
+		r.logger.Info("Telemetry disabled by environment", zap.String("variable", DisableEnv))
+		return
+	}
+
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if r.isStopped() {
+				return
+			}
+			r.Report(ctx)
+		}
+	}
+}
+
+// Report collects a report and sends it to the endpoint and the export file, if they are set.
+// It does nothing if DisableEnv is set, or if the endpoint has asked for reports to stop.
+func (r *Reporter) Report(ctx context.Context) {
+	if Disabled() || r.isStopped() {
+		return
+	}
+
+	rep := r.Collect(ctx)
+	b, err := json.Marshal(rep)
+	if err != nil {
+		r.logger.Info("Failed to encode telemetry report", zap.Error(err))
+		return
+	}
+
+	if r.exportPath != "" {
+		r.count("export", r.export(b))
+	}
+	if r.endpoint != "" {
+		r.count("endpoint", r.send(ctx, b))
+	}
+}
+
+// Disabled reports whether telemetry is disabled by DisableEnv.
+func Disabled() bool {
+	_, ok := os.LookupEnv(DisableEnv)
+	return ok
+}
+
+func (r *Reporter) count(destination string, err error) {
+	if err != nil {
+		r.reports.WithLabelValues(destination, "error").Inc()
+		r.logger.Info("Failed to report telemetry", zap.String("destination", destination), zap.Error(err))
+		return
+	}
+	r.reports.WithLabelValues(destination, "success").Inc()
+}
+
+// export appends the encoded report b to the export file, as a line.
+func (r *Reporter) export(b []byte) error {
+	f, err := os.OpenFile(r.exportPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// send posts the encoded report b to the endpoint.
+func (r *Reporter) send(ctx context.Context, b []byte) error {
+	req, err := http.NewRequest("POST", r.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusGone {
+		r.mu.Lock()
+		r.stopped = true
+		r.mu.Unlock()
+		r.logger.Info("Telemetry endpoint asked for reports to stop", zap.String("endpoint", r.endpoint))
+		return nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("telemetry endpoint responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *Reporter) isStopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stopped
+}
+
+// newInstanceID returns a random instance ID.
+func newInstanceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package telemetry_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/platform/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestReporter_Report(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	runs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "runs_complete"}, []string{"task_id"})
+	reg.MustRegister(runs)
+	runs.WithLabelValues("0000000000000001").Add(2)
+	runs.WithLabelValues("0000000000000002").Add(3)
+
+	var got []telemetry.Report
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rep telemetry.Report
+		if err := json.NewDecoder(r.Body).Decode(&rep); err != nil {
+			t.Error(err)
+		}
+		got = append(got, rep)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "telemetry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	exportPath := filepath.Join(dir, "reports.jsonl")
+
+	r := telemetry.NewReporter("v1", telemetry.WithEndpoint(srv.URL), telemetry.WithExportPath(exportPath))
+	r.Register("tasks", telemetry.PrometheusSource(reg, "runs_complete"))
+	r.Register("config", telemetry.SourceFunc(func(context.Context) (map[string]float64, error) {
+		return map[string]float64{"rate_limited": 1}, nil
+	}))
+
+	r.Report(context.Background())
+	if len(got) != 1 {
+		t.Fatalf("expected 1 report sent, got %d", len(got))
+	}
+	rep := got[0]
+	if rep.Version != "v1" || rep.InstanceID == "" {
+		t.Fatalf("unexpected report %+v", rep)
+	}
+	// The labels of the metric, which identify tasks, are summed away.
+	if len(rep.Counters) != 2 || rep.Counters["tasks.runs_complete"] != 5 || rep.Counters["config.rate_limited"] != 1 {
+		t.Fatalf("unexpected counters %v", rep.Counters)
+	}
+
+	f, err := os.Open(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	for s := bufio.NewScanner(f); s.Scan(); lines++ {
+		var exported telemetry.Report
+		if err := json.Unmarshal(s.Bytes(), &exported); err != nil {
+			t.Fatal(err)
+		}
+		if exported.InstanceID != rep.InstanceID {
+			t.Fatalf("expected exported report from instance %q, got %q", rep.InstanceID, exported.InstanceID)
+		}
+	}
+	if lines != 1 {
+		t.Fatalf("expected 1 exported report, got %d", lines)
+	}
+
+	// Once the endpoint responds with 410 Gone, there are no more reports.
+	status = http.StatusGone
+	r.Report(context.Background())
+	r.Report(context.Background())
+	if len(got) != 2 {
+		t.Fatalf("expected reports to stop after 410 Gone, got %d reports", len(got))
+	}
+}
+
+func TestReporter_Disabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no report while telemetry is disabled")
+	}))
+	defer srv.Close()
+
+	os.Setenv(telemetry.DisableEnv, "1")
+	defer os.Unsetenv(telemetry.DisableEnv)
+
+	r := telemetry.NewReporter("v1", telemetry.WithEndpoint(srv.URL))
+	r.Report(context.Background())
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
@@ -68,6 +69,10 @@ func AuthorizationService(
 			name: "DeleteAuthorization",
 			fn:   DeleteAuthorization,
 		},
+		{
+			name: "RotateAuthorization",
+			fn:   RotateAuthorization,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -477,6 +482,79 @@ func FindAuthorizationByToken(
 				},
 			},
 		},
+		{
+			name: "find authorization by previous token within its grace",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:                     MustIDBase16(authOneID),
+						UserID:                 MustIDBase16(userOneID),
+						Token:                  "rand1",
+						PreviousToken:          "rand0",
+						PreviousTokenExpiresAt: timePtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+						Permissions: []platform.Permission{
+							platform.CreateUserPermission,
+						},
+					},
+				},
+			},
+			args: args{
+				token: "rand0",
+			},
+			wants: wants{
+				authorization: &platform.Authorization{
+					ID:                     MustIDBase16(authOneID),
+					UserID:                 MustIDBase16(userOneID),
+					Status:                 platform.Active,
+					User:                   "cooluser",
+					Token:                  "rand1",
+					PreviousToken:          "rand0",
+					PreviousTokenExpiresAt: timePtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Permissions: []platform.Permission{
+						platform.CreateUserPermission,
+					},
+				},
+			},
+		},
+		{
+			name: "previous token past its grace is not found",
+			fields: AuthorizationFields{
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:                     MustIDBase16(authOneID),
+						UserID:                 MustIDBase16(userOneID),
+						Token:                  "rand1",
+						PreviousToken:          "rand0",
+						PreviousTokenExpiresAt: timePtr(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+						Permissions: []platform.Permission{
+							platform.CreateUserPermission,
+						},
+					},
+				},
+			},
+			args: args{
+				token: "rand0",
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Msg:  "authorization not found",
+					Op:   platform.OpFindAuthorizationByToken,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -900,3 +978,141 @@ func DeleteAuthorization(
 		})
 	}
 }
+
+// RotateAuthorization testing
+func RotateAuthorization(
+	init func(AuthorizationFields, *testing.T) (platform.AuthorizationService, string, func()),
+	t *testing.T,
+) {
+	type args struct {
+		ID    platform.ID
+		grace time.Duration
+	}
+	type wants struct {
+		err           error
+		token         string
+		previousToken string
+		accepted      []string
+		rejected      []string
+	}
+
+	fields := func() AuthorizationFields {
+		return AuthorizationFields{
+			TokenGenerator: &mock.TokenGenerator{
+				TokenFn: func() (string, error) {
+					return "rand2", nil
+				},
+			},
+			Users: []*platform.User{
+				{
+					Name: "cooluser",
+					ID:   MustIDBase16(userOneID),
+				},
+			},
+			Authorizations: []*platform.Authorization{
+				{
+					ID:                     MustIDBase16(authOneID),
+					UserID:                 MustIDBase16(userOneID),
+					Token:                  "rand1",
+					PreviousToken:          "rand0",
+					PreviousTokenExpiresAt: timePtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Permissions: []platform.Permission{
+						platform.CreateUserPermission,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		fields AuthorizationFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "rotate keeps the replaced token for the grace",
+			fields: fields(),
+			args: args{
+				ID:    MustIDBase16(authOneID),
+				grace: time.Hour,
+			},
+			wants: wants{
+				token:         "rand2",
+				previousToken: "rand1",
+				accepted:      []string{"rand2", "rand1"},
+				rejected:      []string{"rand0"},
+			},
+		},
+		{
+			name:   "rotate without grace stops accepting the replaced token",
+			fields: fields(),
+			args: args{
+				ID: MustIDBase16(authOneID),
+			},
+			wants: wants{
+				token:    "rand2",
+				accepted: []string{"rand2"},
+				rejected: []string{"rand1", "rand0"},
+			},
+		},
+		{
+			name:   "rotate missing authorization",
+			fields: fields(),
+			args: args{
+				ID:    MustIDBase16(authTwoID),
+				grace: time.Hour,
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Msg:  "authorization not found",
+					Op:   platform.OpRotateAuthorization,
+				},
+				accepted: []string{"rand1", "rand0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, opPrefix, done := init(tt.fields, t)
+			defer done()
+			ctx := context.TODO()
+
+			a, err := s.RotateAuthorization(ctx, tt.args.ID, tt.args.grace)
+			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
+
+			if err == nil {
+				if a.ID != tt.args.ID {
+					t.Errorf("rotated authorization ID is %s, want %s", a.ID, tt.args.ID)
+				}
+				if a.Token != tt.wants.token {
+					t.Errorf("token is %q, want %q", a.Token, tt.wants.token)
+				}
+				if a.PreviousToken != tt.wants.previousToken {
+					t.Errorf("previous token is %q, want %q", a.PreviousToken, tt.wants.previousToken)
+				}
+				if (a.PreviousTokenExpiresAt != nil) != (tt.wants.previousToken != "") {
+					t.Errorf("previous token expiry is %v, want it set only with a previous token", a.PreviousTokenExpiresAt)
+				}
+			}
+
+			for _, token := range tt.wants.accepted {
+				found, err := s.FindAuthorizationByToken(ctx, token)
+				if err != nil {
+					t.Errorf("failed to find authorization by token %q: %v", token, err)
+					continue
+				}
+				if found.ID != MustIDBase16(authOneID) {
+					t.Errorf("token %q found authorization %s, want %s", token, found.ID, authOneID)
+				}
+			}
+			for _, token := range tt.wants.rejected {
+				if _, err := s.FindAuthorizationByToken(ctx, token); platform.ErrorCode(err) != platform.ENotFound {
+					t.Errorf("expected token %q not to be found, got error %v", token, err)
+				}
+			}
+		})
+	}
+}
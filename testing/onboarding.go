@@ -170,7 +170,7 @@ func Generate(
 						User:        "admin",
 						UserID:      MustIDBase16(oneID),
 						Description: "Deftok",
-						Permissions: []platform.Permission{
+						Permissions: append([]platform.Permission{
 							platform.CreateUserPermission,
 							platform.DeleteUserPermission,
 							{
@@ -178,7 +178,10 @@ func Generate(
 								Action:   platform.WriteAction,
 							},
 							platform.WriteBucketPermission(MustIDBase16(threeID)),
-						},
+						}, append(platform.TaskPermissions(MustIDBase16(twoID)),
+							platform.ReadSecretPermission(MustIDBase16(twoID)),
+							platform.WriteSecretPermission(MustIDBase16(twoID)),
+						)...),
 					},
 				},
 			},
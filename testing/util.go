@@ -2,6 +2,7 @@ package testing
 
 import (
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 )
@@ -40,3 +41,7 @@ func MustIDBase16(s string) platform.ID {
 	}
 	return *id
 }
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -70,8 +70,8 @@ type UserResourceMappingFilter struct {
 	UserType     UserType
 }
 
-var ownerActions = []action{WriteAction, CreateAction, DeleteAction}
-var memberActions = []action{ReadAction}
+var ownerActions = []Action{WriteAction, CreateAction, DeleteAction, RunAction}
+var memberActions = []Action{ReadAction}
 
 // ToPermission converts a user resource mapping into a set of permissions.
 func (m *UserResourceMapping) ToPermissions() []Permission {
@@ -97,5 +97,14 @@ func (m *UserResourceMapping) ToPermissions() []Permission {
 		ps = append(ps, p)
 	}
 
+	// The owners of an organization manage its tasks, and its members may read them.
+	if m.ResourceType == OrgResourceType {
+		if m.UserType == Owner {
+			ps = append(ps, TaskPermissions(m.ResourceID)...)
+		} else {
+			ps = append(ps, TaskPermission(ReadAction, m.ResourceID))
+		}
+	}
+
 	return ps
 }
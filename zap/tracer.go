@@ -269,6 +269,11 @@ func newSpanContext() SpanContext {
 	}
 }
 
+// TraceID returns the ID of the trace the span belongs to, as a string.
+func (c SpanContext) TraceID() string {
+	return c.traceID.String()
+}
+
 func (c SpanContext) ForeachBaggageItem(handler func(k, v string) bool) {
 	for k, v := range c.baggage {
 		if !handler(k, v) {